@@ -0,0 +1,52 @@
+//go:build js && wasm
+
+// Command sociopath-wasm exposes sociopath's parsers to JavaScript, so a browser
+// extension can extract profiles from pages the user is already viewing.
+//
+// Build it and copy the Go runtime's loader next to it:
+//
+//	GOOS=js GOARCH=wasm go build -o sociopath.wasm ./cmd/sociopath-wasm
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// Once loaded, it defines a global function returning a JSON string:
+//
+//	sociopathParseHTML(platform, html, url) // {"profile": {...}} or {"error": "..."}
+//
+// platform may be empty to detect it from url.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+func main() {
+	js.Global().Set("sociopathParseHTML", js.FuncOf(parseHTML))
+	select {} // keep the exported function alive
+}
+
+type result struct {
+	Profile *sociopath.Profile `json:"profile,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+func parseHTML(_ js.Value, args []js.Value) any {
+	var r result
+	if len(args) != 3 {
+		r.Error = "usage: sociopathParseHTML(platform, html, url)"
+	} else {
+		p, err := sociopath.ParseHTML(context.Background(), args[0].String(), []byte(args[1].String()), args[2].String())
+		if err != nil {
+			r.Error = err.Error()
+		}
+		r.Profile = p
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return `{"error": "encoding profile failed"}`
+	}
+	return string(data)
+}
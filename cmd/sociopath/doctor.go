@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// doctor implements "sociopath doctor": it fetches canary profiles live and reports
+// which extractors return complete data, telling broken parsers apart from dead
+// cookies. It returns the exit code.
+func doctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	canariesPath := fs.String("canaries", "", "JSON file of canaries to check instead of the built-in ones: [{\"url\": ..., \"expect\": [\"name\"], \"auth\": false}]")
+	platform := fs.String("platform", "", "only check canaries of this platform")
+	noBrowser := fs.Bool("no-browser", false, "disable reading cookies from browser stores")
+	jsonOut := fs.Bool("json", false, "output JSON instead of a table")
+	debug := fs.Bool("debug", false, "enable debug logging")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: sociopath doctor [options]")
+		fmt.Fprintln(os.Stderr, "\nFetches known canary profiles without the cache and reports which")
+		fmt.Fprintln(os.Stderr, "platforms extract complete data. A status of \"auth\" means your cookies")
+		fmt.Fprintln(os.Stderr, "are missing or dead; \"incomplete\" means the platform's markup changed.")
+		fmt.Fprintln(os.Stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	logLevel := slog.LevelError
+	if *debug {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+
+	canaries := sociopath.DefaultCanaries
+	if *canariesPath != "" {
+		var err error
+		if canaries, err = sociopath.LoadCanaries(*canariesPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -canaries: %v\n", err)
+			return 1
+		}
+	}
+	if *platform != "" {
+		var kept []sociopath.Canary
+		for _, c := range canaries {
+			if strings.EqualFold(sociopath.PlatformForURL(c.URL), *platform) {
+				kept = append(kept, c)
+			}
+		}
+		canaries = kept
+	}
+	if len(canaries) == 0 {
+		fmt.Fprintln(os.Stderr, "No canaries to check")
+		return 1
+	}
+
+	// No cache: a cached page would hide markup changes
+	opts := []sociopath.Option{sociopath.WithLogger(logger)}
+	if !*noBrowser {
+		opts = append(opts, sociopath.WithBrowserCookies())
+	}
+	results := sociopath.Diagnose(context.Background(), canaries, opts...)
+
+	healthy := true
+	for _, h := range results {
+		healthy = healthy && h.Status == sociopath.HealthOK
+	}
+	if *jsonOut {
+		if err := outputJSON(results); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			return 1
+		}
+	} else if err := writeHealth(results); err != nil {
+		fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+		return 1
+	}
+	if !healthy {
+		return 1
+	}
+	return 0
+}
+
+// writeHealth prints results as a table.
+func writeHealth(results []sociopath.Health) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PLATFORM\tSTATUS\tAUTH\tURL\tDETAIL") //nolint:errcheck // checked by Flush
+	for _, h := range results {
+		detail := h.Error
+		if len(h.Missing) > 0 {
+			detail = "missing " + strings.Join(h.Missing, ", ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\n", h.Platform, h.Status, h.Authenticated, h.URL, detail) //nolint:errcheck // checked by Flush
+	}
+	return w.Flush()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/ingest"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// ingestArchives implements "sociopath ingest": it extracts profiles from WARC, MHTML,
+// and HAR archives without any network access. It returns the exit code.
+func ingestArchives(args []string) int {
+	fs := flag.NewFlagSet("ingest", flag.ExitOnError)
+	debug := fs.Bool("debug", false, "enable debug logging")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: sociopath ingest [options] <archive>...")
+		fmt.Fprintln(os.Stderr, "\nReads WARC (.warc, .warc.gz), MHTML (.mhtml, .mht), and HAR (.har)")
+		fmt.Fprintln(os.Stderr, "archives and prints one JSON line per profile found in them. Nothing is")
+		fmt.Fprintln(os.Stderr, "fetched. A HAR exported from the network panel while viewing a LinkedIn")
+		fmt.Fprintln(os.Stderr, "profile logged in yields the full profile.")
+		fmt.Fprintln(os.Stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	logLevel := slog.LevelWarn
+	if *debug {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+
+	ctx := context.Background()
+	enc := json.NewEncoder(os.Stdout)
+	code := 0
+	for _, path := range fs.Args() {
+		profiles, err := ingestFile(ctx, path, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", path, err)
+			code = 1
+		}
+		for _, p := range profiles {
+			if err := enc.Encode(p); err != nil {
+				fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+				return 1
+			}
+		}
+	}
+	return code
+}
+
+func ingestFile(ctx context.Context, path string, logger *slog.Logger) ([]*profile.Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck // read-only
+
+	records, err := ingest.Read(f)
+	if err != nil && len(records) == 0 {
+		return nil, err
+	}
+	if err != nil {
+		// A truncated archive still has usable records before the damage
+		logger.Warn("archive is damaged, using the records before the damage", "path", path, "records", len(records), "error", err)
+	}
+	logger.Debug("read archive", "path", path, "records", len(records))
+	return ingest.Profiles(ctx, records, sociopath.WithLogger(logger))
+}
@@ -5,34 +5,148 @@
 //	sociopath https://mastodon.social/@johndoe
 //	sociopath https://linkedin.com/in/johndoe  # requires LINKEDIN_* env vars
 //	sociopath https://twitter.com/johndoe      # requires TWITTER_* env vars
+//	sociopath replay -failed audit.jsonl       # re-run failed fetches from an -audit log
+//	sociopath doctor                           # check which platforms still extract fully
+//	sociopath ingest saved.mhtml crawl.warc.gz # extract profiles from archives, offline
+//	sociopath native-host -manifest            # collect profiles from the browser extension
+//	sociopath serve -addr :8080                # serve GET /v1/profile?url=... over HTTP
+//
+// The first SIGINT or SIGTERM lets fetches in flight finish and reports what was
+// fetched so far; -run crawls can be continued with -resume. A second abandons them.
 package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/codeGROOVE-dev/sociopath/pkg/analysis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/audit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/blob"
+	"github.com/codeGROOVE-dev/sociopath/pkg/breach"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/export"
+	"github.com/codeGROOVE-dev/sociopath/pkg/generic"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkgraph"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/searchengine"
 	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+	"github.com/codeGROOVE-dev/sociopath/pkg/visited"
 )
 
 func main() {
+	if isNativeHostLaunch(os.Args[1:]) {
+		os.Exit(nativeHost(os.Args[1:]))
+	}
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "replay":
+			os.Exit(replay(os.Args[2:]))
+		case "doctor":
+			os.Exit(doctor(os.Args[2:]))
+		case "ingest":
+			os.Exit(ingestArchives(os.Args[2:]))
+		case "native-host":
+			os.Exit(nativeHost(os.Args[2:]))
+		case "serve":
+			os.Exit(serve(os.Args[2:]))
+		}
+	}
+
 	debug := flag.Bool("debug", false, "enable debug logging")
 	verbose := flag.Bool("v", false, "verbose logging (same as -debug)")
 	noBrowser := flag.Bool("no-browser", false, "disable reading cookies from browser stores (enabled by default)")
 	noCache := flag.Bool("no-cache", false, "disable HTTP caching (enabled by default with 75-day TTL)")
+	offline := flag.Bool("offline", false, "serve every fetch from the HTTP cache only; uncached URLs fail instead of being fetched")
+	staleAfter := flag.Duration("stale-after", 0, "serve cached responses older than this at once and refresh them in the background (e.g. 24h)")
+	userAgent := flag.String("user-agent", "", "User-Agent identifying requests (default names sociopath, its version, and project URL)")
+	contact := flag.String("contact", "", "email address (sent as From) or URL (sent as X-Contact) where site operators can reach you")
+	noBrowserUA := flag.Bool("no-browser-emulation", false, "identify requests to platforms that block unknown clients too, instead of sending a browser User-Agent")
+	var headers http.Header
+	flag.Func("header", "add a header to every request, as 'Name: value' (repeatable), e.g. for proxy credentials", func(v string) error {
+		name, value, ok := strings.Cut(v, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return errors.New("want 'Name: value'")
+		}
+		if headers == nil {
+			headers = make(http.Header)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		return nil
+	})
+	ipv4 := flag.Bool("ipv4", false, "connect over IPv4 only")
+	ipv6 := flag.Bool("ipv6", false, "connect over IPv6 only")
+	resolverSpec := flag.String("resolver", "", "DNS server to look hosts up with: a DNS-over-HTTPS URL (https://...) or host[:port]")
+	bind := flag.String("bind", "", "local IP address or network interface to send requests from")
+	caFile := flag.String("ca-cert", "", "PEM file of root certificates to trust besides the system's, e.g. a TLS-intercepting proxy's")
+	clientCert := flag.String("client-cert", "", "PEM client certificate to present to servers that require one (with -client-key)")
+	clientKey := flag.String("client-key", "", "PEM private key of -client-cert")
 	cacheTTL := flag.Duration("cache-ttl", 75*24*time.Hour, "cache time-to-live (default: 75 days, use 24h for testing)")
 	recursive := flag.Bool("r", false, "recursively fetch social media profiles from discovered links")
 	guessMode := flag.Bool("guess", false, "guess related profiles based on discovered usernames (implies -r)")
+	depthName := flag.String("depth", "standard", "extraction depth: minimal (primary request only), standard, or deep (more posts, feeds)")
+	probe := flag.Bool("probe", false, "with -guess, check username existence endpoints before fetching candidates")
+	graphPath := flag.String("graph", "", "with -r or -guess, write the discovered link graph to this file (.dot, .graphml, .json, or .cypher for Neo4j)")
+	visitedPath := flag.String("visited", "", "with -r or -guess, skip URLs recorded in this file by earlier runs and record new ones")
+	runID := flag.String("run", "", "with -r, save crawl state under this run ID so an interrupted crawl can be resumed")
+	resumeID := flag.String("resume", "", "resume the interrupted -run crawl with this ID (no URL needed)")
+	blobDir := flag.String("blobs", "", "with -r, -guess, or -run, keep page text and post bodies of 4 KiB or more as files in this directory, leaving sha256: references in their place")
+	storeDir := flag.String("store", "", "directory for -run and -resume crawl state (default: user cache dir), or a redis:// URL to share runs between processes, which join a run with -resume")
+	politenessPath := flag.String("politeness", "", "JSON file with per-domain politeness policies (delays, concurrency, hours, daily limits)")
+	featuresPath := flag.String("features", "", "JSON file disabling platforms or capabilities (auth, email, posts); "+sociopath.EnvDisabledPlatforms+" and "+sociopath.EnvDisabledCapabilities+" add to it")
+	quotaSpec := flag.String("quota", "", "daily fetch quotas per platform, e.g. linkedin=200,twitter=500")
+	gists := flag.Bool("gists", false, "scan personal GitHub gists (resume, about, dotfiles) for emails and links")
+	botScore := flag.Bool("bot-score", false, "add bot_score and bot_signals fields estimating how likely each account is a bot")
+	emailEmployer := flag.Bool("email-employer", false, "with -r or -guess, infer employers from company email domains and cross-check employer fields")
+	breachRange := flag.String("breach-range", "", "opt in to flagging emails found in known breaches via this k-anonymity range URL (hash prefix appended); only a flag and count are stored")
+	tags := flag.Bool("tags", false, "add topic tags (e.g. kubernetes, photography) from each profile's bio and posts")
+	searchName := flag.String("search", "", "with -guess, search the web by name: bing (BING_SEARCH_KEY), serpapi (SERPAPI_KEY), or a SearxNG URL")
+	team := flag.Bool("team", false, "treat the argument as a company domain and extract the people on its team pages")
+	orgMode := flag.Bool("org", false, "treat the argument as an organization (GitHub org URL, LinkedIn company URL, or website) and fetch its members")
+	render := flag.Bool("render", false, "render JavaScript-only personal sites with a local headless Chrome or Chromium")
+	esURL := flag.String("es", "", "also index the profiles into this Elasticsearch or OpenSearch index, e.g. http://localhost:9200/sociopath (API key from ES_API_KEY)")
+	auditPath := flag.String("audit", "", "append the outcome of every fetch to this JSON lines file, for use with 'sociopath replay'")
+	reach := flag.Bool("reach", false, "with -r, -guess, -run, -team, or -org, output a follower and account-age summary instead of the profiles")
+	timeline := flag.Bool("timeline", false, "with -r, -guess, -run, -team, or -org, output an employment timeline built from experience, employer fields, and bios instead of the profiles")
+	footprint := flag.Bool("footprint", false, "with -r, -guess, -run, -team, or -org, output an open-source summary (repositories, stars, languages, organizations, package registry accounts) instead of the profiles")
 	flag.Parse()
 
-	if flag.NArg() < 1 {
+	var summarize func([]*sociopath.Profile) any
+	switch {
+	case *reach && *timeline, *reach && *footprint, *timeline && *footprint:
+		fmt.Fprintln(os.Stderr, "Error: only one of -reach, -timeline, and -footprint can be used")
+		os.Exit(1)
+	case *reach:
+		summarize = func(p []*sociopath.Profile) any { return sociopath.SummarizeReach(p) }
+	case *timeline:
+		summarize = func(p []*sociopath.Profile) any { return sociopath.BuildTimeline(p) }
+	case *footprint:
+		summarize = func(p []*sociopath.Profile) any { return sociopath.SummarizeFootprint(p) }
+	}
+
+	if flag.NArg() < 1 && *resumeID == "" {
 		fmt.Fprintln(os.Stderr, "Usage: sociopath [options] <url>")
+		fmt.Fprintln(os.Stderr, "       sociopath replay [options] <audit log>")
+		fmt.Fprintln(os.Stderr, "       sociopath doctor [options]")
+		fmt.Fprintln(os.Stderr, "       sociopath ingest [options] <archive>...")
+		fmt.Fprintln(os.Stderr, "       sociopath native-host [options]")
+		fmt.Fprintln(os.Stderr, "       sociopath serve [options]")
 		fmt.Fprintln(os.Stderr, "\nOptions:")
 		flag.PrintDefaults()
 		fmt.Fprintln(os.Stderr, "\nSupported platforms:")
@@ -52,14 +166,56 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Interrupted runs return from main so deferred flushes run, then exit with this
+	exitCode := 0
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
 	input := flag.Arg(0)
 
+	depth, err := sociopath.ParseDepth(*depthName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Setup logger
 	logLevel := slog.LevelInfo
 	if *debug || *verbose {
 		logLevel = slog.LevelDebug
 	}
-	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+	// Crawls show their progress in a status line below the log on a terminal
+	crawling := *recursive || *guessMode || *runID != "" || *resumeID != ""
+	var logOut io.Writer = os.Stderr
+	var status *statusLine
+	if crawling && isTerminal(os.Stderr) {
+		status = &statusLine{w: os.Stderr}
+		logOut = status
+	}
+	logger := slog.New(slog.NewTextHandler(logOut, &slog.HandlerOptions{Level: logLevel}))
+
+	if *politenessPath != "" {
+		politeness, err := cache.LoadPoliteness(*politenessPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		cache.SetPoliteness(politeness)
+	}
+
+	if *offline && *noCache {
+		fmt.Fprintln(os.Stderr, "Error: -offline needs the cache; remove -no-cache")
+		os.Exit(1)
+	}
+
+	defer func() {
+		stats := cache.ConnectionStats()
+		logger.Info("connection stats", "requests", stats.Requests, "reused", stats.Reused, "http2", stats.HTTP2,
+			"reuse_rate", fmt.Sprintf("%.1f%%", stats.ReuseRate()))
+	}()
 
 	// Setup cache
 	var httpCache *cache.BDCache
@@ -89,10 +245,231 @@ func main() {
 	if httpCache != nil {
 		opts = append(opts, sociopath.WithHTTPCache(httpCache))
 	}
+	if *offline {
+		opts = append(opts, sociopath.WithOfflineMode(true))
+	}
+	if *staleAfter > 0 {
+		opts = append(opts, sociopath.WithStaleWhileRevalidate(*staleAfter))
+	}
+	if *userAgent != "" {
+		opts = append(opts, sociopath.WithUserAgent(*userAgent))
+	}
+	if *contact != "" {
+		opts = append(opts, sociopath.WithContact(*contact))
+	}
+	if *noBrowserUA {
+		opts = append(opts, sociopath.WithoutBrowserEmulation())
+	}
+	if headers != nil {
+		opts = append(opts, sociopath.WithRequestHook(func(req *http.Request) {
+			for name, values := range headers {
+				req.Header[name] = values
+			}
+		}))
+	}
+	if depth != sociopath.DepthStandard {
+		opts = append(opts, sociopath.WithDepth(depth))
+	}
+	if *probe {
+		opts = append(opts, sociopath.WithUsernameProbes())
+	}
+	if *gists {
+		opts = append(opts, sociopath.WithGitHubGists())
+	}
+	if *botScore {
+		opts = append(opts, sociopath.WithBotScores())
+	}
+	if *emailEmployer {
+		opts = append(opts, sociopath.WithEmployerInference(nil))
+	}
+	if *tags {
+		opts = append(opts, sociopath.WithTagger(analysis.NewKeywordTagger(nil)))
+	}
+	if *breachRange != "" {
+		opts = append(opts, sociopath.WithBreachChecker(breach.NewRangeChecker(*breachRange)))
+	}
+	if *render {
+		renderer, err := generic.FindChrome()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -render: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, sociopath.WithRenderer(renderer))
+	}
+	if *searchName != "" {
+		provider, err := newSearchProvider(context.Background(), *searchName, httpCache, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -search: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, sociopath.WithSearchProvider(provider))
+	}
+	features := &sociopath.Features{}
+	if *featuresPath != "" {
+		var err error
+		if features, err = sociopath.LoadFeatures(*featuresPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -features: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := features.ApplyEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(features.DisabledPlatforms) > 0 || len(features.DisabledCapabilities) > 0 {
+		opts = append(opts, sociopath.WithFeatures(features))
+	}
+	if *quotaSpec != "" {
+		quotas, err := parseQuotas(*quotaSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, quotas...)
+	}
+	tlsOpts, err := tlsOptions(*caFile, *clientCert, *clientKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts = append(opts, tlsOpts...)
+	dialOpts, err := dialOptions(*ipv4, *ipv6, *bind, *resolverSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	opts = append(opts, dialOpts...)
+	if *visitedPath != "" {
+		seen, err := visited.Open(*visitedPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := seen.Save(); err != nil {
+				logger.Warn("failed to save visited set", "path", *visitedPath, "error", err)
+			}
+		}()
+		opts = append(opts, sociopath.WithVisitedSet(seen))
+	}
+	if *blobDir != "" {
+		blobs, err := blob.NewFS(*blobDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -blobs: %v\n", err)
+			os.Exit(1)
+		}
+		opts = append(opts, sociopath.WithBlobStore(blobs, blob.DefaultMinSize))
+	}
+	if *auditPath != "" {
+		auditLog, err := audit.Open(*auditPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -audit: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := auditLog.Close(); err != nil {
+				logger.Warn("failed to close audit log", "path", *auditPath, "error", err)
+			}
+		}()
+		opts = append(opts, sociopath.WithAuditLog(auditLog))
+	}
+	if crawling {
+		opts = append(opts, sociopath.WithProgress((&progressReporter{status: status, logger: logger}).report))
+	}
+	if *graphPath != "" {
+		graph := linkgraph.New()
+		defer func() {
+			if err := writeGraph(graph, *graphPath); err != nil {
+				logger.Warn("failed to write link graph", "path", *graphPath, "error", err)
+			}
+		}()
+		opts = append(opts, sociopath.WithLinkGraph(graph))
+	}
 
-	ctx := context.Background()
+	var sinks []export.Sink
+	if *esURL != "" {
+		es, err := newElasticsearch(*esURL, logger)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -es: %v\n", err)
+			os.Exit(1)
+		}
+		sinks = append(sinks, es)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var crawler *sociopath.Crawler
+	if *resumeID != "" || *runID != "" {
+		var st store.Store
+		crawler, st, err = newCrawler(*storeDir, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer func() {
+			if err := st.Close(); err != nil {
+				logger.Warn("failed to close crawl store", "error", err)
+			}
+		}()
+	}
+	// Crawls with a store stop at the next URL, keeping the rest queued for -resume;
+	// other modes can only be canceled
+	drain := cancel
+	if crawler != nil {
+		drain = crawler.Stop
+	}
+	defer onInterrupt(logger, drain, cancel)()
 
 	switch {
+	case crawler != nil:
+		var profiles []*sociopath.Profile
+		if *resumeID != "" {
+			profiles, err = crawler.Resume(ctx, *resumeID)
+		} else {
+			if !isURL(input) {
+				fmt.Fprint(os.Stderr, "Error: -run requires a URL, not a username\n")
+				os.Exit(1)
+			}
+			profiles, err = crawler.Start(ctx, *runID, input)
+		}
+		status.Clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			if id := *resumeID + *runID; !errors.Is(err, sociopath.ErrRunExists) && !errors.Is(err, sociopath.ErrUnknownRun) {
+				fmt.Fprintf(os.Stderr, "Resume with: sociopath -resume %s\n", id)
+			}
+			if len(profiles) == 0 {
+				os.Exit(1)
+			}
+			// Still report what the run fetched before it stopped
+			exitCode = 1
+			ctx = context.WithoutCancel(ctx)
+		}
+		if err := outputProfiles(ctx, profiles, summarize, sinks); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			os.Exit(1)
+		}
+	case *team:
+		profiles, err := sociopath.FetchTeam(ctx, input, opts...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := outputProfiles(ctx, profiles, summarize, sinks); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			os.Exit(1)
+		}
+	case *orgMode:
+		profiles, err := sociopath.FetchOrg(ctx, input, opts...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := outputProfiles(ctx, profiles, summarize, sinks); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			os.Exit(1)
+		}
 	case *guessMode:
 		// Guess mode implies recursive and accepts username or URL
 		var profiles []*sociopath.Profile
@@ -104,12 +481,13 @@ func main() {
 			// Treat as username and guess across platforms
 			profiles, err = sociopath.GuessFromUsername(ctx, input, opts...)
 		}
+		status.Clear()
 
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1) //nolint:gocritic // exitAfterDefer is acceptable in main
 		}
-		if err := outputJSON(profiles); err != nil {
+		if err := outputProfiles(ctx, profiles, summarize, sinks); err != nil {
 			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
 			os.Exit(1)
 		}
@@ -119,11 +497,12 @@ func main() {
 			os.Exit(1)
 		}
 		profiles, err := sociopath.FetchRecursive(ctx, input, opts...)
+		status.Clear()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		if err := outputJSON(profiles); err != nil {
+		if err := outputProfiles(ctx, profiles, summarize, sinks); err != nil {
 			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
 			os.Exit(1)
 		}
@@ -137,6 +516,10 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		if err := exportProfiles(ctx, sinks, []*sociopath.Profile{profile}); err != nil {
+			fmt.Fprintf(os.Stderr, "Export error: %v\n", err)
+			os.Exit(1)
+		}
 		if err := outputJSON(profile); err != nil {
 			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
 			os.Exit(1)
@@ -145,7 +528,215 @@ func main() {
 }
 
 func isURL(s string) bool {
-	return strings.Contains(s, "://") || strings.HasPrefix(s, "http")
+	return strings.Contains(s, "://") || strings.HasPrefix(s, "http") || strings.HasPrefix(s, "urn:li:") || strings.HasPrefix(s, "nostr:")
+}
+
+// parseQuotas parses "platform=n,platform=n" into WithDailyQuota options.
+func parseQuotas(spec string) ([]sociopath.Option, error) {
+	var opts []sociopath.Option
+	for part := range strings.SplitSeq(spec, ",") {
+		platform, n, ok := strings.Cut(strings.TrimSpace(part), "=")
+		limit, err := strconv.Atoi(n)
+		if !ok || platform == "" || err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid quota %q: want platform=count", part)
+		}
+		opts = append(opts, sociopath.WithDailyQuota(platform, limit))
+	}
+	return opts, nil
+}
+
+// tlsOptions returns the options trusting the PEM roots in caFile alongside the
+// system's and presenting the client certificate in certFile and keyFile.
+func tlsOptions(caFile, certFile, keyFile string) ([]sociopath.Option, error) {
+	var opts []sociopath.Option
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no PEM certificates", caFile)
+		}
+		opts = append(opts, sociopath.WithRootCAs(roots))
+	}
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("-client-cert and -client-key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sociopath.WithClientCert(cert))
+	}
+	return opts, nil
+}
+
+// dialOptions returns the options restricting connections to one IP version, sending
+// them from bind, an IP address or the name of an interface, and looking hosts up
+// with the DNS server in resolver.
+func dialOptions(ipv4, ipv6 bool, bind, resolver string) ([]sociopath.Option, error) {
+	version := 0
+	switch {
+	case ipv4 && ipv6:
+		return nil, errors.New("-ipv4 and -ipv6 cannot be used together")
+	case ipv4:
+		version = 4
+	case ipv6:
+		version = 6
+	}
+	var opts []sociopath.Option
+	if version != 0 {
+		opts = append(opts, sociopath.WithIPVersion(version))
+	}
+	if bind != "" {
+		addr, err := netip.ParseAddr(bind)
+		if err != nil {
+			if addr, err = cache.InterfaceAddr(bind, version); err != nil {
+				return nil, fmt.Errorf("-bind: %w", err)
+			}
+		}
+		if version == 4 && !addr.Unmap().Is4() || version == 6 && !addr.Is6() {
+			return nil, fmt.Errorf("-bind address %s is not IPv%d", addr, version)
+		}
+		opts = append(opts, sociopath.WithLocalAddr(addr))
+	}
+	switch {
+	case strings.HasPrefix(resolver, "https://"):
+		doh, err := cache.NewDoHResolver(resolver)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sociopath.WithResolver(doh))
+	case resolver != "":
+		server := resolver
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, "53")
+		}
+		opts = append(opts, sociopath.WithResolver(&net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, server)
+			},
+		}))
+	}
+	return opts, nil
+}
+
+// newSearchProvider returns the search provider named by spec: "bing", "serpapi",
+// or the base URL of a SearxNG instance.
+func newSearchProvider(ctx context.Context, spec string, httpCache *cache.BDCache, logger *slog.Logger) (searchengine.Provider, error) {
+	opts := []searchengine.Option{searchengine.WithLogger(logger)}
+	if httpCache != nil {
+		opts = append(opts, searchengine.WithHTTPCache(httpCache))
+	}
+	switch spec {
+	case "bing":
+		return searchengine.NewBing(ctx, os.Getenv("BING_SEARCH_KEY"), opts...)
+	case "serpapi":
+		return searchengine.NewSerpAPI(ctx, os.Getenv("SERPAPI_KEY"), opts...)
+	default:
+		return searchengine.NewSearxNG(ctx, spec, opts...)
+	}
+}
+
+// newElasticsearch returns a sink for the index named by the last path element of
+// rawURL. Credentials come from the URL's user info or the ES_API_KEY variable.
+func newElasticsearch(rawURL string, logger *slog.Logger) (*export.Elasticsearch, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q: want http://host:9200/index", rawURL)
+	}
+	base, index := "", "sociopath"
+	if trimmed := strings.TrimRight(u.Path, "/"); trimmed != "" {
+		i := strings.LastIndex(trimmed, "/")
+		base, index = trimmed[:i], trimmed[i+1:]
+	}
+	opts := []export.Option{export.WithLogger(logger)}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		opts = append(opts, export.WithBasicAuth(u.User.Username(), password))
+	}
+	if key := os.Getenv("ES_API_KEY"); key != "" {
+		opts = append(opts, export.WithAPIKey(key))
+	}
+	return export.NewElasticsearch(u.Scheme+"://"+u.Host+base, index, opts...), nil
+}
+
+// newCrawler returns a crawler keeping run state in dir, or in the user cache dir if empty,
+// and the store it uses. A redis:// or rediss:// URL keeps it in a Redis server instead,
+// which the processes sharing the run also use to keep to per-domain delays together.
+func newCrawler(dir string, opts []sociopath.Option) (*sociopath.Crawler, store.Store, error) {
+	if strings.HasPrefix(dir, "redis://") || strings.HasPrefix(dir, "rediss://") {
+		client, err := redis.New(dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		cache.SetSharedLimiter(cache.NewRedisLimiter(client, 1))
+		st := store.NewRedis(client)
+		return sociopath.NewCrawler(st, opts...), st, nil
+	}
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			cacheDir = os.TempDir()
+		}
+		dir = filepath.Join(cacheDir, "sociopath", "runs")
+	}
+	st, err := store.NewFS(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sociopath.NewCrawler(st, opts...), st, nil
+}
+
+// writeGraph writes g to path in the format implied by its extension.
+func writeGraph(g *linkgraph.Graph, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".graphml", ".xml":
+		err = g.WriteGraphML(f)
+	case ".json":
+		err = json.NewEncoder(f).Encode(g)
+	case ".cypher", ".cql":
+		err = g.WriteCypher(f)
+	default:
+		err = g.WriteDOT(f)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// outputProfiles sends profiles to sinks, then writes them, or their summary if
+// summarize is set, as JSON.
+func outputProfiles(ctx context.Context, profiles []*sociopath.Profile, summarize func([]*sociopath.Profile) any, sinks []export.Sink) error {
+	if err := exportProfiles(ctx, sinks, profiles); err != nil {
+		return err
+	}
+	if summarize != nil {
+		return outputJSON(summarize(profiles))
+	}
+	return outputJSON(profiles)
+}
+
+// exportProfiles sends profiles to each sink in turn.
+func exportProfiles(ctx context.Context, sinks []export.Sink, profiles []*sociopath.Profile) error {
+	for _, sink := range sinks {
+		if err := sink.Export(ctx, profiles); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+	return nil
 }
 
 func outputJSON(v any) error {
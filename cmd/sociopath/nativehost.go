@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/nativemsg"
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+)
+
+// isNativeHostLaunch reports whether a browser started this process as a native
+// messaging host. Browsers cannot pass a subcommand: Chrome passes the extension's
+// origin, and Firefox the manifest path and extension ID.
+func isNativeHostLaunch(args []string) bool {
+	switch {
+	case len(args) > 0 && strings.HasPrefix(args[0], "chrome-extension://"):
+		return true
+	case len(args) == 2 && filepath.Base(args[0]) == nativemsg.HostName+".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// nativeHost implements "sociopath native-host": it saves the profiles a companion
+// browser extension sends as the user browses. It returns the exit code.
+func nativeHost(args []string) int {
+	fs := flag.NewFlagSet("native-host", flag.ExitOnError)
+	dir := fs.String("store", "", "directory to save profiles in (default: sociopath/runs in the user cache dir)")
+	runID := fs.String("run", nativemsg.DefaultRunID, "run ID to save profiles under")
+	manifest := fs.Bool("manifest", false, "print the host manifest to install for the browser and exit")
+	origins := fs.String("origins", "", "with -manifest, comma-separated Chrome extension origins (chrome-extension://<id>/)")
+	extensions := fs.String("extensions", "", "with -manifest, comma-separated Firefox extension IDs")
+	debug := fs.Bool("debug", false, "enable debug logging")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: sociopath native-host [options]")
+		fmt.Fprintln(os.Stderr, "\nRuns the native messaging host for the companion browser extension,")
+		fmt.Fprintln(os.Stderr, "saving the profiles it sends. Browsers start it themselves once the")
+		fmt.Fprintln(os.Stderr, "manifest printed by -manifest is installed as "+nativemsg.HostName+".json.")
+		fmt.Fprintln(os.Stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	if isNativeHostLaunch(args) {
+		args = nil
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	if *manifest {
+		path, err := os.Executable()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := outputJSON(nativemsg.NewManifest(path, splitComma(*origins), splitComma(*extensions))); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	// Stdout carries the protocol, so everything else goes to stderr
+	logLevel := slog.LevelInfo
+	if *debug {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+
+	if *dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			cacheDir = os.TempDir()
+		}
+		*dir = filepath.Join(cacheDir, "sociopath", "runs")
+	}
+	st, err := store.NewFS(*dir)
+	if err != nil {
+		logger.Error("failed to open store", "dir", *dir, "error", err)
+		return 1
+	}
+	defer st.Close() //nolint:errcheck // writes are flushed as they happen
+
+	if err := nativemsg.NewHost(st, *runID, logger).Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		logger.Error("native messaging failed", "error", err)
+		return 1
+	}
+	return 0
+}
+
+func splitComma(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// progressLogInterval is how often progress is logged when stderr is not a terminal.
+const progressLogInterval = 30 * time.Second
+
+// isTerminal reports whether f is a character device, such as a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// statusLine keeps a one-line status at the bottom of a terminal. Log lines written
+// through it appear above the status, which is redrawn after each.
+type statusLine struct {
+	w    io.Writer
+	line string
+	mu   sync.Mutex
+}
+
+const clearLine = "\r\033[K"
+
+func (s *statusLine) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.line == "" {
+		return s.w.Write(p)
+	}
+	if _, err := io.WriteString(s.w, clearLine); err != nil {
+		return 0, err
+	}
+	n, err := s.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	_, err = io.WriteString(s.w, s.line)
+	return n, err
+}
+
+// Set replaces the status with line.
+func (s *statusLine) Set(line string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.line = line
+	_, _ = io.WriteString(s.w, clearLine+line) //nolint:errcheck // best effort, like the log
+}
+
+// Clear removes the status, if any. A nil statusLine has none.
+func (s *statusLine) Clear() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.line != "" {
+		s.line = ""
+		_, _ = io.WriteString(s.w, clearLine) //nolint:errcheck // best effort, like the log
+	}
+}
+
+// progressReporter renders crawl progress on status if set, and otherwise logs it
+// every progressLogInterval.
+type progressReporter struct {
+	status *statusLine
+	logger *slog.Logger
+	last   time.Time
+}
+
+func (r *progressReporter) report(p sociopath.Progress) {
+	if r.status != nil {
+		r.status.Set(formatProgress(p))
+		return
+	}
+	if time.Since(r.last) < progressLogInterval {
+		return
+	}
+	r.last = time.Now()
+	r.logger.Info("crawl progress", "completed", p.Completed, "failed", p.Failed, "skipped", p.Skipped,
+		"queued", p.Queued, "rate", fmt.Sprintf("%.2f/s", p.Rate), "remaining", formatRemaining(p.Remaining()))
+}
+
+// formatProgress summarizes p on one line, with the three busiest platforms.
+func formatProgress(p sociopath.Progress) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d fetched", p.Completed)
+	if p.Failed > 0 {
+		fmt.Fprintf(&b, ", %d failed", p.Failed)
+	}
+	fmt.Fprintf(&b, ", %d queued, %.1f/s, %s left", p.Queued, p.Rate, formatRemaining(p.Remaining()))
+
+	platforms := make([]string, 0, len(p.Platforms))
+	for name := range p.Platforms {
+		platforms = append(platforms, name)
+	}
+	slices.SortFunc(platforms, func(a, b string) int {
+		return cmp.Or(cmp.Compare(p.Platforms[b], p.Platforms[a]), cmp.Compare(a, b))
+	})
+	if len(platforms) > 3 {
+		platforms = platforms[:3]
+	}
+	for i, name := range platforms {
+		platforms[i] = fmt.Sprintf("%s %d", name, p.Platforms[name])
+	}
+	if len(platforms) > 0 {
+		b.WriteString(" (" + strings.Join(platforms, ", ") + ")")
+	}
+	return b.String()
+}
+
+func formatRemaining(d time.Duration) string {
+	if d < 0 {
+		return "unknown"
+	}
+	return "~" + d.Round(time.Second).String()
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/audit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// replayResult compares an audited fetch with its replay.
+type replayResult struct {
+	URL     string             `json:"url"`
+	Outcome string             `json:"outcome"` // fixed, still_failing, regressed, or unchanged
+	Before  audit.Entry        `json:"before"`
+	After   audit.Entry        `json:"after"`
+	Profile *sociopath.Profile `json:"profile,omitempty"`
+}
+
+// lastEntry is an audit log that keeps only the most recent entry.
+type lastEntry struct {
+	entry audit.Entry
+}
+
+func (l *lastEntry) Record(e audit.Entry) error {
+	l.entry = e
+	return nil
+}
+
+// replay implements "sociopath replay": it re-runs the selected fetches of an audit
+// log against the current parsers, from cached responses where available, and
+// reports which failures are fixed. It returns the exit code.
+func replay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	failed := fs.Bool("failed", false, "only replay fetches that failed or extracted nothing")
+	platform := fs.String("platform", "", "only replay fetches of this platform")
+	urlPart := fs.String("url", "", "only replay fetches whose URL contains this")
+	since := fs.Duration("since", 0, "only replay fetches made within this long (e.g. 24h)")
+	offline := fs.Bool("offline", false, "only use cached responses; uncached URLs fail instead of being fetched")
+	noBrowser := fs.Bool("no-browser", false, "disable reading cookies from browser stores")
+	cacheTTL := fs.Duration("cache-ttl", 75*24*time.Hour, "cache time-to-live")
+	profiles := fs.Bool("profiles", false, "include the replayed profiles in the output")
+	debug := fs.Bool("debug", false, "enable debug logging")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: sociopath replay [options] <audit log>")
+		fmt.Fprintln(os.Stderr, "\nRe-runs fetches recorded with -audit against the current parsers and")
+		fmt.Fprintln(os.Stderr, "prints one JSON line per fetch comparing the old and new outcomes.")
+		fmt.Fprintln(os.Stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	logLevel := slog.LevelWarn
+	if *debug {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+
+	entries, err := audit.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	filter := audit.Filter{Platform: *platform, URL: *urlPart, Failed: *failed}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+	entries = audit.Select(entries, filter)
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stderr, "No audit entries match")
+		return 1
+	}
+
+	httpCache, err := cache.New(*cacheTTL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: cache: %v\n", err)
+		return 1
+	}
+	defer func() {
+		if err := httpCache.Close(); err != nil {
+			logger.Warn("failed to close cache", "error", err)
+		}
+	}()
+
+	last := &lastEntry{}
+	opts := []sociopath.Option{
+		sociopath.WithLogger(logger),
+		sociopath.WithHTTPCache(httpCache),
+		sociopath.WithAuditLog(last),
+	}
+	if !*noBrowser {
+		opts = append(opts, sociopath.WithBrowserCookies())
+	}
+	if *offline {
+		opts = append(opts, sociopath.WithOfflineMode(true))
+	}
+
+	ctx := context.Background()
+	enc := json.NewEncoder(os.Stdout)
+	counts := make(map[string]int)
+	for _, before := range entries {
+		last.entry = audit.Entry{}
+		p, err := sociopath.Fetch(ctx, before.URL, opts...)
+		after := last.entry
+		if after.URL == "" {
+			// Refused before fetching, e.g. by a quota
+			after = audit.Entry{Time: time.Now().UTC(), URL: before.URL, Platform: before.Platform, Status: audit.StatusError}
+			if err != nil {
+				after.Error = err.Error()
+			}
+		}
+		r := replayResult{URL: before.URL, Outcome: outcome(&before, &after), Before: before, After: after}
+		if *profiles {
+			r.Profile = p
+		}
+		counts[r.Outcome]++
+		if err := enc.Encode(r); err != nil {
+			fmt.Fprintf(os.Stderr, "Output error: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Replayed %d fetches: %d fixed, %d still failing, %d regressed, %d unchanged\n",
+		len(entries), counts["fixed"], counts["still_failing"], counts["regressed"], counts["unchanged"])
+	if counts["still_failing"] > 0 || counts["regressed"] > 0 {
+		return 1
+	}
+	return 0
+}
+
+// outcome classifies a replay by whether the fetch failed before and after.
+func outcome(before, after *audit.Entry) string {
+	switch {
+	case before.Failed() && !after.Failed():
+		return "fixed"
+	case before.Failed():
+		return "still_failing"
+	case after.Failed():
+		return "regressed"
+	default:
+		return "unchanged"
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/server"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// serve implements "sociopath serve": it answers profile fetches over HTTP, queueing
+// those over a platform's budget as jobs. It returns the exit code.
+func serve(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	noBrowser := fs.Bool("no-browser", false, "disable reading cookies from browser stores")
+	noCache := fs.Bool("no-cache", false, "disable HTTP caching")
+	cacheTTL := fs.Duration("cache-ttl", 75*24*time.Hour, "cache time-to-live")
+	quotaSpec := fs.String("quota", "", "daily fetch quotas per platform, e.g. linkedin=200,twitter=500; fetches over them are queued")
+	politenessPath := fs.String("politeness", "", "JSON file with per-domain politeness policies")
+	tenantsPath := fs.String("tenants", "", "JSON file of tenants, each with an API key and its own cookies and proxy; implies -no-browser")
+	redisURL := fs.String("redis", "", "share per-domain rate limits with other servers through this redis:// URL")
+	checkpoint := fs.String("checkpoint", "", "file to save queued jobs to on shutdown and queue them again from on start")
+	drainTimeout := fs.Duration("drain-timeout", 30*time.Second, "on SIGINT or SIGTERM, how long to wait for fetches in flight")
+	retryDelay := fs.Duration("retry", time.Minute, "how long a platform's queue waits before retrying when its budget is exhausted")
+	ipv4 := fs.Bool("ipv4", false, "connect over IPv4 only")
+	ipv6 := fs.Bool("ipv6", false, "connect over IPv6 only")
+	resolverSpec := fs.String("resolver", "", "DNS server to look hosts up with: a DNS-over-HTTPS URL (https://...) or host[:port]")
+	bind := fs.String("bind", "", "local IP address or network interface to send requests from")
+	caFile := fs.String("ca-cert", "", "PEM file of root certificates to trust besides the system's")
+	clientCert := fs.String("client-cert", "", "PEM client certificate to present to servers that require one (with -client-key)")
+	clientKey := fs.String("client-key", "", "PEM private key of -client-cert")
+	debug := fs.Bool("debug", false, "enable debug logging")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: sociopath serve [options]")
+		fmt.Fprintln(os.Stderr, "\nServes GET /v1/profile?url=<url>. Fetches over a platform's budget are")
+		fmt.Fprintln(os.Stderr, "queued: the response is 202 with a job whose result GET /v1/jobs/<id>")
+		fmt.Fprintln(os.Stderr, "returns when it is done. With -tenants, requests need a tenant's API key")
+		fmt.Fprintln(os.Stderr, "in an X-API-Key or \"Authorization: Bearer\" header.")
+		fmt.Fprintln(os.Stderr, "\nOptions:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	logLevel := slog.LevelInfo
+	if *debug {
+		logLevel = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logLevel}))
+
+	if *politenessPath != "" {
+		politeness, err := cache.LoadPoliteness(*politenessPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		cache.SetPoliteness(politeness)
+	}
+
+	if *redisURL != "" {
+		client, err := redis.New(*redisURL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -redis: %v\n", err)
+			return 1
+		}
+		defer client.Close() //nolint:errcheck // nothing left to flush
+		cache.SetSharedLimiter(cache.NewRedisLimiter(client, 1))
+	}
+
+	var tenants []server.Tenant
+	if *tenantsPath != "" {
+		var err error
+		if tenants, err = server.LoadTenants(*tenantsPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		// Tenants without cookies of their own would otherwise share these
+		for _, platform := range []string{"linkedin", "twitter", "instagram", "tiktok", "vkontakte", "weibo"} {
+			for _, name := range auth.EnvVarsForPlatform(platform) {
+				if os.Getenv(name) != "" {
+					fmt.Fprintf(os.Stderr, "Error: %s is set, and its cookie would be shared by all tenants; unset it when using -tenants\n", name)
+					return 1
+				}
+			}
+		}
+	}
+
+	opts := []sociopath.Option{sociopath.WithLogger(logger)}
+	if !*noBrowser && tenants == nil {
+		opts = append(opts, sociopath.WithBrowserCookies())
+	}
+	if *quotaSpec != "" {
+		quotas, err := parseQuotas(*quotaSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		opts = append(opts, quotas...)
+	}
+	tlsOpts, err := tlsOptions(*caFile, *clientCert, *clientKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	opts = append(opts, tlsOpts...)
+	dialOpts, err := dialOptions(*ipv4, *ipv6, *bind, *resolverSpec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	opts = append(opts, dialOpts...)
+	if !*noCache {
+		httpCache, err := cache.New(*cacheTTL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cache: %v\n", err)
+			return 1
+		}
+		defer func() {
+			if err := httpCache.Close(); err != nil {
+				logger.Warn("failed to close cache", "error", err)
+			}
+		}()
+		opts = append(opts, sociopath.WithHTTPCache(httpCache))
+	}
+
+	s, err := server.New(context.Background(), server.WithFetchOptions(opts...), server.WithTenants(tenants),
+		server.WithLogger(logger), server.WithRetryDelay(*retryDelay))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *checkpoint != "" {
+		jobs, err := loadCheckpoint(*checkpoint)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -checkpoint: %v\n", err)
+			return 1
+		}
+		if len(jobs) > 0 {
+			logger.Info("queueing jobs from checkpoint", "jobs", len(jobs), "path", *checkpoint)
+			s.Restore(jobs)
+		}
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	served := make(chan error, 1)
+	go func() { served <- srv.ListenAndServe() }()
+	logger.Info("serving", "addr", *addr)
+
+	select {
+	case err := <-served:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	case <-ctx.Done():
+	}
+	stop() // A second signal kills the process
+
+	logger.Info("shutting down, finishing requests and fetches in flight", "timeout", *drainTimeout)
+	drainCtx, cancel := context.WithTimeout(context.Background(), *drainTimeout)
+	defer cancel()
+	if err := srv.Shutdown(drainCtx); err != nil {
+		logger.Warn("requests still in flight at shutdown", "error", err)
+	}
+	pending := s.Drain(drainCtx)
+	switch {
+	case *checkpoint != "":
+		// Saved even when empty, so the next start does not queue jobs already done
+		if err := saveCheckpoint(*checkpoint, pending); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -checkpoint: %v\n", err)
+			return 1
+		}
+		logger.Info("saved queued jobs", "jobs", len(pending), "path", *checkpoint)
+	case len(pending) > 0:
+		logger.Warn("dropping queued jobs; use -checkpoint to keep them", "jobs", len(pending))
+	}
+	return 0
+}
+
+// loadCheckpoint reads the jobs saveCheckpoint wrote to path, if it exists.
+func loadCheckpoint(path string) ([]server.Job, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var jobs []server.Job
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return jobs, nil
+}
+
+// saveCheckpoint writes jobs to path, replacing what an earlier shutdown saved.
+func saveCheckpoint(path string, jobs []server.Job) error {
+	if jobs == nil {
+		jobs = []server.Job{}
+	}
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
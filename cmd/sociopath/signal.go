@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// onInterrupt calls drain on the first SIGINT or SIGTERM and cancel on the second,
+// so a first Ctrl-C lets the work in flight finish and a second abandons it. The
+// returned function stops listening for signals.
+func onInterrupt(logger *slog.Logger, drain, cancel func()) (stop func()) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-signals:
+			logger.Warn("interrupted, finishing fetches in flight; interrupt again to abandon them")
+			drain()
+		case <-done:
+			return
+		}
+		select {
+		case <-signals:
+			logger.Warn("interrupted again, abandoning fetches in flight")
+			cancel()
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
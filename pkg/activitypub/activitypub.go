@@ -0,0 +1,377 @@
+// Package activitypub fetches profiles from any fediverse server as ActivityPub actor
+// objects, covering Mastodon forks, Pleroma, Misskey, WriteFreely, PeerTube, and
+// single-user servers without scraping their pages.
+package activitypub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "activitypub"
+
+// accept asks for the ActivityStreams representation of a URL, in both the forms
+// the ActivityPub specification allows servers to require.
+const accept = `application/activity+json, application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+
+// ErrNotActor is returned when a URL does not serve an ActivityPub actor, such as an
+// ordinary website that ignores the Accept header.
+var ErrNotActor = errors.New("not an ActivityPub actor")
+
+// actorTypes are the ActivityStreams types that describe an account.
+var actorTypes = map[string]bool{
+	"Person": true, "Service": true, "Application": true, "Group": true, "Organization": true,
+}
+
+// actorPaths are path prefixes fediverse servers commonly serve actors under.
+var actorPaths = []string{"/@", "/users/", "/u/", "/a/", "/c/", "/accounts/", "/video-channels/", "/profile/"}
+
+// Match reports whether the URL has a path fediverse servers commonly serve actors
+// under. Fetch works on any URL; Match only flags the likely ones.
+func Match(urlStr string) bool {
+	parsed, err := url.Parse(urlStr)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	for _, prefix := range actorPaths {
+		if strings.HasPrefix(parsed.Path, prefix) && len(parsed.Path) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthRequired returns false because actor objects are public.
+func AuthRequired() bool { return false }
+
+// Client handles ActivityPub requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	depth      profile.Depth
+
+	allowPrivate bool // Lets tests reach servers on loopback
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+	depth  profile.Depth
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
+// New creates an ActivityPub client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
+	}, nil
+}
+
+// actor is the subset of an ActivityPub actor object the client reads. Properties
+// servers send as a string, an object, or an array are kept raw; see href.
+type actor struct {
+	Context           json.RawMessage `json:"@context"`
+	ID                string          `json:"id"`
+	Type              string          `json:"type"`
+	PreferredUsername string          `json:"preferredUsername"`
+	Name              string          `json:"name"`
+	Summary           string          `json:"summary"`
+	URL               json.RawMessage `json:"url"`
+	Icon              json.RawMessage `json:"icon"`
+	Image             json.RawMessage `json:"image"`
+	Published         string          `json:"published"`
+	Updated           string          `json:"updated"`
+	Outbox            string          `json:"outbox"`
+	AlsoKnownAs       []string        `json:"alsoKnownAs"`
+	MovedTo           string          `json:"movedTo"`
+	Attachment        []struct {
+		Type  string          `json:"type"`
+		Name  string          `json:"name"`
+		Value string          `json:"value"`
+		Href  json.RawMessage `json:"href"`
+	} `json:"attachment"`
+}
+
+// Fetch retrieves the actor at urlStr by content negotiation. It returns ErrNotActor
+// if the server answers with anything else.
+//
+// Any server can claim to be an actor, and the outbox and page URLs come from its
+// response, so every request is refused connections to private addresses.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	if !c.allowPrivate {
+		ctx = cache.WithPublicOnly(ctx)
+	}
+	c.logger.InfoContext(ctx, "fetching activitypub actor", "url", urlStr)
+
+	body, err := c.get(ctx, urlStr)
+	if err != nil {
+		return nil, err
+	}
+	var a actor
+	if err := json.Unmarshal(body, &a); err != nil || len(a.Context) == 0 || !actorTypes[a.Type] {
+		return nil, fmt.Errorf("%w: %s", ErrNotActor, urlStr)
+	}
+
+	p := parseActor(&a)
+	p.URL = urlStr
+
+	if limit := c.depth.PostLimit(); a.Outbox != "" && limit > 0 && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		posts, lastActive := c.fetchOutbox(ctx, a.Outbox, limit)
+		p.Posts = posts
+		p.UpdateLastActive(lastActive)
+	}
+	return p, nil
+}
+
+func (c *Client) get(ctx context.Context, urlStr string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", cache.UserAgent)
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+func parseActor(a *actor) *profile.Profile {
+	p := &profile.Profile{
+		Platform:  platform,
+		Username:  a.PreferredUsername,
+		Name:      strings.TrimSpace(a.Name),
+		Bio:       htmlutil.Text(a.Summary),
+		CreatedAt: a.Published,
+		UpdatedAt: a.Updated,
+		Fields:    make(map[string]string),
+	}
+
+	p.Fields["actor_type"] = a.Type
+	if a.ID != "" {
+		p.Fields["actor_id"] = a.ID
+		if u, err := url.Parse(a.ID); err == nil && u.Host != "" && a.PreferredUsername != "" {
+			p.Fields["handle"] = "@" + a.PreferredUsername + "@" + u.Host
+		}
+	}
+	if icon := href(a.Icon); icon != "" {
+		p.Fields[profile.FieldAvatarURL] = icon
+	}
+	if banner := href(a.Image); banner != "" {
+		p.Fields["banner_url"] = banner
+	}
+	if a.MovedTo != "" {
+		p.Fields["moved_to"] = a.MovedTo
+	}
+
+	for _, att := range a.Attachment {
+		switch att.Type {
+		case "PropertyValue":
+			name, value := htmlutil.Text(att.Name), htmlutil.Text(att.Value)
+			if name == "" || value == "" {
+				continue
+			}
+			p.Fields[name] = value
+			lower := strings.ToLower(name)
+			if strings.Contains(lower, "location") || strings.Contains(lower, "city") ||
+				strings.Contains(lower, "country") || strings.Contains(lower, "place") {
+				p.Location = value
+			}
+			p.SocialLinks = append(p.SocialLinks, extractURLs(att.Value)...)
+		case "Link":
+			if link := href(att.Href); link != "" {
+				p.SocialLinks = append(p.SocialLinks, link)
+			}
+		}
+	}
+	// Former and alternate accounts are the same person elsewhere
+	p.SocialLinks = append(p.SocialLinks, a.AlsoKnownAs...)
+
+	self := map[string]bool{a.ID: true, href(a.URL): true}
+	var others []string
+	for _, link := range p.SocialLinks {
+		if !self[link] {
+			others = append(others, link)
+		}
+	}
+	p.SocialLinks = links.Dedupe(others)
+	return p
+}
+
+// fetchOutbox returns the text of the actor's most recent public posts.
+func (c *Client) fetchOutbox(ctx context.Context, outboxURL string, limit int) (posts []profile.Post, lastActive string) {
+	body, err := c.get(ctx, outboxURL)
+	if err != nil {
+		c.logger.DebugContext(ctx, "outbox fetch failed", "url", outboxURL, "error", err)
+		return nil, ""
+	}
+	var collection struct {
+		First        json.RawMessage   `json:"first"`
+		OrderedItems []json.RawMessage `json:"orderedItems"`
+	}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		return nil, ""
+	}
+
+	items := collection.OrderedItems
+	if len(items) == 0 && len(collection.First) > 0 {
+		// The first page is embedded or linked
+		var page struct {
+			OrderedItems []json.RawMessage `json:"orderedItems"`
+		}
+		if err := json.Unmarshal(collection.First, &page); err != nil || len(page.OrderedItems) == 0 {
+			if first := href(collection.First); first != "" {
+				if body, err := c.get(ctx, first); err == nil {
+					_ = json.Unmarshal(body, &page) //nolint:errcheck // an unreadable page has no posts
+				}
+			}
+		}
+		items = page.OrderedItems
+	}
+
+	for _, raw := range items {
+		if len(posts) >= limit {
+			break
+		}
+		post, published, ok := parseActivity(raw)
+		if !ok {
+			continue
+		}
+		if lastActive == "" {
+			lastActive = published
+		}
+		posts = append(posts, post)
+	}
+	return posts, lastActive
+}
+
+// parseActivity returns the post a Create activity publishes. Boosts, likes, and
+// other activities are skipped.
+func parseActivity(raw json.RawMessage) (post profile.Post, published string, ok bool) {
+	var activity struct {
+		Type      string          `json:"type"`
+		Published string          `json:"published"`
+		Object    json.RawMessage `json:"object"`
+	}
+	if err := json.Unmarshal(raw, &activity); err != nil || activity.Type != "Create" {
+		return profile.Post{}, "", false
+	}
+	var obj struct {
+		Type      string          `json:"type"`
+		Name      string          `json:"name"`
+		Content   string          `json:"content"`
+		URL       json.RawMessage `json:"url"`
+		ID        string          `json:"id"`
+		Published string          `json:"published"`
+	}
+	if err := json.Unmarshal(activity.Object, &obj); err != nil {
+		return profile.Post{}, "", false
+	}
+
+	post = profile.Post{Type: profile.PostTypePost, Title: strings.TrimSpace(obj.Name), Content: htmlutil.Text(obj.Content)}
+	switch obj.Type {
+	case "Article", "Page":
+		post.Type = profile.PostTypeArticle
+	case "Video":
+		post.Type = profile.PostTypeVideo
+	case "Question":
+		post.Type = profile.PostTypeQuestion
+	}
+	if post.URL = href(obj.URL); post.URL == "" {
+		post.URL = obj.ID
+	}
+	if post.Title == "" && post.Content == "" {
+		return profile.Post{}, "", false
+	}
+	published = obj.Published
+	if published == "" {
+		published = activity.Published
+	}
+	return post, published, true
+}
+
+// href returns the URL a link-valued property points to. ActivityStreams allows a
+// plain string, a Link or Image object, or an array of either; for arrays the HTML
+// link is preferred.
+func href(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var obj struct {
+		Href      string          `json:"href"`
+		URL       json.RawMessage `json:"url"`
+		MediaType string          `json:"mediaType"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		if obj.Href != "" {
+			return obj.Href
+		}
+		return href(obj.URL)
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil || len(list) == 0 {
+		return ""
+	}
+	for _, item := range list {
+		if err := json.Unmarshal(item, &obj); err == nil && obj.MediaType == "text/html" && obj.Href != "" {
+			return obj.Href
+		}
+	}
+	return href(list[0])
+}
+
+var (
+	hrefPattern = regexp.MustCompile(`href=["']([^"']+)["']`)
+)
+
+// extractURLs returns the absolute link targets in an HTML fragment.
+func extractURLs(htmlContent string) []string {
+	var urls []string
+	for _, m := range hrefPattern.FindAllStringSubmatch(htmlContent, -1) {
+		if strings.HasPrefix(m[1], "http") {
+			urls = append(urls, html.UnescapeString(m[1]))
+		}
+	}
+	return urls
+}
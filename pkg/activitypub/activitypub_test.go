@@ -0,0 +1,188 @@
+package activitypub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://pleroma.example/users/alice", true},
+		{"https://peertube.tv/a/alice", true},
+		{"https://peertube.tv/c/alice_channel", true},
+		{"https://misskey.io/@alice", true},
+		{"https://example.com/@", false},
+		{"https://example.com/about", false},
+		{"not a url", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("ActivityPub should not require auth")
+	}
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/activity+json") {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = io.WriteString(w, "<html><body>Alice's page</body></html>") //nolint:errcheck // test server
+			return
+		}
+		var v any
+		switch r.URL.Path {
+		case "/@alice":
+			v = map[string]any{
+				"@context":          []string{"https://www.w3.org/ns/activitystreams"},
+				"id":                srv.URL + "/users/alice",
+				"type":              "Person",
+				"preferredUsername": "alice",
+				"name":              "Alice Smith ",
+				"summary":           "<p>Writes about <a href=\"" + srv.URL + "/tags/go\">#go</a></p><p>Berlin &amp; beyond</p>",
+				"url":               srv.URL + "/@alice",
+				"icon":              map[string]string{"type": "Image", "url": srv.URL + "/avatar.png"},
+				"published":         "2019-04-01T00:00:00Z",
+				"outbox":            srv.URL + "/users/alice/outbox",
+				"alsoKnownAs":       []string{"https://old.example/users/alice"},
+				"attachment": []map[string]string{
+					{"type": "PropertyValue", "name": "Website", "value": `<a href="https://alice.dev" rel="me">alice.dev</a>`},
+					{"type": "PropertyValue", "name": "Location", "value": "Berlin"},
+				},
+			}
+		case "/users/alice/outbox":
+			v = map[string]any{"type": "OrderedCollection", "first": srv.URL + "/users/alice/outbox?page=1"}
+			if r.URL.Query().Get("page") == "1" {
+				v = map[string]any{"type": "OrderedCollectionPage", "orderedItems": []any{
+					map[string]any{"type": "Announce", "object": "https://elsewhere.example/notes/1"},
+					map[string]any{"type": "Create", "published": "2025-06-01T10:00:00Z", "object": map[string]any{
+						"type": "Note", "content": "<p>Hello fediverse</p>", "url": srv.URL + "/@alice/1",
+					}},
+					map[string]any{"type": "Create", "object": map[string]any{
+						"type": "Article", "name": "Long read", "content": "<p>Body</p>", "id": srv.URL + "/articles/2",
+						"published": "2025-05-01T10:00:00Z",
+					}},
+				}}
+			}
+		case "/notes/1":
+			v = map[string]any{"@context": "https://www.w3.org/ns/activitystreams", "type": "Note", "content": "hi"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/activity+json")
+		_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // test server
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetch(t *testing.T) {
+	srv := newTestServer(t)
+	client, err := New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.allowPrivate = true
+
+	p, err := client.Fetch(context.Background(), srv.URL+"/@alice")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	host := strings.TrimPrefix(srv.URL, "http://")
+	if p.Platform != "activitypub" || p.Username != "alice" || p.Name != "Alice Smith" || p.Location != "Berlin" {
+		t.Errorf("profile = %+v", p)
+	}
+	if p.Bio != "Writes about #go\nBerlin & beyond" {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Fields["handle"] != "@alice@"+host || p.Fields[profile.FieldAvatarURL] != srv.URL+"/avatar.png" || p.Fields["Website"] != "alice.dev" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if want := []string{"https://alice.dev", "https://old.example/users/alice"}; !slices.Equal(p.SocialLinks, want) {
+		t.Errorf("SocialLinks = %v, want %v", p.SocialLinks, want)
+	}
+	if len(p.Posts) != 2 || p.Posts[0].Content != "Hello fediverse" || p.Posts[1].Type != profile.PostTypeArticle || p.Posts[1].URL != srv.URL+"/articles/2" {
+		t.Errorf("Posts = %+v", p.Posts)
+	}
+	if p.LastActive != "2025-06-01T10:00:00Z" {
+		t.Errorf("LastActive = %q", p.LastActive)
+	}
+}
+
+func TestFetchNotActor(t *testing.T) {
+	srv := newTestServer(t)
+	client, err := New(context.Background(), WithDepth(profile.DepthMinimal))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.allowPrivate = true
+	for _, path := range []string{"/notes/1", "/missing"} {
+		if _, err := client.Fetch(context.Background(), srv.URL+path); err == nil {
+			t.Errorf("Fetch(%s) succeeded, want an error", path)
+		} else if path == "/notes/1" && !errors.Is(err, ErrNotActor) {
+			t.Errorf("Fetch(%s) error = %v, want ErrNotActor", path, err)
+		}
+	}
+}
+
+func TestFetchRefusesPrivateAddresses(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/activity+json")
+		_, _ = io.WriteString(w, `{"type":"Person","preferredUsername":"alice"}`) //nolint:errcheck // test server
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := New(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Fetch(context.Background(), srv.URL+"/@alice"); !errors.Is(err, cache.ErrPrivateAddress) {
+		t.Errorf("Fetch(loopback) error = %v, want ErrPrivateAddress", err)
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("server got %d requests, want none", n)
+	}
+}
+
+func TestHref(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{`"https://a.example/"`, "https://a.example/"},
+		{`{"type":"Image","url":"https://a.example/i.png"}`, "https://a.example/i.png"},
+		{`{"type":"Link","href":"https://a.example/l"}`, "https://a.example/l"},
+		{`[{"type":"Link","mediaType":"application/x-mpegURL","href":"https://a.example/v.m3u8"},{"type":"Link","mediaType":"text/html","href":"https://a.example/w"}]`, "https://a.example/w"},
+		{`["https://a.example/first"]`, "https://a.example/first"},
+		{``, ""},
+	}
+	for _, tt := range tests {
+		if got := href(json.RawMessage(tt.raw)); got != tt.want {
+			t.Errorf("href(%s) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
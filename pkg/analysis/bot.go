@@ -0,0 +1,159 @@
+// Package analysis derives signals from fetched profiles, such as how likely an
+// account is to be automated.
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Fields keys set by AnnotateBotScore.
+const (
+	FieldBotScore   = "bot_score"   // Likelihood 0.00-1.00 that the account is a bot or spam
+	FieldBotSignals = "bot_signals" // Comma-separated signals that contributed to bot_score
+)
+
+// Bot signals and their weights. Weights are additive and the total is capped at 1.
+const (
+	SignalDefaultAvatar     = "default_avatar"     // No avatar, or the platform's placeholder
+	SignalNoPosts           = "no_posts"           // No posts, comments, or repositories found
+	SignalFollowRatio       = "follow_ratio"       // Follows far more accounts than follow it
+	SignalNoFollowers       = "no_followers"       // Follows accounts but has no followers
+	SignalGeneratedUsername = "generated_username" // Username looks machine-generated
+	SignalNewAccount        = "new_account"        // Created within the last 30 days
+	SignalYoungAccount      = "young_account"      // Created within the last 180 days
+	SignalEmptyProfile      = "empty_profile"      // No bio and no display name
+)
+
+var botWeights = map[string]float64{
+	SignalDefaultAvatar:     0.15,
+	SignalNoPosts:           0.10,
+	SignalFollowRatio:       0.25,
+	SignalNoFollowers:       0.10,
+	SignalGeneratedUsername: 0.25,
+	SignalNewAccount:        0.20,
+	SignalYoungAccount:      0.10,
+	SignalEmptyProfile:      0.05,
+}
+
+// defaultAvatarMarkers are URL fragments of platforms' placeholder avatars.
+var defaultAvatarMarkers = []string{
+	"default_profile", // Twitter
+	"missing.png",     // Mastodon
+	"default-avatar",
+	"default_avatar",
+	"identicon",
+	"d=mp",
+	"/avatar/default",
+}
+
+var (
+	// word followed by a long run of digits: "john84729301", "jane_doe_29317"
+	digitSuffixUsername = regexp.MustCompile(`^[A-Za-z]+[_-]?[A-Za-z]*[_-]?\d{5,}$`)
+	// Reddit's suggested usernames: "Adjective_Noun_1234", "Adjective-Noun-12"
+	redditStyleUsername = regexp.MustCompile(`^[A-Z][a-z]+[_-][A-Z][a-z]+[_-]?\d{2,4}$`)
+)
+
+// BotScore returns the likelihood from 0 to 1 that p is an automated or spam
+// account, with the signals that contributed. Signals that need data the platform
+// did not provide, such as follower counts, are skipped rather than counted.
+func BotScore(p *profile.Profile) (score float64, signals []string) {
+	return botScore(p, time.Now())
+}
+
+// AnnotateBotScore stores p's BotScore in Fields["bot_score"] and its signals in
+// Fields["bot_signals"].
+func AnnotateBotScore(p *profile.Profile) {
+	score, signals := BotScore(p)
+	if p.Fields == nil {
+		p.Fields = make(map[string]string)
+	}
+	p.Fields[FieldBotScore] = fmt.Sprintf("%.2f", score)
+	if len(signals) > 0 {
+		p.Fields[FieldBotSignals] = strings.Join(signals, ",")
+	} else {
+		delete(p.Fields, FieldBotSignals)
+	}
+}
+
+func botScore(p *profile.Profile, now time.Time) (score float64, signals []string) {
+	add := func(signal string) {
+		signals = append(signals, signal)
+		score += botWeights[signal]
+	}
+
+	if hasDefaultAvatar(p) {
+		add(SignalDefaultAvatar)
+	}
+	if len(p.Posts) == 0 {
+		if n, ok := p.Repositories(); !ok || n == 0 {
+			add(SignalNoPosts)
+		}
+	}
+
+	followers, hasFollowers := p.Followers()
+	following, hasFollowing := p.Following()
+	switch {
+	case hasFollowing && following >= 100 && (!hasFollowers || followers*10 < following):
+		add(SignalFollowRatio)
+	case hasFollowing && following > 0 && (!hasFollowers || followers == 0):
+		add(SignalNoFollowers)
+	}
+
+	if generatedUsername(p.Username) {
+		add(SignalGeneratedUsername)
+	}
+
+	if joined, ok := p.JoinedAt(); ok {
+		switch age := now.Sub(joined); {
+		case age < 30*24*time.Hour:
+			add(SignalNewAccount)
+		case age < 180*24*time.Hour:
+			add(SignalYoungAccount)
+		}
+	}
+
+	if strings.TrimSpace(p.Bio) == "" && (p.Name == "" || p.Name == p.Username) {
+		add(SignalEmptyProfile)
+	}
+
+	return min(score, 1), signals
+}
+
+func hasDefaultAvatar(p *profile.Profile) bool {
+	avatar, ok := p.Field(profile.FieldAvatarURL)
+	if !ok {
+		// Most platforms do not report avatars at all, so absence is not a signal
+		return false
+	}
+	avatar = strings.ToLower(avatar)
+	for _, marker := range defaultAvatarMarkers {
+		if strings.Contains(avatar, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedUsername reports whether username looks machine-generated: a name with a
+// long numeric suffix, Reddit's Adjective_Noun_1234 pattern, or mostly digits.
+func generatedUsername(username string) bool {
+	if username == "" {
+		return false
+	}
+	if digitSuffixUsername.MatchString(username) || redditStyleUsername.MatchString(username) {
+		return true
+	}
+	digits := 0
+	for _, r := range username {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return len(username) >= 8 && digits*2 > len(username)
+}
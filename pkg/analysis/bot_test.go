@@ -0,0 +1,107 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestBotScore(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		p           *profile.Profile
+		wantSignals []string
+		wantScore   float64
+	}{
+		{
+			name: "established human",
+			p: &profile.Profile{
+				Username: "tstromberg", Name: "Thomas Strömberg", Bio: "Security engineer",
+				CreatedAt: "2009-03-01",
+				Fields:    map[string]string{profile.FieldFollowers: "900", profile.FieldFollowing: "120"},
+				Posts:     []profile.Post{{Type: profile.PostTypePost, Title: "hello"}},
+			},
+			wantSignals: nil,
+			wantScore:   0,
+		},
+		{
+			name: "fresh follow-spam account",
+			p: &profile.Profile{
+				Username:  "jessica84729301",
+				CreatedAt: "2024-12-20T00:00:00Z",
+				Fields: map[string]string{
+					profile.FieldFollowers: "3", profile.FieldFollowing: "4,100",
+					profile.FieldAvatarURL: "https://abs.twimg.com/sticky/default_profile_images/default_profile_normal.png",
+				},
+			},
+			wantSignals: []string{
+				SignalDefaultAvatar, SignalNoPosts, SignalFollowRatio,
+				SignalGeneratedUsername, SignalNewAccount, SignalEmptyProfile,
+			},
+			wantScore: 1,
+		},
+		{
+			name: "reddit throwaway",
+			p: &profile.Profile{
+				Username: "Quiet_Lemon_4821", Name: "Quiet_Lemon_4821", Bio: "just browsing",
+				CreatedAt: "2024-09-01",
+				Posts:     []profile.Post{{Type: profile.PostTypeComment, Content: "nice"}},
+			},
+			wantSignals: []string{SignalGeneratedUsername, SignalYoungAccount},
+			wantScore:   0.35,
+		},
+		{
+			name: "repositories count as activity",
+			p: &profile.Profile{
+				Username: "octocat", Name: "The Octocat",
+				Fields: map[string]string{profile.FieldRepositories: "8", profile.FieldFollowing: "5"},
+			},
+			wantSignals: []string{SignalNoFollowers},
+			wantScore:   0.1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, signals := botScore(tt.p, now)
+			if !slices.Equal(signals, tt.wantSignals) {
+				t.Errorf("signals = %v, want %v", signals, tt.wantSignals)
+			}
+			if diff := score - tt.wantScore; diff > 0.001 || diff < -0.001 {
+				t.Errorf("score = %.2f, want %.2f", score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestGeneratedUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"john84729301", true},
+		{"jane_doe_29317", true},
+		{"jane_doe_2931", false},
+		{"Quiet-Lemon-12", true},
+		{"83920174xx", true},
+		{"tstromberg", false},
+		{"user2", false},
+		{"dev1984", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := generatedUsername(tt.username); got != tt.want {
+			t.Errorf("generatedUsername(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotateBotScore(t *testing.T) {
+	p := &profile.Profile{Username: "someone", Name: "Some One", Bio: "hi"}
+	AnnotateBotScore(p)
+	if p.Fields[FieldBotScore] != "0.10" || p.Fields[FieldBotSignals] != SignalNoPosts {
+		t.Errorf("Fields = %v, want bot_score 0.10 with no_posts", p.Fields)
+	}
+}
@@ -0,0 +1,161 @@
+package analysis
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// FieldEmailEmployer is the Fields key InferEmployer sets to the company behind the
+// profile's email domain.
+const FieldEmailEmployer = "email_employer"
+
+// freemailDomains are shared mail providers; their domain says nothing about the mailbox owner.
+var freemailDomains = map[string]bool{
+	"aol.com": true, "fastmail.com": true, "gmail.com": true, "gmx.de": true, "gmx.net": true,
+	"googlemail.com": true, "hey.com": true, "hotmail.com": true, "icloud.com": true,
+	"live.com": true, "mac.com": true, "mail.ru": true, "me.com": true, "outlook.com": true,
+	"pm.me": true, "proton.me": true, "protonmail.com": true, "qq.com": true, "yahoo.com": true,
+	"yandex.ru": true, "zoho.com": true,
+	"users.noreply.github.com": true,
+}
+
+// IsFreemail reports whether domain is a shared mail provider such as gmail.com.
+func IsFreemail(domain string) bool {
+	return freemailDomains[strings.ToLower(strings.TrimSpace(domain))]
+}
+
+// companySuffixes are legal-form and GitHub-org suffixes NormalizeCompany removes.
+var companySuffixes = []string{
+	" incorporated", " inc", " llc", " ltd", " limited", " gmbh", " corporation", " corp", " co",
+	" plc", " ag", " sa", " bv", " oy", " ab", "-dev", "-org", "-io", "-labs", "-hq", " hq",
+}
+
+// NormalizeCompany reduces a company name to the form employers are compared in:
+// lowercase, without a leading "@", punctuation, or legal-form suffix, so
+// "@Chainguard, Inc." and "chainguard-dev" both become "chainguard". Alumni
+// nicknames become their company, so "Xoogler" is "google".
+func NormalizeCompany(name string) string {
+	s := strings.ToLower(strings.TrimSpace(name))
+	s = strings.TrimPrefix(s, "@")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ' || r == '-' {
+			return r
+		}
+		return ' '
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+	for trimmed := true; trimmed; {
+		trimmed = false
+		for _, suffix := range companySuffixes {
+			if rest, ok := strings.CutSuffix(s, suffix); ok && rest != "" {
+				s, trimmed = strings.TrimSpace(rest), true
+			}
+		}
+	}
+	if company, ok := alumniNicknames[strings.TrimSuffix(s, "s")]; ok {
+		return strings.ToLower(company)
+	}
+	return s
+}
+
+// CompanyProvider resolves an email domain to the name of the company that owns it.
+// Implementations may call enrichment services such as Clearbit; they return "" and a
+// nil error for domains they do not know.
+type CompanyProvider interface {
+	CompanyForDomain(ctx context.Context, domain string) (string, error)
+}
+
+// DomainCompany is the built-in CompanyProvider. It names the company after the
+// domain's registrable label, so "eng.chainguard.dev" yields "chainguard".
+type DomainCompany struct{}
+
+// CompanyForDomain implements CompanyProvider.
+func (DomainCompany) CompanyForDomain(_ context.Context, domain string) (string, error) {
+	labels := strings.Split(strings.Trim(strings.ToLower(domain), "."), ".")
+	if len(labels) < 2 {
+		return "", nil
+	}
+	label := labels[len(labels)-2]
+	// Country domains with a second level, like acme.co.uk
+	if len(labels) >= 3 && len(labels[len(labels)-1]) == 2 && slices.Contains([]string{"co", "com", "ac", "org", "net"}, label) {
+		label = labels[len(labels)-3]
+	}
+	return label, nil
+}
+
+// InferEmployer maps each non-freemail email address among profiles to a company with
+// provider and stores it in Fields["email_employer"] of the profile it was found on.
+// It then cross-checks the companies against every profile's employer (see
+// profile.Employer): a profile whose employer agrees with an email domain gets
+// "email_domain:<domain>" in GuessMatch. A nil provider means DomainCompany.
+// Provider errors stop the inference and are returned.
+func InferEmployer(ctx context.Context, provider CompanyProvider, profiles []*profile.Profile) error {
+	if provider == nil {
+		provider = DomainCompany{}
+	}
+
+	companies := make(map[string]string) // email domain -> company
+	var domains []string
+	for _, p := range profiles {
+		email, ok := p.Email()
+		if !ok {
+			continue
+		}
+		_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+		if domain == "" || IsFreemail(domain) {
+			continue
+		}
+		company, seen := companies[domain]
+		if !seen {
+			var err error
+			if company, err = provider.CompanyForDomain(ctx, domain); err != nil {
+				return err
+			}
+			companies[domain] = company
+			domains = append(domains, domain)
+		}
+		if company != "" {
+			if p.Fields == nil {
+				p.Fields = make(map[string]string)
+			}
+			p.Fields[FieldEmailEmployer] = company
+		}
+	}
+
+	for _, p := range profiles {
+		employer, ok := p.Employer()
+		if !ok {
+			continue
+		}
+		for _, domain := range domains {
+			match := "email_domain:" + domain
+			if companiesAgree(employer, companies[domain]) && !slices.Contains(p.GuessMatch, match) {
+				p.GuessMatch = append(p.GuessMatch, match)
+			}
+		}
+	}
+	return nil
+}
+
+// companiesAgree reports whether two company names refer to the same company, allowing
+// one to extend the other ("Google" and "Google Cloud").
+func companiesAgree(a, b string) bool {
+	a, b = NormalizeCompany(a), NormalizeCompany(b)
+	if len(a) < 2 || len(b) < 2 {
+		return false
+	}
+	if a == b {
+		return true
+	}
+	shorter, longer := a, b
+	if len(shorter) > len(longer) {
+		shorter, longer = longer, shorter
+	}
+	// Whole words only, so "meta" does not match "metabase"
+	pad := func(s string) string { return " " + strings.ReplaceAll(s, "-", " ") + " " }
+	return len(shorter) >= 3 && strings.Contains(pad(longer), pad(shorter))
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestNormalizeCompany(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"@Chainguard, Inc.", "chainguard"},
+		{"chainguard-dev", "chainguard"},
+		{"Acme Widgets GmbH", "acme widgets"},
+		{"Google LLC", "google"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeCompany(tt.in); got != tt.want {
+			t.Errorf("NormalizeCompany(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDomainCompany(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"chainguard.dev", "chainguard"},
+		{"eng.chainguard.dev", "chainguard"},
+		{"acme.co.uk", "acme"},
+		{"localhost", ""},
+	}
+	for _, tt := range tests {
+		got, err := DomainCompany{}.CompanyForDomain(context.Background(), tt.domain)
+		if err != nil || got != tt.want {
+			t.Errorf("CompanyForDomain(%q) = %q, %v; want %q", tt.domain, got, err, tt.want)
+		}
+	}
+}
+
+func TestCompaniesAgree(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Chainguard", "chainguard", true},
+		{"Google Cloud", "google", true},
+		{"Metabase", "meta", false},
+		{"Acme", "Initech", false},
+		{"", "acme", false},
+	}
+	for _, tt := range tests {
+		if got := companiesAgree(tt.a, tt.b); got != tt.want {
+			t.Errorf("companiesAgree(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+type fakeCompanies map[string]string
+
+func (f fakeCompanies) CompanyForDomain(_ context.Context, domain string) (string, error) {
+	if domain == "broken.example" {
+		return "", errors.New("provider down")
+	}
+	return f[domain], nil
+}
+
+func TestInferEmployer(t *testing.T) {
+	site := &profile.Profile{Platform: "generic", Fields: map[string]string{profile.FieldEmail: "alice@ABC.xyz"}}
+	gh := &profile.Profile{Platform: "github", Fields: map[string]string{profile.FieldCompany: "@alphabet"}}
+	li := &profile.Profile{Platform: "linkedin", Fields: map[string]string{profile.FieldEmployer: "Initech"}}
+	free := &profile.Profile{Platform: "gravatar", Fields: map[string]string{profile.FieldEmail: "alice@gmail.com"}}
+	profiles := []*profile.Profile{site, gh, li, free}
+
+	provider := fakeCompanies{"abc.xyz": "Alphabet Inc."}
+	for range 2 { // a second run must not duplicate matches
+		if err := InferEmployer(context.Background(), provider, profiles); err != nil {
+			t.Fatalf("InferEmployer() error = %v", err)
+		}
+	}
+
+	if got := site.Fields[FieldEmailEmployer]; got != "Alphabet Inc." {
+		t.Errorf("email_employer = %q, want %q", got, "Alphabet Inc.")
+	}
+	if !slices.Equal(gh.GuessMatch, []string{"email_domain:abc.xyz"}) {
+		t.Errorf("github GuessMatch = %v, want email_domain:abc.xyz", gh.GuessMatch)
+	}
+	if len(li.GuessMatch) != 0 {
+		t.Errorf("linkedin GuessMatch = %v, want none for a different employer", li.GuessMatch)
+	}
+	if _, ok := free.Fields[FieldEmailEmployer]; ok {
+		t.Error("freemail domain mapped to an employer")
+	}
+
+	broken := &profile.Profile{Fields: map[string]string{profile.FieldEmail: "bob@broken.example"}}
+	if err := InferEmployer(context.Background(), provider, []*profile.Profile{broken}); err == nil {
+		t.Error("InferEmployer() ignored a provider error")
+	}
+}
+
+func TestNormalizeCompanyNicknames(t *testing.T) {
+	if got := NormalizeCompany("Xooglers"); got != "google" {
+		t.Errorf("NormalizeCompany(Xooglers) = %q, want google", got)
+	}
+	if !companiesAgree("Googlers", "Google LLC") {
+		t.Error("companiesAgree(Googlers, Google LLC) = false")
+	}
+}
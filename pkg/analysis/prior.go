@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PriorEmployer is a company a bio or headline says someone used to work for.
+type PriorEmployer struct {
+	Company    string  `json:"company"`         // As written, or the company an alumni nickname names
+	Title      string  `json:"title,omitempty"` // The role held there, if stated
+	Confidence float64 `json:"confidence"`      // How surely the phrase means employment, 0.0-1.0
+}
+
+// Confidence of PriorEmployers matches by phrasing.
+const (
+	confidenceTitled   = 0.9  // "former Director @ Chainguard", or an alumni nickname
+	confidenceMarked   = 0.8  // "ex-Stripe", "formerly Google"
+	confidenceSpaced   = 0.6  // "ex Stripe", which is also ordinary prose
+	confidenceListStep = 0.05 // Lost per item after the first in "ex-Stripe/Uber/Lyft"
+)
+
+// alumniNicknames maps what former employees of some companies call themselves,
+// lowercased and in the singular, to the company.
+var alumniNicknames = map[string]string{
+	"xoogler":     "Google",
+	"googler":     "Google",
+	"amazonian":   "Amazon",
+	"microsoftie": "Microsoft",
+	"facebooker":  "Facebook",
+	"metamate":    "Meta",
+	"ibmer":       "IBM",
+	"yahooligan":  "Yahoo",
+	"hubber":      "GitHub",
+	"shopifolk":   "Shopify",
+}
+
+var (
+	// priorMarkerPattern matches the words that introduce former employers.
+	priorMarkerPattern = regexp.MustCompile(`(?i)\b(former(?:ly)?|previously|prev\b\.?|ex)(-|:?\s+)`)
+	// companyListPattern matches one or more companies joined by commas, slashes,
+	// "&", or "and", such as "Stripe, Uber & Lyft".
+	companyListPattern = regexp.MustCompile(`^(\p{Lu}` + companyTail + companyRest + `|\d` + companyTail + `)` +
+		`((?:\s*(?:,|/|&|\band\b)\s*(?:ex-?)?(?:\p{Lu}|\d)` + companyTail + companyRest + `)*)`)
+	// listSeparatorPattern splits the rest of a companyListPattern match.
+	listSeparatorPattern = regexp.MustCompile(`\s*(?:,|/|&|\band\b)\s*(?:ex-?)?`)
+	// nicknamePattern matches alumni nicknames that mean a former employee on their own.
+	nicknamePattern = regexp.MustCompile(`(?i)\bxooglers?\b`)
+)
+
+// PriorEmployers parses the companies a bio or headline says someone worked for
+// before, from phrases such as "ex-Stripe, ex-Uber", "former Director of Security @
+// Chainguard", "formerly Google & Microsoft", and alumni nicknames such as "Xoogler".
+// Each company is listed once, at its highest confidence, in order of appearance.
+func PriorEmployers(text string) []PriorEmployer {
+	var found []PriorEmployer
+	add := func(e PriorEmployer) {
+		if e.Company = resolveNickname(e.Company); e.Company == "" {
+			return
+		}
+		key := NormalizeCompany(e.Company)
+		if i := slices.IndexFunc(found, func(f PriorEmployer) bool { return NormalizeCompany(f.Company) == key }); i >= 0 {
+			if e.Confidence > found[i].Confidence {
+				found[i].Confidence = e.Confidence
+			}
+			if found[i].Title == "" {
+				found[i].Title = e.Title
+			}
+			return
+		}
+		found = append(found, e)
+	}
+
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return strings.ContainsRune("\n|·•;", r) }) {
+		for _, m := range priorMarkerPattern.FindAllStringSubmatchIndex(line, -1) {
+			rest := line[m[1]:]
+			if after, ok := strings.CutPrefix(rest, "at "); ok {
+				rest = after // "formerly at Google"
+			}
+			rest = strings.TrimPrefix(rest, "@")
+			// "former Director of Security @ Chainguard"
+			if r := roleAtPattern.FindStringSubmatch(rest); r != nil && r[1] == "" && r[5] == "" &&
+				roleWordPattern.MatchString(r[2]) && !strings.ContainsAny(r[2], ",;") {
+				add(PriorEmployer{Company: r[3] + r[4], Title: strings.TrimSpace(r[2]), Confidence: confidenceTitled})
+				continue
+			}
+			confidence := confidenceMarked
+			if marker := strings.ToLower(line[m[2]:m[3]]); marker == "ex" && line[m[4]:m[5]] != "-" {
+				confidence = confidenceSpaced
+			}
+			for i, company := range companyList(rest) {
+				add(PriorEmployer{Company: company, Confidence: confidence - float64(i)*confidenceListStep})
+			}
+		}
+		for range nicknamePattern.FindAllString(line, -1) {
+			add(PriorEmployer{Company: "Google", Confidence: confidenceTitled})
+		}
+	}
+	return found
+}
+
+// companyList returns the companies listed at the start of s.
+func companyList(s string) []string {
+	m := companyListPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil
+	}
+	companies := []string{m[1]}
+	for _, c := range listSeparatorPattern.Split(m[2], -1) {
+		// "ex-Stripe, Staff Engineer @ Acme" lists a role next
+		if c = strings.TrimSpace(c); c != "" && !roleWordPattern.MatchString(c) {
+			companies = append(companies, c)
+		}
+	}
+	return companies
+}
+
+// resolveNickname returns the company an alumni nickname such as "Googler" names,
+// or company itself.
+func resolveNickname(company string) string {
+	if c, ok := alumniNicknames[strings.TrimSuffix(strings.ToLower(company), "s")]; ok {
+		return c
+	}
+	return company
+}
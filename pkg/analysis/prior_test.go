@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"testing"
+)
+
+func TestPriorEmployers(t *testing.T) {
+	tests := []struct {
+		text string
+		want []PriorEmployer
+	}{
+		{"ex-Stripe, ex-Uber", []PriorEmployer{{Company: "Stripe", Confidence: 0.8}, {Company: "Uber", Confidence: 0.8}}},
+		{"former Director of Security @ Chainguard & Xoogler", []PriorEmployer{
+			{Company: "Chainguard", Title: "Director of Security", Confidence: 0.9},
+			{Company: "Google", Confidence: 0.9},
+		}},
+		{"Formerly Google & Microsoft. Now gardening", []PriorEmployer{
+			{Company: "Google", Confidence: 0.8}, {Company: "Microsoft", Confidence: 0.75},
+		}},
+		{"SRE | prev: Booking.com/Adyen", []PriorEmployer{{Company: "Booking.com", Confidence: 0.8}, {Company: "Adyen", Confidence: 0.75}}},
+		{"ex-Googler, formerly at Netflix", []PriorEmployer{{Company: "Google", Confidence: 0.8}, {Company: "Netflix", Confidence: 0.8}}},
+		{"ex Amazon, Staff Engineer @ Acme", []PriorEmployer{{Company: "Amazon", Confidence: 0.6}}},
+		{"Index funds and exercise; my ex-wife", nil},
+	}
+	for _, tt := range tests {
+		got := PriorEmployers(tt.text)
+		if len(got) != len(tt.want) {
+			t.Errorf("PriorEmployers(%q) = %+v, want %+v", tt.text, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("PriorEmployers(%q)[%d] = %+v, want %+v", tt.text, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
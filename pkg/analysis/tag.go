@@ -0,0 +1,135 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Fields keys set by ApplyTags.
+const (
+	FieldTopics    = "topics"    // Comma-separated topic tags, most prominent first
+	FieldSentiment = "sentiment" // Overall sentiment from -1.00 (negative) to 1.00 (positive)
+)
+
+// maxTopics limits how many topics ApplyTags records.
+const maxTopics = 10
+
+// Tags are the topics and optional sentiment a Tagger found in a profile's text.
+type Tags struct {
+	Sentiment *float64 // -1 to 1, or nil if the Tagger does not measure sentiment
+	Topics    []string // Topic names, most prominent first
+}
+
+// Tagger attaches topic tags to a profile's bio and posts. Implementations may call
+// out to ML services; KeywordTagger is the built-in default.
+type Tagger interface {
+	Tag(ctx context.Context, bio string, posts []profile.Post) (Tags, error)
+}
+
+// ApplyTags runs t over p's bio and posts and stores the results in
+// Fields["topics"] and, if measured, Fields["sentiment"].
+func ApplyTags(ctx context.Context, t Tagger, p *profile.Profile) error {
+	tags, err := t.Tag(ctx, p.Bio, p.Posts)
+	if err != nil {
+		return err
+	}
+	if p.Fields == nil {
+		p.Fields = make(map[string]string)
+	}
+	if topics := tags.Topics[:min(len(tags.Topics), maxTopics)]; len(topics) > 0 {
+		p.Fields[FieldTopics] = strings.Join(topics, ", ")
+	}
+	if tags.Sentiment != nil {
+		p.Fields[FieldSentiment] = fmt.Sprintf("%.2f", *tags.Sentiment)
+	}
+	return nil
+}
+
+// DefaultTopics maps topic names to the keywords KeywordTagger looks for.
+// Keywords match whole words case-insensitively; punctuation is ignored, so
+// "node.js" also matches "Node JS".
+var DefaultTopics = map[string][]string{
+	"kubernetes":       {"kubernetes", "k8s", "kubectl", "helm"},
+	"golang":           {"golang", "gopher", "go developer"},
+	"python":           {"python", "django", "flask", "pandas"},
+	"rust":             {"rust", "rustlang", "rustacean"},
+	"javascript":       {"javascript", "typescript", "node.js", "react", "vue"},
+	"security":         {"security", "infosec", "appsec", "pentest", "pentester", "cve", "malware"},
+	"machine-learning": {"machine learning", "deep learning", "llm", "pytorch", "tensorflow", "ai"},
+	"devops":           {"devops", "sre", "terraform", "ci/cd", "observability"},
+	"cloud":            {"aws", "gcp", "azure", "cloud native"},
+	"open-source":      {"open source", "opensource", "oss", "maintainer"},
+	"data":             {"data engineer", "data scientist", "data science", "analytics", "sql"},
+	"design":           {"designer", "ux", "figma", "typography"},
+	"photography":      {"photography", "photographer", "photos", "lightroom", "35mm"},
+	"music":            {"music", "musician", "guitar", "synth", "producer"},
+	"gaming":           {"gaming", "gamer", "speedrun", "esports", "gamedev"},
+	"crypto":           {"bitcoin", "ethereum", "crypto", "web3", "nft"},
+}
+
+// KeywordTagger tags text by counting topic keywords. It does not measure sentiment.
+type KeywordTagger struct {
+	keywords map[string][]string // topic -> normalized keywords
+}
+
+// NewKeywordTagger returns a KeywordTagger for topics, or for DefaultTopics if
+// topics is nil.
+func NewKeywordTagger(topics map[string][]string) *KeywordTagger {
+	if topics == nil {
+		topics = DefaultTopics
+	}
+	k := &KeywordTagger{keywords: make(map[string][]string, len(topics))}
+	for topic, words := range topics {
+		for _, w := range words {
+			if n := normalizeWords(w); n != "" {
+				k.keywords[topic] = append(k.keywords[topic], n)
+			}
+		}
+	}
+	return k
+}
+
+// Tag returns the topics whose keywords appear in bio or posts, ordered by how often
+// they appear. A keyword in the bio counts as much as three in posts.
+func (k *KeywordTagger) Tag(_ context.Context, bio string, posts []profile.Post) (Tags, error) {
+	bioText := " " + normalizeWords(bio) + " "
+	var postText strings.Builder
+	for _, post := range posts {
+		postText.WriteString(" " + normalizeWords(post.Title+" "+post.Content+" "+post.Category) + " ")
+	}
+	postsText := postText.String()
+
+	hits := make(map[string]int)
+	for topic, words := range k.keywords {
+		for _, w := range words {
+			hits[topic] += 3*strings.Count(bioText, " "+w+" ") + strings.Count(postsText, " "+w+" ")
+		}
+	}
+
+	var topics []string
+	for topic, n := range hits {
+		if n > 0 {
+			topics = append(topics, topic)
+		}
+	}
+	sort.Slice(topics, func(i, j int) bool {
+		if hits[topics[i]] != hits[topics[j]] {
+			return hits[topics[i]] > hits[topics[j]]
+		}
+		return topics[i] < topics[j]
+	})
+	return Tags{Topics: topics}, nil
+}
+
+// normalizeWords lowercases s and separates its words with single spaces, treating
+// everything but letters and digits as a separator.
+func normalizeWords(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestKeywordTagger(t *testing.T) {
+	tagger := NewKeywordTagger(nil)
+	tests := []struct {
+		name  string
+		bio   string
+		posts []profile.Post
+		want  []string
+	}{
+		{
+			name: "bio outweighs posts",
+			bio:  "SRE. Kubernetes & Helm charts. Weekend photographer.",
+			posts: []profile.Post{
+				{Title: "Shooting 35mm film"},
+				{Content: "My photos from Iceland"},
+				{Content: "Lightroom presets"},
+			},
+			want: []string{"kubernetes", "photography", "devops"},
+		},
+		{
+			name: "punctuation is ignored",
+			bio:  "Building with Node.JS and CI/CD pipelines",
+			want: []string{"devops", "javascript"},
+		},
+		{
+			name: "whole words only",
+			bio:  "Trustworthy goalkeeper, loves gophers",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := tagger.Tag(context.Background(), tt.bio, tt.posts)
+			if err != nil {
+				t.Fatalf("Tag() error = %v", err)
+			}
+			if !slices.Equal(tags.Topics, tt.want) {
+				t.Errorf("Topics = %v, want %v", tags.Topics, tt.want)
+			}
+			if tags.Sentiment != nil {
+				t.Errorf("Sentiment = %v, want nil", *tags.Sentiment)
+			}
+		})
+	}
+}
+
+type fakeTagger struct {
+	tags Tags
+	err  error
+}
+
+func (f fakeTagger) Tag(context.Context, string, []profile.Post) (Tags, error) { return f.tags, f.err }
+
+func TestApplyTags(t *testing.T) {
+	ctx := context.Background()
+	sentiment := 0.25
+	p := &profile.Profile{}
+	if err := ApplyTags(ctx, fakeTagger{tags: Tags{Topics: []string{"rust", "music"}, Sentiment: &sentiment}}, p); err != nil {
+		t.Fatalf("ApplyTags() error = %v", err)
+	}
+	if p.Fields[FieldTopics] != "rust, music" || p.Fields[FieldSentiment] != "0.25" {
+		t.Errorf("Fields = %v, want topics and sentiment", p.Fields)
+	}
+
+	boom := errors.New("model unavailable")
+	if err := ApplyTags(ctx, fakeTagger{err: boom}, &profile.Profile{}); !errors.Is(err, boom) {
+		t.Errorf("ApplyTags() error = %v, want %v", err, boom)
+	}
+}
@@ -0,0 +1,205 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Timeline sources, the kind part of Position.Sources entries.
+const (
+	SourceExperience    = "experience"    // A résumé-style position, as on LinkedIn
+	SourceEmployer      = "employer"      // The profile's employer or company field
+	SourceOrganizations = "organizations" // Membership of a GitHub organization
+	SourceBio           = "bio"           // A phrase in the bio or headline
+)
+
+// minTimelineConfidence is the least confidence a guessed profile needs to contribute
+// to a Timeline.
+const minTimelineConfidence = 0.6
+
+// Position is one job in a Timeline.
+type Position struct {
+	Organization string   `json:"organization"`
+	Title        string   `json:"title,omitempty"`
+	Start        string   `json:"start,omitempty"`   // YYYY, YYYY-MM, or YYYY-MM-DD
+	End          string   `json:"end,omitempty"`     // Same forms; empty if current or unknown
+	Current      bool     `json:"current,omitempty"` // Whether a source says the job is held now
+	Sources      []string `json:"sources"`           // Where it was found, as "<platform>:<source>"
+}
+
+// Timeline is a best-effort employment history of one person, reconstructed from
+// the profiles found for them.
+type Timeline struct {
+	// Positions holds current jobs first, then the rest by when they ended, most
+	// recent first; past jobs known only from a bio, without dates, come last.
+	Positions []Position `json:"positions"`
+}
+
+// companyTail and companyRest complete a company name after its first letter: the
+// rest of a word, then any capitalized words, as in "Chainguard" or "Acme Widgets".
+// Dots may only join word characters, as in "Booking.com", so a sentence's final dot
+// is left out.
+const (
+	companyTail = `(?:[\w&'-]|\.\w)*`
+	companyRest = `(?:\s+[\p{Lu}\d]` + companyTail + `)*`
+)
+
+// roleAtPattern matches "<title> @ <Company>" and "<title> at <Company>" bio and
+// headline clauses, such as "founder & CEO @ codeGROOVE". The company must be
+// capitalized after "at", which is also ordinary prose.
+var roleAtPattern = regexp.MustCompile(`^(?i:(ex|former|formerly|previously|prev)\b[-\s]*)?(.*?)\s+(?:@\s*([\p{L}\d]` +
+	companyTail + companyRest + `)|at\s+(\p{Lu}` + companyTail + companyRest + `))(@?)`)
+
+// roleWordPattern matches words that make the text before "@ Company" a job title
+// rather than, say, "Follow me".
+var roleWordPattern = regexp.MustCompile(`(?i)\b(?:engineer|eng|developer|dev|swe|sre|founder|co-?founder|ceo|cto|cfo|coo|cso|ciso|vp|director|manager|lead|head|architect|scientist|researcher|designer|product|intern|consultant|partner|principal|staff|analyst|advocate|maintainer|president|officer|chief|professor|editor|writer|security|ops|programmer|hacker)s?\b`)
+
+// bioClauses splits a bio into the clauses positions are looked for in.
+var bioClauses = regexp.MustCompile(`[\n|·•;,]+|\.\s+`)
+
+// BuildTimeline reconstructs an employment timeline from profiles believed to belong
+// to one person, such as the results of a recursive fetch. Positions come from
+// Experience entries, then are confirmed or added by employer fields and by bio and
+// headline phrases such as "CEO @ Acme" and those PriorEmployers parses. GitHub
+// organizations only confirm positions found otherwise, as membership alone does not
+// mean employment. Guessed profiles below 0.6 confidence are skipped.
+func BuildTimeline(profiles []*profile.Profile) Timeline {
+	var profs []*profile.Profile
+	for _, p := range profiles {
+		if p != nil && p.Error == "" && (!p.IsGuess || p.Confidence >= minTimelineConfidence) {
+			profs = append(profs, p)
+		}
+	}
+
+	t := &Timeline{}
+	for _, p := range profs {
+		for _, e := range p.Experience {
+			if strings.TrimSpace(e.Organization) == "" {
+				continue
+			}
+			t.addExperience(p.Platform, e)
+		}
+	}
+	for _, p := range profs {
+		if employer, ok := p.Employer(); ok {
+			t.add(Position{Organization: strings.TrimPrefix(employer, "@"), Current: true}, p.Platform+":"+SourceEmployer)
+		}
+		text := p.Bio
+		if headline, ok := p.Field(profile.FieldHeadline); ok {
+			text += "\n" + headline
+		}
+		for _, pos := range bioPositions(text) {
+			t.add(pos, p.Platform+":"+SourceBio)
+		}
+	}
+	for _, p := range profs {
+		for org := range strings.SplitSeq(p.Fields["organizations"], ",") {
+			if org = strings.TrimSpace(org); org != "" {
+				t.confirm(org, p.Platform+":"+SourceOrganizations)
+			}
+		}
+	}
+
+	sort.SliceStable(t.Positions, func(i, j int) bool {
+		return positionRank(t.Positions[i]) > positionRank(t.Positions[j])
+	})
+	if t.Positions == nil {
+		t.Positions = []Position{}
+	}
+	return *t
+}
+
+// addExperience adds a résumé position, merging it with the same position from
+// another platform.
+func (t *Timeline) addExperience(platform string, e profile.Experience) {
+	source := platform + ":" + SourceExperience
+	for i := range t.Positions {
+		p := &t.Positions[i]
+		if p.Start == e.Start && companiesAgree(p.Organization, e.Organization) {
+			p.Sources = appendSource(p.Sources, source)
+			return
+		}
+	}
+	t.Positions = append(t.Positions, Position{
+		Organization: e.Organization,
+		Title:        e.Title,
+		Start:        e.Start,
+		End:          e.End,
+		Current:      e.End == "",
+		Sources:      []string{source},
+	})
+}
+
+// add merges pos into the most recent position at the same organization, filling in
+// its title, or adds it if there is none.
+func (t *Timeline) add(pos Position, source string) {
+	if p := t.latest(pos.Organization); p != nil {
+		if p.Title == "" {
+			p.Title = pos.Title
+		}
+		p.Sources = appendSource(p.Sources, source)
+		return
+	}
+	pos.Sources = []string{source}
+	t.Positions = append(t.Positions, pos)
+}
+
+// confirm adds source to the most recent position at org, if there is one.
+func (t *Timeline) confirm(org, source string) {
+	if p := t.latest(org); p != nil {
+		p.Sources = appendSource(p.Sources, source)
+	}
+}
+
+// latest returns the most recent position at org, or nil.
+func (t *Timeline) latest(org string) *Position {
+	var best *Position
+	for i := range t.Positions {
+		p := &t.Positions[i]
+		if companiesAgree(p.Organization, org) && (best == nil || positionRank(*p) > positionRank(*best)) {
+			best = p
+		}
+	}
+	return best
+}
+
+// positionRank orders positions for Timeline: current first, then by end and start.
+func positionRank(p Position) string {
+	switch {
+	case p.Current:
+		return "3" + p.Start
+	case p.End != "" || p.Start != "":
+		return "2" + p.End + "/" + p.Start
+	default:
+		return "1"
+	}
+}
+
+func appendSource(sources []string, source string) []string {
+	for _, s := range sources {
+		if s == source {
+			return sources
+		}
+	}
+	return append(sources, source)
+}
+
+// bioPositions returns the positions a bio or headline states: current ones for
+// "<title> @ <Company>", and past ones as PriorEmployers finds them.
+func bioPositions(text string) []Position {
+	var positions []Position
+	for _, clause := range bioClauses.Split(text, -1) {
+		// A fediverse handle ("@alice@hachyderm.io") is not a company
+		m := roleAtPattern.FindStringSubmatch(strings.TrimSpace(clause))
+		if m != nil && m[1] == "" && m[5] == "" && roleWordPattern.MatchString(m[2]) && !priorMarkerPattern.MatchString(m[2]) {
+			positions = append(positions, Position{Organization: m[3] + m[4], Title: strings.TrimSpace(m[2]), Current: true})
+		}
+	}
+	for _, e := range PriorEmployers(text) {
+		positions = append(positions, Position{Organization: e.Company, Title: e.Title})
+	}
+	return positions
+}
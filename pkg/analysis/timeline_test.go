@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	profiles := []*profile.Profile{
+		{
+			Platform: "linkedin",
+			Fields:   map[string]string{profile.FieldHeadline: "Founder at CodeGROOVE"},
+			Experience: []profile.Experience{
+				{Title: "Founder", Organization: "codeGROOVE", Start: "2023-02"},
+				{Title: "Director of Security", Organization: "Chainguard, Inc.", Start: "2021-09", End: "2023-01"},
+				{Title: "Staff Engineer", Organization: "Google", Start: "2015", End: "2021-08"},
+			},
+		},
+		{
+			Platform: "mastodon",
+			Bio:      "founder & CEO @ codeGROOVE\nformer Director of Security @ Chainguard & Xoogler\nex-VMware. Follow @alice@hachyderm.io",
+		},
+		{
+			Platform: "github",
+			Fields:   map[string]string{profile.FieldCompany: "@codeGROOVE-dev", "organizations": "chainguard-dev, kubernetes"},
+		},
+		{Platform: "twitter", Bio: "CEO @ Elsewhere", IsGuess: true, Confidence: 0.3},
+	}
+
+	got := BuildTimeline(profiles).Positions
+	want := []Position{
+		{Organization: "codeGROOVE", Title: "Founder", Start: "2023-02", Current: true,
+			Sources: []string{"linkedin:experience", "linkedin:bio", "mastodon:bio", "github:employer"}},
+		{Organization: "Chainguard, Inc.", Title: "Director of Security", Start: "2021-09", End: "2023-01",
+			Sources: []string{"linkedin:experience", "mastodon:bio", "github:organizations"}},
+		{Organization: "Google", Title: "Staff Engineer", Start: "2015", End: "2021-08",
+			Sources: []string{"linkedin:experience", "mastodon:bio"}},
+		{Organization: "VMware", Sources: []string{"mastodon:bio"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("BuildTimeline() = %+v, want %d positions", got, len(want))
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.Organization != w.Organization || g.Title != w.Title || g.Start != w.Start || g.End != w.End ||
+			g.Current != w.Current || !slices.Equal(g.Sources, w.Sources) {
+			t.Errorf("position %d = %+v, want %+v", i, g, w)
+		}
+	}
+
+	if got := BuildTimeline(nil).Positions; got == nil || len(got) != 0 {
+		t.Errorf("BuildTimeline(nil) = %v, want an empty list", got)
+	}
+}
+
+func TestBioPositions(t *testing.T) {
+	tests := []struct {
+		bio  string
+		want []Position
+	}{
+		{"Staff Engineer @chainguard-dev", []Position{{Organization: "chainguard-dev", Title: "Staff Engineer", Current: true}}},
+		{"ex-Stripe, ex-Uber", []Position{{Organization: "Stripe"}, {Organization: "Uber"}}},
+		{"Follow me @ Home", nil},
+		{"Engineer at heart", nil},
+		{"mail me@example.com", nil},
+	}
+	for _, tt := range tests {
+		if got := bioPositions(tt.bio); !slices.EqualFunc(got, tt.want, func(a, b Position) bool {
+			return a.Organization == b.Organization && a.Title == b.Title && a.Current == b.Current
+		}) {
+			t.Errorf("bioPositions(%q) = %+v, want %+v", tt.bio, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package audit records the outcome of every profile fetch as JSON lines, so failed
+// extractions can be found and replayed against newer parsers.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Statuses of an Entry.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Entry is one fetch.
+type Entry struct {
+	Time     time.Time `json:"time"`
+	URL      string    `json:"url"`
+	Platform string    `json:"platform"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	Name     string    `json:"name,omitempty"` // Display name extracted, if any
+	Fields   int       `json:"fields"`         // Number of Fields extracted
+	Links    int       `json:"links"`          // Number of SocialLinks extracted
+	Elapsed  float64   `json:"elapsed_ms"`
+}
+
+// Failed reports whether the fetch returned an error or extracted no data at all.
+func (e *Entry) Failed() bool {
+	return e.Status != StatusOK || e.Name == "" && e.Fields == 0 && e.Links == 0
+}
+
+// Log appends entries to a file. It is safe for concurrent use.
+type Log struct {
+	w  io.WriteCloser
+	mu sync.Mutex
+}
+
+// Open opens the audit log at path for appending, creating it if needed.
+func Open(path string) (*Log, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{w: f}, nil
+}
+
+// Record appends e to the log.
+func (l *Log) Record(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.w.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	return l.w.Close()
+}
+
+// Read parses the entries of an audit log, skipping lines that are not entries.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.URL == "" {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// ReadFile parses the audit log at path.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // read-only file
+	return Read(f)
+}
+
+// Filter selects entries to replay. The zero value selects everything.
+type Filter struct {
+	Since    time.Time // Only entries at or after this time
+	Platform string    // Only entries for this platform
+	URL      string    // Only entries whose URL contains this
+	Failed   bool      // Only failed entries
+}
+
+// Match reports whether e passes f.
+func (f Filter) Match(e *Entry) bool {
+	switch {
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case f.Platform != "" && !strings.EqualFold(f.Platform, e.Platform):
+		return false
+	case f.URL != "" && !strings.Contains(e.URL, f.URL):
+		return false
+	case f.Failed && !e.Failed():
+		return false
+	}
+	return true
+}
+
+// Select returns the entries matching f, keeping only the latest entry for each URL
+// so a URL that failed repeatedly is replayed once.
+func Select(entries []Entry, f Filter) []Entry {
+	latest := make(map[string]int)
+	var selected []Entry
+	for i := range entries {
+		e := &entries[i]
+		if j, ok := latest[e.URL]; ok {
+			if !e.Time.Before(selected[j].Time) {
+				selected[j] = *e
+			}
+			continue
+		}
+		latest[e.URL] = len(selected)
+		selected = append(selected, *e)
+	}
+	kept := selected[:0]
+	for i := range selected {
+		if f.Match(&selected[i]) {
+			kept = append(kept, selected[i])
+		}
+	}
+	return kept
+}
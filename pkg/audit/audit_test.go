@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := Entry{Time: when, URL: "https://github.com/alice", Platform: "github", Status: StatusOK, Name: "Alice", Fields: 3, Links: 2, Elapsed: 12.5}
+	if err := l.Record(want); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Reopening appends
+	l, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if err := l.Record(Entry{Time: when, URL: "https://twitter.com/alice", Status: StatusError, Error: "boom"}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0] != want || entries[1].Error != "boom" {
+		t.Errorf("ReadFile() = %+v", entries)
+	}
+}
+
+func TestRead(t *testing.T) {
+	entries, err := Read(strings.NewReader(`{"url":"https://github.com/alice","status":"ok"}
+not json
+{"status":"ok"}
+`))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("Read() = %+v, %v; want the one entry with a URL", entries, err)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	entries := []Entry{
+		{Time: day(1), URL: "https://github.com/alice", Platform: "github", Status: StatusError},
+		{Time: day(2), URL: "https://github.com/alice", Platform: "github", Status: StatusOK, Name: "Alice"},
+		{Time: day(3), URL: "https://www.linkedin.com/in/bob", Platform: "linkedin", Status: StatusOK},
+		{Time: day(1), URL: "https://twitter.com/carol", Platform: "twitter", Status: StatusError, Error: "rate limited"},
+	}
+	urls := func(es []Entry) string {
+		var s []string
+		for _, e := range es {
+			s = append(s, e.URL)
+		}
+		return strings.Join(s, " ")
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"all, latest per URL", Filter{}, "https://github.com/alice https://www.linkedin.com/in/bob https://twitter.com/carol"},
+		{"failed, including empty extractions", Filter{Failed: true}, "https://www.linkedin.com/in/bob https://twitter.com/carol"},
+		{"platform", Filter{Platform: "GitHub"}, "https://github.com/alice"},
+		{"url", Filter{URL: "carol"}, "https://twitter.com/carol"},
+		{"since", Filter{Since: day(2)}, "https://github.com/alice https://www.linkedin.com/in/bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := append([]Entry(nil), entries...)
+			if got := urls(Select(in, tt.filter)); got != tt.want {
+				t.Errorf("Select() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+//go:build js
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+)
+
+// BrowserSource reads cookies from browser cookie stores. In the browser there are no
+// cookie stores to read, so it never returns cookies: the page's own session is used.
+type BrowserSource struct{}
+
+// NewBrowserSource creates a new browser cookie source.
+func NewBrowserSource(*slog.Logger) *BrowserSource { return &BrowserSource{} }
+
+// Cookies returns no cookies.
+func (*BrowserSource) Cookies(context.Context, string) (map[string]string, error) {
+	return nil, nil //nolint:nilnil // no cookies is not an error
+}
@@ -17,11 +17,14 @@ import (
 
 const platform = "bilibili"
 
+// numericPathPattern matches a numeric path segment, as in user IDs.
+var numericPathPattern = regexp.MustCompile(`/\d+`)
+
 // Match returns true if the URL is a Bilibili user profile URL.
 func Match(urlStr string) bool {
 	lower := strings.ToLower(urlStr)
 	return strings.Contains(lower, "space.bilibili.com/") ||
-		strings.Contains(lower, "bilibili.com/") && regexp.MustCompile(`/\d+`).MatchString(lower)
+		strings.Contains(lower, "bilibili.com/") && numericPathPattern.MatchString(lower)
 }
 
 // AuthRequired returns false because Bilibili profiles are public (but may have bot detection).
@@ -91,6 +94,22 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), normalizedURL, userID)
 }
 
+// Parse extracts a profile from a Bilibili space page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	userID := extractUserID(urlStr)
+	if userID == "" {
+		return nil, fmt.Errorf("could not extract user ID from: %s", urlStr)
+	}
+	return parseProfile(string(data), fmt.Sprintf("https://space.bilibili.com/%s", userID), userID)
+}
+
+// Counts on a space page, labelled in Chinese or English.
+var (
+	followerPattern  = regexp.MustCompile(`(\d+(?:\.\d+)?[万千]?)\s*(?:粉丝|fans)`) //nolint:gosmopolitan // Chinese text is intentional for Bilibili
+	followingPattern = regexp.MustCompile(`(\d+)\s*(?:关注|following)`)           //nolint:gosmopolitan // Chinese text is intentional for Bilibili
+	videoPattern     = regexp.MustCompile(`(\d+)\s*(?:投稿|videos)`)              //nolint:gosmopolitan // Chinese text is intentional for Bilibili
+)
+
 func parseProfile(html, url, userID string) (*profile.Profile, error) {
 	prof := &profile.Profile{
 		Platform: platform,
@@ -113,21 +132,18 @@ func parseProfile(html, url, userID string) (*profile.Profile, error) {
 	prof.Bio = htmlutil.Description(html)
 
 	// Try to extract follower count (粉丝)
-	followerPattern := regexp.MustCompile(`(\d+(?:\.\d+)?[万千]?)\s*(?:粉丝|fans)`) //nolint:gosmopolitan // Chinese text is intentional for Bilibili
 	if matches := followerPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["followers"] = matches[1]
+		prof.Fields[profile.FieldFollowers] = matches[1]
 	}
 
 	// Try to extract following count (关注)
-	followingPattern := regexp.MustCompile(`(\d+)\s*(?:关注|following)`) //nolint:gosmopolitan // Chinese text is intentional for Bilibili
 	if matches := followingPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["following"] = matches[1]
+		prof.Fields[profile.FieldFollowing] = matches[1]
 	}
 
 	// Try to extract video count
-	videoPattern := regexp.MustCompile(`(\d+)\s*(?:投稿|videos)`) //nolint:gosmopolitan // Chinese text is intentional for Bilibili
 	if matches := videoPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["videos"] = matches[1]
+		prof.Fields[profile.FieldVideos] = matches[1]
 	}
 
 	// Extract social links
@@ -150,14 +166,16 @@ func parseProfile(html, url, userID string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// userIDPattern matches the numeric user ID in a space or short profile URL.
+var userIDPattern = regexp.MustCompile(`(?:space\.)?bilibili\.com/(\d+)`)
+
 func extractUserID(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
 	urlStr = strings.TrimPrefix(urlStr, "http://")
 
 	// Extract space.bilibili.com/12345 pattern
-	re := regexp.MustCompile(`(?:space\.)?bilibili\.com/(\d+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := userIDPattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -0,0 +1,174 @@
+// Package blob stores large profile text out of line, as content-addressed blobs.
+//
+// Personal sites' Unstructured markdown and long post bodies can make a profile many
+// times larger than everything else on it. Offload moves such text into a Store and
+// leaves a reference in its place (Profile.UnstructuredRef, Post.ContentRef), so crawl
+// state held in memory and profile logs stay small; Inline puts it back. References
+// name the SHA-256 of the content, so repeated text is stored once and a blob never
+// changes once written.
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// DefaultMinSize is the size in bytes from which Offload moves text out of line.
+// Shorter text costs less inline than its reference and lookup.
+const DefaultMinSize = 4 << 10
+
+const refPrefix = "sha256:"
+
+// ErrNotFound is returned by Store.Get for a reference with no blob.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs by reference. Implementations are safe for concurrent use.
+type Store interface {
+	// Put stores data and returns its reference, Ref(data).
+	Put(ctx context.Context, data []byte) (string, error)
+	// Get returns the blob stored under ref, or ErrNotFound.
+	Get(ctx context.Context, ref string) ([]byte, error)
+}
+
+// Ref returns the reference of data: "sha256:" and its hex-encoded SHA-256.
+func Ref(data []byte) string {
+	sum := sha256.Sum256(data)
+	return refPrefix + hex.EncodeToString(sum[:])
+}
+
+// FS is a Store keeping each blob in a file under a directory, named by its hash
+// and fanned out into subdirectories by its first two hex digits.
+type FS struct {
+	root string
+}
+
+var _ Store = (*FS)(nil)
+
+// NewFS returns a Store rooted at dir, creating it if needed.
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FS{root: dir}, nil
+}
+
+// path returns the file holding ref, or an error if ref is malformed.
+func (s *FS) path(ref string) (string, error) {
+	hash, ok := strings.CutPrefix(ref, refPrefix)
+	if _, err := hex.DecodeString(hash); !ok || err != nil || len(hash) != 2*sha256.Size {
+		return "", fmt.Errorf("invalid blob reference %q", ref)
+	}
+	return filepath.Join(s.root, hash[:2], hash[2:]), nil
+}
+
+// Put writes data unless a blob with the same content already exists.
+func (s *FS) Put(_ context.Context, data []byte) (string, error) {
+	ref := Ref(data)
+	path, err := s.path(ref)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return ref, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	// Written aside and renamed, so a blob is either complete or absent
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()           //nolint:errcheck // already failing
+		_ = os.Remove(tmp.Name()) //nolint:errcheck // already failing
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name()) //nolint:errcheck // already failing
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name()) //nolint:errcheck // already failing
+		return "", err
+	}
+	return ref, nil
+}
+
+// Get reads the blob stored under ref.
+func (s *FS) Get(_ context.Context, ref string) ([]byte, error) {
+	path, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, ref)
+	}
+	return data, err
+}
+
+// Offload returns a copy of p whose Unstructured text and post contents of at least
+// minSize bytes are stored in s and replaced by references. p itself is left as is,
+// so a caller still working with the full text can keep the copy for later. A
+// minSize of zero or less means DefaultMinSize.
+func Offload(ctx context.Context, s Store, p *profile.Profile, minSize int) (*profile.Profile, error) {
+	if minSize <= 0 {
+		minSize = DefaultMinSize
+	}
+	out := *p
+	if len(out.Unstructured) >= minSize {
+		ref, err := s.Put(ctx, []byte(out.Unstructured))
+		if err != nil {
+			return nil, fmt.Errorf("offloading unstructured text of %s: %w", p.URL, err)
+		}
+		out.Unstructured, out.UnstructuredRef = "", ref
+	}
+	copied := false
+	for i := range out.Posts {
+		if len(out.Posts[i].Content) < minSize {
+			continue
+		}
+		if !copied {
+			out.Posts = append([]profile.Post(nil), out.Posts...)
+			copied = true
+		}
+		ref, err := s.Put(ctx, []byte(out.Posts[i].Content))
+		if err != nil {
+			return nil, fmt.Errorf("offloading post content of %s: %w", p.URL, err)
+		}
+		out.Posts[i].Content, out.Posts[i].ContentRef = "", ref
+	}
+	return &out, nil
+}
+
+// Inline reads the blobs p references back from s into the text they replaced, and
+// clears the references.
+func Inline(ctx context.Context, s Store, p *profile.Profile) error {
+	if p.UnstructuredRef != "" {
+		data, err := s.Get(ctx, p.UnstructuredRef)
+		if err != nil {
+			return err
+		}
+		p.Unstructured, p.UnstructuredRef = string(data), ""
+	}
+	for i := range p.Posts {
+		if p.Posts[i].ContentRef == "" {
+			continue
+		}
+		data, err := s.Get(ctx, p.Posts[i].ContentRef)
+		if err != nil {
+			return err
+		}
+		p.Posts[i].Content, p.Posts[i].ContentRef = string(data), ""
+	}
+	return nil
+}
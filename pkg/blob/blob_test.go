@@ -0,0 +1,81 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestFS(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := s.Put(ctx, []byte("hello"))
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if want := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"; ref != want {
+		t.Errorf("Put() = %s, want %s", ref, want)
+	}
+	if again, err := s.Put(ctx, []byte("hello")); err != nil || again != ref {
+		t.Errorf("Put() of the same content = %s, %v; want %s", again, err, ref)
+	}
+	if data, err := s.Get(ctx, ref); err != nil || string(data) != "hello" {
+		t.Errorf("Get() = %q, %v; want hello", data, err)
+	}
+	if _, err := s.Get(ctx, Ref([]byte("other"))); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() of a missing blob error = %v, want ErrNotFound", err)
+	}
+	for _, bad := range []string{"", "md5:abc", "sha256:../../etc/passwd", "sha256:zz"} {
+		if _, err := s.Get(ctx, bad); err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want an invalid reference error", bad, err)
+		}
+	}
+}
+
+func TestOffloadInline(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := strings.Repeat("# Alice\n\nAbout me. ", 300)
+	p := &profile.Profile{
+		URL:          "https://alice.dev/",
+		Bio:          "short bio stays",
+		Unstructured: page,
+		Posts: []profile.Post{
+			{Type: profile.PostTypeArticle, Title: "Long", Content: page},
+			{Type: profile.PostTypeArticle, Title: "Short", Content: "a teaser"},
+		},
+	}
+
+	small, err := Offload(ctx, s, p, 0)
+	if err != nil {
+		t.Fatalf("Offload() error = %v", err)
+	}
+	if small.Unstructured != "" || small.UnstructuredRef != Ref([]byte(page)) {
+		t.Errorf("Offload() Unstructured = %q, ref %q", small.Unstructured, small.UnstructuredRef)
+	}
+	if small.Posts[0].Content != "" || small.Posts[0].ContentRef != small.UnstructuredRef {
+		t.Errorf("Offload() long post = %+v, want its content replaced by the shared reference", small.Posts[0])
+	}
+	if small.Posts[1].Content != "a teaser" || small.Posts[1].ContentRef != "" {
+		t.Errorf("Offload() short post = %+v, want it left inline", small.Posts[1])
+	}
+	if p.Unstructured != page || p.Posts[0].Content != page {
+		t.Error("Offload() modified the profile it was given")
+	}
+
+	if err := Inline(ctx, s, small); err != nil {
+		t.Fatalf("Inline() error = %v", err)
+	}
+	if small.Unstructured != page || small.UnstructuredRef != "" || small.Posts[0].Content != page || small.Posts[0].ContentRef != "" {
+		t.Errorf("Inline() = %+v, want the original text back", small)
+	}
+}
@@ -3,7 +3,6 @@ package bluesky
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -31,6 +30,7 @@ type Client struct {
 	httpClient *http.Client
 	cache      cache.HTTPCache
 	logger     *slog.Logger
+	depth      profile.Depth
 }
 
 // Option configures a Client.
@@ -39,6 +39,7 @@ type Option func(*config)
 type config struct {
 	cache  cache.HTTPCache
 	logger *slog.Logger
+	depth  profile.Depth
 }
 
 // WithHTTPCache sets the HTTP cache.
@@ -51,6 +52,11 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
 // New creates a BlueSky client.
 func New(ctx context.Context, opts ...Option) (*Client, error) {
 	cfg := &config{logger: slog.Default()}
@@ -59,14 +65,10 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
 	}, nil
 }
 
@@ -86,7 +88,7 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
@@ -99,15 +101,18 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	}
 
 	// Fetch recent posts
-	posts, lastActive := c.fetchPosts(ctx, handle, 50)
-	p.Posts = posts
-	if lastActive != "" && lastActive > p.UpdatedAt {
-		p.UpdatedAt = lastActive
+	if limit := c.depth.PostLimit(); limit > 0 && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		posts, lastActive := c.fetchPosts(ctx, handle, limit)
+		p.Posts = posts
+		p.UpdateLastActive(lastActive)
 	}
 
 	return p, nil
 }
 
+// hashtagPattern matches hashtags in a profile description.
+var hashtagPattern = regexp.MustCompile(`#(\w+)`)
+
 func parseAPIResponse(data []byte, urlStr, handle string) (*profile.Profile, error) {
 	var resp struct {
 		Handle      string `json:"handle"`
@@ -136,8 +141,7 @@ func parseAPIResponse(data []byte, urlStr, handle string) (*profile.Profile, err
 
 	// Extract hashtags from bio
 	if resp.Description != "" {
-		re := regexp.MustCompile(`#(\w+)`)
-		p.Fields["hashtags"] = strings.Join(re.FindAllString(resp.Description, -1), ", ")
+		p.Fields["hashtags"] = strings.Join(hashtagPattern.FindAllString(resp.Description, -1), ", ")
 	}
 
 	return p, nil
@@ -151,7 +155,7 @@ func (c *Client) fetchPosts(ctx context.Context, handle string, limit int) (post
 		return nil, ""
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
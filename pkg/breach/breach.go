@@ -0,0 +1,150 @@
+// Package breach flags profiles whose email addresses appear in known data breaches.
+// Only whether an address was breached and how many times are recorded: breach names,
+// dates, and leaked data are never stored.
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // the range protocol is defined over SHA-1
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Fields keys set by Apply.
+const (
+	FieldBreached    = "breached"     // "true" or "false"
+	FieldBreachCount = "breach_count" // Breaches across all of the profile's emails
+)
+
+// Result is what a Checker knows about one email address.
+type Result struct {
+	Breached bool
+	Count    int // Number of breaches, 0 if unknown or not breached
+}
+
+// Checker reports whether an email address appears in known breaches.
+type Checker interface {
+	Check(ctx context.Context, email string) (Result, error)
+}
+
+// Apply checks every email in p's Fields ("email", "email_2", ...) with c and stores
+// the combined result in Fields["breached"] and Fields["breach_count"]. Profiles
+// without emails are left alone.
+func Apply(ctx context.Context, c Checker, p *profile.Profile) error {
+	var emails []string
+	for key, value := range p.Fields {
+		if key == profile.FieldEmail || strings.HasPrefix(key, profile.FieldEmail+"_") && isDigits(key[len(profile.FieldEmail)+1:]) {
+			emails = append(emails, value)
+		}
+	}
+	if len(emails) == 0 {
+		return nil
+	}
+
+	var total Result
+	var errs []error
+	for _, email := range emails {
+		r, err := c.Check(ctx, email)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total.Breached = total.Breached || r.Breached
+		total.Count += r.Count
+	}
+	// Unchecked addresses might be breached, so "false" needs every check to succeed
+	if len(errs) > 0 && !total.Breached {
+		return errors.Join(errs...)
+	}
+	p.Fields[FieldBreached] = strconv.FormatBool(total.Breached)
+	if total.Count > 0 {
+		p.Fields[FieldBreachCount] = strconv.Itoa(total.Count)
+	}
+	return errors.Join(errs...)
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// rangePrefixLen is how many hash characters are sent to the range endpoint.
+const rangePrefixLen = 5
+
+// RangeChecker queries a k-anonymity range endpoint, which only ever sees the first
+// five hex characters of the address's SHA-1 hash and returns every hash suffix it
+// has with that prefix. Lines are "SUFFIX:COUNT", as Pwned Passwords returns them, or
+// "SUFFIX:breach1;breach2", whose names are counted and discarded.
+type RangeChecker struct {
+	httpClient *http.Client
+	rangeURL   string
+}
+
+// NewRangeChecker creates a checker for the endpoint at rangeURL, to which the
+// 5-character hash prefix is appended, e.g. "https://example.com/range/".
+func NewRangeChecker(rangeURL string) *RangeChecker {
+	return &RangeChecker{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		rangeURL:   rangeURL,
+	}
+}
+
+// Check looks up email's hash suffix in its range.
+func (c *RangeChecker) Check(ctx context.Context, email string) (Result, error) {
+	sum := sha1.Sum([]byte(strings.ToLower(strings.TrimSpace(email)))) //nolint:gosec // not used for security
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:rangePrefixLen], hash[rangePrefixLen:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.rangeURL+prefix, http.NoBody)
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+	req.Header.Set("Add-Padding", "true") // ask HIBP-compatible servers to pad responses
+
+	// Deliberately uncached: responses describe many unrelated addresses
+	body, err := cache.FetchURL(ctx, nil, c.httpClient, req, nil)
+	if err != nil {
+		var httpErr *cache.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return Result{}, nil
+		}
+		return Result{}, err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		hashSuffix, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			// Padding entries have a count of zero
+			return Result{Breached: n > 0, Count: n}, nil
+		}
+		var n int
+		for name := range strings.SplitSeq(value, ";") {
+			if strings.TrimSpace(name) != "" {
+				n++
+			}
+		}
+		return Result{Breached: n > 0, Count: n}, nil
+	}
+	return Result{}, scanner.Err()
+}
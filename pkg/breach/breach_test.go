@@ -0,0 +1,133 @@
+package breach
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // test fixture for the range protocol
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func hashOf(email string) (prefix, suffix string) {
+	sum := sha1.Sum([]byte(email)) //nolint:gosec // test fixture
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:5], hash[5:]
+}
+
+func TestRangeChecker(t *testing.T) {
+	pwnedPrefix, pwnedSuffix := hashOf("pwned@example.com")
+	namedPrefix, namedSuffix := hashOf("named@example.com")
+	var gotPrefixes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		gotPrefixes = append(gotPrefixes, prefix)
+		switch prefix {
+		case pwnedPrefix:
+			_, _ = w.Write([]byte("0000000000000000000000000000000000A:0\r\n" + pwnedSuffix + ":3\r\n")) //nolint:errcheck // test server
+		case namedPrefix:
+			_, _ = w.Write([]byte(strings.ToLower(namedSuffix) + ":Adobe;LinkedIn\n")) //nolint:errcheck // test server
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewRangeChecker(server.URL + "/range/")
+	tests := []struct {
+		email string
+		want  Result
+	}{
+		{"Pwned@Example.com", Result{Breached: true, Count: 3}},
+		{"named@example.com", Result{Breached: true, Count: 2}},
+		{"clean@example.com", Result{}},
+	}
+	for _, tt := range tests {
+		got, err := c.Check(context.Background(), tt.email)
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", tt.email, err)
+		}
+		if got != tt.want {
+			t.Errorf("Check(%q) = %+v, want %+v", tt.email, got, tt.want)
+		}
+	}
+	for _, prefix := range gotPrefixes {
+		if len(prefix) != 5 {
+			t.Errorf("sent %q, want only a 5-character hash prefix", prefix)
+		}
+	}
+}
+
+type fakeChecker map[string]Result
+
+func (f fakeChecker) Check(_ context.Context, email string) (Result, error) {
+	r, ok := f[email]
+	if !ok {
+		return Result{}, errors.New("unavailable")
+	}
+	return r, nil
+}
+
+func TestApply(t *testing.T) {
+	checker := fakeChecker{
+		"a@example.com": {Breached: true, Count: 2},
+		"b@example.com": {Breached: true, Count: 1},
+		"c@example.com": {},
+	}
+	tests := []struct {
+		name        string
+		fields      map[string]string
+		wantFields  map[string]string
+		wantErr     bool
+		wantNoField bool
+	}{
+		{
+			name:       "counts add up across emails",
+			fields:     map[string]string{"email": "a@example.com", "email_2": "b@example.com", "email_domain": "example.com"},
+			wantFields: map[string]string{FieldBreached: "true", FieldBreachCount: "3"},
+		},
+		{
+			name:       "clean",
+			fields:     map[string]string{"email": "c@example.com"},
+			wantFields: map[string]string{FieldBreached: "false"},
+		},
+		{
+			name:        "failed check leaves clean result unset",
+			fields:      map[string]string{"email": "c@example.com", "email_2": "down@example.com"},
+			wantErr:     true,
+			wantNoField: true,
+		},
+		{
+			name:       "failed check still reports a known breach",
+			fields:     map[string]string{"email": "a@example.com", "email_2": "down@example.com"},
+			wantFields: map[string]string{FieldBreached: "true", FieldBreachCount: "2"},
+			wantErr:    true,
+		},
+		{
+			name:        "no emails",
+			fields:      map[string]string{"twitter": "https://twitter.com/a"},
+			wantNoField: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &profile.Profile{Fields: tt.fields}
+			err := Apply(context.Background(), checker, p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := p.Fields[FieldBreached]; tt.wantNoField && ok {
+				t.Errorf("Fields = %v, want no breach fields", p.Fields)
+			}
+			for k, want := range tt.wantFields {
+				if got := p.Fields[k]; got != want {
+					t.Errorf("Fields[%q] = %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -26,11 +28,16 @@ type BDCache struct {
 //
 //nolint:govet // fieldalignment: intentional layout for clarity
 type CachedResponse struct {
-	Data    []byte
-	Headers map[string]string
-	ETag    string
+	Data     []byte
+	Headers  map[string]string
+	ETag     string
+	Encoding string // "gzip" if Data is stored compressed; entries written before compression have ""
 }
 
+// compressMinBytes is the smallest body BDCache compresses; gzip framing outweighs
+// the savings on short API answers and cached error markers.
+const compressMinBytes = 512
+
 // New creates a new BDCache with disk persistence.
 // The cache directory defaults to ~/.cache/sociopath.
 // ttl is the default time-to-live for cached entries.
@@ -86,7 +93,13 @@ func (c *BDCache) Get(ctx context.Context, url string) (data []byte, etag string
 		return nil, "", nil, false
 	}
 
-	return resp.Data, resp.ETag, resp.Headers, true
+	data = resp.Data
+	if resp.Encoding != "" {
+		if data, err = decodeBody(resp.Encoding, data); err != nil {
+			return nil, "", nil, false
+		}
+	}
+	return data, resp.ETag, resp.Headers, true
 }
 
 // SetAsync stores a response in the cache asynchronously.
@@ -102,6 +115,12 @@ func (c *BDCache) SetAsyncWithTTL(ctx context.Context, url string, data []byte,
 		Headers: headers,
 		ETag:    etag,
 	}
+	// HTML compresses several-fold; keep whichever form is smaller
+	if len(data) >= compressMinBytes {
+		if compressed, err := gzipBytes(data); err == nil && len(compressed) < len(data) {
+			resp.Data, resp.Encoding = compressed, "gzip"
+		}
+	}
 
 	// Store in cache - we ignore errors as cache failures shouldn't break the application
 	_ = c.cache.Set(ctx, key, resp, ttl) //nolint:errcheck // cache errors are non-critical
@@ -131,6 +150,19 @@ func (c *BDCache) Stats() Stats {
 	}
 }
 
+// gzipBytes compresses data with gzip.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // urlToKey converts a URL to a cache key using SHA256 hash.
 // This ensures keys are filesystem-safe and uniform length.
 func urlToKey(url string) string {
@@ -121,3 +121,31 @@ func TestCacheDirectory(t *testing.T) {
 		t.Error("cache path is not a directory")
 	}
 }
+
+func TestGetSetCompressed(t *testing.T) {
+	cache, err := NewWithPath(1*time.Hour, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWithPath() error = %v", err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	ctx := context.Background()
+	html := bytes.Repeat([]byte("<div class=\"profile\">Jane Doe</div>\n"), 200)
+	if err := cache.SetAsync(ctx, "https://example.com/jane", html, "", nil); err != nil {
+		t.Fatalf("SetAsync() error = %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	stored, found, err := cache.cache.Get(ctx, urlToKey("https://example.com/jane"))
+	if err != nil || !found {
+		t.Fatalf("raw Get() found = %v, error = %v", found, err)
+	}
+	if stored.Encoding != "gzip" || len(stored.Data) >= len(html)/4 {
+		t.Errorf("stored %d bytes with encoding %q, want gzip well under %d bytes", len(stored.Data), stored.Encoding, len(html))
+	}
+
+	got, _, _, found := cache.Get(ctx, "https://example.com/jane")
+	if !found || !bytes.Equal(got, html) {
+		t.Errorf("Get() returned %d bytes (found = %v), want the original %d", len(got), found, len(html))
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without making a request when a host's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Circuit breaker defaults for the shared HTTP layer.
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 2 * time.Minute
+)
+
+// globalCircuitBreaker stops requests to hosts that keep failing hard.
+var globalCircuitBreaker = NewCircuitBreaker(defaultBreakerThreshold, defaultBreakerCooldown)
+
+// CircuitBreaker tracks consecutive hard failures (network errors, 5xx responses, bot
+// challenges) per host. After threshold failures in a row the host's circuit opens and
+// requests fail fast with ErrCircuitOpen until cooldown has passed. The next request is
+// then let through as a probe: success closes the circuit, failure reopens it.
+// It is safe for concurrent use from multiple goroutines.
+type CircuitBreaker struct {
+	hosts     map[string]*breakerState
+	now       func() time.Time
+	threshold int
+	cooldown  time.Duration
+	mu        sync.Mutex
+}
+
+type breakerState struct {
+	openUntil time.Time
+	failures  int
+}
+
+// NewCircuitBreaker creates a circuit breaker that opens after threshold consecutive
+// hard failures and stays open for cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		hosts:     make(map[string]*breakerState),
+		now:       time.Now,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// Allow returns ErrCircuitOpen if requests to host should be short-circuited.
+func (b *CircuitBreaker) Allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if s, ok := b.hosts[host]; ok && b.now().Before(s.openUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// Success records a response showing host is up, closing its circuit.
+func (b *CircuitBreaker) Success(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.hosts, host)
+}
+
+// Failure records a hard failure for host, opening its circuit at the threshold.
+func (b *CircuitBreaker) Failure(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.hosts[host]
+	if !ok {
+		s = &breakerState{}
+		b.hosts[host] = s
+	}
+	s.failures++
+	if s.failures >= b.threshold {
+		s.openUntil = b.now().Add(b.cooldown)
+		slog.Warn("circuit breaker opened", "host", host, "failures", s.failures, "cooldown", b.cooldown)
+	}
+}
+
+// isHardFailure reports whether a response means the host is down or refusing automated
+// clients, as opposed to an ordinary client error like 404.
+func isHardFailure(resp *http.Response) bool {
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	// Cloudflare and AWS WAF interstitials
+	if resp.Header.Get("Cf-Mitigated") == "challenge" {
+		return true
+	}
+	switch resp.Header.Get("X-Amzn-Waf-Action") {
+	case "challenge", "captcha":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	for range 2 {
+		b.Failure("dead.example")
+	}
+	if err := b.Allow("dead.example"); err != nil {
+		t.Fatalf("Allow() = %v before threshold", err)
+	}
+
+	b.Failure("dead.example")
+	if err := b.Allow("dead.example"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() = %v, want ErrCircuitOpen at threshold", err)
+	}
+	if err := b.Allow("alive.example"); err != nil {
+		t.Errorf("other hosts should be unaffected, got %v", err)
+	}
+
+	// After the cooldown one probe is allowed; another failure reopens immediately
+	now = now.Add(time.Minute)
+	if err := b.Allow("dead.example"); err != nil {
+		t.Fatalf("Allow() = %v after cooldown", err)
+	}
+	b.Failure("dead.example")
+	if err := b.Allow("dead.example"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() = %v, want reopened circuit", err)
+	}
+
+	now = now.Add(time.Minute)
+	b.Success("dead.example")
+	b.Failure("dead.example")
+	if err := b.Allow("dead.example"); err != nil {
+		t.Errorf("success should reset the failure count, got %v", err)
+	}
+}
+
+func TestIsHardFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		want   bool
+	}{
+		{"ok", http.StatusOK, nil, false},
+		{"not found", http.StatusNotFound, nil, false},
+		{"rate limited", http.StatusTooManyRequests, nil, false},
+		{"server error", http.StatusBadGateway, nil, true},
+		{"cloudflare challenge", http.StatusForbidden, http.Header{"Cf-Mitigated": {"challenge"}}, true},
+		{"aws waf captcha", http.StatusMethodNotAllowed, http.Header{"X-Amzn-Waf-Action": {"captcha"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: tt.header}
+			if resp.Header == nil {
+				resp.Header = http.Header{}
+			}
+			if got := isHardFailure(resp); got != tt.want {
+				t.Errorf("isHardFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchURLCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	for range defaultBreakerThreshold + 2 {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/profile", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FetchURL(ctx, nil, server.Client(), req, nil); err == nil {
+			t.Fatal("FetchURL() expected error")
+		}
+	}
+
+	if got := requests.Load(); got != defaultBreakerThreshold {
+		t.Errorf("server saw %d requests, want %d before the circuit opened", got, defaultBreakerThreshold)
+	}
+}
+
+func TestFetchURLCircuitBreakerPerTenant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Cookie") == "session=banned" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok")) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	fetch := func(tenant, cookie string) error {
+		ctx := WithTenant(context.Background(), tenant)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/profile", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Cookie", cookie)
+		_, err = FetchURL(ctx, nil, server.Client(), req, nil)
+		return err
+	}
+	for range defaultBreakerThreshold {
+		if err := fetch("banned", "session=banned"); err == nil {
+			t.Fatal("FetchURL() expected error")
+		}
+	}
+	if err := fetch("banned", "session=banned"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("FetchURL() for the failing tenant error = %v, want ErrCircuitOpen", err)
+	}
+	if err := fetch("other", "session=ok"); err != nil {
+		t.Errorf("FetchURL() for another tenant error = %v, want its own circuit", err)
+	}
+}
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// MinOptionalBudget is the least time left on a deadline for which optional
+// sub-requests (posts, README scraping, fallbacks) are still attempted.
+const MinOptionalBudget = 500 * time.Millisecond
+
+// SubContext returns a context for the next of n remaining sub-requests of one fetch,
+// limited to an even share of ctx's remaining time so a slow call cannot starve the
+// ones after it. Without a deadline on ctx (or with n <= 1) the share is not limited.
+func SubContext(ctx context.Context, n int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || n <= 1 {
+		return context.WithCancel(ctx)
+	}
+	share := time.Until(deadline) / time.Duration(n)
+	return context.WithTimeout(ctx, share)
+}
+
+// HasBudget reports whether ctx has at least minimum time left before its deadline.
+// It is always true for contexts without a deadline.
+func HasBudget(ctx context.Context, minimum time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) >= minimum
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSubContext(t *testing.T) {
+	t.Run("no deadline", func(t *testing.T) {
+		ctx, cancel := SubContext(context.Background(), 3)
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok {
+			t.Error("SubContext should not add a deadline when the parent has none")
+		}
+	})
+
+	t.Run("splits remaining time", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancelParent()
+
+		ctx, cancel := SubContext(parent, 3)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			t.Fatal("SubContext should set a deadline")
+		}
+		if left := time.Until(deadline); left > 1100*time.Millisecond || left < 900*time.Millisecond {
+			t.Errorf("share = %v, want about 1s", left)
+		}
+	})
+
+	t.Run("last request gets everything", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancelParent()
+
+		ctx, cancel := SubContext(parent, 1)
+		defer cancel()
+		want, _ := parent.Deadline()
+		if got, _ := ctx.Deadline(); !got.Equal(want) {
+			t.Errorf("deadline = %v, want parent deadline %v", got, want)
+		}
+	})
+}
+
+func TestHasBudget(t *testing.T) {
+	if !HasBudget(context.Background(), time.Hour) {
+		t.Error("contexts without a deadline always have budget")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if HasBudget(ctx, MinOptionalBudget) {
+		t.Error("100ms left should be below MinOptionalBudget")
+	}
+	if !HasBudget(ctx, 10*time.Millisecond) {
+		t.Error("100ms left should cover 10ms")
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if HasBudget(canceled, 0) {
+		t.Error("canceled contexts have no budget")
+	}
+}
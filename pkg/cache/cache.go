@@ -3,8 +3,8 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -54,9 +54,12 @@ type HTTPCache interface {
 type ResponseValidator func(body []byte) bool
 
 // FetchURL fetches a URL with caching support.
+// Requests without an Accept-Encoding header advertise the registered decoders (see
+// RegisterDecoder), and compressed responses are decoded before they are returned or cached.
 // If cache is non-nil and contains the URL, returns cached data.
 // Otherwise, executes the HTTP request, caches successful responses (HTTP 200), and returns the body.
-// Returns an error if the HTTP status is not 200 OK.
+// Returns an error if the HTTP status is not 200 OK, and a *ContentTypeError or
+// *BodyTooLargeError for responses outside the Limits in ctx (see WithLimits).
 // The caller must set all necessary headers on the request before calling this function.
 func FetchURL(ctx context.Context, cache HTTPCache, client *http.Client, req *http.Request, logger *slog.Logger) ([]byte, error) {
 	return FetchURLWithValidator(ctx, cache, client, req, logger, nil)
@@ -73,14 +76,9 @@ func FetchURLWithValidator(
 	logger *slog.Logger,
 	validator ResponseValidator,
 ) ([]byte, error) {
-	// Build cache key that includes auth state to avoid mixing authenticated/unauthenticated responses
-	cacheKey := req.URL.String()
-	if client.Jar != nil {
-		cookies := client.Jar.Cookies(req.URL)
-		if len(cookies) > 0 {
-			cacheKey += "|auth"
-		}
-	}
+	// Key on the canonical URL and on credentials and locale, so mirrors share entries
+	// and each account's or locale's responses stay apart
+	cacheKey := Key(req, client)
 
 	// Check cache
 	if cache == nil {
@@ -88,7 +86,7 @@ func FetchURLWithValidator(
 			logger.Info("cache disabled", "url", req.URL.String())
 		}
 	} else {
-		if data, _, _, found := cache.Get(ctx, cacheKey); found {
+		if data, _, headers, found := cache.Get(ctx, cacheKey); found {
 			cache.RecordHit()
 			// Check if this is a cached error (format: "ERROR:status_code")
 			if s := string(data); strings.HasPrefix(s, "ERROR:") {
@@ -101,6 +99,12 @@ func FetchURLWithValidator(
 			if logger != nil {
 				logger.Debug("cache hit", "key", cacheKey)
 			}
+			if maxAge, ok := staleMaxAge(ctx); ok && req.Method == http.MethodGet && !IsOffline(ctx) && isStale(headers, maxAge) {
+				if logger != nil {
+					logger.Debug("serving stale entry while revalidating", "key", cacheKey)
+				}
+				revalidate(ctx, cache, client, req, logger, validator, cacheKey)
+			}
 			return data, nil
 		}
 		cache.RecordMiss()
@@ -109,15 +113,68 @@ func FetchURLWithValidator(
 		}
 	}
 
-	// Rate limit: wait if we've recently hit this domain
-	globalRateLimiter.Wait(req.URL.String())
+	if IsOffline(ctx) {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, req.URL.String())
+	}
+
+	// Coalesce concurrent requests for the same resource into one
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return fetchAndStore(ctx, cache, client, req, logger, validator, cacheKey)
+	}
+	// The shared fetch can outlive this caller, which may then reuse req, so it sends a copy
+	send := req.Clone(ctx)
+	body, err, shared := globalInflight.do(ctx, req.Method+" "+cacheKey, func(ctx context.Context) ([]byte, error) {
+		return fetchAndStore(ctx, cache, client, send.WithContext(ctx), logger, validator, cacheKey)
+	})
+	if shared && logger != nil {
+		logger.Debug("coalesced with in-flight request", "key", cacheKey)
+	}
+	return body, err
+}
+
+// fetchAndStore executes req and caches the outcome under cacheKey.
+func fetchAndStore(
+	ctx context.Context,
+	cache HTTPCache,
+	client *http.Client,
+	req *http.Request,
+	logger *slog.Logger,
+	validator ResponseValidator,
+	cacheKey string,
+) ([]byte, error) {
+	// Fail fast if this host has been failing hard for this tenant
+	host := req.URL.Host
+	breaker := breakerKey(ctx, host)
+	if err := globalCircuitBreaker.Allow(breaker); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, host)
+	}
+
+	// Rate limit: wait if we've recently hit this domain, and apply politeness policies
+	release, err := globalRateLimiter.Acquire(ctx, req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
-	// Execute request
-	resp, err := client.Do(req)
+	// Execute request, prepared as configured and asking for compressed bodies as browsers do
+	PrepareRequest(ctx, req)
+	setAcceptEncoding(req)
+	resp, err := Client(ctx, shared(client)).Do(traceConnections(req))
 	if err != nil {
+		// Our own cancellation or deadline says nothing about the host
+		if ctx.Err() == nil {
+			globalCircuitBreaker.Failure(breaker)
+		}
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // error ignored intentionally
+	recordProtocol(resp)
+
+	if isHardFailure(resp) {
+		globalCircuitBreaker.Failure(breaker)
+	} else {
+		globalCircuitBreaker.Success(breaker)
+	}
 
 	// Check status code - cache errors for 5 days to avoid hammering servers
 	if resp.StatusCode != http.StatusOK {
@@ -133,8 +190,24 @@ func FetchURLWithValidator(
 		return nil, &HTTPError{StatusCode: resp.StatusCode, URL: req.URL.String()}
 	}
 
+	// Refuse media we cannot parse and bodies too large to be profiles before reading them
+	limits := limitsFrom(ctx)
+	if ct := resp.Header.Get("Content-Type"); !limits.allows(ct) {
+		return nil, &ContentTypeError{URL: req.URL.String(), ContentType: ct}
+	}
+	tooLarge := &BodyTooLargeError{URL: req.URL.String(), MaxBytes: limits.MaxBytes}
+	if limits.MaxBytes > 0 && resp.ContentLength > limits.MaxBytes {
+		return nil, tooLarge
+	}
+
 	// Read response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := readLimited(resp.Body, limits.MaxBytes)
+	if err == nil && !resp.Uncompressed {
+		body, err = decodeBodyLimit(resp.Header.Get("Content-Encoding"), body, limits.MaxBytes)
+	}
+	if errors.Is(err, errBodyTooLarge) {
+		return nil, tooLarge
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +215,7 @@ func FetchURLWithValidator(
 	// Cache successful response only if validator passes (or no validator)
 	shouldCache := validator == nil || validator(body)
 	if cache != nil && shouldCache {
-		_ = cache.SetAsync(ctx, cacheKey, body, "", nil) //nolint:errcheck // async, error ignored
+		_ = cache.SetAsync(ctx, cacheKey, body, "", storedHeaders()) //nolint:errcheck // async, error ignored
 		if logger != nil {
 			logger.Info("cache store", "url", req.URL.String(), "key", cacheKey, "status", 200, "bytes", len(body), "ttl", "default")
 		}
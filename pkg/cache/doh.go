@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Resolver looks up the addresses of a host, as *net.Resolver does. network is "ip",
+// "ip4", or "ip6".
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// DNS record types and the largest response DoHResolver reads.
+const (
+	dnsTypeA       = 1
+	dnsTypeAAAA    = 28
+	maxDNSResponse = 64 << 10
+)
+
+// DoHResolver is a Resolver asking a DNS-over-HTTPS server (RFC 8484), so lookups are
+// private from the local network and its DNS servers.
+type DoHResolver struct {
+	client    *http.Client
+	serverURL string
+}
+
+var _ Resolver = (*DoHResolver)(nil)
+
+// NewDoHResolver returns a resolver for the DoH server at serverURL, such as
+// "https://cloudflare-dns.com/dns-query". The server's own name is looked up with the
+// system resolver, so serverURL may name it by IP address to avoid that.
+func NewDoHResolver(serverURL string) (*DoHResolver, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid DoH server URL %q: want https://host/path", serverURL)
+	}
+	return &DoHResolver{client: &http.Client{Timeout: 5 * time.Second}, serverURL: serverURL}, nil
+}
+
+// LookupNetIP returns the addresses in host's A and AAAA records, or only one of
+// them for network "ip4" or "ip6".
+func (r *DoHResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+	var types []uint16
+	switch network {
+	case "ip4":
+		types = []uint16{dnsTypeA}
+	case "ip6":
+		types = []uint16{dnsTypeAAAA}
+	default:
+		types = []uint16{dnsTypeA, dnsTypeAAAA}
+	}
+	var addrs []netip.Addr
+	var errs []error
+	for _, qtype := range types {
+		found, err := r.query(ctx, host, qtype)
+		addrs = append(addrs, found...)
+		errs = append(errs, err)
+	}
+	if len(addrs) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, Server: r.serverURL, IsNotFound: true}
+	}
+	return addrs, nil
+}
+
+// query asks the server for host's records of qtype.
+func (r *DoHResolver) query(ctx context.Context, host string, qtype uint16) ([]netip.Addr, error) {
+	msg, err := dnsQuery(host, qtype)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.serverURL, bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: host, Server: r.serverURL, IsTemporary: true}
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // error ignored intentionally
+	if resp.StatusCode != http.StatusOK {
+		return nil, &net.DNSError{Err: "server answered " + resp.Status, Name: host, Server: r.serverURL, IsTemporary: true}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDNSResponse))
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := parseDNSAnswers(body, qtype)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: host, Server: r.serverURL}
+	}
+	return addrs, nil
+}
+
+// dnsQuery encodes a recursive query for host's records of qtype. The ID is 0, as
+// RFC 8484 recommends so identical queries can be cached.
+func dnsQuery(host string, qtype uint16) ([]byte, error) {
+	msg := []byte{0, 0, 1, 0, 0, 1, 0, 0, 0, 0, 0, 0} // RD set, one question
+	for label := range strings.SplitSeq(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return nil, fmt.Errorf("invalid host name %q", host)
+		}
+		msg = append(msg, byte(len(label)))
+		msg = append(msg, label...)
+	}
+	msg = append(msg, 0)
+	msg = binary.BigEndian.AppendUint16(msg, qtype)
+	return binary.BigEndian.AppendUint16(msg, 1), nil // Class IN
+}
+
+// parseDNSAnswers returns the addresses in the answers of a DNS response to a query
+// for qtype records. CNAMEs are followed by the server, so their targets' records are
+// among the answers.
+func parseDNSAnswers(msg []byte, qtype uint16) ([]netip.Addr, error) {
+	if len(msg) < 12 {
+		return nil, errors.New("short DNS response")
+	}
+	switch rcode := msg[3] & 0x0f; rcode {
+	case 0:
+	case 3:
+		return nil, nil // NXDOMAIN
+	default:
+		return nil, fmt.Errorf("DNS response code %d", rcode)
+	}
+	questions := int(binary.BigEndian.Uint16(msg[4:]))
+	answers := int(binary.BigEndian.Uint16(msg[6:]))
+	off := 12
+	var err error
+	for range questions {
+		if off, err = skipDNSName(msg, off); err != nil {
+			return nil, err
+		}
+		off += 4 // Type and class
+	}
+	var addrs []netip.Addr
+	for range answers {
+		if off, err = skipDNSName(msg, off); err != nil {
+			return nil, err
+		}
+		if off+10 > len(msg) {
+			return nil, errors.New("truncated DNS answer")
+		}
+		rtype := binary.BigEndian.Uint16(msg[off:])
+		size := int(binary.BigEndian.Uint16(msg[off+8:]))
+		off += 10
+		if off+size > len(msg) {
+			return nil, errors.New("truncated DNS answer")
+		}
+		if rtype == qtype {
+			if addr, ok := netip.AddrFromSlice(msg[off : off+size]); ok {
+				addrs = append(addrs, addr)
+			}
+		}
+		off += size
+	}
+	return addrs, nil
+}
+
+// skipDNSName returns the offset after the possibly compressed name at off.
+func skipDNSName(msg []byte, off int) (int, error) {
+	for off < len(msg) {
+		switch n := int(msg[off]); {
+		case n == 0:
+			return off + 1, nil
+		case n&0xc0 == 0xc0:
+			return off + 2, nil // A pointer ends the name
+		default:
+			off += 1 + n
+		}
+	}
+	return 0, errors.New("truncated DNS name")
+}
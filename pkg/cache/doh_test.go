@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"testing"
+)
+
+// dohAnswer answers a query with a CNAME to the queried name, by compression pointer,
+// and then a.example's record of the queried type, if addrs has one.
+func dohAnswer(query []byte, addrs map[uint16][]byte) []byte {
+	qtype := binary.BigEndian.Uint16(query[len(query)-4:])
+	resp := append([]byte{}, query...)
+	resp[2] |= 0x80 // QR
+	rdata := addrs[qtype]
+	answers := uint16(1)
+	if rdata != nil {
+		answers++
+	}
+	binary.BigEndian.PutUint16(resp[6:], answers)
+
+	// CNAME from the question's name (at offset 12) to "a.example"
+	target := []byte{1, 'a', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 0}
+	resp = append(resp, 0xc0, 12, 0, 5, 0, 1, 0, 0, 0, 60, 0, byte(len(target)))
+	targetOff := len(resp)
+	resp = append(resp, target...)
+	if rdata != nil {
+		resp = append(resp, 0xc0, byte(targetOff))
+		resp = binary.BigEndian.AppendUint16(resp, qtype)
+		resp = append(resp, 0, 1, 0, 0, 0, 60, 0, byte(len(rdata)))
+		resp = append(resp, rdata...)
+	}
+	return resp
+}
+
+func TestDoHResolver(t *testing.T) {
+	addrs := map[uint16][]byte{
+		dnsTypeA:    {93, 184, 216, 34},
+		dnsTypeAAAA: netip.MustParseAddr("2606:2800:220:1::1").AsSlice(),
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		query, _ := io.ReadAll(r.Body)
+		if string(query[13:20]) == "missing" {
+			resp := append([]byte{}, query...)
+			resp[2], resp[3] = 0x81, 0x83 // NXDOMAIN
+			_, _ = w.Write(resp)
+			return
+		}
+		_, _ = w.Write(dohAnswer(query, addrs))
+	}))
+	defer server.Close()
+	r := &DoHResolver{client: server.Client(), serverURL: server.URL}
+	ctx := context.Background()
+
+	got, err := r.LookupNetIP(ctx, "ip", "www.example.com")
+	if err != nil {
+		t.Fatalf("LookupNetIP() error = %v", err)
+	}
+	want := []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("2606:2800:220:1::1")}
+	if !slices.Equal(got, want) {
+		t.Errorf("LookupNetIP(ip) = %v, want %v", got, want)
+	}
+	if got, err := r.LookupNetIP(ctx, "ip4", "www.example.com"); err != nil || !slices.Equal(got, want[:1]) {
+		t.Errorf("LookupNetIP(ip4) = %v, %v, want %v", got, err, want[:1])
+	}
+	var dnsErr *net.DNSError
+	if _, err := r.LookupNetIP(ctx, "ip", "missing.example.com"); !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("LookupNetIP(missing) error = %v, want not found", err)
+	}
+	if _, err := NewDoHResolver("http://dns.example/dns-query"); err == nil {
+		t.Error("NewDoHResolver() accepted a plain HTTP URL")
+	}
+}
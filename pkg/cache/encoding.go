@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupportedEncoding is returned when a response uses a Content-Encoding with no
+// registered Decoder.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// Decoder wraps a compressed response body in a reader of the decoded bytes.
+type Decoder func(r io.Reader) (io.Reader, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]Decoder{
+		"gzip":    func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"x-gzip":  func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"deflate": decodeDeflate,
+	}
+)
+
+// RegisterDecoder makes FetchURL advertise and decode encoding, replacing any Decoder
+// already registered for it. The standard library has no brotli decoder, so programs
+// that want "br" register one:
+//
+//	cache.RegisterDecoder("br", func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil })
+func RegisterDecoder(encoding string, d Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[strings.ToLower(encoding)] = d
+}
+
+// acceptEncoding returns the Accept-Encoding header listing the registered decoders,
+// in the order browsers send them.
+func acceptEncoding() string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	order := map[string]int{"gzip": 0, "deflate": 1, "br": 2, "zstd": 3}
+	var names []string
+	for name := range decoders {
+		if !strings.HasPrefix(name, "x-") {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		oi, iok := order[names[i]]
+		oj, jok := order[names[j]]
+		if iok != jok {
+			return iok
+		}
+		if oi != oj {
+			return oi < oj
+		}
+		return names[i] < names[j]
+	})
+	return strings.Join(names, ", ")
+}
+
+// setAcceptEncoding advertises the registered encodings unless the caller chose its own.
+// Setting the header turns off the transport's transparent gzip handling, so responses
+// to such requests must go through decodeBody.
+func setAcceptEncoding(req *http.Request) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding())
+	}
+}
+
+// decodeBody undoes the encodings listed in a Content-Encoding header, last applied first.
+func decodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	return decodeBodyLimit(contentEncoding, body, -1)
+}
+
+// decodeBodyLimit is decodeBody that stops with errBodyTooLarge once a decoded body
+// exceeds maxBytes, so a small compressed response cannot expand without bound. A
+// negative maxBytes means no limit.
+func decodeBodyLimit(contentEncoding string, body []byte, maxBytes int64) ([]byte, error) {
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		enc := strings.ToLower(strings.TrimSpace(encodings[i]))
+		if enc == "" || enc == "identity" {
+			continue
+		}
+		decodersMu.RLock()
+		d, ok := decoders[enc]
+		decodersMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, enc)
+		}
+		r, err := d(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s body: %w", enc, err)
+		}
+		if body, err = readLimited(r, maxBytes); err != nil {
+			return nil, fmt.Errorf("decoding %s body: %w", enc, err)
+		}
+	}
+	return body, nil
+}
+
+// decodeDeflate reads "deflate" bodies, which HTTP defines as zlib-wrapped but some
+// servers send as raw deflate.
+func decodeDeflate(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && (uint(header[0])<<8|uint(header[1]))%31 == 0 && header[0]&0x0f == 8 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
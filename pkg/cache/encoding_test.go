@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compress(t *testing.T, encoding, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "zlib":
+		w = zlib.NewWriter(&buf)
+	case "flate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression) //nolint:errcheck // valid level
+	}
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeBody(t *testing.T) {
+	const want = "<html>profile</html>"
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"identity", "", []byte(want)},
+		{"gzip", "gzip", compress(t, "gzip", want)},
+		{"zlib deflate", "deflate", compress(t, "zlib", want)},
+		{"raw deflate", "Deflate", compress(t, "flate", want)},
+		{"stacked", "deflate, gzip", func() []byte {
+			inner := compress(t, "zlib", want)
+			return compress(t, "gzip", string(inner))
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeBody(tt.encoding, tt.body)
+			if err != nil {
+				t.Fatalf("decodeBody() error = %v", err)
+			}
+			if string(got) != want {
+				t.Errorf("decodeBody() = %q, want %q", got, want)
+			}
+		})
+	}
+
+	if _, err := decodeBody("zstd", []byte("x")); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("decodeBody(zstd) error = %v, want ErrUnsupportedEncoding", err)
+	}
+}
+
+func TestFetchURLDecodesResponses(t *testing.T) {
+	// A stand-in "br" decoder proves registered decoders are advertised and used
+	RegisterDecoder("br", func(r io.Reader) (io.Reader, error) {
+		b, err := io.ReadAll(r)
+		return strings.NewReader(strings.TrimPrefix(string(b), "BR:")), err
+	})
+	defer func() {
+		decodersMu.Lock()
+		delete(decoders, "br")
+		decodersMu.Unlock()
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept-Encoding")
+		switch {
+		case strings.Contains(accept, "br") && r.URL.Path == "/br":
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = w.Write([]byte("BR:hello"))
+		case strings.Contains(accept, "gzip"):
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(compress(t, "gzip", "hello"))
+		default:
+			t.Errorf("request without Accept-Encoding: %q", accept)
+		}
+	}))
+	defer server.Close()
+
+	if got := acceptEncoding(); got != "gzip, deflate, br" {
+		t.Errorf("acceptEncoding() = %q, want %q", got, "gzip, deflate, br")
+	}
+	for _, path := range []string{"/gzip", "/br"} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := FetchURL(context.Background(), nil, server.Client(), req, nil)
+		if err != nil {
+			t.Fatalf("FetchURL(%s) error = %v", path, err)
+		}
+		if string(body) != "hello" {
+			t.Errorf("FetchURL(%s) = %q, want %q", path, body, "hello")
+		}
+	}
+}
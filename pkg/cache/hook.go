@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestHook adjusts a request just before it is sent, such as to add headers a
+// proxy or an experiment needs.
+type RequestHook func(*http.Request)
+
+type hooksKey struct{}
+
+// WithRequestHook returns a copy of ctx under which FetchURL runs hook on each request
+// it sends, after any hooks already in ctx and after Identify, so hooks can override
+// the identity headers. Responses answered from the cache send no request, and cache
+// keys are computed before hooks run, so hooks should not change what is fetched.
+func WithRequestHook(ctx context.Context, hook RequestHook) context.Context {
+	hooks, _ := ctx.Value(hooksKey{}).([]RequestHook) //nolint:errcheck // a missing value means no hooks
+	return context.WithValue(ctx, hooksKey{}, append(hooks[:len(hooks):len(hooks)], hook))
+}
+
+// PrepareRequest identifies req and runs the request hooks in ctx, as FetchURL does
+// before sending. Clients that reach the network without FetchURL call it themselves.
+func PrepareRequest(ctx context.Context, req *http.Request) {
+	Identify(ctx, req)
+	hooks, _ := ctx.Value(hooksKey{}).([]RequestHook) //nolint:errcheck // a missing value means no hooks
+	for _, hook := range hooks {
+		hook(req)
+	}
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestPrepareRequestHooks(t *testing.T) {
+	ctx := WithIdentity(context.Background(), Identity{UserAgent: "acme-research/2"})
+	ctx = WithRequestHook(ctx, func(req *http.Request) { req.Header.Set("Proxy-Authorization", "Basic abc") })
+	base := ctx
+	ctx = WithRequestHook(ctx, func(req *http.Request) { req.Header.Set("User-Agent", "hooked/1") })
+	sibling := WithRequestHook(base, func(req *http.Request) { req.Header.Set("X-Experiment", "b") })
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	PrepareRequest(ctx, req)
+	if got := req.Header.Get("Proxy-Authorization"); got != "Basic abc" {
+		t.Errorf("Proxy-Authorization = %q, want first hook's value", got)
+	}
+	if got := req.UserAgent(); got != "hooked/1" {
+		t.Errorf("User-Agent = %q, want the hook to override the identity", got)
+	}
+	if got := req.Header.Get("X-Experiment"); got != "" {
+		t.Errorf("X-Experiment = %q, want hooks of a sibling context not to leak", got)
+	}
+
+	req, err = http.NewRequestWithContext(sibling, http.MethodGet, "https://example.com/", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	PrepareRequest(sibling, req)
+	if req.Header.Get("X-Experiment") != "b" || req.UserAgent() != "acme-research/2" {
+		t.Errorf("sibling context headers = %v", req.Header)
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// Version is the sociopath version named in UserAgent. Release builds set it with
+// -ldflags "-X github.com/codeGROOVE-dev/sociopath/pkg/cache.Version=...".
+var Version = "1.0"
+
+// ContactURL is where the operators of sites sociopath visits can learn about it.
+const ContactURL = "https://github.com/codeGROOVE-dev/sociopath"
+
+// UserAgent is the User-Agent of clients that identify themselves rather than
+// emulate a browser.
+var UserAgent = "sociopath/" + Version + " (+" + ContactURL + ")"
+
+// Identity is how requests identify the operator running sociopath.
+type Identity struct {
+	// UserAgent replaces UserAgent on requests that identify themselves.
+	UserAgent string
+	// Contact is an email address, sent as From, or a URL, sent as X-Contact, on
+	// requests that identify themselves.
+	Contact string
+	// Everywhere identifies requests that would otherwise emulate a browser, for the
+	// platforms that block unknown clients, too.
+	Everywhere bool
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx under which FetchURL identifies requests with id.
+// Clients that reach the network without FetchURL call PrepareRequest themselves.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// Identify sets the User-Agent and contact headers of req from the Identity in ctx.
+// Requests with a browser User-Agent are emulating a browser and are left alone unless
+// the Identity applies everywhere; other requests get UserAgent if ctx has no Identity.
+func Identify(ctx context.Context, req *http.Request) {
+	id, _ := ctx.Value(identityKey{}).(Identity) //nolint:errcheck // a missing value is the zero Identity
+	if isBrowserUserAgent(req.Header.Get("User-Agent")) && !id.Everywhere {
+		return
+	}
+	ua := UserAgent
+	if id.UserAgent != "" {
+		ua = id.UserAgent
+	}
+	req.Header.Set("User-Agent", ua)
+	if id.Contact == "" {
+		return
+	}
+	if addr, err := mail.ParseAddress(id.Contact); err == nil {
+		req.Header.Set("From", addr.Address)
+	} else {
+		req.Header.Set("X-Contact", id.Contact)
+	}
+}
+
+// isBrowserUserAgent reports whether ua claims to be a browser, as every browser's
+// does with its "Mozilla/5.0" prefix.
+func isBrowserUserAgent(ua string) bool {
+	return strings.HasPrefix(ua, "Mozilla/")
+}
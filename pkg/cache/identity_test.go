@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIdentify(t *testing.T) {
+	const browser = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0"
+	tests := []struct {
+		name        string
+		id          *Identity
+		ua          string
+		wantUA      string
+		wantFrom    string
+		wantContact string
+	}{
+		{name: "default", ua: "", wantUA: UserAgent},
+		{name: "default keeps browser", ua: browser, wantUA: browser},
+		{
+			name: "email contact", id: &Identity{UserAgent: "acme-research/2", Contact: "Ops <ops@acme.example>"},
+			ua: UserAgent, wantUA: "acme-research/2", wantFrom: "ops@acme.example",
+		},
+		{
+			name: "url contact", id: &Identity{Contact: "https://acme.example/bots"},
+			ua: UserAgent, wantUA: UserAgent, wantContact: "https://acme.example/bots",
+		},
+		{
+			name: "browser emulation kept", id: &Identity{UserAgent: "acme-research/2", Contact: "ops@acme.example"},
+			ua: browser, wantUA: browser,
+		},
+		{
+			name: "everywhere", id: &Identity{UserAgent: "acme-research/2", Contact: "ops@acme.example", Everywhere: true},
+			ua: browser, wantUA: "acme-research/2", wantFrom: "ops@acme.example",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.id != nil {
+				ctx = WithIdentity(ctx, *tt.id)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/", http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.ua != "" {
+				req.Header.Set("User-Agent", tt.ua)
+			}
+			Identify(ctx, req)
+			if got := req.Header.Get("User-Agent"); got != tt.wantUA {
+				t.Errorf("User-Agent = %q, want %q", got, tt.wantUA)
+			}
+			if got := req.Header.Get("From"); got != tt.wantFrom {
+				t.Errorf("From = %q, want %q", got, tt.wantFrom)
+			}
+			if got := req.Header.Get("X-Contact"); got != tt.wantContact {
+				t.Errorf("X-Contact = %q, want %q", got, tt.wantContact)
+			}
+		})
+	}
+}
+
+func TestFetchURLIdentifies(t *testing.T) {
+	var gotUA, gotFrom string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA, gotFrom = r.UserAgent(), r.Header.Get("From")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	ctx := WithIdentity(context.Background(), Identity{UserAgent: "acme-research/2", Contact: "ops@acme.example"})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/identify", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	if _, err := FetchURL(ctx, nil, server.Client(), req, nil); err != nil {
+		t.Fatalf("FetchURL() error = %v", err)
+	}
+	if gotUA != "acme-research/2" || gotFrom != "ops@acme.example" {
+		t.Errorf("server saw User-Agent %q, From %q", gotUA, gotFrom)
+	}
+}
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+)
+
+// Key returns the cache key FetchURL stores the response to req, sent with client,
+// under: the request's canonical URL followed by its variant dimensions.
+//
+// The canonical URL has a lowercase scheme and host, mirror hostnames replaced (see
+// links.MirrorHost, so x.com and twitter.com share entries), default ports, fragments,
+// and tracking parameters removed, and query parameters sorted. The variants are
+// "|auth=<hash>" when the request carries credentials, from client's cookie jar or its
+// own Cookie or Authorization header, naming a hash of those credentials;
+// "|lang=<tag>" for its preferred Accept-Language; and "|accept=<type>" when it asks
+// for a negotiated representation such as an ActivityPub actor. Authenticated,
+// localized, or negotiated responses are thus never served to other requests, and one
+// account's responses never to another's, from the cache or a shared in-flight fetch.
+func Key(req *http.Request, client *http.Client) string {
+	key := canonicalRequestURL(req.URL)
+
+	if id := credentialsHash(req, client); id != "" {
+		key += "|auth=" + id
+	}
+
+	if lang := preferredLanguage(req.Header.Get("Accept-Language")); lang != "" {
+		key += "|lang=" + lang
+	}
+
+	if accept := negotiatedType(req.Header.Get("Accept")); accept != "" {
+		key += "|accept=" + accept
+	}
+	return key
+}
+
+// credentialsHash returns a short hash of the credentials req would be sent with, or
+// "" if it has none. Only the hash goes into keys, which are logged.
+func credentialsHash(req *http.Request, client *http.Client) string {
+	cookie, authorization := req.Header.Get("Cookie"), req.Header.Get("Authorization")
+	var jarCookies []*http.Cookie
+	if client != nil && client.Jar != nil {
+		jarCookies = client.Jar.Cookies(req.URL)
+	}
+	if cookie == "" && authorization == "" && len(jarCookies) == 0 {
+		return ""
+	}
+	h := sha256.New()
+	// Each part is terminated, so values cannot run into the next part
+	_, _ = io.WriteString(h, cookie+"\x00"+authorization+"\x00") //nolint:errcheck // hashes do not fail
+	for _, c := range jarCookies {
+		_, _ = io.WriteString(h, c.Name+"="+c.Value+"\x00") //nolint:errcheck // hashes do not fail
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// negotiatedTypes are media types servers answer with a different document than the
+// page at the same URL, such as an ActivityPub actor instead of a profile page.
+var negotiatedTypes = []string{"application/activity+json", "application/ld+json"}
+
+// negotiatedType returns the first negotiated type an Accept header asks for, or "".
+func negotiatedType(header string) string {
+	for part := range strings.SplitSeq(header, ",") {
+		mediaType, _, _ := strings.Cut(part, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		for _, t := range negotiatedTypes {
+			if mediaType == t {
+				return t
+			}
+		}
+	}
+	return ""
+}
+
+// canonicalRequestURL returns u in the canonical form Key uses.
+func canonicalRequestURL(u *url.URL) string {
+	c, err := url.Parse(links.Canonicalize(u.String()))
+	if err != nil || c.Host == "" {
+		c = &url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path, RawPath: u.RawPath, RawQuery: u.RawQuery}
+	}
+	c.Scheme = strings.ToLower(c.Scheme)
+	host, port := strings.ToLower(c.Hostname()), c.Port()
+	host = links.MirrorHost(host)
+	if port != "" && !(c.Scheme == "https" && port == "443") && !(c.Scheme == "http" && port == "80") {
+		host += ":" + port
+	}
+	c.Host = host
+	c.Fragment, c.RawFragment = "", ""
+	if c.RawQuery != "" {
+		// Encode sorts by parameter name; values keep their order
+		c.RawQuery = c.Query().Encode()
+	}
+	return c.String()
+}
+
+// preferredLanguage returns the first language tag in an Accept-Language header, lowercased.
+func preferredLanguage(header string) string {
+	first, _, _ := strings.Cut(header, ",")
+	first, _, _ = strings.Cut(first, ";")
+	first = strings.ToLower(strings.TrimSpace(first))
+	if first == "*" {
+		return ""
+	}
+	return first
+}
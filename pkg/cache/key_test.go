@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		headers map[string]string
+		want    string
+	}{
+		{"mirror host", "https://x.com/alice", nil, "https://twitter.com/alice"},
+		{"case and port", "HTTPS://Twitter.com:443/alice#tweets", nil, "https://twitter.com/alice"},
+		{"sorted query without tracking", "https://example.com/p?b=2&utm_source=x&a=1", nil, "https://example.com/p?a=1&b=2"},
+		{"custom port kept", "http://localhost:8080/p", nil, "http://localhost:8080/p"},
+		{"path case kept", "https://github.com/TStromberg", nil, "https://github.com/TStromberg"},
+		{"locale", "https://example.com/", map[string]string{"Accept-Language": "de-DE,de;q=0.9,en;q=0.5"}, "https://example.com/|lang=de-de"},
+		{"any locale", "https://example.com/", map[string]string{"Accept-Language": "*"}, "https://example.com/"},
+		{"activitypub", "https://example.com/@alice", map[string]string{"Accept": `application/ld+json; profile="https://www.w3.org/ns/activitystreams", application/activity+json`}, "https://example.com/@alice|accept=application/ld+json"},
+		{"plain json", "https://example.com/api", map[string]string{"Accept": "application/json"}, "https://example.com/api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, http.NoBody) //nolint:noctx // no request is sent
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			if got := Key(req, http.DefaultClient); got != tt.want {
+				t.Errorf("Key() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyCookieJar(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse("https://www.linkedin.com/") //nolint:errcheck // constant URL
+	jar.SetCookies(u, []*http.Cookie{{Name: "li_at", Value: "token"}})
+	authed := &http.Client{Jar: jar}
+
+	req, err := http.NewRequest(http.MethodGet, "https://www.linkedin.com/in/alice/", http.NoBody) //nolint:noctx // no request is sent
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, anon := Key(req, authed), Key(req, http.DefaultClient)
+	if !strings.HasPrefix(got, anon+"|auth=") {
+		t.Errorf("Key() with cookies = %q, without = %q; want distinct |auth= variant", got, anon)
+	}
+
+	// Another account's session must not share entries with this one
+	other, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.SetCookies(u, []*http.Cookie{{Name: "li_at", Value: "other-token"}})
+	if o := Key(req, &http.Client{Jar: other}); o == got {
+		t.Errorf("Key() = %q for both accounts, want distinct keys", got)
+	}
+}
+
+func TestKeyCredentials(t *testing.T) {
+	key := func(header, value string) string {
+		req, err := http.NewRequest(http.MethodGet, "https://x.com/alice", http.NoBody) //nolint:noctx // no request is sent
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		return Key(req, http.DefaultClient)
+	}
+	anon := key("", "")
+	alice, bob := key("Cookie", "auth_token=alice"), key("Cookie", "auth_token=bob")
+	bearer := key("Authorization", "Bearer t")
+	for _, k := range []string{alice, bob, bearer} {
+		if !strings.HasPrefix(k, "https://twitter.com/alice|auth=") {
+			t.Errorf("Key() = %q, want an |auth= variant of %q", k, anon)
+		}
+		if strings.Contains(k, "auth_token") || strings.Contains(k, "Bearer") {
+			t.Errorf("Key() = %q contains the credentials", k)
+		}
+	}
+	if alice == bob || alice == bearer {
+		t.Errorf("Key() = %q, %q, %q; want a distinct key per credential", alice, bob, bearer)
+	}
+	if again := key("Cookie", "auth_token=alice"); again != alice {
+		t.Errorf("Key() = %q, then %q for the same credentials", alice, again)
+	}
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+)
+
+// DefaultMaxBodyBytes bounds a response body, after decompression, when no Limits
+// are set. The largest profile pages are a few megabytes.
+const DefaultMaxBodyBytes = 16 << 20
+
+// DefaultContentTypes are the media types FetchURL accepts when no Limits are set:
+// pages, JSON and XML APIs and feeds, and plain text such as keyserver listings.
+// Types ending in +json or +xml, such as application/activity+json and
+// application/atom+xml, are accepted along with their base type.
+var DefaultContentTypes = []string{
+	"text/html",
+	"application/xhtml+xml",
+	"application/json",
+	"application/xml",
+	"text/xml",
+	"text/plain",
+}
+
+// Limits restricts the responses FetchURL accepts, so a link to a video or an
+// archive is not downloaded in full to be parsed as a profile.
+type Limits struct {
+	// ContentTypes lists the media types accepted, without parameters. Responses
+	// without a Content-Type are accepted. Nil means DefaultContentTypes; a list
+	// holding only "*/*" accepts any type.
+	ContentTypes []string
+	// MaxBytes is the largest body accepted, after decompression. Zero means
+	// DefaultMaxBodyBytes; a negative value means no limit.
+	MaxBytes int64
+}
+
+// ContentTypeError is returned by FetchURL for a response whose Content-Type is not
+// among those allowed.
+type ContentTypeError struct {
+	URL         string
+	ContentType string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("unsupported content type %q fetching %s", e.ContentType, e.URL)
+}
+
+// BodyTooLargeError is returned by FetchURL for a response body larger than allowed.
+type BodyTooLargeError struct {
+	URL      string
+	MaxBytes int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("response body over %d bytes fetching %s", e.MaxBytes, e.URL)
+}
+
+// errBodyTooLarge is returned by readLimited; FetchURL reports it as a BodyTooLargeError.
+var errBodyTooLarge = errors.New("body too large")
+
+// readLimited reads r to the end, or fails with errBodyTooLarge once it has read more
+// than maxBytes. A negative maxBytes means no limit.
+func readLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes < 0 {
+		return io.ReadAll(r)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err == nil && int64(len(data)) > maxBytes {
+		return nil, errBodyTooLarge
+	}
+	return data, err
+}
+
+type limitsKey struct{}
+
+// WithLimits returns a copy of ctx under which FetchURL accepts only responses
+// within l. Cached responses were checked when stored and are not checked again.
+func WithLimits(ctx context.Context, l Limits) context.Context {
+	return context.WithValue(ctx, limitsKey{}, l)
+}
+
+// limitsFrom returns the Limits set by WithLimits, or the defaults.
+func limitsFrom(ctx context.Context) Limits {
+	l, _ := ctx.Value(limitsKey{}).(Limits) //nolint:errcheck // a missing value means the defaults
+	if l.ContentTypes == nil {
+		l.ContentTypes = DefaultContentTypes
+	}
+	if l.MaxBytes == 0 {
+		l.MaxBytes = DefaultMaxBodyBytes
+	}
+	return l
+}
+
+// allows reports whether l accepts a response with the Content-Type header value ct.
+func (l Limits) allows(ct string) bool {
+	if strings.TrimSpace(ct) == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	// application/activity+json is JSON to a parser that accepts application/json
+	base := mediaType
+	if slash := strings.IndexByte(mediaType, '/'); slash >= 0 {
+		if plus := strings.LastIndexByte(mediaType, '+'); plus > slash {
+			base = "application/" + mediaType[plus+1:]
+		}
+	}
+	for _, allowed := range l.ContentTypes {
+		allowed = strings.ToLower(allowed)
+		if allowed == "*/*" || allowed == mediaType || allowed == base {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitsAllows(t *testing.T) {
+	defaults := limitsFrom(context.Background())
+	for _, tt := range []struct {
+		contentType string
+		want        bool
+	}{
+		{"text/html; charset=utf-8", true},
+		{"TEXT/HTML", true},
+		{"application/json", true},
+		{"application/activity+json; charset=utf-8", true},
+		{"application/atom+xml", true},
+		{"text/plain", true},
+		{"", true},
+		{"video/mp4", false},
+		{"application/octet-stream", false},
+		{"image/png", false},
+		{"not a type", false},
+	} {
+		if got := defaults.allows(tt.contentType); got != tt.want {
+			t.Errorf("allows(%q) = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+
+	images := Limits{ContentTypes: []string{"image/*"}}
+	if !images.allows("image/png") || images.allows("text/html") {
+		t.Error("image/* should allow image/png and nothing else")
+	}
+	if !(Limits{ContentTypes: []string{"*/*"}}).allows("video/mp4") {
+		t.Error("*/* should allow any type")
+	}
+}
+
+func TestFetchURLLimits(t *testing.T) {
+	page := strings.Repeat("<p>profile</p>", 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/video":
+			w.Header().Set("Content-Type", "video/mp4")
+			_, _ = w.Write([]byte("\x00\x00\x00\x18ftypmp42"))
+		case "/gzip":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(compress(t, "gzip", page))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(page))
+		}
+	}))
+	defer server.Close()
+
+	fetch := func(ctx context.Context, path string) ([]byte, error) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return FetchURL(ctx, nil, server.Client(), req, nil)
+	}
+
+	ctx := context.Background()
+	if body, err := fetch(ctx, "/page"); err != nil || string(body) != page {
+		t.Errorf("FetchURL(/page) = %d bytes, %v; want the page", len(body), err)
+	}
+	var typeErr *ContentTypeError
+	if _, err := fetch(ctx, "/video"); !errors.As(err, &typeErr) || typeErr.ContentType != "video/mp4" {
+		t.Errorf("FetchURL(/video) error = %v, want a ContentTypeError for video/mp4", err)
+	}
+	if _, err := fetch(WithLimits(ctx, Limits{ContentTypes: []string{"video/*"}}), "/video"); err != nil {
+		t.Errorf("FetchURL(/video) allowing video/* error = %v", err)
+	}
+
+	// Both the body as sent and as decompressed are held to the limit
+	small := WithLimits(ctx, Limits{MaxBytes: int64(len(page) - 1)})
+	for _, path := range []string{"/page", "/gzip"} {
+		var sizeErr *BodyTooLargeError
+		if _, err := fetch(small, path); !errors.As(err, &sizeErr) || sizeErr.MaxBytes != int64(len(page)-1) {
+			t.Errorf("FetchURL(%s) over the limit error = %v, want a BodyTooLargeError", path, err)
+		}
+	}
+	if body, err := fetch(WithLimits(ctx, Limits{MaxBytes: int64(len(page))}), "/gzip"); err != nil || string(body) != page {
+		t.Errorf("FetchURL(/gzip) at the limit = %d bytes, %v; want the page", len(body), err)
+	}
+}
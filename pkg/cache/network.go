@@ -0,0 +1,316 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+	"weak"
+)
+
+// Network is how requests reach servers. The zero Network is Go's default: system
+// roots and resolver, no client certificate, and connections from any local address
+// to any address.
+//
+// Networks are compared by value to share connection pools, so callers should reuse
+// the same pool and certificate pointers rather than load them again per request.
+type Network struct {
+	// RootCAs verifies servers in place of the system roots, for example to trust a
+	// corporate proxy that intercepts TLS.
+	RootCAs *x509.CertPool
+	// Certificate is presented to servers that ask for a client certificate.
+	Certificate *tls.Certificate
+	// LocalAddr is the source address of connections, for hosts with several egress
+	// addresses. Connections then use its address family.
+	LocalAddr netip.Addr
+	// IPVersion restricts connections to IPv4 (4) or IPv6 (6); 0 allows both.
+	IPVersion int
+	// Resolver looks up hosts in place of the system resolver. It must be comparable,
+	// as pointers are.
+	Resolver Resolver
+	// PublicOnly refuses connections to loopback, private, link-local, and unspecified
+	// addresses, checking the addresses Resolver returns so that what is checked is
+	// what is dialed. Connections to a proxy are not checked; the proxy picks the
+	// address of the host it forwards to.
+	PublicOnly bool
+}
+
+// ErrPrivateAddress is returned for connections a PublicOnly Network refuses.
+var ErrPrivateAddress = errors.New("blocked: private address")
+
+type networkKey struct{}
+
+// WithNetwork returns a copy of ctx under which FetchURL sends requests over n.
+// Clients that reach the network without FetchURL use Client, or DialContext and
+// TLSConfig.
+func WithNetwork(ctx context.Context, n Network) context.Context {
+	return context.WithValue(ctx, networkKey{}, n)
+}
+
+// WithPublicOnly returns a copy of ctx whose Network refuses connections to private
+// addresses, for fetching URLs that come from untrusted pages.
+func WithPublicOnly(ctx context.Context) context.Context {
+	n := NetworkFrom(ctx)
+	n.PublicOnly = true
+	return WithNetwork(ctx, n)
+}
+
+// NetworkFrom returns the Network set by WithNetwork, or the zero Network.
+func NetworkFrom(ctx context.Context) Network {
+	n, _ := ctx.Value(networkKey{}).(Network) //nolint:errcheck // a missing value is the zero Network
+	return n
+}
+
+// TLSConfig returns the TLS configuration for connecting to serverName over n.
+func (n Network) TLSConfig(serverName string) *tls.Config {
+	cfg := &tls.Config{ServerName: serverName, RootCAs: n.RootCAs, MinVersion: tls.VersionTLS12}
+	if n.Certificate != nil {
+		cfg.Certificates = []tls.Certificate{*n.Certificate}
+	}
+	return cfg
+}
+
+// dialTimeout and dialKeepAlive match http.DefaultTransport's dialer.
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+// dials reports whether n changes how connections are dialed.
+func (n Network) dials() bool {
+	return n.LocalAddr.IsValid() || n.IPVersion != 0 || n.Resolver != nil || n.PublicOnly
+}
+
+// DialContext connects to address over n. The network "tcp" becomes "tcp4" or "tcp6"
+// as n's IPVersion or LocalAddr requires, and the host is looked up with n's Resolver.
+func (n Network) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d := &net.Dialer{Timeout: dialTimeout, KeepAlive: dialKeepAlive}
+	version := n.IPVersion
+	if n.LocalAddr.IsValid() {
+		d.LocalAddr = &net.TCPAddr{IP: n.LocalAddr.AsSlice(), Zone: n.LocalAddr.Zone()}
+		if version == 0 {
+			version = 6
+			if n.LocalAddr.Unmap().Is4() {
+				version = 4
+			}
+		}
+	}
+	if network == "tcp" && version != 0 {
+		network += strconv.Itoa(version)
+	}
+	if n.Resolver == nil && !n.PublicOnly {
+		return d.DialContext(ctx, network, address)
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := n.lookup(ctx, version, host)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, addr := range addrs {
+		if n.PublicOnly && !isPublic(addr) {
+			errs = append(errs, fmt.Errorf("%w: %s is %s", ErrPrivateAddress, host, addr))
+			continue
+		}
+		conn, err := d.DialContext(ctx, network, net.JoinHostPort(addr.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, &net.DNSError{Err: "no addresses", Name: host, IsNotFound: true}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// lookup returns host's addresses of the given IP version, or of both for 0.
+func (n Network) lookup(ctx context.Context, version int, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr}, nil
+	}
+	resolver := n.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	network := "ip"
+	if version != 0 {
+		network += strconv.Itoa(version)
+	}
+	addrs, err := resolver.LookupNetIP(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+	// Resolvers need not honor network, so filter here
+	out := addrs[:0]
+	for _, addr := range addrs {
+		addr = addr.Unmap()
+		if version == 4 && !addr.Is4() || version == 6 && !addr.Is6() {
+			continue
+		}
+		out = append(out, addr)
+	}
+	return out, nil
+}
+
+// nonPublicPrefixes are ranges netip has no predicate for that still reach the host
+// or its network: "this network" (0.0.0.0/8, which some systems dial as the host),
+// carrier-grade NAT shared space, and NAT64 and 6to4, which embed any IPv4 address,
+// private ones included.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// isPublic reports whether addr is neither loopback, private, link-local, multicast,
+// nor unspecified, nor in nonPublicPrefixes, the addresses that reach the host or its
+// network.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// InterfaceAddr returns the first address of the named network interface, of the
+// given IP version if it is not 0, for binding connections to that interface.
+func InterfaceAddr(name string, ipVersion int) (netip.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	for _, a := range addrs {
+		prefix, err := netip.ParsePrefix(a.String())
+		if err != nil {
+			continue
+		}
+		addr := prefix.Addr()
+		if addr.IsLinkLocalUnicast() || ipVersion == 4 && !addr.Is4() || ipVersion == 6 && !addr.Is6() {
+			continue
+		}
+		return addr, nil
+	}
+	return netip.Addr{}, fmt.Errorf("interface %s has no usable address", name)
+}
+
+// derivedKey identifies a transport derived from base for a Network. The base is held
+// weakly so clients' own transports, such as per-proxy ones, can still be collected.
+type derivedKey struct {
+	base    weak.Pointer[http.Transport]
+	network Network
+}
+
+// derived holds the transports Client derives, so requests over the same Network
+// share connections.
+var derived sync.Map // derivedKey -> *http.Transport
+
+// Client returns client, or a copy sending requests over the Network in ctx. Its
+// transport, or SharedTransport if it has none, is cloned with the Network applied;
+// other http.RoundTripper implementations are used as they are.
+func Client(ctx context.Context, client *http.Client) *http.Client {
+	n := NetworkFrom(ctx)
+	if n == (Network{}) {
+		return client
+	}
+	base := client.Transport
+	if base == nil {
+		base = SharedTransport
+	}
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return client
+	}
+	key := derivedKey{base: weak.Make(t), network: n}
+	if v, ok := derived.Load(key); ok {
+		return withTransport(client, v.(*http.Transport)) //nolint:errcheck,forcetypeassert // only transports are stored
+	}
+
+	d := t.Clone()
+	cfg := n.TLSConfig("")
+	if t.TLSClientConfig != nil {
+		cfg = t.TLSClientConfig.Clone()
+		if n.RootCAs != nil {
+			cfg.RootCAs = n.RootCAs
+		}
+		if n.Certificate != nil {
+			cfg.Certificates = []tls.Certificate{*n.Certificate}
+		}
+	}
+	d.TLSClientConfig = cfg
+	if n.dials() {
+		d.DialContext = n.DialContext
+		if n.PublicOnly && d.Proxy != nil {
+			d.Proxy, d.DialContext = exemptProxies(d.Proxy, n)
+		}
+	}
+	v, loaded := derived.LoadOrStore(key, d)
+	if !loaded {
+		runtime.AddCleanup(t, func(key derivedKey) {
+			if v, ok := derived.LoadAndDelete(key); ok {
+				v.(*http.Transport).CloseIdleConnections() //nolint:errcheck,forcetypeassert // only transports are stored
+			}
+		}, key)
+	}
+	return withTransport(client, v.(*http.Transport)) //nolint:errcheck,forcetypeassert // only transports are stored
+}
+
+// exemptProxies wraps a transport's proxy function to note the proxies it chooses, and
+// returns a dial function for n that connects to them without PublicOnly's checks.
+func exemptProxies(proxy func(*http.Request) (*url.URL, error), n Network) (
+	func(*http.Request) (*url.URL, error), func(context.Context, string, string) (net.Conn, error),
+) {
+	var proxies sync.Map // host:port -> true
+	direct := n
+	direct.PublicOnly = false
+	return func(req *http.Request) (*url.URL, error) {
+			u, err := proxy(req)
+			if u != nil {
+				port := u.Port()
+				if port == "" {
+					port = "80"
+					if u.Scheme == "https" {
+						port = "443"
+					}
+				}
+				proxies.Store(net.JoinHostPort(u.Hostname(), port), true)
+			}
+			return u, err
+		}, func(ctx context.Context, network, address string) (net.Conn, error) {
+			if _, ok := proxies.Load(address); ok {
+				return direct.DialContext(ctx, network, address)
+			}
+			return n.DialContext(ctx, network, address)
+		}
+}
+
+func withTransport(client *http.Client, t *http.Transport) *http.Client {
+	c := *client
+	c.Transport = t
+	return &c
+}
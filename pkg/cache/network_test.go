@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientNetwork(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			http.Error(w, "no client certificate", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequestClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	cert := server.TLS.Certificates[0]
+
+	fetch := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/network", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = FetchURL(ctx, nil, &http.Client{}, req, nil)
+		return err
+	}
+
+	if err := fetch(context.Background()); err == nil {
+		t.Fatal("fetch with system roots succeeded, want an unknown authority error")
+	}
+	if err := fetch(WithNetwork(context.Background(), Network{RootCAs: roots})); err == nil {
+		t.Fatal("fetch without a client certificate succeeded")
+	}
+	n := Network{RootCAs: roots, Certificate: &cert}
+	if err := fetch(WithNetwork(context.Background(), n)); err != nil {
+		t.Fatalf("fetch with roots and certificate error = %v", err)
+	}
+
+	client := &http.Client{}
+	ctx := WithNetwork(context.Background(), n)
+	if Client(context.Background(), client) != client {
+		t.Error("Client() without a Network changed the client")
+	}
+	if a, b := Client(ctx, client), Client(ctx, client); a.Transport != b.Transport {
+		t.Error("Client() derived two transports for the same Network")
+	}
+	if client.Transport != nil {
+		t.Error("Client() modified the client it was given")
+	}
+}
+
+func TestNetworkDialContext(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = ln.Close() }()
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = c.Close()
+		}
+	}()
+	addr := ln.Addr().String()
+	ctx := context.Background()
+
+	n := Network{LocalAddr: netip.MustParseAddr("127.0.0.1")}
+	conn, err := n.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		t.Fatalf("DialContext() from 127.0.0.1 error = %v", err)
+	}
+	if got := conn.LocalAddr().(*net.TCPAddr).IP.String(); got != "127.0.0.1" {
+		t.Errorf("local address = %s, want 127.0.0.1", got)
+	}
+	_ = conn.Close()
+
+	if conn, err := (Network{IPVersion: 6}).DialContext(ctx, "tcp", addr); err == nil {
+		_ = conn.Close()
+		t.Error("IPv6-only DialContext() reached an IPv4 address")
+	}
+	if conn, err := (Network{IPVersion: 4}).DialContext(ctx, "tcp", addr); err != nil {
+		t.Errorf("IPv4-only DialContext() error = %v", err)
+	} else {
+		_ = conn.Close()
+	}
+}
+
+// staticResolver resolves every host to addrs.
+type staticResolver struct{ addrs []netip.Addr }
+
+func (r *staticResolver) LookupNetIP(context.Context, string, string) ([]netip.Addr, error) {
+	return r.addrs, nil
+}
+
+func TestNetworkPublicOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A public-looking name that the resolver points at the loopback server
+	address := net.JoinHostPort("intranet.example.com", port)
+	loopback := &staticResolver{addrs: []netip.Addr{netip.MustParseAddr("127.0.0.1")}}
+	ctx := context.Background()
+
+	conn, err := (Network{Resolver: loopback}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		t.Fatalf("DialContext() with resolver error = %v", err)
+	}
+	_ = conn.Close()
+
+	if _, err := (Network{Resolver: loopback, PublicOnly: true}).DialContext(ctx, "tcp", address); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("PublicOnly DialContext() error = %v, want ErrPrivateAddress", err)
+	}
+
+	ctx = WithPublicOnly(WithNetwork(ctx, Network{Resolver: loopback}))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+"/admin", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FetchURL(ctx, nil, &http.Client{}, req, nil); !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("PublicOnly FetchURL() error = %v, want ErrPrivateAddress", err)
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.215.14":         true,
+		"2606:2800:21f:cb07::1": true,
+		"100.63.255.255":        true,
+		"100.128.0.1":           true,
+		"127.0.0.1":             false,
+		"10.1.2.3":              false,
+		"169.254.169.254":       false,
+		"::ffff:192.168.1.1":    false,
+		"fd00::1":               false,
+		"0.0.0.0":               false,
+		"0.1.2.3":               false,
+		"100.64.0.1":            false,
+		"100.127.255.254":       false,
+		"64:ff9b::7f00:1":       false,
+		"64:ff9b::a00:1":        false,
+		"224.0.0.1":             false,
+		"239.255.255.250":       false,
+		"ff02::1":               false,
+		"ff05::2":               false,
+		"2002:7f00:1::1":        false,
+		"2002:5db8:d70e::1":     false,
+	} {
+		if got := isPublic(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("isPublic(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package cache
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotCached is returned in offline mode for responses that are not in the cache.
+var ErrNotCached = errors.New("not cached")
+
+type offlineKey struct{}
+
+// WithOffline returns a copy of ctx under which FetchURL answers only from the cache,
+// returning ErrNotCached instead of making a request. Clients that reach the network
+// without FetchURL check IsOffline themselves.
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
+// IsOffline reports whether ctx was returned by WithOffline.
+func IsOffline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey{}).(bool) //nolint:errcheck // a missing value means online
+	return offline
+}
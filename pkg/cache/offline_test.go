@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetchURLOffline(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	httpCache, err := NewWithPath(time.Hour, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWithPath() error = %v", err)
+	}
+	defer func() { _ = httpCache.Close() }()
+
+	offline := WithOffline(context.Background())
+	if !IsOffline(offline) || IsOffline(context.Background()) {
+		t.Fatal("IsOffline() does not follow WithOffline")
+	}
+
+	fetch := func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/offline", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return FetchURL(ctx, httpCache, server.Client(), req, nil)
+	}
+
+	if _, err := fetch(offline); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("offline miss error = %v, want ErrNotCached", err)
+	}
+	if requests != 0 {
+		t.Fatalf("offline miss made %d requests", requests)
+	}
+
+	if _, err := fetch(context.Background()); err != nil {
+		t.Fatalf("online fetch error = %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	body, err := fetch(offline)
+	if err != nil || string(body) != "fresh" {
+		t.Errorf("offline hit = %q, %v; want the cached body", body, err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrPolitenessLimit is returned without making a request when a domain's politeness
+// policy forbids it right now: outside its allowed hours, or over its daily limit.
+var ErrPolitenessLimit = errors.New("politeness limit reached")
+
+// Politeness holds per-domain request policies. It is loaded from JSON such as:
+//
+//	{
+//	  "default": {"min_delay": "200ms"},
+//	  "domains": {
+//	    "linkedin.com": {
+//	      "min_delay": "3s",
+//	      "max_concurrency": 1,
+//	      "max_per_day": 200,
+//	      "windows": [{"start": "09:00", "end": "18:00", "timezone": "America/New_York"}]
+//	    }
+//	  }
+//	}
+//
+// A domain policy applies to the domain and its subdomains; the longest match wins.
+type Politeness struct {
+	Domains map[string]DomainPolicy `json:"domains,omitempty"`
+	Default DomainPolicy            `json:"default"`
+}
+
+// DomainPolicy limits requests to one domain. Zero values mean no limit.
+type DomainPolicy struct {
+	Windows        []TimeWindow `json:"windows,omitempty"`         // Hours requests are allowed; any window matches
+	MinDelay       Duration     `json:"min_delay,omitempty"`       // Minimum time between requests
+	MaxConcurrency int          `json:"max_concurrency,omitempty"` // Requests in flight at once
+	MaxPerDay      int          `json:"max_per_day,omitempty"`     // Requests per calendar day (UTC)
+}
+
+// TimeWindow is a daily time range such as 09:00-18:00. A window whose end is
+// before its start wraps past midnight.
+type TimeWindow struct {
+	Start    string `json:"start"`              // "HH:MM"
+	End      string `json:"end"`                // "HH:MM"
+	Timezone string `json:"timezone,omitempty"` // IANA name; default UTC
+}
+
+// Duration is a time.Duration that encodes to JSON as a string like "1.5s".
+type Duration time.Duration
+
+// UnmarshalJSON accepts a duration string ("500ms", "2s") or a number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("duration must be a string like \"2s\": %s", data)
+		}
+		*d = Duration(n)
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalJSON encodes d as a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadPoliteness reads a politeness config from a JSON file.
+func LoadPoliteness(path string) (*Politeness, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ParsePoliteness(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ParsePoliteness parses and validates a JSON politeness config.
+func ParsePoliteness(data []byte) (*Politeness, error) {
+	var p Politeness
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	if err := p.Default.validate(); err != nil {
+		return nil, fmt.Errorf("default: %w", err)
+	}
+	normalized := make(map[string]DomainPolicy, len(p.Domains))
+	for domain, policy := range p.Domains {
+		if err := policy.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", domain, err)
+		}
+		normalized[strings.TrimPrefix(strings.ToLower(domain), "www.")] = policy
+	}
+	p.Domains = normalized
+	return &p, nil
+}
+
+func (d DomainPolicy) validate() error {
+	if d.MinDelay < 0 || d.MaxConcurrency < 0 || d.MaxPerDay < 0 {
+		return errors.New("limits must not be negative")
+	}
+	for _, w := range d.Windows {
+		if _, _, _, err := w.parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyFor returns the policy for host and the domain key it was found under.
+// Hosts without a domain policy get the default policy under their own name.
+func (p *Politeness) policyFor(host string) (policy DomainPolicy, key string) {
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.Contains(host[i:], "]") {
+		host = host[:i]
+	}
+	for d := host; d != ""; {
+		if policy, ok := p.Domains[d]; ok {
+			return policy, d
+		}
+		i := strings.IndexByte(d, '.')
+		if i < 0 {
+			break
+		}
+		d = d[i+1:]
+	}
+	return p.Default, host
+}
+
+// allowedAt reports whether t falls in one of the policy's windows, or true if there are none.
+func (d DomainPolicy) allowedAt(t time.Time) bool {
+	if len(d.Windows) == 0 {
+		return true
+	}
+	for _, w := range d.Windows {
+		start, end, loc, err := w.parse()
+		if err != nil {
+			continue
+		}
+		local := t.In(loc)
+		minute := local.Hour()*60 + local.Minute()
+		if start <= end && minute >= start && minute < end {
+			return true
+		}
+		if start > end && (minute >= start || minute < end) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse returns the window bounds in minutes after midnight and its location.
+func (w TimeWindow) parse() (start, end int, loc *time.Location, err error) {
+	loc = time.UTC
+	if w.Timezone != "" {
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return 0, 0, nil, err
+		}
+	}
+	s, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("window start %q: want HH:MM", w.Start)
+	}
+	e, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("window end %q: want HH:MM", w.End)
+	}
+	return s.Hour()*60 + s.Minute(), e.Hour()*60 + e.Minute(), loc, nil
+}
+
+// SetPoliteness applies p to all requests made through FetchURL. A nil p removes it.
+func SetPoliteness(p *Politeness) {
+	globalRateLimiter.SetPoliteness(p)
+}
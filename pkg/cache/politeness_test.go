@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testPoliteness = `{
+	"default": {"min_delay": "10ms"},
+	"domains": {
+		"www.LinkedIn.com": {
+			"min_delay": "1ms",
+			"max_concurrency": 1,
+			"max_per_day": 2,
+			"windows": [{"start": "09:00", "end": "18:00"}, {"start": "22:00", "end": "02:00"}]
+		}
+	}
+}`
+
+func TestParsePoliteness(t *testing.T) {
+	p, err := ParsePoliteness([]byte(testPoliteness))
+	if err != nil {
+		t.Fatalf("ParsePoliteness() error = %v", err)
+	}
+
+	tests := []struct {
+		host    string
+		wantKey string
+		wantMax int
+	}{
+		{"www.linkedin.com", "linkedin.com", 2},
+		{"api.linkedin.com:443", "linkedin.com", 2},
+		{"github.com", "github.com", 0},
+	}
+	for _, tt := range tests {
+		policy, key := p.policyFor(tt.host)
+		if key != tt.wantKey || policy.MaxPerDay != tt.wantMax {
+			t.Errorf("policyFor(%q) = %q/%d, want %q/%d", tt.host, key, policy.MaxPerDay, tt.wantKey, tt.wantMax)
+		}
+	}
+	if p.Default.MinDelay != Duration(10*time.Millisecond) {
+		t.Errorf("default MinDelay = %v, want 10ms", time.Duration(p.Default.MinDelay))
+	}
+
+	for _, bad := range []string{
+		`{"default": {"min_delay": "soon"}}`,
+		`{"domains": {"x.com": {"windows": [{"start": "9am", "end": "5pm"}]}}}`,
+		`{"domains": {"x.com": {"max_per_day": -1}}}`,
+	} {
+		if _, err := ParsePoliteness([]byte(bad)); err == nil {
+			t.Errorf("ParsePoliteness(%s) should fail", bad)
+		}
+	}
+}
+
+func TestDomainPolicyAllowedAt(t *testing.T) {
+	p, err := ParsePoliteness([]byte(testPoliteness))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy, _ := p.policyFor("linkedin.com")
+
+	tests := []struct {
+		hour int
+		want bool
+	}{
+		{8, false},
+		{9, true},
+		{17, true},
+		{18, false},
+		{23, true}, // wrapping window
+		{1, true},
+		{3, false},
+	}
+	for _, tt := range tests {
+		at := time.Date(2025, 1, 1, tt.hour, 30, 0, 0, time.UTC)
+		if got := policy.allowedAt(at); got != tt.want {
+			t.Errorf("allowedAt(%02d:30) = %v, want %v", tt.hour, got, tt.want)
+		}
+	}
+}
+
+func TestAcquirePoliteness(t *testing.T) {
+	p, err := ParsePoliteness([]byte(testPoliteness))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	r := NewDomainRateLimiter(0)
+	r.now = func() time.Time { return now }
+	r.SetPoliteness(p)
+	ctx := context.Background()
+
+	// Daily limit of 2 across www and bare host
+	for _, u := range []string{"https://www.linkedin.com/in/a", "https://linkedin.com/in/b"} {
+		release, err := r.Acquire(ctx, u)
+		if err != nil {
+			t.Fatalf("Acquire(%s) error = %v", u, err)
+		}
+		release()
+	}
+	if _, err := r.Acquire(ctx, "https://www.linkedin.com/in/c"); !errors.Is(err, ErrPolitenessLimit) {
+		t.Errorf("Acquire() over daily limit error = %v, want ErrPolitenessLimit", err)
+	}
+
+	// The next day the count resets, but only inside the allowed hours
+	now = now.Add(24 * time.Hour)
+	release, err := r.Acquire(ctx, "https://www.linkedin.com/in/c")
+	if err != nil {
+		t.Fatalf("Acquire() next day error = %v", err)
+	}
+
+	// A second concurrent request waits for the slot
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := r.Acquire(waitCtx, "https://www.linkedin.com/in/d"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() with slot taken error = %v, want DeadlineExceeded", err)
+	}
+	release()
+
+	now = now.Add(10 * time.Hour) // 20:00
+	if _, err := r.Acquire(ctx, "https://www.linkedin.com/in/e"); !errors.Is(err, ErrPolitenessLimit) {
+		t.Errorf("Acquire() outside window error = %v, want ErrPolitenessLimit", err)
+	}
+
+	// Other domains only get the default delay
+	if release, err := r.Acquire(ctx, "https://github.com/a"); err != nil {
+		t.Errorf("Acquire() for default domain error = %v", err)
+	} else {
+		release()
+	}
+}
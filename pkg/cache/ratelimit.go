@@ -1,27 +1,49 @@
 package cache
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// DomainRateLimiter enforces a minimum delay between requests to the same domain.
+// DomainRateLimiter enforces a minimum delay between requests to the same domain,
+// plus any limits from a Politeness config.
 // It is safe for concurrent use from multiple goroutines.
 type DomainRateLimiter struct {
 	domainOverride map[string]time.Duration // per-domain minimum delays
 	lastRequest    sync.Map                 // map[string]time.Time
 	mu             sync.Map                 // map[string]*sync.Mutex - per-domain locks
+	slots          sync.Map                 // map[string]chan struct{} - per-domain concurrency slots
+	politeness     atomic.Pointer[Politeness]
+	shared         atomic.Pointer[sharedLimiter]
+	daily          map[string]dailyCount // per-domain request counts for MaxPerDay
+	now            func() time.Time
+	dailyMu        sync.Mutex
 	minDelay       time.Duration
 }
 
+// sharedLimiter boxes a SharedLimiter for atomic.Pointer.
+type sharedLimiter struct {
+	SharedLimiter
+}
+
+type dailyCount struct {
+	day   string
+	count int
+}
+
 // NewDomainRateLimiter creates a rate limiter that enforces minDelay between
 // requests to the same domain. Domain-specific overrides can be set with SetDomainDelay.
 func NewDomainRateLimiter(minDelay time.Duration) *DomainRateLimiter {
 	return &DomainRateLimiter{
 		minDelay:       minDelay,
 		domainOverride: make(map[string]time.Duration),
+		daily:          make(map[string]dailyCount),
+		now:            time.Now,
 	}
 }
 
@@ -31,20 +53,107 @@ func (r *DomainRateLimiter) SetDomainDelay(domain string, delay time.Duration) {
 	r.domainOverride[domain] = delay
 }
 
+// SetPoliteness applies per-domain policies on top of the built-in delays. Policy
+// delays can only slow requests down. Changing the config resets concurrency slots
+// for requests that start afterwards. A nil p removes the policies.
+func (r *DomainRateLimiter) SetPoliteness(p *Politeness) {
+	r.politeness.Store(p)
+	r.slots.Clear()
+}
+
+// SetSharedLimiter makes the limiter also wait for a slot from l, which rations each
+// domain's delay among processes. Concurrency and daily limits stay per process. If l
+// fails, requests are limited by this process alone. A nil l removes it.
+func (r *DomainRateLimiter) SetSharedLimiter(l SharedLimiter) {
+	if l == nil {
+		r.shared.Store(nil)
+		return
+	}
+	r.shared.Store(&sharedLimiter{l})
+}
+
 // Wait blocks until it's safe to make a request to the given URL's domain.
 // It ensures at least minDelay has passed since the last request to that domain.
+// Politeness limits other than delays are not applied; use Acquire for those.
 func (r *DomainRateLimiter) Wait(rawURL string) {
 	u, err := url.Parse(rawURL)
 	if err != nil || u.Host == "" {
 		return
 	}
-	domain := u.Host
+	_ = r.delay(context.Background(), u.Host, u.Host, 0) //nolint:errcheck // background context never expires
+}
 
+// Acquire waits until a request to rawURL is allowed and returns a function that
+// must be called when the request finishes. It returns ErrPolitenessLimit if the
+// domain's policy forbids the request right now, or ctx's error if ctx ends first.
+func (r *DomainRateLimiter) Acquire(ctx context.Context, rawURL string) (release func(), err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return func() {}, nil //nolint:nilerr // unparseable URLs fail later in the HTTP client
+	}
+	p := r.politeness.Load()
+	if p == nil {
+		return func() {}, r.delay(ctx, u.Host, u.Host, 0)
+	}
+
+	policy, key := p.policyFor(u.Host)
+	now := r.now()
+	if !policy.allowedAt(now) {
+		return nil, fmt.Errorf("%w: %s is outside its allowed hours", ErrPolitenessLimit, key)
+	}
+
+	release = func() {}
+	if policy.MaxConcurrency > 0 {
+		slotsI, _ := r.slots.LoadOrStore(key, make(chan struct{}, policy.MaxConcurrency))
+		slots, ok := slotsI.(chan struct{})
+		if ok {
+			select {
+			case slots <- struct{}{}:
+				release = func() { <-slots }
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if err := r.delay(ctx, key, u.Host, time.Duration(policy.MinDelay)); err != nil {
+		release()
+		return nil, err
+	}
+
+	if policy.MaxPerDay > 0 && !r.countDaily(key, policy.MaxPerDay) {
+		release()
+		return nil, fmt.Errorf("%w: %s reached %d requests today", ErrPolitenessLimit, key, policy.MaxPerDay)
+	}
+	return release, nil
+}
+
+// countDaily records a request to key, or reports false if key is at its daily limit.
+func (r *DomainRateLimiter) countDaily(key string, limit int) bool {
+	r.dailyMu.Lock()
+	defer r.dailyMu.Unlock()
+
+	day := r.now().UTC().Format(time.DateOnly)
+	c := r.daily[key]
+	if c.day != day {
+		c = dailyCount{day: day}
+	}
+	if c.count >= limit {
+		return false
+	}
+	c.count++
+	r.daily[key] = c
+	return true
+}
+
+// delay sleeps until the longest of the default delay, host's override, and
+// policyDelay has passed since the last request to key.
+func (r *DomainRateLimiter) delay(ctx context.Context, key, host string, policyDelay time.Duration) error {
 	// Get or create per-domain mutex
-	muI, _ := r.mu.LoadOrStore(domain, &sync.Mutex{})
+	muI, _ := r.mu.LoadOrStore(key, &sync.Mutex{})
 	mu, ok := muI.(*sync.Mutex)
 	if !ok {
-		return
+		return nil
 	}
 
 	mu.Lock()
@@ -52,22 +161,69 @@ func (r *DomainRateLimiter) Wait(rawURL string) {
 
 	// Use domain-specific delay if set, otherwise use default
 	delay := r.minDelay
-	if override, ok := r.domainOverride[domain]; ok {
+	if override, ok := r.domainOverride[host]; ok {
 		delay = override
 	}
+	delay = max(delay, policyDelay)
 
 	// Check last request time
-	if lastI, ok := r.lastRequest.Load(domain); ok {
+	if lastI, ok := r.lastRequest.Load(key); ok {
 		if last, ok := lastI.(time.Time); ok {
 			elapsed := time.Since(last)
 			if elapsed < delay {
 				waitTime := delay - elapsed
-				slog.Debug("rate limiting request", "domain", domain, "wait", waitTime.Round(time.Millisecond))
-				time.Sleep(waitTime)
+				slog.Debug("rate limiting request", "domain", key, "wait", waitTime.Round(time.Millisecond))
+				timer := time.NewTimer(waitTime)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				}
 			}
 		}
 	}
 
+	if shared := r.shared.Load(); shared != nil && delay > 0 {
+		if err := r.waitShared(ctx, shared, key, delay); err != nil {
+			return err
+		}
+	}
+
 	// Record this request
-	r.lastRequest.Store(domain, time.Now())
+	r.lastRequest.Store(key, time.Now())
+	return nil
+}
+
+// waitShared waits for the slot shared reserves for key.
+func (*DomainRateLimiter) waitShared(ctx context.Context, shared *sharedLimiter, key string, delay time.Duration) error {
+	wait, err := shared.Reserve(ctx, key, delay)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		slog.Warn("shared rate limiter failed, limiting locally", "domain", key, "error", err)
+		return nil
+	}
+	if wait <= 0 {
+		return nil
+	}
+	slog.Debug("rate limiting request for other processes", "domain", key, "wait", wait.Round(time.Millisecond))
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// MinDelay returns the built-in minimum delay between requests to host, before any
+// Politeness config is applied.
+func MinDelay(host string) time.Duration {
+	if d, ok := globalRateLimiter.domainOverride[host]; ok {
+		return d
+	}
+	return globalRateLimiter.minDelay
 }
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // Redis names scripts by their SHA-1 digest
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+)
+
+// SharedLimiter rations requests to a domain among processes, so that together
+// they keep to the delays each would keep alone.
+type SharedLimiter interface {
+	// Reserve takes the next slot for a request to key, where slots are interval apart,
+	// and returns how long the caller must wait before using it.
+	Reserve(ctx context.Context, key string, interval time.Duration) (time.Duration, error)
+}
+
+// reserveScript is a token bucket, kept as its theoretical arrival time (GCRA): the
+// time at which the bucket would be full again. Times come from the server's clock,
+// so the processes sharing a bucket need not agree on theirs.
+//
+// KEYS[1] is the bucket; ARGV[1] the interval between tokens and ARGV[2] the bucket
+// size. It returns the milliseconds to wait for the token it takes.
+const reserveScript = `
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then tat = now end
+local wait = tat - (burst - 1) * interval - now
+if wait < 0 then wait = 0 end
+redis.call('SET', KEYS[1], tat + interval, 'PX', tat + interval - now + 1000)
+return wait
+`
+
+var reserveScriptSHA = func() string {
+	sum := sha1.Sum([]byte(reserveScript)) //nolint:gosec // script ID, not security
+	return hex.EncodeToString(sum[:])
+}()
+
+// RedisLimiter is a SharedLimiter keeping a token bucket per domain in Redis.
+type RedisLimiter struct {
+	client *redis.Client
+	burst  int
+}
+
+var _ SharedLimiter = (*RedisLimiter)(nil)
+
+// NewRedisLimiter returns a limiter whose buckets hold burst tokens, so that up to
+// burst requests to a domain that has been idle go out at once. Use 1 to keep every
+// request a full interval apart.
+func NewRedisLimiter(client *redis.Client, burst int) *RedisLimiter {
+	return &RedisLimiter{client: client, burst: max(burst, 1)}
+}
+
+// Reserve takes a token from key's bucket, refilled one per interval.
+func (l *RedisLimiter) Reserve(ctx context.Context, key string, interval time.Duration) (time.Duration, error) {
+	args := []string{"1", "sociopath:ratelimit:" + key, strconv.FormatInt(interval.Milliseconds(), 10), strconv.Itoa(l.burst)}
+	ms, err := l.client.Int(ctx, append([]string{"EVALSHA", reserveScriptSHA}, args...)...)
+	var replyErr redis.Error
+	if errors.As(err, &replyErr) && strings.HasPrefix(string(replyErr), "NOSCRIPT") {
+		// First use on this server; EVAL caches the script for later EVALSHAs
+		ms, err = l.client.Int(ctx, append([]string{"EVAL", reserveScript}, args...)...)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// SetSharedLimiter makes requests made through FetchURL also keep to l, so that
+// several processes share per-domain delays. A nil l removes it.
+func SetSharedLimiter(l SharedLimiter) {
+	globalRateLimiter.SetSharedLimiter(l)
+}
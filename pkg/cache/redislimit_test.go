@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis/redistest"
+)
+
+func TestRedisLimiter(t *testing.T) {
+	srv, err := redistest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close() //nolint:errcheck // test
+
+	// The script's logic in Go, on a clock that does not move
+	const now = int64(1_000_000)
+	tats := make(map[string]int64)
+	srv.HandleScript(reserveScript, func(keys, args []string) (any, error) {
+		interval, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		burst, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		tat := max(tats[keys[0]], now)
+		tats[keys[0]] = tat + interval
+		return max(tat-(burst-1)*interval-now, 0), nil
+	})
+
+	client, err := redis.New(srv.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close() //nolint:errcheck // test
+	ctx := context.Background()
+
+	l := NewRedisLimiter(client, 2)
+	var waits []time.Duration
+	for range 4 {
+		wait, err := l.Reserve(ctx, "example.com", time.Second)
+		if err != nil {
+			t.Fatalf("Reserve() error = %v", err)
+		}
+		waits = append(waits, wait)
+	}
+	want := []time.Duration{0, 0, time.Second, 2 * time.Second}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Errorf("Reserve() waits = %v, want %v", waits, want)
+			break
+		}
+	}
+	if wait, err := l.Reserve(ctx, "other.example", time.Second); err != nil || wait != 0 {
+		t.Errorf("Reserve() for another domain = %v, %v; want 0", wait, err)
+	}
+}
+
+type fixedLimiter struct {
+	wait  time.Duration
+	err   error
+	calls int
+}
+
+func (f *fixedLimiter) Reserve(context.Context, string, time.Duration) (time.Duration, error) {
+	f.calls++
+	return f.wait, f.err
+}
+
+func TestDomainRateLimiterShared(t *testing.T) {
+	r := NewDomainRateLimiter(time.Millisecond)
+	shared := &fixedLimiter{wait: 50 * time.Millisecond}
+	r.SetSharedLimiter(shared)
+
+	start := time.Now()
+	release, err := r.Acquire(context.Background(), "https://example.com/a")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	release()
+	if elapsed := time.Since(start); elapsed < shared.wait || shared.calls != 1 {
+		t.Errorf("Acquire() took %v with %d reservations, want at least %v and 1", elapsed, shared.calls, shared.wait)
+	}
+
+	// Without Redis, requests are still limited locally
+	shared.err = errors.New("connection refused")
+	if _, err := r.Acquire(context.Background(), "https://example.com/b"); err != nil {
+		t.Errorf("Acquire() with failing shared limiter error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	shared.err, shared.wait = nil, time.Hour
+	if _, err := r.Acquire(ctx, "https://example.org/"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() with canceled context error = %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// globalInflight coalesces concurrent fetches of the same URL, so a crawler that
+// discovers one link from several pages sends a single request.
+var globalInflight = &inflightGroup{calls: make(map[string]*inflightCall)}
+
+// inflightGroup is a minimal singleflight: concurrent callers of do with the same key
+// wait for one shared run of the work instead of repeating it. The work runs on a
+// context of its own, so no caller's cancellation fails the others; each caller stops
+// waiting when its own context ends, and the work is canceled once no caller waits.
+type inflightGroup struct {
+	calls map[string]*inflightCall
+	mu    sync.Mutex
+}
+
+type inflightCall struct {
+	err     error
+	cancel  context.CancelFunc
+	done    chan struct{}
+	body    []byte
+	waiters int // Callers still waiting; guarded by the group's mu
+	dups    int // Callers that joined the first; guarded by the group's mu
+}
+
+// do runs fn once per key at a time, on a context carrying ctx's values but not its
+// cancellation. shared reports whether the result came from another caller's
+// request; shared bodies are copies, so callers may modify them. If ctx ends first,
+// do returns ctx's error.
+func (g *inflightGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) (body []byte, err error, shared bool) { //nolint:revive // mirrors x/sync/singleflight
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		c.waiters++
+		g.mu.Unlock()
+		body, err = g.wait(ctx, key, c)
+		return body, err, true
+	}
+	fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c := &inflightCall{cancel: cancel, done: make(chan struct{}), waiters: 1}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		c.body, c.err = fn(fctx)
+		g.mu.Lock()
+		if g.calls[key] == c {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	body, err = g.wait(ctx, key, c)
+	return body, err, false
+}
+
+// wait returns c's result, or ctx's error if ctx ends first. The last caller to stop
+// waiting cancels c, and later callers start a new one.
+func (g *inflightGroup) wait(ctx context.Context, key string, c *inflightCall) ([]byte, error) {
+	select {
+	case <-c.done:
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+			if g.calls[key] == c {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+
+	g.mu.Lock()
+	dups := c.dups
+	g.mu.Unlock()
+	// A body handed to more than one caller is copied for each
+	if dups == 0 || c.body == nil {
+		return c.body, c.err
+	}
+	body := make([]byte, len(c.body))
+	copy(body, c.body)
+	return body, c.err
+}
@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchURLCoalescesConcurrentRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("profile"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	const callers = 8
+	var wg sync.WaitGroup
+	bodies := make([][]byte, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/user", http.NoBody)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			body, err := FetchURL(ctx, nil, server.Client(), req, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			bodies[i] = body
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+	for i, body := range bodies {
+		if string(body) != "profile" {
+			t.Errorf("caller %d got %q", i, body)
+		}
+	}
+	// Each caller owns its body
+	bodies[0][0] = 'X'
+	if bodies[1][0] != 'p' {
+		t.Error("callers should not share body slices")
+	}
+}
+
+func TestInflightGroupSequentialCallsRunAgain(t *testing.T) {
+	g := &inflightGroup{calls: make(map[string]*inflightCall)}
+	calls := 0
+	for range 3 {
+		_, _, shared := g.do(context.Background(), "k", func(context.Context) ([]byte, error) {
+			calls++
+			return []byte("v"), nil
+		})
+		if shared {
+			t.Error("sequential calls should not be shared")
+		}
+	}
+	if calls != 3 {
+		t.Errorf("fn ran %d times, want 3", calls)
+	}
+}
+
+func TestInflightGroupWaitersOutliveCanceledCaller(t *testing.T) {
+	g := &inflightGroup{calls: make(map[string]*inflightCall)}
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("v"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// The first caller gives up while a second is still waiting
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err, _ := g.do(first, "k", fn)
+		firstErr <- err
+	}()
+	<-started
+	second := make(chan []byte, 1)
+	go func() {
+		body, err, shared := g.do(context.Background(), "k", fn)
+		if err != nil || !shared {
+			t.Errorf("second do() = %v, shared %v; want the first caller's result", err, shared)
+		}
+		second <- body
+	}()
+	for {
+		g.mu.Lock()
+		waiters := g.calls["k"].waiters
+		g.mu.Unlock()
+		if waiters == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first do() error = %v, want context.Canceled", err)
+	}
+	close(release)
+	if body := <-second; string(body) != "v" {
+		t.Errorf("second do() body = %q, want v", body)
+	}
+}
+
+func TestInflightGroupCancelsAbandonedWork(t *testing.T) {
+	g := &inflightGroup{calls: make(map[string]*inflightCall)}
+	canceled := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err, _ := g.do(ctx, "k", func(ctx context.Context) ([]byte, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}); !errors.Is(err, context.Canceled) {
+		t.Errorf("do() error = %v, want context.Canceled", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("work kept running with no caller waiting")
+	}
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// storedAtHeader is the cached-headers key recording when FetchURL stored a response.
+const storedAtHeader = "X-Sociopath-Stored-At"
+
+// revalidateTimeout bounds a background revalidation, which outlives its caller.
+const revalidateTimeout = 30 * time.Second
+
+type staleKey struct{}
+
+// WithStaleWhileRevalidate returns a copy of ctx under which FetchURL answers with a
+// cached response older than maxAge at once, and refreshes the cache entry in the
+// background so the next caller gets a fresh one. Failed refreshes keep the old entry.
+// Entries are still dropped at the cache's TTL, so maxAge should be shorter. Responses
+// cached before storage times were recorded count as stale.
+func WithStaleWhileRevalidate(ctx context.Context, maxAge time.Duration) context.Context {
+	return context.WithValue(ctx, staleKey{}, maxAge)
+}
+
+// staleMaxAge returns the maxAge set by WithStaleWhileRevalidate, if any.
+func staleMaxAge(ctx context.Context) (time.Duration, bool) {
+	maxAge, ok := ctx.Value(staleKey{}).(time.Duration)
+	return maxAge, ok
+}
+
+// storedHeaders returns the cached headers recording that a response was stored now.
+func storedHeaders() map[string]string {
+	return map[string]string{storedAtHeader: time.Now().UTC().Format(time.RFC3339)}
+}
+
+// isStale reports whether a cached entry with headers was stored more than maxAge ago.
+func isStale(headers map[string]string, maxAge time.Duration) bool {
+	stored, err := time.Parse(time.RFC3339, headers[storedAtHeader])
+	return err != nil || time.Since(stored) > maxAge
+}
+
+// revalidate refetches req in the background and replaces the cache entry under
+// cacheKey if the response is a valid 200. Concurrent revalidations of one entry are
+// coalesced.
+func revalidate(
+	ctx context.Context, cache HTTPCache, client *http.Client, req *http.Request,
+	logger *slog.Logger, validator ResponseValidator, cacheKey string,
+) {
+	// The caller already has its answer; the refresh must not die with its context
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), revalidateTimeout)
+	req = req.Clone(ctx)
+	go func() {
+		defer cancel()
+		_, err, shared := globalInflight.do(ctx, "revalidate "+cacheKey, func(ctx context.Context) ([]byte, error) {
+			// Fetch without the cache so failures do not overwrite the stale entry
+			body, err := fetchAndStore(ctx, nil, client, req.WithContext(ctx), logger, nil, cacheKey)
+			if err != nil {
+				return nil, err
+			}
+			if validator == nil || validator(body) {
+				_ = cache.SetAsync(ctx, cacheKey, body, "", storedHeaders()) //nolint:errcheck // async, error ignored
+			}
+			return body, nil
+		})
+		if logger != nil && !shared {
+			if err != nil {
+				logger.Debug("revalidation failed, keeping stale entry", "key", cacheKey, "error", err)
+			} else {
+				logger.Debug("revalidated stale entry", "key", cacheKey)
+			}
+		}
+	}()
+}
@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFetchURLStaleWhileRevalidate(t *testing.T) {
+	var version atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if version.Load() == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte{'v', byte('0' + version.Load())})
+	}))
+	defer server.Close()
+
+	httpCache, err := NewWithPath(time.Hour, t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWithPath() error = %v", err)
+	}
+	defer func() { _ = httpCache.Close() }()
+
+	fetch := func(ctx context.Context) string {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/swr", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := FetchURL(ctx, httpCache, server.Client(), req, nil)
+		if err != nil {
+			t.Fatalf("FetchURL() error = %v", err)
+		}
+		return string(body)
+	}
+	waitFor := func(want string) {
+		t.Helper()
+		for range 100 {
+			if data, _, _, found := httpCache.Get(context.Background(), server.URL+"/swr"); found && string(data) == want {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("cache never held %q", want)
+	}
+
+	fetch(context.Background())
+	waitFor("v0")
+
+	// Fresh entries are served without a refresh
+	version.Store(1)
+	fresh := WithStaleWhileRevalidate(context.Background(), time.Hour)
+	if got := fetch(fresh); got != "v0" {
+		t.Errorf("fresh fetch = %q, want v0", got)
+	}
+
+	// Stale entries are served as-is, then replaced in the background
+	stale := WithStaleWhileRevalidate(context.Background(), 0)
+	if got := fetch(stale); got != "v0" {
+		t.Errorf("stale fetch = %q, want the stale v0", got)
+	}
+	waitFor("v1")
+
+	// A failed refresh keeps the stale entry instead of caching the error
+	version.Store(2)
+	if got := fetch(stale); got != "v1" {
+		t.Errorf("stale fetch = %q, want v1", got)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := fetch(context.Background()); got != "v1" {
+		t.Errorf("after failed refresh = %q, want v1", got)
+	}
+}
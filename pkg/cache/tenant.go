@@ -0,0 +1,20 @@
+package cache
+
+import "context"
+
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx under which FetchURL counts failures toward circuit
+// breakers kept for tenant alone. A host that blocks one tenant's account or proxy may
+// still serve the others, so their requests should not fail fast with it.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// breakerKey returns the key the circuit breaker tracks host under for requests in ctx.
+func breakerKey(ctx context.Context, host string) string {
+	if tenant, _ := ctx.Value(tenantKey{}).(string); tenant != "" { //nolint:errcheck // a missing value means no tenant
+		return tenant + "|" + host
+	}
+	return host
+}
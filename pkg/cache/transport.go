@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+)
+
+// Connection pool limits for SharedTransport. http.DefaultTransport keeps only two
+// idle connections per host, so a crawl fetching from one platform with more
+// workers than that closes and redials connections, paying a TLS handshake each time.
+const (
+	maxIdleConns        = 256
+	maxIdleConnsPerHost = 32
+	idleConnTimeout     = 90 * time.Second
+)
+
+// SharedTransport is the transport FetchURL sends requests over for clients without
+// one of their own: http.DefaultTransport with larger idle pools, so that concurrent
+// requests to one host reuse connections, and HTTP/2 wherever servers offer it. It is
+// shared so every platform client draws on the same pool. Clients needing their own,
+// such as for a proxy, should start from SharedTransport.Clone().
+var SharedTransport = newSharedTransport()
+
+func newSharedTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck,forcetypeassert // the default is always an *http.Transport
+	t.MaxIdleConns = maxIdleConns
+	t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	t.IdleConnTimeout = idleConnTimeout
+	t.ForceAttemptHTTP2 = true
+	return t
+}
+
+// shared returns client, or a copy sending requests over SharedTransport if it has
+// no transport of its own.
+func shared(client *http.Client) *http.Client {
+	if client.Transport == nil {
+		return withTransport(client, SharedTransport)
+	}
+	return client
+}
+
+// ConnStats counts the connections FetchURL's requests were sent on.
+type ConnStats struct {
+	Requests int64 // Requests that were sent
+	Reused   int64 // Requests sent on a connection that carried an earlier one
+	HTTP2    int64 // Responses received over HTTP/2
+}
+
+// ReuseRate returns the share of requests sent on reused connections as a
+// percentage (0-100).
+func (s ConnStats) ReuseRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Reused) / float64(s.Requests) * 100
+}
+
+var connStats struct {
+	requests, reused, http2 atomic.Int64
+}
+
+// ConnectionStats returns the connection counts of all FetchURL requests so far.
+func ConnectionStats() ConnStats {
+	return ConnStats{
+		Requests: connStats.requests.Load(),
+		Reused:   connStats.reused.Load(),
+		HTTP2:    connStats.http2.Load(),
+	}
+}
+
+// traceConnections returns req set up to count its connection in ConnectionStats.
+func traceConnections(req *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			connStats.requests.Add(1)
+			if info.Reused {
+				connStats.reused.Add(1)
+			}
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
+
+// recordProtocol counts resp in ConnectionStats if it came over HTTP/2.
+func recordProtocol(resp *http.Response) {
+	if resp.ProtoMajor == 2 {
+		connStats.http2.Add(1)
+	}
+}
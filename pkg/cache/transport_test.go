@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConnectionStats(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	ctx := WithNetwork(context.Background(), Network{RootCAs: roots})
+
+	before := ConnectionStats()
+	for _, path := range []string{"/a", "/b"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := FetchURL(ctx, nil, &http.Client{}, req, nil); err != nil {
+			t.Fatalf("FetchURL(%s) error = %v", path, err)
+		}
+	}
+	after := ConnectionStats()
+
+	if got := after.Requests - before.Requests; got != 2 {
+		t.Errorf("Requests = %d, want 2", got)
+	}
+	if got := after.Reused - before.Reused; got != 1 {
+		t.Errorf("Reused = %d, want 1", got)
+	}
+	if got := after.HTTP2 - before.HTTP2; got != 2 {
+		t.Errorf("HTTP2 = %d, want 2: SharedTransport should negotiate HTTP/2", got)
+	}
+}
+
+func TestConnStatsReuseRate(t *testing.T) {
+	if got := (ConnStats{}).ReuseRate(); got != 0 {
+		t.Errorf("ReuseRate() with no requests = %v, want 0", got)
+	}
+	if got := (ConnStats{Requests: 4, Reused: 3}).ReuseRate(); got != 75 {
+		t.Errorf("ReuseRate() = %v, want 75", got)
+	}
+}
+
+// BenchmarkCrawl fetches 1,000 URLs of mixed sizes from four hosts, two speaking
+// HTTP/1.1 and two HTTP/2 over TLS, with as many workers as a large crawl runs, and
+// reports how many requests reused a connection.
+func BenchmarkCrawl(b *testing.B) {
+	const (
+		urls    = 1000
+		workers = 32
+	)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/")) //nolint:errcheck // paths are generated below
+		_, _ = w.Write([]byte(strings.Repeat("x", 1024*(1+n%64))))
+	})
+	roots := x509.NewCertPool()
+	var hosts []string
+	for i := range 4 {
+		s := httptest.NewUnstartedServer(handler)
+		if i%2 == 0 {
+			s.Start()
+		} else {
+			s.EnableHTTP2 = true
+			s.StartTLS()
+			roots.AddCert(s.Certificate())
+		}
+		defer s.Close()
+		hosts = append(hosts, s.URL)
+	}
+	targets := make([]string, urls)
+	for i := range targets {
+		targets[i] = fmt.Sprintf("%s/%d", hosts[i%len(hosts)], i)
+	}
+
+	for _, tt := range []struct {
+		name string
+		base *http.Transport
+	}{
+		{"DefaultTransport", http.DefaultTransport.(*http.Transport)}, //nolint:errcheck,forcetypeassert // always an *http.Transport
+		{"SharedTransport", SharedTransport},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			var requests, reused atomic.Int64
+			trace := &httptrace.ClientTrace{GotConn: func(info httptrace.GotConnInfo) {
+				requests.Add(1)
+				if info.Reused {
+					reused.Add(1)
+				}
+			}}
+			ctx := httptrace.WithClientTrace(context.Background(), trace)
+			for b.Loop() {
+				t := tt.base.Clone()
+				t.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+				client := &http.Client{Transport: t}
+				crawl(ctx, b, client, targets, workers)
+				t.CloseIdleConnections()
+			}
+			b.ReportMetric(float64(reused.Load())/float64(requests.Load())*100, "%reused")
+			b.ReportMetric(float64(urls)*float64(b.N)/b.Elapsed().Seconds(), "urls/s")
+		})
+	}
+}
+
+// crawl fetches every target with workers concurrent requests.
+func crawl(ctx context.Context, b *testing.B, client *http.Client, targets []string, workers int) {
+	b.Helper()
+	next := make(chan string)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for target := range next {
+				req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, http.NoBody)
+				if err != nil {
+					b.Error(err)
+					continue
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					b.Error(err)
+					continue
+				}
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close() //nolint:errcheck // the body was read in full
+			}
+		})
+	}
+	for _, target := range targets {
+		next <- target
+	}
+	close(next)
+	wg.Wait()
+}
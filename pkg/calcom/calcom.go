@@ -0,0 +1,227 @@
+// Package calcom fetches cal.com booking pages.
+package calcom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "calcom"
+
+// Booking pages are cal.com/<user>; organizations get their own subdomain (acme.cal.com/<user>).
+var usernamePattern = regexp.MustCompile(`(?i)(?:^|//)(?:([\w-]+)\.)?cal\.com/([\w.-]+)(?:/[\w.-]+)?/?(?:[?#]|$)`)
+
+// nonProfiles are cal.com paths and subdomains that are not booking pages.
+var nonProfiles = map[string]bool{
+	"app": true, "login": true, "signup": true, "auth": true, "pricing": true, "blog": true,
+	"docs": true, "apps": true, "enterprise": true, "about": true, "privacy": true, "terms": true,
+	"event-types": true, "bookings": true, "settings": true, "team": true, "security": true, "api": true,
+}
+
+// Match returns true if the URL is a cal.com booking page.
+func Match(urlStr string) bool {
+	_, username := extractUsername(urlStr)
+	return username != ""
+}
+
+// AuthRequired returns false because booking pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles cal.com requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a cal.com client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves the owner of a cal.com booking page and their meeting types.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	org, username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	host := "cal.com"
+	if org != "" {
+		host = org + ".cal.com"
+	}
+	normalizedURL := "https://" + host + "/" + username
+	c.logger.InfoContext(ctx, "fetching cal.com profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username, org)
+}
+
+// Parse extracts a profile from a cal.com booking page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	org, username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	host := "cal.com"
+	if org != "" {
+		host = org + ".cal.com"
+	}
+	return parseProfile(string(data), "https://"+host+"/"+username, username, org)
+}
+
+var nextDataPattern = regexp.MustCompile(`(?s)<script id="__NEXT_DATA__" type="application/json"[^>]*>(.*?)</script>`)
+
+func parseProfile(content, urlStr, username, org string) (*profile.Profile, error) {
+	m := nextDataPattern.FindStringSubmatch(content)
+	if m == nil {
+		return nil, errors.New("profile not found (no page data)")
+	}
+	var data struct {
+		Props struct {
+			PageProps struct {
+				Profile struct {
+					Name  string `json:"name"`
+					Image string `json:"image"`
+				} `json:"profile"`
+				Users []struct {
+					Name      string `json:"name"`
+					Username  string `json:"username"`
+					Bio       string `json:"bio"`
+					AvatarURL string `json:"avatarUrl"`
+				} `json:"users"`
+				// Organization members carry the org they book for.
+				Entity struct {
+					OrgSlug string `json:"orgSlug"`
+					Name    string `json:"name"`
+				} `json:"entity"`
+				EventTypes []struct {
+					Title       string `json:"title"`
+					Slug        string `json:"slug"`
+					Length      int    `json:"length"`
+					Description string `json:"description"`
+				} `json:"eventTypes"`
+			} `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err := json.Unmarshal([]byte(m[1]), &data); err != nil {
+		return nil, fmt.Errorf("parse cal.com page data: %w", err)
+	}
+	pp := data.Props.PageProps
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Name:     strings.TrimSpace(pp.Profile.Name),
+		Fields:   make(map[string]string),
+	}
+	if pp.Profile.Image != "" {
+		p.Fields[profile.FieldAvatarURL] = pp.Profile.Image
+	}
+	if len(pp.Users) > 0 {
+		u := pp.Users[0]
+		if p.Name == "" {
+			p.Name = strings.TrimSpace(u.Name)
+		}
+		p.Bio = strings.TrimSpace(u.Bio)
+		if u.AvatarURL != "" {
+			p.Fields[profile.FieldAvatarURL] = u.AvatarURL
+		}
+	}
+	if p.Name == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	switch {
+	case pp.Entity.Name != "":
+		p.Fields[profile.FieldEmployer] = pp.Entity.Name
+	case org != "":
+		p.Fields["organization"] = org
+	}
+
+	var names []string
+	for _, e := range pp.EventTypes {
+		if e.Title == "" {
+			continue
+		}
+		names = append(names, e.Title)
+		post := profile.Post{
+			Type:    profile.PostTypePost,
+			Title:   e.Title,
+			Content: strings.TrimSpace(e.Description),
+			URL:     urlStr + "/" + e.Slug,
+		}
+		if e.Length > 0 {
+			post.Category = fmt.Sprintf("%d min", e.Length)
+		}
+		p.Posts = append(p.Posts, post)
+	}
+	if len(names) > 0 {
+		p.Fields["meeting_types"] = strings.Join(names, ", ")
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(p.Bio), Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) (org, username string) {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] || nonProfiles[strings.ToLower(m[2])] {
+		return "", ""
+	}
+	if org = strings.ToLower(m[1]); org == "www" {
+		org = ""
+	}
+	return org, m[2]
+}
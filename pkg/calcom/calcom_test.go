@@ -0,0 +1,76 @@
+package calcom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestExtractUsername(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantOrg  string
+		wantUser string
+	}{
+		{"https://cal.com/janedoe", "", "janedoe"},
+		{"https://cal.com/janedoe/30min", "", "janedoe"},
+		{"https://www.cal.com/janedoe", "", "janedoe"},
+		{"https://acme.cal.com/janedoe", "acme", "janedoe"},
+		{"cal.com/janedoe?date=2026-10-20", "", "janedoe"},
+		{"https://app.cal.com/bookings", "", ""},
+		{"https://cal.com/pricing", "", ""},
+		{"https://notcal.com/janedoe", "", ""},
+	}
+	for _, tt := range tests {
+		org, user := extractUsername(tt.url)
+		if org != tt.wantOrg || user != tt.wantUser {
+			t.Errorf("extractUsername(%q) = %q, %q, want %q, %q", tt.url, org, user, tt.wantOrg, tt.wantUser)
+		}
+		if Match(tt.url) != (tt.wantUser != "") {
+			t.Errorf("Match(%q) = %v", tt.url, Match(tt.url))
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("cal.com should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{
+		"profile":{"name":"Jane Doe","image":"https://cal.com/janedoe/avatar.png"},
+		"users":[{"name":"Jane Doe","username":"janedoe","bio":"Founder. Find me at https://github.com/janedoe"}],
+		"entity":{"orgSlug":"acme","name":"Acme Corp"},
+		"eventTypes":[{"title":"Intro call","slug":"intro","length":15,"description":"Quick hello"},{"title":"Deep dive","slug":"deep","length":60}]
+	}}}</script>`
+
+	p, err := parseProfile(page, "https://acme.cal.com/janedoe", "janedoe", "acme")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Bio != "Founder. Find me at https://github.com/janedoe" {
+		t.Errorf("Name = %q, Bio = %q", p.Name, p.Bio)
+	}
+	if p.Fields[profile.FieldEmployer] != "Acme Corp" || p.Fields[profile.FieldAvatarURL] != "https://cal.com/janedoe/avatar.png" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if p.Fields["meeting_types"] != "Intro call, Deep dive" || len(p.Posts) != 2 || p.Posts[1].Category != "60 min" {
+		t.Errorf("meeting_types = %q, Posts = %+v", p.Fields["meeting_types"], p.Posts)
+	}
+	if p.Posts[0].URL != "https://acme.cal.com/janedoe/intro" {
+		t.Errorf("Posts[0].URL = %q", p.Posts[0].URL)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://github.com/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	page := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"users":[]}}}</script>`
+	if _, err := parseProfile(page, "https://cal.com/nobody", "nobody", ""); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseProfile() error = %v, want ErrProfileNotFound", err)
+	}
+}
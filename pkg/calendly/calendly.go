@@ -0,0 +1,204 @@
+// Package calendly fetches Calendly booking pages.
+package calendly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "calendly"
+
+// Booking pages are calendly.com/<user>, optionally followed by an event type slug.
+var usernamePattern = regexp.MustCompile(`(?i)calendly\.com/([\w.-]+)(?:/[\w.-]+)?/?(?:[?#]|$)`)
+
+// nonProfiles are calendly.com paths that are not booking pages.
+var nonProfiles = map[string]bool{
+	"app": true, "login": true, "signup": true, "pricing": true, "blog": true, "integrations": true,
+	"about": true, "features": true, "resources": true, "help": true, "legal": true, "event_types": true,
+	"api": true, "solutions": true, "enterprise": true, "privacy": true, "security": true,
+}
+
+// Match returns true if the URL is a Calendly booking page.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because booking pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles Calendly requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Calendly client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves the owner of a Calendly booking page and their meeting types.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://calendly.com/" + username
+	c.logger.InfoContext(ctx, "fetching calendly profile", "url", normalizedURL, "username", username)
+
+	// The booking page is a client-rendered app backed by a public booking API.
+	body, err := c.get(ctx, "https://calendly.com/api/booking/profiles/"+username)
+	if err != nil {
+		return nil, err
+	}
+	p, err := parseProfile(body, normalizedURL, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		body, err := c.get(ctx, "https://calendly.com/api/booking/profiles/"+username+"/event_types")
+		if err != nil {
+			c.logger.DebugContext(ctx, "calendly event types unavailable", "username", username, "error", err)
+			return p, nil
+		}
+		addEventTypes(p, body)
+	}
+	return p, nil
+}
+
+func (c *Client) get(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+func parseProfile(data []byte, urlStr, username string) (*profile.Profile, error) {
+	var resp struct {
+		Name      string `json:"name"`
+		Slug      string `json:"slug"`
+		Type      string `json:"type"` // "User" or "Team"
+		AvatarURL string `json:"avatar_url"`
+		LogoURL   string `json:"logo_url"`
+		Timezone  string `json:"timezone"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse calendly profile: %w", err)
+	}
+	if resp.Name == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Name:     strings.TrimSpace(resp.Name),
+		Fields:   make(map[string]string),
+	}
+	if resp.Slug != "" {
+		p.Username = resp.Slug
+	}
+	if resp.AvatarURL != "" {
+		p.Fields[profile.FieldAvatarURL] = resp.AvatarURL
+	}
+	if resp.LogoURL != "" {
+		p.Fields["logo_url"] = resp.LogoURL
+	}
+	if resp.Timezone != "" {
+		p.Fields["timezone"] = resp.Timezone
+	}
+	if strings.EqualFold(resp.Type, "Team") {
+		p.Fields["type"] = "team"
+	}
+	return p, nil
+}
+
+// addEventTypes records the page's meeting types as posts, e.g. "30 Minute Meeting (30 min)".
+func addEventTypes(p *profile.Profile, data []byte) {
+	var events []struct {
+		Name        string `json:"name"`
+		Slug        string `json:"slug"`
+		Duration    int    `json:"duration"`
+		Description string `json:"description_plain"`
+		Secret      bool   `json:"secret"`
+	}
+	if err := json.Unmarshal(data, &events); err != nil {
+		return
+	}
+
+	var names []string
+	for _, e := range events {
+		if e.Secret || e.Name == "" {
+			continue
+		}
+		names = append(names, e.Name)
+		post := profile.Post{
+			Type:    profile.PostTypePost,
+			Title:   e.Name,
+			Content: strings.TrimSpace(e.Description),
+			URL:     p.URL + "/" + e.Slug,
+		}
+		if e.Duration > 0 {
+			post.Category = fmt.Sprintf("%d min", e.Duration)
+		}
+		p.Posts = append(p.Posts, post)
+		p.SocialLinks = append(p.SocialLinks, htmlutil.SocialLinks(e.Description)...)
+	}
+	if len(names) > 0 {
+		p.Fields["meeting_types"] = strings.Join(names, ", ")
+	}
+	p.SocialLinks = links.Clean(p.SocialLinks, Match)
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,64 @@
+package calendly
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://calendly.com/janedoe", true},
+		{"https://calendly.com/janedoe/30min", true},
+		{"https://calendly.com/jane-doe/intro-call?month=2026-10", true},
+		{"https://calendly.com/app/login", false},
+		{"https://calendly.com/pricing", false},
+		{"https://calendly.com/janedoe/30min/extra", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Calendly should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	data := `{"name":"Jane Doe","slug":"janedoe","type":"User","avatar_url":"https://assets.calendly.com/a.png",
+		"logo_url":"https://assets.calendly.com/acme.png","timezone":"America/New_York"}`
+
+	p, err := parseProfile([]byte(data), "https://calendly.com/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Fields[profile.FieldAvatarURL] != "https://assets.calendly.com/a.png" || p.Fields["timezone"] != "America/New_York" {
+		t.Errorf("Name = %q, Fields = %v", p.Name, p.Fields)
+	}
+
+	events := `[{"name":"30 Minute Meeting","slug":"30min","duration":30,"description_plain":"Chat about Acme. https://www.linkedin.com/in/janedoe"},
+		{"name":"Board sync","slug":"board","duration":60,"secret":true}]`
+	addEventTypes(p, []byte(events))
+	if len(p.Posts) != 1 || p.Posts[0].Title != "30 Minute Meeting" || p.Posts[0].Category != "30 min" || p.Posts[0].URL != "https://calendly.com/janedoe/30min" {
+		t.Errorf("Posts = %+v", p.Posts)
+	}
+	if p.Fields["meeting_types"] != "30 Minute Meeting" {
+		t.Errorf("meeting_types = %q", p.Fields["meeting_types"])
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://www.linkedin.com/in/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+
+	if _, err := parseProfile([]byte(`{"message":"Not Found"}`), "https://calendly.com/x", "x"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseProfile(not found) error = %v, want ErrProfileNotFound", err)
+	}
+}
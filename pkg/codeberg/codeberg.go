@@ -113,6 +113,32 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseHTML(body, urlStr, username), nil
 }
 
+// Parse extracts a profile from a Codeberg profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	if !strings.HasPrefix(urlStr, "http") {
+		urlStr = "https://codeberg.org/" + username
+	}
+	return parseHTML(data, urlStr, username), nil
+}
+
+// Profile page patterns for parseHTML.
+var (
+	ogTitlePattern     = regexp.MustCompile(`<meta\s+property="og:title"\s+content="([^"]+)"`)
+	avatarTitlePattern = regexp.MustCompile(`<img[^>]+class="[^"]*avatar[^"]*"[^>]+title="([^"]+)"`)
+	headerPattern      = regexp.MustCompile(`<span\s+class="header[^"]*"[^>]*>([^<]+)</span>`)
+	ogDescPattern      = regexp.MustCompile(`<meta\s+property="og:description"\s+content="([^"]+)"`)
+	websitePattern     = regexp.MustCompile(`<a[^>]+rel="[^"]*\bme\b[^"]*"[^>]+href="(https?://[^"]+)"`)
+	websitePattern2    = regexp.MustCompile(`<a[^>]+href="(https?://[^"]+)"[^>]+rel="[^"]*\bme\b[^"]*"`)
+	joinedPattern      = regexp.MustCompile(`Joined\s+on\s+(\d{4}-\d{2}-\d{2})`)
+	followersPattern   = regexp.MustCompile(`(\d+)\s*followers`)
+	followingPattern   = regexp.MustCompile(`(\d+)\s*following`)
+	pronounsPattern    = regexp.MustCompile(`class="username"[^>]*>[^<]*·\s*([^<]+)</span>`)
+)
+
 func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	content := string(data)
 
@@ -126,7 +152,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 
 	// Extract name from og:title meta tag or title attribute on avatar
 	// Pattern: <meta property="og:title" content="Woohyun Joh">
-	ogTitlePattern := regexp.MustCompile(`<meta\s+property="og:title"\s+content="([^"]+)"`)
 	if m := ogTitlePattern.FindStringSubmatch(content); len(m) > 1 {
 		prof.Name = strings.TrimSpace(html.UnescapeString(m[1]))
 	}
@@ -134,7 +159,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	// Fallback: Extract from avatar title attribute
 	// Pattern: title="Woohyun Joh"
 	if prof.Name == "" {
-		avatarTitlePattern := regexp.MustCompile(`<img[^>]+class="[^"]*avatar[^"]*"[^>]+title="([^"]+)"`)
 		if m := avatarTitlePattern.FindStringSubmatch(content); len(m) > 1 {
 			prof.Name = strings.TrimSpace(html.UnescapeString(m[1]))
 		}
@@ -143,7 +167,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	// Fallback: Extract from profile-avatar-name header
 	// Pattern: <span class="header text center">Woohyun Joh</span>
 	if prof.Name == "" {
-		headerPattern := regexp.MustCompile(`<span\s+class="header[^"]*"[^>]*>([^<]+)</span>`)
 		if m := headerPattern.FindStringSubmatch(content); len(m) > 1 {
 			prof.Name = strings.TrimSpace(html.UnescapeString(m[1]))
 		}
@@ -152,7 +175,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	// Extract bio/description from og:description meta tag
 	// This contains the user's bio, not Codeberg's default description
 	// Pattern: <meta property="og:description" content="...">
-	ogDescPattern := regexp.MustCompile(`<meta\s+property="og:description"\s+content="([^"]+)"`)
 	if m := ogDescPattern.FindStringSubmatch(content); len(m) > 1 {
 		bio := strings.TrimSpace(html.UnescapeString(m[1]))
 		// Filter out Codeberg's default description
@@ -164,7 +186,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	// Extract website if present (users can add a website link)
 	// Look for links with rel="...me..." which indicates a verified personal link
 	// Pattern: <a ... rel="noopener noreferrer me" href="https://...">https://...</a>
-	websitePattern := regexp.MustCompile(`<a[^>]+rel="[^"]*\bme\b[^"]*"[^>]+href="(https?://[^"]+)"`)
 	if m := websitePattern.FindStringSubmatch(content); len(m) > 1 {
 		website := m[1]
 		// Filter out Codeberg's own links
@@ -176,7 +197,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 	// Also try href first pattern
 	if prof.Website == "" {
-		websitePattern2 := regexp.MustCompile(`<a[^>]+href="(https?://[^"]+)"[^>]+rel="[^"]*\bme\b[^"]*"`)
 		if m := websitePattern2.FindStringSubmatch(content); len(m) > 1 {
 			website := m[1]
 			if !strings.Contains(website, "codeberg.org") {
@@ -187,28 +207,24 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 
 	// Extract join date
 	// Pattern: Joined on 2023-04-06
-	joinedPattern := regexp.MustCompile(`Joined\s+on\s+(\d{4}-\d{2}-\d{2})`)
 	if m := joinedPattern.FindStringSubmatch(content); len(m) > 1 {
 		prof.CreatedAt = m[1]
 	}
 
 	// Extract follower/following counts
-	followersPattern := regexp.MustCompile(`(\d+)\s*followers`)
 	if m := followersPattern.FindStringSubmatch(content); len(m) > 1 {
-		prof.Fields["followers"] = m[1]
+		prof.Fields[profile.FieldFollowers] = m[1]
 	}
-	followingPattern := regexp.MustCompile(`(\d+)\s*following`)
 	if m := followingPattern.FindStringSubmatch(content); len(m) > 1 {
-		prof.Fields["following"] = m[1]
+		prof.Fields[profile.FieldFollowing] = m[1]
 	}
 
 	// Extract pronouns if present (e.g., "he/him")
 	// Pattern: johwhj  · he/him
-	pronounsPattern := regexp.MustCompile(`class="username"[^>]*>[^<]*·\s*([^<]+)</span>`)
 	if m := pronounsPattern.FindStringSubmatch(content); len(m) > 1 {
 		pronouns := strings.TrimSpace(m[1])
 		if pronouns != "" && len(pronouns) < 20 { // Sanity check
-			prof.Fields["pronouns"] = pronouns
+			prof.Fields[profile.FieldPronouns] = pronouns
 		}
 	}
 
@@ -219,6 +235,9 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	return prof
 }
 
+// usernamePattern matches the username in a Codeberg URL.
+var usernamePattern = regexp.MustCompile(`codeberg\.org/([^/?]+)`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
@@ -226,8 +245,7 @@ func extractUsername(urlStr string) string {
 	urlStr = strings.TrimPrefix(urlStr, "www.")
 
 	// Extract codeberg.org/username
-	re := regexp.MustCompile(`codeberg\.org/([^/?]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -281,3 +281,19 @@ func TestNoSocialLinksExtracted(t *testing.T) {
 		t.Errorf("Expected no social links, got %v", profile.SocialLinks)
 	}
 }
+
+// BenchmarkParseHTML measures parsing a small profile page. While parseHTML compiled
+// its patterns on each call it took about 74µs, 58 KB, and 383 allocations here.
+func BenchmarkParseHTML(b *testing.B) {
+	page := []byte(`<html><head>
+		<meta property="og:title" content="Woohyun Joh">
+	</head><body>
+		<span class="username">johwhj  · he/him</span>
+		<div>0 followers · 0 following</div>
+		<div>Joined on 2023-04-06</div>
+	</body></html>`)
+	b.ReportAllocs()
+	for b.Loop() {
+		parseHTML(page, "https://codeberg.org/johwhj", "johwhj")
+	}
+}
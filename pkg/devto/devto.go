@@ -3,7 +3,6 @@ package devto
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"html"
@@ -33,6 +32,7 @@ type Client struct {
 	httpClient *http.Client
 	cache      cache.HTTPCache
 	logger     *slog.Logger
+	depth      profile.Depth
 }
 
 // Option configures a Client.
@@ -41,6 +41,7 @@ type Option func(*config)
 type config struct {
 	cache  cache.HTTPCache
 	logger *slog.Logger
+	depth  profile.Depth
 }
 
 // WithHTTPCache sets the HTTP cache.
@@ -53,6 +54,11 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
 // New creates a Dev.to client.
 func New(ctx context.Context, opts ...Option) (*Client, error) {
 	cfg := &config{logger: slog.Default()}
@@ -61,14 +67,10 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
 	}, nil
 }
 
@@ -85,7 +87,7 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
@@ -95,15 +97,36 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	p := parseHTML(body, urlStr, username)
 
 	// Fetch recent articles via API
-	posts, lastActive := c.fetchArticles(ctx, username, 50)
-	p.Posts = posts
-	if lastActive != "" && lastActive > p.UpdatedAt {
-		p.UpdatedAt = lastActive
+	if limit := c.depth.PostLimit(); limit > 0 && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		posts, lastActive := c.fetchArticles(ctx, username, limit)
+		p.Posts = posts
+		p.UpdateLastActive(lastActive)
 	}
 
 	return p, nil
 }
 
+// Parse extracts a profile from a Dev.to profile page's HTML without fetching anything.
+// Articles, which Fetch adds from the API, are not included.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseHTML(data, urlStr, username), nil
+}
+
+// Profile page patterns for parseHTML.
+var (
+	namePattern    = regexp.MustCompile(`<h1[^>]*class="[^"]*crayons-title[^"]*"[^>]*>\s*([^<]+)\s*</h1>`)
+	locPattern     = regexp.MustCompile(`(?s)<title[^>]*>Location</title>.*?</svg>\s*<span>\s*([^<]+?)\s*</span>`)
+	joinedPattern  = regexp.MustCompile(`<time\s+datetime="([^"]+)"[^>]*>([^<]+)</time>`)
+	workPattern    = regexp.MustCompile(`<strong[^>]*>\s*<p>Work</p>\s*</strong>\s*<p[^>]*>\s*<p>([^<]+)</p>`)
+	websitePattern = regexp.MustCompile(`<a\s+href=["'](https?://[^"']+)["'][^>]*class="[^"]*profile-header__meta__item[^"]*"`)
+	twitterPattern = regexp.MustCompile(`<a[^>]+href=["'](https?://(?:twitter\.com|x\.com)/[^"']+)["']`)
+	githubPattern  = regexp.MustCompile(`<a[^>]+href=["'](https?://github\.com/[^"']+)["']`)
+)
+
 func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	content := string(data)
 
@@ -117,7 +140,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract name from crayons-title h1
-	namePattern := regexp.MustCompile(`<h1[^>]*class="[^"]*crayons-title[^"]*"[^>]*>\s*([^<]+)\s*</h1>`)
 	if m := namePattern.FindStringSubmatch(content); len(m) > 1 {
 		p.Name = strings.TrimSpace(html.UnescapeString(m[1]))
 	}
@@ -134,7 +156,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	p.Bio = htmlutil.Description(content)
 
 	// Extract location - look for <title>Location</title> followed by <span>location</span>
-	locPattern := regexp.MustCompile(`(?s)<title[^>]*>Location</title>.*?</svg>\s*<span>\s*([^<]+?)\s*</span>`)
 	if m := locPattern.FindStringSubmatch(content); len(m) > 1 {
 		loc := strings.TrimSpace(html.UnescapeString(m[1]))
 		if loc != "" && !strings.Contains(strings.ToLower(loc), "joined") {
@@ -143,13 +164,11 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract joined date
-	joinedPattern := regexp.MustCompile(`<time\s+datetime="([^"]+)"[^>]*>([^<]+)</time>`)
 	if m := joinedPattern.FindStringSubmatch(content); len(m) > 2 {
 		p.CreatedAt = m[1] // ISO datetime format
 	}
 
 	// Extract work/employment - look for <p>Work</p> followed by value
-	workPattern := regexp.MustCompile(`<strong[^>]*>\s*<p>Work</p>\s*</strong>\s*<p[^>]*>\s*<p>([^<]+)</p>`)
 	if m := workPattern.FindStringSubmatch(content); len(m) > 1 {
 		work := strings.TrimSpace(html.UnescapeString(m[1]))
 		if work != "" {
@@ -158,7 +177,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract website - look for profile-header__meta__item link
-	websitePattern := regexp.MustCompile(`<a\s+href=["'](https?://[^"']+)["'][^>]*class="[^"]*profile-header__meta__item[^"]*"`)
 	if m := websitePattern.FindStringSubmatch(content); len(m) > 1 {
 		website := m[1]
 		// Filter out social media URLs
@@ -171,13 +189,11 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract Twitter
-	twitterPattern := regexp.MustCompile(`<a[^>]+href=["'](https?://(?:twitter\.com|x\.com)/[^"']+)["']`)
 	if m := twitterPattern.FindStringSubmatch(content); len(m) > 1 {
 		p.Fields["twitter"] = m[1]
 	}
 
 	// Extract GitHub
-	githubPattern := regexp.MustCompile(`<a[^>]+href=["'](https?://github\.com/[^"']+)["']`)
 	if m := githubPattern.FindStringSubmatch(content); len(m) > 1 {
 		p.Fields["github"] = m[1]
 	}
@@ -195,7 +211,7 @@ func (c *Client) fetchArticles(ctx context.Context, username string, limit int)
 		return nil, ""
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
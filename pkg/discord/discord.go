@@ -0,0 +1,164 @@
+// Package discord fetches Discord server details from invite links.
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "discord"
+
+// Invites appear as discord.gg/<code> or discord.com/invite/<code>.
+var invitePattern = regexp.MustCompile(`(?i)(?:discord\.gg|(?:discord|discordapp)\.com/invite)/([\w-]+)/?(?:[?#]|$)`)
+
+// Match returns true if the URL is a Discord server invite.
+func Match(urlStr string) bool {
+	return invitePattern.MatchString(urlStr)
+}
+
+// AuthRequired returns false because invite metadata is public.
+func AuthRequired() bool { return false }
+
+// Client handles Discord requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Discord client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves the server behind a Discord invite.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	m := invitePattern.FindStringSubmatch(urlStr)
+	if m == nil {
+		return nil, fmt.Errorf("could not extract invite code from: %s", urlStr)
+	}
+	code := m[1]
+
+	normalizedURL := "https://discord.gg/" + code
+	c.logger.InfoContext(ctx, "fetching discord invite", "url", normalizedURL, "code", code)
+
+	// The invite landing page is a client-rendered app; the API behind it is public.
+	apiURL := "https://discord.com/api/v10/invites/" + code + "?with_counts=true"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInvite(body, normalizedURL)
+}
+
+func parseInvite(data []byte, urlStr string) (*profile.Profile, error) {
+	var resp struct {
+		Code  json.RawMessage `json:"code"` // a string for invites, a number for API errors
+		Guild *struct {
+			ID            string   `json:"id"`
+			Name          string   `json:"name"`
+			Description   string   `json:"description"`
+			VanityURLCode string   `json:"vanity_url_code"`
+			Features      []string `json:"features"`
+		} `json:"guild"`
+		Inviter *struct {
+			Username string `json:"username"`
+		} `json:"inviter"`
+		MemberCount   int    `json:"approximate_member_count"`
+		PresenceCount int    `json:"approximate_presence_count"`
+		ExpiresAt     string `json:"expires_at"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse discord invite: %w", err)
+	}
+	// Unknown or expired invites return {"message": "Unknown Invite", "code": 10006}.
+	if resp.Guild == nil {
+		return nil, profile.ErrProfileNotFound
+	}
+	if resp.Guild.Name == "" {
+		return nil, errors.New("profile not found (no server name)")
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: strings.Trim(string(resp.Code), `"`),
+		Name:     resp.Guild.Name,
+		Bio:      strings.TrimSpace(resp.Guild.Description),
+		Fields: map[string]string{
+			"type":     "server",
+			"guild_id": resp.Guild.ID,
+		},
+	}
+	if resp.Guild.VanityURLCode != "" {
+		p.Username = resp.Guild.VanityURLCode
+	}
+	if resp.MemberCount > 0 {
+		p.Fields["members"] = strconv.Itoa(resp.MemberCount)
+	}
+	if resp.PresenceCount > 0 {
+		p.Fields["online"] = strconv.Itoa(resp.PresenceCount)
+	}
+	if resp.Inviter != nil && resp.Inviter.Username != "" {
+		p.Fields["inviter"] = resp.Inviter.Username
+	}
+	if resp.ExpiresAt != "" {
+		p.Fields["expires"] = resp.ExpiresAt
+	}
+	for _, f := range resp.Guild.Features {
+		if f == "VERIFIED" || f == "PARTNERED" {
+			p.Fields[strings.ToLower(f)] = "true"
+		}
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(p.Bio), Match)
+	return p, nil
+}
@@ -0,0 +1,69 @@
+package discord
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://discord.gg/golang", true},
+		{"https://discord.gg/AbC123/", true},
+		{"https://discord.com/invite/golang", true},
+		{"https://discordapp.com/invite/golang?utm_source=site", true},
+		{"https://discord.com/users/123456789", false},
+		{"https://discord.com/channels/1/2", false},
+		{"https://example.com/invite/golang", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Discord invites should not require auth")
+	}
+}
+
+func TestParseInvite(t *testing.T) {
+	data := `{"type":0,"code":"AbC123","expires_at":null,
+		"guild":{"id":"118456055842734083","name":"Gophers","description":"The Go community server. Docs: https://github.com/golang",
+			"vanity_url_code":"golang","features":["COMMUNITY","VERIFIED"]},
+		"inviter":{"username":"janedoe"},
+		"approximate_member_count":52310,"approximate_presence_count":8120}`
+
+	p, err := parseInvite([]byte(data), "https://discord.gg/AbC123")
+	if err != nil {
+		t.Fatalf("parseInvite() error = %v", err)
+	}
+	if p.Name != "Gophers" || p.Username != "golang" {
+		t.Errorf("Name = %q, Username = %q", p.Name, p.Username)
+	}
+	want := map[string]string{
+		"type": "server", "guild_id": "118456055842734083", "members": "52310",
+		"online": "8120", "inviter": "janedoe", "verified": "true",
+	}
+	for k, v := range want {
+		if p.Fields[k] != v {
+			t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+		}
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://github.com/golang" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseInviteUnknown(t *testing.T) {
+	data := `{"message": "Unknown Invite", "code": 10006}`
+	if _, err := parseInvite([]byte(data), "https://discord.gg/expired"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseInvite() error = %v, want ErrProfileNotFound", err)
+	}
+}
@@ -0,0 +1,263 @@
+// Package ens resolves Ethereum Name Service names, such as "vitalik.eth", to the
+// text records their owners set: a website, social handles, an email, an avatar.
+package ens
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "ens"
+
+// DefaultResolver is the public ENS resolver API queried for names. It reads the
+// name's resolver contract on chain and returns its text records as JSON.
+const DefaultResolver = "https://enstate.rs"
+
+// appHost is the official ENS app, whose /name.eth pages are the canonical profile URLs.
+const appHost = "app.ens.domains"
+
+// namePattern matches .eth names, including subnames such as "pay.alice.eth".
+var namePattern = regexp.MustCompile(`(?i)(?:^|[^\w.@/-])((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+eth)\b`)
+
+// socialRecords maps text record keys (ENSIP-5) to the profile URL a handle becomes.
+var socialRecords = map[string]string{
+	"com.github":    "https://github.com/",
+	"com.twitter":   "https://twitter.com/",
+	"com.linkedin":  "https://www.linkedin.com/in/",
+	"com.reddit":    "https://www.reddit.com/user/",
+	"io.keybase":    "https://keybase.io/",
+	"org.telegram":  "https://t.me/",
+	"xyz.farcaster": "https://warpcast.com/",
+}
+
+// Match returns true for ENS app URLs naming a .eth name.
+func Match(urlStr string) bool {
+	return NameFromURL(urlStr) != ""
+}
+
+// AuthRequired returns false because ENS records are public.
+func AuthRequired() bool { return false }
+
+// NameFromURL returns the ENS name in an ENS app URL such as
+// "https://app.ens.domains/vitalik.eth", or "" if urlStr is not one.
+func NameFromURL(urlStr string) string {
+	parsed, err := url.Parse(urlStr)
+	if err != nil || !strings.EqualFold(parsed.Hostname(), appHost) {
+		return ""
+	}
+	name, _, _ := strings.Cut(strings.Trim(parsed.Path, "/"), "/")
+	name = strings.ToLower(name)
+	if !isName(name) {
+		return ""
+	}
+	return name
+}
+
+// URL returns the ENS app URL for name.
+func URL(name string) string {
+	return "https://" + appHost + "/" + strings.ToLower(name)
+}
+
+// FindNames returns the .eth names mentioned in text, such as a bio, lowercased and
+// in order of appearance. Email addresses and URL paths ending in .eth are skipped.
+func FindNames(text string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range namePattern.FindAllStringSubmatch(text, -1) {
+		name := strings.ToLower(m[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isName(s string) bool {
+	m := namePattern.FindStringSubmatch(s)
+	return m != nil && m[1] == s
+}
+
+// Client resolves ENS names.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	resolver   string
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache    cache.HTTPCache
+	logger   *slog.Logger
+	resolver string
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithResolver sets the base URL of an enstate-compatible resolver API, replacing
+// DefaultResolver. Use it to point at a self-hosted instance.
+func WithResolver(baseURL string) Option {
+	return func(c *config) { c.resolver = strings.TrimSuffix(baseURL, "/") }
+}
+
+// New creates an ENS client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default(), resolver: DefaultResolver}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		resolver:   cfg.resolver,
+	}, nil
+}
+
+// Fetch resolves the name in an ENS app URL.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	name := NameFromURL(urlStr)
+	if name == "" {
+		return nil, fmt.Errorf("could not extract ENS name from: %s", urlStr)
+	}
+	p, err := c.Resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	p.URL = urlStr
+	return p, nil
+}
+
+// record is a resolved name as the resolver API returns it.
+type record struct {
+	Name    string            `json:"name"`
+	Address string            `json:"address"`
+	Avatar  string            `json:"avatar"`
+	Header  string            `json:"header"`
+	Records map[string]string `json:"records"`
+}
+
+// Resolve returns the profile the text records of name describe. Social handles
+// become SocialLinks; since only the name's owner can set its records, they are as
+// strong a claim of ownership as a rel="me" link.
+func (c *Client) Resolve(ctx context.Context, name string) (*profile.Profile, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !isName(name) {
+		return nil, fmt.Errorf("invalid ENS name: %s", name)
+	}
+	c.logger.InfoContext(ctx, "resolving ENS name", "name", name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.resolver+"/n/"+url.PathEscape(name), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		var httpErr *cache.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s", profile.ErrProfileNotFound, name)
+		}
+		return nil, err
+	}
+
+	var rec record
+	if err := json.Unmarshal(body, &rec); err != nil {
+		return nil, fmt.Errorf("parsing ENS records: %w", err)
+	}
+	if rec.Name == "" {
+		return nil, fmt.Errorf("%w: %s", profile.ErrProfileNotFound, name)
+	}
+	return parseRecord(&rec), nil
+}
+
+func parseRecord(rec *record) *profile.Profile {
+	text := func(key string) string { return strings.TrimSpace(rec.Records[key]) }
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      URL(rec.Name),
+		Username: rec.Name,
+		Name:     text("name"),
+		Bio:      text("description"),
+		Location: text("location"),
+		Website:  text("url"),
+		Fields:   make(map[string]string),
+	}
+	if p.Name == "" {
+		p.Name = rec.Name
+	}
+	if rec.Address != "" {
+		p.Fields["eth_address"] = rec.Address
+	}
+	if email := text("email"); strings.Contains(email, "@") {
+		p.Fields[profile.FieldEmail] = email
+	}
+	if discord := text("com.discord"); discord != "" {
+		p.Fields["discord"] = discord
+	}
+	// The resolver turns NFT avatar records (eip155:1/erc721:...) into image URLs
+	for key, value := range map[string]string{profile.FieldAvatarURL: rec.Avatar, "banner_url": rec.Header} {
+		if strings.HasPrefix(value, "https://") {
+			p.Fields[key] = value
+		}
+	}
+
+	keys := make([]string, 0, len(socialRecords))
+	for key := range socialRecords {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var found []string
+	for _, key := range keys {
+		if link := socialLink(socialRecords[key], text(key)); link != "" {
+			found = append(found, link)
+		}
+	}
+	if p.Website != "" {
+		found = append(found, p.Website)
+	}
+	p.SocialLinks = links.Clean(found, nil)
+	return p
+}
+
+// socialLink turns a handle record into a profile URL. Some owners store the full URL
+// rather than the handle.
+func socialLink(prefix, value string) string {
+	value = strings.TrimPrefix(value, "@")
+	switch {
+	case value == "":
+		return ""
+	case strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://"):
+		return value
+	case strings.ContainsAny(value, " /?#"):
+		return ""
+	default:
+		return prefix + value
+	}
+}
@@ -0,0 +1,108 @@
+package ens
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://app.ens.domains/vitalik.eth", true},
+		{"https://app.ens.domains/pay.alice.eth?tab=records", true},
+		{"https://app.ens.domains/", false},
+		{"https://app.ens.domains/faq", false},
+		{"https://example.com/vitalik.eth", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("ENS should not require auth")
+	}
+}
+
+func TestFindNames(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"builder. vitalik.eth | gm", []string{"vitalik.eth"}},
+		{"Alice.ETH, pay.alice.eth and alice.eth again", []string{"alice.eth", "pay.alice.eth"}},
+		{"(nick.eth)", []string{"nick.eth"}},
+		{"mail me at me@alice.eth or see https://alice.eth.limo", nil},
+		{"I love ethereum and .eth names", nil},
+	}
+	for _, tt := range tests {
+		if got := FindNames(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("FindNames(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/n/vitalik.eth" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"error":"Not found"}`)) //nolint:errcheck // test server
+			return
+		}
+		_, _ = w.Write([]byte(`{
+			"name": "vitalik.eth",
+			"address": "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045",
+			"avatar": "https://euc.li/vitalik.eth",
+			"records": {
+				"avatar": "eip155:1/erc1155:0xb32979486938aa9694bfc898f35dbed459f44424/10063",
+				"url": "https://vitalik.ca",
+				"com.twitter": "@VitalikButerin",
+				"com.github": "vbuterin",
+				"org.telegram": "https://t.me/vitalik",
+				"email": "vitalik@example.com",
+				"description": "mi pinxe lo crino tcati"
+			}
+		}`)) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx, WithResolver(server.URL+"/"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	p, err := client.Fetch(ctx, "https://app.ens.domains/vitalik.eth")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Platform != "ens" || p.Username != "vitalik.eth" || p.Name != "vitalik.eth" || p.Website != "https://vitalik.ca" {
+		t.Errorf("profile = %+v", p)
+	}
+	if p.Bio != "mi pinxe lo crino tcati" {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Fields[profile.FieldEmail] != "vitalik@example.com" || p.Fields[profile.FieldAvatarURL] != "https://euc.li/vitalik.eth" ||
+		p.Fields["eth_address"] != "0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	want := []string{"https://github.com/vbuterin", "https://twitter.com/VitalikButerin", "https://t.me/vitalik", "https://vitalik.ca"}
+	if !slices.Equal(p.SocialLinks, want) {
+		t.Errorf("SocialLinks = %v, want %v", p.SocialLinks, want)
+	}
+
+	if _, err := client.Fetch(ctx, "https://app.ens.domains/nobody.eth"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("Fetch(unregistered) error = %v, want ErrProfileNotFound", err)
+	}
+}
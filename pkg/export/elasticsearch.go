@@ -0,0 +1,317 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// elasticsearchMapping is the index mapping Elasticsearch creates the index with.
+// Identifiers are keywords for exact matching and aggregation; prose is analyzed text.
+// Names, locations, and companies are both, so they can be searched and grouped.
+// Fields values are keywords, whatever the platform put in them.
+const elasticsearchMapping = `{
+  "mappings": {
+    "dynamic_templates": [
+      {"fields": {"path_match": "fields.*", "match_mapping_type": "string", "mapping": {"type": "keyword", "ignore_above": 1024}}}
+    ],
+    "properties": {
+      "fingerprint":   {"type": "keyword"},
+      "platform":      {"type": "keyword"},
+      "url":           {"type": "keyword"},
+      "username":      {"type": "keyword"},
+      "name":          {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+      "bio":           {"type": "text"},
+      "location":      {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+      "website":       {"type": "keyword"},
+      "company":       {"type": "keyword", "fields": {"text": {"type": "text"}}},
+      "email":         {"type": "keyword"},
+      "created_at":    {"type": "date"},
+      "updated_at":    {"type": "date"},
+      "last_active":   {"type": "date"},
+      "fields":        {"type": "object"},
+      "social_links":  {"type": "keyword"},
+      "aliases":       {"type": "keyword"},
+      "posts": {"properties": {
+        "type":     {"type": "keyword"},
+        "title":    {"type": "text"},
+        "content":  {"type": "text"},
+        "url":      {"type": "keyword"},
+        "category": {"type": "keyword"}
+      }},
+      "experience": {"properties": {
+        "title":        {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+        "organization": {"type": "keyword", "fields": {"text": {"type": "text"}}},
+        "location":     {"type": "text"},
+        "start":        {"type": "keyword"},
+        "end":          {"type": "keyword"},
+        "description":  {"type": "text"}
+      }},
+      "education": {"properties": {
+        "school": {"type": "keyword", "fields": {"text": {"type": "text"}}},
+        "degree": {"type": "text"},
+        "field":  {"type": "text"},
+        "start":  {"type": "keyword"},
+        "end":    {"type": "keyword"}
+      }},
+      "unstructured":  {"type": "text"},
+      "authenticated": {"type": "boolean"},
+      "error":         {"type": "text"},
+      "is_guess":      {"type": "boolean"},
+      "confidence":    {"type": "float"},
+      "guess_match":   {"type": "keyword"},
+      "exported_at":   {"type": "date"}
+    }
+  }
+}`
+
+// esDocument is a profile as indexed in Elasticsearch.
+type esDocument struct {
+	Fingerprint   string               `json:"fingerprint"`
+	Platform      string               `json:"platform,omitempty"`
+	URL           string               `json:"url,omitempty"`
+	Username      string               `json:"username,omitempty"`
+	Name          string               `json:"name,omitempty"`
+	Bio           string               `json:"bio,omitempty"`
+	Location      string               `json:"location,omitempty"`
+	Website       string               `json:"website,omitempty"`
+	Company       string               `json:"company,omitempty"`
+	Email         string               `json:"email,omitempty"`
+	CreatedAt     string               `json:"created_at,omitempty"`
+	UpdatedAt     string               `json:"updated_at,omitempty"`
+	LastActive    string               `json:"last_active,omitempty"`
+	Fields        map[string]string    `json:"fields,omitempty"`
+	SocialLinks   []string             `json:"social_links,omitempty"`
+	Aliases       []string             `json:"aliases,omitempty"`
+	Posts         []profile.Post       `json:"posts,omitempty"`
+	Experience    []profile.Experience `json:"experience,omitempty"`
+	Education     []profile.Education  `json:"education,omitempty"`
+	Unstructured  string               `json:"unstructured,omitempty"`
+	Authenticated bool                 `json:"authenticated,omitempty"`
+	Error         string               `json:"error,omitempty"`
+	IsGuess       bool                 `json:"is_guess,omitempty"`
+	Confidence    float64              `json:"confidence,omitempty"`
+	GuessMatch    []string             `json:"guess_match,omitempty"`
+	ExportedAt    string               `json:"exported_at"`
+}
+
+// newESDocument flattens p into an esDocument. Dates that do not parse are left out
+// rather than failing the whole bulk request.
+func newESDocument(p *profile.Profile, now time.Time) esDocument {
+	company, _ := p.Employer()
+	email, _ := p.Email()
+	return esDocument{
+		Fingerprint:   p.Fingerprint(),
+		Platform:      p.Platform,
+		URL:           p.URL,
+		Username:      strings.TrimPrefix(p.Username, "@"),
+		Name:          p.Name,
+		Bio:           p.Bio,
+		Location:      p.Location,
+		Website:       p.Website,
+		Company:       company,
+		Email:         strings.ToLower(email),
+		CreatedAt:     esDate(p.CreatedAt),
+		UpdatedAt:     esDate(p.UpdatedAt),
+		LastActive:    esDate(p.LastActive),
+		Fields:        p.Fields,
+		SocialLinks:   p.SocialLinks,
+		Aliases:       p.Aliases,
+		Posts:         p.Posts,
+		Experience:    p.Experience,
+		Education:     p.Education,
+		Unstructured:  p.Unstructured,
+		Authenticated: p.Authenticated,
+		Error:         p.Error,
+		IsGuess:       p.IsGuess,
+		Confidence:    p.Confidence,
+		GuessMatch:    p.GuessMatch,
+		ExportedAt:    now.UTC().Format(time.RFC3339),
+	}
+}
+
+func esDate(s string) string {
+	t, ok := profile.ParseTime(s)
+	if !ok {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Elasticsearch is a Sink that bulk-indexes profiles into an Elasticsearch or
+// OpenSearch index, one document per account keyed by its fingerprint:
+//
+//	es := export.NewElasticsearch("http://localhost:9200", "sociopath", export.WithAPIKey(key))
+//	err := es.Export(ctx, profiles)
+//
+// The index is created with a mapping suited to profiles on first use if it does not
+// exist; an existing index keeps its own mapping.
+type Elasticsearch struct {
+	cfg      *config
+	endpoint string
+	index    string
+
+	mu      sync.Mutex
+	created bool
+	now     func() time.Time
+}
+
+var _ Sink = (*Elasticsearch)(nil)
+
+// NewElasticsearch returns a Sink writing to index on the cluster at endpoint, such as
+// "https://es.example.com:9200".
+func NewElasticsearch(endpoint, index string, opts ...Option) *Elasticsearch {
+	cfg := newConfig(opts)
+	if cfg.httpClient == nil {
+		cfg.httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &Elasticsearch{
+		cfg:      cfg,
+		endpoint: strings.TrimRight(endpoint, "/"),
+		index:    index,
+		now:      time.Now,
+	}
+}
+
+// CreateIndex creates the index with the profile mapping. It succeeds if the index
+// already exists.
+func (e *Elasticsearch) CreateIndex(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.created {
+		return nil
+	}
+	resp, err := e.do(ctx, http.MethodPut, "/"+e.index, "application/json", strings.NewReader(elasticsearchMapping))
+	if err != nil {
+		return fmt.Errorf("creating index %s: %w", e.index, err)
+	}
+	if resp.status != http.StatusOK && !strings.Contains(string(resp.body), "resource_already_exists_exception") {
+		return fmt.Errorf("creating index %s: %w", e.index, resp.err())
+	}
+	e.created = true
+	return nil
+}
+
+// Export implements Sink. Profiles are sent in batches through the _bulk API and are
+// searchable when Export returns. Documents the cluster rejects are reported together
+// after every batch has been sent.
+func (e *Elasticsearch) Export(ctx context.Context, profiles []*profile.Profile) error {
+	if err := e.CreateIndex(ctx); err != nil {
+		return err
+	}
+	now := e.now()
+	var errs []error
+	for start := 0; start < len(profiles); start += e.cfg.batchSize {
+		batch := profiles[start:min(start+e.cfg.batchSize, len(profiles))]
+		var body bytes.Buffer
+		enc := json.NewEncoder(&body)
+		n := 0
+		for _, p := range batch {
+			if p == nil {
+				continue
+			}
+			doc := newESDocument(p, now)
+			action := map[string]map[string]string{"index": {"_index": e.index, "_id": doc.Fingerprint}}
+			if err := enc.Encode(action); err != nil {
+				return err
+			}
+			if err := enc.Encode(doc); err != nil {
+				return err
+			}
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		if err := e.bulk(ctx, &body); err != nil {
+			errs = append(errs, err)
+		}
+		e.cfg.logger.DebugContext(ctx, "exported profiles to elasticsearch", "index", e.index, "count", n)
+	}
+	return errors.Join(errs...)
+}
+
+// bulk sends one NDJSON _bulk request and reports the documents it failed to index.
+func (e *Elasticsearch) bulk(ctx context.Context, body io.Reader) error {
+	resp, err := e.do(ctx, http.MethodPost, "/_bulk?refresh=wait_for", "application/x-ndjson", body)
+	if err != nil {
+		return fmt.Errorf("bulk indexing: %w", err)
+	}
+	if resp.status != http.StatusOK {
+		return fmt.Errorf("bulk indexing: %w", resp.err())
+	}
+	var result struct {
+		Items []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+		Errors bool `json:"errors"`
+	}
+	if err := json.Unmarshal(resp.body, &result); err != nil {
+		return fmt.Errorf("parsing bulk response: %w", err)
+	}
+	if !result.Errors {
+		return nil
+	}
+	var errs []error
+	for _, item := range result.Items {
+		for _, r := range item {
+			if r.Error != nil {
+				errs = append(errs, fmt.Errorf("document %s: %s: %s", r.ID, r.Error.Type, r.Error.Reason))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// esResponse is a response read in full.
+type esResponse struct {
+	body   []byte
+	status int
+}
+
+func (r esResponse) err() error {
+	body := string(r.body)
+	if len(body) > 512 {
+		body = body[:512] + "..."
+	}
+	return fmt.Errorf("HTTP %d: %s", r.status, body)
+}
+
+func (e *Elasticsearch) do(ctx context.Context, method, path, contentType string, body io.Reader) (esResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, method, e.endpoint+path, body)
+	if err != nil {
+		return esResponse{}, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case e.cfg.apiKey != "":
+		req.Header.Set("Authorization", "ApiKey "+e.cfg.apiKey)
+	case e.cfg.username != "":
+		req.SetBasicAuth(e.cfg.username, e.cfg.password)
+	}
+	resp, err := e.cfg.httpClient.Do(req)
+	if err != nil {
+		return esResponse{}, err
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // error irrelevant after read
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return esResponse{}, err
+	}
+	return esResponse{body: data, status: resp.StatusCode}, nil
+}
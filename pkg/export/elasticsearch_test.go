@@ -0,0 +1,181 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// fakeES is a minimal Elasticsearch that records index creation and bulk documents.
+type fakeES struct {
+	mu        sync.Mutex
+	mapping   map[string]any
+	docs      map[string]map[string]any
+	bulks     int
+	auth      string
+	exists    bool
+	rejectIDs map[string]bool
+}
+
+func (f *fakeES) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = r.Header.Get("Authorization")
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/people":
+		if f.exists {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"error":{"type":"resource_already_exists_exception"},"status":400}`) //nolint:errcheck // test server
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&f.mapping) //nolint:errcheck // checked by the test
+		f.exists = true
+		_, _ = io.WriteString(w, `{"acknowledged":true}`) //nolint:errcheck // test server
+	case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+		f.bulks++
+		if f.docs == nil {
+			f.docs = make(map[string]map[string]any)
+		}
+		type item struct {
+			ID     string            `json:"_id"`
+			Status int               `json:"status"`
+			Error  map[string]string `json:"error,omitempty"`
+		}
+		var items []map[string]item
+		failed := false
+		sc := bufio.NewScanner(r.Body)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			var action map[string]map[string]string
+			if err := json.Unmarshal(sc.Bytes(), &action); err != nil || !sc.Scan() {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			id := action["index"]["_id"]
+			if f.rejectIDs[id] {
+				failed = true
+				items = append(items, map[string]item{"index": {ID: id, Status: 400, Error: map[string]string{"type": "mapper_parsing_exception", "reason": "bad date"}}})
+				continue
+			}
+			var doc map[string]any
+			_ = json.Unmarshal(sc.Bytes(), &doc) //nolint:errcheck // checked by the test
+			f.docs[id] = doc
+			items = append(items, map[string]item{"index": {ID: id, Status: 201}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": failed, "items": items}) //nolint:errcheck // test server
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func testProfiles() []*profile.Profile {
+	return []*profile.Profile{
+		{
+			Platform: "github", URL: "https://github.com/alice", Username: "alice", Name: "Alice Smith",
+			Bio: "Kubernetes hacker", CreatedAt: "2015-03-01T10:00:00Z",
+			Fields: map[string]string{"company": "@Chainguard", "email": "Alice@Chainguard.dev"},
+		},
+		{Platform: "mastodon", URL: "https://hachyderm.io/@alice", Username: "@alice", CreatedAt: "last spring"},
+		nil,
+	}
+}
+
+func TestElasticsearchExport(t *testing.T) {
+	fake := &fakeES{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	es := NewElasticsearch(srv.URL+"/", "people", WithAPIKey("secret"), WithBatchSize(1))
+	if err := es.Export(context.Background(), testProfiles()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if fake.mapping == nil {
+		t.Fatal("index was not created")
+	}
+	props := fake.mapping["mappings"].(map[string]any)["properties"].(map[string]any)
+	for field, want := range map[string]string{"platform": "keyword", "username": "keyword", "company": "keyword", "bio": "text", "unstructured": "text"} {
+		if got := props[field].(map[string]any)["type"]; got != want {
+			t.Errorf("mapping %s type = %v, want %s", field, got, want)
+		}
+	}
+	if fake.auth != "ApiKey secret" {
+		t.Errorf("Authorization = %q, want ApiKey secret", fake.auth)
+	}
+	if fake.bulks != 2 {
+		t.Errorf("bulk requests = %d, want 2 (batch size 1, nil skipped)", fake.bulks)
+	}
+
+	alice := testProfiles()[0]
+	doc, ok := fake.docs[alice.Fingerprint()]
+	if !ok {
+		t.Fatalf("no document with _id %s; have %d docs", alice.Fingerprint(), len(fake.docs))
+	}
+	for field, want := range map[string]string{
+		"platform": "github", "username": "alice", "company": "@Chainguard",
+		"email": "alice@chainguard.dev", "created_at": "2015-03-01T10:00:00Z",
+	} {
+		if doc[field] != want {
+			t.Errorf("doc[%s] = %v, want %q", field, doc[field], want)
+		}
+	}
+
+	masto := fake.docs[testProfiles()[1].Fingerprint()]
+	if masto["username"] != "alice" {
+		t.Errorf("username = %v, want @ stripped", masto["username"])
+	}
+	if _, ok := masto["created_at"]; ok {
+		t.Errorf("unparseable created_at was indexed: %v", masto["created_at"])
+	}
+}
+
+func TestElasticsearchExistingIndex(t *testing.T) {
+	fake := &fakeES{exists: true}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	es := NewElasticsearch(srv.URL, "people", WithBasicAuth("elastic", "changeme"))
+	if err := es.Export(context.Background(), testProfiles()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(fake.docs) != 2 {
+		t.Errorf("indexed %d docs, want 2", len(fake.docs))
+	}
+	if !strings.HasPrefix(fake.auth, "Basic ") {
+		t.Errorf("Authorization = %q, want basic auth", fake.auth)
+	}
+}
+
+func TestElasticsearchRejectedDocuments(t *testing.T) {
+	profiles := testProfiles()
+	fake := &fakeES{rejectIDs: map[string]bool{profiles[1].Fingerprint(): true}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	err := NewElasticsearch(srv.URL, "people").Export(context.Background(), profiles)
+	if err == nil || !strings.Contains(err.Error(), "mapper_parsing_exception") {
+		t.Fatalf("Export() error = %v, want the rejected document reported", err)
+	}
+	if len(fake.docs) != 1 {
+		t.Errorf("indexed %d docs, want the other one kept", len(fake.docs))
+	}
+}
+
+func TestElasticsearchIndexError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	if err := NewElasticsearch(srv.URL, "people").Export(context.Background(), testProfiles()); err == nil {
+		t.Fatal("Export() succeeded against a cluster refusing access")
+	}
+}
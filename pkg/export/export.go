@@ -0,0 +1,69 @@
+// Package export writes crawled profiles to external systems, such as search engines
+// and databases, so a crawl's results can be queried alongside earlier ones.
+package export
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Sink receives the profiles of a crawl. Exporting a profile again replaces the copy
+// already stored for the same account (see profile.Profile.Fingerprint).
+type Sink interface {
+	Export(ctx context.Context, profiles []*profile.Profile) error
+}
+
+// defaultBatchSize is how many profiles a sink sends per request by default.
+const defaultBatchSize = 500
+
+// Option configures a sink.
+type Option func(*config)
+
+type config struct {
+	httpClient *http.Client
+	logger     *slog.Logger
+	username   string
+	password   string
+	apiKey     string
+	batchSize  int
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithHTTPClient sets the HTTP client used by sinks that talk to a server over HTTP.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) { c.httpClient = client }
+}
+
+// WithBasicAuth sets the username and password sent to the server.
+func WithBasicAuth(username, password string) Option {
+	return func(c *config) { c.username, c.password = username, password }
+}
+
+// WithAPIKey sets the API key sent to the server, in place of basic auth.
+func WithAPIKey(key string) Option {
+	return func(c *config) { c.apiKey = key }
+}
+
+// WithBatchSize sets how many profiles are sent per request (default 500).
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.batchSize = n
+		}
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{logger: slog.Default(), batchSize: defaultBatchSize}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
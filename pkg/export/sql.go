@@ -0,0 +1,290 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Dialect is the flavor of SQL a database speaks.
+type Dialect int
+
+// Supported dialects.
+const (
+	SQLite   Dialect = iota // SQLite 3.24 or later
+	Postgres                // PostgreSQL 9.5 or later
+)
+
+// sqlExportSchema creates the tables SQL writes. It is idempotent and valid in every
+// Dialect. Child tables are keyed by the profile's fingerprint and, where order
+// matters, the item's position on the profile.
+var sqlExportSchema = []string{
+	`CREATE TABLE IF NOT EXISTS profiles (
+		fingerprint TEXT PRIMARY KEY,
+		platform TEXT NOT NULL,
+		url TEXT NOT NULL,
+		username TEXT NOT NULL,
+		name TEXT NOT NULL,
+		bio TEXT NOT NULL,
+		location TEXT NOT NULL,
+		website TEXT NOT NULL,
+		company TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL,
+		last_active TEXT NOT NULL,
+		unstructured TEXT NOT NULL,
+		authenticated INTEGER NOT NULL,
+		error TEXT NOT NULL,
+		is_guess INTEGER NOT NULL,
+		confidence DOUBLE PRECISION NOT NULL,
+		exported_at TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS profiles_platform_username ON profiles (platform, username)`,
+	`CREATE TABLE IF NOT EXISTS profile_fields (
+		fingerprint TEXT NOT NULL,
+		key TEXT NOT NULL,
+		value TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, key)
+	)`,
+	`CREATE TABLE IF NOT EXISTS profile_links (
+		fingerprint TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		url TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, seq)
+	)`,
+	`CREATE INDEX IF NOT EXISTS profile_links_url ON profile_links (url)`,
+	`CREATE TABLE IF NOT EXISTS profile_aliases (
+		fingerprint TEXT NOT NULL,
+		alias TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, alias)
+	)`,
+	`CREATE INDEX IF NOT EXISTS profile_aliases_alias ON profile_aliases (alias)`,
+	`CREATE TABLE IF NOT EXISTS profile_emails (
+		fingerprint TEXT NOT NULL,
+		email TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, email)
+	)`,
+	`CREATE INDEX IF NOT EXISTS profile_emails_email ON profile_emails (email)`,
+	`CREATE TABLE IF NOT EXISTS profile_experience (
+		fingerprint TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		organization TEXT NOT NULL,
+		location TEXT NOT NULL,
+		start_date TEXT NOT NULL,
+		end_date TEXT NOT NULL,
+		description TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, seq)
+	)`,
+	`CREATE TABLE IF NOT EXISTS profile_posts (
+		fingerprint TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		type TEXT NOT NULL,
+		title TEXT NOT NULL,
+		content TEXT NOT NULL,
+		url TEXT NOT NULL,
+		category TEXT NOT NULL,
+		PRIMARY KEY (fingerprint, seq)
+	)`,
+}
+
+// sqlChildTables hold the per-profile rows replaced on every export.
+var sqlChildTables = []string{
+	"profile_fields", "profile_links", "profile_aliases", "profile_emails", "profile_experience", "profile_posts",
+}
+
+// SQL is a Sink that writes profiles into normalized tables: one row per account in
+// "profiles", keyed by its fingerprint, and its fields, social links, aliases, email
+// addresses, experience, and posts in "profile_fields", "profile_links",
+// "profile_aliases", "profile_emails", "profile_experience", and "profile_posts". Exporting an account again updates its
+// row and replaces its child rows, so the tables always hold the latest crawl.
+//
+// Like store.SQL, the driver is not linked in by this package:
+//
+//	import _ "github.com/jackc/pgx/v5/stdlib"
+//
+//	db, err := sql.Open("pgx", "postgres://localhost/people")
+//	s, err := export.NewSQL(ctx, db, export.Postgres)
+type SQL struct {
+	db      *sql.DB
+	cfg     *config
+	dialect Dialect
+	now     func() time.Time
+}
+
+var _ Sink = (*SQL)(nil)
+
+// NewSQL returns a Sink writing to db, creating its tables if they do not exist.
+func NewSQL(ctx context.Context, db *sql.DB, dialect Dialect, opts ...Option) (*SQL, error) {
+	s := &SQL{db: db, cfg: newConfig(opts), dialect: dialect, now: time.Now}
+	for _, stmt := range sqlExportSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("creating export schema: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Export implements Sink. Each batch of profiles is written in one transaction.
+func (s *SQL) Export(ctx context.Context, profiles []*profile.Profile) error {
+	now := s.now()
+	for start := 0; start < len(profiles); start += s.cfg.batchSize {
+		batch := profiles[start:min(start+s.cfg.batchSize, len(profiles))]
+		if err := s.exportBatch(ctx, batch, now); err != nil {
+			return err
+		}
+		s.cfg.logger.DebugContext(ctx, "exported profiles to sql", "count", len(batch))
+	}
+	return nil
+}
+
+func (s *SQL) exportBatch(ctx context.Context, batch []*profile.Profile, now time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // no-op after commit
+
+	for _, p := range batch {
+		if p == nil {
+			continue
+		}
+		for _, st := range profileStatements(p, now) {
+			if _, err := tx.ExecContext(ctx, s.dialect.rebind(st.query), st.args...); err != nil {
+				return fmt.Errorf("exporting %s: %w", p.URL, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// sqlStatement is a query with "?" placeholders and its arguments.
+type sqlStatement struct {
+	query string
+	args  []any
+}
+
+// profileStatements returns the statements that store p: an upsert of its profiles
+// row, deletes of its old child rows, and inserts of the new ones.
+func profileStatements(p *profile.Profile, now time.Time) []sqlStatement {
+	fp := p.Fingerprint()
+	company, _ := p.Employer()
+	stmts := []sqlStatement{{
+		query: `INSERT INTO profiles (fingerprint, platform, url, username, name, bio, location, website,
+			company, created_at, updated_at, last_active, unstructured, authenticated, error, is_guess,
+			confidence, exported_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (fingerprint) DO UPDATE SET platform = excluded.platform, url = excluded.url,
+			username = excluded.username, name = excluded.name, bio = excluded.bio,
+			location = excluded.location, website = excluded.website, company = excluded.company,
+			created_at = excluded.created_at, updated_at = excluded.updated_at,
+			last_active = excluded.last_active, unstructured = excluded.unstructured,
+			authenticated = excluded.authenticated, error = excluded.error, is_guess = excluded.is_guess,
+			confidence = excluded.confidence, exported_at = excluded.exported_at`,
+		args: []any{
+			fp, p.Platform, p.URL, strings.TrimPrefix(p.Username, "@"), p.Name, p.Bio, p.Location, p.Website,
+			company, p.CreatedAt, p.UpdatedAt, p.LastActive, p.Unstructured, boolInt(p.Authenticated), p.Error,
+			boolInt(p.IsGuess), p.Confidence, now.UTC().Format(time.RFC3339),
+		},
+	}}
+	for _, table := range sqlChildTables {
+		stmts = append(stmts, sqlStatement{query: `DELETE FROM ` + table + ` WHERE fingerprint = ?`, args: []any{fp}})
+	}
+
+	keys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_fields (fingerprint, key, value) VALUES (?, ?, ?)`,
+			args:  []any{fp, k, p.Fields[k]},
+		})
+	}
+	for i, link := range p.SocialLinks {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_links (fingerprint, seq, url) VALUES (?, ?, ?)`,
+			args:  []any{fp, i, link},
+		})
+	}
+	for _, alias := range p.Aliases {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_aliases (fingerprint, alias) VALUES (?, ?)`,
+			args:  []any{fp, alias},
+		})
+	}
+	for _, email := range emails(p) {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_emails (fingerprint, email) VALUES (?, ?)`,
+			args:  []any{fp, email},
+		})
+	}
+	for i, e := range p.Experience {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_experience (fingerprint, seq, title, organization, location, start_date, end_date, description)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			args: []any{fp, i, e.Title, e.Organization, e.Location, e.Start, e.End, e.Description},
+		})
+	}
+	for i, post := range p.Posts {
+		stmts = append(stmts, sqlStatement{
+			query: `INSERT INTO profile_posts (fingerprint, seq, type, title, content, url, category)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			args: []any{fp, i, string(post.Type), post.Title, post.Content, post.URL, post.Category},
+		})
+	}
+	return stmts
+}
+
+// emails returns p's distinct email addresses, lowercased, from Fields["email"] and
+// the "email_2", "email_3", ... keys profile.Builder adds further addresses under.
+func emails(p *profile.Profile) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for k, v := range p.Fields {
+		if k != profile.FieldEmail && !strings.HasPrefix(k, profile.FieldEmail+"_") {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(k, profile.FieldEmail+"_")); k != profile.FieldEmail && err != nil {
+			continue
+		}
+		if email := strings.ToLower(strings.TrimSpace(v)); email != "" && !seen[email] {
+			seen[email] = true
+			out = append(out, email)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// rebind rewrites query's "?" placeholders into the dialect's form.
+func (d Dialect) rebind(query string) string {
+	if d != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
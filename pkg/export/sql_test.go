@@ -0,0 +1,80 @@
+package export
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestProfileStatements(t *testing.T) {
+	p := &profile.Profile{
+		Platform: "github", URL: "https://github.com/alice", Username: "@alice", IsGuess: true, Confidence: 0.8,
+		Fields: map[string]string{
+			"email": "Alice@Example.com", "email_2": "alice@example.com", "email_3": "a@corp.dev",
+			"email_employer": "corp", "company": "Corp",
+		},
+		SocialLinks: []string{"https://twitter.com/alice", "https://alice.dev"},
+		Aliases:     []string{"https://github.com/alice-old"},
+		Experience:  []profile.Experience{{Title: "Engineer", Organization: "Corp", Start: "2020"}},
+		Posts:       []profile.Post{{Type: profile.PostTypeRepository, Title: "tool", URL: "https://github.com/alice/tool"}},
+	}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	stmts := profileStatements(p, now)
+
+	counts := make(map[string]int)
+	for _, st := range stmts {
+		if got, want := strings.Count(st.query, "?"), len(st.args); got != want {
+			t.Errorf("%d placeholders for %d args in %q", got, want, st.query)
+		}
+		if st.args[0] != p.Fingerprint() {
+			t.Errorf("first arg = %v, want fingerprint", st.args[0])
+		}
+		verb, table, _ := strings.Cut(strings.Join(strings.Fields(st.query)[:3], " "), " ")
+		if verb == "INSERT" || verb == "DELETE" {
+			table = strings.Fields(table)[1]
+		}
+		counts[verb+" "+table]++
+	}
+	want := map[string]int{
+		"INSERT profiles": 1, "INSERT profile_fields": 5, "INSERT profile_links": 2, "INSERT profile_emails": 2,
+		"INSERT profile_aliases": 1, "INSERT profile_experience": 1, "INSERT profile_posts": 1,
+	}
+	for _, table := range sqlChildTables {
+		want["DELETE "+table] = 1
+	}
+	for k, n := range want {
+		if counts[k] != n {
+			t.Errorf("%s statements = %d, want %d (all: %v)", k, counts[k], n, counts)
+		}
+	}
+
+	upsert := stmts[0]
+	if !strings.Contains(upsert.query, "ON CONFLICT (fingerprint) DO UPDATE") {
+		t.Errorf("profile insert is not an upsert: %s", upsert.query)
+	}
+	if upsert.args[3] != "alice" || upsert.args[8] != "Corp" || upsert.args[15] != 1 || upsert.args[17] != "2026-01-02T03:04:05Z" {
+		t.Errorf("profile args = %v", upsert.args)
+	}
+}
+
+func TestEmails(t *testing.T) {
+	p := &profile.Profile{Fields: map[string]string{
+		"email": "B@x.com", "email_2": "a@x.com", "email_3": "b@x.com", "email_employer": "x", "email_verified": "true",
+	}}
+	if got, want := emails(p), []string{"a@x.com", "b@x.com"}; !slices.Equal(got, want) {
+		t.Errorf("emails() = %v, want %v", got, want)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	q := `INSERT INTO t (a, b) VALUES (?, ?)`
+	if got := SQLite.rebind(q); got != q {
+		t.Errorf("SQLite.rebind() = %q", got)
+	}
+	if got, want := Postgres.rebind(q), `INSERT INTO t (a, b) VALUES ($1, $2)`; got != want {
+		t.Errorf("Postgres.rebind() = %q, want %q", got, want)
+	}
+}
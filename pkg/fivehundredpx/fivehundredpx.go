@@ -0,0 +1,194 @@
+// Package fivehundredpx fetches 500px photographer profiles.
+package fivehundredpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "fivehundredpx"
+
+// Profiles are /p/<user>; older links omit the /p/ prefix.
+var usernamePattern = regexp.MustCompile(`(?i)500px\.com/(?:p/)?([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are 500px.com paths that are not photographers.
+var nonProfiles = map[string]bool{
+	"p": true, "login": true, "signup": true, "discover": true, "popular": true, "editors": true,
+	"upcoming": true, "fresh": true, "licensing": true, "about": true, "blog": true, "search": true,
+	"galleries": true, "photo": true, "quests": true, "pricing": true, "settings": true, "upload": true,
+}
+
+// Match returns true if the URL is a 500px profile.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles 500px requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a 500px client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a 500px profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://500px.com/p/" + username
+	c.logger.InfoContext(ctx, "fetching 500px profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a 500px profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://500px.com/p/"+username, username)
+}
+
+var (
+	ogTitlePattern = regexp.MustCompile(`(?i)<meta[^>]+property="og:title"[^>]+content="([^"]+)"`)
+	// The page embeds the user as JSON; string values are captured with their quotes.
+	fullnamePattern  = regexp.MustCompile(`"fullname":("(?:[^"\\]|\\.)*")`)
+	aboutPattern     = regexp.MustCompile(`"about":("(?:[^"\\]|\\.)*")`)
+	cityPattern      = regexp.MustCompile(`"city":("(?:[^"\\]|\\.)*")`)
+	countryPattern   = regexp.MustCompile(`"country":("(?:[^"\\]|\\.)*")`)
+	websitePattern   = regexp.MustCompile(`"website":("(?:[^"\\]|\\.)*")`)
+	followersPattern = regexp.MustCompile(`"followers(?:_c|C)ount":(\d+)`)
+	followingPattern = regexp.MustCompile(`"(?:friends|following)(?:_c|C)ount":(\d+)`)
+	photosPattern    = regexp.MustCompile(`"photos(?:_c|C)ount":(\d+)`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	p.Name = jsonString(fullnamePattern, content)
+	if p.Name == "" {
+		// og:title reads "Jane Doe's Photography | 500px" or "Jane Doe | 500px".
+		if m := ogTitlePattern.FindStringSubmatch(content); m != nil {
+			name := strings.TrimSpace(strings.TrimSuffix(html.UnescapeString(m[1]), "| 500px"))
+			p.Name = strings.TrimSpace(strings.TrimSuffix(name, "'s Photography"))
+		}
+	}
+	if p.Name == "" || strings.EqualFold(p.Name, "500px") {
+		return nil, errors.New("profile not found (no display name)")
+	}
+
+	p.Bio = jsonString(aboutPattern, content)
+	if p.Bio == "" {
+		p.Bio = htmlutil.Description(content)
+	}
+	city, country := jsonString(cityPattern, content), jsonString(countryPattern, content)
+	switch {
+	case city != "" && country != "":
+		p.Location = city + ", " + country
+	default:
+		p.Location = city + country
+	}
+	p.Website = jsonString(websitePattern, content)
+
+	for field, pattern := range map[string]*regexp.Regexp{
+		profile.FieldFollowers: followersPattern,
+		profile.FieldFollowing: followingPattern,
+		"photos":               photosPattern,
+	} {
+		if m := pattern.FindStringSubmatch(content); m != nil {
+			p.Fields[field] = m[1]
+		}
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+// jsonString returns the first JSON string value pattern captures, decoded.
+func jsonString(pattern *regexp.Regexp, content string) string {
+	m := pattern.FindStringSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal([]byte(m[1]), &s); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,76 @@
+package fivehundredpx
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://500px.com/p/janedoe", true},
+		{"https://500px.com/p/janedoe?view=photos", true},
+		{"https://500px.com/janedoe", true},
+		{"https://500px.com/photo/1234/sunset", false},
+		{"https://500px.com/popular", false},
+		{"https://500px.com/p/", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("500px should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name         string
+		page         string
+		wantName     string
+		wantLocation string
+		wantFollow   string
+	}{
+		{
+			name: "embedded json",
+			page: `<script>window.__INITIAL_STATE__ = {"user":{"username":"janedoe","fullname":"Jane Doe",
+				"about":"Landscape photographer & teacher","city":"Calgary","country":"Canada",
+				"followersCount":2048,"friendsCount":77,"photosCount":310,"website":"https://janedoe.photo"}};</script>`,
+			wantName:     "Jane Doe",
+			wantLocation: "Calgary, Canada",
+			wantFollow:   "2048",
+		},
+		{
+			name:     "og title fallback",
+			page:     `<meta property="og:title" content="Jane Doe&#39;s Photography | 500px">`,
+			wantName: "Jane Doe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := parseProfile(tt.page, "https://500px.com/p/janedoe", "janedoe")
+			if err != nil {
+				t.Fatalf("parseProfile() error = %v", err)
+			}
+			if p.Name != tt.wantName || p.Location != tt.wantLocation || p.Fields["followers"] != tt.wantFollow {
+				t.Errorf("Name = %q, Location = %q, followers = %q", p.Name, p.Location, p.Fields["followers"])
+			}
+		})
+	}
+
+	p, err := parseProfile(tests[0].page, "https://500px.com/p/janedoe", "janedoe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Bio != "Landscape photographer & teacher" || p.Website != "https://janedoe.photo" || p.Fields["photos"] != "310" {
+		t.Errorf("Bio = %q, Website = %q, photos = %q", p.Bio, p.Website, p.Fields["photos"])
+	}
+
+	if _, err := parseProfile(`<title>500px</title>`, "https://500px.com/p/x", "x"); err == nil {
+		t.Error("parseProfile(generic page) should fail")
+	}
+}
@@ -0,0 +1,185 @@
+// Package flickr fetches Flickr people pages.
+package flickr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "flickr"
+
+// People pages are /people/<user>; a photostream root (/photos/<user>) names the same account.
+var usernamePattern = regexp.MustCompile(`(?i)flickr\.com/(?:people|photos)/([\w@.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are /photos/ paths that are not accounts.
+var nonProfiles = map[string]bool{"tags": true, "upload": true, "organize": true, "friends": true}
+
+// Match returns true if the URL is a Flickr people page or photostream.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because people pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles Flickr requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Flickr client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Flickr profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://www.flickr.com/people/" + username + "/"
+	c.logger.InfoContext(ctx, "fetching flickr profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a Flickr people page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://www.flickr.com/people/"+username+"/", username)
+}
+
+var (
+	ogTitlePattern = regexp.MustCompile(`(?i)<meta[^>]+property="og:title"[^>]+content="([^"]+)"`)
+	// Counts render as "1.2K Followers • 310 Following" and "4,512 Photos".
+	followersPattern = regexp.MustCompile(`(?i)([\d.,]+[KM]?)\s+Followers`)
+	followingPattern = regexp.MustCompile(`(?i)([\d.,]+[KM]?)\s+Following`)
+	photosPattern    = regexp.MustCompile(`(?i)([\d.,]+[KM]?)\s+Photos`)
+	// The about section is a definition list: <dt>Hometown</dt><dd>Leeds, UK</dd>
+	aboutPattern  = regexp.MustCompile(`(?is)<dt[^>]*>\s*([^<]+?)\s*</dt>\s*<dd[^>]*>(.*?)</dd>`)
+	joinedPattern = regexp.MustCompile(`(?i)>\s*Joined\s+((?:[A-Z][a-z]+ )?\d{4})\s*<`)
+	tagPattern    = regexp.MustCompile(`<[^>]+>`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	if m := ogTitlePattern.FindStringSubmatch(content); m != nil {
+		p.Name = strings.TrimSpace(html.UnescapeString(m[1]))
+	}
+	if p.Name == "" || strings.EqualFold(p.Name, "Flickr") {
+		return nil, errors.New("profile not found (no display name)")
+	}
+	p.Bio = htmlutil.Description(content)
+
+	for _, m := range aboutPattern.FindAllStringSubmatch(content, -1) {
+		value := stripTags(m[2])
+		if value == "" {
+			continue
+		}
+		switch strings.ToLower(m[1]) {
+		case "currently":
+			p.Location = value
+		case "hometown":
+			if p.Location == "" {
+				p.Location = value
+			}
+			p.Fields["hometown"] = value
+		case "occupation":
+			p.Fields["occupation"] = value
+		case "website":
+			p.Website = value
+		}
+	}
+
+	for field, pattern := range map[string]*regexp.Regexp{
+		profile.FieldFollowers: followersPattern,
+		profile.FieldFollowing: followingPattern,
+		"photos":               photosPattern,
+	} {
+		if m := pattern.FindStringSubmatch(content); m != nil {
+			p.Fields[field] = m[1]
+		}
+	}
+	if m := joinedPattern.FindStringSubmatch(content); m != nil {
+		p.Fields["joined"] = m[1]
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+func stripTags(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(s, " "))), " ")
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
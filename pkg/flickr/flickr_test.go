@@ -0,0 +1,70 @@
+package flickr
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.flickr.com/people/janedoe/", true},
+		{"https://flickr.com/photos/janedoe", true},
+		{"https://www.flickr.com/photos/12345678@N00/", true},
+		{"https://www.flickr.com/photos/janedoe/5123456789/", false},
+		{"https://www.flickr.com/photos/tags/sunset", false},
+		{"https://www.flickr.com/explore", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Flickr should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><head><title>About Jane Doe | Flickr</title>
+<meta property="og:title" content="Jane Doe">
+<meta name="description" content="Street and landscape photographer.">
+</head><body>
+<p class="followers truncate no-shrink">1.2K Followers • 310 Following</p>
+<p class="photo-count">4,512 Photos</p>
+<p class="metadata-item joined">Joined 2009</p>
+<dl><dt>Occupation</dt><dd>Photographer at Acme</dd></dl>
+<dl><dt>Hometown</dt><dd>Leeds, UK</dd></dl>
+<dl><dt>Currently</dt><dd><span>Berlin, Germany</span></dd></dl>
+<a href="https://www.instagram.com/janedoe.photo">Instagram</a>
+</body></html>`
+
+	p, err := parseProfile(page, "https://www.flickr.com/people/janedoe/", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Bio != "Street and landscape photographer." {
+		t.Errorf("Name = %q, Bio = %q", p.Name, p.Bio)
+	}
+	if p.Location != "Berlin, Germany" || p.Fields["hometown"] != "Leeds, UK" {
+		t.Errorf("Location = %q, hometown = %q", p.Location, p.Fields["hometown"])
+	}
+	want := map[string]string{
+		"followers": "1.2K", "following": "310", "photos": "4,512",
+		"joined": "2009", "occupation": "Photographer at Acme",
+	}
+	for k, v := range want {
+		if p.Fields[k] != v {
+			t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+		}
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://www.instagram.com/janedoe.photo" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+
+	if _, err := parseProfile(`<meta property="og:title" content="Flickr">`, "https://www.flickr.com/people/x/", "x"); err == nil {
+		t.Error("parseProfile(generic page) should fail")
+	}
+}
@@ -0,0 +1,134 @@
+package generic
+
+import (
+	"context"
+	"encoding/xml"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+var (
+	feedLinkPattern = regexp.MustCompile(`(?i)<link[^>]+type=["']application/(?:rss|atom)\+xml["'][^>]*>`)
+	hrefPattern     = regexp.MustCompile(`(?i)href=["']([^"']+)["']`)
+)
+
+// feedDateLayouts are the date formats seen in RSS pubDate and Atom updated elements.
+var feedDateLayouts = []string{time.RFC3339, time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "2006-01-02"}
+
+// discoverFeed returns the absolute URL of the first RSS or Atom feed advertised by the page.
+func discoverFeed(content, baseURL string) string {
+	tag := feedLinkPattern.FindString(content)
+	if tag == "" {
+		return ""
+	}
+	m := hrefPattern.FindStringSubmatch(tag)
+	if len(m) < 2 {
+		return ""
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return resolveURL(base, html.UnescapeString(m[1]))
+}
+
+// fetchFeed retrieves and parses a feed.
+func (c *Client) fetchFeed(ctx context.Context, feedURL string) (posts []profile.Post, lastActive string) {
+	if err := ValidateURL(feedURL); err != nil {
+		return nil, ""
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, http.NoBody)
+	if err != nil {
+		return nil, ""
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		c.logger.DebugContext(ctx, "feed fetch failed", "url", feedURL, "error", err)
+		return nil, ""
+	}
+	return parseFeed(body)
+}
+
+// parseFeed extracts posts from an RSS 2.0 or Atom feed, newest first as published.
+// lastActive is the most recent entry date as YYYY-MM-DD.
+func parseFeed(data []byte) (posts []profile.Post, lastActive string) {
+	var feed struct {
+		Channel struct {
+			Items []struct {
+				Title       string `xml:"title"`
+				Link        string `xml:"link"`
+				PubDate     string `xml:"pubDate"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+		Entries []struct {
+			Title string `xml:"title"`
+			Links []struct {
+				Href string `xml:"href,attr"`
+				Rel  string `xml:"rel,attr"`
+			} `xml:"link"`
+			Updated   string `xml:"updated"`
+			Published string `xml:"published"`
+			Summary   string `xml:"summary"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, ""
+	}
+
+	add := func(title, link, date, summary string) {
+		if len(posts) >= maxBlogPosts || (title == "" && link == "") {
+			return
+		}
+		posts = append(posts, profile.Post{
+			Type:    profile.PostTypeArticle,
+			Title:   strings.TrimSpace(html.UnescapeString(title)),
+			Content: strings.TrimSpace(htmlutil.ToMarkdown(summary)),
+			URL:     strings.TrimSpace(link),
+		})
+		if d := parseFeedDate(date); d > lastActive {
+			lastActive = d
+		}
+	}
+
+	for _, item := range feed.Channel.Items {
+		add(item.Title, item.Link, item.PubDate, item.Description)
+	}
+	for _, e := range feed.Entries {
+		var link string
+		for _, l := range e.Links {
+			if l.Rel == "" || l.Rel == "alternate" {
+				link = l.Href
+				break
+			}
+		}
+		date := e.Published
+		if date == "" {
+			date = e.Updated
+		}
+		add(e.Title, link, date, e.Summary)
+	}
+
+	return posts, lastActive
+}
+
+func parseFeedDate(s string) string {
+	s = strings.TrimSpace(s)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format("2006-01-02")
+		}
+	}
+	return ""
+}
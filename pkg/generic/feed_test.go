@@ -0,0 +1,87 @@
+package generic
+
+import "testing"
+
+func TestDiscoverFeed(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "rss relative",
+			content: `<head><link rel="alternate" type="application/rss+xml" title="Blog" href="/index.xml"></head>`,
+			want:    "https://example.com/index.xml",
+		},
+		{
+			name:    "atom absolute, href first",
+			content: `<link href="https://feeds.example.net/atom" rel="alternate" type="application/atom+xml">`,
+			want:    "https://feeds.example.net/atom",
+		},
+		{
+			name:    "no feed",
+			content: `<link rel="stylesheet" href="/style.css">`,
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := discoverFeed(tt.content, "https://example.com/about"); got != tt.want {
+				t.Errorf("discoverFeed() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		wantTitles     []string
+		wantLastActive string
+	}{
+		{
+			name: "rss",
+			data: `<?xml version="1.0"?><rss version="2.0"><channel>
+				<item><title>Older &amp; wiser</title><link>https://example.com/older</link><pubDate>Mon, 02 Jan 2023 10:00:00 +0000</pubDate></item>
+				<item><title>Newest</title><link>https://example.com/new</link><pubDate>Tue, 05 Mar 2024 10:00:00 +0000</pubDate>
+					<description>&lt;p&gt;Hello&lt;/p&gt;</description></item>
+			</channel></rss>`,
+			wantTitles:     []string{"Older & wiser", "Newest"},
+			wantLastActive: "2024-03-05",
+		},
+		{
+			name: "atom",
+			data: `<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom">
+				<entry><title>Post</title><link rel="alternate" href="https://example.com/post"/><updated>2024-06-01T12:00:00Z</updated></entry>
+			</feed>`,
+			wantTitles:     []string{"Post"},
+			wantLastActive: "2024-06-01",
+		},
+		{
+			name: "not xml",
+			data: `<html>nope`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts, lastActive := parseFeed([]byte(tt.data))
+			if len(posts) != len(tt.wantTitles) {
+				t.Fatalf("parseFeed() returned %d posts, want %d", len(posts), len(tt.wantTitles))
+			}
+			for i, want := range tt.wantTitles {
+				if posts[i].Title != want {
+					t.Errorf("posts[%d].Title = %q, want %q", i, posts[i].Title, want)
+				}
+				if posts[i].URL == "" {
+					t.Errorf("posts[%d].URL is empty", i)
+				}
+			}
+			if lastActive != tt.wantLastActive {
+				t.Errorf("lastActive = %q, want %q", lastActive, tt.wantLastActive)
+			}
+		})
+	}
+}
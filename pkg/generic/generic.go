@@ -3,7 +3,6 @@ package generic
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"html"
@@ -17,6 +16,7 @@ import (
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
@@ -36,14 +36,18 @@ type Client struct {
 	httpClient *http.Client
 	cache      cache.HTTPCache
 	logger     *slog.Logger
+	renderer   Renderer
+	depth      profile.Depth
 }
 
 // Option configures a Client.
 type Option func(*config)
 
 type config struct {
-	cache  cache.HTTPCache
-	logger *slog.Logger
+	cache    cache.HTTPCache
+	logger   *slog.Logger
+	renderer Renderer
+	depth    profile.Depth
 }
 
 // WithHTTPCache sets the HTTP cache.
@@ -56,6 +60,17 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
+// WithRenderer renders pages whose static HTML is an empty JavaScript app shell,
+// so client-rendered personal sites still yield content. FindChrome returns one.
+func WithRenderer(r Renderer) Option {
+	return func(c *config) { c.renderer = r }
+}
+
 // New creates a generic client.
 func New(ctx context.Context, opts ...Option) (*Client, error) {
 	cfg := &config{logger: slog.Default()}
@@ -64,14 +79,11 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		renderer:   cfg.renderer,
+		depth:      cfg.depth,
 	}, nil
 }
 
@@ -83,9 +95,12 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	}
 
 	// Security: validate URL
-	if err := validateURL(urlStr); err != nil {
+	if err := ValidateURL(urlStr); err != nil {
 		return nil, err
 	}
+	// Names and redirects can lead to private addresses too, so connections are
+	// checked against the addresses actually dialed
+	ctx = cache.WithPublicOnly(ctx)
 
 	c.logger.InfoContext(ctx, "fetching generic website", "url", urlStr)
 
@@ -102,7 +117,42 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 		return nil, err
 	}
 
-	return parseHTML(body, urlStr), nil
+	// Rendering always reaches the network, so offline runs parse the cached shell
+	if c.renderer != nil && !cache.IsOffline(ctx) && isSPAShell(string(body)) {
+		c.logger.DebugContext(ctx, "static HTML is an app shell, rendering", "url", urlStr)
+		rendered, err := c.renderer.Render(ctx, urlStr)
+		if err != nil {
+			c.logger.WarnContext(ctx, "render failed, using static HTML", "url", urlStr, "error", err)
+		} else {
+			body = []byte(rendered)
+		}
+	}
+
+	p := parseHTML(body, urlStr)
+
+	// At deep depth, fall back to the site's feed for posts the page does not list
+	if c.depth == profile.DepthDeep {
+		if feedURL := discoverFeed(string(body), urlStr); feedURL != "" {
+			p.Fields["feed"] = feedURL
+			if len(p.Posts) == 0 {
+				if posts, lastActive := c.fetchFeed(ctx, feedURL); len(posts) > 0 {
+					p.Posts = posts
+					p.Platform = "blog"
+					p.UpdateLastActive(lastActive)
+				}
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// Parse extracts a profile from a website's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	if !strings.HasPrefix(urlStr, "http://") && !strings.HasPrefix(urlStr, "https://") {
+		urlStr = "https://" + urlStr
+	}
+	return parseHTML(data, urlStr), nil
 }
 
 func parseHTML(data []byte, urlStr string) *profile.Profile {
@@ -126,8 +176,8 @@ func parseHTML(data []byte, urlStr string) *profile.Profile {
 	contactLinks := htmlutil.ContactLinks(content, urlStr)
 	p.SocialLinks = append(p.SocialLinks, contactLinks...)
 
-	// Deduplicate social links
-	p.SocialLinks = dedupeLinks(p.SocialLinks)
+	// Deduplicate social links and drop denylisted ones
+	p.SocialLinks = links.Clean(p.SocialLinks, nil)
 
 	// Extract emails
 	emails := htmlutil.EmailAddresses(content)
@@ -146,9 +196,9 @@ func parseHTML(data []byte, urlStr string) *profile.Profile {
 		p.Posts = posts
 		p.Platform = "blog"
 		if lastActive != "" {
-			p.UpdatedAt = lastActive
+			p.LastActive = lastActive
 		} else if len(posts) > 0 && posts[0].URL != "" {
-			p.UpdatedAt = extractDateFromURL(posts[0].URL)
+			p.LastActive = extractDateFromURL(posts[0].URL)
 		}
 	}
 
@@ -161,6 +211,18 @@ type blogPost struct {
 	date string // YYYY-MM-DD format for sorting
 }
 
+// Post list layouts extractBlogPosts recognizes.
+var (
+	jekyllPattern = regexp.MustCompile(`<li>\s*<span>(\d{1,2})\s+(\w{3})\s+(\d{4})</span>\s*(?:&raquo;|»)\s*` +
+		`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
+	datePostPattern   = regexp.MustCompile(`(?i)<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>\s*[-–—]\s*(\d{4}-\d{2}-\d{2})`)
+	datePrefixPattern = regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>(\d{4}-\d{2}-\d{2})\s*[-–—]\s*([^<]+)</a>`)
+	usDatePattern     = regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>[^<]*:\s*(\d{1,2})\.(\d{1,2})\.(\d{2,4})`)
+	articlePattern    = regexp.MustCompile(`(?is)<article[^>]*>(.*?)</article>`)
+	linkPattern       = regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
+	sectionPattern    = regexp.MustCompile(`(?is)<h[123][^>]*>[^<]*(?:posts?|articles?|blog)[^<]*</h[123]>\s*(.*?)(?:<h[123]|</body|$)`)
+)
+
 // extractBlogPosts detects if a page is a blog and extracts post entries.
 // Returns posts and the date of the most recent post (if available).
 func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive string) {
@@ -179,7 +241,6 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 
 	// Pattern 1: Links with dates in format YYYY-MM-DD or similar near them
 	// e.g., <a href="/posts/2025/...">Title</a> - 2025-07-07
-	datePostPattern := regexp.MustCompile(`(?i)<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>\s*[-–—]\s*(\d{4}-\d{2}-\d{2})`)
 	for _, m := range datePostPattern.FindAllStringSubmatch(content, maxBlogPosts) {
 		postURL := resolveURL(base, m[1])
 		if !isPostURL(postURL) {
@@ -201,7 +262,6 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 	}
 
 	// Pattern 2: Links with date prefix in link text (e.g., "2023-04-25 – Title")
-	datePrefixPattern := regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>(\d{4}-\d{2}-\d{2})\s*[-–—]\s*([^<]+)</a>`)
 	for _, m := range datePrefixPattern.FindAllStringSubmatch(content, maxBlogPosts) {
 		postURL := resolveURL(base, m[1])
 		if !isPostURL(postURL) {
@@ -223,9 +283,6 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 
 	// Pattern 3: Jekyll-style post list with date span before link
 	// e.g., <li><span>19 Feb 2015</span> &raquo; <a href="URL">Title</a></li>
-	jekyllRe := `<li>\s*<span>(\d{1,2})\s+(\w{3})\s+(\d{4})</span>\s*(?:&raquo;|»)\s*` +
-		`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`
-	jekyllPattern := regexp.MustCompile(jekyllRe)
 	for _, m := range jekyllPattern.FindAllStringSubmatch(content, maxBlogPosts) {
 		postURL := resolveURL(base, m[4])
 		if !isPostURL(postURL) {
@@ -248,7 +305,6 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 
 	// Pattern 4: Links followed by date in MM.DD.YYYY or MM.DD.YY format
 	// e.g., <a href="URL">Title</a> ... : 05.15.2020
-	usDatePattern := regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>[^<]*:\s*(\d{1,2})\.(\d{1,2})\.(\d{2,4})`)
 	for _, m := range usDatePattern.FindAllStringSubmatch(content, maxBlogPosts) {
 		postURL := resolveURL(base, m[1])
 		title := html.UnescapeString(strings.TrimSpace(m[2]))
@@ -278,10 +334,8 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 	}
 
 	// Pattern 5: All links within an <article> element pointing to post URLs
-	articlePattern := regexp.MustCompile(`(?is)<article[^>]*>(.*?)</article>`)
 	if m := articlePattern.FindStringSubmatch(content); len(m) > 1 {
 		articleContent := m[1]
-		linkPattern := regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
 		for _, lm := range linkPattern.FindAllStringSubmatch(articleContent, maxBlogPosts) {
 			postURL := resolveURL(base, lm[1])
 			if !isPostURL(postURL) {
@@ -304,10 +358,8 @@ func extractBlogPosts(content, baseURL string) (posts []profile.Post, lastActive
 
 	// Pattern 6: Look for links in post/blog sections
 	// Find section with "posts", "articles", "blog" heading, then extract links
-	sectionPattern := regexp.MustCompile(`(?is)<h[123][^>]*>[^<]*(?:posts?|articles?|blog)[^<]*</h[123]>\s*(.*?)(?:<h[123]|</body|$)`)
 	if m := sectionPattern.FindStringSubmatch(content); len(m) > 1 {
 		sectionContent := m[1]
-		linkPattern := regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
 		for _, lm := range linkPattern.FindAllStringSubmatch(sectionContent, maxBlogPosts) {
 			postURL := resolveURL(base, lm[1])
 			if !isPostURL(postURL) {
@@ -374,6 +426,9 @@ func isNavLink(title string) bool {
 	return false
 }
 
+// headingPattern matches headings that introduce a list of posts.
+var headingPattern = regexp.MustCompile(`(?i)<h[123][^>]*>[^<]*(?:recent posts?|latest posts?|blog posts?|articles?)[^<]*</h[123]>`)
+
 // isBlogPage checks if the page appears to be a blog.
 func isBlogPage(content string) bool {
 	lower := strings.ToLower(content)
@@ -394,10 +449,12 @@ func isBlogPage(content string) bool {
 	}
 
 	// Check for blog-related headings
-	headingPattern := regexp.MustCompile(`(?i)<h[123][^>]*>[^<]*(?:recent posts?|latest posts?|blog posts?|articles?)[^<]*</h[123]>`)
 	return headingPattern.MatchString(content)
 }
 
+// yearPattern matches a year path segment, as in /2024/.
+var yearPattern = regexp.MustCompile(`/20[12]\d/`)
+
 // isPostURL checks if a URL looks like a blog post URL.
 func isPostURL(urlStr string) bool {
 	lower := strings.ToLower(urlStr)
@@ -411,7 +468,6 @@ func isPostURL(urlStr string) bool {
 	}
 
 	// Check for year patterns like /2024/ or /2025/
-	yearPattern := regexp.MustCompile(`/20[12]\d/`)
 	return yearPattern.MatchString(urlStr)
 }
 
@@ -424,10 +480,12 @@ func resolveURL(base *url.URL, ref string) string {
 	return base.ResolveReference(refURL).String()
 }
 
+// datePattern matches a date in a post URL path: a year, then optionally month and day.
+var datePattern = regexp.MustCompile(`/(20[12]\d)[/-]?(\d{2})?[/-]?(\d{2})?/`)
+
 // extractDateFromURL extracts an ISO date from a URL containing year/month/day patterns.
 func extractDateFromURL(urlStr string) string {
 	// Look for /YYYY/MM/DD/ or /YYYY-MM-DD/ patterns
-	datePattern := regexp.MustCompile(`/(20[12]\d)[/-]?(\d{2})?[/-]?(\d{2})?/`)
 	if m := datePattern.FindStringSubmatch(urlStr); len(m) > 1 {
 		year := m[1]
 		month := "01"
@@ -443,33 +501,25 @@ func extractDateFromURL(urlStr string) string {
 	return ""
 }
 
-// cleanEmail removes anti-spam text from email addresses.
-func cleanEmail(email string) string {
-	// Remove "NOSPAM" (case-insensitive) from email addresses
-	lower := strings.ToLower(email)
-	if strings.Contains(lower, "nospam") {
-		// Find position of "nospam" and remove it
-		idx := strings.Index(lower, "nospam")
-		return email[:idx] + email[idx+6:]
-	}
-	return email
-}
+// antiSpamMarkers are words people insert into addresses for humans to remove.
+var antiSpamMarkers = []string{"nospam", "no-spam", "removethis", "remove-this"}
 
-func dedupeLinks(links []string) []string {
-	seen := make(map[string]bool)
-	var result []string
-	for _, link := range links {
-		normalized := strings.TrimSuffix(strings.ToLower(link), "/")
-		if !seen[normalized] {
-			seen[normalized] = true
-			result = append(result, link)
+// cleanEmail removes anti-spam text from email addresses. Spelled-out and encoded
+// addresses are decoded earlier, by htmlutil.EmailAddresses.
+func cleanEmail(email string) string {
+	for _, marker := range antiSpamMarkers {
+		// Only the first occurrence; the rest may be part of the real address
+		if idx := strings.Index(strings.ToLower(email), marker); idx != -1 {
+			return email[:idx] + email[idx+len(marker):]
 		}
 	}
-	return result
+	return email
 }
 
-// validateURL checks for SSRF vulnerabilities.
-func validateURL(urlStr string) error {
+// ValidateURL refuses URLs naming local hosts, private addresses, or cloud metadata
+// services, which a crawled link or API caller must not make us fetch. Connections
+// also need cache.WithPublicOnly, since names and redirects can lead there too.
+func ValidateURL(urlStr string) error {
 	parsed, err := url.Parse(urlStr)
 	if err != nil {
 		return fmt.Errorf("invalid URL: %w", err)
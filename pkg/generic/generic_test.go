@@ -49,9 +49,9 @@ func TestValidateURL(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.url, func(t *testing.T) {
-			err := validateURL(tt.url)
+			err := ValidateURL(tt.url)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("validateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+				t.Errorf("ValidateURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
 			}
 		})
 	}
@@ -67,6 +67,7 @@ func TestCleanEmail(t *testing.T) {
 		{"user@NoSpAmtest.org", "user@test.org"},
 		{"normal@example.com", "normal@example.com"},
 		{"test@nospam.nospam.org", "test@.nospam.org"}, // Only removes first occurrence
+		{"jane@REMOVETHISdoe.dev", "jane@doe.dev"},
 	}
 
 	for _, tt := range tests {
@@ -177,20 +178,6 @@ func TestParseHTML(t *testing.T) {
 	}
 }
 
-func TestDedupeLinks(t *testing.T) {
-	links := []string{
-		"https://github.com/user",
-		"https://GITHUB.COM/user/",
-		"https://twitter.com/user",
-		"https://github.com/user",
-	}
-
-	deduped := dedupeLinks(links)
-	if len(deduped) != 2 {
-		t.Errorf("dedupeLinks() returned %d links, want 2", len(deduped))
-	}
-}
-
 func TestWithOptions(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,156 @@
+package generic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	neturl "net/url"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// Renderer loads a page in a browser and returns the DOM after its scripts run.
+// The generic client uses it for pages whose static HTML is an empty SPA shell.
+type Renderer interface {
+	Render(ctx context.Context, url string) (string, error)
+}
+
+// ChromeRenderer renders pages with a headless Chrome or Chromium binary.
+//
+// Chrome reaches the network only through a proxy in this process, which dials with
+// the cache.Network in the context, so PublicOnly, resolvers, and local addresses hold
+// for the page and everything it loads. A proxy from the environment is passed to
+// Chrome instead. Settings Chrome cannot honor, such as custom TLS roots or headers
+// added by request hooks, make Render fail with ErrRenderUnsupported.
+type ChromeRenderer struct {
+	Path    string        // Browser executable
+	Timeout time.Duration // Per-page limit; defaults to 15 seconds
+}
+
+// ErrRenderUnsupported is returned by ChromeRenderer.Render when the request's
+// network settings cannot be applied to the browser.
+var ErrRenderUnsupported = errors.New("network settings cannot be applied to the browser")
+
+// chromeNames are the executable names Chrome and Chromium install under.
+var chromeNames = []string{
+	"chromium", "chromium-browser", "google-chrome", "google-chrome-stable", "chrome",
+	"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+	"/Applications/Chromium.app/Contents/MacOS/Chromium",
+}
+
+// FindChrome returns a ChromeRenderer for the first Chrome or Chromium found on this machine.
+func FindChrome() (*ChromeRenderer, error) {
+	for _, name := range chromeNames {
+		if path, err := exec.LookPath(name); err == nil {
+			return &ChromeRenderer{Path: path}, nil
+		}
+	}
+	return nil, errors.New("no chrome or chromium executable found")
+}
+
+// Render implements Renderer.
+func (r *ChromeRenderer) Render(ctx context.Context, url string) (string, error) {
+	if err := ValidateURL(url); err != nil {
+		return "", err
+	}
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = 15 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	netArgs, done, err := chromeNetworkArgs(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("render %s: %w", url, err)
+	}
+	defer done()
+
+	args := append([]string{
+		"--headless=new", "--disable-gpu", "--no-first-run", "--mute-audio",
+		"--virtual-time-budget=5000",
+	}, netArgs...)
+	//nolint:gosec // url has passed ValidateURL; the path is chosen by the caller
+	cmd := exec.CommandContext(ctx, r.Path, append(args, "--dump-dom", url)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("render %s: %w: %s", url, err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// probeUserAgent stands in for Chrome's own User-Agent when checking which headers
+// the identity and request hooks in a context would set.
+const probeUserAgent = "Mozilla/5.0 (render probe)"
+
+// chromeNetworkArgs returns the flags that make Chrome load rawURL as ctx's network
+// settings require, and a function releasing what they need once Chrome exits.
+func chromeNetworkArgs(ctx context.Context, rawURL string) ([]string, func(), error) {
+	n := cache.NetworkFrom(ctx)
+	if n.RootCAs != nil || n.Certificate != nil {
+		return nil, nil, fmt.Errorf("%w: custom TLS roots or client certificates", ErrRenderUnsupported)
+	}
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Chrome can take a User-Agent, but not other headers
+	var args []string
+	probe := &http.Request{URL: u, Header: http.Header{"User-Agent": {probeUserAgent}}}
+	cache.PrepareRequest(ctx, probe)
+	if ua := probe.Header.Get("User-Agent"); ua != probeUserAgent {
+		args = append(args, "--user-agent="+ua)
+	}
+	probe.Header.Del("User-Agent")
+	if len(probe.Header) > 0 {
+		return nil, nil, fmt.Errorf("%w: request headers %v", ErrRenderUnsupported, slices.Sorted(maps.Keys(probe.Header)))
+	}
+
+	// Chrome resolves no names itself: the proxy does, or the one it was given does.
+	// WebRTC would otherwise connect around the proxy.
+	args = append(args, "--force-webrtc-ip-handling-policy=disable_non_proxied_udp")
+	if proxy, err := http.ProxyFromEnvironment(&http.Request{URL: u}); err == nil && proxy != nil {
+		args = append(args, "--proxy-server="+proxy.Scheme+"://"+proxy.Host,
+			"--host-resolver-rules=MAP * ~NOTFOUND , EXCLUDE "+proxy.Hostname())
+		return args, func() {}, nil
+	}
+	p, err := startRenderProxy(n)
+	if err != nil {
+		return nil, nil, err
+	}
+	args = append(args, "--proxy-server=http://"+p.addr(), "--proxy-bypass-list=<-loopback>",
+		"--host-resolver-rules=MAP * ~NOTFOUND , EXCLUDE 127.0.0.1")
+	return args, p.close, nil
+}
+
+var (
+	// spaRootPattern matches the empty mount points React, Vue, Next, Nuxt, and Angular render into.
+	spaRootPattern   = regexp.MustCompile(`(?i)<(?:div|main)[^>]+id=["'](?:root|app|__next|__nuxt|svelte)["'][^>]*>\s*</(?:div|main)>|<app-root[^>]*>\s*</app-root>`)
+	invisiblePattern = regexp.MustCompile(`(?is)<(script|style|noscript|template|head)[^>]*>.*?</(?:script|style|noscript|template|head)>`)
+	tagPattern       = regexp.MustCompile(`<[^>]+>`)
+)
+
+// minVisibleText is how much visible text a page needs before it is treated as server-rendered.
+const minVisibleText = 200
+
+// isSPAShell reports whether html is a client-rendered page with no content of its own:
+// an empty framework mount point, or almost no visible text outside scripts.
+func isSPAShell(html string) bool {
+	visible := invisiblePattern.ReplaceAllString(html, " ")
+	visible = strings.Join(strings.Fields(tagPattern.ReplaceAllString(visible, " ")), " ")
+	if len(visible) >= minVisibleText {
+		return false
+	}
+	return spaRootPattern.MatchString(html) || strings.Contains(strings.ToLower(html), "<script")
+}
@@ -0,0 +1,135 @@
+package generic
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+func TestIsSPAShell(t *testing.T) {
+	prose := "<p>" + strings.Repeat("I write about distributed systems and gardening. ", 10) + "</p>"
+	tests := []struct {
+		name string
+		html string
+		want bool
+	}{
+		{"react root", `<html><head><title>Jane</title></head><body><div id="root"></div><script src="/main.js"></script></body></html>`, true},
+		{"next root", `<body><div id="__next">  </div></body>`, true},
+		{"angular", `<body><app-root></app-root></body>`, true},
+		{"noscript only", `<body><noscript>You need to enable JavaScript to run this app.</noscript><script src="/app.js"></script></body>`, true},
+		{"server rendered app", `<body><div id="root">` + prose + `</div><script src="/app.js"></script></body>`, false},
+		{"static page", `<body><h1>Jane Doe</h1>` + prose + `</body>`, false},
+		{"short static page", `<body><h1>Jane Doe</h1><p>Coming soon.</p></body>`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSPAShell(tt.html); got != tt.want {
+				t.Errorf("isSPAShell() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChromeRendererRefuses(t *testing.T) {
+	// No browser is started: each case fails before Chrome would run
+	r := &ChromeRenderer{Path: "/nonexistent/chrome"}
+	ctx := context.Background()
+
+	if _, err := r.Render(ctx, "http://127.0.0.1:8080/"); err == nil || strings.Contains(err.Error(), "nonexistent") {
+		t.Errorf("Render(loopback) error = %v, want ValidateURL's", err)
+	}
+	roots := cache.WithNetwork(ctx, cache.Network{RootCAs: x509.NewCertPool()})
+	if _, err := r.Render(roots, "https://example.com/"); !errors.Is(err, ErrRenderUnsupported) {
+		t.Errorf("Render() with custom roots error = %v, want ErrRenderUnsupported", err)
+	}
+	hooked := cache.WithRequestHook(ctx, func(req *http.Request) { req.Header.Set("X-Experiment", "b") })
+	if _, err := r.Render(hooked, "https://example.com/"); !errors.Is(err, ErrRenderUnsupported) {
+		t.Errorf("Render() with a header hook error = %v, want ErrRenderUnsupported", err)
+	}
+}
+
+func TestChromeNetworkArgs(t *testing.T) {
+	ctx := cache.WithIdentity(cache.WithPublicOnly(context.Background()),
+		cache.Identity{UserAgent: "sociopath-test/1.0", Everywhere: true})
+	args, done, err := chromeNetworkArgs(ctx, "https://example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+	joined := strings.Join(args, " ")
+	for _, want := range []string{"--user-agent=sociopath-test/1.0", "--proxy-server=http://127.0.0.1:", "--host-resolver-rules=MAP * ~NOTFOUND"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("args = %v, want %s", args, want)
+		}
+	}
+}
+
+func TestRenderProxy(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = io.WriteString(w, "rendered") //nolint:errcheck // test server
+	}))
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+	}))
+	defer tlsSrv.Close()
+
+	get := func(t *testing.T, n cache.Network, target string, tlsClient *http.Client) (*http.Response, error) {
+		t.Helper()
+		p, err := startRenderProxy(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(p.close)
+		proxyURL := &url.URL{Scheme: "http", Host: p.addr()}
+		transport := &http.Transport{Proxy: http.ProxyURL(proxyURL)}
+		if tlsClient != nil {
+			transport.TLSClientConfig = tlsClient.Transport.(*http.Transport).TLSClientConfig //nolint:errcheck,forcetypeassert // httptest clients use *http.Transport
+		}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := transport.RoundTrip(req)
+		if err == nil {
+			t.Cleanup(func() { _ = resp.Body.Close() }) //nolint:errcheck // test
+		}
+		return resp, err
+	}
+
+	// Without PublicOnly, plain and tunneled requests go through
+	resp, err := get(t, cache.Network{}, srv.URL, nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET via proxy = %v, %v", resp, err)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "rendered" { //nolint:errcheck // checked by content
+		t.Errorf("body = %q", body)
+	}
+	if resp, err := get(t, cache.Network{}, tlsSrv.URL, tlsSrv.Client()); err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("HTTPS GET via proxy = %v, %v", resp, err)
+	}
+
+	// With it, neither reaches loopback
+	hits.Store(0)
+	public := cache.Network{PublicOnly: true}
+	if resp, err := get(t, public, srv.URL, nil); err != nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("GET loopback via PublicOnly proxy = %v, %v; want 403", resp, err)
+	}
+	if _, err := get(t, public, tlsSrv.URL, tlsSrv.Client()); err == nil {
+		t.Error("HTTPS GET loopback via PublicOnly proxy succeeded")
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("servers got %d requests through a PublicOnly proxy, want none", n)
+	}
+}
@@ -0,0 +1,160 @@
+package generic
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// renderProxy is an HTTP proxy on loopback for a rendering browser. It dials every
+// connection, plain or tunneled, over a cache.Network, so the network's checks apply
+// to each host a page loads from and each redirect it follows.
+type renderProxy struct {
+	ln        net.Listener
+	srv       *http.Server
+	transport *http.Transport
+	network   cache.Network
+	tunnels   sync.WaitGroup
+	conns     map[net.Conn]struct{} // Both ends of open tunnels
+	mu        sync.Mutex
+	closed    bool
+}
+
+func startRenderProxy(n cache.Network) (*renderProxy, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &renderProxy{
+		ln:      ln,
+		network: n,
+		conns:   make(map[net.Conn]struct{}),
+		// Only plain HTTP is sent from here, and no further proxy
+		transport: &http.Transport{DialContext: n.DialContext, ResponseHeaderTimeout: 30 * time.Second},
+	}
+	p.srv = &http.Server{Handler: p, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = p.srv.Serve(ln) }() //nolint:errcheck // Serve returns ErrServerClosed once closed
+	return p, nil
+}
+
+// addr returns the proxy's host:port.
+func (p *renderProxy) addr() string {
+	return p.ln.Addr().String()
+}
+
+// close stops the proxy and its open connections.
+func (p *renderProxy) close() {
+	_ = p.srv.Close() //nolint:errcheck // closing the listener cannot fail usefully
+	p.mu.Lock()
+	p.closed = true
+	for c := range p.conns {
+		_ = c.Close() //nolint:errcheck // ends the tunnel
+	}
+	p.mu.Unlock()
+	p.tunnels.Wait()
+	p.transport.CloseIdleConnections()
+}
+
+// track records the ends of a tunnel for close, or reports false if the proxy is
+// already closed.
+func (p *renderProxy) track(conns ...net.Conn) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	for _, c := range conns {
+		p.conns[c] = struct{}{}
+	}
+	p.tunnels.Add(2)
+	return true
+}
+
+// untrack forgets the ends of a finished tunnel.
+func (p *renderProxy) untrack(conns ...net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, c := range conns {
+		delete(p.conns, c)
+	}
+}
+
+func (p *renderProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r)
+		return
+	}
+	if r.URL.Host == "" {
+		http.Error(w, "proxy requests need an absolute URL", http.StatusBadRequest)
+		return
+	}
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // error ignored intentionally
+	for k, vs := range resp.Header {
+		w.Header()[k] = vs
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body) //nolint:errcheck // the browser went away
+}
+
+// tunnel connects the browser to r.Host for a CONNECT request, and relays bytes both
+// ways until either side closes.
+func (p *renderProxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	upstream, err := p.network.DialContext(r.Context(), "tcp", r.Host)
+	if err != nil {
+		proxyError(w, err)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		_ = upstream.Close() //nolint:errcheck // already failing
+		http.Error(w, "tunneling unsupported", http.StatusInternalServerError)
+		return
+	}
+	client, buf, err := hj.Hijack()
+	if err != nil {
+		_ = upstream.Close() //nolint:errcheck // already failing
+		return
+	}
+	_, err = client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n"))
+	if err != nil || !p.track(client, upstream) {
+		_ = client.Close()   //nolint:errcheck // already failing
+		_ = upstream.Close() //nolint:errcheck // already failing
+		return
+	}
+
+	go func() {
+		defer p.tunnels.Done()
+		_, _ = io.Copy(upstream, buf) //nolint:errcheck // either side closing ends the tunnel
+		_ = upstream.Close()          //nolint:errcheck // ends the other direction
+	}()
+	go func() {
+		defer p.tunnels.Done()
+		_, _ = io.Copy(client, upstream) //nolint:errcheck // either side closing ends the tunnel
+		_ = client.Close()               //nolint:errcheck // ends the other direction
+		p.untrack(client, upstream)
+	}()
+}
+
+// proxyError answers a request the proxy could not forward: 403 for addresses the
+// network refuses, and 502 for other failures.
+func proxyError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, cache.ErrPrivateAddress) {
+		status = http.StatusForbidden
+	}
+	http.Error(w, err.Error(), status)
+}
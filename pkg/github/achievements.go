@@ -0,0 +1,61 @@
+package github
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+var (
+	// achievementPattern matches the sidebar's achievement badges, which link to
+	// "/<user>?achievement=<slug>&tab=achievements" around the badge and its tier label.
+	achievementPattern = regexp.MustCompile(`(?s)<a[^>]+href="[^"]*[?&](?:amp;)?achievement=([a-z0-9-]+)[^"]*"[^>]*>(.*?)</a>`)
+	// tierPattern matches a badge's tier label, such as "x3".
+	tierPattern = regexp.MustCompile(`achievement-tier-label[^>]*>\s*x(\d+)\s*<`)
+	// sponsorButtonPattern matches the Sponsor button of accounts with a Sponsors listing.
+	sponsorButtonPattern = regexp.MustCompile(`(?i)<a[^>]+href="/sponsors/([a-z\d-]+)"`)
+	// sponsoringPattern matches the sidebar's "Sponsoring" section up to the next heading.
+	sponsoringPattern = regexp.MustCompile(`(?s)<h2[^>]*>\s*Sponsoring\s*</h2>(.*?)(?:<h2|$)`)
+	// sponsoredAccountPattern matches an account's avatar link in the Sponsoring section.
+	sponsoredAccountPattern = regexp.MustCompile(`data-hovercard-type="(?:user|organization)"`)
+)
+
+// extractAchievements returns the achievement badges on a profile page in the order
+// shown, with tiers above the first as in "pull-shark x3".
+func extractAchievements(html string) []string {
+	var badges []string
+	seen := make(map[string]bool)
+	for _, m := range achievementPattern.FindAllStringSubmatch(html, -1) {
+		slug := m[1]
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		if tier := tierPattern.FindStringSubmatch(m[2]); tier != nil && tier[1] != "1" {
+			slug += " x" + tier[1]
+		}
+		badges = append(badges, slug)
+	}
+	return badges
+}
+
+// addSponsorship records from a profile page whether the user can be sponsored and,
+// unless the API already said, how many accounts the page shows it sponsoring.
+func addSponsorship(prof *profile.Profile, html string) {
+	for _, m := range sponsorButtonPattern.FindAllStringSubmatch(html, -1) {
+		if strings.EqualFold(m[1], prof.Username) {
+			prof.Fields[profile.FieldSponsorable] = "true"
+			break
+		}
+	}
+	if _, ok := prof.Fields[profile.FieldSponsoring]; ok {
+		return
+	}
+	if m := sponsoringPattern.FindStringSubmatch(html); m != nil {
+		if n := len(sponsoredAccountPattern.FindAllString(m[1], -1)); n > 0 {
+			prof.Fields[profile.FieldSponsoring] = strconv.Itoa(n)
+		}
+	}
+}
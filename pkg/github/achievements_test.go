@@ -0,0 +1,74 @@
+package github
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const achievementsPage = `<div class="border-top color-border-muted pt-3 mt-3 d-none d-md-block">
+<h2 class="h4 mb-2">Achievements</h2>
+<a href="/jane?achievement=pull-shark&amp;tab=achievements" class="position-relative">
+  <img alt="Achievement: Pull Shark" class="achievement-badge-sidebar" src="https://github.githubassets.com/pull-shark.png">
+  <span class="Label achievement-tier-label achievement-tier-label--bronze text-small px-1 mt-n1">x3</span>
+</a>
+<a href="/jane?achievement=starstruck&amp;tab=achievements" class="position-relative">
+  <img alt="Achievement: Starstruck" class="achievement-badge-sidebar" src="https://github.githubassets.com/starstruck.png">
+</a>
+<a href="/jane?achievement=pull-shark&amp;tab=achievements"><img alt="Achievement: Pull Shark"></a>
+</div>
+<div><h2 class="h4 mb-2">Sponsoring</h2>
+<a href="/acme" data-hovercard-type="organization"><img alt="@acme"></a>
+<a href="/bob" data-hovercard-type="user"><img alt="@bob"></a>
+</div>
+<div><h2 class="h4 mb-2">Organizations</h2>
+<a href="/golang" data-hovercard-type="organization"><img alt="@golang"></a>
+</div>
+<a class="btn" href="/sponsors/Jane"><span>Sponsor</span></a>`
+
+func TestExtractAchievements(t *testing.T) {
+	got := extractAchievements(achievementsPage)
+	want := []string{"pull-shark x3", "starstruck"}
+	if !slices.Equal(got, want) {
+		t.Errorf("extractAchievements() = %v, want %v", got, want)
+	}
+	if got := extractAchievements("<p>No badges</p>"); got != nil {
+		t.Errorf("extractAchievements() without badges = %v", got)
+	}
+}
+
+func TestAddSponsorship(t *testing.T) {
+	p := &profile.Profile{Username: "jane", Fields: map[string]string{}}
+	addSponsorship(p, achievementsPage)
+	if !p.Sponsorable() {
+		t.Error("Sponsorable() = false, want the Sponsor button to count")
+	}
+	if n, ok := p.Sponsoring(); !ok || n != 2 {
+		t.Errorf("Sponsoring() = %d, %v, want the 2 accounts in the Sponsoring section", n, ok)
+	}
+
+	// Another account's Sponsor button, and a count from the API, are kept apart
+	p = &profile.Profile{Username: "bob", Fields: map[string]string{profile.FieldSponsoring: "7"}}
+	addSponsorship(p, achievementsPage)
+	if p.Sponsorable() {
+		t.Error("Sponsorable() = true from another account's Sponsor button")
+	}
+	if n, _ := p.Sponsoring(); n != 7 {
+		t.Errorf("Sponsoring() = %d, want the API's count kept", n)
+	}
+}
+
+func TestParseGraphQLResponseSponsors(t *testing.T) {
+	data := []byte(`{"data": {"user": {"login": "jane", "hasSponsorsListing": true,
+		"sponsors": {"totalCount": 12}, "sponsoring": {"totalCount": 3}}}}`)
+	p, err := parseGraphQLResponse(data, "https://github.com/jane", "jane")
+	if err != nil {
+		t.Fatalf("parseGraphQLResponse() error = %v", err)
+	}
+	sponsors, _ := p.Sponsors()
+	sponsoring, _ := p.Sponsoring()
+	if !p.Sponsorable() || sponsors != 12 || sponsoring != 3 {
+		t.Errorf("sponsorable = %v, sponsors = %d, sponsoring = %d, want true, 12, 3", p.Sponsorable(), sponsors, sponsoring)
+	}
+}
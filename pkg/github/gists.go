@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// gistSource is recorded in Fields["email_source"] when the email came from a gist.
+const gistSource = "gist"
+
+// personalGistFile matches file names of gists about their owner, such as a resume.
+var personalGistFile = regexp.MustCompile(`(?i)^(?:resume|cv|about|about[-_]?me|bio|contact|whoami)(?:\.[a-z]+)?$`)
+
+// personalGistWords are words in a gist description that mark it as about its owner.
+var personalGistWords = []string{"dotfiles", "resume", "about me", "my setup", "contact"}
+
+// gist is one entry of the user gists API.
+type gist struct {
+	Files       map[string]struct{} `json:"files"`
+	Description string              `json:"description"`
+	URL         string              `json:"html_url"`
+}
+
+// WithGists makes Fetch list the user's public gists and scan the descriptions of
+// personal ones, such as resumes and dotfiles, for email addresses and links. They are
+// recorded in Fields["gist_emails"] and Fields["gist_links"]; the email also fills
+// Fields["email"], with Fields["email_source"] set to "gist", if the profile has none.
+func WithGists() Option {
+	return func(c *config) { c.gists = true }
+}
+
+// fetchGists returns the user's most recent public gists.
+func (c *Client) fetchGists(ctx context.Context, username string) []gist {
+	apiURL := "https://api.github.com/users/" + username + "/gists?per_page=100"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	body, err := c.doAPIRequest(ctx, req)
+	if err != nil {
+		c.logger.DebugContext(ctx, "github gists fetch failed", "username", username, "error", err)
+		return nil
+	}
+	var gists []gist
+	if err := json.Unmarshal(body, &gists); err != nil {
+		c.logger.DebugContext(ctx, "github gists parse failed", "username", username, "error", err)
+		return nil
+	}
+	return gists
+}
+
+// isPersonalGist reports whether g looks like it is about its owner rather than code.
+func isPersonalGist(g gist) bool {
+	for name := range g.Files {
+		if personalGistFile.MatchString(name) {
+			return true
+		}
+	}
+	desc := strings.ToLower(g.Description)
+	for _, w := range personalGistWords {
+		if strings.Contains(desc, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// addGistContacts adds the emails and links in the descriptions of personal gists.
+func addGistContacts(prof *profile.Profile, gists []gist) {
+	var emails, found []string
+	seen := make(map[string]bool)
+	for _, g := range gists {
+		if g.Description == "" || !isPersonalGist(g) {
+			continue
+		}
+		for _, e := range htmlutil.EmailAddresses(g.Description) {
+			if !seen[e] {
+				seen[e] = true
+				emails = append(emails, e)
+			}
+		}
+		for _, link := range htmlutil.SocialLinks(g.Description) {
+			if !seen[link] {
+				seen[link] = true
+				found = append(found, link)
+			}
+		}
+	}
+	if len(emails) > 0 {
+		prof.Fields["gist_emails"] = strings.Join(emails, ", ")
+		if _, ok := prof.Fields[profile.FieldEmail]; !ok {
+			prof.Fields[profile.FieldEmail] = emails[0]
+			prof.Fields["email_source"] = gistSource
+		}
+	}
+	if len(found) > 0 {
+		prof.Fields["gist_links"] = strings.Join(found, ", ")
+		prof.SocialLinks = append(prof.SocialLinks, found...)
+	}
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const gistsResponse = `[
+{"html_url":"https://gist.github.com/jane/1","description":"My resume - jane@janedoe.dev, https://mastodon.social/@jane","files":{"notes.md":{"filename":"notes.md"}}},
+{"html_url":"https://gist.github.com/jane/2","description":"Contact me at work@janedoe.dev","files":{"about.md":{"filename":"about.md"}}},
+{"html_url":"https://gist.github.com/jane/3","description":"Benchmark by bob@bobsite.dev https://twitter.com/bob","files":{"bench_test.go":{"filename":"bench_test.go"}}},
+{"html_url":"https://gist.github.com/jane/4","description":null,"files":{"resume.md":{"filename":"resume.md"}}}
+]`
+
+func TestAddGistContacts(t *testing.T) {
+	var gists []gist
+	if err := json.Unmarshal([]byte(gistsResponse), &gists); err != nil {
+		t.Fatal(err)
+	}
+	p := &profile.Profile{Username: "jane", Fields: map[string]string{}}
+	addGistContacts(p, gists)
+
+	if got, want := p.Fields["gist_emails"], "jane@janedoe.dev, work@janedoe.dev"; got != want {
+		t.Errorf("gist_emails = %q, want %q", got, want)
+	}
+	if p.Fields[profile.FieldEmail] != "jane@janedoe.dev" || p.Fields["email_source"] != gistSource {
+		t.Errorf("email = %q from %q, want the first gist email", p.Fields[profile.FieldEmail], p.Fields["email_source"])
+	}
+	if !slices.Equal(p.SocialLinks, []string{"https://mastodon.social/@jane"}) {
+		t.Errorf("SocialLinks = %v, want only the resume gist's link", p.SocialLinks)
+	}
+
+	// An email from the profile itself is kept
+	p = &profile.Profile{Username: "jane", Fields: map[string]string{profile.FieldEmail: "jane@corp.dev"}}
+	addGistContacts(p, gists)
+	if p.Fields[profile.FieldEmail] != "jane@corp.dev" || p.Fields["email_source"] != "" {
+		t.Errorf("email = %q from %q, want the profile's kept", p.Fields[profile.FieldEmail], p.Fields["email_source"])
+	}
+}
+
+func TestIsPersonalGist(t *testing.T) {
+	tests := []struct {
+		file, desc string
+		want       bool
+	}{
+		{"resume.md", "", true},
+		{"About-Me.txt", "", true},
+		{"CV", "", true},
+		{"README.md", "my dotfiles", true},
+		{"main.go", "quick hack", false},
+		{"resume_parser.py", "parses PDFs", false},
+	}
+	for _, tt := range tests {
+		g := gist{Files: map[string]struct{}{tt.file: {}}, Description: tt.desc}
+		if got := isPersonalGist(g); got != tt.want {
+			t.Errorf("isPersonalGist(%q, %q) = %v, want %v", tt.file, tt.desc, got, tt.want)
+		}
+	}
+}
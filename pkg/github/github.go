@@ -20,6 +20,7 @@ import (
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
@@ -69,6 +70,8 @@ type Client struct {
 	cache      cache.HTTPCache
 	logger     *slog.Logger
 	token      string
+	depth      profile.Depth
+	gists      bool
 }
 
 // Option configures a Client.
@@ -78,6 +81,8 @@ type config struct {
 	cache  cache.HTTPCache
 	logger *slog.Logger
 	token  string
+	depth  profile.Depth
+	gists  bool
 }
 
 // WithHTTPCache sets the HTTP cache.
@@ -90,6 +95,11 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
 // WithToken sets the GitHub API token.
 func WithToken(token string) Option {
 	return func(c *config) { c.token = token }
@@ -125,6 +135,8 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 		cache:      cfg.cache,
 		logger:     logger,
 		token:      token,
+		depth:      cfg.depth,
+		gists:      cfg.gists,
 	}, nil
 }
 
@@ -142,11 +154,26 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 
 	c.logger.InfoContext(ctx, "fetching github profile", "url", urlStr, "username", username)
 
-	// Fetch API data, with fallback to HTML scraping on failure
-	prof, apiErr := c.fetchAPI(ctx, urlStr, username)
-
-	// Fetch HTML to extract rel="me" links, README, and organizations
-	htmlContent, htmlLinks := c.fetchHTML(ctx, urlStr)
+	// Fetch API data, with fallback to HTML scraping on failure.
+	// The API call gets half the deadline so the HTML fetch still has time.
+	apiCtx, cancel := cache.SubContext(ctx, 2)
+	prof, apiErr := c.fetchAPI(apiCtx, urlStr, username)
+	cancel()
+
+	// Fetch HTML to extract rel="me" links, README, and organizations.
+	// At minimal depth the page is only needed as a fallback when the API fails,
+	// and when the API succeeded it is skipped if too little of the deadline is left.
+	var htmlContent string
+	var htmlLinks []string
+	switch {
+	case apiErr != nil:
+		htmlContent, htmlLinks = c.fetchHTML(ctx, urlStr)
+	case c.depth == profile.DepthMinimal:
+	case !cache.HasBudget(ctx, cache.MinOptionalBudget):
+		c.logger.InfoContext(ctx, "skipping github HTML fetch, deadline budget exhausted", "url", urlStr)
+	default:
+		htmlContent, htmlLinks = c.fetchHTML(ctx, urlStr)
+	}
 
 	// If API failed, try to build profile from HTML
 	if apiErr != nil {
@@ -175,39 +202,83 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 			return nil, fmt.Errorf("API failed and no HTML content available: %w", apiErr)
 		}
 
-		prof = c.parseProfileFromHTML(ctx, htmlContent, urlStr, username)
+		prof = parseProfileFromHTML(htmlContent, urlStr, username)
 		c.logger.InfoContext(ctx, "built profile from HTML scraping", "url", urlStr, "username", username)
 	}
 
 	prof.SocialLinks = append(prof.SocialLinks, htmlLinks...)
 
+	// The latest public event is the best activity signal; profile updated_at only
+	// tracks edits to the profile itself
+	if apiErr == nil && c.depth != profile.DepthMinimal && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		prof.UpdateLastActive(c.fetchLastEvent(ctx, username))
+	}
+	if c.gists && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		addGistContacts(prof, c.fetchGists(ctx, username))
+	}
+
 	// Extract README and organizations from HTML if available
 	if htmlContent != "" {
-		// Extract organizations
-		orgs := extractOrganizations(htmlContent)
-		if len(orgs) > 0 {
-			prof.Fields["organizations"] = strings.Join(orgs, ", ")
-		}
+		addHTMLDetails(prof, htmlContent)
+	}
+	// Pinned repositories may already have shown a Pages site; otherwise ask the API
+	if _, ok := prof.Fields[fieldPages]; !ok && apiErr == nil && c.depth != profile.DepthMinimal &&
+		cache.HasBudget(ctx, cache.MinOptionalBudget) && c.hasPagesRepo(ctx, username) {
+		addPagesSite(prof)
+	}
 
-		// Extract README - get raw HTML for link extraction, then convert to markdown
-		readmeHTML := extractREADMEHTML(htmlContent)
-		if readmeHTML != "" {
-			// Extract social links from raw HTML (before conversion loses image-only links)
-			readmeLinks := htmlutil.SocialLinks(readmeHTML)
-			prof.SocialLinks = append(prof.SocialLinks, readmeLinks...)
+	// Deduplicate and drop same-platform (GitHub to GitHub) and denylisted links
+	prof.SocialLinks = links.Clean(prof.SocialLinks, Match)
 
-			// Convert to markdown for unstructured content
-			prof.Unstructured = htmlutil.ToMarkdown(readmeHTML)
-		}
-	}
+	return prof, nil
+}
 
-	// Deduplicate and filter out same-platform links (GitHub to GitHub)
-	prof.SocialLinks = dedupeLinks(prof.SocialLinks)
-	prof.SocialLinks = filterSamePlatformLinks(prof.SocialLinks)
+// Parse extracts a profile from a GitHub profile page's HTML without fetching anything.
+// Only what the page shows is available: no email, follower counts, or last activity.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	if !strings.HasPrefix(urlStr, "http") {
+		urlStr = "https://github.com/" + username
+	}
 
+	content := string(data)
+	prof := parseProfileFromHTML(content, urlStr, username)
+	prof.SocialLinks = extractSocialLinks(content)
+	addHTMLDetails(prof, content)
+	prof.SocialLinks = links.Clean(prof.SocialLinks, Match)
 	return prof, nil
 }
 
+// addHTMLDetails adds the organizations, achievements, sponsorship, Pages site, and
+// README from a profile page to prof.
+func addHTMLDetails(prof *profile.Profile, htmlContent string) {
+	orgs := extractOrganizations(htmlContent)
+	if len(orgs) > 0 {
+		prof.Fields[profile.FieldOrganizations] = strings.Join(orgs, ", ")
+	}
+	if badges := extractAchievements(htmlContent); len(badges) > 0 {
+		prof.Fields[profile.FieldAchievements] = strings.Join(badges, ", ")
+	}
+	addSponsorship(prof, htmlContent)
+	if linksPagesRepo(htmlContent, prof.Username) {
+		addPagesSite(prof)
+	}
+
+	// Extract README - get raw HTML for link extraction, then convert to markdown
+	readmeHTML := extractREADMEHTML(htmlContent)
+	if readmeHTML != "" {
+		// Extract social links from raw HTML (before conversion loses image-only links)
+		readmeLinks := htmlutil.SocialLinks(readmeHTML)
+		prof.SocialLinks = append(prof.SocialLinks, readmeLinks...)
+
+		// Convert to markdown for unstructured content
+		prof.Unstructured = htmlutil.ToMarkdown(readmeHTML)
+	}
+}
+
 // APIError contains details about a GitHub API error.
 //
 //nolint:govet // fieldalignment: intentional layout for readability
@@ -244,7 +315,7 @@ func (c *Client) fetchAPI(ctx context.Context, urlStr, username string) (*profil
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
@@ -258,6 +329,39 @@ func (c *Client) fetchAPI(ctx context.Context, urlStr, username string) (*profil
 	return parseJSON(body, urlStr, username)
 }
 
+// fetchLastEvent returns the time of the user's most recent public event, or "" if
+// it is unavailable.
+func (c *Client) fetchLastEvent(ctx context.Context, username string) string {
+	apiURL := "https://api.github.com/users/" + username + "/events/public?per_page=1"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	body, err := c.doAPIRequest(ctx, req)
+	if err != nil {
+		c.logger.DebugContext(ctx, "github events fetch failed", "username", username, "error", err)
+		return ""
+	}
+	return parseLastEvent(body)
+}
+
+// parseLastEvent returns created_at of the first event in a GitHub events response.
+func parseLastEvent(data []byte) string {
+	var events []struct {
+		CreatedAt string `json:"created_at"`
+	}
+	if err := json.Unmarshal(data, &events); err != nil || len(events) == 0 {
+		return ""
+	}
+	return events[0].CreatedAt
+}
+
 func (c *Client) fetchGraphQL(ctx context.Context, urlStr, username string) (*profile.Profile, error) {
 	query := `
 	query($login: String!) {
@@ -269,6 +373,7 @@ func (c *Client) fetchGraphQL(ctx context.Context, urlStr, username string) (*pr
 			company
 			websiteUrl
 			twitterUsername
+			pronouns
 			createdAt
 			updatedAt
 
@@ -287,7 +392,22 @@ func (c *Client) fetchGraphQL(ctx context.Context, urlStr, username string) (*pr
 				totalCount
 			}
 
-			repositories(first: 1, ownerAffiliations: OWNER) {
+			repositories(first: 20, ownerAffiliations: OWNER, privacy: PUBLIC, orderBy: {field: STARGAZERS, direction: DESC}) {
+				totalCount
+				nodes {
+					isFork
+					stargazerCount
+					primaryLanguage {
+						name
+					}
+				}
+			}
+
+			hasSponsorsListing
+			sponsors {
+				totalCount
+			}
+			sponsoring {
 				totalCount
 			}
 		}
@@ -311,7 +431,7 @@ func (c *Client) fetchGraphQL(ctx context.Context, urlStr, username string) (*pr
 	}
 	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := c.doAPIRequest(ctx, req)
 	if err != nil {
@@ -335,6 +455,7 @@ func parseGraphQLResponse(data []byte, urlStr, _ string) (*profile.Profile, erro
 				Company        string `json:"company"`
 				WebsiteURL     string `json:"websiteUrl"`
 				TwitterUser    string `json:"twitterUsername"`
+				Pronouns       string `json:"pronouns"`
 				CreatedAt      string `json:"createdAt"`
 				UpdatedAt      string `json:"updatedAt"`
 				SocialAccounts struct {
@@ -346,7 +467,13 @@ func parseGraphQLResponse(data []byte, urlStr, _ string) (*profile.Profile, erro
 				} `json:"socialAccounts"`
 				Followers    struct{ TotalCount int } `json:"followers"`
 				Following    struct{ TotalCount int } `json:"following"`
-				Repositories struct{ TotalCount int } `json:"repositories"`
+				Repositories struct {
+					TotalCount int                   `json:"totalCount"`
+					Nodes      []repositoryFootprint `json:"nodes"`
+				} `json:"repositories"`
+				Sponsors    struct{ TotalCount int } `json:"sponsors"`
+				Sponsoring  struct{ TotalCount int } `json:"sponsoring"`
+				Sponsorable bool                     `json:"hasSponsorsListing"`
 			} `json:"user"`
 		} `json:"data"`
 	}
@@ -384,18 +511,31 @@ func parseGraphQLResponse(data []byte, urlStr, _ string) (*profile.Profile, erro
 	// Add company
 	if user.Company != "" {
 		company := strings.TrimPrefix(user.Company, "@")
-		prof.Fields["company"] = company
+		prof.Fields[profile.FieldCompany] = company
+	}
+	if user.Pronouns != "" {
+		prof.Fields[profile.FieldPronouns] = user.Pronouns
 	}
 
 	// Add stats
 	if user.Repositories.TotalCount > 0 {
-		prof.Fields["public_repos"] = strconv.Itoa(user.Repositories.TotalCount)
+		prof.Fields[profile.FieldRepositories] = strconv.Itoa(user.Repositories.TotalCount)
 	}
+	addRepositoryFootprint(prof, user.Repositories.Nodes)
 	if user.Followers.TotalCount > 0 {
-		prof.Fields["followers"] = strconv.Itoa(user.Followers.TotalCount)
+		prof.Fields[profile.FieldFollowers] = strconv.Itoa(user.Followers.TotalCount)
 	}
 	if user.Following.TotalCount > 0 {
-		prof.Fields["following"] = strconv.Itoa(user.Following.TotalCount)
+		prof.Fields[profile.FieldFollowing] = strconv.Itoa(user.Following.TotalCount)
+	}
+	if user.Sponsorable {
+		prof.Fields[profile.FieldSponsorable] = "true"
+	}
+	if user.Sponsors.TotalCount > 0 {
+		prof.Fields[profile.FieldSponsors] = strconv.Itoa(user.Sponsors.TotalCount)
+	}
+	if user.Sponsoring.TotalCount > 0 {
+		prof.Fields[profile.FieldSponsoring] = strconv.Itoa(user.Sponsoring.TotalCount)
 	}
 
 	// Add Twitter from GraphQL
@@ -453,8 +593,12 @@ func (c *Client) doAPIRequest(ctx context.Context, req *http.Request) ([]byte, e
 	} else {
 		c.logger.InfoContext(ctx, "cache disabled", "url", req.URL.String())
 	}
+	if cache.IsOffline(ctx) {
+		return nil, fmt.Errorf("%w: %s", cache.ErrNotCached, req.URL.String())
+	}
 
-	resp, err := c.httpClient.Do(req)
+	cache.PrepareRequest(ctx, req)
+	resp, err := cache.Client(ctx, c.httpClient).Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -523,11 +667,13 @@ func (c *Client) fetchHTML(ctx context.Context, urlStr string) (content string,
 	return content, links
 }
 
+// articlePattern matches the README GitHub renders on a profile page.
+var articlePattern = regexp.MustCompile(`(?s)<article[^>]*class="[^"]*markdown-body[^"]*"[^>]*>(.*?)</article>`)
+
 // extractREADMEHTML extracts the raw README HTML from GitHub profile page.
 func extractREADMEHTML(htmlContent string) string {
 	// GitHub embeds README in <article class="markdown-body entry-content ...">
 	// Extract everything from the opening tag to the closing </article>
-	articlePattern := regexp.MustCompile(`(?s)<article[^>]*class="[^"]*markdown-body[^"]*"[^>]*>(.*?)</article>`)
 	matches := articlePattern.FindStringSubmatch(htmlContent)
 	if len(matches) < 2 {
 		return ""
@@ -541,13 +687,18 @@ func extractREADMEHTML(htmlContent string) string {
 	return readmeHTML
 }
 
+// rel="me" links, with the rel attribute before or after the href.
+var (
+	relMePattern     = regexp.MustCompile(`<a[^>]+rel=["'][^"']*\bme\b[^"']*["'][^>]+href=["']([^"']+)["']`)
+	hrefFirstPattern = regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]+rel=["'][^"']*\bme\b[^"']*["']`)
+)
+
 // extractSocialLinks extracts social media links from HTML, focusing on rel="me" verified links.
 func extractSocialLinks(html string) []string {
 	var links []string
 
 	// GitHub uses rel="nofollow me" for verified social links
 	// Example: <a rel="nofollow me" href="https://triangletoot.party/@thomrstrom">...</a>
-	relMePattern := regexp.MustCompile(`<a[^>]+rel=["'][^"']*\bme\b[^"']*["'][^>]+href=["']([^"']+)["']`)
 	matches := relMePattern.FindAllStringSubmatch(html, -1)
 	for _, match := range matches {
 		if len(match) > 1 {
@@ -560,7 +711,6 @@ func extractSocialLinks(html string) []string {
 	}
 
 	// Also check for href first, then rel (both orders work)
-	hrefFirstPattern := regexp.MustCompile(`<a[^>]+href=["']([^"']+)["'][^>]+rel=["'][^"']*\bme\b[^"']*["']`)
 	matches = hrefFirstPattern.FindAllStringSubmatch(html, -1)
 	for _, match := range matches {
 		if len(match) <= 1 {
@@ -586,13 +736,18 @@ func extractSocialLinks(html string) []string {
 	return links
 }
 
+// Organization avatars in a profile's sidebar, and organization links as a fallback.
+var (
+	orgAvatarPattern = regexp.MustCompile(`aria-label="([^"]+)"[^>]*>\s*<img[^>]+alt="@([^"]+)"`)
+	orgLinkPattern   = regexp.MustCompile(`href="/([^/"]+)"[^>]*aria-label="([^"]+)"`)
+)
+
 // extractOrganizations extracts organization names from GitHub profile HTML.
 // Organizations are listed in the profile sidebar with aria-label attributes.
 func extractOrganizations(html string) []string {
 	// Pattern: aria-label="organizationname"
 	// This matches the organization links in the profile sidebar
-	pattern := regexp.MustCompile(`aria-label="([^"]+)"[^>]*>\s*<img[^>]+alt="@([^"]+)"`)
-	matches := pattern.FindAllStringSubmatch(html, -1)
+	matches := orgAvatarPattern.FindAllStringSubmatch(html, -1)
 
 	var orgs []string
 	seen := make(map[string]bool)
@@ -611,8 +766,7 @@ func extractOrganizations(html string) []string {
 
 	// Fallback pattern: just look for organization links
 	if len(orgs) == 0 {
-		linkPattern := regexp.MustCompile(`href="/([^/"]+)"[^>]*aria-label="([^"]+)"`)
-		matches = linkPattern.FindAllStringSubmatch(html, -1)
+		matches = orgLinkPattern.FindAllStringSubmatch(html, -1)
 		for _, match := range matches {
 			if len(match) > 2 {
 				orgName := match[2]
@@ -703,7 +857,7 @@ func parseJSON(data []byte, urlStr, _ string) (*profile.Profile, error) {
 	if ghUser.Company != "" {
 		// Remove @ prefix if present
 		company := strings.TrimPrefix(ghUser.Company, "@")
-		prof.Fields["company"] = company
+		prof.Fields[profile.FieldCompany] = company
 	}
 
 	// Add Twitter username
@@ -715,13 +869,13 @@ func parseJSON(data []byte, urlStr, _ string) (*profile.Profile, error) {
 
 	// Add stats
 	if ghUser.PublicRepos > 0 {
-		prof.Fields["public_repos"] = strconv.Itoa(ghUser.PublicRepos)
+		prof.Fields[profile.FieldRepositories] = strconv.Itoa(ghUser.PublicRepos)
 	}
 	if ghUser.Followers > 0 {
-		prof.Fields["followers"] = strconv.Itoa(ghUser.Followers)
+		prof.Fields[profile.FieldFollowers] = strconv.Itoa(ghUser.Followers)
 	}
 	if ghUser.Following > 0 {
-		prof.Fields["following"] = strconv.Itoa(ghUser.Following)
+		prof.Fields[profile.FieldFollowing] = strconv.Itoa(ghUser.Following)
 	}
 
 	// Add avatar URL
@@ -745,6 +899,9 @@ func parseJSON(data []byte, urlStr, _ string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// usernamePattern matches the username in a GitHub URL.
+var usernamePattern = regexp.MustCompile(`github\.com/([^/?]+)`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
@@ -752,40 +909,24 @@ func extractUsername(urlStr string) string {
 	urlStr = strings.TrimPrefix(urlStr, "www.")
 
 	// Extract github.com/username
-	re := regexp.MustCompile(`github\.com/([^/?]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
 	return ""
 }
 
-func filterSamePlatformLinks(links []string) []string {
-	var filtered []string
-	for _, link := range links {
-		// Skip GitHub URLs
-		if !Match(link) {
-			filtered = append(filtered, link)
-		}
-	}
-	return filtered
-}
-
-func dedupeLinks(links []string) []string {
-	seen := make(map[string]bool)
-	var result []string
-	for _, link := range links {
-		normalized := strings.TrimSuffix(strings.ToLower(link), "/")
-		if !seen[normalized] {
-			seen[normalized] = true
-			result = append(result, link)
-		}
-	}
-	return result
-}
+// Profile page patterns for parseProfileFromHTML, used when the API is unavailable.
+var (
+	namePattern    = regexp.MustCompile(`<span[^>]+class="[^"]*p-name[^"]*"[^>]*itemprop="name"[^>]*>\s*([^<]+)`)
+	bioPattern     = regexp.MustCompile(`data-bio-text="([^"]+)"`)
+	locPattern     = regexp.MustCompile(`itemprop="homeLocation"[^>]*aria-label="Home location:\s*([^"]+)"`)
+	websitePattern = regexp.MustCompile(`(?s)itemprop="url"[^>]*data-test-selector="profile-website-url"[^>]*>.*?href="([^"]+)"`)
+	avatarPattern  = regexp.MustCompile(`<img[^>]+class="[^"]*avatar avatar-user[^"]*"[^>]+src="([^"]+)"`)
+)
 
 // parseProfileFromHTML extracts profile data from GitHub HTML when API is unavailable.
-func (c *Client) parseProfileFromHTML(ctx context.Context, html, urlStr, username string) *profile.Profile {
+func parseProfileFromHTML(html, urlStr, username string) *profile.Profile {
 	prof := &profile.Profile{
 		Platform:      platform,
 		URL:           urlStr,
@@ -795,25 +936,21 @@ func (c *Client) parseProfileFromHTML(ctx context.Context, html, urlStr, usernam
 	}
 
 	// Extract full name: <span class="p-name vcard-fullname..." itemprop="name">
-	namePattern := regexp.MustCompile(`<span[^>]+class="[^"]*p-name[^"]*"[^>]*itemprop="name"[^>]*>\s*([^<]+)`)
 	if matches := namePattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Name = strings.TrimSpace(matches[1])
 	}
 
 	// Extract bio: <div class="p-note user-profile-bio..." data-bio-text="...">
-	bioPattern := regexp.MustCompile(`data-bio-text="([^"]+)"`)
 	if matches := bioPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Bio = strings.TrimSpace(matches[1])
 	}
 
 	// Extract location: <li... itemprop="homeLocation"... aria-label="Home location: ...">
-	locPattern := regexp.MustCompile(`itemprop="homeLocation"[^>]*aria-label="Home location:\s*([^"]+)"`)
 	if matches := locPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Location = strings.TrimSpace(matches[1])
 	}
 
 	// Extract website: <li itemprop="url" data-test-selector="profile-website-url"...>...<a...href="...">
-	websitePattern := regexp.MustCompile(`(?s)itemprop="url"[^>]*data-test-selector="profile-website-url"[^>]*>.*?href="([^"]+)"`)
 	if matches := websitePattern.FindStringSubmatch(html); len(matches) > 1 {
 		website := matches[1]
 		if !strings.HasPrefix(website, "http") {
@@ -824,18 +961,9 @@ func (c *Client) parseProfileFromHTML(ctx context.Context, html, urlStr, usernam
 	}
 
 	// Extract avatar URL
-	avatarPattern := regexp.MustCompile(`<img[^>]+class="[^"]*avatar avatar-user[^"]*"[^>]+src="([^"]+)"`)
 	if matches := avatarPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Fields["avatar_url"] = matches[1]
 	}
 
-	c.logger.DebugContext(ctx, "parsed profile from HTML",
-		"username", username,
-		"name", prof.Name,
-		"bio", prof.Bio,
-		"location", prof.Location,
-		"website", prof.Website,
-	)
-
 	return prof
 }
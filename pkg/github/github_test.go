@@ -4,8 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
 func TestMatch(t *testing.T) {
@@ -175,11 +180,15 @@ func TestFetch(t *testing.T) {
 </html>`
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/users/testuser" {
+		switch r.URL.Path {
+		case "/users/testuser":
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte(apiJSON))
-		} else {
+		case "/users/testuser/events/public":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"type": "PushEvent", "created_at": "2024-05-01T12:00:00Z"}]`))
+		default:
 			w.Header().Set("Content-Type", "text/html")
 			w.WriteHeader(http.StatusOK)
 			_, _ = w.Write([]byte(htmlContent))
@@ -214,6 +223,9 @@ func TestFetch(t *testing.T) {
 	if profile.Website != "https://testuser.dev" {
 		t.Errorf("Website = %q, want %q", profile.Website, "https://testuser.dev")
 	}
+	if profile.LastActive != "2024-05-01T12:00:00Z" {
+		t.Errorf("LastActive = %q, want latest public event time", profile.LastActive)
+	}
 }
 
 func TestFetch_NotFound(t *testing.T) {
@@ -237,6 +249,78 @@ func TestFetch_NotFound(t *testing.T) {
 	}
 }
 
+func TestFetch_MinimalDepth(t *testing.T) {
+	var htmlRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/testuser" {
+			_, _ = w.Write([]byte(`{"login": "testuser", "name": "Test User", "html_url": "https://github.com/testuser"}`))
+			return
+		}
+		htmlRequests++
+		_, _ = w.Write([]byte(`<html><a rel="me" href="https://mastodon.social/@testuser">Mastodon</a></html>`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx, WithDepth(profile.DepthMinimal))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.token = "" // use the REST API rather than GraphQL
+	client.httpClient = &http.Client{
+		Transport: &mockTransport{mockURL: server.URL},
+	}
+
+	p, err := client.Fetch(ctx, "https://github.com/testuser")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Name != "Test User" {
+		t.Errorf("Name = %q, want %q", p.Name, "Test User")
+	}
+	if htmlRequests != 0 {
+		t.Errorf("minimal depth made %d HTML requests, want 0", htmlRequests)
+	}
+	if len(p.SocialLinks) != 0 {
+		t.Errorf("SocialLinks = %v, want none without the HTML fetch", p.SocialLinks)
+	}
+}
+
+func TestFetch_SkipsHTMLWhenBudgetLow(t *testing.T) {
+	var htmlRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/testuser" {
+			_, _ = w.Write([]byte(`{"login": "testuser", "name": "Test User", "html_url": "https://github.com/testuser"}`))
+			return
+		}
+		htmlRequests++
+		_, _ = w.Write([]byte(`<html></html>`))
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.token = "" // use the REST API rather than GraphQL
+	client.httpClient = &http.Client{
+		Transport: &mockTransport{mockURL: server.URL},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	p, err := client.Fetch(ctx, "https://github.com/testuser")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Name != "Test User" {
+		t.Errorf("Name = %q, want %q", p.Name, "Test User")
+	}
+	if htmlRequests != 0 {
+		t.Errorf("made %d HTML requests with under MinOptionalBudget left, want 0", htmlRequests)
+	}
+}
+
 func TestFetch_InvalidUsername(t *testing.T) {
 	ctx := context.Background()
 	client, err := New(ctx)
@@ -367,39 +451,25 @@ func TestExtractOrganizations(t *testing.T) {
 	}
 }
 
-func TestFilterSamePlatformLinks(t *testing.T) {
-	links := []string{
+func TestCleanLinks(t *testing.T) {
+	in := []string{
 		"https://github.com/user",
 		"https://twitter.com/user",
 		"https://mastodon.social/@user",
 	}
 
-	filtered := filterSamePlatformLinks(links)
+	filtered := links.Clean(in, Match)
 	if len(filtered) != 2 {
-		t.Errorf("filterSamePlatformLinks() returned %d links, want 2", len(filtered))
+		t.Errorf("links.Clean() returned %d links, want 2", len(filtered))
 	}
 
 	for _, link := range filtered {
 		if Match(link) {
-			t.Errorf("filterSamePlatformLinks() should have removed %q", link)
+			t.Errorf("links.Clean() should have removed %q", link)
 		}
 	}
 }
 
-func TestDedupeLinks(t *testing.T) {
-	links := []string{
-		"https://twitter.com/user",
-		"https://TWITTER.COM/user/",
-		"https://mastodon.social/@user",
-		"https://twitter.com/user",
-	}
-
-	deduped := dedupeLinks(links)
-	if len(deduped) != 2 {
-		t.Errorf("dedupeLinks() returned %d links, want 2", len(deduped))
-	}
-}
-
 func TestParseJSON_WithEmailInBlog(t *testing.T) {
 	// Test case where blog field contains an email (which should be extracted)
 	sampleJSON := `{
@@ -471,12 +541,6 @@ func TestWithOptions(t *testing.T) {
 }
 
 func TestParseProfileFromHTML(t *testing.T) {
-	ctx := context.Background()
-	client, err := New(ctx)
-	if err != nil {
-		t.Fatalf("New() error = %v", err)
-	}
-
 	// Sample HTML based on github.com/tstromberg profile structure
 	sampleHTML := `
 <html>
@@ -563,7 +627,7 @@ CEO @ codeGROOVE
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prof := client.parseProfileFromHTML(ctx, tt.html, tt.urlStr, tt.username)
+			prof := parseProfileFromHTML(tt.html, tt.urlStr, tt.username)
 
 			if prof.Platform != "github" {
 				t.Errorf("Platform = %q, want %q", prof.Platform, "github")
@@ -615,3 +679,31 @@ func TestAPIError(t *testing.T) {
 		}
 	})
 }
+
+func TestParse(t *testing.T) {
+	page := []byte(`<span class="p-name vcard-fullname d-block" itemprop="name">Jane Doe</span>
+<a rel="nofollow me" class="Link--primary" href="https://hachyderm.io/@jane">@jane</a>
+<a href="/acme" aria-label="acme"><img src="x.png" alt="@acme"></a>
+<article class="markdown-body entry-content container-lg"><p>Hi, I write <a href="https://jane.dev">a blog</a>.</p></article>`)
+
+	p, err := Parse(context.Background(), page, "github.com/jane")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if p.URL != "https://github.com/jane" || p.Username != "jane" || p.Name != "Jane Doe" {
+		t.Errorf("Parse() = %+v", p)
+	}
+	if p.Fields["organizations"] != "acme" {
+		t.Errorf("organizations = %q, want %q", p.Fields["organizations"], "acme")
+	}
+	if !slices.Contains(p.SocialLinks, "https://hachyderm.io/@jane") {
+		t.Errorf("SocialLinks = %v, want the rel=me link", p.SocialLinks)
+	}
+	if !strings.Contains(p.Unstructured, "a blog") {
+		t.Errorf("Unstructured = %q, want the README", p.Unstructured)
+	}
+
+	if _, err := Parse(context.Background(), page, "https://example.com/"); err == nil {
+		t.Error("Parse() accepted a URL without a username")
+	}
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// orgPagePattern matches organization URLs: github.com/<org> and github.com/orgs/<org>/people.
+var orgPagePattern = regexp.MustCompile(`(?i)github\.com/(?:orgs/)?([\w-]+)(?:/people)?/?(?:[?#]|$)`)
+
+// orgMembersPageSize is the largest page the members API returns.
+const orgMembersPageSize = 100
+
+// OrgName returns the organization in a github.com/<org> or github.com/orgs/<org> URL, or "".
+// The name is not checked against the API, so it may also be a user.
+func OrgName(urlStr string) string {
+	m := orgPagePattern.FindStringSubmatch(urlStr)
+	if m == nil || strings.EqualFold(m[1], "orgs") {
+		return ""
+	}
+	return m[1]
+}
+
+// OrgMembers returns the profile URLs of up to limit public members of org.
+// Members who hide their membership are not listed. A user, rather than an
+// organization, yields an *APIError with StatusCode 404.
+func (c *Client) OrgMembers(ctx context.Context, org string, limit int) ([]string, error) {
+	var urls []string
+	for page := 1; len(urls) < limit; page++ {
+		apiURL := fmt.Sprintf("https://api.github.com/orgs/%s/public_members?per_page=%d&page=%d", org, orgMembersPageSize, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/vnd.github.v3+json")
+		req.Header.Set("User-Agent", cache.UserAgent)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+
+		body, err := c.doAPIRequest(ctx, req)
+		if err != nil {
+			if len(urls) > 0 {
+				c.logger.WarnContext(ctx, "github org member listing stopped early", "org", org, "error", err)
+				break
+			}
+			return nil, err
+		}
+
+		members, n, err := parseOrgMembers(body)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if len(urls) < limit {
+				urls = append(urls, m)
+			}
+		}
+		if n < orgMembersPageSize {
+			break
+		}
+	}
+	return urls, nil
+}
+
+// parseOrgMembers returns the profile URLs of the human members on one page of
+// results and the number of entries on the page, bots included.
+func parseOrgMembers(data []byte) (urls []string, n int, err error) {
+	var members []struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+		Type    string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, 0, fmt.Errorf("parsing org members: %w", err)
+	}
+
+	for _, m := range members {
+		if m.Login == "" || m.Type == "Bot" {
+			continue
+		}
+		u := m.HTMLURL
+		if u == "" {
+			u = "https://github.com/" + m.Login
+		}
+		urls = append(urls, u)
+	}
+	return urls, len(members), nil
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOrgName(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/codeGROOVE-dev", "codeGROOVE-dev"},
+		{"https://github.com/orgs/codeGROOVE-dev/people", "codeGROOVE-dev"},
+		{"github.com/kubernetes/", "kubernetes"},
+		{"https://github.com/codeGROOVE-dev/sociopath", ""},
+		{"https://example.com/acme", ""},
+	}
+
+	for _, tt := range tests {
+		if got := OrgName(tt.url); got != tt.want {
+			t.Errorf("OrgName(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestOrgMembers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/public_members" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"login": "jane", "html_url": "https://github.com/jane", "type": "User"},
+			{"login": "acme-bot", "html_url": "https://github.com/apps/acme-bot", "type": "Bot"},
+			{"login": "john", "type": "User"}
+		]`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	urls, err := client.OrgMembers(ctx, "acme", 50)
+	if err != nil {
+		t.Fatalf("OrgMembers() error = %v", err)
+	}
+	want := []string{"https://github.com/jane", "https://github.com/john"}
+	if strings.Join(urls, " ") != strings.Join(want, " ") {
+		t.Errorf("OrgMembers() = %v, want %v", urls, want)
+	}
+
+	if urls, err := client.OrgMembers(ctx, "acme", 1); err != nil || len(urls) != 1 {
+		t.Errorf("OrgMembers(limit 1) = %v, %v; want one member", urls, err)
+	}
+
+	if _, err := client.OrgMembers(ctx, "jane", 50); err == nil {
+		t.Error("OrgMembers() on a user should fail")
+	}
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// fieldPages holds the user's GitHub Pages site.
+const fieldPages = "github_pages"
+
+// pagesRepoPattern matches links to "<user>/<user>.github.io" repositories, such as a
+// pinned one on the profile page.
+var pagesRepoPattern = regexp.MustCompile(`(?i)href="(?:https://github\.com)?/([a-z\d-]+)/([a-z\d-]+)\.github\.io"`)
+
+// pagesSite returns the GitHub Pages URL of username's user site.
+func pagesSite(username string) string {
+	return "https://" + strings.ToLower(username) + ".github.io"
+}
+
+// hasPagesRepo reports whether the user has a "<user>.github.io" repository that
+// publishes a Pages site.
+func (c *Client) hasPagesRepo(ctx context.Context, username string) bool {
+	apiURL := "https://api.github.com/repos/" + username + "/" + username + ".github.io"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	body, err := c.doAPIRequest(ctx, req)
+	if err != nil {
+		return false
+	}
+	var repo struct {
+		HasPages bool `json:"has_pages"`
+	}
+	return json.Unmarshal(body, &repo) == nil && repo.HasPages
+}
+
+// linksPagesRepo reports whether a profile page links the user's "<user>.github.io"
+// repository.
+func linksPagesRepo(html, username string) bool {
+	for _, m := range pagesRepoPattern.FindAllStringSubmatch(html, -1) {
+		if strings.EqualFold(m[1], username) && strings.EqualFold(m[2], username) {
+			return true
+		}
+	}
+	return false
+}
+
+// addPagesSite records the user's Pages site as the website if the profile has none,
+// or as a link otherwise, so that recursive crawls follow it either way.
+func addPagesSite(prof *profile.Profile) {
+	site := pagesSite(prof.Username)
+	prof.Fields[fieldPages] = site
+	if prof.Website == "" {
+		prof.Website = site
+		prof.Fields["website"] = site
+		return
+	}
+	if !strings.EqualFold(strings.TrimSuffix(prof.Website, "/"), site) {
+		prof.SocialLinks = append(prof.SocialLinks, site)
+	}
+}
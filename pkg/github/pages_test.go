@@ -0,0 +1,48 @@
+package github
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestLinksPagesRepo(t *testing.T) {
+	tests := []struct {
+		html string
+		want bool
+	}{
+		{`<a href="/Jane/jane.github.io" class="Link"><span class="repo">jane.github.io</span></a>`, true},
+		{`<a href="https://github.com/jane/Jane.github.io">`, true},
+		{`<a href="/bob/bob.github.io">`, false},
+		{`<a href="/jane/blog">`, false},
+	}
+	for _, tt := range tests {
+		if got := linksPagesRepo(tt.html, "jane"); got != tt.want {
+			t.Errorf("linksPagesRepo(%q) = %v, want %v", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestAddPagesSite(t *testing.T) {
+	p := &profile.Profile{Username: "Jane", Fields: map[string]string{}}
+	addPagesSite(p)
+	if p.Website != "https://jane.github.io" || p.Fields[fieldPages] != p.Website {
+		t.Errorf("Website = %q, %s = %q, want the Pages site", p.Website, fieldPages, p.Fields[fieldPages])
+	}
+	if len(p.SocialLinks) != 0 {
+		t.Errorf("SocialLinks = %v, want the site only as Website", p.SocialLinks)
+	}
+
+	p = &profile.Profile{Username: "jane", Website: "https://jane.dev", Fields: map[string]string{}}
+	addPagesSite(p)
+	if p.Website != "https://jane.dev" || !slices.Equal(p.SocialLinks, []string{"https://jane.github.io"}) {
+		t.Errorf("Website = %q, SocialLinks = %v, want the blog kept and the Pages site linked", p.Website, p.SocialLinks)
+	}
+
+	p = &profile.Profile{Username: "jane", Website: "https://jane.github.io/", Fields: map[string]string{}}
+	addPagesSite(p)
+	if len(p.SocialLinks) != 0 {
+		t.Errorf("SocialLinks = %v, want no duplicate of the Website", p.SocialLinks)
+	}
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// maxLanguages is how many of the top repositories' languages are recorded.
+const maxLanguages = 5
+
+// repositoryFootprint is one of the user's most-starred repositories as the
+// GraphQL API returns it.
+type repositoryFootprint struct {
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	StargazerCount int  `json:"stargazerCount"`
+	IsFork         bool `json:"isFork"`
+}
+
+// addRepositoryFootprint records the stars on the user's own top repositories and
+// their most common primary languages. Forks are skipped: their stars and language
+// belong to the upstream project.
+func addRepositoryFootprint(prof *profile.Profile, repos []repositoryFootprint) {
+	stars := 0
+	counts := map[string]int{}
+	var languages []string
+	for _, r := range repos {
+		if r.IsFork {
+			continue
+		}
+		stars += r.StargazerCount
+		if r.PrimaryLanguage == nil || r.PrimaryLanguage.Name == "" {
+			continue
+		}
+		if counts[r.PrimaryLanguage.Name] == 0 {
+			languages = append(languages, r.PrimaryLanguage.Name)
+		}
+		counts[r.PrimaryLanguage.Name]++
+	}
+	if stars > 0 {
+		prof.Fields[profile.FieldStars] = strconv.Itoa(stars)
+	}
+	// Stable, so languages used equally often keep the order of their most-starred repository
+	sort.SliceStable(languages, func(i, j int) bool { return counts[languages[i]] > counts[languages[j]] })
+	if len(languages) > maxLanguages {
+		languages = languages[:maxLanguages]
+	}
+	if len(languages) > 0 {
+		prof.Fields[profile.FieldLanguages] = strings.Join(languages, ", ")
+	}
+}
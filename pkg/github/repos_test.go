@@ -0,0 +1,31 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestParseGraphQLResponse_RepositoryFootprint(t *testing.T) {
+	data := []byte(`{"data":{"user":{"login":"alice","repositories":{"totalCount":42,"nodes":[
+		{"isFork":false,"stargazerCount":900,"primaryLanguage":{"name":"Go"}},
+		{"isFork":true,"stargazerCount":5000,"primaryLanguage":{"name":"C"}},
+		{"isFork":false,"stargazerCount":80,"primaryLanguage":{"name":"Python"}},
+		{"isFork":false,"stargazerCount":15,"primaryLanguage":{"name":"Python"}},
+		{"isFork":false,"stargazerCount":5,"primaryLanguage":null}
+	]}}}}`)
+
+	prof, err := parseGraphQLResponse(data, "https://github.com/alice", "alice")
+	if err != nil {
+		t.Fatalf("parseGraphQLResponse() error = %v", err)
+	}
+	if got, _ := prof.Repositories(); got != 42 {
+		t.Errorf("Repositories() = %d, want 42", got)
+	}
+	if got, _ := prof.Stars(); got != 1000 {
+		t.Errorf("Stars() = %d, want 1000 without the fork", got)
+	}
+	if got := prof.Fields[profile.FieldLanguages]; got != "Python, Go" {
+		t.Errorf("languages = %q, want %q", got, "Python, Go")
+	}
+}
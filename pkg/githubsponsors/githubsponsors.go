@@ -0,0 +1,152 @@
+// Package githubsponsors fetches GitHub Sponsors pages.
+package githubsponsors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "githubsponsors"
+
+var usernamePattern = regexp.MustCompile(`(?i)github\.com/sponsors/([a-z\d](?:[a-z\d-]*[a-z\d])?)/?(?:[?#]|$)`)
+
+// Match returns true if the URL is a GitHub Sponsors page.
+func Match(urlStr string) bool {
+	return usernamePattern.MatchString(urlStr)
+}
+
+// AuthRequired returns false because sponsors pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles GitHub Sponsors requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a GitHub Sponsors client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a GitHub Sponsors profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://github.com/sponsors/" + username
+	c.logger.InfoContext(ctx, "fetching github sponsors profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a GitHub Sponsors page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://github.com/sponsors/"+username, username)
+}
+
+var (
+	// The heading reads "Sponsor Jane Doe" or, without a display name, "Sponsor @janedoe"
+	headingPattern  = regexp.MustCompile(`(?is)<h1[^>]*>\s*Sponsor\s+(.+?)\s*</h1>`)
+	sponsorsPattern = regexp.MustCompile(`(?i)([\d,]+)\s+(?:current\s+)?sponsors?\b`)
+	goalPattern     = regexp.MustCompile(`(?i)(\d+)%\s+towards`)
+	tagPattern      = regexp.MustCompile(`<[^>]+>`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	title := htmlutil.Title(content)
+	if !strings.Contains(title, "GitHub Sponsors") {
+		return nil, errors.New("profile not found (not a sponsors page)")
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Name:     username,
+		Fields:   make(map[string]string),
+	}
+
+	if m := headingPattern.FindStringSubmatch(content); m != nil {
+		if name := strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(m[1], ""))); name != "" && !strings.HasPrefix(name, "@") {
+			p.Name = name
+		}
+	}
+	p.Bio = htmlutil.Description(content)
+	if m := sponsorsPattern.FindStringSubmatch(content); m != nil {
+		p.Fields[profile.FieldSponsors] = m[1]
+	}
+	if m := goalPattern.FindStringSubmatch(content); m != nil {
+		p.Fields["goal_progress"] = m[1] + "%"
+	}
+
+	// The sponsored account's own profile leads to everything else
+	found := append([]string{"https://github.com/" + username}, htmlutil.SocialLinks(content)...)
+	p.SocialLinks = links.Clean(found, Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	if m := usernamePattern.FindStringSubmatch(urlStr); m != nil {
+		return m[1]
+	}
+	return ""
+}
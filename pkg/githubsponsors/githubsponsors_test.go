@@ -0,0 +1,57 @@
+package githubsponsors
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/sponsors/janedoe", true},
+		{"https://github.com/sponsors/jane-doe/", true},
+		{"https://github.com/sponsors/janedoe?o=esb", true},
+		{"https://github.com/sponsors", false},
+		{"https://github.com/sponsors/janedoe/dashboard", false},
+		{"https://github.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("GitHub Sponsors should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><head><title>Sponsor @janedoe on GitHub Sponsors · GitHub</title>
+<meta name="description" content="I maintain the foo and bar libraries.">
+</head><body>
+<h1 class="f2">Sponsor <span>Jane Doe</span></h1>
+<p>37 current sponsors</p><p>62% towards 50 monthly sponsors goal</p>
+<a href="https://twitter.com/janedoe">@janedoe</a>
+</body></html>`
+
+	p, err := parseProfile(page, "https://github.com/sponsors/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Bio != "I maintain the foo and bar libraries." {
+		t.Errorf("Name = %q, Bio = %q", p.Name, p.Bio)
+	}
+	if p.Fields["sponsors"] != "37" || p.Fields["goal_progress"] != "62%" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	want := []string{"https://github.com/janedoe", "https://twitter.com/janedoe"}
+	if len(p.SocialLinks) != len(want) || p.SocialLinks[0] != want[0] || p.SocialLinks[1] != want[1] {
+		t.Errorf("SocialLinks = %v, want %v", p.SocialLinks, want)
+	}
+
+	if _, err := parseProfile(`<title>Page not found · GitHub</title>`, "https://github.com/sponsors/x", "x"); err == nil {
+		t.Error("parseProfile(not found) should fail")
+	}
+}
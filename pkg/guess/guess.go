@@ -8,8 +8,11 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/searchengine"
 )
 
 // Fetcher is a function that fetches a profile from a URL.
@@ -18,11 +21,17 @@ type Fetcher func(ctx context.Context, url string) (*profile.Profile, error)
 // PlatformDetector is a function that returns the platform name for a URL.
 type PlatformDetector func(url string) string
 
+// UsernameChecker reports, per platform, whether username is registered there.
+// Platforms missing from the result are unknown and are still tried.
+type UsernameChecker func(ctx context.Context, username string) map[string]bool
+
 // Config holds configuration for guessing.
 type Config struct {
 	Logger           *slog.Logger
 	Fetcher          Fetcher
 	PlatformDetector PlatformDetector
+	UsernameChecker  UsernameChecker       // optional; prunes candidates for unregistered handles
+	Search           searchengine.Provider // optional; finds profiles by name and employer
 }
 
 // Popular Mastodon servers to check.
@@ -273,6 +282,10 @@ func Related(ctx context.Context, known []*profile.Profile, cfg Config) []*profi
 	candidates := generateCandidates(usernames, names, knownURLs, knownPlatforms, vouchedPlatforms)
 	cfg.Logger.Info("generated guess candidates", "count", len(candidates))
 
+	if cfg.UsernameChecker != nil {
+		candidates = pruneUnregistered(ctx, candidates, cfg.UsernameChecker, cfg.Logger)
+	}
+
 	// Fetch candidates concurrently
 	var guessed []*profile.Profile
 	var mu sync.Mutex
@@ -319,6 +332,55 @@ func Related(ctx context.Context, known []*profile.Profile, cfg Config) []*profi
 
 	wg.Wait()
 
+	// Search engines find accounts whose handles share nothing with the known usernames
+	if cfg.Search != nil {
+		covered := make(map[string]bool, len(knownPlatforms))
+		for platform := range knownPlatforms {
+			covered[platform] = true
+		}
+		for _, p := range guessed {
+			covered[p.Platform] = true
+		}
+
+		for _, c := range searchCandidates(ctx, cfg, names, known, knownURLs, covered) {
+			if ctx.Err() != nil {
+				break
+			}
+
+			wg.Add(1)
+			go func(candidate candidateURL) {
+				defer wg.Done()
+
+				fetchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				defer cancel()
+
+				p, err := cfg.Fetcher(fetchCtx, candidate.url)
+				if err != nil {
+					cfg.Logger.Info("search candidate failed", "url", candidate.url, "error", err)
+					return
+				}
+
+				confidence, matches := scoreMatch(p, known, candidate)
+				if confidence < 0.3 {
+					cfg.Logger.Info("search candidate low confidence, skipping", "url", candidate.url, "confidence", confidence)
+					return
+				}
+
+				p.IsGuess = true
+				p.Confidence = confidence
+				p.GuessMatch = matches
+
+				cfg.Logger.Info("found profile from search", "url", p.URL, "confidence", confidence, "matches", matches)
+
+				mu.Lock()
+				guessed = append(guessed, p)
+				mu.Unlock()
+			}(c)
+		}
+
+		wg.Wait()
+	}
+
 	// Second round: Fetch social links and extract usernames from guessed profiles
 	// This handles cases like finding "thomrstrom" from a Mastodon link in a GitHub profile
 	if len(guessed) > 0 {
@@ -575,6 +637,10 @@ func Related(ctx context.Context, known []*profile.Profile, cfg Config) []*profi
 		}
 	}
 
+	// Guesses that link back into the known identity, directly or through a cycle,
+	// are corroborated by the link graph
+	boostLinkClusters(known, guessed, cfg.Logger)
+
 	// Filter to only highest confidence per platform
 	guessed = filterHighestConfidencePerPlatform(guessed)
 
@@ -631,10 +697,33 @@ type candidateURL struct {
 	url        string
 	username   string
 	platform   string
-	matchType  string // "username" or "name"
+	matchType  string // "username", "name", "search", or "linked"
 	sourceName string // for name-based matches, store the original name
 }
 
+// pruneUnregistered drops username candidates on platforms where the checker
+// reports the handle as unregistered. Name-based candidates are kept.
+func pruneUnregistered(ctx context.Context, candidates []candidateURL, check UsernameChecker, logger *slog.Logger) []candidateURL {
+	registered := make(map[string]map[string]bool)
+	var kept []candidateURL
+	for _, c := range candidates {
+		if c.matchType != "username" {
+			kept = append(kept, c)
+			continue
+		}
+		if _, ok := registered[c.username]; !ok {
+			registered[c.username] = check(ctx, c.username)
+		}
+		if ok, known := registered[c.username][c.platform]; known && !ok {
+			logger.Debug("skipping guess candidate, handle not registered", "url", c.url, "platform", c.platform)
+			continue
+		}
+		kept = append(kept, c)
+	}
+	logger.Info("pruned guess candidates by username probe", "before", len(candidates), "after", len(kept))
+	return kept
+}
+
 func extractUsernames(profiles []*profile.Profile) []string {
 	seen := make(map[string]bool)
 	var usernames []string
@@ -659,6 +748,15 @@ func extractUsernames(profiles []*profile.Profile) []string {
 				}
 			}
 		}
+
+		// Handles the bio claims, such as "@alice on most platforms" or "formerly @alice"
+		for _, h := range append(p.Handles(), p.FormerHandles()...) {
+			u := strings.ToLower(h)
+			if isValidUsername(u) && !seen[u] {
+				seen[u] = true
+				usernames = append(usernames, u)
+			}
+		}
 	}
 
 	return usernames
@@ -1000,6 +1098,10 @@ func scoreMatch(guessed *profile.Profile, known []*profile.Profile, candidate ca
 			score += 0.10
 			matches = append(matches, "name:slug")
 		}
+	} else if matchType == "search" {
+		// The search engine tied this account to the name, but nothing else vouches for it yet
+		score += 0.10
+		matches = append(matches, "search:name")
 	} else {
 		// Username match scoring
 		guessedUser := strings.ToLower(guessed.Username)
@@ -1073,22 +1175,12 @@ func scoreMatch(guessed *profile.Profile, known []*profile.Profile, candidate ca
 			guessedEmployer := ""
 			knownEmployer := ""
 
-			// Extract employer from guessed profile (LinkedIn uses "employer", GitHub uses "company")
-			if guessed.Fields != nil {
-				if emp := guessed.Fields["employer"]; emp != "" {
-					guessedEmployer = strings.ToLower(strings.TrimSpace(emp))
-				} else if comp := guessed.Fields["company"]; comp != "" {
-					guessedEmployer = strings.ToLower(strings.TrimSpace(comp))
-				}
+			// Employer falls back to the self-described company (GitHub)
+			if emp, ok := guessed.Employer(); ok {
+				guessedEmployer = strings.ToLower(strings.TrimSpace(emp))
 			}
-
-			// Extract employer from known profile
-			if kp.Fields != nil {
-				if emp := kp.Fields["employer"]; emp != "" {
-					knownEmployer = strings.ToLower(strings.TrimSpace(emp))
-				} else if comp := kp.Fields["company"]; comp != "" {
-					knownEmployer = strings.ToLower(strings.TrimSpace(comp))
-				}
+			if emp, ok := kp.Employer(); ok {
+				knownEmployer = strings.ToLower(strings.TrimSpace(emp))
 			}
 
 			// Check for employer match
@@ -1161,7 +1253,7 @@ func scoreMatch(guessed *profile.Profile, known []*profile.Profile, candidate ca
 	if bestNameScore > 0 {
 		// Name match alone shouldn't push score too high for name-based LinkedIn guesses
 		// For username-based matches, name match is a stronger signal
-		if matchType == "name" {
+		if matchType == "name" || matchType == "search" {
 			score += bestNameScore * 0.15
 		} else {
 			score += bestNameScore * 0.3
@@ -1219,7 +1311,7 @@ func scoreMatch(guessed *profile.Profile, known []*profile.Profile, candidate ca
 	// For LinkedIn name-based matches without strong signals (employer, location, link),
 	// require a tech-related job title to avoid false positives from common names.
 	// A "Career Coach" or "Partner at Law Firm" with the same name is unlikely to be the same person.
-	if guessed.Platform == "linkedin" && matchType == "name" &&
+	if guessed.Platform == "linkedin" && (matchType == "name" || matchType == "search") &&
 		!hasLink && !hasEmployerMatch && !hasOrgMatch && bestLocScore < 0.5 {
 		// Check both bio (headline) and title field for tech indicators
 		title := ""
@@ -1276,8 +1368,15 @@ func scoreName(a, b string) float64 {
 		return 0
 	}
 
-	a = strings.ToLower(strings.TrimSpace(a))
-	b = strings.ToLower(strings.TrimSpace(b))
+	// Full-width forms and separators such as "、" are folded so CJK locations compare
+	// like Latin ones
+	a, _ = profile.NormalizeLocation(a)
+	b, _ = profile.NormalizeLocation(b)
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	if a == "" || b == "" {
+		return 0
+	}
 
 	// Exact match
 	if a == b {
@@ -1337,8 +1436,15 @@ func scoreLocation(a, b string) float64 {
 		return 0
 	}
 
-	a = strings.ToLower(strings.TrimSpace(a))
-	b = strings.ToLower(strings.TrimSpace(b))
+	// Full-width forms and separators such as "、" are folded so CJK locations compare
+	// like Latin ones
+	a, _ = profile.NormalizeLocation(a)
+	b, _ = profile.NormalizeLocation(b)
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	if a == "" || b == "" {
+		return 0
+	}
 
 	// Exact match
 	if a == b {
@@ -1357,7 +1463,7 @@ func scoreLocation(a, b string) float64 {
 	var overlap int
 	for _, wa := range wordsA {
 		wa = strings.TrimSpace(wa)
-		if len(wa) < 2 {
+		if utf8.RuneCountInString(wa) < 2 {
 			continue
 		}
 		for _, wb := range wordsB {
@@ -1432,15 +1538,29 @@ func extractSignificantWords(s string) []string {
 
 	var words []string
 	for _, w := range strings.Fields(s) {
-		w = strings.Trim(w, ".,!?;:\"'()[]{}|/\\")
+		w = strings.Trim(w, ".,!?;:\"'()[]{}|/\\。，、！？：；「」『』（）")
 		w = strings.ToLower(w)
-		if len(w) >= 3 && !commonWords[w] {
+		// Counted in runes: a Cyrillic "во" is as short as "in", and two Han
+		// characters such as "北京" are a whole word
+		n := utf8.RuneCountInString(w)
+		if (n >= 3 || (n == 2 && isIdeographic(w))) && !commonWords[w] {
 			words = append(words, w)
 		}
 	}
 	return words
 }
 
+// isIdeographic reports whether s is written in Han, kana, or Hangul, where two
+// characters make a meaningful word.
+func isIdeographic(s string) bool {
+	for _, r := range s {
+		if !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			return false
+		}
+	}
+	return true
+}
+
 // extractOrganizationList parses organization names from Fields["organizations"].
 // It normalizes organization names by removing common suffixes like "-dev", "-org", etc.
 func extractOrganizationList(fields map[string]string) []string {
@@ -1677,6 +1797,13 @@ func extractInterests(p *profile.Profile) map[string]bool {
 		}
 	}
 
+	// Bio hashtags name interests directly
+	for _, tag := range p.Hashtags() {
+		if len(tag) >= 2 {
+			interests[tag] = true
+		}
+	}
+
 	// Extract interest keywords from bio
 	bioInterests := extractInterestKeywords(p.Bio)
 	for k, v := range bioInterests {
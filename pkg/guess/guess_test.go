@@ -1,6 +1,8 @@
 package guess
 
 import (
+	"context"
+	"log/slog"
 	"testing"
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
@@ -292,6 +294,17 @@ func TestExtractUsernames(t *testing.T) {
 			},
 			want: []string{},
 		},
+		{
+			name: "handles claimed in the bio",
+			profiles: []*profile.Profile{
+				{Platform: "github", Username: "jdoe", Fields: map[string]string{
+					profile.FieldHandles:       "JaneDoe",
+					profile.FieldFormerHandles: "janed_old",
+					profile.FieldMentions:      "acmecorp",
+				}},
+			},
+			want: []string{"jdoe", "janedoe", "janed_old"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -516,3 +529,105 @@ func TestSlugifyName(t *testing.T) {
 		})
 	}
 }
+
+func TestPruneUnregistered(t *testing.T) {
+	candidates := []candidateURL{
+		{url: "https://github.com/n4j", username: "n4j", platform: "github", matchType: "username"},
+		{url: "https://medium.com/@n4j", username: "n4j", platform: "medium", matchType: "username"},
+		{url: "https://twitter.com/n4j", username: "n4j", platform: "twitter", matchType: "username"},
+		{url: "https://www.linkedin.com/in/jane-doe/", username: "jane-doe", platform: "linkedin", matchType: "name"},
+	}
+
+	calls := 0
+	check := func(_ context.Context, username string) map[string]bool {
+		calls++
+		if username != "n4j" {
+			t.Errorf("checker called with %q", username)
+		}
+		return map[string]bool{"github": true, "medium": false, "linkedin": false}
+	}
+
+	got := pruneUnregistered(context.Background(), candidates, check, slog.New(slog.DiscardHandler))
+
+	if calls != 1 {
+		t.Errorf("checker called %d times, want 1 per username", calls)
+	}
+	var platforms []string
+	for _, c := range got {
+		platforms = append(platforms, c.platform)
+	}
+	// medium is unregistered; twitter is unknown; name-based linkedin is not a handle check
+	want := []string{"github", "twitter", "linkedin"}
+	if len(platforms) != len(want) {
+		t.Fatalf("kept platforms = %v, want %v", platforms, want)
+	}
+	for i := range want {
+		if platforms[i] != want[i] {
+			t.Errorf("kept platforms = %v, want %v", platforms, want)
+			break
+		}
+	}
+}
+
+func TestBoostLinkClusters(t *testing.T) {
+	known := []*profile.Profile{
+		{Platform: "github", URL: "https://github.com/alice", SocialLinks: []string{"https://alice.dev"}},
+	}
+	reciprocal := &profile.Profile{
+		Platform:   "mastodon",
+		URL:        "https://mastodon.social/@alice",
+		Website:    "https://github.com/alice",
+		Confidence: 0.5,
+		GuessMatch: []string{"username:exact"},
+	}
+	// github -> bluesky -> devto -> github cycle without reciprocal pairs
+	cycleA := &profile.Profile{
+		Platform:    "bluesky",
+		URL:         "https://bsky.app/profile/alice.bsky.social",
+		SocialLinks: []string{"https://dev.to/alice"},
+		Confidence:  0.5,
+	}
+	cycleB := &profile.Profile{
+		Platform:    "devto",
+		URL:         "https://dev.to/alice",
+		SocialLinks: []string{"https://github.com/alice"},
+		Confidence:  0.5,
+	}
+	// Links to the known profile, but nothing links back
+	oneWay := &profile.Profile{
+		Platform:    "twitter",
+		URL:         "https://twitter.com/alice",
+		SocialLinks: []string{"https://github.com/alice"},
+		Confidence:  0.5,
+	}
+	// Let the known profile link to the Mastodon and Bluesky guesses, closing the pair and the cycle
+	known[0].SocialLinks = append(known[0].SocialLinks,
+		"https://mastodon.social/@alice", "https://bsky.app/profile/alice.bsky.social")
+
+	boostLinkClusters(known, []*profile.Profile{reciprocal, cycleA, cycleB, oneWay}, slog.Default())
+
+	tests := []struct {
+		p    *profile.Profile
+		want float64
+		tag  bool
+	}{
+		{reciprocal, 0.7, true},
+		{cycleA, 0.6, true},
+		{cycleB, 0.6, true},
+		{oneWay, 0.5, false},
+	}
+	for _, tt := range tests {
+		if diff := tt.p.Confidence - tt.want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s confidence = %v, want %v", tt.p.Platform, tt.p.Confidence, tt.want)
+		}
+		tagged := false
+		for _, m := range tt.p.GuessMatch {
+			if m == "link-graph" {
+				tagged = true
+			}
+		}
+		if tagged != tt.tag {
+			t.Errorf("%s link-graph match = %v, want %v (matches %v)", tt.p.Platform, tagged, tt.tag, tt.p.GuessMatch)
+		}
+	}
+}
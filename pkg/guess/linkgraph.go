@@ -0,0 +1,100 @@
+package guess
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkgraph"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Confidence boosts for guessed profiles in a link cluster anchored to a known profile.
+const (
+	reciprocalLinkBoost = 0.2 // guessed profile and a cluster member link to each other
+	cycleLinkBoost      = 0.1 // guessed profile is only part of a longer link cycle
+)
+
+// buildLinkGraph returns the graph of links between the given profiles.
+func buildLinkGraph(profiles []*profile.Profile) *linkgraph.Graph {
+	g := linkgraph.New()
+	for _, p := range profiles {
+		id := normalizeURL(p.URL)
+		g.AddNode(linkgraph.Node{ID: id, URL: p.URL, Platform: p.Platform, Username: p.Username, Fetched: true})
+		for _, link := range p.SocialLinks {
+			g.AddEdge(id, normalizeURL(link), link, linkgraph.EdgeRelMe)
+		}
+		if p.Website != "" {
+			g.AddEdge(id, normalizeURL(p.Website), p.Website, linkgraph.EdgeWebsite)
+		}
+		for _, v := range p.Fields {
+			if strings.HasPrefix(v, "http") {
+				g.AddEdge(id, normalizeURL(v), v, linkgraph.EdgeField)
+			}
+		}
+	}
+	return g
+}
+
+// boostLinkClusters raises the confidence of guessed profiles that sit in a cluster of
+// mutually reachable profiles (A↔B, or A→B→C→A) together with, or linking to, a known
+// profile. Links in both directions are hard for an impostor to fake, so such guesses
+// get "link-graph" added to their GuessMatch.
+func boostLinkClusters(known, guessed []*profile.Profile, logger *slog.Logger) {
+	if len(guessed) == 0 {
+		return
+	}
+
+	all := make([]*profile.Profile, 0, len(known)+len(guessed))
+	all = append(all, known...)
+	all = append(all, guessed...)
+	g := buildLinkGraph(all)
+
+	knownIDs := make(map[string]bool, len(known))
+	for _, p := range known {
+		knownIDs[normalizeURL(p.URL)] = true
+	}
+	byID := make(map[string]*profile.Profile, len(guessed))
+	for _, p := range guessed {
+		byID[normalizeURL(p.URL)] = p
+	}
+
+	for _, cluster := range g.Clusters() {
+		if !anchored(g, cluster, knownIDs) {
+			continue
+		}
+		for _, id := range cluster {
+			p, ok := byID[id]
+			if !ok {
+				continue
+			}
+			boost := cycleLinkBoost
+			for _, other := range cluster {
+				if other != id && g.Reciprocal(id, other) {
+					boost = reciprocalLinkBoost
+					break
+				}
+			}
+			old := p.Confidence
+			p.Confidence = min(1.0, p.Confidence+boost)
+			p.GuessMatch = append(p.GuessMatch, "link-graph")
+			logger.Debug("link cluster boosted confidence",
+				"url", p.URL, "cluster_size", len(cluster),
+				"old_confidence", old, "new_confidence", p.Confidence)
+		}
+	}
+}
+
+// anchored reports whether cluster contains a known profile or links to one.
+func anchored(g *linkgraph.Graph, cluster []string, knownIDs map[string]bool) bool {
+	for _, id := range cluster {
+		if knownIDs[id] {
+			return true
+		}
+		for k := range knownIDs {
+			if g.Linked(id, k) {
+				return true
+			}
+		}
+	}
+	return false
+}
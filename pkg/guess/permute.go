@@ -0,0 +1,179 @@
+package guess
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Permutation is a username a person might use, derived from their name or a known handle.
+type Permutation struct {
+	Username string  `json:"username"`
+	Pattern  string  `json:"pattern"` // How it was derived: "handle", "first.last", "flast", ...
+	Score    float64 `json:"score"`   // 0-1; how often people with this name use the pattern
+}
+
+// minPermutationLength is the shortest username Permutations returns.
+const minPermutationLength = 3
+
+// Name patterns, most common first, with how often handles follow them.
+var namePatterns = []struct {
+	pattern string
+	score   float64
+	build   func(first, last string) string
+}{
+	{"firstlast", 0.8, func(f, l string) string { return f + l }},
+	{"flast", 0.75, func(f, l string) string { return f[:1] + l }},
+	{"first.last", 0.7, func(f, l string) string { return f + "." + l }},
+	{"first_last", 0.6, func(f, l string) string { return f + "_" + l }},
+	{"first-last", 0.55, func(f, l string) string { return f + "-" + l }},
+	{"lastfirst", 0.45, func(f, l string) string { return l + f }},
+	{"last.first", 0.4, func(f, l string) string { return l + "." + f }},
+	{"flast-short", 0.4, func(f, l string) string { return f[:1] + l[:min(len(l), 5)] }},
+	{"firstl", 0.35, func(f, l string) string { return f + l[:1] }},
+	{"last", 0.35, func(_, l string) string { return l }},
+	{"first", 0.2, func(f, _ string) string { return f }},
+}
+
+// surnameParticles join the following word into the surname: "van der Berg" -> "vanderberg".
+var surnameParticles = map[string]bool{
+	"van": true, "von": true, "der": true, "den": true, "de": true, "da": true, "di": true,
+	"del": true, "la": true, "le": true, "du": true, "dos": true, "das": true, "ter": true,
+}
+
+// transliterations spell letters with diacritics in ASCII. Each group of letters maps to
+// the replacement that follows it.
+var transliterations = func() map[rune]string {
+	m := make(map[rune]string)
+	for _, group := range [][2]string{
+		{"àáâãäåāăą", "a"}, {"çćĉċč", "c"}, {"ďđ", "d"}, {"èéêëēĕėęě", "e"}, {"ĝğġģ", "g"},
+		{"ĥħ", "h"}, {"ìíîïĩīĭįı", "i"}, {"ĵ", "j"}, {"ķ", "k"}, {"ĺļľŀł", "l"}, {"ñńņňŉ", "n"},
+		{"òóôõöøōŏő", "o"}, {"ŕŗř", "r"}, {"śŝşšș", "s"}, {"ţťŧț", "t"}, {"ùúûüũūŭůűų", "u"},
+		{"ŵ", "w"}, {"ýÿŷ", "y"}, {"źżž", "z"}, {"æ", "ae"}, {"œ", "oe"}, {"ß", "ss"}, {"þ", "th"},
+		{"ð", "d"},
+	} {
+		for _, r := range group[0] {
+			m[r] = group[1]
+		}
+	}
+	return m
+}()
+
+// localeTransliterations override the default spelling for a language's conventions.
+var localeTransliterations = map[string]map[rune]string{
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue"},
+	"da": {'å': "aa", 'ø': "oe"},
+	"no": {'å': "aa", 'ø': "oe"},
+}
+
+// Transliterate lowercases s and spells it in ASCII letters and digits, following the
+// conventions of locale (an ISO 639-1 code such as "de") where it has its own, so
+// "Strömberg" is "stromberg" by default and "stroemberg" for "de". Apostrophes and
+// hyphens are dropped, joining "O'Brien" into one word; other punctuation separates
+// words, and scripts without a Latin spelling are dropped.
+func Transliterate(s, locale string) string {
+	override := localeTransliterations[strings.ToLower(locale)]
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case r == '\'' || r == '\u2019' || r == '-':
+			// O'Brien and Smith-Jones are one word in a handle
+		case override[r] != "":
+			b.WriteString(override[r])
+		case transliterations[r] != "":
+			b.WriteString(transliterations[r])
+		case unicode.IsSpace(r) || unicode.IsPunct(r):
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Permutations returns usernames a person named name, known to use handle, is likely
+// to hold elsewhere, best first. Either argument may be empty. Names are spelled with
+// Transliterate for locale and, with a lower score, in the other common ASCII spelling:
+//
+//	Permutations("Thomas Strömberg", "tstromberg", "")
+//	// tstromberg (handle), thomasstromberg, thomas.stromberg, ..., tstrom, stromberg
+//
+// Only the first and last names are used; middle names are skipped and surname
+// particles such as "van" are kept with the surname.
+func Permutations(name, handle, locale string) []Permutation {
+	best := make(map[string]Permutation)
+	add := func(username, pattern string, score float64) {
+		if len(username) < minPermutationLength || !isValidUsername(username) {
+			return
+		}
+		if prev, ok := best[username]; !ok || score > prev.Score {
+			best[username] = Permutation{Username: username, Pattern: pattern, Score: score}
+		}
+	}
+
+	if h := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(handle), "@")); h != "" {
+		add(h, "handle", 1)
+		add(strings.Map(func(r rune) rune {
+			if strings.ContainsRune("._-", r) {
+				return -1
+			}
+			return r
+		}, h), "handle-joined", 0.7)
+		add(strings.TrimRightFunc(h, unicode.IsDigit), "handle-no-digits", 0.5)
+	}
+
+	// The locale's spelling first, then the default or German-style alternative
+	spellings := []string{Transliterate(name, locale)}
+	for _, alt := range []string{"", "de"} {
+		if s := Transliterate(name, alt); s != spellings[0] {
+			spellings = append(spellings, s)
+			break
+		}
+	}
+	for i, spelled := range spellings {
+		first, last := splitName(spelled)
+		if first == "" {
+			continue
+		}
+		weight := 1.0
+		if i > 0 {
+			weight = 0.8
+		}
+		if last == "" {
+			add(first, "first", 0.5*weight)
+			continue
+		}
+		for _, np := range namePatterns {
+			add(np.build(first, last), np.pattern, np.score*weight)
+		}
+	}
+
+	perms := make([]Permutation, 0, len(best))
+	for _, p := range best {
+		perms = append(perms, p)
+	}
+	sort.Slice(perms, func(i, j int) bool {
+		if perms[i].Score != perms[j].Score {
+			return perms[i].Score > perms[j].Score
+		}
+		return perms[i].Username < perms[j].Username
+	})
+	return perms
+}
+
+// splitName returns the first name and surname of a transliterated name, joining
+// surname particles into the surname.
+func splitName(spelled string) (first, last string) {
+	words := strings.Fields(spelled)
+	if len(words) == 0 {
+		return "", ""
+	}
+	if len(words) == 1 {
+		return words[0], ""
+	}
+	start := len(words) - 1
+	for start > 1 && surnameParticles[words[start-1]] {
+		start--
+	}
+	return words[0], strings.Join(words[start:], "")
+}
@@ -0,0 +1,83 @@
+package guess
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		in, locale string
+		want       string
+	}{
+		{"Thomas Strömberg", "", "thomas stromberg"},
+		{"Thomas Strömberg", "de", "thomas stroemberg"},
+		{"Søren Kierkegaard", "da", "soeren kierkegaard"},
+		{"Łukasz Żółw", "", "lukasz zolw"},
+		{"John O'Brien-Smith", "", "john obriensmith"},
+		{"Straße", "", "strasse"},
+		{"Dan Lorenc, PMP", "", "dan lorenc pmp"},
+		{"李小龍", "", ""},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.in, tt.locale); got != tt.want {
+			t.Errorf("Transliterate(%q, %q) = %q, want %q", tt.in, tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	perms := Permutations("Thomas Strömberg", "@TStromberg", "")
+	if len(perms) == 0 || perms[0].Username != "tstromberg" || perms[0].Pattern != "handle" {
+		t.Fatalf("Permutations()[0] = %+v, want the handle first", perms)
+	}
+
+	rank := make(map[string]int)
+	for i, p := range perms {
+		if _, dup := rank[p.Username]; dup {
+			t.Errorf("duplicate permutation %q", p.Username)
+		}
+		rank[p.Username] = i
+		if i > 0 && p.Score > perms[i-1].Score {
+			t.Errorf("permutations not sorted by score at %d: %+v", i, perms)
+		}
+	}
+	for _, want := range []string{"thomas.stromberg", "thomasstromberg", "tstrom", "stromberg", "thomas.stroemberg"} {
+		if _, ok := rank[want]; !ok {
+			t.Errorf("Permutations() missing %q", want)
+		}
+	}
+	if rank["thomas.stromberg"] > rank["thomas.stroemberg"] {
+		t.Error("alternate spelling ranked above the default spelling")
+	}
+	if rank["thomas.stromberg"] > rank["stromberg"] {
+		t.Error("surname alone ranked above first.last")
+	}
+
+	de := Permutations("Thomas Strömberg", "", "de")
+	if de[0].Username != "thomasstroemberg" {
+		t.Errorf("Permutations(de)[0] = %q, want the German spelling first", de[0].Username)
+	}
+}
+
+func TestPermutationsSurnameParticles(t *testing.T) {
+	perms := Permutations("Guido van Rossum", "", "")
+	found := false
+	for _, p := range perms {
+		if p.Username == "gvanrossum" {
+			found = true
+		}
+		if p.Username == "grossum" {
+			t.Error("surname particle dropped from surname")
+		}
+	}
+	if !found {
+		t.Errorf("Permutations() missing gvanrossum: %+v", perms)
+	}
+}
+
+func TestPermutationsEmpty(t *testing.T) {
+	if got := Permutations("", "", ""); len(got) != 0 {
+		t.Errorf("Permutations of nothing = %+v, want none", got)
+	}
+	if got := Permutations("Cher", "", ""); len(got) != 1 || got[0].Username != "cher" {
+		t.Errorf("Permutations(Cher) = %+v, want [cher]", got)
+	}
+}
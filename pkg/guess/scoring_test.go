@@ -149,6 +149,30 @@ func TestScoreLocation(t *testing.T) {
 			wantMax:  0.5,
 			hasMatch: true,
 		},
+		{
+			name:     "full-width CJK separators",
+			locA:     "北京，海淀区",
+			locB:     "北京、海淀区",
+			wantMin:  1.0,
+			wantMax:  1.0,
+			hasMatch: true,
+		},
+		{
+			name:     "Cyrillic city in region",
+			locA:     "Москва",
+			locB:     "Город: Москва, Россия",
+			wantMin:  0.7,
+			wantMax:  0.9,
+			hasMatch: true,
+		},
+		{
+			name:     "different Arabic cities",
+			locA:     "القاهرة، مصر",
+			locB:     "دبي، الإمارات",
+			wantMin:  0.0,
+			wantMax:  0.0,
+			hasMatch: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -465,6 +489,12 @@ func TestExtractSignificantWords(t *testing.T) {
 			wantWords: []string{"security", "engineer", "#infosec", "#golang", "#kubernetes"},
 			notWords:  []string{},
 		},
+		{
+			name:      "non-Latin words counted in characters",
+			input:     "Разработчик в Яндексе。 北京 工程师",
+			wantWords: []string{"разработчик", "яндексе", "北京", "工程师"},
+			notWords:  []string{"в"},
+		},
 		{
 			name:      "preserve company names",
 			input:     "Working at Google on cloud infrastructure",
@@ -0,0 +1,68 @@
+package guess
+
+import (
+	"context"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const (
+	maxSearchQueries = 2  // names searched per Related call
+	searchLimit      = 10 // results requested per query
+)
+
+// searchCandidates asks cfg.Search for profiles of each known name, qualified by
+// employer when one is known, and returns results on social platforms that neither
+// the known profiles nor the guesses so far cover.
+func searchCandidates(
+	ctx context.Context, cfg Config, names []string, profiles []*profile.Profile,
+	knownURLs, coveredPlatforms map[string]bool,
+) []candidateURL {
+	if cfg.PlatformDetector == nil || len(names) == 0 {
+		return nil
+	}
+
+	employer := ""
+	for _, p := range profiles {
+		if employer = strings.TrimPrefix(getEmployer(p.Fields), "@"); employer != "" {
+			break
+		}
+	}
+
+	var candidates []candidateURL
+	for i, name := range names {
+		if i == maxSearchQueries || ctx.Err() != nil {
+			break
+		}
+		query := `"` + name + `"`
+		if employer != "" {
+			query += " " + employer
+		}
+		results, err := cfg.Search.Search(ctx, query, searchLimit)
+		if err != nil {
+			cfg.Logger.Info("search failed", "query", query, "error", err)
+			continue
+		}
+		for _, r := range results {
+			platform := cfg.PlatformDetector(r.URL)
+			if platform == "" || platform == "generic" || coveredPlatforms[platform] || knownURLs[normalizeURL(r.URL)] {
+				continue
+			}
+			username := extractUsernameFromURL(r.URL)
+			if username == "" {
+				continue
+			}
+			knownURLs[normalizeURL(r.URL)] = true
+			candidates = append(candidates, candidateURL{
+				url:        r.URL,
+				username:   strings.ToLower(username),
+				platform:   platform,
+				matchType:  "search",
+				sourceName: name,
+			})
+		}
+	}
+	cfg.Logger.Info("generated search candidates", "count", len(candidates))
+	return candidates
+}
@@ -0,0 +1,94 @@
+package guess
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/searchengine"
+)
+
+type fakeSearch struct {
+	queries []string
+	results []searchengine.Result
+}
+
+func (f *fakeSearch) Search(_ context.Context, query string, _ int) ([]searchengine.Result, error) {
+	f.queries = append(f.queries, query)
+	return f.results, nil
+}
+
+func detectPlatform(url string) string {
+	for _, p := range []string{"github", "linkedin", "twitter"} {
+		if strings.Contains(url, p+".com") {
+			return p
+		}
+	}
+	return "generic"
+}
+
+func TestRelatedSearch(t *testing.T) {
+	known := []*profile.Profile{{
+		Platform: "github",
+		URL:      "https://github.com/jd",
+		Username: "jd",
+		Name:     "Jane Doe",
+		Location: "Portland, OR",
+		Fields:   map[string]string{"company": "@acme"},
+	}}
+	search := &fakeSearch{results: []searchengine.Result{
+		{Title: "Jane Doe - Acme | LinkedIn", URL: "https://www.linkedin.com/in/jane-doe-4821"},
+		{Title: "Jane Doe (@jd)", URL: "https://github.com/jd"}, // already known
+		{Title: "Jane's blog", URL: "https://janedoe.dev"},      // not a social platform
+	}}
+	fetcher := func(_ context.Context, url string) (*profile.Profile, error) {
+		if url != "https://www.linkedin.com/in/jane-doe-4821" {
+			return nil, errors.New("not found")
+		}
+		return &profile.Profile{
+			Platform: "linkedin",
+			URL:      url,
+			Username: "jane-doe-4821",
+			Name:     "Jane Doe",
+			Bio:      "Software Engineer at Acme",
+			Location: "Portland, Oregon",
+			Fields:   map[string]string{"employer": "Acme"},
+		}, nil
+	}
+
+	got := Related(context.Background(), known, Config{
+		Logger:           slog.New(slog.DiscardHandler),
+		Fetcher:          fetcher,
+		PlatformDetector: detectPlatform,
+		Search:           search,
+	})
+
+	if want := []string{`"Jane Doe" acme`}; !slices.Equal(search.queries, want) {
+		t.Errorf("queries = %q, want %q", search.queries, want)
+	}
+	var found *profile.Profile
+	for _, p := range got {
+		if p.URL == "https://www.linkedin.com/in/jane-doe-4821" {
+			found = p
+		}
+	}
+	if found == nil {
+		t.Fatalf("search result not in guesses: %v", got)
+	}
+	if !found.IsGuess || found.Confidence < 0.3 {
+		t.Errorf("search guess IsGuess = %v, Confidence = %v", found.IsGuess, found.Confidence)
+	}
+}
+
+func TestScoreMatchSearch(t *testing.T) {
+	known := []*profile.Profile{{Platform: "github", Name: "Jane Doe"}}
+	guessed := &profile.Profile{Platform: "twitter", Username: "zz_top", Name: "Jane Doe"}
+	_, matches := scoreMatch(guessed, known, candidateURL{username: "zz_top", platform: "twitter", matchType: "search"})
+	if !slices.Contains(matches, "search:name") || slices.Contains(matches, "username:exact") {
+		t.Errorf("matches = %v, want search:name and no username match", matches)
+	}
+}
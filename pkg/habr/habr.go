@@ -99,135 +99,137 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), normalizedURL, username)
 }
 
-func parseProfile(html, url, username string) (*profile.Profile, error) {
-	prof := &profile.Profile{
-		Platform: platform,
-		URL:      url,
-		Username: username,
-		Fields:   make(map[string]string),
+// Parse extracts a profile from a Habr user page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
 	}
+	return parseProfile(string(data), fmt.Sprintf("https://habr.com/en/users/%s", username), username)
+}
+
+// Profile page patterns for parseProfile.
+var (
+	aboutPattern      = regexp.MustCompile(`(?is)About</dt>.*?<div class="tm-user-profile__content">\s*<span>(.*?)</span>`)
+	spacePattern      = regexp.MustCompile(`\s+`)
+	locationPattern   = regexp.MustCompile(`(?is)Location</dt>\s*<dd[^>]*>(.*?)</dd>`)
+	registeredPattern = regexp.MustCompile(`(?is)Registered</dt>\s*<dd[^>]*>(.*?)</dd>`)
+	contactPattern    = regexp.MustCompile(`(?i)Contact info[^>]*>(.*?)</div`)
+	urlPattern        = regexp.MustCompile(`https?://[^\s<>"]+`)
+)
+
+func parseProfile(html, url, username string) (*profile.Profile, error) {
+	b := profile.NewBuilder(platform, url)
 
 	// Extract name from title or profile header
-	prof.Name = htmlutil.Title(html)
-	if prof.Name != "" {
+	name := htmlutil.Title(html)
+	if name != "" {
 		// Clean up title (remove " - JS / Habr" suffix)
-		if idx := strings.Index(prof.Name, " - "); idx != -1 {
-			prof.Name = strings.TrimSpace(prof.Name[:idx])
+		if idx := strings.Index(name, " - "); idx != -1 {
+			name = strings.TrimSpace(name[:idx])
 		}
 	}
 
 	// Extract bio from "About" section
 	// Try pattern: About followed by tm-user-profile__content with span
-	aboutPattern := regexp.MustCompile(`(?is)About</dt>.*?<div class="tm-user-profile__content">\s*<span>(.*?)</span>`)
+	var bio string
 	if matches := aboutPattern.FindStringSubmatch(html); len(matches) > 1 {
-		bio := htmlutil.ToMarkdown(matches[1])
-		bio = strings.TrimSpace(bio)
+		about := htmlutil.ToMarkdown(matches[1])
+		about = strings.TrimSpace(about)
 		// Remove excessive whitespace
-		bio = regexp.MustCompile(`\s+`).ReplaceAllString(bio, " ")
-		if bio != "" && len(bio) > 10 {
-			prof.Bio = bio
+		about = spacePattern.ReplaceAllString(about, " ")
+		if about != "" && len(about) > 10 {
+			bio = about
 		}
 	}
 
 	// If no bio from About, try meta description
-	if prof.Bio == "" {
-		prof.Bio = htmlutil.Description(html)
+	if bio == "" {
+		bio = htmlutil.Description(html)
 	}
 
 	// Extract location - look for "Location" label followed by content
-	locationPattern := regexp.MustCompile(`(?is)Location</dt>\s*<dd[^>]*>(.*?)</dd>`)
+	var location string
 	if matches := locationPattern.FindStringSubmatch(html); len(matches) > 1 {
 		loc := htmlutil.ToMarkdown(matches[1])
 		loc = strings.TrimSpace(loc)
-		loc = regexp.MustCompile(`\s+`).ReplaceAllString(loc, " ")
-		if loc != "" {
-			prof.Location = loc
-		}
+		location = spacePattern.ReplaceAllString(loc, " ")
+	}
+
+	// Extract registration date, e.g. "February 12, 2013"
+	var registered string
+	if matches := registeredPattern.FindStringSubmatch(html); len(matches) > 1 {
+		registered = strings.TrimSpace(htmlutil.ToMarkdown(matches[1]))
 	}
 
 	// Extract contact info (website, GitHub, etc.)
-	contactPattern := regexp.MustCompile(`(?i)Contact info[^>]*>(.*?)</div`)
 	if matches := contactPattern.FindStringSubmatch(html); len(matches) > 1 {
 		// Extract links from contact section
-		links := htmlutil.SocialLinks(matches[1])
-		for _, link := range links {
-			// Skip image URLs
-			if strings.Contains(link, "favicon") || strings.Contains(link, "/favicons/") ||
-				strings.HasSuffix(link, ".ico") || strings.HasSuffix(link, ".svg") ||
-				strings.HasSuffix(link, ".png") || strings.HasSuffix(link, ".jpg") ||
-				strings.HasSuffix(link, ".jpeg") || strings.HasSuffix(link, ".gif") {
-				continue
+		for _, link := range htmlutil.SocialLinks(matches[1]) {
+			if !isAssetURL(link) {
+				b.AddLink(link)
 			}
-			prof.SocialLinks = append(prof.SocialLinks, link)
 		}
 
 		// Also check for plain URLs
-		urlPattern := regexp.MustCompile(`https?://[^\s<>"]+`)
-		urls := urlPattern.FindAllString(matches[1], -1)
-		for _, u := range urls {
+		for _, u := range urlPattern.FindAllString(matches[1], -1) {
 			u = strings.TrimRight(u, ".,;)")
-			// Skip Habr URLs and image URLs
-			if strings.Contains(u, "habr.com") || strings.Contains(u, "habrahabr.ru") ||
-				strings.Contains(u, "favicon") || strings.Contains(u, "/favicons/") ||
-				strings.HasSuffix(u, ".ico") || strings.HasSuffix(u, ".svg") ||
-				strings.HasSuffix(u, ".png") || strings.HasSuffix(u, ".jpg") ||
-				strings.HasSuffix(u, ".jpeg") || strings.HasSuffix(u, ".gif") {
-				continue
-			}
-			isDuplicate := false
-			for _, existing := range prof.SocialLinks {
-				if existing == u {
-					isDuplicate = true
-					break
-				}
-			}
-			if !isDuplicate {
-				prof.SocialLinks = append(prof.SocialLinks, u)
+			if !isHabrURL(u) && !isAssetURL(u) {
+				b.AddLink(u)
 			}
 		}
 	}
 
 	// Extract all social links from page
-	pageLinks := htmlutil.SocialLinks(html)
-	for _, link := range pageLinks {
+	for _, link := range htmlutil.SocialLinks(html) {
 		// Skip Habr links, Habr's own social media, retargeting pixels, and favicons/assets
-		if strings.Contains(link, "habr.com") || strings.Contains(link, "habrahabr.ru") ||
+		if isHabrURL(link) ||
 			strings.Contains(link, "habr_eng") || strings.Contains(link, "habr.eng") ||
 			strings.Contains(link, "/rtrg") || // VK retargeting pixel
-			strings.Contains(link, "favicon") || strings.Contains(link, "/assets/") ||
-			strings.Contains(link, "/favicons/") ||
-			strings.HasSuffix(link, ".ico") || strings.HasSuffix(link, ".svg") ||
-			strings.HasSuffix(link, ".png") || strings.HasSuffix(link, ".jpg") ||
-			strings.HasSuffix(link, ".jpeg") || strings.HasSuffix(link, ".gif") {
+			strings.Contains(link, "/assets/") ||
+			isAssetURL(link) {
 			continue
 		}
-		isDuplicate := false
-		for _, existing := range prof.SocialLinks {
-			if existing == link {
-				isDuplicate = true
-				break
-			}
-		}
-		if !isDuplicate {
-			prof.SocialLinks = append(prof.SocialLinks, link)
-		}
+		b.AddLink(link)
 	}
 
-	if prof.Name == "" {
+	if name == "" {
 		return nil, errors.New("failed to extract profile name")
 	}
 
-	return prof, nil
+	b.Edit(func(p *profile.Profile) {
+		p.Username = username
+		p.Name = name
+		p.Bio = bio
+		p.Location = location
+		p.CreatedAt = registered
+	})
+	return b.Build(), nil
 }
 
+// isHabrURL reports whether u points at Habr itself.
+func isHabrURL(u string) bool {
+	return strings.Contains(u, "habr.com") || strings.Contains(u, "habrahabr.ru")
+}
+
+// isAssetURL reports whether u is a favicon or image rather than a profile link.
+func isAssetURL(u string) bool {
+	return strings.Contains(u, "favicon") || strings.Contains(u, "/favicons/") ||
+		strings.HasSuffix(u, ".ico") || strings.HasSuffix(u, ".svg") ||
+		strings.HasSuffix(u, ".png") || strings.HasSuffix(u, ".jpg") ||
+		strings.HasSuffix(u, ".jpeg") || strings.HasSuffix(u, ".gif")
+}
+
+// usernamePattern matches the username in a Habr profile URL.
+var usernamePattern = regexp.MustCompile(`/users/([^/?#]+)`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
 	urlStr = strings.TrimPrefix(urlStr, "http://")
 
 	// Extract /users/username pattern
-	re := regexp.MustCompile(`/users/([^/?#]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -174,6 +174,7 @@ func TestParseProfile(t *testing.T) {
 		wantName     string
 		wantBio      string
 		wantLocation string
+		wantCreated  string
 		wantErr      bool
 	}{
 		{
@@ -187,11 +188,14 @@ func TestParseProfile(t *testing.T) {
 				</div>
 				<dt>Location</dt>
 				<dd>Berlin, Germany</dd>
+				<dt class="tm-description-list__title">Registered</dt>
+				<dd class="tm-description-list__body"> February 12, 2013 </dd>
 			</body></html>`,
 			username:     "johndoe",
 			wantName:     "John Doe",
 			wantBio:      "Senior backend engineer specializing in Go",
 			wantLocation: "Berlin, Germany",
+			wantCreated:  "February 12, 2013",
 		},
 		{
 			name: "bio from description",
@@ -237,6 +241,9 @@ func TestParseProfile(t *testing.T) {
 			if tt.wantLocation != "" && profile.Location != tt.wantLocation {
 				t.Errorf("Location = %q, want %q", profile.Location, tt.wantLocation)
 			}
+			if profile.CreatedAt != tt.wantCreated {
+				t.Errorf("CreatedAt = %q, want %q", profile.CreatedAt, tt.wantCreated)
+			}
 		})
 	}
 }
@@ -0,0 +1,81 @@
+package htmlutil
+
+import (
+	"encoding/hex"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Spelled-out separators: "[at]", "(at)", "{at}", " AT " and their "dot" equivalents.
+// Lowercase " at " is left alone since "works at example.com" is prose, not an address.
+const (
+	obfuscatedAt  = `(?:\s*[\[\(\{<]\s*(?i:at)\s*[\]\)\}>]\s*|\s+AT\s+)`
+	obfuscatedDot = `(?:\s*[\[\(\{<]\s*(?i:dot)\s*[\]\)\}>]\s*|\s+DOT\s+|\.)`
+)
+
+var (
+	spelledEmailPattern = regexp.MustCompile(`([a-zA-Z0-9._%+-]+)` + obfuscatedAt +
+		`([a-zA-Z0-9-]+(?:` + obfuscatedDot + `[a-zA-Z0-9-]+)+)`)
+	dotSeparator      = regexp.MustCompile(obfuscatedDot)
+	cfEmailPattern    = regexp.MustCompile(`(?:data-cfemail="|/cdn-cgi/l/email-protection#)([0-9a-fA-F]+)`)
+	rtlTextPattern    = regexp.MustCompile(`(?is)<\w+[^>]*style="[^"]*direction:\s*rtl[^"]*"[^>]*>([^<]+)<`)
+	exactEmailPattern = regexp.MustCompile(`^` + emailPattern.String() + `$`)
+)
+
+// obfuscatedEmails returns addresses hidden from naive scrapers: HTML-entity encoding,
+// "name [at] example [dot] com" spellings, text reversed with CSS direction:rtl, and
+// Cloudflare email protection.
+func obfuscatedEmails(htmlContent string) []string {
+	var emails []string
+	add := func(s string) {
+		if s = strings.ToLower(strings.TrimSpace(s)); exactEmailPattern.MatchString(s) {
+			emails = append(emails, s)
+		}
+	}
+
+	for _, m := range cfEmailPattern.FindAllStringSubmatch(htmlContent, -1) {
+		if email, ok := decodeCFEmail(m[1]); ok {
+			add(email)
+		}
+	}
+
+	// Entities such as "&#106;&#111;&#101;&#64;" decode to plain text before matching
+	text := html.UnescapeString(htmlContent)
+
+	for _, m := range rtlTextPattern.FindAllStringSubmatch(text, -1) {
+		add(reverse(m[1]))
+	}
+	for _, m := range spelledEmailPattern.FindAllStringSubmatch(text, -1) {
+		add(m[1] + "@" + dotSeparator.ReplaceAllString(m[2], "."))
+	}
+	if text != htmlContent {
+		for _, email := range emailPattern.FindAllString(text, -1) {
+			add(email)
+		}
+	}
+	return emails
+}
+
+// decodeCFEmail decodes Cloudflare's email protection encoding: the first byte is
+// an XOR key applied to each byte that follows.
+func decodeCFEmail(encoded string) (string, bool) {
+	b, err := hex.DecodeString(encoded)
+	if err != nil || len(b) < 2 {
+		return "", false
+	}
+	key := b[0]
+	out := make([]byte, len(b)-1)
+	for i, c := range b[1:] {
+		out[i] = c ^ key
+	}
+	return string(out), true
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
@@ -0,0 +1,53 @@
+package htmlutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEmailAddressesObfuscated(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"brackets", `Mail me: jane [at] doe [dot] dev`, "jane@doe.dev"},
+		{"parens", `jane(at)doe(dot)co(dot)uk`, "jane@doe.co.uk"},
+		{"mixed", `jane.doe {AT} example-mail.org`, "jane.doe@example-mail.org"},
+		{"uppercase words", `jane AT doe DOT dev`, "jane@doe.dev"},
+		{"decimal entities", `&#106;&#97;&#110;&#101;&#64;&#100;&#111;&#101;&#46;&#100;&#101;&#118;`, "jane@doe.dev"},
+		{"hex entities", `jane&#x40;doe&#x2e;dev`, "jane@doe.dev"},
+		{"reversed", `<span style="unicode-bidi:bidi-override; direction: rtl;">ved.eod@enaj</span>`, "jane@doe.dev"},
+		{"cloudflare attribute", `<span class="__cf_email__" data-cfemail="4228232c2702262d276c262734">[email&#160;protected]</span>`, "jane@doe.dev"},
+		{"cloudflare link", `<a href="/cdn-cgi/l/email-protection#4228232c2702262d276c262734">email</a>`, "jane@doe.dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EmailAddresses(tt.html)
+			if !slices.Contains(got, tt.want) {
+				t.Errorf("EmailAddresses(%q) = %v, want it to contain %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailAddressesNotObfuscated(t *testing.T) {
+	for _, text := range []string{
+		"She works at example.com",
+		"Meet me at the park. Bring snacks.",
+		`<span style="direction: rtl">שלום עולם</span>`,
+	} {
+		if got := EmailAddresses(text); len(got) != 0 {
+			t.Errorf("EmailAddresses(%q) = %v, want none", text, got)
+		}
+	}
+}
+
+func TestDecodeCFEmail(t *testing.T) {
+	if _, ok := decodeCFEmail("zz"); ok {
+		t.Error("decodeCFEmail(invalid hex) should fail")
+	}
+	if _, ok := decodeCFEmail("42"); ok {
+		t.Error("decodeCFEmail(key only) should fail")
+	}
+}
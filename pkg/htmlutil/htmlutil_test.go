@@ -58,6 +58,26 @@ func TestToMarkdown(t *testing.T) {
 			html: "",
 			want: "",
 		},
+		{
+			name: "uppercase tags",
+			html: "<P>Upper <B>bold</B></P><UL><LI>x</LI></UL>",
+			want: "Upper **bold**\n- x",
+		},
+		{
+			name: "comments and stray angle brackets",
+			html: "<p>a < b</p><!-- <p>c > d</p> --><p>e</p>",
+			want: "a < b\ne",
+		},
+		{
+			name: "link with markup keeps only text",
+			html: `<a href="/x"><img src="y.png"> photo</a> <a href="/z?a=1&amp;b=2">z</a>`,
+			want: "photo [z](/z?a=1&b=2)",
+		},
+		{
+			name: "script containing tags",
+			html: "<p>a</p><script>document.write('<p>b</p>')</script><p>c</p>",
+			want: "a\nc",
+		},
 	}
 
 	for _, tt := range tests {
@@ -208,3 +228,34 @@ func TestSocialLinksDeduplication(t *testing.T) {
 		t.Errorf("expected 1 occurrence, got %d", count)
 	}
 }
+
+func TestSocialLinksShareIntents(t *testing.T) {
+	html := `
+		<a href="https://twitter.com/intent/follow?screen_name=johndoe&amp;ref_src=twsrc">Follow</a>
+		<a href="https://www.linkedin.com/sharing/share-offsite/?url=https%3A%2F%2Fwww.linkedin.com%2Fin%2Fjohndoe">Share</a>
+		<a href="https://www.facebook.com/sharer/sharer.php?u=https://johndoe.dev/posts/hello">Share post</a>
+	`
+
+	got := SocialLinks(html)
+	want := map[string]bool{
+		"https://twitter.com/johndoe":         true,
+		"https://www.linkedin.com/in/johndoe": true,
+	}
+	for _, link := range got {
+		if !want[link] {
+			t.Errorf("unexpected link %q in %v", link, got)
+		}
+		delete(want, link)
+	}
+	for link := range want {
+		t.Errorf("missing %q in %v", link, got)
+	}
+}
+
+func TestEmailAddressesMailto(t *testing.T) {
+	html := `<a href="mailto:john%40doe.dev?subject=Hello">Email me</a>`
+	got := EmailAddresses(html)
+	if len(got) != 1 || got[0] != "john@doe.dev" {
+		t.Errorf("EmailAddresses() = %v, want [john@doe.dev]", got)
+	}
+}
@@ -2,85 +2,150 @@
 package htmlutil
 
 import (
+	"bytes"
 	"html"
-	"regexp"
 	"strings"
 )
 
 // ToMarkdown converts HTML content to markdown format.
+//
+// It reads the document as a single stream of tokens rather than rewriting the whole
+// string once per element type, so multi-megabyte pages such as long READMEs and
+// blogs cost one pass and roughly one copy of their text.
 func ToMarkdown(htmlContent string) string {
 	if htmlContent == "" {
 		return ""
 	}
 
-	content := htmlContent
-
-	// Remove script and style tags with their content
-	content = scriptPattern.ReplaceAllString(content, "")
-	content = stylePattern.ReplaceAllString(content, "")
-
-	// Convert headers
-	content = h1Pattern.ReplaceAllString(content, "\n# $1\n")
-	content = h2Pattern.ReplaceAllString(content, "\n## $1\n")
-	content = h3Pattern.ReplaceAllString(content, "\n### $1\n")
-
-	// Convert links
-	content = linkPattern.ReplaceAllString(content, "[$2]($1)")
-
-	// Convert paragraphs and line breaks
-	content = strings.ReplaceAll(content, "</p>", "\n\n")
-	content = strings.ReplaceAll(content, "<p>", "")
-	content = strings.ReplaceAll(content, "<br>", "\n")
-	content = strings.ReplaceAll(content, "<br/>", "\n")
-	content = strings.ReplaceAll(content, "<br />", "\n")
-
-	// Convert lists
-	content = strings.ReplaceAll(content, "<li>", "- ")
-	content = strings.ReplaceAll(content, "</li>", "\n")
-	content = strings.ReplaceAll(content, "<ul>", "\n")
-	content = strings.ReplaceAll(content, "</ul>", "\n")
-	content = strings.ReplaceAll(content, "<ol>", "\n")
-	content = strings.ReplaceAll(content, "</ol>", "\n")
-
-	// Convert bold and italic
-	content = boldPattern.ReplaceAllString(content, "**$1**")
-	content = italicPattern.ReplaceAllString(content, "*$1*")
+	var md markdownWriter
+	md.out.Grow(len(htmlContent) / 4)
+	z := tokenizer{s: htmlContent}
+	skip := false // Inside <script> or <style>
+	var link struct {
+		href   string
+		text   []byte
+		open   bool
+		nested bool // The link text contains markup, so only the text is kept
+	}
+	for {
+		t, ok := z.next()
+		if !ok {
+			break
+		}
+		if t.kind == textToken {
+			switch {
+			case skip:
+				skip = false
+			case link.open:
+				link.text = append(link.text, t.text...)
+			default:
+				md.writeText(t.text)
+			}
+			continue
+		}
+		if link.open && !strings.EqualFold(t.name, "a") {
+			link.nested = true
+			md.writeText(string(link.text))
+			link.text = link.text[:0]
+		}
+		start := t.kind == startTagToken
+		switch name := strings.ToLower(t.name); name {
+		case "script", "style":
+			skip = start
+		case "h1", "h2", "h3":
+			if start {
+				md.write("\n")
+				md.write("###"[:name[1]-'0'])
+				md.write(" ")
+			} else {
+				md.write("\n")
+			}
+		case "a":
+			switch {
+			case start && !link.open:
+				link.href, link.open, link.nested = attr(t.attrs, "href"), true, false
+				link.text = link.text[:0]
+			case !start && link.open:
+				text := string(link.text)
+				if link.href != "" && text != "" && !link.nested {
+					md.write("[")
+					md.writeText(text)
+					md.write("](")
+					md.writeText(link.href)
+					md.write(")")
+				} else {
+					md.writeText(text)
+				}
+				link.open = false
+			}
+		case "b", "strong":
+			md.write("**")
+		case "i", "em":
+			md.write("*")
+		case "p":
+			if !start {
+				md.write("\n\n")
+			}
+		case "br", "ul", "ol":
+			md.write("\n")
+		case "li":
+			if start {
+				md.write("- ")
+			} else {
+				md.write("\n")
+			}
+		}
+	}
+	if link.open {
+		md.writeText(string(link.text))
+	}
+	return md.String()
+}
 
-	// Remove all remaining HTML tags
-	content = tagPattern.ReplaceAllString(content, "")
+// markdownWriter collects ToMarkdown's output a line at a time, trimming each line
+// and dropping blank ones as they complete.
+type markdownWriter struct {
+	out  strings.Builder
+	line []byte
+}
 
-	// Unescape HTML entities after removing tags
-	content = html.UnescapeString(content)
+// writeText writes escaped document text.
+func (w *markdownWriter) writeText(s string) {
+	if strings.IndexByte(s, '&') >= 0 {
+		s = html.UnescapeString(s)
+	}
+	w.write(s)
+}
 
-	// Clean up excessive whitespace
-	// First, split by newlines and trim each line
-	lines := strings.Split(content, "\n")
-	var cleaned []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			cleaned = append(cleaned, line)
+// write writes s, ending the current line at each newline.
+func (w *markdownWriter) write(s string) {
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			w.line = append(w.line, s...)
+			return
 		}
+		w.line = append(w.line, s[:i]...)
+		w.endLine()
+		s = s[i+1:]
 	}
-	content = strings.Join(cleaned, "\n")
-
-	// Replace multiple consecutive newlines with at most two newlines
-	content = multiNewlinePattern.ReplaceAllString(content, "\n\n")
-	content = strings.TrimSpace(content)
+}
 
-	return content
+// endLine moves the current line to the output unless it is blank.
+func (w *markdownWriter) endLine() {
+	line := bytes.TrimSpace(w.line)
+	w.line = w.line[:0]
+	if len(line) == 0 {
+		return
+	}
+	if w.out.Len() > 0 {
+		w.out.WriteByte('\n')
+	}
+	w.out.Write(line)
 }
 
-// Pre-compiled patterns for HTML to Markdown conversion.
-var (
-	scriptPattern       = regexp.MustCompile(`(?is)<script[^>]*>.*?</script>`)
-	stylePattern        = regexp.MustCompile(`(?is)<style[^>]*>.*?</style>`)
-	h1Pattern           = regexp.MustCompile(`(?i)<h1[^>]*>(.*?)</h1>`)
-	h2Pattern           = regexp.MustCompile(`(?i)<h2[^>]*>(.*?)</h2>`)
-	h3Pattern           = regexp.MustCompile(`(?i)<h3[^>]*>(.*?)</h3>`)
-	linkPattern         = regexp.MustCompile(`(?i)<a[^>]+href=["']([^"']+)["'][^>]*>([^<]+)</a>`)
-	boldPattern         = regexp.MustCompile(`(?i)<(?:b|strong)[^>]*>(.*?)</(?:b|strong)>`)
-	italicPattern       = regexp.MustCompile(`(?i)<(?:i|em)[^>]*>(.*?)</(?:i|em)>`)
-	tagPattern          = regexp.MustCompile(`<[^>]+>`)
-	multiNewlinePattern = regexp.MustCompile(`\n{3,}`)
-)
+// String ends the current line and returns the output.
+func (w *markdownWriter) String() string {
+	w.endLine()
+	return w.out.String()
+}
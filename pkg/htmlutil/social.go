@@ -1,9 +1,12 @@
 package htmlutil
 
 import (
+	"html"
 	"net/url"
 	"regexp"
 	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 )
 
 // SocialLinks extracts social media URLs from HTML content.
@@ -25,6 +28,14 @@ func SocialLinks(htmlContent string) []string {
 		}
 	}
 
+	// Follow and share buttons point at the profile through intent URLs
+	for _, u := range shareLinks(htmlContent) {
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+
 	// Also extract links with social/personal keywords in the link text
 	personalLinks := extractPersonalLinks(htmlContent)
 	for _, u := range personalLinks {
@@ -35,17 +46,39 @@ func SocialLinks(htmlContent string) []string {
 		}
 	}
 
+	return links.DefaultDenylist.Filter(urls)
+}
+
+// sharePattern matches follow/share button hrefs, whose query names the profile.
+var sharePattern = regexp.MustCompile(`(?i)href=["']?(https?://(?:www\.)?(?:twitter\.com|x\.com|linkedin\.com|facebook\.com)/(?:intent|share|sharing|sharer)[^\s"'<>]*)`)
+
+// shareLinks returns the profile URLs that follow and share buttons point at.
+// Share buttons for the page itself (a blog post, say) are not profiles and are skipped.
+func shareLinks(htmlContent string) []string {
+	var urls []string
+	for _, m := range sharePattern.FindAllStringSubmatch(htmlContent, -1) {
+		href := html.UnescapeString(m[1])
+		u := links.Canonicalize(href)
+		if u == href || !strings.HasPrefix(u, "http") || IsEmailURL(u) || !isSocialPlatformURL(u) {
+			continue
+		}
+		urls = append(urls, u)
+	}
 	return urls
 }
 
+// HTML anchors, including ones wrapping markup, and markdown links.
+var (
+	anchorPattern       = regexp.MustCompile(`(?i)<a[^>]+href=["']?([^\s"'>]+)["']?[^>]*>([^<]*(?:<[^/][^>]*>[^<]*)*)</a>`)
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+)
+
 // extractPersonalLinks finds URLs with social/personal keywords in link text.
 func extractPersonalLinks(htmlContent string) []string {
 	var urls []string
 
 	// Pattern to find anchor tags
-	anchorPattern := regexp.MustCompile(`(?i)<a[^>]+href=["']?([^\s"'>]+)["']?[^>]*>([^<]*(?:<[^/][^>]*>[^<]*)*)</a>`)
 	// Also check markdown-style links: [text](url)
-	markdownPattern := regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
 
 	// Personal keywords that indicate a personal/social link
 	personalKeywords := []string{"blog", "website", "portfolio", "homepage", "personal site"}
@@ -71,7 +104,7 @@ func extractPersonalLinks(htmlContent string) []string {
 	}
 
 	// Markdown links
-	mdMatches := markdownPattern.FindAllStringSubmatch(htmlContent, -1)
+	mdMatches := markdownLinkPattern.FindAllStringSubmatch(htmlContent, -1)
 	for _, match := range mdMatches {
 		if len(match) < 3 {
 			continue
@@ -130,6 +163,8 @@ func cleanURL(s string) string {
 
 var emailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}`)
 
+var mailtoPattern = regexp.MustCompile(`(?i)href=["']?(mailto:[^\s"'<>]+)`)
+
 // ExtractEmailFromURL extracts an email address from URLs like "https://user@domain.com" or "http://email@example.com".
 // Returns the email address and true if found, empty string and false otherwise.
 func ExtractEmailFromURL(urlStr string) (string, bool) {
@@ -173,6 +208,13 @@ func EmailAddresses(htmlContent string) []string {
 	seen := make(map[string]bool)
 
 	matches := emailPattern.FindAllString(htmlContent, -1)
+	// mailto links may percent-encode the address, hiding it from emailPattern
+	for _, m := range mailtoPattern.FindAllStringSubmatch(htmlContent, -1) {
+		if email, ok := links.Email(html.UnescapeString(m[1])); ok {
+			matches = append(matches, email)
+		}
+	}
+	matches = append(matches, obfuscatedEmails(htmlContent)...)
 	for _, email := range matches {
 		email = strings.ToLower(email)
 
@@ -198,6 +240,9 @@ func EmailAddresses(htmlContent string) []string {
 	return emails
 }
 
+// anchorTitlePattern matches links with a title attribute, for ContactLinks.
+var anchorTitlePattern = regexp.MustCompile(`(?i)<a[^>]+href=["']?([^\s"'>]+)["']?[^>]*title=["']?([^"'>]+)["']?[^>]*>`)
+
 // ContactLinks extracts contact/about page URLs from HTML content.
 // These pages often contain additional social media links.
 func ContactLinks(htmlContent, baseURL string) []string {
@@ -206,12 +251,10 @@ func ContactLinks(htmlContent, baseURL string) []string {
 
 	// Pattern to find anchor tags with contact-related text
 	// Handles both quoted and unquoted href attributes
-	anchorPattern := regexp.MustCompile(`(?i)<a[^>]+href=["']?([^\s"'>]+)["']?[^>]*>([^<]*(?:<[^/][^>]*>[^<]*)*)</a>`)
 	matches := anchorPattern.FindAllStringSubmatch(htmlContent, -1)
 
 	// Also look for title attributes
-	titlePattern := regexp.MustCompile(`(?i)<a[^>]+href=["']?([^\s"'>]+)["']?[^>]*title=["']?([^"'>]+)["']?[^>]*>`)
-	titleMatches := titlePattern.FindAllStringSubmatch(htmlContent, -1)
+	titleMatches := anchorTitlePattern.FindAllStringSubmatch(htmlContent, -1)
 	for _, match := range titleMatches {
 		if len(match) >= 3 {
 			matches = append(matches, match)
@@ -225,7 +268,7 @@ func ContactLinks(htmlContent, baseURL string) []string {
 		href := strings.TrimSpace(match[1])
 		text := strings.ToLower(strings.TrimSpace(match[2]))
 		// Strip HTML tags from text content
-		text = regexp.MustCompile(`<[^>]+>`).ReplaceAllString(text, " ")
+		text = tagPattern.ReplaceAllString(text, " ")
 		text = strings.TrimSpace(text)
 
 		// Look for contact-related link text or title
@@ -330,6 +373,10 @@ func isSocialPlatformURL(u string) bool {
 		"discord.com", "discordapp.com",
 		"medium.com", "reddit.com", "substack.com",
 		"weibo.com", "weibo.cn", "zhihu.com", "bilibili.com",
+		"sessionize.com", "meetup.com", "patreon.com", "ko-fi.com",
+		"itch.io", "steamcommunity.com", "discord.gg",
+		"flickr.com", "500px.com", "soundcloud.com", "read.cv", "polywork.com",
+		"calendly.com", "//cal.com", ".cal.com",
 	}
 	for _, p := range platforms {
 		if strings.Contains(lower, p) {
@@ -368,21 +415,36 @@ var socialPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`https?://(?:www\.)?youtube\.com/(?:@[\w-]+|c/[\w-]+|user/[\w-]+|channel/[\w-]+)`), // YouTube handles and channels
 	regexp.MustCompile(`https?://(?:www\.)?twitch\.tv/\w+`),
 	regexp.MustCompile(`https?://(?:www\.)?tiktok\.com/@\w+`),
-	regexp.MustCompile(`https?://(?:www\.)?github\.com/[\w-]+/?(?:[^\w-/]|$)`),         // Profile only, not /user/project
-	regexp.MustCompile(`https?://(?:www\.)?(?:discord|discordapp)\.com/users/[\w.-]+`), // Discord user profiles (numeric ID or username)
-	regexp.MustCompile(`https?://(?:www\.)?vk\.com/[\w.]+`),                            // VKontakte
-	regexp.MustCompile(`https?://(?:www\.)?habr\.com/(?:ru/)?users/[\w-]+`),            // Habr (formerly Habrhabr)
-	regexp.MustCompile(`https?://habrahabr\.ru/users/[\w-]+`),                          // Old Habrhabr domain
-	regexp.MustCompile(`https?://(?:www\.)?medium\.com/@[\w-]+`),                       // Medium
-	regexp.MustCompile(`https?://(?:www\.)?reddit\.com/user/[\w-]+`),                   // Reddit
-	regexp.MustCompile(`https?://(?:old\.)?reddit\.com/user/[\w-]+`),                   // Old Reddit
-	regexp.MustCompile(`https?://[\w-]+\.substack\.com`),                               // Substack
-	regexp.MustCompile(`https?://(?:www\.)?weibo\.com/[\w-]+`),                         // Weibo
-	regexp.MustCompile(`https?://(?:www\.)?weibo\.cn/[\w-]+`),                          // Weibo mobile
-	regexp.MustCompile(`https?://(?:www\.)?zhihu\.com/people/[\w-]+`),                  // Zhihu
-	regexp.MustCompile(`https?://space\.bilibili\.com/\d+`),                            // Bilibili
-	regexp.MustCompile(`https?://(?:www\.)?bilibili\.com/\d+`),                         // Bilibili short URL
-	regexp.MustCompile(`skype:[\w.-]+\??[\w=&]*`),                                      // Skype links
+	regexp.MustCompile(`https?://(?:www\.)?github\.com/[\w-]+/?(?:[^\w-/]|$)`),               // Profile only, not /user/project
+	regexp.MustCompile(`https?://(?:www\.)?(?:discord|discordapp)\.com/users/[\w.-]+`),       // Discord user profiles (numeric ID or username)
+	regexp.MustCompile(`https?://(?:www\.)?vk\.com/[\w.]+`),                                  // VKontakte
+	regexp.MustCompile(`https?://(?:www\.)?habr\.com/(?:ru/)?users/[\w-]+`),                  // Habr (formerly Habrhabr)
+	regexp.MustCompile(`https?://habrahabr\.ru/users/[\w-]+`),                                // Old Habrhabr domain
+	regexp.MustCompile(`https?://(?:www\.)?medium\.com/@[\w-]+`),                             // Medium
+	regexp.MustCompile(`https?://(?:www\.)?reddit\.com/user/[\w-]+`),                         // Reddit
+	regexp.MustCompile(`https?://(?:old\.)?reddit\.com/user/[\w-]+`),                         // Old Reddit
+	regexp.MustCompile(`https?://[\w-]+\.substack\.com`),                                     // Substack
+	regexp.MustCompile(`https?://(?:www\.)?weibo\.com/[\w-]+`),                               // Weibo
+	regexp.MustCompile(`https?://(?:www\.)?weibo\.cn/[\w-]+`),                                // Weibo mobile
+	regexp.MustCompile(`https?://(?:www\.)?zhihu\.com/people/[\w-]+`),                        // Zhihu
+	regexp.MustCompile(`https?://space\.bilibili\.com/\d+`),                                  // Bilibili
+	regexp.MustCompile(`https?://(?:www\.)?bilibili\.com/\d+`),                               // Bilibili short URL
+	regexp.MustCompile(`https?://sessionize\.com/[\w.-]+`),                                   // Sessionize speakers
+	regexp.MustCompile(`https?://(?:www\.)?meetup\.com/(?:[\w-]+/)?members/\d+`),             // Meetup members
+	regexp.MustCompile(`https?://(?:www\.)?patreon\.com/(?:c/)?[\w.-]+`),                     // Patreon creators
+	regexp.MustCompile(`https?://(?:www\.)?ko-fi\.com/[\w.-]+`),                              // Ko-fi creators
+	regexp.MustCompile(`https?://(?:www\.)?github\.com/sponsors/[\w-]+`),                     // GitHub Sponsors
+	regexp.MustCompile(`https?://[\w-]+\.itch\.io/?(?:[^\w/.-]|$)`),                          // itch.io creators
+	regexp.MustCompile(`https?://steamcommunity\.com/(?:id|profiles)/[\w-]+`),                // Steam Community
+	regexp.MustCompile(`https?://(?:discord\.gg|(?:discord|discordapp)\.com/invite)/[\w-]+`), // Discord invites
+	regexp.MustCompile(`https?://(?:www\.)?flickr\.com/(?:people|photos)/[\w@.-]+`),          // Flickr
+	regexp.MustCompile(`https?://(?:www\.)?500px\.com/p/[\w.-]+`),                            // 500px
+	regexp.MustCompile(`https?://(?:www\.)?soundcloud\.com/[\w-]+`),                          // SoundCloud
+	regexp.MustCompile(`https?://read\.cv/[\w.-]+`),                                          // read.cv
+	regexp.MustCompile(`https?://(?:www\.)?polywork\.com/[\w.-]+`),                           // Polywork
+	regexp.MustCompile(`https?://(?:www\.)?calendly\.com/[\w.-]+`),                           // Calendly booking pages
+	regexp.MustCompile(`https?://(?:[\w-]+\.)?cal\.com/[\w.-]+`),                             // cal.com booking pages
+	regexp.MustCompile(`skype:[\w.-]+\??[\w=&]*`),                                            // Skype links
 	regexp.MustCompile(`https?://bsky\.app/profile/[\w.-]+`),
 	regexp.MustCompile(`https?://[\w.-]+\.social/@\w+`),
 	regexp.MustCompile(`https?://mastodon\.[\w.-]+/@\w+`),
@@ -0,0 +1,94 @@
+package htmlutil
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// entityPattern matches complete HTML character references. References without the
+// closing ";" are left alone so that text such as "AT&T" or "&copy" is not rewritten
+// by a second pass over already decoded strings.
+var entityPattern = regexp.MustCompile(`&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+
+// invisible reports whether r is a zero-width or formatting character that breaks
+// matching without changing how text reads, such as U+200B ZERO WIDTH SPACE, a byte
+// order mark, a soft hyphen, or a directional mark. Zero width joiners and
+// non-joiners are kept: emoji sequences and Persian words depend on them.
+func invisible(r rune) bool {
+	switch r {
+	case '\u200b', '\u2060', '\ufeff', '\u00ad', '\u200e', '\u200f', '\u180e':
+		return true
+	}
+	// Bidirectional embeddings, overrides, and isolates
+	return (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// cleanRunes decodes entities, drops invisible characters, and turns every other
+// kind of space (NBSP, thin spaces, the ideographic space) into a plain one.
+func cleanRunes(s string) string {
+	if strings.IndexByte(s, '&') >= 0 {
+		s = entityPattern.ReplaceAllStringFunc(s, html.UnescapeString)
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case invisible(r):
+			return -1
+		case r == '\n':
+			return r
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// CleanText returns s as a single line: HTML entities decoded, zero-width characters
+// removed, and runs of whitespace, including NBSP and line breaks, collapsed to one
+// space.
+func CleanText(s string) string {
+	return strings.Join(strings.Fields(cleanRunes(s)), " ")
+}
+
+// CleanMultiline is CleanText for text whose line breaks matter, such as bios: each
+// line is cleaned on its own, and runs of blank lines collapse to one.
+func CleanMultiline(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	var lines []string
+	blank := false
+	for line := range strings.SplitSeq(s, "\n") {
+		line = CleanText(line)
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// breakPattern matches the tags that end a line of text, and tagPattern any tag.
+var (
+	breakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>|</li>`)
+	tagPattern   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// Text returns the text of an HTML fragment such as a bio, one line per paragraph or
+// line break: tags are removed before entities are decoded, so "&lt;3" stays text,
+// and each line is cleaned by CleanText with blank lines dropped.
+func Text(fragment string) string {
+	s := tagPattern.ReplaceAllString(breakPattern.ReplaceAllString(fragment, "\n"), "")
+	var lines []string
+	for line := range strings.SplitSeq(s, "\n") {
+		if line = CleanText(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
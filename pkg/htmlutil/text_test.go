@@ -0,0 +1,115 @@
+package htmlutil
+
+import "testing"
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "basic paragraph",
+			input: "<p>Hello</p>",
+			want:  "Hello",
+		},
+		{
+			name:  "multiple paragraphs",
+			input: "<p>Hello</p><p>World</p>",
+			want:  "Hello\nWorld",
+		},
+		{
+			name:  "HTML entities",
+			input: "Hello &amp; World",
+			want:  "Hello & World",
+		},
+		{
+			name:  "links",
+			input: "<a href='url'>link</a>",
+			want:  "link",
+		},
+		{
+			name:  "br tag",
+			input: "Line 1<br>Line 2",
+			want:  "Line 1\nLine 2",
+		},
+		{
+			name:  "br self-closing",
+			input: "Line 1<br/>Line 2",
+			want:  "Line 1\nLine 2",
+		},
+		{
+			name:  "br with space",
+			input: "Line 1<br />Line 2",
+			want:  "Line 1\nLine 2",
+		},
+		{
+			name:  "div tags",
+			input: "<div>Block 1</div><div>Block 2</div>",
+			want:  "Block 1\nBlock 2",
+		},
+		{
+			name:  "complex bio with multiple breaks",
+			input: "KD4UHP - based out of Carrboro, NC<br>founder &amp; CEO @ codeGROOVE<br />former Director of Security @ Chainguard &amp; Xoogler<br/>#unix #infosec #bikes",
+			want:  "KD4UHP - based out of Carrboro, NC\nfounder & CEO @ codeGROOVE\nformer Director of Security @ Chainguard & Xoogler\n#unix #infosec #bikes",
+		},
+		{
+			name:  "empty lines removed",
+			input: "<p>Line 1</p><p></p><p>Line 2</p>",
+			want:  "Line 1\nLine 2",
+		},
+		{
+			name:  "escaped markup stays text",
+			input: "<p>I &lt;3 Go</p>",
+			want:  "I <3 Go",
+		},
+		{
+			name:  "NBSP and zero-width characters",
+			input: "Hello&nbsp;\u00a0World\u200b<br>caf\u00e9&#8203;",
+			want:  "Hello World\ncaf\u00e9",
+		},
+		{
+			name:  "whitespace normalized",
+			input: "<p>  Line 1  </p><br/><p>   Line 2   </p>",
+			want:  "Line 1\nLine 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Text(tt.input)
+			if got != tt.want {
+				t.Errorf("Text(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"&lt;script&gt;", "<script>"},
+		{"&amp;&quot;&#39;", "&\"'"},
+		{"Hello&nbsp;World", "Hello World"},
+		{"  Jane\u00a0\u00a0Doe \n", "Jane Doe"},
+		{"Jane\u200bDoe\ufeff", "JaneDoe"},
+		{"\u202aAcme Corp\u202c", "Acme Corp"},
+		{"AT&T & Co", "AT&T & Co"},
+		{"\U0001F3F3\uFE0F\u200D\U0001F308 pride", "\U0001F3F3\uFE0F\u200D\U0001F308 pride"},
+		{"東京\u3000日本", "東京 日本"},
+	}
+	for _, tt := range tests {
+		if got := CleanText(tt.input); got != tt.want {
+			t.Errorf("CleanText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCleanMultiline(t *testing.T) {
+	got := CleanMultiline("  Go &amp; Rust\u00a0 \r\n\r\n\n\u200b\nKubernetes  maintainer\n\n")
+	if want := "Go & Rust\n\nKubernetes maintainer"; got != want {
+		t.Errorf("CleanMultiline() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package htmlutil
+
+import "strings"
+
+// tokenKind is the kind of a token.
+type tokenKind int
+
+const (
+	textToken     tokenKind = iota // Text between tags, still escaped
+	startTagToken                  // <name attrs> or <name attrs/>
+	endTagToken                    // </name>
+)
+
+// token is one piece of an HTML document. Its strings are slices of the document,
+// so tokenizing allocates nothing per token.
+type token struct {
+	kind  tokenKind
+	name  string // Tag name as written; compare with strings.EqualFold
+	attrs string // Raw attributes of a start tag
+	text  string // Text of a text token
+}
+
+// tokenizer splits an HTML document into tokens in one pass. It is not a full HTML5
+// tokenizer: comments, doctypes, and processing instructions are skipped, and the
+// contents of raw text elements such as <script> are returned as a single text token
+// after their start tag, so callers can skip them.
+type tokenizer struct {
+	s       string
+	pos     int
+	rawText string // Name of the raw text element just opened, if any
+}
+
+// next returns the next token, or false at the end of the document.
+func (z *tokenizer) next() (token, bool) {
+	if z.rawText != "" {
+		return z.rawTextToken(), true
+	}
+	for z.pos < len(z.s) {
+		i := strings.IndexByte(z.s[z.pos:], '<')
+		if i < 0 {
+			t := token{kind: textToken, text: z.s[z.pos:]}
+			z.pos = len(z.s)
+			return t, true
+		}
+		if i > 0 {
+			t := token{kind: textToken, text: z.s[z.pos : z.pos+i]}
+			z.pos += i
+			return t, true
+		}
+		if t, ok := z.tag(); ok {
+			return t, true
+		}
+	}
+	return token{}, false
+}
+
+// tag reads the markup at z.pos, which starts with '<'. It returns false for
+// comments and declarations, which it skips, and returns a lone '<' as text.
+func (z *tokenizer) tag() (token, bool) {
+	rest := z.s[z.pos+1:]
+	switch {
+	case strings.HasPrefix(rest, "!--"):
+		if end := strings.Index(rest[3:], "-->"); end >= 0 {
+			z.pos += 1 + 3 + end + 3
+		} else {
+			z.pos = len(z.s)
+		}
+		return token{}, false
+	case strings.HasPrefix(rest, "!") || strings.HasPrefix(rest, "?"):
+		z.skipPast('>')
+		return token{}, false
+	}
+
+	kind := startTagToken
+	if strings.HasPrefix(rest, "/") {
+		kind = endTagToken
+		rest = rest[1:]
+	}
+	n := 0
+	for n < len(rest) && isNameByte(rest[n]) {
+		n++
+	}
+	if n == 0 || !isLetter(rest[0]) {
+		// Not markup, as in "a < b"
+		z.pos++
+		return token{kind: textToken, text: "<"}, true
+	}
+	name := rest[:n]
+	start := len(z.s) - len(rest) + n
+	end := tagEnd(z.s, start)
+	t := token{kind: kind, name: name, attrs: strings.TrimSuffix(strings.TrimSpace(z.s[start:end]), "/")}
+	z.pos = min(end+1, len(z.s))
+	if kind == startTagToken && isRawText(name) {
+		z.rawText = name
+	}
+	return t, true
+}
+
+// rawTextToken returns the contents of the raw text element just opened, up to but
+// not including its end tag.
+func (z *tokenizer) rawTextToken() token {
+	name := z.rawText
+	z.rawText = ""
+	rest := z.s[z.pos:]
+	end := len(rest)
+	for i := 0; ; {
+		j := strings.Index(rest[i:], "</")
+		if j < 0 {
+			break
+		}
+		i += j
+		if after := rest[i+2:]; len(after) >= len(name) && strings.EqualFold(after[:len(name)], name) {
+			end = i
+			break
+		}
+		i += 2
+	}
+	z.pos += end
+	return token{kind: textToken, text: rest[:end]}
+}
+
+// skipPast moves z.pos past the next c, or to the end of the document.
+func (z *tokenizer) skipPast(c byte) {
+	if i := strings.IndexByte(z.s[z.pos:], c); i >= 0 {
+		z.pos += i + 1
+		return
+	}
+	z.pos = len(z.s)
+}
+
+// tagEnd returns the index of the '>' closing the tag whose attributes start at i,
+// ignoring any inside quoted attribute values, or len(s) if there is none.
+func tagEnd(s string, i int) int {
+	var quote byte
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return len(s)
+}
+
+// attr returns the value of the attribute key in a start tag's raw attributes.
+func attr(attrs, key string) string {
+	for attrs != "" {
+		attrs = strings.TrimLeft(attrs, " \t\r\n/")
+		n := 0
+		for n < len(attrs) && !strings.ContainsRune(" \t\r\n=/>", rune(attrs[n])) {
+			n++
+		}
+		if n == 0 {
+			return ""
+		}
+		name := attrs[:n]
+		attrs = strings.TrimLeft(attrs[n:], " \t\r\n")
+		var value string
+		if rest, ok := strings.CutPrefix(attrs, "="); ok {
+			rest = strings.TrimLeft(rest, " \t\r\n")
+			if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+				if end := strings.IndexByte(rest[1:], rest[0]); end >= 0 {
+					value, attrs = rest[1:1+end], rest[2+end:]
+				} else {
+					value, attrs = rest[1:], ""
+				}
+			} else {
+				end := strings.IndexAny(rest, " \t\r\n>")
+				if end < 0 {
+					end = len(rest)
+				}
+				value, attrs = rest[:end], rest[end:]
+			}
+		}
+		if strings.EqualFold(name, key) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isRawText reports whether name is an element whose contents are not markup.
+func isRawText(name string) bool {
+	return strings.EqualFold(name, "script") || strings.EqualFold(name, "style")
+}
+
+func isLetter(c byte) bool { return (c|0x20) >= 'a' && (c|0x20) <= 'z' }
+
+func isNameByte(c byte) bool { return isLetter(c) || (c >= '0' && c <= '9') || c == '-' || c == ':' }
@@ -0,0 +1,77 @@
+package htmlutil
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenizer(t *testing.T) {
+	z := tokenizer{s: `<!DOCTYPE html><a HREF="x>y" title='t'>hi</A><!-- skip --><br/>1 < 2<style>p > a {}</style>`}
+	var got []string
+	for {
+		tok, ok := z.next()
+		if !ok {
+			break
+		}
+		switch tok.kind {
+		case textToken:
+			got = append(got, "text:"+tok.text)
+		case startTagToken:
+			got = append(got, "start:"+tok.name+"["+tok.attrs+"]")
+		case endTagToken:
+			got = append(got, "end:"+tok.name)
+		}
+	}
+	want := []string{
+		`start:a[HREF="x>y" title='t']`, "text:hi", "end:A", "start:br[]", "text:1 ", "text:<", "text: 2",
+		"start:style[]", "text:p > a {}", "end:style",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("tokens =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestAttr(t *testing.T) {
+	tests := []struct {
+		attrs string
+		want  string
+	}{
+		{`href="https://example.com"`, "https://example.com"},
+		{`class=link HREF='/a b'`, "/a b"},
+		{`data-href="no" href=/bare rel=me`, "/bare"},
+		{`disabled href = "spaced"`, "spaced"},
+		{`title="x"`, ""},
+	}
+	for _, tt := range tests {
+		if got := attr(tt.attrs, "href"); got != tt.want {
+			t.Errorf("attr(%q, href) = %q, want %q", tt.attrs, got, tt.want)
+		}
+	}
+}
+
+// largePage returns about 800 KB of HTML shaped like a long README or blog post:
+// headings, paragraphs with links and emphasis, lists, and inline scripts.
+func largePage() string {
+	var b strings.Builder
+	b.WriteString("<html><head><title>Big page</title><style>body{margin:0}</style></head><body>\n")
+	for i := range 4000 {
+		b.WriteString(`<h2>Section</h2><p>Some <b>bold</b> and <em>italic</em> text with a <a href="https://example.com/post">link</a> &amp; more.</p>`)
+		b.WriteString("<ul><li>one</li><li>two</li></ul><div class=\"note\"><span>note</span><br/></div>\n")
+		if i%100 == 0 {
+			b.WriteString("<script>var x = '<p>not text</p>';</script>\n")
+		}
+	}
+	b.WriteString("</body></html>")
+	return b.String()
+}
+
+// BenchmarkToMarkdown measures converting a large page. Before ToMarkdown used the
+// tokenizer it took about 146ms, 40 MB, and 16,000 allocations per page here.
+func BenchmarkToMarkdown(b *testing.B) {
+	page := largePage()
+	b.SetBytes(int64(len(page)))
+	b.ReportAllocs()
+	for b.Loop() {
+		ToMarkdown(page)
+	}
+}
@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// har is the part of the HAR 1.2 format read here.
+type har struct {
+	Log struct {
+		Entries []struct {
+			Request struct {
+				URL string `json:"url"`
+			} `json:"request"`
+			Response struct {
+				Content struct {
+					MimeType string `json:"mimeType"`
+					Text     string `json:"text"`
+					Encoding string `json:"encoding"`
+				} `json:"content"`
+				Status int `json:"status"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+// ReadHAR reads the responses of a HAR file, as exported from a browser's network
+// panel. Entries whose bodies were not saved are skipped.
+func ReadHAR(r io.Reader) ([]Record, error) {
+	var h har
+	if err := json.NewDecoder(r).Decode(&h); err != nil {
+		return nil, fmt.Errorf("har: %w", err)
+	}
+	var records []Record
+	for _, e := range h.Log.Entries {
+		content := e.Response.Content
+		if content.Text == "" {
+			continue
+		}
+		body := []byte(content.Text)
+		if content.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(content.Text)
+			if err != nil {
+				continue
+			}
+			body = decoded
+		}
+		if len(body) > MaxRecordSize {
+			continue
+		}
+		records = append(records, Record{
+			URL:         e.Request.URL,
+			ContentType: mediaType(content.MimeType),
+			Body:        body,
+			Status:      e.Response.Status,
+		})
+	}
+	return records, nil
+}
+
+// isVoyager reports whether rec is a response of LinkedIn's internal API, which
+// logged-in pages load their profile data from.
+func isVoyager(rec *Record) bool {
+	u, err := url.Parse(rec.URL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	return (host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com")) &&
+		strings.HasPrefix(u.Path, "/voyager/api/") && strings.Contains(rec.ContentType, "json")
+}
@@ -0,0 +1,133 @@
+// Package ingest reads previously captured pages from WARC archives, such as those
+// written by archive crawls, MHTML files, such as those saved by a browser's "Save
+// page as", and HAR exports from a browser's network panel, and extracts profiles
+// from them without making any requests.
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// MaxRecordSize is the largest record body read into memory; larger records, which
+// are never profile pages, are skipped.
+const MaxRecordSize = 32 << 20
+
+// Record is one captured resource.
+type Record struct {
+	URL         string
+	ContentType string // Media type without parameters, e.g. "text/html"
+	Body        []byte
+	Status      int // HTTP status of the capture, 200 for MHTML parts and WARC resources
+}
+
+// IsHTML reports whether the record is a page, as opposed to an image or script.
+func (r *Record) IsHTML() bool {
+	return r.ContentType == "text/html" || r.ContentType == "application/xhtml+xml"
+}
+
+// Read reads the records of a WARC archive, optionally gzipped, an MHTML file, or a
+// HAR file, detecting the format from its first bytes.
+func Read(r io.Reader) ([]Record, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(head, []byte{0x1f, 0x8b}) || bytes.HasPrefix(head, []byte("WARC/")):
+		return ReadWARC(br)
+	case bytes.HasPrefix(bytes.TrimLeft(head, " \t\r\n\xef\xbb\xbf"), []byte("{")):
+		return ReadHAR(br)
+	default:
+		return ReadMHTML(br)
+	}
+}
+
+// Profiles extracts a profile from every HTML record whose platform has an HTML parser,
+// as sociopath.ParseHTML does. When a URL was captured more than once, only the last
+// successful capture is used. LinkedIn Voyager API responses, which HAR exports of
+// logged-in sessions contain, are merged into full LinkedIn profiles that replace
+// those parsed from the pages. Records that fail to parse are reported in the joined
+// error without stopping the others.
+func Profiles(ctx context.Context, records []Record, opts ...sociopath.Option) ([]*profile.Profile, error) {
+	latest := make(map[string]int)
+	var order []string
+	var voyager [][]byte
+	for i := range records {
+		rec := &records[i]
+		if rec.Status < 200 || rec.Status > 299 || rec.URL == "" {
+			continue
+		}
+		if isVoyager(rec) {
+			voyager = append(voyager, rec.Body)
+			continue
+		}
+		if !rec.IsHTML() {
+			continue
+		}
+		if _, ok := latest[rec.URL]; !ok {
+			order = append(order, rec.URL)
+		}
+		latest[rec.URL] = i
+	}
+
+	var profiles []*profile.Profile
+	var errs []error
+	full := make(map[string]bool)
+	if len(voyager) > 0 {
+		ps, err := sociopath.ParseVoyager(ctx, voyager, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("linkedin voyager responses: %w", err))
+		}
+		for _, p := range ps {
+			full[strings.ToLower(p.Username)] = true
+		}
+		profiles = append(profiles, ps...)
+	}
+	for _, u := range order {
+		rec := &records[latest[u]]
+		p, err := sociopath.ParseHTML(ctx, "", rec.Body, rec.URL, opts...)
+		if errors.Is(err, sociopath.ErrNoParser) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rec.URL, err))
+			continue
+		}
+		if p.Platform == "linkedin" && full[strings.ToLower(p.Username)] {
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, errors.Join(errs...)
+}
+
+// mediaType returns the media type of a Content-Type header, lowercased and without
+// parameters.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mt, _, _ = strings.Cut(contentType, ";")
+	}
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
+// readLimited reads all of r, or returns nil and no error if r is larger than
+// MaxRecordSize.
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxRecordSize+1))
+	if err != nil || len(data) > MaxRecordSize {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,168 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func warcRecord(kind, uri, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: %s\r\nWARC-Record-ID: <urn:uuid:1>\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		kind, uri, len(block), block)
+}
+
+func httpResponse(status, contentType, body string) string {
+	return fmt.Sprintf("HTTP/1.1 %s\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s", status, contentType, len(body), body)
+}
+
+const githubPage = `<span class="p-name vcard-fullname" itemprop="name">%s</span>`
+
+func testWARC() string {
+	return warcRecord("request", "https://github.com/jane", "GET /jane HTTP/1.1\r\nHost: github.com\r\n\r\n") +
+		warcRecord("response", "https://github.com/jane", httpResponse("200 OK", "text/html; charset=utf-8", fmt.Sprintf(githubPage, "Jane Old"))) +
+		warcRecord("response", "<https://github.com/jane>", httpResponse("200 OK", "text/html", fmt.Sprintf(githubPage, "Jane Doe"))) +
+		warcRecord("response", "https://github.com/ghost", httpResponse("404 Not Found", "text/html", "Not Found")) +
+		warcRecord("resource", "https://github.com/logo.png", "\x89PNG") +
+		warcRecord("metadata", "https://github.com/jane", "outlinks: none")
+}
+
+func TestReadWARC(t *testing.T) {
+	records, err := Read(strings.NewReader(testWARC()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("Read() returned %d records, want 4: %+v", len(records), records)
+	}
+	if r := records[1]; r.URL != "https://github.com/jane" || r.ContentType != "text/html" || r.Status != 200 || !strings.Contains(string(r.Body), "Jane Doe") {
+		t.Errorf("records[1] = %+v", r)
+	}
+	if records[2].Status != 404 || records[3].ContentType != "" || records[3].IsHTML() {
+		t.Errorf("records[2:] = %+v", records[2:])
+	}
+
+	// Several gzip members, as .warc.gz files are written
+	var buf bytes.Buffer
+	for _, rec := range strings.SplitAfter(testWARC(), "\r\n\r\nWARC/") {
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write([]byte(rec)); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gzipped, err := Read(&buf)
+	if err != nil || len(gzipped) != len(records) {
+		t.Errorf("Read(gzip) = %d records, %v; want %d", len(gzipped), err, len(records))
+	}
+
+	if _, err := ReadWARC(strings.NewReader("WARC/1.0\r\nWARC-Type: response\r\n\r\n")); err == nil {
+		t.Error("ReadWARC() accepted a record without Content-Length")
+	}
+}
+
+const testMHTML = "From: <Saved by Blink>\r\n" +
+	"Snapshot-Content-Location: https://github.com/jane\r\n" +
+	"Subject: Jane Doe\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/related;\r\n\ttype=\"text/html\";\r\n\tboundary=\"----MultipartBoundary--abc\"\r\n" +
+	"\r\n" +
+	"------MultipartBoundary--abc\r\n" +
+	"Content-Type: text/html\r\n" +
+	"Content-ID: <frame-1@mhtml.blink>\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"Content-Location: https://github.com/jane\r\n" +
+	"\r\n" +
+	"<span class=3D\"p-name vcard-fullname\" itemprop=3D\"name\">Jane Doe</span>\r\n" +
+	"------MultipartBoundary--abc\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"Content-Location: https://avatars.githubusercontent.com/u/1\r\n" +
+	"\r\n" +
+	"iVBORw==\r\n" +
+	"------MultipartBoundary--abc--\r\n"
+
+func TestReadMHTML(t *testing.T) {
+	records, err := Read(strings.NewReader(testMHTML))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Read() returned %d records, want 2", len(records))
+	}
+	if r := records[0]; r.URL != "https://github.com/jane" || !r.IsHTML() || !strings.Contains(string(r.Body), `class="p-name`) {
+		t.Errorf("records[0] = %+v", r)
+	}
+	if r := records[1]; r.ContentType != "image/png" || string(r.Body) != "\x89PNG" {
+		t.Errorf("records[1] = %+v", r)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	records, err := Read(strings.NewReader(testWARC()))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	profiles, err := Profiles(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("Profiles() returned %d profiles, want 1 (404s and images skipped)", len(profiles))
+	}
+	if p := profiles[0]; p.Platform != "github" || p.Username != "jane" || p.Name != "Jane Doe" {
+		t.Errorf("profile = %+v, want the later capture", p)
+	}
+}
+
+func TestReadHARProfiles(t *testing.T) {
+	voyager := `{"data": {"*elements": ["urn:li:fsd_profile:ACoJane"]}, "included": [{"$type": "com.linkedin.voyager.dash.identity.profile.Profile", "entityUrn": "urn:li:fsd_profile:ACoJane", "firstName": "Jane", "lastName": "Doe", "publicIdentifier": "janedoe", "headline": "Staff Engineer"}]}`
+	page := `<meta property="og:title" content="Jane Doe - Engineer | LinkedIn">`
+	h := map[string]any{"log": map[string]any{"entries": []any{
+		map[string]any{
+			"request":  map[string]any{"url": "https://www.linkedin.com/in/janedoe/"},
+			"response": map[string]any{"status": 200, "content": map[string]any{"mimeType": "text/html; charset=utf-8", "text": page}},
+		},
+		map[string]any{
+			"request": map[string]any{"url": "https://www.linkedin.com/voyager/api/identity/dash/profiles?q=memberIdentity&memberIdentity=janedoe"},
+			"response": map[string]any{"status": 200, "content": map[string]any{
+				"mimeType": "application/vnd.linkedin.normalized+json+2.1",
+				"text":     base64.StdEncoding.EncodeToString([]byte(voyager)),
+				"encoding": "base64",
+			}},
+		},
+		map[string]any{
+			"request":  map[string]any{"url": "https://static.licdn.com/app.js"},
+			"response": map[string]any{"status": 200, "content": map[string]any{"mimeType": "application/javascript"}},
+		},
+	}}}
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := Read(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(records) != 2 || records[1].ContentType != "application/vnd.linkedin.normalized+json+2.1" || !isVoyager(&records[1]) {
+		t.Fatalf("Read() = %+v", records)
+	}
+
+	profiles, err := Profiles(context.Background(), records)
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("Profiles() returned %d profiles, want the Voyager profile replacing the page's", len(profiles))
+	}
+	if p := profiles[0]; p.Username != "janedoe" || !p.Authenticated || p.Fields["headline"] != "Staff Engineer" {
+		t.Errorf("profile = %+v", p)
+	}
+}
@@ -0,0 +1,75 @@
+package ingest
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// ReadMHTML reads the parts of an MHTML file. The first part is normally the saved
+// page itself, followed by its images, stylesheets, and frames.
+func ReadMHTML(r io.Reader) ([]Record, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("mhtml: %w", err)
+	}
+	mt, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("mhtml: %w", err)
+	}
+	if !strings.HasPrefix(mt, "multipart/") {
+		// A single-resource file is the page itself
+		data, err := readLimited(decodeTransfer(msg.Body, msg.Header.Get("Content-Transfer-Encoding")))
+		if err != nil {
+			return nil, fmt.Errorf("mhtml: %w", err)
+		}
+		return []Record{{URL: msg.Header.Get("Snapshot-Content-Location"), ContentType: mt, Body: data, Status: http.StatusOK}}, nil
+	}
+
+	// Browsers only set the page URL on the first part, and on the message as a whole
+	pageURL := msg.Header.Get("Snapshot-Content-Location")
+	var records []Record
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		// NextPart decodes quoted-printable parts itself
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, fmt.Errorf("mhtml: %w", err)
+		}
+		data, err := readLimited(decodeTransfer(part, part.Header.Get("Content-Transfer-Encoding")))
+		if err != nil {
+			return records, fmt.Errorf("mhtml: %w", err)
+		}
+		if data == nil {
+			continue
+		}
+		u := part.Header.Get("Content-Location")
+		if u == "" && len(records) == 0 {
+			u = pageURL
+		}
+		records = append(records, Record{
+			URL:         u,
+			ContentType: mediaType(part.Header.Get("Content-Type")),
+			Body:        data,
+			Status:      http.StatusOK,
+		})
+	}
+}
+
+// decodeTransfer undoes a base64 Content-Transfer-Encoding; other encodings are
+// either decoded by the multipart reader or need no decoding.
+func decodeTransfer(r io.Reader, encoding string) io.Reader {
+	if strings.EqualFold(strings.TrimSpace(encoding), "base64") {
+		return base64.NewDecoder(base64.StdEncoding, r)
+	}
+	return r
+}
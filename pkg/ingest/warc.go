@@ -0,0 +1,130 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+)
+
+// ReadWARC reads the response and resource records of a WARC archive. Archives
+// compressed as a whole or record by record (.warc.gz) are decompressed. Request,
+// metadata, and other records are skipped, as are responses whose HTTP message
+// cannot be parsed.
+func ReadWARC(r io.Reader) ([]Record, error) {
+	br := bufio.NewReader(r)
+	if head, err := br.Peek(2); err == nil && head[0] == 0x1f && head[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("warc: %w", err)
+		}
+		defer gz.Close() //nolint:errcheck // read-only
+		br = bufio.NewReader(gz)
+	}
+
+	var records []Record
+	tp := textproto.NewReader(br)
+	for {
+		version, err := readVersion(br)
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		header, err := tp.ReadMIMEHeader()
+		if err != nil {
+			return records, fmt.Errorf("warc: %s record header: %w", version, err)
+		}
+		length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+		if err != nil || length < 0 {
+			return records, fmt.Errorf("warc: record %s has no valid Content-Length", header.Get("WARC-Record-ID"))
+		}
+		if length > MaxRecordSize {
+			if _, err := io.CopyN(io.Discard, br, length); err != nil {
+				return records, fmt.Errorf("warc: %w", err)
+			}
+			continue
+		}
+		block := make([]byte, length)
+		if _, err := io.ReadFull(br, block); err != nil {
+			return records, fmt.Errorf("warc: truncated record %s: %w", header.Get("WARC-Record-ID"), err)
+		}
+
+		// WARC 0.x wrote target URIs in angle brackets
+		uri := strings.Trim(header.Get("WARC-Target-URI"), "<>")
+		switch header.Get("WARC-Type") {
+		case "response":
+			if rec, ok := parseHTTPResponse(uri, block); ok {
+				records = append(records, rec)
+			}
+		case "resource":
+			records = append(records, Record{
+				URL:         uri,
+				ContentType: mediaType(header.Get("Content-Type")),
+				Body:        block,
+				Status:      http.StatusOK,
+			})
+		default:
+		}
+	}
+}
+
+// readVersion skips the blank lines between records and returns the next record's
+// version line.
+func readVersion(br *bufio.Reader) (string, error) {
+	for {
+		line, err := br.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line != "" {
+			if !strings.HasPrefix(line, "WARC/") {
+				return "", fmt.Errorf("warc: expected a record, got %q", truncate(line, 40))
+			}
+			return line, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+// parseHTTPResponse parses the HTTP response stored in a response record.
+func parseHTTPResponse(uri string, block []byte) (Record, bool) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(block)), nil)
+	if err != nil {
+		return Record{}, false
+	}
+	defer resp.Body.Close() //nolint:errcheck // in memory
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return Record{}, false
+		}
+		body = gz
+	}
+	data, err := readLimited(body)
+	if err != nil || data == nil {
+		return Record{}, false
+	}
+	return Record{
+		URL:         uri,
+		ContentType: mediaType(resp.Header.Get("Content-Type")),
+		Body:        data,
+		Status:      resp.StatusCode,
+	}, true
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,167 @@
+// Package itchio fetches itch.io creator pages.
+package itchio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "itchio"
+
+// Creator pages live on their own subdomain; game pages add a path and are not profiles.
+var usernamePattern = regexp.MustCompile(`(?i)^(?:https?://)?([\w-]+)\.itch\.io/?(?:[?#]|$)`)
+
+// nonProfiles are itch.io subdomains that do not belong to creators.
+var nonProfiles = map[string]bool{
+	"www": true, "static": true, "img": true, "api": true, "blog": true, "status": true,
+}
+
+// Match returns true if the URL is an itch.io creator page.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because creator pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles itch.io requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates an itch.io client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves an itch.io creator profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://" + username + ".itch.io/"
+	c.logger.InfoContext(ctx, "fetching itch.io profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from an itch.io creator page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://"+username+".itch.io/", username)
+}
+
+var (
+	// Creator pages are titled "Jane Doe - itch.io".
+	titleSuffix  = regexp.MustCompile(`(?i)\s*-\s*itch\.io\s*$`)
+	aboutPattern = regexp.MustCompile(`(?is)<div[^>]+class="[^"]*user_formatted[^"]*"[^>]*>(.*?)</div>`)
+	// Each game cell links its title with class "title game_link"; attribute order varies.
+	gamePattern = regexp.MustCompile(`(?is)<a\s([^>]*\bclass="title game_link"[^>]*)>(.*?)</a>`)
+	hrefPattern = regexp.MustCompile(`\bhref="([^"]+)"`)
+	tagPattern  = regexp.MustCompile(`<[^>]+>`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	p.Name = strings.TrimSpace(titleSuffix.ReplaceAllString(htmlutil.Title(content), ""))
+	if p.Name == "" || strings.EqualFold(p.Name, "itch.io") || strings.Contains(strings.ToLower(p.Name), "page not found") {
+		return nil, errors.New("profile not found (no creator name)")
+	}
+
+	if m := aboutPattern.FindStringSubmatch(content); m != nil {
+		p.Bio = stripTags(m[1])
+	}
+	if p.Bio == "" {
+		p.Bio = htmlutil.Description(content)
+	}
+
+	for _, m := range gamePattern.FindAllStringSubmatch(content, -1) {
+		post := profile.Post{Type: profile.PostTypeGame, Title: stripTags(m[2])}
+		if h := hrefPattern.FindStringSubmatch(m[1]); h != nil {
+			post.URL = h[1]
+		}
+		p.Posts = append(p.Posts, post)
+	}
+	if len(p.Posts) > 0 {
+		p.Fields["games"] = fmt.Sprint(len(p.Posts))
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+func stripTags(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(s, " "))), " ")
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return strings.ToLower(m[1])
+}
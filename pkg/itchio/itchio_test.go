@@ -0,0 +1,70 @@
+package itchio
+
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://janedoe.itch.io", true},
+		{"https://janedoe.itch.io/", true},
+		{"https://Jane-Doe.itch.io/?ref=twitter", true},
+		{"janedoe.itch.io", true},
+		{"https://janedoe.itch.io/space-game", false},
+		{"https://itch.io/profile/janedoe", false},
+		{"https://www.itch.io/", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("itch.io should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><head><title>Jane Doe - itch.io</title>
+<meta name="description" content="Games by Jane Doe">
+</head><body>
+<div class="user_formatted"><p>Solo dev making <em>cozy</em> puzzle games.</p></div>
+<div class="game_cell"><a class="thumb_link game_link" href="https://janedoe.itch.io/tidepool"></a>
+<a data-action="game_grid" class="title game_link" href="https://janedoe.itch.io/tidepool">Tidepool</a></div>
+<div class="game_cell"><a href="https://janedoe.itch.io/moss" class="title game_link">Moss &amp; Stone</a></div>
+<a href="https://twitter.com/janedoe_games">Twitter</a>
+</body></html>`
+
+	p, err := parseProfile(page, "https://janedoe.itch.io/", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Bio != "Solo dev making cozy puzzle games." {
+		t.Errorf("Name = %q, Bio = %q", p.Name, p.Bio)
+	}
+	if len(p.Posts) != 2 {
+		t.Fatalf("Posts = %+v, want 2 games", p.Posts)
+	}
+	if p.Posts[0].Title != "Tidepool" || p.Posts[0].URL != "https://janedoe.itch.io/tidepool" || p.Posts[0].Type != profile.PostTypeGame {
+		t.Errorf("Posts[0] = %+v", p.Posts[0])
+	}
+	if p.Posts[1].Title != "Moss & Stone" || p.Fields["games"] != "2" {
+		t.Errorf("Posts[1] = %+v, games = %q", p.Posts[1], p.Fields["games"])
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://twitter.com/janedoe_games" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+
+	if _, err := parseProfile(`<title>Page not found - itch.io</title>`, "https://x.itch.io/", "x"); err == nil {
+		t.Error("parseProfile(not found) should fail")
+	}
+}
@@ -0,0 +1,156 @@
+// Package kofi fetches Ko-fi creator pages.
+package kofi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "kofi"
+
+var usernamePattern = regexp.MustCompile(`(?i)ko-fi\.com/([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are ko-fi.com paths that are not creator pages.
+var nonProfiles = map[string]bool{
+	"account": true, "home": true, "explore": true, "about": true, "gold": true, "shop": true,
+	"manage": true, "login": true, "signup": true, "blog": true, "s": true, "i": true, "post": true,
+	"album": true, "commissions": true, "summary": true, "feed": true, "legal": true,
+}
+
+// Match returns true if the URL is a Ko-fi creator page.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because creator pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles Ko-fi requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Ko-fi client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Ko-fi profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://ko-fi.com/" + username
+	c.logger.InfoContext(ctx, "fetching ko-fi profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a Ko-fi page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://ko-fi.com/"+username, username)
+}
+
+var (
+	// Ko-fi titles pages "Buy Jane Doe a Coffee. ko-fi.com/janedoe - Ko-fi ❤️ Where creators get support..."
+	buyPattern        = regexp.MustCompile(`(?i)^Buy (.+?) a (?:Coffee|Tea|Ko-fi)\b`)
+	aboutPattern      = regexp.MustCompile(`(?is)<div[^>]+class="[^"]*kfds-c-para-control[^"]*"[^>]*>(.*?)</div>`)
+	supportersPattern = regexp.MustCompile(`(?i)([\d,.]+[KkMm]?)\s+supporters?\b`)
+	tagPattern        = regexp.MustCompile(`<[^>]+>`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	if m := buyPattern.FindStringSubmatch(htmlutil.Title(content)); m != nil {
+		p.Name = strings.TrimSpace(m[1])
+	}
+	if p.Name == "" {
+		return nil, errors.New("profile not found (no creator name)")
+	}
+
+	if m := aboutPattern.FindStringSubmatch(content); m != nil {
+		p.Bio = strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(m[1], " "))), " ")
+	}
+	if p.Bio == "" {
+		p.Bio = htmlutil.Description(content)
+	}
+	if m := supportersPattern.FindStringSubmatch(content); m != nil {
+		p.Fields["supporters"] = m[1]
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
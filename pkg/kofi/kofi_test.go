@@ -0,0 +1,59 @@
+package kofi
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://ko-fi.com/janedoe", true},
+		{"https://ko-fi.com/janedoe/", true},
+		{"https://ko-fi.com/explore", false},
+		{"https://ko-fi.com/s/abc123", false},
+		{"https://ko-fi.com/", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Ko-fi should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><head><title>Buy Jane Doe a Coffee. ko-fi.com/janedoe - Ko-fi ❤️ Where creators get support from fans</title>
+<meta name="description" content="Support Jane Doe on Ko-fi">
+</head><body>
+<div class="kfds-c-para-control kfds-c-para-control--profile"><p>I make <b>free</b> Go libraries &amp; tutorials.</p></div>
+<span>1,204 supporters</span>
+<a href="https://github.com/janedoe">GitHub</a>
+</body></html>`
+
+	p, err := parseProfile(page, "https://ko-fi.com/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" {
+		t.Errorf("Name = %q", p.Name)
+	}
+	if p.Bio != "I make free Go libraries & tutorials." {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Fields["supporters"] != "1,204" {
+		t.Errorf("supporters = %q", p.Fields["supporters"])
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://github.com/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+
+	if _, err := parseProfile(`<title>Ko-fi</title>`, "https://ko-fi.com/x", "x"); err == nil {
+		t.Error("parseProfile(generic page) should fail")
+	}
+}
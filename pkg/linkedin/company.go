@@ -0,0 +1,243 @@
+package linkedin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// maxCompanyCandidates caps how many typeahead hits are checked against the domain.
+const maxCompanyCandidates = 5
+
+// employeePageSize is how many people search results Voyager returns per page.
+const employeePageSize = 10
+
+// companyURLPattern extracts the universal name from a company page URL.
+var companyURLPattern = regexp.MustCompile(`(?i)linkedin\.com/company/([^/?#]+)`)
+
+// Company is a LinkedIn company page.
+type Company struct {
+	Name          string `json:"name"`
+	UniversalName string `json:"universal_name"` // slug in linkedin.com/company/<universal_name>
+	URL           string `json:"url"`
+	Website       string `json:"website,omitempty"`
+	ID            string `json:"id,omitempty"` // numeric company ID used by search filters
+}
+
+// CompanyName returns the universal name in a linkedin.com/company/<name> URL, or "".
+func CompanyName(urlStr string) string {
+	if m := companyURLPattern.FindStringSubmatch(urlStr); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// Company fetches the company page with the given universal name. Requires session cookies.
+func (c *Client) Company(ctx context.Context, universalName string) (*Company, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("%w: company lookup uses the voyager API", profile.ErrAuthRequired)
+	}
+	return c.fetchCompany(ctx, universalName)
+}
+
+// Employees searches for people who list company as their current employer and
+// returns up to limit profile URLs, in search order. Requires session cookies.
+func (c *Client) Employees(ctx context.Context, company *Company, limit int) ([]string, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("%w: people search uses the voyager API", profile.ErrAuthRequired)
+	}
+	if company.ID == "" {
+		return nil, fmt.Errorf("company %q has no id", company.UniversalName)
+	}
+
+	var urls []string
+	seen := make(map[string]bool)
+	for start := 0; len(urls) < limit; start += employeePageSize {
+		query := fmt.Sprintf("(flagshipSearchIntent:SEARCH_SRP,queryParameters:(currentCompany:List(%s),resultType:List(PEOPLE)))", company.ID)
+		apiURL := fmt.Sprintf("%s/search/dash/clusters?decorationId=com.linkedin.voyager.dash.deco.search.SearchClusterCollection-175"+
+			"&origin=FACETED_SEARCH&q=all&query=%s&start=%d&count=%d", voyagerBaseURL, query, start, employeePageSize)
+
+		body, err := c.voyagerGet(ctx, apiURL)
+		if err != nil {
+			if len(urls) > 0 {
+				c.logger.WarnContext(ctx, "linkedin employee search stopped early", "company", company.UniversalName, "error", err)
+				break
+			}
+			return nil, fmt.Errorf("employee search failed: %w", err)
+		}
+
+		found := parseEmployeeSearch(body)
+		added := 0
+		for _, u := range found {
+			if !seen[u] && len(urls) < limit {
+				seen[u] = true
+				urls = append(urls, u)
+				added++
+			}
+		}
+		if added == 0 || len(found) < employeePageSize {
+			break
+		}
+	}
+	return urls, nil
+}
+
+// parseEmployeeSearch returns the member profile URLs in a people search response.
+// Results that LinkedIn hides ("LinkedIn Member") link to search pages and are skipped.
+func parseEmployeeSearch(data []byte) []string {
+	var resp struct {
+		Included []struct {
+			NavigationURL string `json:"navigationUrl"`
+		} `json:"included"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil
+	}
+
+	var urls []string
+	for _, e := range resp.Included {
+		if id := extractPublicID(e.NavigationURL); id != "" {
+			urls = append(urls, "https://www.linkedin.com/in/"+id+"/")
+		}
+	}
+	return urls
+}
+
+// ResolveCompany finds the LinkedIn company page whose website is on domain
+// (e.g. "acme.com", "https://www.acme.com/about", or "jane@acme.com").
+// Candidates come from the Voyager typeahead; only a company whose listed website
+// matches the domain is returned. Requires session cookies.
+func (c *Client) ResolveCompany(ctx context.Context, domain string) (*Company, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("%w: company lookup uses the voyager API", profile.ErrAuthRequired)
+	}
+
+	domain = normalizeDomain(domain)
+	keyword, _, _ := strings.Cut(domain, ".")
+	if keyword == "" {
+		return nil, fmt.Errorf("invalid company domain: %q", domain)
+	}
+
+	candidates, err := c.companyTypeahead(ctx, keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range candidates {
+		if i == maxCompanyCandidates {
+			break
+		}
+		company, err := c.fetchCompany(ctx, name)
+		if err != nil {
+			c.logger.DebugContext(ctx, "linkedin company lookup failed", "universal_name", name, "error", err)
+			continue
+		}
+		if normalizeDomain(company.Website) == domain {
+			return company, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no linkedin company for %s", profile.ErrProfileNotFound, domain)
+}
+
+// companyTypeahead returns the universal names of companies matching keyword, best match first.
+func (c *Client) companyTypeahead(ctx context.Context, keyword string) ([]string, error) {
+	apiURL := fmt.Sprintf("%s/typeahead/hitsV2?keywords=%s&origin=OTHER&q=type&type=COMPANY",
+		voyagerBaseURL, url.QueryEscape(keyword))
+
+	body, err := c.voyagerGet(ctx, apiURL)
+	if err != nil {
+		return nil, fmt.Errorf("company typeahead failed: %w", err)
+	}
+
+	var resp struct {
+		Elements []struct {
+			HitInfo struct {
+				Company struct {
+					Company struct {
+						UniversalName string `json:"universalName"`
+					} `json:"company"`
+				} `json:"com.linkedin.voyager.typeahead.TypeaheadCompany"`
+			} `json:"hitInfo"`
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing typeahead response: %w", err)
+	}
+
+	var names []string
+	for _, e := range resp.Elements {
+		if name := e.HitInfo.Company.Company.UniversalName; name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// fetchCompany retrieves a company page by universal name.
+func (c *Client) fetchCompany(ctx context.Context, universalName string) (*Company, error) {
+	apiURL := fmt.Sprintf("%s/organization/companies?q=universalName&universalName=%s",
+		voyagerBaseURL, url.QueryEscape(universalName))
+
+	body, err := c.voyagerGet(ctx, apiURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Elements []struct {
+			Name           string `json:"name"`
+			UniversalName  string `json:"universalName"`
+			CompanyPageURL string `json:"companyPageUrl"`
+			EntityURN      string `json:"entityUrn"` // "urn:li:fs_normalized_company:1441"
+		} `json:"elements"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing company response: %w", err)
+	}
+	if len(resp.Elements) == 0 {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	e := resp.Elements[0]
+	return &Company{
+		Name:          e.Name,
+		UniversalName: e.UniversalName,
+		URL:           "https://www.linkedin.com/company/" + e.UniversalName + "/",
+		Website:       e.CompanyPageURL,
+		ID:            e.EntityURN[strings.LastIndex(e.EntityURN, ":")+1:],
+	}, nil
+}
+
+// voyagerGet performs a plain-JSON Voyager request with the session cookies.
+func (c *Client) voyagerGet(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	c.setVoyagerHeaders(req)
+	req.Header.Set("Accept", "application/json")
+
+	return cache.FetchURL(ctx, c.cache, c.authClient, req, c.logger)
+}
+
+// normalizeDomain reduces a URL, email address, or host to a bare lowercase domain
+// without "www.", so "https://www.Acme.com/about" and "jane@acme.com" both yield "acme.com".
+func normalizeDomain(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if _, after, ok := strings.Cut(s, "@"); ok && !strings.Contains(s, "/") {
+		s = after
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "https://"), "http://")
+	if i := strings.IndexAny(s, "/?#:"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(s, "www."), ".")
+}
@@ -0,0 +1,168 @@
+package linkedin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"acme.com", "acme.com"},
+		{"https://www.Acme.com/about", "acme.com"},
+		{"http://acme.com:8080", "acme.com"},
+		{"jane@acme.com", "acme.com"},
+		{"www.acme.co.uk.", "acme.co.uk"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeDomain(tt.in); got != tt.want {
+			t.Errorf("normalizeDomain(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResolveCompany(t *testing.T) {
+	ctx := context.Background()
+	client, err := New(ctx,
+		WithLogger(slog.New(slog.DiscardHandler)),
+		WithCookies(map[string]string{"li_at": "token", "JSESSIONID": "ajax:123"}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/typeahead/hitsV2"):
+			_, _ = w.Write([]byte(`{"elements": [
+				{"hitInfo": {"com.linkedin.voyager.typeahead.TypeaheadCompany": {"company": {"universalName": "acme-records"}}}},
+				{"hitInfo": {"com.linkedin.voyager.typeahead.TypeaheadCompany": {"company": {"universalName": "acme-corp"}}}}
+			]}`))
+		case r.URL.Query().Get("universalName") == "acme-records":
+			_, _ = w.Write([]byte(`{"elements": [{"name": "Acme Records", "universalName": "acme-records", "companyPageUrl": "https://acmerecords.example"}]}`))
+		case r.URL.Query().Get("universalName") == "acme-corp":
+			_, _ = w.Write([]byte(`{"elements": [{"name": "Acme Corp", "universalName": "acme-corp", "companyPageUrl": "https://www.acme.com/"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client.authClient.Transport = &mockTransport{mockURL: server.URL}
+
+	company, err := client.ResolveCompany(ctx, "jane@acme.com")
+	if err != nil {
+		t.Fatalf("ResolveCompany() error = %v", err)
+	}
+	if company.Name != "Acme Corp" {
+		t.Errorf("Name = %q, want %q", company.Name, "Acme Corp")
+	}
+	if company.URL != "https://www.linkedin.com/company/acme-corp/" {
+		t.Errorf("URL = %q", company.URL)
+	}
+
+	if _, err := client.ResolveCompany(ctx, "acme.io"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("ResolveCompany(acme.io) error = %v, want ErrProfileNotFound", err)
+	}
+}
+
+func TestResolveCompanyRequiresAuth(t *testing.T) {
+	t.Setenv("LINKEDIN_LI_AT", "")
+	t.Setenv("LINKEDIN_JSESSIONID", "")
+	client, err := New(context.Background(), WithLogger(slog.New(slog.DiscardHandler)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := client.ResolveCompany(context.Background(), "acme.com"); !errors.Is(err, profile.ErrAuthRequired) {
+		t.Errorf("error = %v, want ErrAuthRequired", err)
+	}
+}
+
+func TestCompanyName(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.linkedin.com/company/Acme-Corp/", "acme-corp"},
+		{"linkedin.com/company/acme?trk=foo", "acme"},
+		{"https://www.linkedin.com/in/jane/", ""},
+		{"https://acme.com", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CompanyName(tt.url); got != tt.want {
+			t.Errorf("CompanyName(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestEmployees(t *testing.T) {
+	ctx := context.Background()
+	client, err := New(ctx,
+		WithLogger(slog.New(slog.DiscardHandler)),
+		WithCookies(map[string]string{"li_at": "token", "JSESSIONID": "ajax:123"}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("universalName") == "acme-corp":
+			_, _ = w.Write([]byte(`{"elements": [{"name": "Acme Corp", "universalName": "acme-corp",
+				"entityUrn": "urn:li:fs_normalized_company:1441"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/search/dash/clusters"):
+			if !strings.Contains(r.URL.RawQuery, "currentCompany:List(1441)") {
+				t.Errorf("search query = %q, want company filter", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"included": [
+				{"navigationUrl": "https://www.linkedin.com/in/jane-doe?miniProfileUrn=x"},
+				{"navigationUrl": "https://www.linkedin.com/search/results/people/?keywords=LinkedIn%20Member"},
+				{"navigationUrl": "https://www.linkedin.com/in/john-roe"},
+				{"navigationUrl": "https://www.linkedin.com/in/jane-doe"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client.authClient.Transport = &mockTransport{mockURL: server.URL}
+
+	company, err := client.Company(ctx, "acme-corp")
+	if err != nil {
+		t.Fatalf("Company() error = %v", err)
+	}
+	if company.ID != "1441" {
+		t.Errorf("ID = %q, want %q", company.ID, "1441")
+	}
+
+	urls, err := client.Employees(ctx, company, 10)
+	if err != nil {
+		t.Fatalf("Employees() error = %v", err)
+	}
+	want := []string{"https://www.linkedin.com/in/jane-doe/", "https://www.linkedin.com/in/john-roe/"}
+	if strings.Join(urls, " ") != strings.Join(want, " ") {
+		t.Errorf("Employees() = %v, want %v", urls, want)
+	}
+}
+
+func TestEmployeesRequiresAuth(t *testing.T) {
+	t.Setenv("LINKEDIN_LI_AT", "")
+	t.Setenv("LINKEDIN_JSESSIONID", "")
+	client, err := New(context.Background(), WithLogger(slog.New(slog.DiscardHandler)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	company := &Company{UniversalName: "acme-corp", ID: "1441"}
+	if _, err := client.Employees(context.Background(), company, 10); !errors.Is(err, profile.ErrAuthRequired) {
+		t.Errorf("error = %v, want ErrAuthRequired", err)
+	}
+}
@@ -1,32 +1,71 @@
 // Package linkedin fetches LinkedIn user profile data.
-// NOTE: LinkedIn authentication is currently broken due to their anti-scraping measures.
-// This package returns minimal profiles with just the URL and username for manual verification.
+// NOTE: LinkedIn authentication is best-effort; their anti-scraping measures frequently
+// invalidate sessions. With session cookies, profiles come from the Voyager API used by the
+// web app. Without a working session, this package falls back to the public profile page
+// (and, optionally, search-engine cache snapshots of it), which only exposes meta tag data.
+// If that fails too, it returns a minimal profile with just the URL and username.
 package linkedin
 
 import (
 	"context"
+	"fmt"
+	"html"
 	"log/slog"
+	"net/http"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
+	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
 const platform = "linkedin"
 
-// Match returns true if the URL is a LinkedIn profile URL.
+// defaultLocale is the profile language requested when WithLocale is not given.
+const defaultLocale = "en_US"
+
+// Sources for unauthenticated profile data, recorded in Fields["source"].
+const (
+	sourcePublicProfile = "public_profile"
+	sourceBingCache     = "bing_cache"
+	sourceGoogleCache   = "google_cache"
+)
+
+// Match returns true if the URL is a LinkedIn profile URL or member profile URN.
+// URNs count on their own or in the path of a linkedin.com URL, not elsewhere.
 func Match(urlStr string) bool {
-	return strings.Contains(strings.ToLower(urlStr), "linkedin.com/in/")
+	if bareURNPattern.MatchString(urlStr) {
+		return true
+	}
+	if !strings.Contains(urlStr, "://") {
+		urlStr = "https://" + urlStr
+	}
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return false
+	}
+	return strings.HasPrefix(strings.ToLower(parsed.Path), "/in/") || profileURNPattern.MatchString(parsed.Path)
 }
 
-// AuthRequired returns true because LinkedIn requires authentication.
-// NOTE: Auth is currently broken, but we keep this true to indicate the limitation.
+// AuthRequired returns true because LinkedIn requires authentication for full profiles.
 func AuthRequired() bool { return true }
 
 // Client handles LinkedIn requests.
 type Client struct {
-	logger *slog.Logger
+	httpClient   *http.Client
+	authClient   *http.Client // nil without session cookies
+	cache        cache.HTTPCache
+	logger       *slog.Logger
+	csrfToken    string
+	locale       string
+	searchCaches bool
 }
 
 // Option configures a Client.
@@ -36,20 +75,30 @@ type config struct {
 	cookies        map[string]string
 	cache          cache.HTTPCache
 	logger         *slog.Logger
+	proxy          *url.URL
+	locale         string
 	browserCookies bool
+	noCookies      bool
+	searchCaches   bool
 }
 
-// WithCookies sets explicit cookie values (currently unused - auth is broken).
+// WithCookies sets explicit cookie values (li_at and JSESSIONID are required).
 func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
-// WithHTTPCache sets the HTTP cache (currently unused - auth is broken).
+// WithoutCookies makes the client anonymous: it reads no cookies from WithCookies,
+// the environment, or browsers.
+func WithoutCookies() Option {
+	return func(c *config) { c.noCookies = true }
+}
+
+// WithHTTPCache sets the HTTP cache.
 func WithHTTPCache(httpCache cache.HTTPCache) Option {
 	return func(c *config) { c.cache = httpCache }
 }
 
-// WithBrowserCookies enables reading cookies from browser stores (currently unused - auth is broken).
+// WithBrowserCookies enables reading cookies from browser stores.
 func WithBrowserCookies() Option {
 	return func(c *config) { c.browserCookies = true }
 }
@@ -59,54 +108,424 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithSearchEngineCache enables falling back to Bing and Google cache snapshots
+// when LinkedIn refuses to serve the public profile page.
+func WithSearchEngineCache() Option {
+	return func(c *config) { c.searchCaches = true }
+}
+
+// WithProxy sends every LinkedIn request through the HTTP proxy at proxyURL, so a
+// session is always used from the same address.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *config) { c.proxy = proxyURL }
+}
+
+// WithLocale selects the profile language, as a LinkedIn locale such as "de_DE".
+// Multilingual profiles return the name, headline, and summary in this language
+// when the member provided it. Defaults to "en_US".
+func WithLocale(locale string) Option {
+	return func(c *config) { c.locale = locale }
+}
+
 // New creates a LinkedIn client.
-// NOTE: LinkedIn authentication is currently broken. The client will return minimal profiles.
-func New(_ context.Context, opts ...Option) (*Client, error) {
-	cfg := &config{logger: slog.Default()}
+// Cookie sources: WithCookies > environment variables > browser.
+// Unlike other authenticated platforms, missing cookies are not an error:
+// the client falls back to public profile data.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default(), locale: defaultLocale}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	cfg.logger.Warn("linkedin auth is broken - will return minimal profiles only")
+	var transport http.RoundTripper // nil uses cache.SharedTransport
+	if cfg.proxy != nil {
+		t := cache.SharedTransport.Clone()
+		t.Proxy = http.ProxyURL(cfg.proxy)
+		transport = t
+	}
+
+	c := &Client{
+		httpClient:   &http.Client{Timeout: 3 * time.Second, Transport: transport},
+		cache:        cfg.cache,
+		logger:       cfg.logger,
+		locale:       normalizeLocale(cfg.locale),
+		searchCaches: cfg.searchCaches,
+	}
 
-	return &Client{
-		logger: cfg.logger,
-	}, nil
+	var sources []auth.Source
+	if !cfg.noCookies {
+		if len(cfg.cookies) > 0 {
+			sources = append(sources, auth.NewStaticSource(cfg.cookies))
+		}
+		sources = append(sources, auth.EnvSource{})
+		if cfg.browserCookies {
+			sources = append(sources, auth.NewBrowserSource(cfg.logger))
+		}
+	}
+
+	cookies, err := auth.ChainSources(ctx, platform, sources...)
+	if err != nil {
+		return nil, fmt.Errorf("cookie retrieval failed: %w", err)
+	}
+	if cookies["li_at"] == "" || cookies["JSESSIONID"] == "" {
+		cfg.logger.WarnContext(ctx, "linkedin session cookies unavailable - will return public profile data only",
+			"env", auth.EnvVarsForPlatform(platform))
+		return c, nil
+	}
+
+	jar, err := auth.NewCookieJar("linkedin.com", cookies)
+	if err != nil {
+		return nil, fmt.Errorf("cookie jar creation failed: %w", err)
+	}
+	c.authClient = &http.Client{Jar: jar, Timeout: 3 * time.Second, Transport: transport}
+	// Voyager expects the JSESSIONID value (without quotes) as its CSRF token
+	c.csrfToken = strings.Trim(cookies["JSESSIONID"], `"`)
+
+	cfg.logger.InfoContext(ctx, "linkedin client created", "cookie_count", len(cookies))
+	return c, nil
 }
 
 // Fetch retrieves a LinkedIn profile.
-// NOTE: LinkedIn authentication is currently broken. This returns a minimal profile
-// with just the URL and username. The link is preserved for manual verification.
-func (c *Client) Fetch(_ context.Context, urlStr string) (*profile.Profile, error) {
-	// Normalize URL
-	if !strings.HasPrefix(urlStr, "http") {
+// With session cookies, this uses the Voyager API. Otherwise, or if the session is
+// rejected, it returns whatever the public profile page exposes, or a minimal profile
+// with just the URL and username if LinkedIn serves an auth wall. The link is preserved
+// for manual verification either way.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	// Normalize URL; member URNs resolve through /in/<profile-id>
+	urn := extractURN(urlStr)
+	switch {
+	case urn != "":
+		urlStr = "https://www.linkedin.com/in/" + strings.TrimPrefix(urn, "urn:li:fsd_profile:")
+	case !strings.HasPrefix(urlStr, "http"):
 		urlStr = "https://www.linkedin.com/in/" + urlStr
 	}
 
 	username := extractPublicID(urlStr)
 
-	c.logger.Info("linkedin auth broken - returning minimal profile", "url", urlStr, "username", username)
+	if c.authClient != nil && username != "" {
+		// Leave half the deadline for the public-page fallback
+		voyagerCtx, cancel := cache.SubContext(ctx, 2)
+		p, err := c.fetchVoyager(voyagerCtx, urlStr, username)
+		cancel()
+		if err == nil {
+			recordIdentifiers(p, username, urn)
+			return p, nil
+		}
+		c.logger.WarnContext(ctx, "linkedin voyager fetch failed, falling back to public profile", "url", urlStr, "error", err)
+	}
+
+	if p := c.fetchPublic(ctx, urlStr, username); p != nil {
+		recordIdentifiers(p, username, urn)
+		return p, nil
+	}
+
+	c.logger.Info("linkedin public profile unavailable - returning minimal profile", "url", urlStr, "username", username)
 
-	// Return minimal profile with just the URL - auth is broken so we can't fetch details
-	return &profile.Profile{
+	// Return minimal profile with just the URL - we can't fetch details
+	p := &profile.Profile{
 		Platform:      platform,
 		URL:           urlStr,
 		Authenticated: false,
 		Username:      username,
 		Fields:        make(map[string]string),
-	}, nil
+	}
+	recordIdentifiers(p, username, urn)
+	return p, nil
 }
 
 // EnableDebug enables debug logging (currently a no-op).
 func (*Client) EnableDebug() {}
 
+// publicSource is a URL that may serve the public profile page.
+type publicSource struct {
+	name string
+	url  string
+}
+
+// fetchPublic tries the public profile page, then search-engine caches if enabled.
+// Returns nil if no source produced usable profile data.
+func (c *Client) fetchPublic(ctx context.Context, urlStr, username string) *profile.Profile {
+	if username == "" {
+		return nil
+	}
+	publicURL := "https://www.linkedin.com/in/" + username + "/"
+	if c.locale != defaultLocale {
+		// The public page honors ?locale= for members with a secondary-language profile
+		publicURL += "?locale=" + url.QueryEscape(c.locale)
+	}
+
+	sources := []publicSource{{sourcePublicProfile, publicURL}}
+	if c.searchCaches {
+		sources = append(sources,
+			publicSource{sourceBingCache, "https://cc.bingj.com/cache.aspx?q=" + url.QueryEscape(publicURL) + "&url=" + url.QueryEscape(publicURL)},
+			publicSource{sourceGoogleCache, "https://webcache.googleusercontent.com/search?q=cache:" + url.QueryEscape(publicURL)},
+		)
+	}
+
+	for i, src := range sources {
+		// Search-engine caches are optional; don't start one without time to finish it
+		if i > 0 && !cache.HasBudget(ctx, cache.MinOptionalBudget) {
+			c.logger.Debug("skipping linkedin public source, deadline budget exhausted", "source", src.name)
+			break
+		}
+		body, err := c.fetchPublicSource(ctx, src, len(sources)-i)
+		if err != nil {
+			c.logger.Debug("linkedin public fetch failed", "source", src.name, "url", src.url, "error", err)
+			continue
+		}
+
+		if p := parsePublicProfile(string(body), urlStr, username, src.name); p != nil {
+			c.logger.Info("built linkedin profile from public data", "source", src.name, "url", urlStr)
+			return p
+		}
+		c.logger.Debug("linkedin public page had no profile data", "source", src.name, "url", src.url)
+	}
+
+	return nil
+}
+
+// fetchPublicSource fetches one public source with its share of the deadline,
+// given n sources (including this one) still to try.
+func (c *Client) fetchPublicSource(ctx context.Context, src publicSource, n int) ([]byte, error) {
+	ctx, cancel := cache.SubContext(ctx, n)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+func (c *Client) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", acceptLanguage(c.locale))
+}
+
+// normalizeLocale converts "de-DE", "de_de", or "de" style locales to LinkedIn's "de_DE" form.
+func normalizeLocale(locale string) string {
+	lang, region, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "-", "_"), "_")
+	lang = strings.ToLower(lang)
+	if lang == "" {
+		return defaultLocale
+	}
+	if region == "" {
+		return lang
+	}
+	return lang + "_" + strings.ToUpper(region)
+}
+
+// acceptLanguage builds an Accept-Language header preferring locale, with English as a fallback.
+func acceptLanguage(locale string) string {
+	lang, region, _ := strings.Cut(locale, "_")
+	header := lang
+	if region != "" {
+		header = lang + "-" + region + "," + lang + ";q=0.9"
+	}
+	if lang != "en" {
+		header += ",en;q=0.5"
+	}
+	return header
+}
+
+// Pre-compiled patterns for public profile meta tags. LinkedIn emits both attribute orders
+// and always double-quotes content, which may contain apostrophes.
+var (
+	ogTitlePattern     = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:title["'][^>]+content="([^"]+)"`)
+	ogTitleAltPattern  = regexp.MustCompile(`(?i)<meta[^>]+content="([^"]+)"[^>]+property=["']og:title["']`)
+	ogDescPattern      = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:description["'][^>]+content="([^"]+)"`)
+	ogDescAltPattern   = regexp.MustCompile(`(?i)<meta[^>]+content="([^"]+)"[^>]+property=["']og:description["']`)
+	metaDescPattern    = regexp.MustCompile(`(?i)<meta[^>]+name=["']description["'][^>]+content="([^"]+)"`)
+	connectionsPattern = regexp.MustCompile(`^([\d,]+\+?) connections`)
+	boilerplatePattern = regexp.MustCompile(`(?i)\s*View [^.]*profile on LinkedIn.*$`)
+	publicIDPattern    = regexp.MustCompile(`linkedin\.com/in/([^/?#]+)`)
+	profileURNPattern  = regexp.MustCompile(`urn:li:(?:fsd_profile|fs_profile|fs_miniProfile):([\w-]+)`)
+	bareURNPattern     = regexp.MustCompile(`^urn:li:(?:fsd_profile|fs_profile|fs_miniProfile):[\w-]+$`)
+	topCardPattern     = regexp.MustCompile(`(?is)<section[^>]+class="[^"]*\btop-card[^"]*"[^>]*>.*?</section>`)
+	canonicalPattern   = regexp.MustCompile(`(?i)<link[^>]+rel="canonical"[^>]+href="([^"]+)"`)
+	ogURLPattern       = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:url["'][^>]+content="([^"]+)"`)
+	codeBlockPattern   = regexp.MustCompile(`(?s)<code[^>]*>(.*?)</code>`)
+	authWallPrefixes   = []string{"sign up", "log in", "linkedin login", "join linkedin"}
+)
+
+// Parse extracts a profile from the HTML of a LinkedIn profile page obtained some other
+// way, such as by a browser extension. Pages viewed while logged in embed the same
+// normalized data the Voyager API returns; logged-out pages only have the public meta
+// tags. It returns profile.ErrProfileNotFound if the page has neither.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	content := string(data)
+	username := extractPublicID(urlStr)
+	urn := extractURN(urlStr)
+
+	// Logged-in pages embed many Voyager responses, including the viewer's own profile
+	var found *profile.Profile
+	for _, m := range codeBlockPattern.FindAllStringSubmatch(content, -1) {
+		block := html.UnescapeString(m[1])
+		if !strings.Contains(block, `"included"`) || !strings.Contains(block, "Profile") {
+			continue
+		}
+		p, err := parseVoyagerProfile([]byte(block), defaultLocale)
+		if err != nil || p.Name == "" {
+			continue
+		}
+		if username == "" || strings.EqualFold(p.Username, username) || urn != "" && p.Fields["urn"] == urn {
+			found = p
+			break
+		}
+	}
+	if found == nil {
+		found = parsePublicProfile(content, urlStr, username, sourcePublicProfile)
+	}
+	if found == nil {
+		return nil, profile.ErrProfileNotFound
+	}
+	found.URL = urlStr
+	recordIdentifiers(found, username, urn)
+	return found, nil
+}
+
+// parsePublicProfile extracts profile data from the public profile page meta tags.
+// Returns nil if the page is an auth wall or carries no profile data.
+func parsePublicProfile(content, urlStr, username, source string) *profile.Profile {
+	title := firstMatch(content, ogTitlePattern, ogTitleAltPattern)
+	if title == "" {
+		return nil
+	}
+	title = strings.TrimSpace(strings.TrimSuffix(title, "| LinkedIn"))
+	lowerTitle := strings.ToLower(title)
+	if lowerTitle == "linkedin" {
+		return nil
+	}
+	for _, prefix := range authWallPrefixes {
+		if strings.HasPrefix(lowerTitle, prefix) {
+			return nil
+		}
+	}
+
+	p := &profile.Profile{
+		Platform:      platform,
+		URL:           urlStr,
+		Authenticated: false,
+		Username:      username,
+		Fields:        make(map[string]string),
+	}
+
+	// The canonical URL carries the current vanity name, which differs from the requested
+	// one when the member renamed their profile or we fetched by profile ID.
+	canonical := extractPublicID(firstMatch(content, canonicalPattern, ogURLPattern))
+	if canonical != "" {
+		p.Username = canonical
+	}
+	if urn := ownURN(content, canonical); urn != "" {
+		p.Fields["urn"] = urn
+	}
+
+	// og:title is "Name - Headline | LinkedIn"
+	parts := strings.Split(title, " - ")
+	p.Name = strings.TrimSpace(parts[0])
+	if len(parts) > 1 {
+		p.Fields["headline"] = strings.TrimSpace(strings.Join(parts[1:], " - "))
+	}
+
+	// og:description is "Bio · Experience: X · Education: Y · Location: Z · 500+ connections on LinkedIn. View ..."
+	desc := firstMatch(content, ogDescPattern, ogDescAltPattern, metaDescPattern)
+	for _, seg := range strings.Split(desc, " · ") {
+		seg = strings.TrimSpace(boilerplatePattern.ReplaceAllString(seg, ""))
+		switch {
+		case seg == "":
+		case strings.HasPrefix(seg, "Experience: "):
+			p.Fields[profile.FieldEmployer] = strings.TrimPrefix(seg, "Experience: ")
+		case strings.HasPrefix(seg, "Education: "):
+			p.Fields["education"] = strings.TrimPrefix(seg, "Education: ")
+		case strings.HasPrefix(seg, "Location: "):
+			p.Location = strings.TrimPrefix(seg, "Location: ")
+		case connectionsPattern.MatchString(seg):
+			p.Fields[profile.FieldConnections] = connectionsPattern.FindStringSubmatch(seg)[1]
+		case p.Bio == "":
+			p.Bio = seg
+		}
+	}
+
+	// Meta tags are truncated summaries, and cache snapshots may be outdated.
+	p.Fields["source"] = source
+	p.Fields["confidence"] = "medium"
+	if source != sourcePublicProfile {
+		p.Fields["confidence"] = "low"
+	}
+
+	return p
+}
+
+func firstMatch(content string, patterns ...*regexp.Regexp) string {
+	for _, re := range patterns {
+		if m := re.FindStringSubmatch(content); len(m) > 1 {
+			return strings.TrimSpace(html.UnescapeString(m[1]))
+		}
+	}
+	return ""
+}
+
 // extractPublicID extracts the username from a LinkedIn profile URL.
 func extractPublicID(urlStr string) string {
 	// Pattern: linkedin.com/in/username or linkedin.com/in/username/
-	re := regexp.MustCompile(`linkedin\.com/in/([^/?]+)`)
-	matches := re.FindStringSubmatch(urlStr)
+	matches := publicIDPattern.FindStringSubmatch(urlStr)
 	if len(matches) > 1 {
+		if id, err := url.PathUnescape(matches[1]); err == nil {
+			return id
+		}
 		return matches[1]
 	}
 	return ""
 }
+
+// extractURN returns the first member profile URN in s, normalized to the
+// urn:li:fsd_profile form. Percent-encoded URNs (as found in Voyager URLs) are decoded.
+func extractURN(s string) string {
+	if strings.Contains(s, "%3A") || strings.Contains(s, "%3a") {
+		if decoded, err := url.QueryUnescape(s); err == nil {
+			s = decoded
+		}
+	}
+	if m := profileURNPattern.FindStringSubmatch(s); len(m) > 1 {
+		return "urn:li:fsd_profile:" + m[1]
+	}
+	return ""
+}
+
+// ownURN returns the URN of the member whose public profile page content is, given
+// the public ID in its canonical URL. Other members' URNs appear throughout the page,
+// in sidebars such as "People also viewed", so only the canonical URL and the
+// profile's top card are trusted.
+func ownURN(content, canonical string) string {
+	if isProfileID(canonical) {
+		return "urn:li:fsd_profile:" + canonical
+	}
+	return extractURN(topCardPattern.FindString(content))
+}
+
+// isProfileID returns true if id is an opaque member profile ID (as embedded in URNs)
+// rather than a vanity name. LinkedIn profile IDs always start with "ACo".
+func isProfileID(id string) bool {
+	return strings.HasPrefix(id, "ACo") && len(id) > 20
+}
+
+// recordIdentifiers stores the member URN and any previous vanity name on p, so
+// URLs stored under an old vanity name or a profile ID keep resolving to this profile.
+// requestedID is the public ID the caller asked for.
+func recordIdentifiers(p *profile.Profile, requestedID, urn string) {
+	if urn == "" && isProfileID(requestedID) {
+		urn = "urn:li:fsd_profile:" + requestedID
+	}
+	if urn != "" && p.Fields["urn"] == "" {
+		p.Fields["urn"] = urn
+	}
+	if p.Username != "" && !isProfileID(p.Username) {
+		p.Fields["public_id"] = p.Username
+	}
+	if requestedID != "" && !isProfileID(requestedID) && !strings.EqualFold(requestedID, p.Username) {
+		p.Fields["previous_public_id"] = requestedID
+	}
+}
@@ -3,6 +3,8 @@ package linkedin
 import (
 	"context"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -16,7 +18,14 @@ func TestMatch(t *testing.T) {
 		{"https://linkedin.com/in/johndoe/", true},
 		{"linkedin.com/in/johndoe", true},
 		{"https://LINKEDIN.COM/IN/johndoe", true},
+		{"urn:li:fsd_profile:ACoAAAbCdEfGhIjKlMnOpQrStUvWxYz012345", true},
+		{"https://www.linkedin.com/voyager/api/identity/dash/profiles/urn:li:fsd_profile:ACoAAAbCdEf", true},
 		{"https://linkedin.com/company/acme", false},
+		{"https://evil.example/urn:li:fsd_profile:ACoAAAbCdEf", false},
+		{"https://example.com/?next=urn:li:fsd_profile:ACoAAAbCdEf", false},
+		{"https://www.linkedin.com/feed/?highlight=urn:li:fsd_profile:ACoAAAbCdEf", false},
+		{"https://example.com/linkedin.com/in/johndoe", false},
+		{"https://notlinkedin.com/in/johndoe", false},
 		{"https://twitter.com/johndoe", false},
 		{"https://example.com", false},
 	}
@@ -45,6 +54,7 @@ func TestExtractPublicID(t *testing.T) {
 		{"https://linkedin.com/in/johndoe", "johndoe"},
 		{"https://linkedin.com/in/johndoe/", "johndoe"},
 		{"https://linkedin.com/in/john-doe-123", "john-doe-123"},
+		{"https://linkedin.com/in/j%C3%B6rg-m%C3%BCller", "jörg-müller"},
 		{"https://example.com", ""},
 	}
 
@@ -58,6 +68,59 @@ func TestExtractPublicID(t *testing.T) {
 	}
 }
 
+func TestExtractURN(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"urn:li:fsd_profile:ACoAAAbCdEf", "urn:li:fsd_profile:ACoAAAbCdEf"},
+		{"urn:li:fs_miniProfile:ACoAAAbCdEf", "urn:li:fsd_profile:ACoAAAbCdEf"},
+		{"https://www.linkedin.com/voyager/api/identity/dash/profiles/urn%3Ali%3Afsd_profile%3AACoAAAbCdEf", "urn:li:fsd_profile:ACoAAAbCdEf"},
+		{"https://linkedin.com/in/johndoe", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := extractURN(tt.in); got != tt.want {
+				t.Errorf("extractURN(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVanityNameChange(t *testing.T) {
+	page := `<link rel="canonical" href="https://www.linkedin.com/in/jane-smith">` + publicProfileHTML
+
+	t.Run("renamed_vanity", func(t *testing.T) {
+		p := parsePublicProfile(page, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile)
+		recordIdentifiers(p, "janedoe", "")
+		if p.Username != "jane-smith" {
+			t.Errorf("Username = %q, want %q", p.Username, "jane-smith")
+		}
+		if p.Fields["public_id"] != "jane-smith" {
+			t.Errorf("public_id = %q, want %q", p.Fields["public_id"], "jane-smith")
+		}
+		if p.Fields["previous_public_id"] != "janedoe" {
+			t.Errorf("previous_public_id = %q, want %q", p.Fields["previous_public_id"], "janedoe")
+		}
+	})
+
+	t.Run("fetched_by_urn", func(t *testing.T) {
+		const id = "ACoAAAbCdEfGhIjKlMnOpQrStUvWxYz012345"
+		p := parsePublicProfile(page, "https://www.linkedin.com/in/"+id, id, sourcePublicProfile)
+		recordIdentifiers(p, id, "urn:li:fsd_profile:"+id)
+		if p.Username != "jane-smith" {
+			t.Errorf("Username = %q, want %q", p.Username, "jane-smith")
+		}
+		if p.Fields["urn"] != "urn:li:fsd_profile:"+id {
+			t.Errorf("urn = %q", p.Fields["urn"])
+		}
+		if _, ok := p.Fields["previous_public_id"]; ok {
+			t.Errorf("previous_public_id should not be set for profile IDs, got %q", p.Fields["previous_public_id"])
+		}
+	})
+}
+
 func TestNew(t *testing.T) {
 	ctx := context.Background()
 
@@ -92,6 +155,13 @@ func TestFetch(t *testing.T) {
 		t.Fatalf("New() failed: %v", err)
 	}
 
+	// LinkedIn answers unauthenticated scrapers with HTTP 999
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(999)
+	}))
+	defer server.Close()
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
 	t.Run("returns_minimal_profile", func(t *testing.T) {
 		prof, err := client.Fetch(ctx, "https://www.linkedin.com/in/johndoe")
 		if err != nil {
@@ -114,6 +184,20 @@ func TestFetch(t *testing.T) {
 		}
 	})
 
+	t.Run("fetch_by_urn", func(t *testing.T) {
+		const id = "ACoAAAbCdEfGhIjKlMnOpQrStUvWxYz012345"
+		prof, err := client.Fetch(ctx, "urn:li:fsd_profile:"+id)
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if prof.URL != "https://www.linkedin.com/in/"+id {
+			t.Errorf("URL = %q, want profile ID URL", prof.URL)
+		}
+		if prof.Fields["urn"] != "urn:li:fsd_profile:"+id {
+			t.Errorf("urn = %q", prof.Fields["urn"])
+		}
+	})
+
 	t.Run("normalizes_url", func(t *testing.T) {
 		prof, err := client.Fetch(ctx, "johndoe")
 		if err != nil {
@@ -124,3 +208,139 @@ func TestFetch(t *testing.T) {
 		}
 	})
 }
+
+const publicProfileHTML = `<!DOCTYPE html><html><head>
+<title>Jane Doe - Staff Engineer - Acme | LinkedIn</title>
+<meta property="og:title" content="Jane Doe - Staff Engineer - Acme | LinkedIn">
+<meta property="og:description" content="Building reliable systems at scale · Experience: Acme Corp · Education: MIT · Location: Raleigh · 500+ connections on LinkedIn. View Jane Doe&#39;s profile on LinkedIn, a professional community of 1 billion members.">
+</head><body></body></html>`
+
+func TestFetchPublicProfile(t *testing.T) {
+	ctx := context.Background()
+	client, err := New(ctx, WithLogger(slog.New(slog.DiscardHandler)), WithSearchEngineCache())
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+
+	t.Run("public_page", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(publicProfileHTML))
+		}))
+		defer server.Close()
+		client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+		prof, err := client.Fetch(ctx, "https://www.linkedin.com/in/janedoe")
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if prof.Name != "Jane Doe" {
+			t.Errorf("Name = %q, want %q", prof.Name, "Jane Doe")
+		}
+		if prof.Fields["source"] != sourcePublicProfile {
+			t.Errorf("source = %q, want %q", prof.Fields["source"], sourcePublicProfile)
+		}
+		if prof.Authenticated {
+			t.Error("Authenticated should be false for public data")
+		}
+	})
+
+	t.Run("falls_back_to_search_cache", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/cache.aspx" {
+				_, _ = w.Write([]byte(publicProfileHTML))
+				return
+			}
+			w.WriteHeader(999)
+		}))
+		defer server.Close()
+		client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+		prof, err := client.Fetch(ctx, "https://www.linkedin.com/in/janedoe")
+		if err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+		if prof.Fields["source"] != sourceBingCache {
+			t.Errorf("source = %q, want %q", prof.Fields["source"], sourceBingCache)
+		}
+		if prof.Fields["confidence"] != "low" {
+			t.Errorf("confidence = %q, want %q", prof.Fields["confidence"], "low")
+		}
+	})
+}
+
+func TestParsePublicProfile(t *testing.T) {
+	t.Run("meta_tags", func(t *testing.T) {
+		p := parsePublicProfile(publicProfileHTML, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile)
+		if p == nil {
+			t.Fatal("parsePublicProfile() returned nil")
+		}
+		want := map[string]string{
+			"headline":    "Staff Engineer - Acme",
+			"employer":    "Acme Corp",
+			"education":   "MIT",
+			"connections": "500+",
+			"source":      sourcePublicProfile,
+			"confidence":  "medium",
+		}
+		for k, v := range want {
+			if p.Fields[k] != v {
+				t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+			}
+		}
+		if p.Bio != "Building reliable systems at scale" {
+			t.Errorf("Bio = %q", p.Bio)
+		}
+		if p.Location != "Raleigh" {
+			t.Errorf("Location = %q, want %q", p.Location, "Raleigh")
+		}
+	})
+
+	t.Run("own_urn", func(t *testing.T) {
+		const page = `<meta property="og:title" content="Jane Doe - Staff Engineer | LinkedIn">
+<section class="top-card-layout container-lined" data-member="urn:li:fsd_profile:ACoJane"><h1>Jane Doe</h1></section>
+<section class="aside-section"><a data-member="urn:li:fsd_profile:ACoOther">Also viewed</a></section>`
+		p := parsePublicProfile(page, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile)
+		if p == nil || p.Fields["urn"] != "urn:li:fsd_profile:ACoJane" {
+			t.Errorf("parsePublicProfile() = %+v, want the top card's URN", p)
+		}
+
+		// Without a top card, another member's URN is not taken for the profile's
+		const sidebarOnly = `<meta property="og:title" content="Jane Doe - Staff Engineer | LinkedIn">
+<section class="aside-section"><a data-member="urn:li:fsd_profile:ACoOther">Also viewed</a></section>`
+		p = parsePublicProfile(sidebarOnly, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile)
+		if p == nil || p.Fields["urn"] != "" {
+			t.Errorf("parsePublicProfile() = %+v, want no URN", p)
+		}
+
+		const byID = `<meta property="og:title" content="Jane Doe | LinkedIn">
+<link rel="canonical" href="https://www.linkedin.com/in/ACoAAAbCdEfGhIjKlMnOpQrSt">
+<a data-member="urn:li:fsd_profile:ACoOther">Also viewed</a>`
+		p = parsePublicProfile(byID, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile)
+		if p == nil || p.Fields["urn"] != "urn:li:fsd_profile:ACoAAAbCdEfGhIjKlMnOpQrSt" {
+			t.Errorf("parsePublicProfile() = %+v, want the canonical URL's URN", p)
+		}
+	})
+
+	t.Run("auth_wall", func(t *testing.T) {
+		authWall := `<meta property="og:title" content="Sign Up | LinkedIn">`
+		if p := parsePublicProfile(authWall, "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile); p != nil {
+			t.Errorf("parsePublicProfile() = %+v, want nil for auth wall", p)
+		}
+	})
+
+	t.Run("no_meta", func(t *testing.T) {
+		if p := parsePublicProfile("<html></html>", "https://www.linkedin.com/in/janedoe", "janedoe", sourcePublicProfile); p != nil {
+			t.Errorf("parsePublicProfile() = %+v, want nil", p)
+		}
+	})
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
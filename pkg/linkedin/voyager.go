@@ -0,0 +1,428 @@
+package linkedin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// voyagerBaseURL is the root of LinkedIn's internal (Voyager) API used by the web app.
+const voyagerBaseURL = "https://www.linkedin.com/voyager/api"
+
+// profileDecoration selects the full profile view, including the profile picture frame.
+const profileDecoration = "com.linkedin.voyager.dash.deco.identity.profile.FullProfileWithEntities-93"
+
+// fetchVoyager retrieves a profile from the Voyager API using the session cookies.
+func (c *Client) fetchVoyager(ctx context.Context, urlStr, publicID string) (*profile.Profile, error) {
+	apiURL := fmt.Sprintf("%s/identity/dash/profiles?q=memberIdentity&memberIdentity=%s&decorationId=%s",
+		voyagerBaseURL, url.QueryEscape(publicID), profileDecoration)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	c.setVoyagerHeaders(req)
+
+	body, err := cache.FetchURL(ctx, c.cache, c.authClient, req, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("voyager request failed: %w", err)
+	}
+
+	p, err := parseVoyagerProfile(body, c.locale)
+	if err != nil {
+		return nil, err
+	}
+	p.URL = urlStr
+	return p, nil
+}
+
+func (c *Client) setVoyagerHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "application/vnd.linkedin.normalized+json+2.1")
+	req.Header.Set("Csrf-Token", c.csrfToken)
+	req.Header.Set("X-Restli-Protocol-Version", "2.0.0")
+	req.Header.Set("X-Li-Lang", c.locale)
+	req.Header.Set("Accept-Language", acceptLanguage(c.locale))
+}
+
+// voyagerResponse is the normalized Voyager envelope: the requested entity URNs in
+// data, and every referenced entity flattened into included.
+type voyagerResponse struct {
+	Data struct {
+		Elements []string `json:"*elements"`
+	} `json:"data"`
+	Included []json.RawMessage `json:"included"`
+}
+
+// voyagerEntity holds the union of fields we read from included entities.
+// Which fields are set depends on $type.
+//
+//nolint:govet // fieldalignment: intentional layout for readability
+type voyagerEntity struct {
+	Type      string `json:"$type"`
+	EntityURN string `json:"entityUrn"`
+
+	// com.linkedin.voyager.dash.identity.profile.Profile
+	FirstName        string `json:"firstName"`
+	LastName         string `json:"lastName"`
+	Headline         string `json:"headline"`
+	Summary          string `json:"summary"`
+	PublicIdentifier string `json:"publicIdentifier"`
+	GeoLocation      *struct {
+		GeoURN string `json:"*geo"`
+	} `json:"geoLocation"`
+	ProfilePicture *struct {
+		FrameType string `json:"frameType"`
+	} `json:"profilePicture"`
+	PrimaryLocale *struct {
+		Country  string `json:"country"`
+		Language string `json:"language"`
+	} `json:"primaryLocale"`
+	// Multilingual profiles carry every variant keyed by locale, e.g. "de_DE"
+	MultiLocaleFirstName map[string]string `json:"multiLocaleFirstName"`
+	MultiLocaleLastName  map[string]string `json:"multiLocaleLastName"`
+	MultiLocaleHeadline  map[string]string `json:"multiLocaleHeadline"`
+	MultiLocaleSummary   map[string]string `json:"multiLocaleSummary"`
+
+	// com.linkedin.voyager.dash.common.Geo
+	DefaultLocalizedName string `json:"defaultLocalizedName"`
+
+	// com.linkedin.voyager.dash.identity.profile.OpenToWorkPreferences
+	JobTitles      []string `json:"jobTitles"`
+	Locations      []string `json:"locations"`
+	WorkplaceTypes []string `json:"workplaceTypes"`
+	JobTypes       []string `json:"jobTypes"`
+
+	// Profile sections: Position, Education, Certification, Publication,
+	// VolunteerExperience, and Honor
+	Title        string       `json:"title"`
+	Name         string       `json:"name"`
+	Role         string       `json:"role"`
+	CompanyName  string       `json:"companyName"`
+	LocationName string       `json:"locationName"`
+	SchoolName   string       `json:"schoolName"`
+	DegreeName   string       `json:"degreeName"`
+	FieldOfStudy string       `json:"fieldOfStudy"`
+	Authority    string       `json:"authority"`
+	Publisher    string       `json:"publisher"`
+	Issuer       string       `json:"issuer"`
+	Cause        string       `json:"cause"`
+	URL          string       `json:"url"`
+	Description  string       `json:"description"`
+	DateRange    *voyagerSpan `json:"dateRange"`
+	PublishedOn  *voyagerDate `json:"publishedOn"`
+	IssuedOn     *voyagerDate `json:"issuedOn"`
+}
+
+// voyagerDate is a partial date; month and day are omitted when unknown.
+type voyagerDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Day   int `json:"day"`
+}
+
+// String formats the date as YYYY, YYYY-MM, or YYYY-MM-DD.
+func (d *voyagerDate) String() string {
+	switch {
+	case d == nil || d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	case d.Day == 0:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	default:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	}
+}
+
+type voyagerSpan struct {
+	Start *voyagerDate `json:"start"`
+	End   *voyagerDate `json:"end"`
+}
+
+func (s *voyagerSpan) bounds() (start, end string) {
+	if s == nil {
+		return "", ""
+	}
+	return s.Start.String(), s.End.String()
+}
+
+// localized returns the variant for locale, then any variant in the same language,
+// then fallback (the member's default-locale value).
+func localized(variants map[string]string, locale, fallback string) string {
+	if v := variants[locale]; v != "" {
+		return v
+	}
+	lang, _, _ := strings.Cut(locale, "_")
+	for _, k := range slices.Sorted(maps.Keys(variants)) {
+		if v := variants[k]; v != "" && (k == lang || strings.HasPrefix(k, lang+"_")) {
+			return v
+		}
+	}
+	return fallback
+}
+
+func (e *voyagerEntity) isType(suffix string) bool {
+	return strings.HasSuffix(e.Type, "."+suffix)
+}
+
+// parseVoyagerProfile converts a normalized Voyager profile response into a Profile,
+// preferring the localized name, headline, and summary for locale when present.
+func parseVoyagerProfile(data []byte, locale string) (*profile.Profile, error) {
+	var resp voyagerResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parsing voyager response: %w", err)
+	}
+
+	entities := make([]*voyagerEntity, 0, len(resp.Included))
+	byURN := make(map[string]*voyagerEntity)
+	for _, raw := range resp.Included {
+		var e voyagerEntity
+		if err := json.Unmarshal(raw, &e); err != nil {
+			continue
+		}
+		entities = append(entities, &e)
+		if e.EntityURN != "" {
+			byURN[e.EntityURN] = &e
+		}
+	}
+
+	// The requested profile is the first data element; fall back to the first Profile entity.
+	var member *voyagerEntity
+	if len(resp.Data.Elements) > 0 {
+		member = byURN[resp.Data.Elements[0]]
+	}
+	if member == nil {
+		for _, e := range entities {
+			if e.isType("Profile") {
+				member = e
+				break
+			}
+		}
+	}
+	if member == nil {
+		return nil, errors.New("no profile entity in voyager response")
+	}
+	return voyagerProfile(member, entities, byURN, locale), nil
+}
+
+// ParseVoyager converts Voyager API responses captured some other way, such as in a
+// browser's HAR export, into profiles. A profile page loads its sections in several
+// responses, so they are merged: the result has a profile for every member that some
+// response was requested for, with the sections of that member only.
+func ParseVoyager(responses [][]byte) ([]*profile.Profile, error) {
+	var entities []*voyagerEntity
+	byURN := make(map[string]*voyagerEntity)
+	var requested []string
+	for _, data := range responses {
+		var resp voyagerResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			continue
+		}
+		requested = append(requested, resp.Data.Elements...)
+		for _, raw := range resp.Included {
+			var e voyagerEntity
+			if err := json.Unmarshal(raw, &e); err != nil {
+				continue
+			}
+			if prev := byURN[e.EntityURN]; prev != nil && e.EntityURN != "" {
+				*prev = e
+				continue
+			}
+			entities = append(entities, &e)
+			if e.EntityURN != "" {
+				byURN[e.EntityURN] = &e
+			}
+		}
+	}
+
+	// Members are profiles responses were requested for, not everyone they mention
+	var members []*voyagerEntity
+	seen := make(map[string]bool)
+	for _, urn := range requested {
+		if e := byURN[urn]; e != nil && e.isType("Profile") && e.PublicIdentifier != "" && !seen[urn] {
+			seen[urn] = true
+			members = append(members, e)
+		}
+	}
+	if len(members) == 0 {
+		return nil, errors.New("no profile entity in voyager responses")
+	}
+
+	var profiles []*profile.Profile
+	for _, member := range members {
+		p := voyagerProfile(member, memberEntities(member, entities), byURN, defaultLocale)
+		p.URL = "https://www.linkedin.com/in/" + p.Username
+		profiles = append(profiles, p)
+	}
+	return profiles, nil
+}
+
+// memberEntities drops the entities of other members, whose URNs embed their profile
+// IDs, e.g. "urn:li:fsd_profilePosition:(ACoAAB1,123)".
+func memberEntities(member *voyagerEntity, entities []*voyagerEntity) []*voyagerEntity {
+	var others []string
+	for _, e := range entities {
+		if e != member && e.isType("Profile") {
+			if id := profileID(e.EntityURN); id != "" && id != profileID(member.EntityURN) {
+				others = append(others, id)
+			}
+		}
+	}
+	kept := make([]*voyagerEntity, 0, len(entities))
+	for _, e := range entities {
+		if e == member || !slices.ContainsFunc(others, func(id string) bool { return strings.Contains(e.EntityURN, id) }) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// profileID returns the ID in a profile URN such as "urn:li:fsd_profile:ACoAAB1".
+func profileID(urn string) string {
+	if i := strings.LastIndexByte(urn, ':'); i >= 0 && strings.Contains(urn, "profile:") {
+		return urn[i+1:]
+	}
+	return ""
+}
+
+// voyagerProfile builds the profile of member from the entities of its response.
+func voyagerProfile(member *voyagerEntity, entities []*voyagerEntity, byURN map[string]*voyagerEntity, locale string) *profile.Profile {
+	p := &profile.Profile{
+		Platform:      platform,
+		Authenticated: true,
+		Username:      member.PublicIdentifier,
+		Name: strings.TrimSpace(localized(member.MultiLocaleFirstName, locale, member.FirstName) + " " +
+			localized(member.MultiLocaleLastName, locale, member.LastName)),
+		Bio:    localized(member.MultiLocaleSummary, locale, member.Summary),
+		Fields: make(map[string]string),
+	}
+	if headline := localized(member.MultiLocaleHeadline, locale, member.Headline); headline != "" {
+		p.Fields["headline"] = headline
+	}
+	if member.PrimaryLocale != nil && member.PrimaryLocale.Language != "" {
+		p.Fields["primary_locale"] = member.PrimaryLocale.Language + "_" + member.PrimaryLocale.Country
+	}
+	if member.EntityURN != "" {
+		p.Fields["urn"] = member.EntityURN
+	}
+	if member.GeoLocation != nil {
+		if geo := byURN[member.GeoLocation.GeoURN]; geo != nil {
+			p.Location = geo.DefaultLocalizedName
+		}
+	}
+
+	parseBadges(p, member, entities)
+	parseSections(p, entities)
+
+	return p
+}
+
+// parseSections maps the profile section entities onto the Profile's structured slices.
+// Voyager returns section entries in display order, which is most recent first.
+func parseSections(p *profile.Profile, entities []*voyagerEntity) {
+	for _, e := range entities {
+		start, end := e.DateRange.bounds()
+		switch {
+		case e.isType("Position"):
+			p.Experience = append(p.Experience, profile.Experience{
+				Title:        e.Title,
+				Organization: e.CompanyName,
+				Location:     e.LocationName,
+				Start:        start,
+				End:          end,
+				Description:  e.Description,
+			})
+		case e.isType("Education"):
+			p.Education = append(p.Education, profile.Education{
+				School: e.SchoolName,
+				Degree: e.DegreeName,
+				Field:  e.FieldOfStudy,
+				Start:  start,
+				End:    end,
+			})
+		case e.isType("Certification"):
+			p.Certifications = append(p.Certifications, profile.Certification{
+				Name:      e.Name,
+				Authority: e.Authority,
+				Issued:    start,
+				Expires:   end,
+				URL:       e.URL,
+			})
+		case e.isType("Publication"):
+			p.Publications = append(p.Publications, profile.Publication{
+				Title:       e.Name,
+				Publisher:   e.Publisher,
+				Date:        e.PublishedOn.String(),
+				URL:         e.URL,
+				Description: e.Description,
+			})
+		case e.isType("VolunteerExperience"):
+			p.Volunteering = append(p.Volunteering, profile.Volunteering{
+				Role:         e.Role,
+				Organization: e.CompanyName,
+				Cause:        strings.ToLower(e.Cause),
+				Start:        start,
+				End:          end,
+			})
+		case e.isType("Honor"):
+			p.Honors = append(p.Honors, profile.Honor{
+				Title:       e.Title,
+				Issuer:      e.Issuer,
+				Date:        e.IssuedOn.String(),
+				Description: e.Description,
+			})
+		default:
+		}
+	}
+
+	// Keep the flat employer field used by guessing in sync with the current position
+	if len(p.Experience) > 0 && p.Experience[0].End == "" && p.Experience[0].Organization != "" {
+		p.Fields[profile.FieldEmployer] = p.Experience[0].Organization
+	}
+}
+
+// parseBadges records the #OpenToWork / #Hiring profile picture frames and the
+// member's open-to-work preferences (when visible to the viewer).
+func parseBadges(p *profile.Profile, member *voyagerEntity, entities []*voyagerEntity) {
+	if member.ProfilePicture != nil {
+		frame := strings.ToUpper(strings.ReplaceAll(member.ProfilePicture.FrameType, "_", ""))
+		switch {
+		case strings.Contains(frame, "OPENTOWORK"):
+			p.Fields["open_to_work"] = "true"
+		case strings.Contains(frame, "HIRING"):
+			p.Fields["hiring"] = "true"
+		}
+	}
+
+	for _, e := range entities {
+		if !e.isType("OpenToWorkPreferences") {
+			continue
+		}
+		p.Fields["open_to_work"] = "true"
+		if len(e.JobTitles) > 0 {
+			p.Fields["open_to_work_roles"] = strings.Join(e.JobTitles, ", ")
+		}
+		if len(e.Locations) > 0 {
+			p.Fields["open_to_work_locations"] = strings.Join(e.Locations, ", ")
+		}
+		if len(e.JobTypes) > 0 {
+			p.Fields["open_to_work_job_types"] = strings.ToLower(strings.Join(e.JobTypes, ", "))
+		}
+		for _, w := range e.WorkplaceTypes {
+			if strings.EqualFold(w, "REMOTE") {
+				p.Fields["open_to_work_remote"] = "true"
+			}
+		}
+		break
+	}
+}
@@ -0,0 +1,419 @@
+package linkedin
+
+import (
+	"context"
+	"errors"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const voyagerProfileJSON = `{
+	"data": {"*elements": ["urn:li:fsd_profile:ACoAAAbCdEfGhIjKlMnOpQrStUvWxYz012345"]},
+	"included": [
+		{
+			"$type": "com.linkedin.voyager.dash.common.Geo",
+			"entityUrn": "urn:li:fsd_geo:102571732",
+			"defaultLocalizedName": "Raleigh, North Carolina, United States"
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Profile",
+			"entityUrn": "urn:li:fsd_profile:ACoAAAbCdEfGhIjKlMnOpQrStUvWxYz012345",
+			"firstName": "Jane",
+			"lastName": "Doe",
+			"headline": "Staff Engineer at Acme",
+			"summary": "I build distributed systems.",
+			"publicIdentifier": "janedoe",
+			"geoLocation": {"*geo": "urn:li:fsd_geo:102571732"},
+			"profilePicture": {"frameType": "OPEN_TO_WORK"}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.OpenToWorkPreferences",
+			"jobTitles": ["Staff Engineer", "Principal Engineer"],
+			"locations": ["Raleigh, NC"],
+			"workplaceTypes": ["HYBRID", "REMOTE"],
+			"jobTypes": ["FULL_TIME"]
+		}
+	]
+}`
+
+func TestParseVoyagerProfile(t *testing.T) {
+	p, err := parseVoyagerProfile([]byte(voyagerProfileJSON), defaultLocale)
+	if err != nil {
+		t.Fatalf("parseVoyagerProfile() error = %v", err)
+	}
+
+	if p.Name != "Jane Doe" {
+		t.Errorf("Name = %q, want %q", p.Name, "Jane Doe")
+	}
+	if p.Username != "janedoe" {
+		t.Errorf("Username = %q, want %q", p.Username, "janedoe")
+	}
+	if p.Location != "Raleigh, North Carolina, United States" {
+		t.Errorf("Location = %q", p.Location)
+	}
+	if !p.Authenticated {
+		t.Error("Authenticated should be true for voyager profiles")
+	}
+
+	want := map[string]string{
+		"headline":               "Staff Engineer at Acme",
+		"open_to_work":           "true",
+		"open_to_work_roles":     "Staff Engineer, Principal Engineer",
+		"open_to_work_locations": "Raleigh, NC",
+		"open_to_work_job_types": "full_time",
+		"open_to_work_remote":    "true",
+	}
+	for k, v := range want {
+		if p.Fields[k] != v {
+			t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+		}
+	}
+	if _, ok := p.Fields["hiring"]; ok {
+		t.Error("hiring should not be set")
+	}
+}
+
+func TestParseBadges(t *testing.T) {
+	tests := []struct {
+		frame string
+		want  string
+	}{
+		{"OPEN_TO_WORK", "open_to_work"},
+		{"#OPENTOWORK", "open_to_work"},
+		{"HIRING", "hiring"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frame, func(t *testing.T) {
+			data := strings.Replace(voyagerProfileJSON, `"frameType": "OPEN_TO_WORK"`, `"frameType": "`+tt.frame+`"`, 1)
+			// Drop the preferences entity so only the frame is considered
+			data = data[:strings.Index(data, `,
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.OpenToWorkPreferences"`)] + "]}"
+			p, err := parseVoyagerProfile([]byte(data), defaultLocale)
+			if err != nil {
+				t.Fatalf("parseVoyagerProfile() error = %v", err)
+			}
+			for _, k := range []string{"open_to_work", "hiring"} {
+				if got, want := p.Fields[k] == "true", k == tt.want; got != want {
+					t.Errorf("Fields[%q] set = %v, want %v", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseVoyagerProfileErrors(t *testing.T) {
+	if _, err := parseVoyagerProfile([]byte("not json"), defaultLocale); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := parseVoyagerProfile([]byte(`{"included": []}`), defaultLocale); err == nil {
+		t.Error("expected error for response without a profile")
+	}
+}
+
+func TestFetchVoyager(t *testing.T) {
+	ctx := context.Background()
+	client, err := New(ctx,
+		WithLogger(slog.New(slog.DiscardHandler)),
+		WithCookies(map[string]string{"li_at": "token", "JSESSIONID": `"ajax:123"`}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if client.csrfToken != "ajax:123" {
+		t.Errorf("csrfToken = %q, want %q", client.csrfToken, "ajax:123")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/voyager/api/identity/dash/profiles") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Csrf-Token") != "ajax:123" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Li-Lang") != "en_US" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(voyagerProfileJSON))
+	}))
+	defer server.Close()
+	client.authClient.Transport = &mockTransport{mockURL: server.URL}
+
+	p, err := client.Fetch(ctx, "https://www.linkedin.com/in/janedoe")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !p.Authenticated {
+		t.Error("Authenticated should be true")
+	}
+	if p.URL != "https://www.linkedin.com/in/janedoe" {
+		t.Errorf("URL = %q", p.URL)
+	}
+	if p.Fields["open_to_work"] != "true" {
+		t.Error("open_to_work should be set")
+	}
+}
+
+func TestParseSections(t *testing.T) {
+	data := `{
+	"included": [
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Profile",
+			"firstName": "Jane",
+			"lastName": "Doe",
+			"publicIdentifier": "janedoe"
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Position",
+			"title": "Staff Engineer",
+			"companyName": "Acme",
+			"locationName": "Raleigh, NC",
+			"dateRange": {"start": {"year": 2021, "month": 3}}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Position",
+			"title": "Software Engineer",
+			"companyName": "Initech",
+			"dateRange": {"start": {"year": 2015}, "end": {"year": 2021, "month": 2}}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Education",
+			"schoolName": "NC State University",
+			"degreeName": "BS",
+			"fieldOfStudy": "Computer Science",
+			"dateRange": {"start": {"year": 2011}, "end": {"year": 2015}}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Certification",
+			"name": "Certified Kubernetes Administrator",
+			"authority": "The Linux Foundation",
+			"url": "https://www.credly.com/badges/abc",
+			"dateRange": {"start": {"year": 2022, "month": 6}, "end": {"year": 2025, "month": 6}}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Publication",
+			"name": "Scaling Raft",
+			"publisher": "USENIX",
+			"publishedOn": {"year": 2020, "month": 7, "day": 15}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.VolunteerExperience",
+			"role": "Mentor",
+			"companyName": "Code for Durham",
+			"cause": "EDUCATION",
+			"dateRange": {"start": {"year": 2019}}
+		},
+		{
+			"$type": "com.linkedin.voyager.dash.identity.profile.Honor",
+			"title": "Engineer of the Year",
+			"issuer": "Acme",
+			"issuedOn": {"year": 2023}
+		}
+	]
+}`
+
+	p, err := parseVoyagerProfile([]byte(data), defaultLocale)
+	if err != nil {
+		t.Fatalf("parseVoyagerProfile() error = %v", err)
+	}
+
+	if len(p.Experience) != 2 {
+		t.Fatalf("Experience = %d entries, want 2", len(p.Experience))
+	}
+	if got := p.Experience[0]; got.Organization != "Acme" || got.Start != "2021-03" || got.End != "" || got.Location != "Raleigh, NC" {
+		t.Errorf("Experience[0] = %+v", got)
+	}
+	if got := p.Experience[1]; got.Start != "2015" || got.End != "2021-02" {
+		t.Errorf("Experience[1] = %+v", got)
+	}
+	if p.Fields["employer"] != "Acme" {
+		t.Errorf("Fields[employer] = %q, want %q", p.Fields["employer"], "Acme")
+	}
+
+	if len(p.Education) != 1 || p.Education[0].Field != "Computer Science" || p.Education[0].End != "2015" {
+		t.Errorf("Education = %+v", p.Education)
+	}
+	if len(p.Certifications) != 1 || p.Certifications[0].Issued != "2022-06" || p.Certifications[0].Expires != "2025-06" {
+		t.Errorf("Certifications = %+v", p.Certifications)
+	}
+	if len(p.Publications) != 1 || p.Publications[0].Title != "Scaling Raft" || p.Publications[0].Date != "2020-07-15" {
+		t.Errorf("Publications = %+v", p.Publications)
+	}
+	if len(p.Volunteering) != 1 || p.Volunteering[0].Cause != "education" || p.Volunteering[0].Organization != "Code for Durham" {
+		t.Errorf("Volunteering = %+v", p.Volunteering)
+	}
+	if len(p.Honors) != 1 || p.Honors[0].Date != "2023" {
+		t.Errorf("Honors = %+v", p.Honors)
+	}
+}
+
+func TestVoyagerDateString(t *testing.T) {
+	tests := []struct {
+		date *voyagerDate
+		want string
+	}{
+		{nil, ""},
+		{&voyagerDate{}, ""},
+		{&voyagerDate{Year: 2020}, "2020"},
+		{&voyagerDate{Year: 2020, Month: 3}, "2020-03"},
+		{&voyagerDate{Year: 2020, Month: 3, Day: 5}, "2020-03-05"},
+	}
+	for _, tt := range tests {
+		if got := tt.date.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestParseVoyagerProfileLocale(t *testing.T) {
+	data := `{
+	"included": [{
+		"$type": "com.linkedin.voyager.dash.identity.profile.Profile",
+		"firstName": "Jurgen",
+		"lastName": "Muller",
+		"headline": "Software Engineer",
+		"summary": "I write compilers.",
+		"publicIdentifier": "jmuller",
+		"primaryLocale": {"country": "US", "language": "en"},
+		"multiLocaleFirstName": {"en_US": "Jurgen", "de_DE": "Jürgen"},
+		"multiLocaleLastName": {"en_US": "Muller", "de_DE": "Müller"},
+		"multiLocaleHeadline": {"en_US": "Software Engineer", "de_DE": "Softwareentwickler"},
+		"multiLocaleSummary": {"en_US": "I write compilers."}
+	}]
+}`
+
+	tests := []struct {
+		locale       string
+		wantName     string
+		wantHeadline string
+	}{
+		{"en_US", "Jurgen Muller", "Software Engineer"},
+		{"de_DE", "Jürgen Müller", "Softwareentwickler"},
+		{"de_AT", "Jürgen Müller", "Softwareentwickler"},
+		{"fr_FR", "Jurgen Muller", "Software Engineer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			p, err := parseVoyagerProfile([]byte(data), tt.locale)
+			if err != nil {
+				t.Fatalf("parseVoyagerProfile() error = %v", err)
+			}
+			if p.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", p.Name, tt.wantName)
+			}
+			if p.Fields["headline"] != tt.wantHeadline {
+				t.Errorf("headline = %q, want %q", p.Fields["headline"], tt.wantHeadline)
+			}
+			if p.Bio != "I write compilers." {
+				t.Errorf("Bio = %q, want default-locale summary", p.Bio)
+			}
+			if p.Fields["primary_locale"] != "en_US" {
+				t.Errorf("primary_locale = %q", p.Fields["primary_locale"])
+			}
+		})
+	}
+}
+
+func TestLocaleHeaders(t *testing.T) {
+	tests := []struct {
+		in         string
+		wantLocale string
+		wantAccept string
+	}{
+		{"", "en_US", "en-US,en;q=0.9"},
+		{"de_DE", "de_DE", "de-DE,de;q=0.9,en;q=0.5"},
+		{"pt-br", "pt_BR", "pt-BR,pt;q=0.9,en;q=0.5"},
+		{"ja", "ja", "ja,en;q=0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := normalizeLocale(tt.in)
+			if got != tt.wantLocale {
+				t.Errorf("normalizeLocale(%q) = %q, want %q", tt.in, got, tt.wantLocale)
+			}
+			if accept := acceptLanguage(got); accept != tt.wantAccept {
+				t.Errorf("acceptLanguage(%q) = %q, want %q", got, accept, tt.wantAccept)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	viewer := `{"data": {"*elements": ["urn:li:fsd_profile:ACoViewer"]}, "included": [
+		{"$type": "com.linkedin.voyager.dash.identity.profile.Profile", "entityUrn": "urn:li:fsd_profile:ACoViewer",
+		 "firstName": "Viewer", "lastName": "Person", "publicIdentifier": "viewer"}]}`
+	page := `<html><head><meta property="og:title" content="Jane Doe - Engineer | LinkedIn"></head><body>
+<code style="display: none" id="bpr-guid-1">` + html.EscapeString(viewer) + `</code>
+<code style="display: none" id="bpr-guid-2">` + html.EscapeString(voyagerProfileJSON) + `</code>
+</body></html>`
+
+	p, err := Parse(context.Background(), []byte(page), "https://www.linkedin.com/in/janedoe/")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || !p.Authenticated || p.Location == "" || p.URL != "https://www.linkedin.com/in/janedoe/" {
+		t.Errorf("Parse() = %+v, want the viewed member's Voyager data", p)
+	}
+
+	// Logged-out pages only have meta tags
+	public := `<meta property="og:title" content="Jane Doe - Engineer | LinkedIn">`
+	p, err = Parse(context.Background(), []byte(public), "https://www.linkedin.com/in/janedoe")
+	if err != nil || p.Authenticated || p.Name != "Jane Doe" || p.Fields["headline"] != "Engineer" {
+		t.Errorf("Parse(public) = %+v, %v", p, err)
+	}
+
+	if _, err := Parse(context.Background(), []byte(`<title>Sign Up | LinkedIn</title>`), "https://www.linkedin.com/in/janedoe"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("Parse(auth wall) error = %v, want ErrProfileNotFound", err)
+	}
+}
+
+func TestParseVoyager(t *testing.T) {
+	// A profile page's own response, a later response with its positions, and the
+	// navigation bar's response for the viewer, whose position must not leak in
+	responses := [][]byte{
+		[]byte(`{"data": {"*elements": ["urn:li:fsd_profile:ACoJane"]}, "included": [
+			{"$type": "com.linkedin.voyager.dash.identity.profile.Profile", "entityUrn": "urn:li:fsd_profile:ACoJane",
+			 "firstName": "Jane", "lastName": "Doe", "publicIdentifier": "janedoe"},
+			{"$type": "com.linkedin.voyager.dash.identity.profile.Profile", "entityUrn": "urn:li:fsd_profile:ACoViewer",
+			 "firstName": "Vic", "lastName": "Viewer", "publicIdentifier": "vic"}
+		]}`),
+		[]byte(`{"data": {"*elements": []}, "included": [
+			{"$type": "com.linkedin.voyager.dash.identity.profile.Position", "entityUrn": "urn:li:fsd_profilePosition:(ACoJane,1)",
+			 "title": "Staff Engineer", "companyName": "Acme", "dateRange": {"start": {"year": 2021}}},
+			{"$type": "com.linkedin.voyager.dash.identity.profile.Position", "entityUrn": "urn:li:fsd_profilePosition:(ACoViewer,2)",
+			 "title": "Recruiter", "companyName": "Initech", "dateRange": {"start": {"year": 2020}}}
+		]}`),
+		[]byte(`not json`),
+	}
+
+	profiles, err := ParseVoyager(responses)
+	if err != nil {
+		t.Fatalf("ParseVoyager() error = %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("ParseVoyager() returned %d profiles, want only the requested one", len(profiles))
+	}
+	p := profiles[0]
+	if p.Name != "Jane Doe" || p.URL != "https://www.linkedin.com/in/janedoe" || !p.Authenticated {
+		t.Errorf("profile = %+v", p)
+	}
+	if len(p.Experience) != 1 || p.Experience[0].Organization != "Acme" {
+		t.Errorf("Experience = %+v, want only Jane's position", p.Experience)
+	}
+
+	if _, err := ParseVoyager(responses[1:]); err == nil {
+		t.Error("ParseVoyager() without a requested profile should fail")
+	}
+}
@@ -0,0 +1,88 @@
+package linkgraph
+
+import "sort"
+
+// Linked reports whether the graph has an edge of any type from a to b.
+func (g *Graph) Linked(a, b string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, t := range []EdgeType{EdgeRelMe, EdgeENS, EdgeWebsite, EdgeField, EdgeBio, EdgeReadme} {
+		if g.edges[Edge{From: a, To: b, Type: t}] {
+			return true
+		}
+	}
+	return false
+}
+
+// Reciprocal reports whether a and b link to each other.
+func (g *Graph) Reciprocal(a, b string) bool {
+	return g.Linked(a, b) && g.Linked(b, a)
+}
+
+// Clusters returns the groups of nodes that can all reach each other by following
+// links (strongly connected components), covering both reciprocal pairs (A↔B) and
+// longer cycles (A→B→C→A). Single nodes are omitted. Each cluster is sorted, and
+// clusters are ordered by their first node.
+func (g *Graph) Clusters() [][]string {
+	g.mu.Lock()
+	adj := make(map[string][]string, len(g.order))
+	for _, e := range g.list {
+		adj[e.From] = append(adj[e.From], e.To)
+	}
+	order := append([]string(nil), g.order...)
+	g.mu.Unlock()
+
+	// Tarjan's algorithm
+	index := make(map[string]int, len(order))
+	low := make(map[string]int, len(order))
+	onStack := make(map[string]bool)
+	var stack []string
+	var clusters [][]string
+	next := 0
+
+	var visit func(v string)
+	visit = func(v string) {
+		index[v] = next
+		low[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range adj[v] {
+			if _, seen := index[w]; !seen {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], index[w])
+			}
+		}
+
+		if low[v] != index[v] {
+			return
+		}
+		var c []string
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			c = append(c, w)
+			if w == v {
+				break
+			}
+		}
+		if len(c) > 1 {
+			sort.Strings(c)
+			clusters = append(clusters, c)
+		}
+	}
+
+	for _, v := range order {
+		if _, seen := index[v]; !seen {
+			visit(v)
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i][0] < clusters[j][0] })
+	return clusters
+}
@@ -0,0 +1,35 @@
+package linkgraph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusters(t *testing.T) {
+	g := New()
+	// a <-> b reciprocal pair
+	g.AddEdge("a", "b", "https://a", EdgeRelMe)
+	g.AddEdge("b", "a", "https://b", EdgeBio)
+	// c -> d -> e -> c cycle
+	g.AddEdge("c", "d", "https://d", EdgeRelMe)
+	g.AddEdge("d", "e", "https://e", EdgeWebsite)
+	g.AddEdge("e", "c", "https://c", EdgeField)
+	// f -> a one-way, not part of any cluster
+	g.AddEdge("f", "a", "https://a", EdgeRelMe)
+
+	got := g.Clusters()
+	want := [][]string{{"a", "b"}, {"c", "d", "e"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clusters() = %v, want %v", got, want)
+	}
+
+	if !g.Reciprocal("a", "b") {
+		t.Error("Reciprocal(a, b) = false, want true")
+	}
+	if g.Reciprocal("c", "d") {
+		t.Error("Reciprocal(c, d) = true, want false")
+	}
+	if !g.Linked("f", "a") || g.Linked("a", "f") {
+		t.Error("Linked(f, a) should be true and Linked(a, f) false")
+	}
+}
@@ -0,0 +1,69 @@
+package linkgraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteCypher writes the graph as Cypher statements for Neo4j or Memgraph, one per
+// line, to be run with cypher-shell (which sends them over Bolt):
+//
+//	sociopath -r -graph crawl.cypher https://github.com/alice
+//	cypher-shell -u neo4j -p secret < crawl.cypher
+//
+// Each node becomes a :Profile keyed by its ID and each edge a :LINKS_TO relationship
+// with the edge type in its "type" property. Each cluster of nodes that link to one
+// another (see Clusters) is tied to an :Identity node by :SAME_AS relationships; an
+// identity's ID is derived from its members, so it is stable across runs.
+// Statements use MERGE, so loading a later crawl into the same database updates
+// the graph instead of duplicating it.
+func (g *Graph) WriteCypher(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("CREATE CONSTRAINT sociopath_profile_id IF NOT EXISTS FOR (p:Profile) REQUIRE p.id IS UNIQUE;\n")
+	b.WriteString("CREATE CONSTRAINT sociopath_identity_id IF NOT EXISTS FOR (i:Identity) REQUIRE i.id IS UNIQUE;\n")
+	for _, n := range g.Nodes() {
+		fmt.Fprintf(&b, "MERGE (p:Profile {id: %s}) SET p.url = %s", cypherQuote(n.ID), cypherQuote(n.URL))
+		if n.Platform != "" {
+			fmt.Fprintf(&b, ", p.platform = %s", cypherQuote(n.Platform))
+		}
+		if n.Username != "" {
+			fmt.Fprintf(&b, ", p.username = %s", cypherQuote(n.Username))
+		}
+		// Once fetched, a profile stays fetched when a later crawl only sees a link to it
+		if n.Fetched {
+			b.WriteString(", p.fetched = true;\n")
+		} else {
+			b.WriteString(", p.fetched = coalesce(p.fetched, false);\n")
+		}
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "MATCH (a:Profile {id: %s}), (b:Profile {id: %s}) MERGE (a)-[:LINKS_TO {type: %s}]->(b);\n",
+			cypherQuote(e.From), cypherQuote(e.To), cypherQuote(string(e.Type)))
+	}
+	for _, c := range g.Clusters() {
+		ids := make([]string, len(c))
+		for i, id := range c {
+			ids[i] = cypherQuote(id)
+		}
+		fmt.Fprintf(&b, "MERGE (i:Identity {id: %s}) WITH i MATCH (p:Profile) WHERE p.id IN [%s] MERGE (p)-[:SAME_AS]->(i);\n",
+			cypherQuote(identityID(c)), strings.Join(ids, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// identityID returns a stable ID for a sorted cluster of node IDs.
+func identityID(cluster []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(cluster, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// cypherQuote returns s as a single-quoted Cypher string literal.
+func cypherQuote(s string) string {
+	return "'" + cypherEscaper.Replace(s) + "'"
+}
+
+var cypherEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
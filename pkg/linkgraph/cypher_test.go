@@ -0,0 +1,31 @@
+package linkgraph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCypher(t *testing.T) {
+	g := testGraph()
+	g.AddNode(Node{ID: "o'brien.dev", URL: "https://o'brien.dev"})
+	var buf bytes.Buffer
+	if err := g.WriteCypher(&buf); err != nil {
+		t.Fatalf("WriteCypher() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"CREATE CONSTRAINT sociopath_profile_id IF NOT EXISTS FOR (p:Profile) REQUIRE p.id IS UNIQUE;",
+		"MERGE (p:Profile {id: 'github.com/alice'}) SET p.url = 'https://github.com/alice', p.platform = 'github', p.fetched = true;",
+		"MERGE (p:Profile {id: 'o\\'brien.dev'}) SET p.url = 'https://o\\'brien.dev', p.fetched = coalesce(p.fetched, false);",
+		"MATCH (a:Profile {id: 'github.com/alice'}), (b:Profile {id: 'mastodon.social/@alice'}) MERGE (a)-[:LINKS_TO {type: 'rel=me'}]->(b);",
+		"WHERE p.id IN ['github.com/alice', 'mastodon.social/@alice'] MERGE (p)-[:SAME_AS]->(i);",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Cypher output missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "MERGE (i:Identity"); n != 1 {
+		t.Errorf("got %d identities, want 1 for the reciprocal pair", n)
+	}
+}
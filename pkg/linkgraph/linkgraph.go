@@ -0,0 +1,238 @@
+// Package linkgraph records which profiles link to which during a crawl, and exports
+// the result as JSON, Graphviz DOT, GraphML, or Cypher for visualization and network
+// analysis.
+package linkgraph
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EdgeType describes where on a profile a link was found.
+type EdgeType string
+
+// Edge types, from strongest to weakest evidence of shared ownership.
+const (
+	EdgeRelMe   EdgeType = "rel=me"  // Declared profile link (rel="me", link fields, verified links)
+	EdgeENS     EdgeType = "ens"     // ENS text record, which only the name's owner can set
+	EdgeWebsite EdgeType = "website" // The profile's website field
+	EdgeField   EdgeType = "field"   // A platform-specific field such as "twitter"
+	EdgeBio     EdgeType = "bio"     // Mentioned in the bio text
+	EdgeReadme  EdgeType = "readme"  // Mentioned in a README or page body
+)
+
+// Node is a profile URL in the graph.
+type Node struct {
+	ID       string `json:"id"`                 // Normalized URL
+	URL      string `json:"url"`                // URL as first seen
+	Platform string `json:"platform,omitempty"` // Platform, if the profile was fetched
+	Username string `json:"username,omitempty"` // Username, if known
+	Fetched  bool   `json:"fetched,omitempty"`  // Whether the crawler fetched this profile
+}
+
+// Edge is a link from one profile to another.
+type Edge struct {
+	From string   `json:"from"` // Source node ID
+	To   string   `json:"to"`   // Target node ID
+	Type EdgeType `json:"type"` // Where the link was found
+}
+
+// Graph is a directed link graph. It is safe for concurrent use.
+type Graph struct {
+	nodes map[string]*Node
+	edges map[Edge]bool
+	order []string // node IDs in insertion order
+	list  []Edge   // edges in insertion order
+	mu    sync.Mutex
+}
+
+// New creates an empty graph.
+func New() *Graph {
+	return &Graph{
+		nodes: make(map[string]*Node),
+		edges: make(map[Edge]bool),
+	}
+}
+
+// AddNode adds n or merges it into an existing node with the same ID,
+// filling in fields the existing node lacks.
+func (g *Graph) AddNode(n Node) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.addNode(n)
+}
+
+func (g *Graph) addNode(n Node) {
+	existing, ok := g.nodes[n.ID]
+	if !ok {
+		g.nodes[n.ID] = &n
+		g.order = append(g.order, n.ID)
+		return
+	}
+	if existing.URL == "" {
+		existing.URL = n.URL
+	}
+	if existing.Platform == "" {
+		existing.Platform = n.Platform
+	}
+	if existing.Username == "" {
+		existing.Username = n.Username
+	}
+	existing.Fetched = existing.Fetched || n.Fetched
+}
+
+// AddEdge records a link from node from to the profile at toURL, adding the target
+// node if needed. Duplicate edges of the same type are ignored, as are self-links.
+func (g *Graph) AddEdge(from, to, toURL string, t EdgeType) {
+	if from == to {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.addNode(Node{ID: to, URL: toURL})
+	e := Edge{From: from, To: to, Type: t}
+	if g.edges[e] {
+		return
+	}
+	g.edges[e] = true
+	g.list = append(g.list, e)
+}
+
+// Nodes returns the nodes in the order they were added.
+func (g *Graph) Nodes() []Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	nodes := make([]Node, 0, len(g.order))
+	for _, id := range g.order {
+		nodes = append(nodes, *g.nodes[id])
+	}
+	return nodes
+}
+
+// Edges returns the edges in the order they were added.
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Edge(nil), g.list...)
+}
+
+// MarshalJSON encodes the graph as {"nodes": [...], "edges": [...]}.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}{g.Nodes(), g.Edges()})
+}
+
+// WriteDOT writes the graph in Graphviz DOT format.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph sociopath {\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes() {
+		label := n.ID
+		if n.Platform != "" {
+			label = n.Platform + "\n" + n.ID
+		}
+		style := ""
+		if !n.Fetched {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(n.ID), dotQuote(label), style)
+	}
+	for _, e := range g.Edges() {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(string(e.Type)))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a DOT quoted string, with newlines as \n line breaks.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteGraphML writes the graph in GraphML format.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	type data struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+	type node struct {
+		ID   string `xml:"id,attr"`
+		Data []data `xml:"data"`
+	}
+	type edge struct {
+		Source string `xml:"source,attr"`
+		Target string `xml:"target,attr"`
+		Data   []data `xml:"data"`
+	}
+	type key struct {
+		ID       string `xml:"id,attr"`
+		For      string `xml:"for,attr"`
+		AttrName string `xml:"attr.name,attr"`
+		AttrType string `xml:"attr.type,attr"`
+	}
+	type graph struct {
+		EdgeDefault string `xml:"edgedefault,attr"`
+		Nodes       []node `xml:"node"`
+		Edges       []edge `xml:"edge"`
+	}
+	type graphML struct {
+		XMLName xml.Name `xml:"graphml"`
+		XMLNS   string   `xml:"xmlns,attr"`
+		Keys    []key    `xml:"key"`
+		Graph   graph    `xml:"graph"`
+	}
+
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []key{
+			{ID: "url", For: "node", AttrName: "url", AttrType: "string"},
+			{ID: "platform", For: "node", AttrName: "platform", AttrType: "string"},
+			{ID: "username", For: "node", AttrName: "username", AttrType: "string"},
+			{ID: "fetched", For: "node", AttrName: "fetched", AttrType: "boolean"},
+			{ID: "type", For: "edge", AttrName: "type", AttrType: "string"},
+		},
+		Graph: graph{EdgeDefault: "directed"},
+	}
+	for _, n := range g.Nodes() {
+		d := []data{{Key: "url", Value: n.URL}}
+		if n.Platform != "" {
+			d = append(d, data{Key: "platform", Value: n.Platform})
+		}
+		if n.Username != "" {
+			d = append(d, data{Key: "username", Value: n.Username})
+		}
+		d = append(d, data{Key: "fetched", Value: strconv.FormatBool(n.Fetched)})
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node{ID: n.ID, Data: d})
+	}
+	for _, e := range g.Edges() {
+		doc.Graph.Edges = append(doc.Graph.Edges, edge{
+			Source: e.From,
+			Target: e.To,
+			Data:   []data{{Key: "type", Value: string(e.Type)}},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,107 @@
+package linkgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func testGraph() *Graph {
+	g := New()
+	g.AddNode(Node{ID: "github.com/alice", URL: "https://github.com/alice", Platform: "github", Fetched: true})
+	g.AddEdge("github.com/alice", "mastodon.social/@alice", "https://mastodon.social/@alice", EdgeRelMe)
+	g.AddEdge("github.com/alice", "mastodon.social/@alice", "https://mastodon.social/@alice", EdgeRelMe) // duplicate
+	g.AddEdge("github.com/alice", "github.com/alice", "https://github.com/alice", EdgeReadme)            // self-link
+	g.AddNode(Node{ID: "mastodon.social/@alice", Platform: "mastodon", Username: "alice", Fetched: true})
+	g.AddEdge("mastodon.social/@alice", "github.com/alice", "https://github.com/alice", EdgeBio)
+	return g
+}
+
+func TestGraph(t *testing.T) {
+	g := testGraph()
+
+	nodes := g.Nodes()
+	if len(nodes) != 2 {
+		t.Fatalf("got %d nodes, want 2", len(nodes))
+	}
+	// The edge target added first, then merged with the fetched profile
+	m := nodes[1]
+	if m.URL != "https://mastodon.social/@alice" || m.Platform != "mastodon" || m.Username != "alice" || !m.Fetched {
+		t.Errorf("merged node = %+v", m)
+	}
+
+	edges := g.Edges()
+	want := []Edge{
+		{From: "github.com/alice", To: "mastodon.social/@alice", Type: EdgeRelMe},
+		{From: "mastodon.social/@alice", To: "github.com/alice", Type: EdgeBio},
+	}
+	if len(edges) != len(want) {
+		t.Fatalf("edges = %+v, want %+v", edges, want)
+	}
+	for i := range want {
+		if edges[i] != want[i] {
+			t.Errorf("edges[%d] = %+v, want %+v", i, edges[i], want[i])
+		}
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testGraph().WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"digraph sociopath {",
+		`"github.com/alice" [label="github\ngithub.com/alice"];`,
+		`"github.com/alice" -> "mastodon.social/@alice" [label="rel=me"];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testGraph().WriteGraphML(&buf); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("GraphML output is not valid XML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 2 {
+		t.Errorf("got %d nodes and %d edges, want 2 and 2", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	data, err := json.Marshal(testGraph())
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got struct {
+		Nodes []Node `json:"nodes"`
+		Edges []Edge `json:"edges"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got.Nodes) != 2 || len(got.Edges) != 2 {
+		t.Errorf("got %d nodes and %d edges, want 2 and 2", len(got.Nodes), len(got.Edges))
+	}
+}
@@ -0,0 +1,113 @@
+package links
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// trackingParams are query parameters that identify a click rather than a page.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true,
+	"igshid": true, "igsh": true, "si": true, "ref_src": true, "ref_url": true,
+	"mc_cid": true, "mc_eid": true, "trk": true, "trkinfo": true, "originalsubdomain": true,
+}
+
+// Canonicalize rewrites share and intent links to the profile they are about and
+// strips tracking parameters:
+//
+//	https://twitter.com/intent/follow?screen_name=alice  -> https://twitter.com/alice
+//	https://twitter.com/intent/tweet?text=hi&via=alice   -> https://twitter.com/alice
+//	https://www.linkedin.com/sharing/share-offsite/?url=https://linkedin.com/in/alice
+//	                                                     -> https://linkedin.com/in/alice
+//	https://alice.dev/?utm_source=twitter                -> https://alice.dev/
+//	mailto:alice@example.com?subject=Hi                  -> mailto:alice@example.com
+//
+// Links it does not recognize are returned with only tracking parameters removed.
+func Canonicalize(link string) string {
+	link = strings.TrimSpace(link)
+	if email, ok := Email(link); ok {
+		return "mailto:" + email
+	}
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+	if target := shareTarget(u); target != "" && target != link {
+		return Canonicalize(target)
+	}
+
+	q := u.Query()
+	changed := false
+	for key := range q {
+		if k := strings.ToLower(key); strings.HasPrefix(k, "utm_") || trackingParams[k] {
+			q.Del(key)
+			changed = true
+		}
+	}
+	if !changed {
+		return link
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// shareTarget returns the URL a share or intent link points at, or "".
+func shareTarget(u *url.URL) string {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	host = strings.TrimPrefix(host, "mobile.")
+	path := strings.TrimSuffix(strings.ToLower(u.Path), "/")
+	q := u.Query()
+
+	switch host {
+	case "twitter.com", "x.com":
+		switch path {
+		case "/intent/follow", "/intent/user":
+			if name := strings.TrimPrefix(q.Get("screen_name"), "@"); name != "" {
+				return "https://" + host + "/" + name
+			}
+			if id := q.Get("user_id"); id != "" {
+				return "https://" + host + "/i/user/" + id
+			}
+		case "/intent/tweet", "/intent/post", "/share":
+			if via := strings.TrimPrefix(q.Get("via"), "@"); via != "" {
+				return "https://" + host + "/" + via
+			}
+		}
+	case "linkedin.com":
+		if path == "/sharing/share-offsite" || path == "/sharearticle" || path == "/cws/share" {
+			return q.Get("url")
+		}
+	case "facebook.com", "m.facebook.com":
+		if path == "/sharer/sharer.php" || path == "/sharer.php" || path == "/share.php" {
+			return q.Get("u")
+		}
+	}
+	return ""
+}
+
+// Email returns the address in a mailto link, without subject or other parameters.
+func Email(link string) (string, bool) {
+	rest, ok := cutPrefixFold(strings.TrimSpace(link), "mailto:")
+	if !ok {
+		return "", false
+	}
+	rest, _, _ = strings.Cut(rest, "?")
+	if unescaped, err := url.PathUnescape(rest); err == nil {
+		rest = unescaped
+	}
+	// mailto links may list several recipients; the first is the owner's
+	rest, _, _ = strings.Cut(rest, ",")
+	addr, err := mail.ParseAddress(strings.TrimSpace(rest))
+	if err != nil {
+		return "", false
+	}
+	return strings.ToLower(addr.Address), true
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
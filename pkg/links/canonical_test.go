@@ -0,0 +1,50 @@
+package links
+
+import "testing"
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://twitter.com/intent/follow?screen_name=alice", "https://twitter.com/alice"},
+		{"https://x.com/intent/user?screen_name=@alice&ref_src=twsrc", "https://x.com/alice"},
+		{"https://twitter.com/intent/user?user_id=12345", "https://twitter.com/i/user/12345"},
+		{"https://twitter.com/intent/tweet?text=Great+post&via=alice", "https://twitter.com/alice"},
+		{"https://twitter.com/intent/tweet?text=no+author", "https://twitter.com/intent/tweet?text=no+author"},
+		{
+			"https://www.linkedin.com/sharing/share-offsite/?url=https%3A%2F%2Fwww.linkedin.com%2Fin%2Falice%3Ftrk%3Dshare",
+			"https://www.linkedin.com/in/alice",
+		},
+		{"https://www.facebook.com/sharer/sharer.php?u=https://github.com/alice", "https://github.com/alice"},
+		{"https://alice.dev/?utm_source=twitter&utm_medium=bio", "https://alice.dev/"},
+		{"https://alice.dev/posts?page=2&fbclid=abc", "https://alice.dev/posts?page=2"},
+		{"https://github.com/alice", "https://github.com/alice"},
+		{"mailto:Alice@Example.com?subject=Hello%20there", "mailto:alice@example.com"},
+		{"MAILTO:alice%40example.com", "mailto:alice@example.com"},
+	}
+	for _, tt := range tests {
+		if got := Canonicalize(tt.in); got != tt.want {
+			t.Errorf("Canonicalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEmail(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"mailto:alice@example.com", "alice@example.com", true},
+		{"mailto:alice@example.com,bob@example.com?cc=carol@example.com", "alice@example.com", true},
+		{"mailto:", "", false},
+		{"https://example.com", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Email(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Email(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package links
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// mirrorHosts map hostnames to the one whose pages they serve unchanged.
+var mirrorHosts = map[string]string{
+	"x.com":              "twitter.com",
+	"www.x.com":          "twitter.com",
+	"www.twitter.com":    "twitter.com",
+	"discordapp.com":     "discord.com",
+	"www.discordapp.com": "discord.com",
+}
+
+// MirrorHost returns the hostname that serves the same pages as host, such as
+// "twitter.com" for "x.com", or host itself lowercased if it has no such twin.
+func MirrorHost(host string) string {
+	host = strings.ToLower(host)
+	if canonical, ok := mirrorHosts[host]; ok {
+		return canonical
+	}
+	return host
+}
+
+// migratedHosts map domains a platform, or a Mastodon instance, has moved away from
+// to the one it moved to. Unlike mirrors, old domains often only redirect, so links
+// to them are rewritten before matching and fetching rather than just compared as
+// equal. Guarded by migrationsMu, as AddHostMigration may add to it at run time.
+var migratedHosts = map[string]string{
+	"vkontakte.ru":     "vk.com",
+	"www.vkontakte.ru": "vk.com",
+	"instagr.am":       "instagram.com",
+	"www.instagr.am":   "instagram.com",
+	"telegram.me":      "t.me",
+	"www.telegram.me":  "t.me",
+	"fb.com":           "facebook.com",
+	"www.fb.com":       "facebook.com",
+}
+
+var migrationsMu sync.RWMutex
+
+// AddHostMigration records that from has moved to to, such as a Mastodon instance
+// that changed its domain, so later calls to MigratedHost and MigrateURL follow it.
+func AddHostMigration(from, to string) {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	if from == "" || to == "" || from == to {
+		return
+	}
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migratedHosts[from] = to
+}
+
+// MigratedHost returns the domain host has moved to, such as "vk.com" for
+// "vkontakte.ru", or host itself lowercased if it has not moved.
+func MigratedHost(host string) string {
+	host = strings.ToLower(host)
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+	// Follow chains such as an instance that moved twice, bounded in case of a cycle
+	for range len(migratedHosts) {
+		next, ok := migratedHosts[host]
+		if !ok {
+			break
+		}
+		host = next
+	}
+	return host
+}
+
+// MigrateURL returns link with its host replaced by MigratedHost, so that
+// https://vkontakte.ru/alice becomes https://vk.com/alice. Links on hosts that have
+// not moved, or that cannot be parsed, are returned unchanged.
+func MigrateURL(link string) string {
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+	host := MigratedHost(u.Hostname())
+	if host == strings.ToLower(u.Hostname()) {
+		return link
+	}
+	if port := u.Port(); port != "" {
+		host += ":" + port
+	}
+	u.Host = host
+	return u.String()
+}
@@ -0,0 +1,148 @@
+// Package links cleans the lists of links extractors find on profiles: removing
+// duplicates, links back to the profile's own platform, and denylisted links such as
+// analytics beacons, CDN assets, and share buttons.
+package links
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DefaultDenylist holds links no extractor should report. Programs may extend it
+// with Add; every extractor and the sociopath dispatcher consult it.
+var DefaultDenylist = NewDenylist(
+	// Analytics and tracking
+	"google-analytics.com", "googletagmanager.com", "doubleclick.net", "connect.facebook.net",
+	"facebook.com/tr", "analytics.twitter.com", "bat.bing.com", "hotjar.com", "segment.io",
+	"plausible.io/js", "stats.wp.com", "mc.yandex.ru", "vk.com/rtrg",
+
+	// CDNs and static assets
+	"cdn.jsdelivr.net", "cdnjs.cloudflare.com", "unpkg.com", "fonts.googleapis.com",
+	"fonts.gstatic.com", "ajax.googleapis.com", "abs.twimg.com", "pbs.twimg.com",
+	"static.licdn.com", "media.licdn.com", "static.xx.fbcdn.net",
+
+	// Share and compose intents, which point at the sharer rather than a profile
+	"twitter.com/intent", "x.com/intent", "twitter.com/share", "x.com/share",
+	"facebook.com/sharer", "facebook.com/share.php", "facebook.com/dialog",
+	"linkedin.com/sharing", "linkedin.com/shareArticle", "reddit.com/submit",
+	"pinterest.com/pin/create", "t.me/share", "api.whatsapp.com/send",
+	"news.ycombinator.com/submitlink", "bsky.app/intent",
+)
+
+// Denylist matches links by host, including subdomains, and optional path prefix.
+type Denylist struct {
+	patterns []pattern
+	mu       sync.RWMutex
+}
+
+type pattern struct {
+	host string
+	path string // lowercase path whose subtree is denied, or "" for the whole host
+}
+
+// NewDenylist returns a Denylist of patterns such as "doubleclick.net" (any link on
+// that host or its subdomains) or "twitter.com/intent" (that path and those under it).
+func NewDenylist(patterns ...string) *Denylist {
+	d := &Denylist{}
+	d.Add(patterns...)
+	return d
+}
+
+// Add adds patterns to the denylist.
+func (d *Denylist) Add(patterns ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, p := range patterns {
+		p = strings.ToLower(strings.TrimSpace(p))
+		p = strings.TrimPrefix(strings.TrimPrefix(p, "https://"), "http://")
+		host, path, _ := strings.Cut(p, "/")
+		if host == "" {
+			continue
+		}
+		if path = strings.TrimSuffix(path, "/"); path != "" {
+			path = "/" + path
+		}
+		d.patterns = append(d.patterns, pattern{host: strings.TrimPrefix(host, "www."), path: path})
+	}
+}
+
+// Denied reports whether link matches a denylist pattern. Unparseable links are denied.
+func (d *Denylist) Denied(link string) bool {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return true
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.ToLower(u.EscapedPath())
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, p := range d.patterns {
+		if host != p.host && !strings.HasSuffix(host, "."+p.host) {
+			continue
+		}
+		if p.path == "" || path == p.path || strings.HasPrefix(path, p.path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns links that are not denied.
+func (d *Denylist) Filter(links []string) []string {
+	var kept []string
+	for _, link := range links {
+		if !d.Denied(link) {
+			kept = append(kept, link)
+		}
+	}
+	return kept
+}
+
+// Key returns the form links are compared in: lowercased without a trailing slash.
+func Key(link string) string {
+	return strings.TrimSuffix(strings.ToLower(link), "/")
+}
+
+// Dedupe returns links without duplicates as compared by Key, keeping the first
+// spelling of each.
+func Dedupe(links []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, link := range links {
+		if k := Key(link); !seen[k] {
+			seen[k] = true
+			result = append(result, link)
+		}
+	}
+	return result
+}
+
+// ExcludeMatching returns links for which match is false. Platform packages pass
+// their Match function to drop links back to their own platform.
+func ExcludeMatching(links []string, match func(string) bool) []string {
+	var kept []string
+	for _, link := range links {
+		if !match(link) {
+			kept = append(kept, link)
+		}
+	}
+	return kept
+}
+
+// Clean canonicalizes links, then dedupes them and drops mailto links, those on the
+// DefaultDenylist, and, if samePlatform is non-nil, those it matches.
+func Clean(links []string, samePlatform func(string) bool) []string {
+	var canonical []string
+	for _, link := range links {
+		if c := Canonicalize(link); !strings.HasPrefix(c, "mailto:") {
+			canonical = append(canonical, c)
+		}
+	}
+	links = DefaultDenylist.Filter(Dedupe(canonical))
+	if samePlatform != nil {
+		links = ExcludeMatching(links, samePlatform)
+	}
+	return links
+}
@@ -0,0 +1,109 @@
+package links
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDenylist(t *testing.T) {
+	tests := []struct {
+		link string
+		want bool
+	}{
+		{"https://www.googletagmanager.com/gtag/js?id=G-1", true},
+		{"https://region1.google-analytics.com/g/collect", true},
+		{"https://twitter.com/intent/tweet?text=hi", true},
+		{"https://x.com/Intent/follow?screen_name=alice", true},
+		{"https://www.linkedin.com/shareArticle?url=x", true},
+		{"https://cdn.jsdelivr.net/npm/foo", true},
+		{"https://twitter.com/alice", false},
+		{"https://x.com/intentional_dev", false},
+		{"https://linkedin.com/in/alice", false},
+		{"https://notgoogle-analytics.com.example.org/", false},
+		{"http://[::1", true}, // unparseable
+	}
+	for _, tt := range tests {
+		if got := DefaultDenylist.Denied(tt.link); got != tt.want {
+			t.Errorf("Denied(%q) = %v, want %v", tt.link, got, tt.want)
+		}
+	}
+
+	custom := NewDenylist("https://example.com/ads/", "tracker.io")
+	if !custom.Denied("https://example.com/ads/banner") || !custom.Denied("https://cdn.tracker.io/x") {
+		t.Error("custom denylist missed a pattern")
+	}
+	if custom.Denied("https://example.com/about") {
+		t.Error("custom denylist matched outside its path prefix")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	in := []string{
+		"https://twitter.com/user",
+		"https://TWITTER.COM/user/",
+		"https://mastodon.social/@user",
+		"https://twitter.com/user",
+	}
+	want := []string{"https://twitter.com/user", "https://mastodon.social/@user"}
+	if got := Dedupe(in); !slices.Equal(got, want) {
+		t.Errorf("Dedupe() = %v, want %v", got, want)
+	}
+}
+
+func TestClean(t *testing.T) {
+	isGitHub := func(link string) bool { return strings.Contains(link, "github.com") }
+	in := []string{
+		"https://github.com/alice",
+		"https://twitter.com/alice",
+		"https://twitter.com/alice/",
+		"https://twitter.com/intent/tweet?via=alice",
+		"https://fonts.googleapis.com/css?family=Inter",
+		"https://alice.dev",
+	}
+	want := []string{"https://twitter.com/alice", "https://alice.dev"}
+	if got := Clean(in, isGitHub); !slices.Equal(got, want) {
+		t.Errorf("Clean() = %v, want %v", got, want)
+	}
+	if got := Clean(in[:1], nil); len(got) != 1 {
+		t.Errorf("Clean(nil matcher) = %v, want same-platform link kept", got)
+	}
+}
+
+func TestMirrorHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"X.com", "twitter.com"},
+		{"www.twitter.com", "twitter.com"},
+		{"discordapp.com", "discord.com"},
+		{"old.reddit.com", "old.reddit.com"},
+	}
+	for _, tt := range tests {
+		if got := MirrorHost(tt.host); got != tt.want {
+			t.Errorf("MirrorHost(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateURL(t *testing.T) {
+	AddHostMigration("Old.Example.Social", "mid.example.social")
+	AddHostMigration("mid.example.social", "new.example.social")
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://vkontakte.ru/durov", "https://vk.com/durov"},
+		{"http://instagr.am/alice/?hl=en", "http://instagram.com/alice/?hl=en"},
+		{"https://TELEGRAM.ME/alice", "https://t.me/alice"},
+		{"https://old.example.social/@alice", "https://new.example.social/@alice"},
+		{"https://x.com/alice", "https://x.com/alice"},
+		{"not a url", "not a url"},
+	}
+	for _, tt := range tests {
+		if got := MigrateURL(tt.in); got != tt.want {
+			t.Errorf("MigrateURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package linktree
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -60,14 +59,9 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
 	}, nil
 }
 
@@ -100,6 +94,18 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseHTML(body, urlStr, username), nil
 }
 
+// Parse extracts a profile from a Linktree page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	if !strings.HasPrefix(urlStr, "http") {
+		urlStr = "https://linktr.ee/" + username
+	}
+	return parseHTML(data, urlStr, username), nil
+}
+
 func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	content := string(data)
 
@@ -133,10 +139,12 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	return p
 }
 
+// nextDataPattern matches the Next.js page data script, which may carry extra attributes.
+var nextDataPattern = regexp.MustCompile(`(?s)<script id="__NEXT_DATA__" type="application/json"[^>]*>(.*?)</script>`)
+
 func extractNextData(content string) map[string]any {
 	// Use (?s) flag to make . match newlines, [^>]* to match extra attributes
-	re := regexp.MustCompile(`(?s)<script id="__NEXT_DATA__" type="application/json"[^>]*>(.*?)</script>`)
-	if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+	if matches := nextDataPattern.FindStringSubmatch(content); len(matches) > 1 {
 		var data map[string]any
 		if err := json.Unmarshal([]byte(matches[1]), &data); err == nil {
 			return data
@@ -297,17 +305,27 @@ func categorizeSocialIcon(p *profile.Profile, url, linkType string) {
 	}
 }
 
-func extractMetaContent(content, property string) string {
-	// Try property first
-	re := regexp.MustCompile(`<meta[^>]+property="` + regexp.QuoteMeta(property) + `"[^>]+content="([^"]*)"`)
-	if matches := re.FindStringSubmatch(content); len(matches) > 1 {
-		return matches[1]
+// metaPatterns holds, for each meta property extractMetaContent is asked for, patterns
+// matching its content attribute after and before the property attribute.
+var metaPatterns = map[string][]*regexp.Regexp{
+	"og:title":       metaContentPatterns("og:title"),
+	"og:description": metaContentPatterns("og:description"),
+}
+
+func metaContentPatterns(property string) []*regexp.Regexp {
+	quoted := regexp.QuoteMeta(property)
+	return []*regexp.Regexp{
+		regexp.MustCompile(`<meta[^>]+property="` + quoted + `"[^>]+content="([^"]*)"`),
+		regexp.MustCompile(`<meta[^>]+content="([^"]*)"[^>]+property="` + quoted + `"`),
 	}
+}
 
-	// Try content before property
-	re = regexp.MustCompile(`<meta[^>]+content="([^"]*)"[^>]+property="` + regexp.QuoteMeta(property) + `"`)
-	if matches := re.FindStringSubmatch(content); len(matches) > 1 {
-		return matches[1]
+func extractMetaContent(content, property string) string {
+	// Try property first, then content before property
+	for _, re := range metaPatterns[property] {
+		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+			return matches[1]
+		}
 	}
 
 	return ""
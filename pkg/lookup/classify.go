@@ -0,0 +1,73 @@
+package lookup
+
+import (
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/analysis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// Status describes who, if anyone, holds a handle on a platform.
+type Status string
+
+// Handle statuses reported in Presence.Status.
+const (
+	StatusAvailable Status = "available" // Not registered
+	StatusPerson    Status = "person"    // Held by what looks like an individual's account
+	StatusSquatted  Status = "squatted"  // Held by a brand, or parked, reserved, or automated
+)
+
+// squatBotScore is the analysis.BotScore at or above which an account counts as squatted.
+const squatBotScore = 0.5
+
+// brandMarkers are name and bio fragments of organization, product, and reserved accounts.
+var brandMarkers = []string{
+	"official account", "official page", "official profile", "the official",
+	"this account is reserved", "handle is reserved", "username is reserved", "reserved for",
+	"parked", "for sale", "coming soon", "placeholder", "inactive account",
+	" inc.", " inc,", " llc", " ltd", " gmbh", " corp.", " corporation", "™", "®",
+}
+
+// Classify decides whether a registered account belongs to a person or is held by a
+// brand or squatter, from its bot score, brand wording, and how complete it is.
+func Classify(p *profile.Profile) Status {
+	text := " " + strings.ToLower(p.Name+" \n "+p.Bio) + " "
+	for _, marker := range brandMarkers {
+		if strings.Contains(text, marker) {
+			return StatusSquatted
+		}
+	}
+	// GitHub reports "Organization"; booking pages report "team"
+	if t := strings.ToLower(p.Fields["type"]); t == "organization" || t == "team" {
+		return StatusSquatted
+	}
+
+	if score, _ := analysis.BotScore(p); score >= squatBotScore {
+		return StatusSquatted
+	}
+
+	// A registered account with nothing on it is held, not used.
+	if completeness(p) < 2 {
+		return StatusSquatted
+	}
+	return StatusPerson
+}
+
+// completeness counts the populated identity fields of p.
+func completeness(p *profile.Profile) int {
+	n := 0
+	for _, present := range []bool{
+		p.Name != "" && !strings.EqualFold(p.Name, p.Username),
+		p.Bio != "",
+		p.Location != "",
+		p.Website != "",
+		p.CreatedAt != "",
+		len(p.Posts) > 0,
+		len(p.SocialLinks) > 0,
+	} {
+		if present {
+			n++
+		}
+	}
+	return n
+}
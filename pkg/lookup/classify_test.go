@@ -0,0 +1,62 @@
+package lookup
+
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *profile.Profile
+		want Status
+	}{
+		{
+			name: "person",
+			p: &profile.Profile{
+				Username: "janedoe", Name: "Jane Doe", Bio: "SRE. Opinions my own.", Location: "Oslo",
+				SocialLinks: []string{"https://github.com/janedoe"},
+			},
+			want: StatusPerson,
+		},
+		{
+			name: "brand name",
+			p:    &profile.Profile{Username: "acme", Name: "Acme™", Bio: "Rockets and anvils", Location: "Arizona"},
+			want: StatusSquatted,
+		},
+		{
+			name: "reserved",
+			p:    &profile.Profile{Username: "janedoe", Name: "janedoe", Bio: "This account is reserved."},
+			want: StatusSquatted,
+		},
+		{
+			name: "organization",
+			p: &profile.Profile{
+				Username: "acme", Name: "Acme", Bio: "We build things", Location: "Remote",
+				Fields: map[string]string{"type": "Organization"},
+			},
+			want: StatusSquatted,
+		},
+		{
+			name: "empty",
+			p:    &profile.Profile{Username: "janedoe", Name: "janedoe"},
+			want: StatusSquatted,
+		},
+		{
+			name: "bot",
+			p: &profile.Profile{
+				Username: "jane84729301", Name: "Jane", Bio: "crypto", CreatedAt: "2099-01-01T00:00:00Z",
+				Fields: map[string]string{profile.FieldFollowers: "0", profile.FieldFollowing: "4000"},
+			},
+			want: StatusSquatted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.p); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
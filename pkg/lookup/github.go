@@ -0,0 +1,58 @@
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+// githubCommitSource finds GitHub accounts that authored commits with an email address.
+type githubCommitSource struct{ c *Client }
+
+func (githubCommitSource) Name() string { return "github_commits" }
+
+func (s githubCommitSource) LookupEmail(ctx context.Context, email string) ([]Candidate, error) {
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+	if s.c.githubToken != "" {
+		headers["Authorization"] = "Bearer " + s.c.githubToken
+	}
+
+	apiURL := fmt.Sprintf("%s/search/commits?q=%s&per_page=10", githubAPIURL, url.QueryEscape("author-email:"+email))
+	body, err := s.c.fetch(ctx, apiURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []struct {
+			Author *struct {
+				Login   string `json:"login"`
+				HTMLURL string `json:"html_url"`
+			} `json:"author"` // null when the email is not attached to an account
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing github commit search: %w", err)
+	}
+
+	var candidates []Candidate
+	seen := make(map[string]bool)
+	for _, item := range resp.Items {
+		if item.Author == nil || item.Author.HTMLURL == "" || seen[item.Author.Login] {
+			continue
+		}
+		seen[item.Author.Login] = true
+		// GitHub attributes commits to an account only when it owns the email
+		candidates = append(candidates, Candidate{
+			URL:        item.Author.HTMLURL,
+			Platform:   "github",
+			Username:   item.Author.Login,
+			Source:     s.Name(),
+			Confidence: 0.85,
+		})
+	}
+	return candidates, nil
+}
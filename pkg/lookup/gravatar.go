@@ -0,0 +1,86 @@
+package lookup
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec // Gravatar identifies profiles by the MD5 of the email address
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const gravatarBaseURL = "https://en.gravatar.com"
+
+// gravatarSource finds the Gravatar profile for an email and the accounts it links.
+type gravatarSource struct{ c *Client }
+
+func (gravatarSource) Name() string { return "gravatar" }
+
+//nolint:govet // fieldalignment: intentional layout for readability
+type gravatarResponse struct {
+	Entry []struct {
+		ProfileURL        string `json:"profileUrl"`
+		PreferredUsername string `json:"preferredUsername"`
+		Accounts          []struct {
+			URL       string `json:"url"`
+			Shortname string `json:"shortname"`
+			Username  string `json:"username"`
+			Verified  any    `json:"verified"` // "true" or true depending on API version
+		} `json:"accounts"`
+		URLs []struct {
+			Value string `json:"value"`
+		} `json:"urls"`
+	} `json:"entry"`
+}
+
+func (s gravatarSource) LookupEmail(ctx context.Context, email string) ([]Candidate, error) {
+	sum := md5.Sum([]byte(email)) //nolint:gosec // not used for security
+	body, err := s.c.fetch(ctx, fmt.Sprintf("%s/%s.json", gravatarBaseURL, hex.EncodeToString(sum[:])), nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var resp gravatarResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing gravatar response: %w", err)
+	}
+
+	var candidates []Candidate
+	for _, e := range resp.Entry {
+		if e.ProfileURL != "" {
+			// The profile is keyed by a hash of this exact address
+			candidates = append(candidates, Candidate{
+				URL:        e.ProfileURL,
+				Platform:   "gravatar",
+				Username:   e.PreferredUsername,
+				Source:     s.Name(),
+				Confidence: 0.95,
+			})
+		}
+		for _, a := range e.Accounts {
+			if a.URL == "" {
+				continue
+			}
+			confidence := 0.7
+			if v := fmt.Sprint(a.Verified); strings.EqualFold(v, "true") {
+				confidence = 0.9
+			}
+			candidates = append(candidates, Candidate{
+				URL:        a.URL,
+				Platform:   a.Shortname,
+				Username:   a.Username,
+				Source:     s.Name(),
+				Confidence: confidence,
+			})
+		}
+		for _, u := range e.URLs {
+			if u.Value != "" {
+				candidates = append(candidates, Candidate{URL: u.Value, Source: s.Name(), Confidence: 0.6})
+			}
+		}
+	}
+	return candidates, nil
+}
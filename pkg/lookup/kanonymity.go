@@ -0,0 +1,85 @@
+package lookup
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // the range protocol is defined over SHA-1
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// kAnonymityPrefixLen is how many hash characters are sent to the range endpoint.
+const kAnonymityPrefixLen = 5
+
+// KAnonymitySource queries an HIBP-style range endpoint, which only ever sees the first
+// five hex characters of the email's SHA-1 hash. The endpoint returns one line per
+// matching hash, "SUFFIX:site1;site2", naming the sites the address is registered at.
+// Matches are weak evidence of an account, so candidates point at the site itself.
+type KAnonymitySource struct {
+	httpClient *http.Client
+	name       string
+	rangeURL   string
+}
+
+// NewKAnonymitySource creates a source named name. The 5-character hash prefix is
+// appended to rangeURL, e.g. "https://example.com/range/".
+func NewKAnonymitySource(name, rangeURL string) *KAnonymitySource {
+	return &KAnonymitySource{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		name:       name,
+		rangeURL:   rangeURL,
+	}
+}
+
+// Name returns the source name.
+func (s *KAnonymitySource) Name() string { return s.name }
+
+// LookupEmail returns a candidate for each site the email's hash is listed at.
+func (s *KAnonymitySource) LookupEmail(ctx context.Context, email string) ([]Candidate, error) {
+	sum := sha1.Sum([]byte(strings.ToLower(email))) //nolint:gosec // not used for security
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:kAnonymityPrefixLen], hash[kAnonymityPrefixLen:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.rangeURL+prefix, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+	req.Header.Set("Add-Padding", "true") // ask HIBP-compatible servers to pad responses
+
+	// Deliberately uncached: responses describe many unrelated addresses
+	body, err := cache.FetchURL(ctx, nil, s.httpClient, req, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var candidates []Candidate
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		hashSuffix, sites, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+		for _, site := range strings.Split(sites, ";") {
+			site = strings.ToLower(strings.TrimSpace(site))
+			if site == "" {
+				continue
+			}
+			candidates = append(candidates, Candidate{
+				URL:        "https://" + site,
+				Platform:   site,
+				Source:     s.name,
+				Confidence: 0.3,
+			})
+		}
+	}
+	return candidates, scanner.Err()
+}
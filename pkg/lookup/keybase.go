@@ -0,0 +1,81 @@
+package lookup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/analysis"
+)
+
+const keybaseAPIURL = "https://keybase.io/_/api/1.0"
+
+// keybaseSource finds Keybase users who proved ownership of the email's domain.
+// Keybase does not expose lookups by email address, so this only applies to
+// personal or company domains, and the confidence is correspondingly lower.
+type keybaseSource struct{ c *Client }
+
+func (keybaseSource) Name() string { return "keybase" }
+
+func (s keybaseSource) LookupEmail(ctx context.Context, email string) ([]Candidate, error) {
+	_, domain, _ := strings.Cut(email, "@")
+	if domain == "" || analysis.IsFreemail(domain) {
+		return nil, nil
+	}
+
+	apiURL := fmt.Sprintf("%s/user/lookup.json?domain=%s&fields=basics,proofs_summary", keybaseAPIURL, url.QueryEscape(domain))
+	body, err := s.c.fetch(ctx, apiURL, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var resp struct {
+		Them []struct {
+			Basics struct {
+				Username string `json:"username"`
+			} `json:"basics"`
+			ProofsSummary struct {
+				All []struct {
+					ProofType  string `json:"proof_type"`
+					Nametag    string `json:"nametag"`
+					ServiceURL string `json:"service_url"`
+				} `json:"all"`
+			} `json:"proofs_summary"`
+		} `json:"them"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing keybase response: %w", err)
+	}
+
+	var candidates []Candidate
+	for _, u := range resp.Them {
+		if u.Basics.Username == "" {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			URL:        "https://keybase.io/" + u.Basics.Username,
+			Platform:   "keybase",
+			Username:   u.Basics.Username,
+			Source:     s.Name(),
+			Confidence: 0.5,
+		})
+		for _, proof := range u.ProofsSummary.All {
+			if proof.ServiceURL == "" || proof.ProofType == "dns" || proof.ProofType == "generic_web_site" {
+				continue
+			}
+			candidates = append(candidates, Candidate{
+				URL:        proof.ServiceURL,
+				Platform:   proof.ProofType,
+				Username:   proof.Nametag,
+				Source:     s.Name(),
+				Confidence: 0.45,
+			})
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,210 @@
+// Package lookup finds candidate profile URLs from an identifier such as an email
+// address. It is the inverse of the URL-to-profile flow: results are leads to be
+// fetched and verified, not profiles.
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// Candidate is a profile URL believed to belong to the looked-up identity.
+type Candidate struct {
+	URL        string  `json:"url"`
+	Platform   string  `json:"platform,omitempty"`
+	Username   string  `json:"username,omitempty"`
+	Source     string  `json:"source"`     // Comma-separated sources that produced the candidate
+	Confidence float64 `json:"confidence"` // 0.0-1.0
+}
+
+// EmailSource finds candidate profiles for an email address.
+// Implementations should return (nil, nil) when they have no match.
+type EmailSource interface {
+	Name() string
+	LookupEmail(ctx context.Context, email string) ([]Candidate, error)
+}
+
+// Client performs reverse lookups.
+type Client struct {
+	httpClient       *http.Client
+	cache            cache.HTTPCache
+	logger           *slog.Logger
+	githubToken      string
+	emailSources     []EmailSource
+	probes           []Probe
+	probeConcurrency int
+	fetcher          ProfileFetcher
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache            cache.HTTPCache
+	logger           *slog.Logger
+	githubToken      string
+	emailSources     []EmailSource
+	probes           []Probe
+	probeConcurrency int
+	fetcher          ProfileFetcher
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithGitHubToken sets the token used for GitHub commit search, raising its rate limit.
+func WithGitHubToken(token string) Option {
+	return func(c *config) { c.githubToken = token }
+}
+
+// WithEmailSource adds an email source, such as a KAnonymitySource, to the built-in ones.
+func WithEmailSource(src EmailSource) Option {
+	return func(c *config) { c.emailSources = append(c.emailSources, src) }
+}
+
+// New creates a lookup client with the Gravatar, GitHub commit search, Keybase, and
+// PGP keyserver email sources and the DefaultProbes username checks.
+func New(_ context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default(), probes: DefaultProbes, probeConcurrency: defaultProbeConcurrency}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	c := &Client{
+		httpClient:       &http.Client{Timeout: 5 * time.Second},
+		cache:            cfg.cache,
+		logger:           cfg.logger,
+		githubToken:      cfg.githubToken,
+		probes:           cfg.probes,
+		probeConcurrency: max(cfg.probeConcurrency, 1),
+		fetcher:          cfg.fetcher,
+	}
+	c.emailSources = append([]EmailSource{
+		gravatarSource{c},
+		githubCommitSource{c},
+		keybaseSource{c},
+		pgpSource{c},
+	}, cfg.emailSources...)
+
+	return c, nil
+}
+
+// ByEmail queries every email source concurrently and returns the merged candidates,
+// highest confidence first. Candidates found by several sources are merged and their
+// confidence combined. An error is returned only if every source failed.
+func (c *Client) ByEmail(ctx context.Context, email string) ([]Candidate, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address %q: %w", email, err)
+	}
+	email = strings.ToLower(addr.Address)
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []Candidate
+		errs    []error
+	)
+	for _, src := range c.emailSources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found, err := src.LookupEmail(ctx, email)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				c.logger.DebugContext(ctx, "email lookup source failed", "source", src.Name(), "error", err)
+				errs = append(errs, fmt.Errorf("%s: %w", src.Name(), err))
+				return
+			}
+			c.logger.DebugContext(ctx, "email lookup source finished", "source", src.Name(), "candidates", len(found))
+			results = append(results, found...)
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 && len(errs) == len(c.emailSources) {
+		return nil, errors.Join(errs...)
+	}
+	return merge(results), nil
+}
+
+// merge dedupes candidates by URL, combining confidences as independent evidence
+// (1 - Π(1-c)) and sorting the result by confidence.
+func merge(candidates []Candidate) []Candidate {
+	byURL := make(map[string]*Candidate)
+	var order []string
+	for _, cand := range candidates {
+		key := normalizeURL(cand.URL)
+		existing, ok := byURL[key]
+		if !ok {
+			cand := cand
+			byURL[key] = &cand
+			order = append(order, key)
+			continue
+		}
+		existing.Confidence = 1 - (1-existing.Confidence)*(1-cand.Confidence)
+		if !strings.Contains(","+existing.Source+",", ","+cand.Source+",") {
+			existing.Source += "," + cand.Source
+		}
+		if existing.Platform == "" {
+			existing.Platform = cand.Platform
+		}
+		if existing.Username == "" {
+			existing.Username = cand.Username
+		}
+	}
+
+	merged := make([]Candidate, 0, len(order))
+	for _, key := range order {
+		merged = append(merged, *byURL[key])
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Confidence > merged[j].Confidence
+	})
+	return merged
+}
+
+// normalizeURL lowercases a URL and strips the scheme, "www.", and trailing slash for comparison.
+func normalizeURL(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	u = strings.TrimPrefix(u, "www.")
+	return strings.TrimSuffix(u, "/")
+}
+
+// fetch performs a cached GET request with the given headers.
+func (c *Client) fetch(ctx context.Context, urlStr string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+// isNotFound reports whether err is an HTTP 404, which sources treat as "no match".
+func isNotFound(err error) bool {
+	var httpErr *cache.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
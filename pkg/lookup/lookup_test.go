@@ -0,0 +1,187 @@
+package lookup
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // test fixture for the range protocol
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{WithLogger(slog.New(slog.DiscardHandler))}, opts...)
+	c, err := New(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.httpClient.Transport = &mockTransport{mockURL: server.URL}
+	return c
+}
+
+func TestByEmail(t *testing.T) {
+	var gotAuth string
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, ".json") && strings.HasPrefix(r.URL.Path, "/_/api"):
+			if r.URL.Query().Get("domain") != "janedoe.dev" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"them": [{"basics": {"username": "jdoe"}, "proofs_summary": {"all": [
+				{"proof_type": "github", "nametag": "janedoe", "service_url": "https://github.com/janedoe"},
+				{"proof_type": "dns", "nametag": "janedoe.dev", "service_url": "dns://janedoe.dev"}
+			]}}]}`))
+		case strings.HasSuffix(r.URL.Path, ".json"):
+			_, _ = w.Write([]byte(`{"entry": [{
+				"profileUrl": "http://gravatar.com/janedoe",
+				"preferredUsername": "janedoe",
+				"accounts": [{"url": "https://github.com/janedoe", "shortname": "github", "username": "janedoe", "verified": "true"}],
+				"urls": [{"value": "https://janedoe.dev"}]
+			}]}`))
+		case r.URL.Path == "/pks/lookup":
+			if r.URL.Query().Get("search") != "jane@janedoe.dev" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("info:1:1\npub:0123456789ABCDEF0123456789ABCDEF01234567:1:4096:1500000000::\n" +
+				"uid:Jane%20Doe%20%3Cjane%40janedoe.dev%3E:1500000000::\n"))
+		case r.URL.Path == "/search/commits":
+			gotAuth = r.Header.Get("Authorization")
+			_, _ = w.Write([]byte(`{"items": [
+				{"author": {"login": "janedoe", "html_url": "https://github.com/janedoe"}},
+				{"author": {"login": "janedoe", "html_url": "https://github.com/janedoe"}},
+				{"author": null}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}, WithGitHubToken("ghp_test"))
+
+	got, err := c.ByEmail(context.Background(), "Jane Doe <Jane@JaneDoe.dev>")
+	if err != nil {
+		t.Fatalf("ByEmail() error = %v", err)
+	}
+	if gotAuth != "Bearer ghp_test" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+
+	if len(got) == 0 || got[0].URL != "https://github.com/janedoe" {
+		t.Fatalf("top candidate = %+v, want github.com/janedoe", got)
+	}
+	// Found by gravatar (0.9), github commits (0.85), and keybase (0.45)
+	if got[0].Confidence < 0.99 {
+		t.Errorf("combined confidence = %v, want >= 0.99", got[0].Confidence)
+	}
+	for _, src := range []string{"gravatar", "github_commits", "keybase"} {
+		if !strings.Contains(got[0].Source, src) {
+			t.Errorf("Source = %q, want it to include %q", got[0].Source, src)
+		}
+	}
+
+	urls := make(map[string]bool)
+	for _, cand := range got {
+		urls[cand.URL] = true
+	}
+	for _, want := range []string{
+		"http://gravatar.com/janedoe", "https://janedoe.dev", "https://keybase.io/jdoe",
+		"https://keys.openpgp.org/pks/lookup?op=index&options=mr&search=0x0123456789ABCDEF0123456789ABCDEF01234567",
+	} {
+		if !urls[want] {
+			t.Errorf("missing candidate %q in %+v", want, got)
+		}
+	}
+	if urls["dns://janedoe.dev"] {
+		t.Error("DNS proofs should not be returned as candidates")
+	}
+}
+
+func TestByEmailFreemailSkipsKeybase(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/_/api") {
+			t.Errorf("keybase should not be queried for freemail domains")
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	got, err := c.ByEmail(context.Background(), "someone@gmail.com")
+	if err != nil {
+		// The github commit search 404 is an error, but the other sources succeeded
+		t.Fatalf("ByEmail() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %d candidates, want 0", len(got))
+	}
+}
+
+func TestByEmailErrors(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if _, err := c.ByEmail(context.Background(), "not-an-email"); err == nil {
+		t.Error("expected error for invalid email")
+	}
+	if _, err := c.ByEmail(context.Background(), "jane@janedoe.dev"); err == nil {
+		t.Error("expected error when every source fails")
+	}
+}
+
+func TestKAnonymitySource(t *testing.T) {
+	sum := sha1.Sum([]byte("jane@example.com")) //nolint:gosec // test fixture
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write([]byte("0000000000000000000000000000000000A:other.example\n" +
+			hash[5:] + ":Example.org;forum.example.net\n"))
+	}))
+	defer server.Close()
+
+	src := NewKAnonymitySource("breaches", server.URL+"/range/")
+	got, err := src.LookupEmail(context.Background(), "Jane@Example.com")
+	if err != nil {
+		t.Fatalf("LookupEmail() error = %v", err)
+	}
+	if gotPath != "/range/"+hash[:5] {
+		t.Errorf("requested %q, want only the 5-character prefix", gotPath)
+	}
+	if len(got) != 2 || got[0].URL != "https://example.org" || got[0].Source != "breaches" {
+		t.Errorf("LookupEmail() = %+v", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	got := merge([]Candidate{
+		{URL: "https://a.example/x", Source: "one", Confidence: 0.5},
+		{URL: "https://b.example/y/", Source: "one", Confidence: 0.6},
+		{URL: "http://www.b.example/y", Source: "two", Platform: "b", Confidence: 0.5},
+	})
+
+	if len(got) != 2 {
+		t.Fatalf("merge() returned %d candidates, want 2", len(got))
+	}
+	if got[0].URL != "https://b.example/y/" || got[0].Source != "one,two" || got[0].Platform != "b" {
+		t.Errorf("merged candidate = %+v", got[0])
+	}
+	if got[0].Confidence < 0.79 || got[0].Confidence > 0.81 {
+		t.Errorf("combined confidence = %v, want 0.8", got[0].Confidence)
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
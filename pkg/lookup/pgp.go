@@ -0,0 +1,49 @@
+package lookup
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/pgp"
+)
+
+// pgpSource finds OpenPGP keys published for an email address. Each key is a candidate
+// whose profile lists the owner's other user IDs; keys from keyservers that confirm
+// addresses are strong evidence, the others are not.
+type pgpSource struct{ c *Client }
+
+func (pgpSource) Name() string { return "pgp" }
+
+func (s pgpSource) LookupEmail(ctx context.Context, email string) ([]Candidate, error) {
+	var candidates []Candidate
+	for _, ks := range pgp.DefaultKeyservers {
+		body, err := s.c.fetch(ctx, pgp.IndexURL(ks, email), nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		u, err := url.Parse(ks)
+		if err != nil {
+			continue
+		}
+		confidence := 0.4
+		if pgp.VerifiesEmail(u.Hostname()) {
+			confidence = 0.9
+		}
+		for _, key := range pgp.ParseIndex(body) {
+			if key.Revoked {
+				continue
+			}
+			candidates = append(candidates, Candidate{
+				URL:        pgp.IndexURL(ks, "0x"+key.Fingerprint),
+				Platform:   "pgp",
+				Username:   key.Fingerprint,
+				Source:     s.Name(),
+				Confidence: confidence,
+			})
+		}
+	}
+	return candidates, nil
+}
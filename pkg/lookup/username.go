@@ -0,0 +1,210 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// defaultProbeConcurrency caps simultaneous probes; per-domain spacing is enforced by cache.FetchURL.
+const defaultProbeConcurrency = 4
+
+// handlePattern matches handles that are safe to substitute into probe URLs.
+var handlePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Probe checks whether a handle is registered on one platform.
+type Probe struct {
+	Platform string // Platform name, as used by the guess engine
+	URL      string // Profile URL; %s is replaced with the handle
+	CheckURL string // Existence endpoint; %s is replaced with the handle. Defaults to URL.
+	Method   string // http.MethodHead (default) or http.MethodGet
+	// Missing lists status codes meaning "not registered". Defaults to 404.
+	Missing []int
+	// MissingText, if set, marks a 200 GET response containing it as "not registered" (soft 404s).
+	MissingText string
+}
+
+// DefaultProbes are the existence checks used by ByUsername unless WithProbes is given.
+// They favor JSON APIs and plain HEAD requests that answer 404 for unknown handles.
+var DefaultProbes = []Probe{
+	{Platform: "github", URL: "https://github.com/%s", CheckURL: "https://api.github.com/users/%s", Method: http.MethodGet},
+	{Platform: "codeberg", URL: "https://codeberg.org/%s", CheckURL: "https://codeberg.org/api/v1/users/%s", Method: http.MethodGet},
+	{Platform: "devto", URL: "https://dev.to/%s", CheckURL: "https://dev.to/api/users/by_username?url=%s", Method: http.MethodGet},
+	{Platform: "reddit", URL: "https://reddit.com/user/%s", CheckURL: "https://www.reddit.com/user/%s/about.json", Method: http.MethodGet},
+	{
+		Platform: "bluesky",
+		URL:      "https://bsky.app/profile/%s.bsky.social",
+		CheckURL: "https://public.api.bsky.app/xrpc/com.atproto.identity.resolveHandle?handle=%s.bsky.social",
+		Method:   http.MethodGet,
+		Missing:  []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{Platform: "medium", URL: "https://medium.com/@%s"},
+	{Platform: "youtube", URL: "https://youtube.com/@%s"},
+	{Platform: "habr", URL: "https://habr.com/users/%s"},
+	{Platform: "keybase", URL: "https://keybase.io/%s"},
+}
+
+// Presence records whether a handle is registered on a platform and, when a
+// ProfileFetcher is configured, who holds it.
+type Presence struct {
+	Platform   string `json:"platform"`
+	URL        string `json:"url"`
+	Registered bool   `json:"registered"`
+	Status     Status `json:"status,omitempty"` // Empty if registered but not classified
+}
+
+// ProfileFetcher fetches the profile at url; sociopath.Fetch satisfies it.
+type ProfileFetcher func(ctx context.Context, url string) (*profile.Profile, error)
+
+// WithProbes replaces the default username probes.
+func WithProbes(probes ...Probe) Option {
+	return func(c *config) { c.probes = probes }
+}
+
+// WithProbeConcurrency sets how many username probes run at once.
+func WithProbeConcurrency(n int) Option {
+	return func(c *config) { c.probeConcurrency = n }
+}
+
+// WithProfileFetcher makes ByUsername fetch each registered profile and Classify
+// whether a person, or a brand or squatter, holds the handle.
+func WithProfileFetcher(fetch ProfileFetcher) Option {
+	return func(c *config) { c.fetcher = fetch }
+}
+
+// ByUsername probes each configured platform for handle and reports where it is and
+// is not registered, in probe order. Platforms whose check is inconclusive (timeouts,
+// rate limits, unexpected status codes) are omitted. Registered handles are
+// classified only when a ProfileFetcher is configured.
+func (c *Client) ByUsername(ctx context.Context, handle string) ([]Presence, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if !handlePattern.MatchString(handle) {
+		return nil, fmt.Errorf("invalid handle: %q", handle)
+	}
+
+	results := make([]*Presence, len(c.probes))
+	sem := make(chan struct{}, c.probeConcurrency)
+	var wg sync.WaitGroup
+	for i, probe := range c.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			registered, err := c.probe(ctx, probe, handle)
+			if err != nil {
+				c.logger.DebugContext(ctx, "username probe inconclusive", "platform", probe.Platform, "handle", handle, "error", err)
+				return
+			}
+			presence := &Presence{
+				Platform:   probe.Platform,
+				URL:        fmt.Sprintf(probe.URL, handle),
+				Registered: registered,
+			}
+			switch {
+			case !registered:
+				presence.Status = StatusAvailable
+			case c.fetcher != nil:
+				presence.Status = c.classify(ctx, presence.URL)
+			default:
+			}
+			results[i] = presence
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var presence []Presence
+	for _, r := range results {
+		if r != nil {
+			presence = append(presence, *r)
+		}
+	}
+	return presence, nil
+}
+
+// Registered returns ByUsername's answers as a platform to registered map, the form
+// the guess engine uses to prune candidates. Handles held by a brand or squatter
+// are reported as unregistered so that parked accounts are not guessed.
+func (c *Client) Registered(ctx context.Context, handle string) map[string]bool {
+	presence, err := c.ByUsername(ctx, handle)
+	if err != nil {
+		return nil
+	}
+	m := make(map[string]bool, len(presence))
+	for _, p := range presence {
+		m[p.Platform] = p.Registered && p.Status != StatusSquatted
+	}
+	return m
+}
+
+// classify fetches the profile at profileURL and classifies its holder. It returns
+// an empty Status when the profile cannot be fetched or is behind a login.
+func (c *Client) classify(ctx context.Context, profileURL string) Status {
+	p, err := c.fetcher(ctx, profileURL)
+	if err != nil || p == nil || p.Error != "" {
+		c.logger.DebugContext(ctx, "handle holder not classified", "url", profileURL, "error", err)
+		return ""
+	}
+	status := Classify(p)
+	c.logger.DebugContext(ctx, "classified handle holder", "url", profileURL, "status", status)
+	return status
+}
+
+// probe runs a single existence check.
+func (c *Client) probe(ctx context.Context, p Probe, handle string) (bool, error) {
+	checkURL := p.CheckURL
+	if checkURL == "" {
+		checkURL = p.URL
+	}
+	method := p.Method
+	if method == "" {
+		method = http.MethodHead
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf(checkURL, url.PathEscape(handle)), http.NoBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+
+	// HEAD responses have no body, so they must not share cache entries with GETs of the same URL
+	httpCache := c.cache
+	if method != http.MethodGet {
+		httpCache = nil
+	}
+	body, err := cache.FetchURL(ctx, httpCache, c.httpClient, req, nil)
+	if err != nil {
+		var httpErr *cache.HTTPError
+		missing := p.Missing
+		if len(missing) == 0 {
+			missing = []int{http.StatusNotFound}
+		}
+		if errors.As(err, &httpErr) && slices.Contains(missing, httpErr.StatusCode) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if p.MissingText != "" && strings.Contains(string(body), p.MissingText) {
+		return false, nil
+	}
+	return true, nil
+}
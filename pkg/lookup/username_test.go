@@ -0,0 +1,128 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestByUsername(t *testing.T) {
+	probes := []Probe{
+		{Platform: "github", URL: "https://github.com/%s", CheckURL: "https://api.github.com/users/%s", Method: http.MethodGet},
+		{Platform: "medium", URL: "https://medium.com/@%s"},
+		{Platform: "bluesky", URL: "https://bsky.app/profile/%s", CheckURL: "https://bsky.example/resolve?handle=%s", Method: http.MethodGet, Missing: []int{http.StatusBadRequest}},
+		{Platform: "forum", URL: "https://forum.example/u/%s", Method: http.MethodGet, MissingText: "User not found"},
+		{Platform: "flaky", URL: "https://flaky.example/%s"},
+	}
+
+	var headSeen bool
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/users/janedoe":
+			_, _ = w.Write([]byte(`{"login": "janedoe"}`))
+		case r.URL.Path == "/@janedoe":
+			headSeen = r.Method == http.MethodHead
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/resolve":
+			w.WriteHeader(http.StatusBadRequest)
+		case strings.HasPrefix(r.URL.Path, "/u/"):
+			_, _ = w.Write([]byte("<html>User not found</html>"))
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}, WithProbes(probes...), WithProbeConcurrency(2))
+
+	got, err := c.ByUsername(context.Background(), "@janedoe")
+	if err != nil {
+		t.Fatalf("ByUsername() error = %v", err)
+	}
+	if !headSeen {
+		t.Error("probes without a method should use HEAD")
+	}
+
+	want := []Presence{
+		{Platform: "github", URL: "https://github.com/janedoe", Registered: true},
+		{Platform: "medium", URL: "https://medium.com/@janedoe", Registered: false, Status: StatusAvailable},
+		{Platform: "bluesky", URL: "https://bsky.app/profile/janedoe", Registered: false, Status: StatusAvailable},
+		{Platform: "forum", URL: "https://forum.example/u/janedoe", Registered: false, Status: StatusAvailable},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ByUsername() = %+v, want %+v (inconclusive probes omitted)", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ByUsername()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	registered := c.Registered(context.Background(), "janedoe")
+	if !registered["github"] || registered["medium"] {
+		t.Errorf("Registered() = %v", registered)
+	}
+	if _, ok := registered["flaky"]; ok {
+		t.Error("inconclusive platforms should be absent from Registered()")
+	}
+}
+
+func TestByUsernameInvalidHandle(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("no request expected for an invalid handle")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, handle := range []string{"", "../etc/passwd", "jane doe", "a?b=c"} {
+		if _, err := c.ByUsername(context.Background(), handle); err == nil {
+			t.Errorf("ByUsername(%q) expected error", handle)
+		}
+	}
+}
+
+func TestByUsernameClassify(t *testing.T) {
+	probes := []Probe{
+		{Platform: "github", URL: "https://github.com/%s", Method: http.MethodGet},
+		{Platform: "medium", URL: "https://medium.com/@%s", Method: http.MethodGet},
+		{Platform: "reddit", URL: "https://reddit.com/user/%s", Method: http.MethodGet},
+		{Platform: "devto", URL: "https://dev.to/%s", Method: http.MethodGet},
+	}
+	profiles := map[string]*profile.Profile{
+		"https://github.com/janedoe": {
+			Username: "janedoe", Name: "Jane Doe", Bio: "Go developer", Location: "Austin, TX",
+			Posts: []profile.Post{{Type: profile.PostTypeRepository, Title: "widget"}},
+		},
+		"https://medium.com/@janedoe":     {Username: "janedoe", Name: "JaneDoe Inc.", Bio: "The official account of JaneDoe Inc."},
+		"https://reddit.com/user/janedoe": {Username: "janedoe"},
+	}
+	fetch := func(_ context.Context, url string) (*profile.Profile, error) {
+		if p, ok := profiles[url]; ok {
+			return p, nil
+		}
+		return nil, errors.New("fetch failed")
+	}
+
+	c := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, WithProbes(probes...), WithProfileFetcher(fetch))
+
+	got, err := c.ByUsername(context.Background(), "janedoe")
+	if err != nil {
+		t.Fatalf("ByUsername() error = %v", err)
+	}
+	want := map[string]Status{"github": StatusPerson, "medium": StatusSquatted, "reddit": StatusSquatted, "devto": ""}
+	for _, p := range got {
+		if p.Status != want[p.Platform] {
+			t.Errorf("%s status = %q, want %q", p.Platform, p.Status, want[p.Platform])
+		}
+	}
+
+	registered := c.Registered(context.Background(), "janedoe")
+	wantRegistered := map[string]bool{"github": true, "medium": false, "reddit": false, "devto": true}
+	for platform, want := range wantRegistered {
+		if registered[platform] != want {
+			t.Errorf("Registered()[%q] = %v, want %v", platform, registered[platform], want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// handlePattern matches fediverse handles such as "@alice@hachyderm.io". The leading
+// "@" tells them from email addresses, and the domain must have a dot.
+var handlePattern = regexp.MustCompile(`(?i)(?:^|[^\w@/.-])@([a-z0-9_]+(?:[.-][a-z0-9_]+)*)@((?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,})\b`)
+
+// KnownInstance reports whether host is a well-known Mastodon server, whose
+// /@user and /users/user paths are always profiles.
+func KnownInstance(host string) bool {
+	return knownInstances[strings.ToLower(host)]
+}
+
+// FindHandles returns the fediverse handles mentioned in text, such as a bio, as
+// "user@domain" in order of appearance.
+func FindHandles(text string) []string {
+	var handles []string
+	seen := make(map[string]bool)
+	for _, m := range handlePattern.FindAllStringSubmatch(text, -1) {
+		handle := m[1] + "@" + strings.ToLower(m[2])
+		if key := strings.ToLower(handle); !seen[key] {
+			seen[key] = true
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
+
+// HandleURL returns the profile URL for a handle such as "@alice@hachyderm.io" or
+// "alice@hachyderm.io", or "" if handle is not one. The domain is assumed to be the
+// server; Resolve finds the server of handles whose domain is not.
+func HandleURL(handle string) string {
+	user, domain, ok := splitHandle(handle)
+	if !ok {
+		return ""
+	}
+	return "https://" + domain + "/@" + user
+}
+
+func splitHandle(handle string) (user, domain string, ok bool) {
+	user, domain, ok = strings.Cut(strings.TrimPrefix(strings.TrimSpace(handle), "@"), "@")
+	if !ok || user == "" || !strings.Contains(domain, ".") || strings.ContainsAny(domain, "/@") {
+		return "", "", false
+	}
+	return user, strings.ToLower(domain), true
+}
+
+// Canonical returns the URL of the account a Mastodon profile URL shows on its home
+// server: remote views such as "https://mastodon.social/@alice@hachyderm.io" become
+// "https://hachyderm.io/@alice", and "/users/alice" paths become "/@alice". URLs
+// that are not Mastodon profiles are returned unchanged.
+func Canonical(urlStr string) string {
+	parsed, err := url.Parse(urlStr)
+	if err != nil || parsed.Host == "" || !Match(urlStr) {
+		return urlStr
+	}
+	username := extractUsername(parsed.Path)
+	if username == "" {
+		return urlStr
+	}
+	if u := HandleURL(username); u != "" {
+		return u
+	}
+	return "https://" + strings.ToLower(parsed.Host) + "/@" + username
+}
+
+// SameAccount reports whether two Mastodon profile URLs are the same account, seen
+// either on its home server or as a remote view on another.
+func SameAccount(a, b string) bool {
+	if !Match(a) || !Match(b) {
+		return false
+	}
+	return strings.EqualFold(Canonical(a), Canonical(b))
+}
+
+// errNoProfilePage is returned by Resolve for WebFinger answers without a profile URL.
+var errNoProfilePage = errors.New("webfinger: no profile page")
+
+// Resolve asks the handle's domain over WebFinger (RFC 7033) for the account's
+// profile URL. It differs from HandleURL for accounts whose handle domain is not their
+// server, such as "@alice@example.com" hosted at social.example.com.
+func (c *Client) Resolve(ctx context.Context, handle string) (string, error) {
+	user, domain, ok := splitHandle(handle)
+	if !ok {
+		return "", errors.New("invalid fediverse handle: " + handle)
+	}
+	apiURL := "https://" + domain + "/.well-known/webfinger?resource=" + url.QueryEscape("acct:"+user+"@"+domain)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/jrd+json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return "", err
+	}
+	return parseWebFinger(body)
+}
+
+// parseWebFinger returns the profile page in a WebFinger response, falling back to
+// the ActivityPub actor URL, which Mastodon also serves as a page.
+func parseWebFinger(data []byte) (string, error) {
+	var jrd struct {
+		Links []struct {
+			Rel  string `json:"rel"`
+			Type string `json:"type"`
+			Href string `json:"href"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal(data, &jrd); err != nil {
+		return "", err
+	}
+	var actor string
+	for _, l := range jrd.Links {
+		switch {
+		case !strings.HasPrefix(l.Href, "https://"):
+		case l.Rel == "http://webfinger.net/rel/profile-page":
+			return l.Href, nil
+		case l.Rel == "self" && strings.Contains(l.Type, "activity+json") && actor == "":
+			actor = l.Href
+		}
+	}
+	if actor == "" {
+		return "", errNoProfilePage
+	}
+	return actor, nil
+}
@@ -0,0 +1,167 @@
+package mastodon
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+func TestFindHandles(t *testing.T) {
+	bio := "Rustacean. Also @alice@Hachyderm.io and (@alice_2@social.example.co.uk), " +
+		"mail alice@example.com, @twitter_only, https://mastodon.social/@bob@fosstodon.org, @alice@hachyderm.io"
+	got := FindHandles(bio)
+	want := []string{"alice@hachyderm.io", "alice_2@social.example.co.uk"}
+	if !slices.Equal(got, want) {
+		t.Errorf("FindHandles() = %v, want %v", got, want)
+	}
+}
+
+func TestHandleURL(t *testing.T) {
+	tests := []struct{ handle, want string }{
+		{"@alice@hachyderm.io", "https://hachyderm.io/@alice"},
+		{"alice@Fosstodon.org", "https://fosstodon.org/@alice"},
+		{"@alice", ""},
+		{"alice@localhost", ""},
+	}
+	for _, tt := range tests {
+		if got := HandleURL(tt.handle); got != tt.want {
+			t.Errorf("HandleURL(%q) = %q, want %q", tt.handle, got, tt.want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct{ url, want string }{
+		{"https://mastodon.social/@alice@hachyderm.io", "https://hachyderm.io/@alice"},
+		{"https://Hachyderm.io/users/alice", "https://hachyderm.io/@alice"},
+		{"https://hachyderm.io/@alice", "https://hachyderm.io/@alice"},
+		{"https://github.com/alice", "https://github.com/alice"},
+	}
+	for _, tt := range tests {
+		if got := Canonical(tt.url); got != tt.want {
+			t.Errorf("Canonical(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+
+	if !SameAccount("https://mastodon.social/@Alice@hachyderm.io", "https://hachyderm.io/@alice") {
+		t.Error("SameAccount() = false for a remote view and its home URL")
+	}
+	if SameAccount("https://mastodon.social/@alice", "https://hachyderm.io/@alice") {
+		t.Error("SameAccount() = true for namesakes on two servers")
+	}
+}
+
+func TestParseWebFinger(t *testing.T) {
+	jrd := `{"subject":"acct:alice@example.com","links":[
+		{"rel":"self","type":"application/activity+json","href":"https://social.example.com/users/alice"},
+		{"rel":"http://webfinger.net/rel/profile-page","type":"text/html","href":"https://social.example.com/@alice"}]}`
+	if got, err := parseWebFinger([]byte(jrd)); err != nil || got != "https://social.example.com/@alice" {
+		t.Errorf("parseWebFinger() = %q, %v, want the profile page", got, err)
+	}
+	actorOnly := `{"links":[{"rel":"self","type":"application/activity+json","href":"https://social.example.com/users/alice"}]}`
+	if got, err := parseWebFinger([]byte(actorOnly)); err != nil || got != "https://social.example.com/users/alice" {
+		t.Errorf("parseWebFinger() without a profile page = %q, %v, want the actor", got, err)
+	}
+	if _, err := parseWebFinger([]byte(`{"links":[]}`)); err == nil {
+		t.Error("parseWebFinger() without links succeeded")
+	}
+}
+
+func TestFetch_SplitDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Host == "example.com" && r.URL.Path == "/.well-known/webfinger":
+			if r.URL.Query().Get("resource") != "acct:alice@example.com" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(`{"links":[{"rel":"http://webfinger.net/rel/profile-page","href":"https://social.example.com/@alice"}]}`))
+		case r.Host == "social.example.com" && r.URL.Path == "/api/v1/accounts/lookup":
+			_, _ = w.Write([]byte(`{"id":"","username":"alice","display_name":"Alice"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	p, err := client.Fetch(ctx, "https://example.com/@alice")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Name != "Alice" || p.URL != "https://social.example.com/@alice" {
+		t.Errorf("Fetch() = %q at %q, want Alice at her server", p.Name, p.URL)
+	}
+}
+
+// loopbackResolver resolves every name to the loopback address.
+type loopbackResolver struct{}
+
+func (loopbackResolver) LookupNetIP(context.Context, string, string) ([]netip.Addr, error) {
+	return []netip.Addr{netip.MustParseAddr("127.0.0.1")}, nil
+}
+
+func TestFetch_WebFingerToPrivateAddress(t *testing.T) {
+	var mu sync.Mutex
+	var hosts []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hosts = append(hosts, r.Host)
+		mu.Unlock()
+		host, port, _ := net.SplitHostPort(r.Host) //nolint:errcheck // the server is on a port
+		switch r.URL.Path {
+		case "/.well-known/webfinger":
+			// A public-looking home server, which resolves to loopback
+			home := "https://example.com:" + port + "/@alice"
+			_, _ = w.Write([]byte(`{"links":[{"rel":"http://webfinger.net/rel/profile-page","href":"` + home + `"}]}`))
+		case "/api/v1/accounts/lookup":
+			if host != "example.com" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"","username":"alice","display_name":"Alice"}`))
+		case "/@alice":
+			_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Alice (@alice)"></head></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := cache.WithNetwork(context.Background(), cache.Network{Resolver: loopbackResolver{}})
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.httpClient = server.Client()
+
+	start := server.URL + "/@alice"
+	p, err := client.Fetch(ctx, start)
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.URL != start {
+		t.Errorf("Fetch() URL = %q, want the page asked for, not the private home server", p.URL)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, host := range hosts {
+		if strings.HasPrefix(host, "example.com:") {
+			t.Errorf("Fetch() reached the home server at %s", host)
+		}
+	}
+}
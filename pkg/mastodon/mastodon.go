@@ -3,16 +3,15 @@ package mastodon
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"html"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
@@ -21,6 +20,40 @@ import (
 
 const platform = "mastodon"
 
+// locationWords are profile field name words that mark a location, in the languages
+// common on the fediverse. They must match whole words: "ort" is also in "portfolio".
+var locationWords = map[string]bool{
+	"ort": true, "standort": true, "wohnort": true, "stadt": true, "heimat": true,
+	"lieu": true, "ville": true, "pays": true, "ubicación": true, "ciudad": true,
+	"localização": true, "cidade": true, "luogo": true, "città": true,
+	"plaats": true, "woonplaats": true, "lokalizacja": true, "miasto": true,
+	"местоположение": true, "город": true, "страна": true, "місто": true, "розташування": true,
+	"الموقع": true, "المدينة": true, "מיקום": true,
+}
+
+// locationSubstrings mark a location anywhere in a field name, which suits scripts
+// written without spaces.
+var locationSubstrings = []string{
+	"location", "city", "country", "place",
+	"所在地", "場所", "居住地", "地点", "位置", "城市", "위치", "지역",
+}
+
+// isLocationLabel reports whether a profile field named name holds a location.
+func isLocationLabel(name string) bool {
+	lower := strings.ToLower(name)
+	for _, s := range locationSubstrings {
+		if strings.Contains(lower, s) {
+			return true
+		}
+	}
+	for _, word := range strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) }) {
+		if locationWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
 // Known Mastodon instances.
 var knownInstances = map[string]bool{
 	"mastodon.social": true, "mastodon.online": true, "fosstodon.org": true,
@@ -65,6 +98,7 @@ type Client struct {
 	httpClient *http.Client
 	cache      cache.HTTPCache
 	logger     *slog.Logger
+	depth      profile.Depth
 }
 
 // Option configures a Client.
@@ -73,6 +107,7 @@ type Option func(*config)
 type config struct {
 	cache  cache.HTTPCache
 	logger *slog.Logger
+	depth  profile.Depth
 }
 
 // WithHTTPCache sets the HTTP cache.
@@ -85,6 +120,11 @@ func WithLogger(logger *slog.Logger) Option {
 	return func(c *config) { c.logger = logger }
 }
 
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
 // New creates a Mastodon client.
 func New(ctx context.Context, opts ...Option) (*Client, error) {
 	cfg := &config{logger: slog.Default()}
@@ -93,14 +133,10 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
 	}, nil
 }
 
@@ -118,11 +154,19 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 
 	c.logger.InfoContext(ctx, "fetching mastodon profile", "url", urlStr, "username", username)
 
-	// Try API first
-	p, err := c.fetchViaAPI(ctx, parsed.Host, username)
+	// Try API first, leaving half the deadline for the HTML fallback
+	apiCtx, cancel := cache.SubContext(ctx, 2)
+	p, err := c.fetchViaAPI(apiCtx, parsed.Host, username)
+	cancel()
 	if err == nil {
 		p.URL = urlStr
-		return p, nil
+		return c.followMoves(ctx, p), nil
+	}
+
+	// The host may only serve the handle's domain, as for "@alice@example.com"
+	// hosted at social.example.com; WebFinger names the server
+	if p := c.fetchViaWebFinger(ctx, parsed.Host, username); p != nil {
+		return c.followMoves(ctx, p), nil
 	}
 
 	c.logger.Debug("API fetch failed, falling back to HTML", "error", err)
@@ -139,7 +183,7 @@ func (c *Client) fetchViaAPI(ctx context.Context, host, username string) (*profi
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
@@ -152,17 +196,80 @@ func (c *Client) fetchViaAPI(ctx context.Context, host, username string) (*profi
 	}
 
 	// Fetch recent posts if we have an account ID
-	if accountID != "" {
-		posts, lastActive := c.fetchStatuses(ctx, host, accountID, 50)
+	if limit := c.depth.PostLimit(); accountID != "" && limit > 0 && cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		posts, lastActive := c.fetchStatuses(ctx, host, accountID, limit)
 		p.Posts = posts
-		if lastActive != "" && lastActive > p.UpdatedAt {
-			p.UpdatedAt = lastActive
-		}
+		p.UpdateLastActive(lastActive)
 	}
 
 	return p, nil
 }
 
+// fetchViaWebFinger fetches the account that host's WebFinger names for username
+// from its server, or returns nil if it is on host itself or cannot be found. The
+// server may be any host the answer names, so connections to it are refused private
+// addresses.
+func (c *Client) fetchViaWebFinger(ctx context.Context, host, username string) *profile.Profile {
+	if strings.Contains(username, "@") || !cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		return nil
+	}
+	home, err := c.Resolve(ctx, username+"@"+host)
+	if err != nil {
+		c.logger.DebugContext(ctx, "webfinger lookup failed", "host", host, "username", username, "error", err)
+		return nil
+	}
+	parsed, err := url.Parse(home)
+	if err != nil || strings.EqualFold(parsed.Host, host) {
+		return nil
+	}
+	homeUser := extractUsername(parsed.Path)
+	if homeUser == "" {
+		return nil
+	}
+	p, err := c.fetchViaAPI(cache.WithPublicOnly(ctx), parsed.Host, homeUser)
+	if err != nil {
+		c.logger.DebugContext(ctx, "webfinger home server fetch failed", "url", home, "error", err)
+		return nil
+	}
+	p.URL = home
+	return p
+}
+
+// maxMoves bounds how many account moves followMoves follows, in case of a cycle.
+const maxMoves = 3
+
+// followMoves returns the account p moved to, if its FieldMovedTo is set and that
+// account can be fetched, with p's address and those of any earlier moves recorded
+// in FieldMovedFrom. Otherwise it returns p, which keeps FieldMovedTo. Accounts can
+// claim to have moved anywhere, so connections to private addresses are refused.
+func (c *Client) followMoves(ctx context.Context, p *profile.Profile) *profile.Profile {
+	ctx = cache.WithPublicOnly(ctx)
+	var from []string
+	for range maxMoves {
+		target := p.Fields[profile.FieldMovedTo]
+		if target == "" || !cache.HasBudget(ctx, cache.MinOptionalBudget) {
+			break
+		}
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Host == "" || extractUsername(parsed.Path) == "" {
+			break
+		}
+		c.logger.InfoContext(ctx, "following moved mastodon account", "from", p.URL, "to", target)
+		next, err := c.fetchViaAPI(ctx, parsed.Host, extractUsername(parsed.Path))
+		if err != nil {
+			c.logger.DebugContext(ctx, "moved account fetch failed", "url", target, "error", err)
+			break
+		}
+		next.URL = target
+		from = append([]string{p.URL}, from...)
+		p = next
+	}
+	if len(from) > 0 {
+		p.Fields[profile.FieldMovedFrom] = strings.Join(from, ", ")
+	}
+	return p
+}
+
 func (*Client) parseAPIResponse(data []byte) (*profile.Profile, string, error) {
 	var acc struct {
 		ID          string `json:"id"`
@@ -174,6 +281,9 @@ func (*Client) parseAPIResponse(data []byte) (*profile.Profile, string, error) {
 			Name  string `json:"name"`
 			Value string `json:"value"`
 		} `json:"fields"`
+		Moved *struct {
+			URL string `json:"url"`
+		} `json:"moved"`
 	}
 
 	if err := json.Unmarshal(data, &acc); err != nil {
@@ -185,19 +295,17 @@ func (*Client) parseAPIResponse(data []byte) (*profile.Profile, string, error) {
 		Authenticated: false,
 		Username:      acc.Username,
 		Name:          acc.DisplayName,
-		Bio:           stripHTML(acc.Note),
+		Bio:           htmlutil.Text(acc.Note),
 		Fields:        make(map[string]string),
 	}
 
 	// Extract fields and look for location
 	for _, f := range acc.Fields {
-		name := stripHTML(f.Name)
-		value := stripHTML(f.Value)
+		name := htmlutil.Text(f.Name)
+		value := htmlutil.Text(f.Value)
 		p.Fields[name] = value
 
-		lower := strings.ToLower(name)
-		if strings.Contains(lower, "location") || strings.Contains(lower, "city") ||
-			strings.Contains(lower, "country") || strings.Contains(lower, "place") {
+		if isLocationLabel(name) {
 			p.Location = value
 		}
 
@@ -215,6 +323,10 @@ func (*Client) parseAPIResponse(data []byte) (*profile.Profile, string, error) {
 		p.CreatedAt = acc.CreatedAt
 	}
 
+	if acc.Moved != nil && acc.Moved.URL != "" {
+		p.Fields[profile.FieldMovedTo] = acc.Moved.URL
+	}
+
 	return p, acc.ID, nil
 }
 
@@ -223,17 +335,30 @@ func (c *Client) fetchViaHTML(ctx context.Context, urlStr, username string) (*pr
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.parseHTML(body, urlStr, username), nil
+	return parseHTML(body, urlStr, username), nil
 }
 
-func (*Client) parseHTML(data []byte, urlStr, username string) *profile.Profile {
+// Parse extracts a profile from a Mastodon profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	username := extractUsername(parsed.Path)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseHTML(data, urlStr, username), nil
+}
+
+func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	content := string(data)
 
 	p := &profile.Profile{
@@ -264,7 +389,7 @@ func (c *Client) fetchStatuses(ctx context.Context, host, accountID string, limi
 		return nil, ""
 	}
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
@@ -281,7 +406,7 @@ func (c *Client) fetchStatuses(ctx context.Context, host, accountID string, limi
 	}
 
 	for i, s := range statuses {
-		text := stripHTML(s.Content)
+		text := htmlutil.Text(s.Content)
 		if text == "" {
 			continue
 		}
@@ -311,37 +436,11 @@ func extractUsername(path string) string {
 	return ""
 }
 
-func stripHTML(s string) string {
-	s = html.UnescapeString(s)
-	// Replace common block-level tags with newlines
-	s = strings.ReplaceAll(s, "<br>", "\n")
-	s = strings.ReplaceAll(s, "<br/>", "\n")
-	s = strings.ReplaceAll(s, "<br />", "\n")
-	s = strings.ReplaceAll(s, "</p>", "\n")
-	s = strings.ReplaceAll(s, "</div>", "\n")
-
-	// Remove all other HTML tags
-	re := regexp.MustCompile(`<[^>]+>`)
-	s = re.ReplaceAllString(s, "")
-
-	// Normalize whitespace: replace multiple newlines with single newlines,
-	// but preserve intentional line breaks by converting to spaces
-	lines := strings.Split(s, "\n")
-	var cleaned []string
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			cleaned = append(cleaned, line)
-		}
-	}
-
-	// Join with newlines to preserve paragraph structure
-	return strings.Join(cleaned, "\n")
-}
+// hrefPattern matches link targets in profile field HTML.
+var hrefPattern = regexp.MustCompile(`href=["']([^"']+)["']`)
 
 func extractURLs(htmlContent string) []string {
-	re := regexp.MustCompile(`href=["']([^"']+)["']`)
-	matches := re.FindAllStringSubmatch(htmlContent, -1)
+	matches := hrefPattern.FindAllStringSubmatch(htmlContent, -1)
 	var urls []string
 	for _, m := range matches {
 		if len(m) > 1 && strings.HasPrefix(m[1], "http") {
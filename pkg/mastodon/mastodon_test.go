@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
 func TestMatch(t *testing.T) {
@@ -60,79 +62,6 @@ func TestExtractUsername(t *testing.T) {
 	}
 }
 
-func TestStripHTML(t *testing.T) {
-	tests := []struct {
-		name  string
-		input string
-		want  string
-	}{
-		{
-			name:  "basic paragraph",
-			input: "<p>Hello</p>",
-			want:  "Hello",
-		},
-		{
-			name:  "multiple paragraphs",
-			input: "<p>Hello</p><p>World</p>",
-			want:  "Hello\nWorld",
-		},
-		{
-			name:  "HTML entities",
-			input: "Hello &amp; World",
-			want:  "Hello & World",
-		},
-		{
-			name:  "links",
-			input: "<a href='url'>link</a>",
-			want:  "link",
-		},
-		{
-			name:  "br tag",
-			input: "Line 1<br>Line 2",
-			want:  "Line 1\nLine 2",
-		},
-		{
-			name:  "br self-closing",
-			input: "Line 1<br/>Line 2",
-			want:  "Line 1\nLine 2",
-		},
-		{
-			name:  "br with space",
-			input: "Line 1<br />Line 2",
-			want:  "Line 1\nLine 2",
-		},
-		{
-			name:  "div tags",
-			input: "<div>Block 1</div><div>Block 2</div>",
-			want:  "Block 1\nBlock 2",
-		},
-		{
-			name:  "complex bio with multiple breaks",
-			input: "KD4UHP - based out of Carrboro, NC<br>founder &amp; CEO @ codeGROOVE<br />former Director of Security @ Chainguard &amp; Xoogler<br/>#unix #infosec #bikes",
-			want:  "KD4UHP - based out of Carrboro, NC\nfounder & CEO @ codeGROOVE\nformer Director of Security @ Chainguard & Xoogler\n#unix #infosec #bikes",
-		},
-		{
-			name:  "empty lines removed",
-			input: "<p>Line 1</p><p></p><p>Line 2</p>",
-			want:  "Line 1\nLine 2",
-		},
-		{
-			name:  "whitespace normalized",
-			input: "<p>  Line 1  </p><br/><p>   Line 2   </p>",
-			want:  "Line 1\nLine 2",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := stripHTML(tt.input)
-			if got != tt.want {
-				t.Errorf("stripHTML(%q) = %q, want %q", tt.input, got, tt.want)
-			}
-		})
-	}
-}
-
 func TestNew(t *testing.T) {
 	ctx := context.Background()
 	client, err := New(ctx)
@@ -198,6 +127,50 @@ func TestFetch_ViaAPI(t *testing.T) {
 	}
 }
 
+func TestFetch_MovedAccount(t *testing.T) {
+	accounts := map[string]string{
+		"alice": `{"id": "1", "username": "alice", "display_name": "Alice",
+			"moved": {"username": "alice", "url": "https://hachyderm.io/@alice"}}`,
+		"alice@hachyderm.io": `{"id": "2", "username": "alice", "display_name": "Alice Liddell",
+			"fields": [{"name": "Location", "value": "Wonderland"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acct := r.URL.Query().Get("acct")
+		if r.Host == "hachyderm.io" {
+			acct += "@hachyderm.io"
+		}
+		body, ok := accounts[acct]
+		if !ok || r.URL.Path != "/api/v1/accounts/lookup" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	p, err := client.Fetch(ctx, "https://mastodon.social/@alice")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.URL != "https://hachyderm.io/@alice" || p.Name != "Alice Liddell" {
+		t.Errorf("URL, Name = %q, %q; want the account it moved to", p.URL, p.Name)
+	}
+	if got := p.MovedFrom(); len(got) != 1 || got[0] != "https://mastodon.social/@alice" {
+		t.Errorf("MovedFrom() = %v, want [https://mastodon.social/@alice]", got)
+	}
+	if _, ok := p.Fields[profile.FieldMovedTo]; ok {
+		t.Errorf("moved_to = %q, want unset on the new account", p.Fields[profile.FieldMovedTo])
+	}
+}
+
 type mockTransport struct {
 	mockURL string
 }
@@ -251,6 +224,22 @@ func TestFetch_ViaHTML(t *testing.T) {
 	}
 }
 
+func TestParse(t *testing.T) {
+	page := []byte(`<title>@johndoe - Mastodon</title>
+<meta name="description" content="Hello, I'm John Doe">`)
+
+	p, err := Parse(context.Background(), page, "https://mastodon.social/@johndoe")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if p.Platform != "mastodon" || p.Username != "johndoe" || p.URL != "https://mastodon.social/@johndoe" {
+		t.Errorf("Parse() = %+v", p)
+	}
+	if _, err := Parse(context.Background(), page, "https://mastodon.social/about"); err == nil {
+		t.Error("Parse() accepted a URL without a username")
+	}
+}
+
 func TestFetch_InvalidURL(t *testing.T) {
 	ctx := context.Background()
 	client, err := New(ctx)
@@ -310,6 +299,42 @@ func TestParseAPIResponse(t *testing.T) {
 			wantName:     "User Two",
 			wantLocation: "London",
 		},
+		{
+			name: "Japanese location field",
+			json: `{
+				"username": "tanaka",
+				"display_name": "田中 太郎",
+				"note": "<p>エンジニア</p>",
+				"fields": [{"name": "所在地", "value": "東京都"}]
+			}`,
+			wantUsername: "tanaka",
+			wantName:     "田中 太郎",
+			wantLocation: "東京都",
+		},
+		{
+			name: "Cyrillic location field",
+			json: `{
+				"username": "ivan",
+				"display_name": "Иван Петров",
+				"note": "",
+				"fields": [{"name": "Город", "value": "Санкт-Петербург"}]
+			}`,
+			wantUsername: "ivan",
+			wantName:     "Иван Петров",
+			wantLocation: "Санкт-Петербург",
+		},
+		{
+			name: "Arabic location field after a portfolio",
+			json: `{
+				"username": "layla",
+				"display_name": "ليلى",
+				"note": "",
+				"fields": [{"name": "الموقع", "value": "القاهرة، مصر"}, {"name": "Portfolio", "value": "layla.dev"}]
+			}`,
+			wantUsername: "layla",
+			wantName:     "ليلى",
+			wantLocation: "القاهرة، مصر",
+		},
 		{
 			name:    "invalid json",
 			json:    `{invalid}`,
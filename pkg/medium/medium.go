@@ -94,6 +94,18 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), normalizedURL, username)
 }
 
+// Parse extracts a profile from a Medium profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), fmt.Sprintf("https://medium.com/@%s", username), username)
+}
+
+// followerPattern matches a follower count such as "1.2K Followers".
+var followerPattern = regexp.MustCompile(`(\d+(?:\.\d+)?[KMk]?)\s*(?:Followers|followers)`)
+
 func parseProfile(html, url, username string) (*profile.Profile, error) {
 	// Detect error pages before attempting to parse
 	lowerHTML := strings.ToLower(html)
@@ -138,9 +150,8 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	prof.Bio = htmlutil.Description(html)
 
 	// Try to extract follower count
-	followerPattern := regexp.MustCompile(`(\d+(?:\.\d+)?[KMk]?)\s*(?:Followers|followers)`)
 	if matches := followerPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["followers"] = matches[1]
+		prof.Fields[profile.FieldFollowers] = matches[1]
 	}
 
 	// Extract social links
@@ -163,20 +174,24 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// Usernames in medium.com/@alice and medium.com/user/alice URLs.
+var (
+	usernamePattern = regexp.MustCompile(`medium\.com/@([^/?#]+)`)
+	userIDPattern   = regexp.MustCompile(`medium\.com/user/([^/?#]+)`)
+)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
 	urlStr = strings.TrimPrefix(urlStr, "http://")
 
 	// Extract medium.com/@username pattern
-	re := regexp.MustCompile(`medium\.com/@([^/?#]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
 	// Also try /user/ pattern
-	re2 := regexp.MustCompile(`medium\.com/user/([^/?#]+)`)
-	if matches := re2.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := userIDPattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -0,0 +1,183 @@
+// Package meetup fetches Meetup.com member profiles.
+package meetup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "meetup"
+
+// memberPattern matches /members/ID and /GROUP/members/ID.
+var memberPattern = regexp.MustCompile(`(?i)meetup\.com/(?:[\w-]+/)?members/(\d+)`)
+
+// Match returns true if the URL is a Meetup member profile URL.
+func Match(urlStr string) bool {
+	return memberPattern.MatchString(urlStr)
+}
+
+// AuthRequired returns false because member profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles Meetup requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Meetup client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Meetup member profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	id := extractMemberID(urlStr)
+	if id == "" {
+		return nil, fmt.Errorf("could not extract member ID from: %s", urlStr)
+	}
+
+	normalizedURL := "https://www.meetup.com/members/" + id + "/"
+	c.logger.InfoContext(ctx, "fetching meetup profile", "url", normalizedURL, "member_id", id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, id)
+}
+
+// Parse extracts a profile from a Meetup member page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	id := extractMemberID(urlStr)
+	if id == "" {
+		return nil, fmt.Errorf("could not extract member ID from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://www.meetup.com/members/"+id+"/", id)
+}
+
+var (
+	ogTitlePattern = regexp.MustCompile(`(?i)<meta[^>]+property="og:title"[^>]+content="([^"]+)"`)
+	// The page embeds the member as JSON; string values are captured with their quotes
+	bioPattern    = regexp.MustCompile(`"bio":("(?:[^"\\]|\\.)*")`)
+	cityPattern   = regexp.MustCompile(`"city":("(?:[^"\\]|\\.)*")`)
+	statePattern  = regexp.MustCompile(`"state":("(?:[^"\\]|\\.)*")`)
+	joinedPattern = regexp.MustCompile(`"(?:joinTime|joinedDate|memberSince)":("(?:[^"\\]|\\.)*")`)
+	groupPattern  = regexp.MustCompile(`"groupName":("(?:[^"\\]|\\.)*")`)
+)
+
+func parseProfile(content, urlStr, id string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: id,
+		Fields:   make(map[string]string),
+	}
+
+	if m := ogTitlePattern.FindStringSubmatch(content); m != nil {
+		p.Name = strings.TrimSpace(html.UnescapeString(m[1]))
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSpace(strings.TrimSuffix(htmlutil.Title(content), "| Meetup"))
+	}
+	if p.Name == "" || strings.EqualFold(p.Name, "Meetup") {
+		return nil, errors.New("profile not found (no member name)")
+	}
+
+	p.Bio = jsonString(bioPattern, content)
+	city, state := jsonString(cityPattern, content), jsonString(statePattern, content)
+	switch {
+	case city != "" && state != "":
+		p.Location = city + ", " + strings.ToUpper(state)
+	case city != "":
+		p.Location = city
+	}
+	p.CreatedAt = jsonString(joinedPattern, content)
+
+	var groups []string
+	seen := make(map[string]bool)
+	for _, m := range groupPattern.FindAllStringSubmatch(content, -1) {
+		if name := unquote(m[1]); name != "" && !seen[name] {
+			seen[name] = true
+			groups = append(groups, name)
+		}
+	}
+	if len(groups) > 0 {
+		p.Fields["groups"] = strings.Join(groups, ", ")
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+// jsonString returns the first JSON string value pattern captures, decoded.
+func jsonString(pattern *regexp.Regexp, content string) string {
+	if m := pattern.FindStringSubmatch(content); m != nil {
+		return unquote(m[1])
+	}
+	return ""
+}
+
+func unquote(quoted string) string {
+	var s string
+	if err := json.Unmarshal([]byte(quoted), &s); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+func extractMemberID(urlStr string) string {
+	if m := memberPattern.FindStringSubmatch(urlStr); m != nil {
+		return m[1]
+	}
+	return ""
+}
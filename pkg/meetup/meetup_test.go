@@ -0,0 +1,69 @@
+package meetup
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.meetup.com/members/123456789/", true},
+		{"https://www.meetup.com/golang-pdx/members/123456789/", true},
+		{"meetup.com/members/42", true},
+		{"https://www.meetup.com/golang-pdx/", false},
+		{"https://www.meetup.com/golang-pdx/events/300000000/", false},
+		{"https://example.com/members/123", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Meetup should not require auth")
+	}
+}
+
+const memberHTML = `<html><head><title>Jane Doe | Meetup</title>
+<meta property="og:title" content="Jane Doe">
+</head><body>
+<script id="__NEXT_DATA__" type="application/json">{"props":{"member":{"name":"Jane Doe","bio":"Gopher, organizer, \"coffee\" enthusiast","city":"Portland","state":"or","joinTime":"2015-03-02T18:00:00Z",
+"memberships":[{"groupName":"Go PDX"},{"groupName":"Rust PDX"},{"groupName":"Go PDX"}],
+"social":[{"url":"https://twitter.com/janedoe"},{"url":"https://github.com/janedoe"}]}}}</script>
+</body></html>`
+
+func TestParseProfile(t *testing.T) {
+	p, err := parseProfile(memberHTML, "https://www.meetup.com/members/123/", "123")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Username != "123" {
+		t.Errorf("Name = %q, Username = %q", p.Name, p.Username)
+	}
+	if p.Bio != `Gopher, organizer, "coffee" enthusiast` {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Location != "Portland, OR" {
+		t.Errorf("Location = %q", p.Location)
+	}
+	if p.CreatedAt != "2015-03-02T18:00:00Z" {
+		t.Errorf("CreatedAt = %q", p.CreatedAt)
+	}
+	if p.Fields["groups"] != "Go PDX, Rust PDX" {
+		t.Errorf("groups = %q", p.Fields["groups"])
+	}
+	if len(p.SocialLinks) != 2 {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	if _, err := parseProfile(`<html><title>Meetup</title></html>`, "https://www.meetup.com/members/1/", "1"); err == nil {
+		t.Error("parseProfile(no member) should fail")
+	}
+}
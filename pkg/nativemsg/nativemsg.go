@@ -0,0 +1,202 @@
+// Package nativemsg implements the native messaging host a companion browser extension
+// talks to. As the user browses, the extension sends the HTML of profile pages they
+// visit, and any LinkedIn Voyager API responses those pages load, and the host parses
+// them and saves the profiles to a local store, building a personal profile database
+// without sociopath making any requests of its own.
+//
+// Messages in both directions are JSON objects preceded by their length as a 32-bit
+// integer in native byte order, as Chrome and Firefox define native messaging.
+package nativemsg
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+)
+
+// HostName is the name the extension connects to, and the manifest is installed under.
+const HostName = "dev.codegroove.sociopath"
+
+// DefaultRunID is the store run that profiles sent by the extension are saved under.
+const DefaultRunID = "browser"
+
+// Browsers cap messages to the host at 64 MiB and messages from it at 1 MiB.
+const (
+	MaxRequestSize  = 64 << 20
+	MaxResponseSize = 1 << 20
+)
+
+// Request types.
+const (
+	TypePing    = "ping"    // Checks that the host is running
+	TypePage    = "page"    // The HTML of a visited page
+	TypeVoyager = "voyager" // LinkedIn Voyager API responses a visited page loaded
+)
+
+// Request is a message from the extension.
+type Request struct {
+	ID        string            `json:"id,omitempty"` // Echoed in the response
+	Type      string            `json:"type"`
+	URL       string            `json:"url,omitempty"`
+	Platform  string            `json:"platform,omitempty"` // Detected from URL if empty
+	HTML      string            `json:"html,omitempty"`
+	Responses []json.RawMessage `json:"responses,omitempty"` // Voyager response bodies
+}
+
+// Response is the host's reply to a Request.
+type Response struct {
+	ID       string  `json:"id,omitempty"`
+	Error    string  `json:"error,omitempty"`
+	Profiles []Saved `json:"profiles,omitempty"`
+}
+
+// Saved identifies a profile the host saved.
+type Saved struct {
+	URL      string `json:"url"`
+	Platform string `json:"platform"`
+	Username string `json:"username,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// ReadRequest reads one message from r. It returns io.EOF when the browser closes the
+// connection.
+func ReadRequest(r io.Reader) (*Request, error) {
+	var n uint32
+	if err := binary.Read(r, binary.NativeEndian, &n); err != nil {
+		return nil, err
+	}
+	if n > MaxRequestSize {
+		return nil, fmt.Errorf("message of %d bytes exceeds the %d byte limit", n, MaxRequestSize)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("truncated message: %w", err)
+	}
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+	return &req, nil
+}
+
+// WriteResponse writes one message to w.
+func WriteResponse(w io.Writer, resp *Response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if len(data) > MaxResponseSize {
+		return fmt.Errorf("response of %d bytes exceeds the %d byte limit", len(data), MaxResponseSize)
+	}
+	if err := binary.Write(w, binary.NativeEndian, uint32(len(data))); err != nil { //nolint:gosec // bounded above
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// Host parses pages sent by the extension and saves the profiles in them.
+type Host struct {
+	store  store.Store
+	logger *slog.Logger
+	runID  string
+	opts   []sociopath.Option
+}
+
+// NewHost returns a host saving profiles to st under runID, parsing them with opts.
+func NewHost(st store.Store, runID string, logger *slog.Logger, opts ...sociopath.Option) *Host {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Host{store: st, runID: runID, logger: logger, opts: append([]sociopath.Option{sociopath.WithLogger(logger)}, opts...)}
+}
+
+// Serve answers requests read from r on w until r is closed. Stdout is the only way to
+// reply, so logging must go elsewhere, such as stderr, which browsers keep.
+func (h *Host) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	for {
+		req, err := ReadRequest(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := WriteResponse(w, h.Handle(ctx, req)); err != nil {
+			return err
+		}
+	}
+}
+
+// Handle parses and saves the profiles in req.
+func (h *Host) Handle(ctx context.Context, req *Request) *Response {
+	resp := &Response{ID: req.ID}
+	var profiles []*profile.Profile
+	switch req.Type {
+	case TypePing:
+		return resp
+	case TypePage:
+		p, err := sociopath.ParseHTML(ctx, req.Platform, []byte(req.HTML), req.URL, h.opts...)
+		if err != nil {
+			resp.Error = err.Error()
+			return resp
+		}
+		profiles = []*profile.Profile{p}
+	case TypeVoyager:
+		responses := make([][]byte, len(req.Responses))
+		for i, r := range req.Responses {
+			responses[i] = r
+		}
+		var err error
+		if profiles, err = sociopath.ParseVoyager(ctx, responses, h.opts...); err != nil {
+			resp.Error = err.Error()
+			return resp
+		}
+	default:
+		resp.Error = fmt.Sprintf("unknown request type %q", req.Type)
+		return resp
+	}
+
+	for _, p := range profiles {
+		if err := h.store.SaveProfile(ctx, h.runID, p); err != nil {
+			h.logger.ErrorContext(ctx, "failed to save profile", "url", p.URL, "error", err)
+			resp.Error = err.Error()
+			return resp
+		}
+		h.logger.InfoContext(ctx, "saved profile from browser", "url", p.URL, "platform", p.Platform)
+		resp.Profiles = append(resp.Profiles, Saved{URL: p.URL, Platform: p.Platform, Username: p.Username, Name: p.Name})
+	}
+	return resp
+}
+
+// Manifest is a native messaging host manifest, which tells the browser how to start
+// the host and which extensions may connect to it.
+type Manifest struct {
+	Name              string   `json:"name"`
+	Description       string   `json:"description"`
+	Path              string   `json:"path"`
+	Type              string   `json:"type"`
+	AllowedOrigins    []string `json:"allowed_origins,omitempty"`    // Chrome: "chrome-extension://<id>/"
+	AllowedExtensions []string `json:"allowed_extensions,omitempty"` // Firefox: extension IDs
+}
+
+// NewManifest returns the manifest for the host at path, allowing the given Chrome
+// extension origins and Firefox extension IDs to connect.
+func NewManifest(path string, origins, extensionIDs []string) *Manifest {
+	return &Manifest{
+		Name:              HostName,
+		Description:       "sociopath profile collector",
+		Path:              path,
+		Type:              "stdio",
+		AllowedOrigins:    origins,
+		AllowedExtensions: extensionIDs,
+	}
+}
@@ -0,0 +1,113 @@
+package nativemsg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+)
+
+func writeRequest(t *testing.T, w io.Writer, req *Request) {
+	t.Helper()
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := binary.Write(w, binary.NativeEndian, uint32(len(data))); err != nil { //nolint:gosec // test data
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readResponse(t *testing.T, r io.Reader) *Response {
+	t.Helper()
+	var n uint32
+	if err := binary.Read(r, binary.NativeEndian, &n); err != nil {
+		t.Fatalf("reading response length: %v", err)
+	}
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		t.Fatal(err)
+	}
+	var resp Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return &resp
+}
+
+func TestServe(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var in bytes.Buffer
+	writeRequest(t, &in, &Request{ID: "1", Type: TypePing})
+	writeRequest(t, &in, &Request{ID: "2", Type: TypePage, URL: "https://github.com/jane",
+		HTML: `<span class="p-name vcard-fullname" itemprop="name">Jane Doe</span>`})
+	writeRequest(t, &in, &Request{ID: "3", Type: TypeVoyager, Responses: []json.RawMessage{json.RawMessage(
+		`{"data": {"*elements": ["urn:li:fsd_profile:ACoJane"]}, "included": [{"$type": "com.linkedin.voyager.dash.identity.profile.Profile", "entityUrn": "urn:li:fsd_profile:ACoJane", "firstName": "Jane", "lastName": "Doe", "publicIdentifier": "janedoe"}]}`,
+	)}})
+	writeRequest(t, &in, &Request{ID: "4", Type: TypePage, Platform: "myspace", URL: "https://myspace.com/jane"})
+	writeRequest(t, &in, &Request{ID: "5", Type: "delete"})
+
+	var out bytes.Buffer
+	if err := NewHost(st, DefaultRunID, nil).Serve(ctx, &in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	if resp := readResponse(t, &out); resp.ID != "1" || resp.Error != "" {
+		t.Errorf("ping response = %+v", resp)
+	}
+	if resp := readResponse(t, &out); resp.ID != "2" || len(resp.Profiles) != 1 || resp.Profiles[0].Platform != "github" || resp.Profiles[0].Name != "Jane Doe" {
+		t.Errorf("page response = %+v", resp)
+	}
+	if resp := readResponse(t, &out); resp.ID != "3" || len(resp.Profiles) != 1 || resp.Profiles[0].URL != "https://www.linkedin.com/in/janedoe" {
+		t.Errorf("voyager response = %+v", resp)
+	}
+	for _, id := range []string{"4", "5"} {
+		if resp := readResponse(t, &out); resp.ID != id || resp.Error == "" {
+			t.Errorf("response %s = %+v, want an error", id, resp)
+		}
+	}
+
+	saved, err := st.Profiles(ctx, DefaultRunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 2 || saved[0].URL != "https://github.com/jane" || saved[1].Platform != "linkedin" {
+		t.Errorf("saved profiles = %+v", saved)
+	}
+}
+
+func TestReadRequestLimits(t *testing.T) {
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.NativeEndian, uint32(MaxRequestSize+1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRequest(&buf); err == nil {
+		t.Error("ReadRequest() accepted an oversized message")
+	}
+
+	buf.Reset()
+	if err := binary.Write(&buf, binary.NativeEndian, uint32(10)); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString("{}")
+	if _, err := ReadRequest(&buf); err == nil {
+		t.Error("ReadRequest() accepted a truncated message")
+	}
+
+	big := &Response{Error: string(bytes.Repeat([]byte("x"), MaxResponseSize))}
+	if err := WriteResponse(io.Discard, big); err == nil {
+		t.Error("WriteResponse() wrote a response over the browser's limit")
+	}
+}
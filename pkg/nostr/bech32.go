@@ -0,0 +1,159 @@
+package nostr
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidKey is returned for npub, nprofile, and hex keys that do not decode to a
+// 32-byte public key.
+var ErrInvalidKey = errors.New("invalid nostr key")
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// DecodeNpub returns the hex public key an "npub1..." key (NIP-19) encodes.
+func DecodeNpub(npub string) (string, error) {
+	hrp, data, err := bech32Decode(npub)
+	if err != nil {
+		return "", err
+	}
+	if hrp != "npub" || len(data) != 32 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, npub)
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// EncodeNpub returns the "npub1..." form of a hex public key.
+func EncodeNpub(pubkey string) (string, error) {
+	data, err := hex.DecodeString(pubkey)
+	if err != nil || len(data) != 32 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidKey, pubkey)
+	}
+	return bech32Encode("npub", data)
+}
+
+// decodeNprofile returns the public key and relay hints an "nprofile1..." key encodes.
+func decodeNprofile(nprofile string) (pubkey string, relays []string, err error) {
+	hrp, data, err := bech32Decode(nprofile)
+	if err != nil {
+		return "", nil, err
+	}
+	if hrp != "nprofile" {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidKey, nprofile)
+	}
+	// TLV entries: type 0 is the public key, type 1 a relay URL
+	for len(data) >= 2 {
+		typ, n := data[0], int(data[1])
+		if len(data) < 2+n {
+			break
+		}
+		value := data[2 : 2+n]
+		switch {
+		case typ == 0 && n == 32:
+			pubkey = hex.EncodeToString(value)
+		case typ == 1:
+			relays = append(relays, string(value))
+		}
+		data = data[2+n:]
+	}
+	if pubkey == "" {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidKey, nprofile)
+	}
+	return pubkey, relays, nil
+}
+
+// bech32Decode decodes a bech32 string (BIP-173) into its human-readable part and
+// 8-bit data. NIP-19 strings may exceed BIP-173's 90 character limit.
+func bech32Decode(s string) (hrp string, data []byte, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidKey, s)
+	}
+	hrp = s[:sep]
+	values := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		v := strings.IndexRune(bech32Charset, c)
+		if v < 0 {
+			return "", nil, fmt.Errorf("%w: %s", ErrInvalidKey, s)
+		}
+		values = append(values, byte(v))
+	}
+	if bech32Polymod(append(hrpExpand(hrp), values...)) != 1 {
+		return "", nil, fmt.Errorf("%w: bad checksum: %s", ErrInvalidKey, s)
+	}
+	data, ok := convertBits(values[:len(values)-6], 5, 8, false)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidKey, s)
+	}
+	return hrp, data, nil
+}
+
+func bech32Encode(hrp string, data []byte) (string, error) {
+	values, ok := convertBits(data, 8, 5, true)
+	if !ok {
+		return "", ErrInvalidKey
+	}
+	mod := bech32Polymod(append(append(hrpExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ 1
+	for i := range 6 {
+		values = append(values, byte(mod>>uint(5*(5-i)))&31)
+	}
+	var b strings.Builder
+	b.WriteString(hrp + "1")
+	for _, v := range values {
+		b.WriteByte(bech32Charset[v])
+	}
+	return b.String(), nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := range 5 {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := range len(hrp) {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := range len(hrp) {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits regroups data from fromBits-bit to toBits-bit values.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, bool) {
+	var acc, bits uint
+	maxv := uint(1)<<toBits - 1
+	var out []byte
+	for _, v := range data {
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, false
+	}
+	return out, true
+}
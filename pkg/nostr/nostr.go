@@ -0,0 +1,303 @@
+// Package nostr resolves nostr public keys (npub, nprofile, or hex) and NIP-05
+// identifiers to the kind-0 metadata event their owner published on public relays.
+package nostr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "nostr"
+
+// DefaultRelays are queried for metadata in addition to any relays a key or NIP-05
+// document suggests.
+var DefaultRelays = []string{
+	"wss://purplepag.es",
+	"wss://relay.damus.io",
+	"wss://nos.lol",
+	"wss://relay.nostr.band",
+}
+
+// webClients are web apps whose profile URLs contain the key: njump.me/npub1...,
+// primal.net/p/npub1..., and so on.
+var webClients = map[string]bool{
+	"njump.me": true, "primal.net": true, "snort.social": true, "iris.to": true,
+	"nostr.band": true, "coracle.social": true, "nostrudel.ninja": true, "nostr.com": true,
+}
+
+var (
+	keyPattern    = regexp.MustCompile(`\b(npub1[02-9ac-hj-np-z]{58}|nprofile1[02-9ac-hj-np-z]+)\b`)
+	hexKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+)
+
+// Match returns true for "nostr:" URIs (NIP-21) and web client URLs naming a key.
+func Match(urlStr string) bool {
+	lower := strings.ToLower(urlStr)
+	if strings.HasPrefix(lower, "nostr:") {
+		return keyPattern.MatchString(lower)
+	}
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return false
+	}
+	return webClients[strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")] && keyPattern.MatchString(parsed.Path)
+}
+
+// AuthRequired returns false because nostr metadata is public.
+func AuthRequired() bool { return false }
+
+// Client resolves nostr profiles.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	relays     []string
+	query      func(ctx context.Context, relayURL, pubkey string) (*event, error)
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+	relays []string
+}
+
+// WithHTTPCache sets the HTTP cache. Metadata events found on relays are cached too.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithRelays replaces DefaultRelays with relays, as wss:// URLs.
+func WithRelays(relays ...string) Option {
+	return func(c *config) { c.relays = relays }
+}
+
+// New creates a nostr client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default(), relays: DefaultRelays}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		relays:     cfg.relays,
+		query:      queryMetadata,
+	}, nil
+}
+
+// metadata is the content of a kind-0 event (NIP-01, NIP-24).
+type metadata struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	About       string `json:"about"`
+	Picture     string `json:"picture"`
+	Banner      string `json:"banner"`
+	Website     string `json:"website"`
+	LUD16       string `json:"lud16"`
+	NIP05       string `json:"nip05"`
+}
+
+// Fetch resolves the key in a "nostr:" URI or web client URL.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	key := keyPattern.FindString(strings.ToLower(urlStr))
+	if key == "" {
+		return nil, fmt.Errorf("could not extract nostr key from: %s", urlStr)
+	}
+	p, err := c.Resolve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	p.URL = urlStr
+	return p, nil
+}
+
+// Resolve returns the profile for identifier: an npub or nprofile key, a hex public
+// key, or a NIP-05 identifier such as "alice@example.com". The newest metadata event
+// any relay returns wins. Event signatures are not verified, so a relay could serve
+// forged metadata; a verified NIP-05 address (Fields["nip05_verified"]) corroborates it.
+func (c *Client) Resolve(ctx context.Context, identifier string) (*profile.Profile, error) {
+	identifier = strings.TrimPrefix(strings.TrimSpace(identifier), "nostr:")
+	var pubkey string
+	var hints []string
+	var err error
+	switch {
+	case strings.HasPrefix(identifier, "npub1"):
+		pubkey, err = DecodeNpub(identifier)
+	case strings.HasPrefix(identifier, "nprofile1"):
+		pubkey, hints, err = decodeNprofile(identifier)
+	case hexKeyPattern.MatchString(identifier):
+		pubkey = strings.ToLower(identifier)
+	case strings.Contains(identifier, "@") || strings.Contains(identifier, "."):
+		pubkey, hints, err = c.lookupNIP05(ctx, identifier)
+	default:
+		err = fmt.Errorf("%w: %s", ErrInvalidKey, identifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.InfoContext(ctx, "fetching nostr profile", "pubkey", pubkey)
+	ev, err := c.fetchMetadata(ctx, pubkey, hints)
+	if err != nil {
+		return nil, err
+	}
+	var meta metadata
+	if err := json.Unmarshal([]byte(ev.Content), &meta); err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	npub, _ := EncodeNpub(pubkey) //nolint:errcheck // pubkey is valid hex by now
+	p := &profile.Profile{
+		Platform:  platform,
+		URL:       "nostr:" + npub,
+		Username:  meta.Name,
+		Name:      strings.TrimSpace(meta.DisplayName),
+		Bio:       strings.TrimSpace(meta.About),
+		Website:   meta.Website,
+		UpdatedAt: time.Unix(ev.CreatedAt, 0).UTC().Format(time.RFC3339),
+		Fields:    map[string]string{"npub": npub, "pubkey": pubkey},
+	}
+	if p.Name == "" {
+		p.Name = meta.Name
+	}
+	for key, value := range map[string]string{
+		profile.FieldAvatarURL: meta.Picture, "banner_url": meta.Banner, "lud16": meta.LUD16, "nip05": meta.NIP05,
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			p.Fields[key] = value
+		}
+	}
+	if meta.NIP05 != "" {
+		if verified, _, err := c.lookupNIP05(ctx, meta.NIP05); err == nil && verified == pubkey {
+			p.Fields["nip05_verified"] = "true"
+		}
+	}
+
+	var found []string
+	if meta.Website != "" {
+		found = append(found, meta.Website)
+	}
+	found = append(found, htmlutil.SocialLinks(meta.About)...)
+	p.SocialLinks = links.Clean(found, Match)
+	return p, nil
+}
+
+// fetchMetadata returns the newest kind-0 event for pubkey from the cache or, queried
+// concurrently, the configured relays and hints.
+func (c *Client) fetchMetadata(ctx context.Context, pubkey string, hints []string) (*event, error) {
+	cacheKey := "nostr:kind0:" + pubkey
+	if c.cache != nil {
+		if data, _, _, found := c.cache.Get(ctx, cacheKey); found {
+			var ev event
+			if err := json.Unmarshal(data, &ev); err == nil {
+				c.cache.RecordHit()
+				return &ev, nil
+			}
+		}
+		c.cache.RecordMiss()
+	}
+	if cache.IsOffline(ctx) {
+		return nil, fmt.Errorf("%w: %s", cache.ErrNotCached, cacheKey)
+	}
+
+	relays := links.Dedupe(append(append([]string(nil), hints...), c.relays...))
+	ctx, cancel := context.WithTimeout(ctx, relayTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var newest *event
+	var lastErr error
+	for _, relay := range relays {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ev, err := c.query(ctx, relay, pubkey)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				c.logger.DebugContext(ctx, "nostr relay query failed", "relay", relay, "error", err)
+				lastErr = err
+			}
+			if ev != nil && (newest == nil || ev.CreatedAt > newest.CreatedAt) {
+				newest = ev
+			}
+		}()
+	}
+	wg.Wait()
+
+	if newest == nil {
+		if lastErr != nil {
+			return nil, fmt.Errorf("%w: no relay returned metadata (last error: %w)", profile.ErrProfileNotFound, lastErr)
+		}
+		return nil, profile.ErrProfileNotFound
+	}
+	if c.cache != nil {
+		if data, err := json.Marshal(newest); err == nil {
+			_ = c.cache.SetAsync(ctx, cacheKey, data, "", nil) //nolint:errcheck // cache is best effort
+		}
+	}
+	return newest, nil
+}
+
+// lookupNIP05 resolves a NIP-05 identifier ("name@domain", or "domain" for
+// "_@domain") through the domain's /.well-known/nostr.json document.
+func (c *Client) lookupNIP05(ctx context.Context, identifier string) (pubkey string, relays []string, err error) {
+	name, domain, found := strings.Cut(strings.ToLower(strings.TrimSpace(identifier)), "@")
+	if !found {
+		name, domain = "_", name
+	}
+	if name == "" || domain == "" || strings.ContainsAny(domain, "/?#") {
+		return "", nil, fmt.Errorf("invalid NIP-05 identifier: %s", identifier)
+	}
+
+	nip05URL := "https://" + domain + "/.well-known/nostr.json?name=" + url.QueryEscape(name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nip05URL, http.NoBody)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var doc struct {
+		Names  map[string]string   `json:"names"`
+		Relays map[string][]string `json:"relays"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return "", nil, fmt.Errorf("parsing %s: %w", nip05URL, err)
+	}
+	for n, key := range doc.Names {
+		if strings.EqualFold(n, name) && hexKeyPattern.MatchString(key) {
+			key = strings.ToLower(key)
+			return key, doc.Relays[key], nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: %s", profile.ErrProfileNotFound, identifier)
+}
@@ -0,0 +1,245 @@
+package nostr
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // WebSocket handshake
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const (
+	testNpub   = "npub10elfcs4fr0l0r8af98jlmgdh9c8tcxjvz9qkw038js35mp4dma8qzvjptg"
+	testPubkey = "7e7e9c42a91bfef19fa929e5fda1b72e0ebc1a4c1141673e2794234d86addf4e"
+)
+
+func TestNpub(t *testing.T) {
+	got, err := DecodeNpub(testNpub)
+	if err != nil || got != testPubkey {
+		t.Errorf("DecodeNpub() = %q, %v; want %q", got, err, testPubkey)
+	}
+	enc, err := EncodeNpub(testPubkey)
+	if err != nil || enc != testNpub {
+		t.Errorf("EncodeNpub() = %q, %v; want %q", enc, err, testNpub)
+	}
+
+	bad := testNpub[:len(testNpub)-1] + "q"
+	if _, err := DecodeNpub(bad); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("DecodeNpub(bad checksum) error = %v, want ErrInvalidKey", err)
+	}
+	if _, err := EncodeNpub("abcd"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("EncodeNpub(short) error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestDecodeNprofile(t *testing.T) {
+	// NIP-19's example nprofile
+	const nprofile = "nprofile1qqsrhuxx8l9ex335q7he0f09aej04zpazpl0ne2cgukyawd24mayt8gpp4mhxue69uhhytnc9e3k7mgpz4mhxue69uhkg6nzv9ejuumpv34kytnrdaksjlyr9p"
+	pubkey, relays, err := decodeNprofile(nprofile)
+	if err != nil {
+		t.Fatalf("decodeNprofile() error = %v", err)
+	}
+	if pubkey != "3bf0c63fcb93463407af97a5e5ee64fa883d107ef9e558472c4eb9aaaefa459d" {
+		t.Errorf("pubkey = %q", pubkey)
+	}
+	if len(relays) != 2 || relays[0] != "wss://r.x.com" || relays[1] != "wss://djbas.sadkb.com" {
+		t.Errorf("relays = %v", relays)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"nostr:" + testNpub, true},
+		{"https://njump.me/" + testNpub, true},
+		{"https://primal.net/p/" + testNpub, true},
+		{"https://snort.social/p/" + testNpub, true},
+		{"nostr:note1abc", false},
+		{"https://primal.net/home", false},
+		{"https://example.com/" + testNpub, false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("nostr should not require auth")
+	}
+}
+
+// relayServer is a NIP-01 relay that answers every REQ with events, then EOSE.
+func relayServer(t *testing.T, events ...event) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + wsGUID)) //nolint:gosec // WebSocket handshake
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Errorf("hijack: %v", err)
+			return
+		}
+		defer func() { _ = conn.Close() }() //nolint:errcheck // test server
+
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n" + //nolint:errcheck // test server
+			"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+		_ = rw.Flush() //nolint:errcheck // test server
+
+		ws := &wsConn{conn: conn, r: rw.Reader}
+		msg, err := ws.readMessage()
+		if err != nil {
+			return
+		}
+		var req []json.RawMessage
+		if err := json.Unmarshal(msg, &req); err != nil || len(req) < 2 {
+			t.Errorf("bad REQ %s", msg)
+			return
+		}
+		var sub string
+		_ = json.Unmarshal(req[1], &sub) //nolint:errcheck // checked by the client's response handling
+		for _, ev := range events {
+			frame, _ := json.Marshal([]any{"EVENT", sub, ev}) //nolint:errcheck // test data
+			writeServerFrame(t, conn, frame)
+		}
+		frame, _ := json.Marshal([]string{"EOSE", sub}) //nolint:errcheck // test data
+		writeServerFrame(t, conn, frame)
+		_, _ = ws.readMessage() //nolint:errcheck // CLOSE request
+	}))
+}
+
+// writeServerFrame writes an unmasked text frame, as servers send them.
+func writeServerFrame(t *testing.T, w interface{ Write([]byte) (int, error) }, payload []byte) {
+	t.Helper()
+	header := []byte{0x80 | opText}
+	if len(payload) < 126 {
+		header = append(header, byte(len(payload)))
+	} else {
+		header = append(header, 126, byte(len(payload)>>8), byte(len(payload)))
+	}
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		t.Errorf("write frame: %v", err)
+	}
+}
+
+func TestQueryMetadata(t *testing.T) {
+	older := event{PubKey: testPubkey, Kind: 0, CreatedAt: 100, Content: `{"name":"old"}`}
+	newer := event{PubKey: testPubkey, Kind: 0, CreatedAt: 200, Content: `{"name":"new"}`}
+	forged := event{PubKey: strings.Repeat("0", 64), Kind: 0, CreatedAt: 300, Content: `{"name":"forged"}`}
+	server := relayServer(t, older, newer, forged)
+	defer server.Close()
+
+	ev, err := queryMetadata(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http"), testPubkey)
+	if err != nil {
+		t.Fatalf("queryMetadata() error = %v", err)
+	}
+	if ev == nil || ev.Content != `{"name":"new"}` {
+		t.Errorf("queryMetadata() = %+v, want newest event from the author", ev)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	content, _ := json.Marshal(map[string]string{ //nolint:errcheck // test data
+		"name":         "fiatjaf",
+		"display_name": "Fiat Jaf",
+		"about":        "building nostr. code at https://github.com/fiatjaf",
+		"picture":      "https://example.com/me.png",
+		"website":      "https://fiatjaf.com",
+		"lud16":        "fiatjaf@getalby.com",
+		"nip05":        "_@fiatjaf.com",
+	})
+	nip05 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.well-known/nostr.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"names":{"_":"` + testPubkey + `"},"relays":{"` + testPubkey + `":["wss://hint.example"]}}`)) //nolint:errcheck // test server
+	}))
+	defer nip05.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx, WithRelays("wss://one.example", "wss://two.example"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: nip05.URL}}
+	var queried []string
+	client.query = func(_ context.Context, relayURL, pubkey string) (*event, error) {
+		if pubkey != testPubkey {
+			t.Errorf("queried pubkey %q", pubkey)
+		}
+		queried = append(queried, relayURL)
+		switch relayURL {
+		case "wss://one.example":
+			return &event{PubKey: pubkey, CreatedAt: 1700000000, Content: string(content)}, nil
+		case "wss://two.example":
+			return &event{PubKey: pubkey, CreatedAt: 1600000000, Content: `{"name":"stale"}`}, nil
+		default:
+			return nil, errors.New("offline")
+		}
+	}
+	// Serialize the fake relays so queried needs no lock
+	client.query = serialized(client.query)
+
+	for _, id := range []string{testNpub, testPubkey, "fiatjaf.com", "nostr:" + testNpub} {
+		p, err := client.Resolve(ctx, id)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error = %v", id, err)
+		}
+		if p.Platform != "nostr" || p.Username != "fiatjaf" || p.Name != "Fiat Jaf" || p.Website != "https://fiatjaf.com" {
+			t.Errorf("Resolve(%q) = %+v", id, p)
+		}
+		if p.Fields["npub"] != testNpub || p.Fields["lud16"] != "fiatjaf@getalby.com" || p.Fields["nip05_verified"] != "true" {
+			t.Errorf("Resolve(%q) Fields = %v", id, p.Fields)
+		}
+		if p.UpdatedAt != "2023-11-14T22:13:20Z" {
+			t.Errorf("Resolve(%q) UpdatedAt = %q", id, p.UpdatedAt)
+		}
+		if len(p.SocialLinks) != 2 {
+			t.Errorf("Resolve(%q) SocialLinks = %v", id, p.SocialLinks)
+		}
+	}
+
+	var hinted bool
+	for _, r := range queried {
+		hinted = hinted || r == "wss://hint.example"
+	}
+	if !hinted {
+		t.Errorf("NIP-05 relay hint was not queried: %v", queried)
+	}
+
+	if _, err := client.Resolve(ctx, "nobody@fiatjaf.com"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("Resolve(unknown NIP-05) error = %v, want ErrProfileNotFound", err)
+	}
+	if _, err := client.Resolve(ctx, "npub1invalid"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Resolve(invalid npub) error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func serialized(query func(context.Context, string, string) (*event, error)) func(context.Context, string, string) (*event, error) {
+	ch := make(chan struct{}, 1)
+	return func(ctx context.Context, relayURL, pubkey string) (*event, error) {
+		ch <- struct{}{}
+		defer func() { <-ch }()
+		return query(ctx, relayURL, pubkey)
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
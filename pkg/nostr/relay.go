@@ -0,0 +1,280 @@
+package nostr
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // required by the WebSocket handshake, not used for security
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// Relays speak NIP-01 over WebSocket. The standard library has no WebSocket client,
+// so this file implements the small part of RFC 6455 a read-only query needs.
+
+const (
+	wsGUID         = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	maxMessageSize = 1 << 20
+	relayTimeout   = 5 * time.Second
+)
+
+// WebSocket opcodes.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+var errRelayClosed = errors.New("relay closed the connection")
+
+// event is a signed nostr event (NIP-01).
+type event struct {
+	ID        string     `json:"id"`
+	PubKey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+// wsConn is a client WebSocket connection.
+type wsConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// dialRelay opens a WebSocket connection to a ws:// or wss:// relay URL.
+func dialRelay(ctx context.Context, relayURL string) (*wsConn, error) {
+	u, err := url.Parse(relayURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "ws" {
+			port = "80"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	network := cache.NetworkFrom(ctx)
+	conn, err := network.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "wss":
+		conn = tls.Client(conn, network.TLSConfig(u.Hostname()))
+	case "ws":
+	default:
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, fmt.Errorf("unsupported relay scheme: %s", u.Scheme)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(relayTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, err
+	}
+
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+	hs, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+u.Host+u.RequestURI(), http.NoBody)
+	if err != nil {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, err
+	}
+	hs.Header.Set("Upgrade", "websocket")
+	hs.Header.Set("Connection", "Upgrade")
+	hs.Header.Set("Sec-WebSocket-Key", key)
+	hs.Header.Set("Sec-WebSocket-Version", "13")
+	hs.Header.Set("User-Agent", cache.UserAgent)
+	cache.PrepareRequest(ctx, hs)
+	if err := hs.Write(conn); err != nil {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, &http.Request{Method: http.MethodGet})
+	if err != nil {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, fmt.Errorf("relay handshake: %w", err)
+	}
+	_ = resp.Body.Close()                 //nolint:errcheck // a 101 response has no body
+	sum := sha1.Sum([]byte(key + wsGUID)) //nolint:gosec // see import
+	if resp.StatusCode != http.StatusSwitchingProtocols ||
+		resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		_ = conn.Close() //nolint:errcheck // unusable connection
+		return nil, fmt.Errorf("relay handshake: unexpected response %s", resp.Status)
+	}
+	return &wsConn{conn: conn, r: r}, nil
+}
+
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(opClose, nil) //nolint:errcheck // best effort before closing
+	return c.conn.Close()
+}
+
+// writeFrame sends one masked frame, as RFC 6455 requires of clients.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, 0x80|byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 0x80|126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 0x80|127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+	header = append(header, mask...)
+	masked := make([]byte, len(payload))
+	for i, b := range payload {
+		masked[i] = b ^ mask[i%4]
+	}
+	_, err := c.conn.Write(append(header, masked...))
+	return err
+}
+
+// readMessage returns the next text message, answering pings on the way.
+func (c *wsConn) readMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(c.r, head[:]); err != nil {
+			return nil, err
+		}
+		fin, opcode := head[0]&0x80 != 0, head[0]&0x0F
+		n := uint64(head[1] & 0x7F)
+		switch n {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return nil, err
+			}
+			n = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.r, ext[:]); err != nil {
+				return nil, err
+			}
+			n = binary.BigEndian.Uint64(ext[:])
+		}
+		var mask [4]byte
+		masked := head[1]&0x80 != 0
+		if masked {
+			if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+				return nil, err
+			}
+		}
+		if n > maxMessageSize || uint64(len(msg))+n > maxMessageSize {
+			return nil, fmt.Errorf("relay message exceeds %d bytes", maxMessageSize)
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(c.r, payload); err != nil {
+			return nil, err
+		}
+		if masked {
+			for i := range payload {
+				payload[i] ^= mask[i%4]
+			}
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			return nil, errRelayClosed
+		case opText, opContinuation:
+			msg = append(msg, payload...)
+			if fin {
+				return msg, nil
+			}
+		}
+	}
+}
+
+// queryMetadata asks a relay for pubkey's kind-0 metadata events and returns the
+// newest, or nil if the relay has none.
+func queryMetadata(ctx context.Context, relayURL, pubkey string) (*event, error) {
+	conn, err := dialRelay(ctx, relayURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }() //nolint:errcheck // query is done
+
+	subID := "sociopath-" + pubkey[:8]
+	filter := map[string]any{"kinds": []int{0}, "authors": []string{pubkey}, "limit": 1}
+	req, err := json.Marshal([]any{"REQ", subID, filter})
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.writeFrame(opText, req); err != nil {
+		return nil, err
+	}
+
+	var newest *event
+	for {
+		msg, err := conn.readMessage()
+		if err != nil {
+			return newest, err
+		}
+		var frame []json.RawMessage
+		if err := json.Unmarshal(msg, &frame); err != nil || len(frame) < 2 {
+			continue
+		}
+		var typ, sub string
+		_ = json.Unmarshal(frame[0], &typ) //nolint:errcheck // unknown frames are skipped
+		_ = json.Unmarshal(frame[1], &sub) //nolint:errcheck // unknown frames are skipped
+		switch typ {
+		case "EVENT":
+			if sub != subID || len(frame) < 3 {
+				continue
+			}
+			var ev event
+			if err := json.Unmarshal(frame[2], &ev); err != nil || ev.Kind != 0 || !strings.EqualFold(ev.PubKey, pubkey) {
+				continue
+			}
+			if newest == nil || ev.CreatedAt > newest.CreatedAt {
+				newest = &ev
+			}
+		case "EOSE", "CLOSED":
+			if sub == subID {
+				closeReq, _ := json.Marshal([]string{"CLOSE", subID}) //nolint:errcheck // constant shape
+				_ = conn.writeFrame(opText, closeReq)                 //nolint:errcheck // best effort
+				return newest, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Package patreon fetches Patreon creator pages.
+package patreon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "patreon"
+
+var usernamePattern = regexp.MustCompile(`(?i)patreon\.com/(?:c/|cw/)?([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are patreon.com paths that are not creator pages.
+var nonProfiles = map[string]bool{
+	"login": true, "signup": true, "home": true, "explore": true, "about": true, "pricing": true,
+	"posts": true, "product": true, "policy": true, "messages": true, "settings": true, "search": true,
+	"create": true, "apps": true, "careers": true, "c": true, "cw": true, "user": true, "join": true,
+}
+
+// Match returns true if the URL is a Patreon creator page.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because creator pages are public.
+func AuthRequired() bool { return false }
+
+// Client handles Patreon requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Patreon client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Patreon profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://www.patreon.com/" + username
+	c.logger.InfoContext(ctx, "fetching patreon profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a Patreon creator page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://www.patreon.com/"+username, username)
+}
+
+var (
+	ogTitlePattern = regexp.MustCompile(`(?i)<meta[^>]+property="og:title"[^>]+content="([^"]+)"`)
+	patronsPattern = regexp.MustCompile(`"patron_count":\s*(\d+)`)
+	postsPattern   = regexp.MustCompile(`"post_count":\s*(\d+)`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	// og:title is "Jane Doe | creating Podcasts | Patreon"
+	title := htmlutil.Title(content)
+	if m := ogTitlePattern.FindStringSubmatch(content); m != nil {
+		title = html.UnescapeString(m[1])
+	}
+	parts := strings.Split(title, " | ")
+	if len(parts) > 0 {
+		p.Name = strings.TrimSpace(parts[0])
+	}
+	if p.Name == "" || strings.EqualFold(p.Name, "Patreon") {
+		return nil, errors.New("profile not found (no creator name)")
+	}
+	if len(parts) > 2 {
+		p.Fields[profile.FieldHeadline] = strings.TrimSpace(parts[1])
+	}
+
+	p.Bio = htmlutil.Description(content)
+	if m := patronsPattern.FindStringSubmatch(content); m != nil {
+		p.Fields["patrons"] = m[1]
+	}
+	if m := postsPattern.FindStringSubmatch(content); m != nil {
+		p.Fields["posts"] = m[1]
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(content), Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,59 @@
+package patreon
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.patreon.com/janedoe", true},
+		{"https://patreon.com/c/janedoe", true},
+		{"https://www.patreon.com/cw/janedoe/", true},
+		{"https://www.patreon.com/janedoe?utm_source=github", true},
+		{"https://www.patreon.com/posts/my-update-12345", false},
+		{"https://www.patreon.com/login", false},
+		{"https://www.patreon.com/", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Patreon should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><head><title>Jane Doe | creating Podcasts | Patreon</title>
+<meta property="og:title" content="Jane Doe | creating Podcasts | Patreon">
+<meta name="description" content="Weekly deep dives into open source maintainership.">
+</head><body><script>window.patreon = {"campaign":{"patron_count": 412, "post_count": 98}};</script>
+<a href="https://twitter.com/janedoe">Twitter</a><a href="https://www.patreon.com/janedoe">Patreon</a></body></html>`
+
+	p, err := parseProfile(page, "https://www.patreon.com/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Fields["headline"] != "creating Podcasts" {
+		t.Errorf("Name = %q, headline = %q", p.Name, p.Fields["headline"])
+	}
+	if p.Bio != "Weekly deep dives into open source maintainership." {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Fields["patrons"] != "412" || p.Fields["posts"] != "98" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://twitter.com/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+
+	if _, err := parseProfile(`<title>Patreon</title>`, "https://www.patreon.com/x", "x"); err == nil {
+		t.Error("parseProfile(generic page) should fail")
+	}
+}
@@ -0,0 +1,291 @@
+// Package peertube fetches PeerTube account and channel data.
+package peertube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "peertube"
+
+// Known PeerTube instances. Others are recognized by a "peertube", "tube", or
+// "videos" label in their hostname.
+var knownInstances = map[string]bool{
+	"framatube.org": true, "tilvids.com": true, "video.blender.org": true, "diode.zone": true,
+	"peertube.tv": true, "tube.tchncs.de": true, "videos.lukesmith.xyz": true, "kolektiva.media": true,
+	"spectra.video": true, "makertube.net": true, "videos.trom.tf": true, "tube.kockatoo.org": true,
+}
+
+// Match returns true if the URL is a PeerTube account (/a/name) or channel (/c/name) URL.
+func Match(urlStr string) bool {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return false
+	}
+	if kind, name := splitPath(parsed.Path); kind == "" || name == "" {
+		return false
+	}
+	host := strings.ToLower(strings.TrimPrefix(parsed.Hostname(), "www."))
+	if knownInstances[host] {
+		return true
+	}
+	for label := range strings.SplitSeq(host, ".") {
+		if strings.Contains(label, "peertube") || label == "tube" || label == "videos" {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthRequired returns false because PeerTube profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles PeerTube requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	depth      profile.Depth
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+	depth  profile.Depth
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
+// New creates a PeerTube client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
+	}, nil
+}
+
+// actor is an account or video channel as the REST API returns it.
+type actor struct {
+	Name           string `json:"name"`
+	Host           string `json:"host"`
+	DisplayName    string `json:"displayName"`
+	Description    string `json:"description"`
+	Support        string `json:"support"`
+	URL            string `json:"url"`
+	CreatedAt      string `json:"createdAt"`
+	UpdatedAt      string `json:"updatedAt"`
+	FollowersCount int    `json:"followersCount"`
+	FollowingCount int    `json:"followingCount"`
+	Avatars        []struct {
+		Path  string `json:"path"`
+		Width int    `json:"width"`
+	} `json:"avatars"`
+	OwnerAccount *struct {
+		Name string `json:"name"`
+		Host string `json:"host"`
+		URL  string `json:"url"`
+	} `json:"ownerAccount"`
+}
+
+// Fetch retrieves a PeerTube account or channel, its recent videos, and the name of
+// the instance hosting it.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	kind, name := splitPath(parsed.Path)
+	if name == "" {
+		return nil, fmt.Errorf("could not extract account or channel from: %s", urlStr)
+	}
+	base := "https://" + parsed.Host
+	c.logger.InfoContext(ctx, "fetching peertube profile", "url", urlStr, "kind", kind, "name", name)
+
+	body, err := c.get(ctx, base+"/api/v1/"+kind+"/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+	var a actor
+	if err := json.Unmarshal(body, &a); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", kind, err)
+	}
+
+	p := parseActor(&a, base)
+	p.URL = urlStr
+	if kind == "video-channels" {
+		p.Fields["channel"] = name
+	}
+
+	if c.depth == profile.DepthMinimal || !cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		return p, nil
+	}
+	if limit := c.depth.PostLimit(); limit > 0 {
+		c.addVideos(ctx, p, base+"/api/v1/"+kind+"/"+url.PathEscape(name)+"/videos", limit)
+	}
+	c.addInstance(ctx, p, base)
+	return p, nil
+}
+
+func (c *Client) get(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+func parseActor(a *actor, base string) *profile.Profile {
+	p := &profile.Profile{
+		Platform:  platform,
+		Username:  a.Name,
+		Name:      strings.TrimSpace(a.DisplayName),
+		Bio:       strings.TrimSpace(a.Description),
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+		Fields:    make(map[string]string),
+	}
+	p.Fields[profile.FieldFollowers] = strconv.Itoa(a.FollowersCount)
+	if a.FollowingCount > 0 {
+		p.Fields[profile.FieldFollowing] = strconv.Itoa(a.FollowingCount)
+	}
+	if a.Host != "" {
+		p.Fields["instance"] = a.Host
+	}
+	if a.Support != "" {
+		p.Fields["support"] = strings.TrimSpace(a.Support)
+	}
+
+	// Avatars come in several sizes; the largest is the most useful
+	bestWidth := -1
+	for _, av := range a.Avatars {
+		if av.Path != "" && av.Width > bestWidth {
+			bestWidth = av.Width
+			p.Fields[profile.FieldAvatarURL] = base + av.Path
+		}
+	}
+
+	var found []string
+	// A channel belongs to an account: the same person on the same instance
+	if a.OwnerAccount != nil && a.OwnerAccount.URL != "" {
+		p.Fields["owner"] = a.OwnerAccount.Name + "@" + a.OwnerAccount.Host
+		found = append(found, a.OwnerAccount.URL)
+	}
+	found = append(found, htmlutil.SocialLinks(a.Description+"\n"+a.Support)...)
+	p.SocialLinks = links.Clean(found, nil)
+	return p
+}
+
+// addVideos adds the most recent videos to p's Posts and the total to its Fields.
+func (c *Client) addVideos(ctx context.Context, p *profile.Profile, apiURL string, limit int) {
+	body, err := c.get(ctx, apiURL+"?sort=-publishedAt&count="+strconv.Itoa(min(limit, 100)))
+	if err != nil {
+		c.logger.DebugContext(ctx, "peertube videos fetch failed", "url", apiURL, "error", err)
+		return
+	}
+	var resp struct {
+		Total int `json:"total"`
+		Data  []struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+			URL         string `json:"url"`
+			PublishedAt string `json:"publishedAt"`
+			Channel     struct {
+				DisplayName string `json:"displayName"`
+			} `json:"channel"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return
+	}
+	p.Fields[profile.FieldVideos] = strconv.Itoa(resp.Total)
+	for i, v := range resp.Data {
+		p.Posts = append(p.Posts, profile.Post{
+			Type:     profile.PostTypeVideo,
+			Title:    v.Name,
+			Content:  strings.TrimSpace(v.Description),
+			URL:      v.URL,
+			Category: v.Channel.DisplayName,
+		})
+		// Videos are newest first
+		if i == 0 {
+			p.UpdateLastActive(v.PublishedAt)
+		}
+	}
+}
+
+// addInstance records the name and description of the instance hosting p.
+func (c *Client) addInstance(ctx context.Context, p *profile.Profile, base string) {
+	body, err := c.get(ctx, base+"/api/v1/config/about")
+	if err != nil {
+		return
+	}
+	var about struct {
+		Instance struct {
+			Name             string `json:"name"`
+			ShortDescription string `json:"shortDescription"`
+		} `json:"instance"`
+	}
+	if err := json.Unmarshal(body, &about); err != nil {
+		return
+	}
+	if about.Instance.Name != "" {
+		p.Fields["instance_name"] = about.Instance.Name
+	}
+	if about.Instance.ShortDescription != "" {
+		p.Fields["instance_description"] = about.Instance.ShortDescription
+	}
+}
+
+// splitPath returns the API collection ("accounts" or "video-channels") and the
+// name a profile path refers to, or "" if it is not a profile path.
+func splitPath(path string) (kind, name string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", ""
+	}
+	switch parts[0] {
+	case "a", "accounts":
+		return "accounts", parts[1]
+	case "c", "video-channels":
+		return "video-channels", parts[1]
+	default:
+		return "", ""
+	}
+}
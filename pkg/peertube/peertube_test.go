@@ -0,0 +1,137 @@
+package peertube
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://framatube.org/a/framasoft", true},
+		{"https://tilvids.com/c/techlore_channel/videos", true},
+		{"https://peertube.example.org/accounts/alice", true},
+		{"https://tube.example.net/video-channels/alice_channel", true},
+		{"https://framatube.org/w/abc123", false},
+		{"https://framatube.org/a/", false},
+		{"https://lemmy.ml/c/linux", false},
+		{"https://youtube.com/c/alice", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("PeerTube should not require auth")
+	}
+}
+
+func TestFetchChannel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		switch r.URL.Path {
+		case "/api/v1/video-channels/alice_channel":
+			v = map[string]any{
+				"name": "alice_channel", "host": "framatube.org", "displayName": "Alice's Workshop",
+				"description": "Woodworking videos. Also on https://github.com/alice",
+				"support":     "https://ko-fi.com/alice", "createdAt": "2020-01-02T03:04:05.000Z",
+				"followersCount": 1200,
+				"avatars":        []map[string]any{{"path": "/lazy-static/avatars/s.png", "width": 48}, {"path": "/lazy-static/avatars/l.png", "width": 120}},
+				"ownerAccount":   map[string]string{"name": "alice", "host": "framatube.org", "url": "https://framatube.org/accounts/alice"},
+			}
+		case "/api/v1/video-channels/alice_channel/videos":
+			if r.URL.Query().Get("sort") != "-publishedAt" {
+				t.Errorf("videos sort = %q", r.URL.Query().Get("sort"))
+			}
+			v = map[string]any{"total": 42, "data": []map[string]any{
+				{"name": "Dovetails", "url": "https://framatube.org/w/1", "publishedAt": "2025-03-01T00:00:00.000Z", "channel": map[string]string{"displayName": "Alice's Workshop"}},
+				{"name": "Chisels", "url": "https://framatube.org/w/2", "publishedAt": "2025-02-01T00:00:00.000Z"},
+			}}
+		case "/api/v1/config/about":
+			v = map[string]any{"instance": map[string]string{"name": "Framatube", "shortDescription": "Videos by Framasoft"}}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	p, err := client.Fetch(ctx, "https://framatube.org/c/alice_channel")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Platform != "peertube" || p.Username != "alice_channel" || p.Name != "Alice's Workshop" {
+		t.Errorf("profile = %+v", p)
+	}
+	for k, want := range map[string]string{
+		profile.FieldFollowers: "1200", profile.FieldVideos: "42", profile.FieldAvatarURL: "https://framatube.org/lazy-static/avatars/l.png",
+		"owner": "alice@framatube.org", "instance_name": "Framatube", "channel": "alice_channel",
+	} {
+		if p.Fields[k] != want {
+			t.Errorf("Fields[%s] = %q, want %q", k, p.Fields[k], want)
+		}
+	}
+	for _, want := range []string{"https://framatube.org/accounts/alice", "https://github.com/alice", "https://ko-fi.com/alice"} {
+		if !slices.Contains(p.SocialLinks, want) {
+			t.Errorf("SocialLinks = %v, missing %s", p.SocialLinks, want)
+		}
+	}
+	if len(p.Posts) != 2 || p.Posts[0].Type != profile.PostTypeVideo || p.Posts[0].Title != "Dovetails" {
+		t.Errorf("Posts = %+v", p.Posts)
+	}
+	if p.LastActive != "2025-03-01T00:00:00.000Z" {
+		t.Errorf("LastActive = %q", p.LastActive)
+	}
+}
+
+func TestFetchMinimal(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": "alice", "displayName": "Alice"}) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	client, err := New(context.Background(), WithDepth(profile.DepthMinimal))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	if _, err := client.Fetch(context.Background(), "https://framatube.org/a/alice"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if want := []string{"/api/v1/accounts/alice"}; !slices.Equal(paths, want) {
+		t.Errorf("requested %v, want only %v", paths, want)
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
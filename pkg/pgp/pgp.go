@@ -0,0 +1,319 @@
+// Package pgp looks up OpenPGP keys published on public keyservers. A key's user IDs
+// name the addresses and names its owner uses, and its creation date shows how long
+// they have used them.
+package pgp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "pgp"
+
+// DefaultKeyservers are queried by ByEmail. keys.openpgp.org only publishes user IDs
+// whose address the owner confirmed; the Ubuntu keyserver publishes whatever it is
+// sent, so its user IDs are weaker evidence.
+var DefaultKeyservers = []string{
+	"https://keys.openpgp.org",
+	"https://keyserver.ubuntu.com",
+}
+
+// verifyingKeyservers confirm that user ID addresses belong to the key's owner.
+var verifyingKeyservers = map[string]bool{"keys.openpgp.org": true}
+
+// VerifiesEmail reports whether the keyserver at host confirms user ID addresses
+// before publishing them.
+func VerifiesEmail(host string) bool {
+	return verifyingKeyservers[strings.ToLower(host)]
+}
+
+// Key is a public key as a keyserver's index lists it.
+type Key struct {
+	Fingerprint string    // Hex fingerprint, or long key ID from servers that only list IDs
+	Created     time.Time // Zero if the server did not say
+	Expires     time.Time // Zero if the key does not expire
+	Revoked     bool
+	UIDs        []UID
+	Keyserver   string // Host of the keyserver the key was found on
+}
+
+// UID is a user ID such as "Jane Doe (work) <jane@example.com>".
+type UID struct {
+	Raw     string
+	Name    string
+	Comment string
+	Email   string
+}
+
+// Match returns true for keyserver lookup URLs, as IndexURL builds them.
+func Match(urlStr string) bool {
+	_, search := parseLookupURL(urlStr)
+	return search != ""
+}
+
+// AuthRequired returns false because keyservers are public.
+func AuthRequired() bool { return false }
+
+// IndexURL returns the HKP machine-readable index URL that searches keyserver (a base
+// URL such as "https://keys.openpgp.org") for search: an email or a 0x-prefixed
+// fingerprint.
+func IndexURL(keyserver, search string) string {
+	return strings.TrimSuffix(keyserver, "/") + "/pks/lookup?op=index&options=mr&search=" + url.QueryEscape(search)
+}
+
+// parseLookupURL returns the base URL and search term of a keyserver lookup URL.
+func parseLookupURL(urlStr string) (base, search string) {
+	u, err := url.Parse(urlStr)
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	host := strings.ToLower(u.Hostname())
+	known := false
+	for _, ks := range DefaultKeyservers {
+		known = known || strings.HasSuffix(ks, "://"+host)
+	}
+	switch {
+	case !known:
+		return "", ""
+	case u.Path == "/pks/lookup":
+		search = u.Query().Get("search")
+	case u.Path == "/search": // keys.openpgp.org's web UI
+		search = u.Query().Get("q")
+	}
+	return "https://" + u.Host, strings.TrimSpace(search)
+}
+
+// Client queries keyservers.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	keyservers []string
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	keyservers []string
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithKeyservers replaces DefaultKeyservers for ByEmail.
+func WithKeyservers(keyservers ...string) Option {
+	return func(c *config) { c.keyservers = keyservers }
+}
+
+// New creates a keyserver client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default(), keyservers: DefaultKeyservers}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		keyservers: cfg.keyservers,
+	}, nil
+}
+
+// Fetch returns the profile of the first key a keyserver lookup URL finds.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	base, search := parseLookupURL(urlStr)
+	if search == "" {
+		return nil, fmt.Errorf("not a keyserver lookup URL: %s", urlStr)
+	}
+	keys, err := c.index(ctx, base, search)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, profile.ErrProfileNotFound
+	}
+	p := KeyProfile(&keys[0])
+	p.URL = urlStr
+	return p, nil
+}
+
+// ByEmail returns the keys every configured keyserver lists for email. A key found on
+// several keyservers is returned once per keyserver, so each copy keeps its provenance.
+func (c *Client) ByEmail(ctx context.Context, email string) ([]Key, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address %q: %w", email, err)
+	}
+	var keys []Key
+	var errs []error
+	for _, ks := range c.keyservers {
+		found, err := c.index(ctx, ks, strings.ToLower(addr.Address))
+		if err != nil {
+			c.logger.DebugContext(ctx, "keyserver lookup failed", "keyserver", ks, "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		keys = append(keys, found...)
+	}
+	if len(errs) > 0 && len(errs) == len(c.keyservers) {
+		return nil, errors.Join(errs...)
+	}
+	return keys, nil
+}
+
+func (c *Client) index(ctx context.Context, keyserver, search string) ([]Key, error) {
+	c.logger.InfoContext(ctx, "searching keyserver", "keyserver", keyserver, "search", search)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, IndexURL(keyserver, search), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+	host := req.URL.Hostname()
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		var httpErr *cache.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, nil // HKP's "no keys found"
+		}
+		return nil, err
+	}
+	keys := ParseIndex(body)
+	for i := range keys {
+		keys[i].Keyserver = host
+	}
+	return keys, nil
+}
+
+// ParseIndex parses an HKP machine-readable index (draft-shaw-openpgp-hkp, section
+// 5.2): a "pub" line per key followed by a "uid" line per user ID. Revoked user IDs
+// are dropped.
+func ParseIndex(body []byte) []Key {
+	var keys []Key
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		switch fields[0] {
+		case "pub":
+			if len(fields) < 2 || fields[1] == "" {
+				continue
+			}
+			key := Key{Fingerprint: strings.ToUpper(fields[1])}
+			key.Created = parseTime(column(fields, 4))
+			key.Expires = parseTime(column(fields, 5))
+			key.Revoked = strings.Contains(column(fields, 6), "r")
+			keys = append(keys, key)
+		case "uid":
+			if len(keys) == 0 || len(fields) < 2 || strings.Contains(column(fields, 4), "r") {
+				continue
+			}
+			raw, err := url.PathUnescape(fields[1])
+			if err != nil {
+				raw = fields[1]
+			}
+			if uid := parseUID(raw); uid.Raw != "" {
+				keys[len(keys)-1].UIDs = append(keys[len(keys)-1].UIDs, uid)
+			}
+		}
+	}
+	return keys
+}
+
+// column returns fields[i], or "" for the trailing columns servers may omit.
+func column(fields []string, i int) string {
+	if i >= len(fields) {
+		return ""
+	}
+	return fields[i]
+}
+
+// parseTime parses an index timestamp, which is in seconds since the epoch.
+func parseTime(s string) time.Time {
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || secs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0).UTC()
+}
+
+// parseUID splits a user ID of the conventional "Name (Comment) <email>" form.
+// Any part may be missing.
+func parseUID(raw string) UID {
+	uid := UID{Raw: strings.TrimSpace(raw)}
+	rest := uid.Raw
+	if open := strings.LastIndexByte(rest, '<'); open >= 0 && strings.HasSuffix(rest, ">") {
+		uid.Email = strings.ToLower(strings.TrimSpace(rest[open+1 : len(rest)-1]))
+		rest = rest[:open]
+	} else if !strings.Contains(rest, " ") && strings.Contains(rest, "@") {
+		uid.Email, rest = strings.ToLower(rest), ""
+	}
+	if open := strings.IndexByte(rest, '('); open >= 0 {
+		if end := strings.LastIndexByte(rest, ')'); end > open {
+			uid.Comment = strings.TrimSpace(rest[open+1 : end])
+			rest = rest[:open] + rest[end+1:]
+		}
+	}
+	uid.Name = strings.TrimSpace(rest)
+	return uid
+}
+
+// KeyProfile returns the profile for key. Each user ID is kept verbatim in Fields
+// ("uid", "uid_2", ...) alongside its parsed addresses, and Fields["keyserver"]
+// records where the key was found.
+func KeyProfile(key *Key) *profile.Profile {
+	b := profile.NewBuilder(platform, IndexURL("https://"+key.Keyserver, "0x"+key.Fingerprint))
+	b.Edit(func(p *profile.Profile) {
+		p.Username = key.Fingerprint
+		if !key.Created.IsZero() {
+			p.CreatedAt = key.Created.Format(time.RFC3339)
+		}
+		for _, uid := range key.UIDs {
+			if p.Name == "" {
+				p.Name = uid.Name
+			}
+		}
+	})
+	b.AddField("fingerprint", key.Fingerprint)
+	b.AddField("keyserver", key.Keyserver)
+	if VerifiesEmail(key.Keyserver) {
+		b.AddField("emails_verified", "true")
+	}
+	if !key.Expires.IsZero() {
+		b.AddField("expires", key.Expires.Format(time.RFC3339))
+	}
+	if key.Revoked {
+		b.AddField("revoked", "true")
+	}
+	for i, uid := range key.UIDs {
+		name := "uid"
+		if i > 0 {
+			name += "_" + strconv.Itoa(i+1)
+		}
+		b.AddField(name, uid.Raw)
+		b.AddEmail(uid.Email)
+	}
+	return b.Build()
+}
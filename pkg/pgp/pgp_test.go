@@ -0,0 +1,143 @@
+package pgp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const testIndex = `info:1:2
+pub:0123456789ABCDEF0123456789ABCDEF01234567:1:4096:1262304000:1893456000:
+uid:Jane%20Doe%20(work)%20%3Cjane%40example.com%3E:1262304000::
+uid:Jane%20Doe%20%3CJDoe%40Example.org%3E:1262304000::
+uid:Old%20Name%20%3Cold%40example.net%3E:1262304000::r
+pub:fedcba9876543210:17:1024:946684800::r
+uid:jane%40example.com::
+`
+
+func TestParseIndex(t *testing.T) {
+	keys := ParseIndex([]byte(testIndex))
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(keys))
+	}
+
+	k := keys[0]
+	if k.Fingerprint != "0123456789ABCDEF0123456789ABCDEF01234567" || k.Revoked {
+		t.Errorf("key = %+v", k)
+	}
+	if !k.Created.Equal(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)) || !k.Expires.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Created = %v, Expires = %v", k.Created, k.Expires)
+	}
+	want := []UID{
+		{Raw: "Jane Doe (work) <jane@example.com>", Name: "Jane Doe", Comment: "work", Email: "jane@example.com"},
+		{Raw: "Jane Doe <JDoe@Example.org>", Name: "Jane Doe", Email: "jdoe@example.org"},
+	}
+	if len(k.UIDs) != len(want) {
+		t.Fatalf("UIDs = %+v, want %+v (revoked user ID dropped)", k.UIDs, want)
+	}
+	for i := range want {
+		if k.UIDs[i] != want[i] {
+			t.Errorf("UIDs[%d] = %+v, want %+v", i, k.UIDs[i], want[i])
+		}
+	}
+
+	if !keys[1].Revoked || keys[1].Fingerprint != "FEDCBA9876543210" || len(keys[1].UIDs) != 1 || keys[1].UIDs[0].Email != "jane@example.com" {
+		t.Errorf("second key = %+v", keys[1])
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{IndexURL("https://keys.openpgp.org", "0x0123456789ABCDEF"), true},
+		{"https://keyserver.ubuntu.com/pks/lookup?search=jane%40example.com&op=index", true},
+		{"https://keys.openpgp.org/search?q=jane@example.com", true},
+		{"https://keys.openpgp.org/about", false},
+		{"https://example.com/pks/lookup?search=jane@example.com", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestByEmailAndFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("search") {
+		case "jane@example.com", "0x0123456789ABCDEF0123456789ABCDEF01234567":
+			_, _ = w.Write([]byte(testIndex)) //nolint:errcheck // test server
+		default:
+			http.Error(w, "No keys found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx, WithKeyservers(server.URL))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	keys, err := client.ByEmail(ctx, "Jane <JANE@example.com>")
+	if err != nil {
+		t.Fatalf("ByEmail() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0].Keyserver != "127.0.0.1" {
+		t.Fatalf("ByEmail() = %+v", keys)
+	}
+	if keys, err := client.ByEmail(ctx, "nobody@example.com"); err != nil || len(keys) != 0 {
+		t.Errorf("ByEmail(unknown) = %+v, %v; want no keys", keys, err)
+	}
+
+	p := KeyProfile(&keys[0])
+	if p.Platform != "pgp" || p.Name != "Jane Doe" || p.CreatedAt != "2010-01-01T00:00:00Z" {
+		t.Errorf("profile = %+v", p)
+	}
+	for key, want := range map[string]string{
+		"fingerprint": "0123456789ABCDEF0123456789ABCDEF01234567",
+		"keyserver":   "127.0.0.1",
+		"email":       "jane@example.com",
+		"email_2":     "jdoe@example.org",
+		"uid":         "Jane Doe (work) <jane@example.com>",
+		"uid_2":       "Jane Doe <JDoe@Example.org>",
+		"expires":     "2030-01-01T00:00:00Z",
+	} {
+		if got := p.Fields[key]; got != want {
+			t.Errorf("Fields[%q] = %q, want %q", key, got, want)
+		}
+	}
+	if _, ok := p.Fields["emails_verified"]; ok {
+		t.Error("emails_verified set for a keyserver that does not verify addresses")
+	}
+
+	// Fetch only accepts the known keyservers' URLs, so point it at the test server
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+	fetched, err := client.Fetch(ctx, IndexURL("https://keys.openpgp.org", "0x"+keys[0].Fingerprint))
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if fetched.Fields["fingerprint"] != "0123456789ABCDEF0123456789ABCDEF01234567" || fetched.Fields["emails_verified"] != "true" {
+		t.Errorf("Fetch() = %+v", fetched)
+	}
+	if _, err := client.Fetch(ctx, IndexURL("https://keys.openpgp.org", "0xFFFF")); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("Fetch(unknown) error = %v, want ErrProfileNotFound", err)
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,128 @@
+// Package policy lets embedders decide which profiles may be fetched, for example only
+// those of users who consented, or nothing under certain top-level domains. A Decider
+// is consulted before every fetch.
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrDenied is returned for fetches a Decider denies.
+var ErrDenied = errors.New("denied by policy")
+
+// Request describes a fetch about to be made.
+type Request struct {
+	URL           string // Profile URL
+	Platform      string // Platform, as sociopath.PlatformForURL names it
+	Authenticated bool   // Whether login cookies would be sent
+}
+
+// Decision is a Decider's verdict on a Request.
+type Decision struct {
+	Allow  bool
+	Reason string // Why, for the log
+}
+
+// Allow returns a decision allowing the request for reason.
+func Allow(reason string) Decision { return Decision{Allow: true, Reason: reason} }
+
+// Deny returns a decision denying the request for reason.
+func Deny(reason string) Decision { return Decision{Reason: reason} }
+
+// Decider decides whether a fetch may be made. An error denies the fetch.
+type Decider interface {
+	Decide(ctx context.Context, req Request) (Decision, error)
+}
+
+// DeciderFunc adapts a function to the Decider interface.
+type DeciderFunc func(ctx context.Context, req Request) (Decision, error)
+
+// Decide calls f.
+func (f DeciderFunc) Decide(ctx context.Context, req Request) (Decision, error) { return f(ctx, req) }
+
+// All returns a Decider that allows a request only if every decider does, stopping at
+// the first denial.
+func All(deciders ...Decider) Decider {
+	return DeciderFunc(func(ctx context.Context, req Request) (Decision, error) {
+		var reasons []string
+		for _, d := range deciders {
+			decision, err := d.Decide(ctx, req)
+			if err != nil || !decision.Allow {
+				return decision, err
+			}
+			if decision.Reason != "" {
+				reasons = append(reasons, decision.Reason)
+			}
+		}
+		return Allow(strings.Join(reasons, "; ")), nil
+	})
+}
+
+// Hosts is a Decider over URL hosts. Entries match a host and its subdomains, so
+// "example.com" covers "www.example.com", and an entry starting with a dot, such as
+// ".ru", matches a top-level or other parent domain.
+type Hosts struct {
+	Allow []string // If set, only these hosts may be fetched
+	Deny  []string // These hosts may never be fetched; Deny wins over Allow
+}
+
+// Decide allows req if its host is not denied and, when Allow is set, is allowed.
+func (h Hosts) Decide(_ context.Context, req Request) (Decision, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Hostname() == "" {
+		return Deny("unparseable URL"), nil
+	}
+	host := strings.ToLower(u.Hostname())
+	if entry, ok := matchHost(host, h.Deny); ok {
+		return Deny("host " + host + " is denied by " + entry), nil
+	}
+	if len(h.Allow) == 0 {
+		return Allow(""), nil
+	}
+	if entry, ok := matchHost(host, h.Allow); ok {
+		return Allow("host " + host + " is allowed by " + entry), nil
+	}
+	return Deny("host " + host + " is not on the allowlist"), nil
+}
+
+func matchHost(host string, entries []string) (string, bool) {
+	for _, entry := range entries {
+		e := strings.ToLower(strings.TrimSpace(entry))
+		if e == "" {
+			continue
+		}
+		if host == strings.TrimPrefix(e, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(e, ".")) {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// Platforms is a Decider over platforms, as sociopath.PlatformForURL names them.
+type Platforms struct {
+	Allow []string // If set, only these platforms may be fetched
+	Deny  []string // These platforms may never be fetched; Deny wins over Allow
+}
+
+// Decide allows req if its platform is not denied and, when Allow is set, is allowed.
+func (p Platforms) Decide(_ context.Context, req Request) (Decision, error) {
+	if contains(p.Deny, req.Platform) {
+		return Deny("platform " + req.Platform + " is denied"), nil
+	}
+	if len(p.Allow) > 0 && !contains(p.Allow, req.Platform) {
+		return Deny("platform " + req.Platform + " is not on the allowlist"), nil
+	}
+	return Allow(""), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
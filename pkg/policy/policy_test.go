@@ -0,0 +1,73 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHosts(t *testing.T) {
+	h := Hosts{Allow: []string{"github.com", "mastodon.social"}, Deny: []string{".ru", "gist.github.com"}}
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/alice", true},
+		{"https://www.github.com/alice", true},
+		{"https://gist.github.com/alice", false},
+		{"https://mastodon.social/@alice", true},
+		{"https://vk.ru/alice", false},
+		{"https://twitter.com/alice", false},
+		{"https://notgithub.com/alice", false},
+		{"not a url", false},
+	}
+	for _, tt := range tests {
+		d, err := h.Decide(context.Background(), Request{URL: tt.url})
+		if err != nil {
+			t.Fatalf("Decide(%q) error = %v", tt.url, err)
+		}
+		if d.Allow != tt.want || d.Reason == "" && !tt.want {
+			t.Errorf("Decide(%q) = %+v, want allow %v with a reason for denials", tt.url, d, tt.want)
+		}
+	}
+
+	denyOnly := Hosts{Deny: []string{".xyz"}}
+	if d, _ := denyOnly.Decide(context.Background(), Request{URL: "https://example.com"}); !d.Allow { //nolint:errcheck // Hosts never fails
+		t.Error("deny-only Hosts should allow unlisted hosts")
+	}
+}
+
+func TestPlatforms(t *testing.T) {
+	p := Platforms{Allow: []string{"github", "linkedin"}, Deny: []string{"LinkedIn"}}
+	for platform, want := range map[string]bool{"github": true, "linkedin": false, "twitter": false} {
+		if d, _ := p.Decide(context.Background(), Request{Platform: platform}); d.Allow != want { //nolint:errcheck // Platforms never fails
+			t.Errorf("Decide(%q) = %+v, want allow %v", platform, d, want)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	noAuth := DeciderFunc(func(_ context.Context, req Request) (Decision, error) {
+		if req.Authenticated {
+			return Deny("authenticated fetches are not allowed"), nil
+		}
+		return Allow("anonymous"), nil
+	})
+	d := All(Hosts{Allow: []string{"github.com"}}, noAuth)
+
+	got, err := d.Decide(context.Background(), Request{URL: "https://github.com/alice"})
+	if err != nil || !got.Allow || got.Reason != "host github.com is allowed by github.com; anonymous" {
+		t.Errorf("Decide(anonymous) = %+v, %v", got, err)
+	}
+	got, err = d.Decide(context.Background(), Request{URL: "https://github.com/alice", Authenticated: true})
+	if err != nil || got.Allow || got.Reason != "authenticated fetches are not allowed" {
+		t.Errorf("Decide(authenticated) = %+v, %v", got, err)
+	}
+
+	failing := All(DeciderFunc(func(context.Context, Request) (Decision, error) {
+		return Decision{}, errors.New("consent service down")
+	}), noAuth)
+	if _, err := failing.Decide(context.Background(), Request{URL: "https://github.com/alice"}); err == nil {
+		t.Error("All() swallowed a decider error")
+	}
+}
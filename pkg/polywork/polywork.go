@@ -0,0 +1,240 @@
+// Package polywork fetches Polywork profiles.
+package polywork
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "polywork"
+
+var usernamePattern = regexp.MustCompile(`(?i)polywork\.com/([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are polywork.com paths that are not people.
+var nonProfiles = map[string]bool{
+	"login": true, "signup": true, "explore": true, "about": true, "blog": true, "careers": true,
+	"privacy": true, "terms": true, "settings": true, "search": true, "p": true, "collabs": true,
+}
+
+// Match returns true if the URL is a Polywork profile.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles Polywork requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Polywork client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Polywork profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://www.polywork.com/" + username
+	c.logger.InfoContext(ctx, "fetching polywork profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a Polywork profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://www.polywork.com/"+username, username)
+}
+
+var nextDataPattern = regexp.MustCompile(`(?s)<script id="__NEXT_DATA__" type="application/json"[^>]*>(.*?)</script>`)
+
+// pwProfile is the user object Polywork embeds in __NEXT_DATA__ (props.pageProps.user).
+type pwProfile struct {
+	Handle    string `json:"handle"`
+	Name      string `json:"name"`
+	Headline  string `json:"headline"`
+	Bio       string `json:"bio"`
+	Location  string `json:"location"`
+	Pronouns  string `json:"pronouns"`
+	Positions []struct {
+		Title       string `json:"title"`
+		CompanyName string `json:"companyName"`
+		Location    string `json:"location"`
+		Description string `json:"description"`
+		StartDate   string `json:"startDate"` // "2021-03-01"
+		EndDate     string `json:"endDate"`
+	} `json:"positions"`
+	Education []struct {
+		School    string `json:"schoolName"`
+		Degree    string `json:"degree"`
+		Field     string `json:"fieldOfStudy"`
+		StartDate string `json:"startDate"`
+		EndDate   string `json:"endDate"`
+	} `json:"education"`
+	Links []struct {
+		URL string `json:"url"`
+	} `json:"links"`
+	Badges []struct {
+		Name string `json:"name"`
+	} `json:"badges"`
+}
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	m := nextDataPattern.FindStringSubmatch(content)
+	if m == nil {
+		return nil, errors.New("profile not found (no page data)")
+	}
+	var data struct {
+		Props struct {
+			PageProps struct {
+				User *pwProfile `json:"user"`
+			} `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err := json.Unmarshal([]byte(m[1]), &data); err != nil {
+		return nil, fmt.Errorf("parse polywork page data: %w", err)
+	}
+	u := data.Props.PageProps.User
+	if u == nil || u.Name == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Name:     strings.TrimSpace(u.Name),
+		Bio:      strings.TrimSpace(u.Bio),
+		Location: strings.TrimSpace(u.Location),
+		Fields:   make(map[string]string),
+	}
+	if u.Handle != "" {
+		p.Username = u.Handle
+	}
+	if u.Headline != "" {
+		p.Fields[profile.FieldHeadline] = u.Headline
+	}
+	if u.Pronouns != "" {
+		p.Fields[profile.FieldPronouns] = u.Pronouns
+	}
+
+	for _, pos := range u.Positions {
+		p.Experience = append(p.Experience, profile.Experience{
+			Title:        pos.Title,
+			Organization: pos.CompanyName,
+			Location:     pos.Location,
+			Start:        monthOf(pos.StartDate),
+			End:          monthOf(pos.EndDate),
+			Description:  pos.Description,
+		})
+	}
+	for _, ed := range u.Education {
+		p.Education = append(p.Education, profile.Education{
+			School: ed.School,
+			Degree: ed.Degree,
+			Field:  ed.Field,
+			Start:  monthOf(ed.StartDate),
+			End:    monthOf(ed.EndDate),
+		})
+	}
+	if len(p.Experience) > 0 && p.Experience[0].End == "" && p.Experience[0].Organization != "" {
+		p.Fields[profile.FieldEmployer] = p.Experience[0].Organization
+	}
+
+	var badges []string
+	for _, b := range u.Badges {
+		if b.Name != "" {
+			badges = append(badges, b.Name)
+		}
+	}
+	if len(badges) > 0 {
+		p.Fields["badges"] = strings.Join(badges, ", ")
+	}
+
+	var found []string
+	for _, l := range u.Links {
+		if strings.HasPrefix(l.URL, "http") {
+			found = append(found, l.URL)
+		}
+	}
+	p.SocialLinks = links.Clean(found, Match)
+	return p, nil
+}
+
+// monthOf trims Polywork's full dates ("2021-03-01") to the YYYY-MM precision it actually records.
+func monthOf(date string) string {
+	if len(date) >= len("2006-01") {
+		return date[:len("2006-01")]
+	}
+	return date
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,78 @@
+package polywork
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://www.polywork.com/janedoe", true},
+		{"https://polywork.com/jane.doe/", true},
+		{"https://www.polywork.com/janedoe/highlights/abc", false},
+		{"https://www.polywork.com/explore", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Polywork should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"user":{
+		"handle":"janedoe","name":"Jane Doe","headline":"Staff Engineer at Acme","bio":"Distributed systems.",
+		"location":"Austin, TX","pronouns":"she/her",
+		"positions":[
+			{"title":"Staff Engineer","companyName":"Acme","startDate":"2021-03-01","endDate":null},
+			{"title":"Senior Engineer","companyName":"Initech","location":"Dallas, TX","startDate":"2017-06-01","endDate":"2021-02-28"}],
+		"education":[{"schoolName":"UT Austin","degree":"BS","fieldOfStudy":"Computer Science","startDate":"2011","endDate":"2015"}],
+		"links":[{"url":"https://github.com/janedoe"},{"url":"https://www.polywork.com/janedoe"}],
+		"badges":[{"name":"Speaker"},{"name":"Mentor"}]
+	}}}}</script>`
+
+	p, err := parseProfile(page, "https://www.polywork.com/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Location != "Austin, TX" || p.Fields[profile.FieldHeadline] != "Staff Engineer at Acme" {
+		t.Errorf("Name = %q, Location = %q, Fields = %v", p.Name, p.Location, p.Fields)
+	}
+	if len(p.Experience) != 2 {
+		t.Fatalf("Experience = %+v", p.Experience)
+	}
+	if got := p.Experience[1]; got.Start != "2017-06" || got.End != "2021-02" || got.Location != "Dallas, TX" {
+		t.Errorf("Experience[1] = %+v", got)
+	}
+	if p.Fields[profile.FieldEmployer] != "Acme" || p.Fields["badges"] != "Speaker, Mentor" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if len(p.Education) != 1 || p.Education[0].Field != "Computer Science" || p.Education[0].Start != "2011" {
+		t.Errorf("Education = %+v", p.Education)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://github.com/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	if _, err := parseProfile(`<html></html>`, "https://www.polywork.com/x", "x"); err == nil {
+		t.Error("parseProfile(no page data) should fail")
+	}
+	page := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"user":null}}}</script>`
+	if _, err := parseProfile(page, "https://www.polywork.com/x", "x"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseProfile() error = %v, want ErrProfileNotFound", err)
+	}
+}
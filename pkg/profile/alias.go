@@ -0,0 +1,79 @@
+package profile
+
+import (
+	"net/url"
+	"strings"
+)
+
+// aliasKey returns the form aliases are compared in: IdentityURL for URLs, and
+// lowercased without "@" for usernames.
+func aliasKey(alias string) string {
+	if strings.Contains(alias, "/") {
+		return IdentityURL(alias)
+	}
+	return strings.ToLower(strings.TrimPrefix(alias, "@"))
+}
+
+// AddAlias records alias, a URL or username the account was known by, in Aliases
+// unless it is p's own URL or username or is already there.
+func (p *Profile) AddAlias(alias string) {
+	alias = strings.TrimSpace(alias)
+	if alias == "" || alias == "@" {
+		return
+	}
+	key := aliasKey(alias)
+	if key == aliasKey(p.URL) || key == aliasKey(p.Username) || p.hasAlias(alias) {
+		return
+	}
+	p.Aliases = append(p.Aliases, alias)
+}
+
+// hasAlias reports whether alias is already in Aliases.
+func (p *Profile) hasAlias(alias string) bool {
+	key := aliasKey(alias)
+	for _, a := range p.Aliases {
+		if aliasKey(a) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// AddAliases records in Aliases the earlier addresses p's own data reveals: accounts
+// that moved to it (FieldMovedFrom) and the usernames its bio says were used before
+// (FieldFormerHandles). Run it after AddBioHints.
+func (p *Profile) AddAliases() {
+	for _, a := range p.MovedFrom() {
+		p.AddAlias(a)
+	}
+	for _, h := range p.FormerHandles() {
+		p.AddAlias(h)
+	}
+}
+
+// AddRequestedAlias records requested, the URL p was fetched from, in Aliases when it
+// does not name p's username, as when a platform redirects a renamed account's old
+// URL to its new one. Generic pages and failed fetches are skipped, as their
+// username, if any, is not the platform's.
+func (p *Profile) AddRequestedAlias(requested string) {
+	if p.Platform == "" || p.Platform == "generic" || p.Error != "" || p.URL == "" || p.Username == "" {
+		return
+	}
+	u, err := url.Parse(requested)
+	if err != nil {
+		return
+	}
+	// The username may be a path segment, as in /@alice@hachyderm.io, or a query
+	// value, as in /intent/follow?screen_name=alice
+	names := strings.Split(u.Path, "/")
+	for _, values := range u.Query() {
+		names = append(names, values...)
+	}
+	for _, name := range names {
+		name, _, _ = strings.Cut(strings.TrimPrefix(name, "@"), "@")
+		if strings.EqualFold(name, strings.TrimPrefix(p.Username, "@")) {
+			return
+		}
+	}
+	p.AddAlias(requested)
+}
@@ -0,0 +1,58 @@
+package profile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddAliases(t *testing.T) {
+	p := &Profile{
+		Platform: "mastodon", URL: "https://hachyderm.io/@alice", Username: "alice",
+		Fields: map[string]string{
+			FieldMovedFrom:     "https://mastodon.social/@alice, https://fosstodon.org/@alice",
+			FieldFormerHandles: "alice_old, Alice",
+		},
+	}
+	p.AddAliases()
+	p.AddAlias("https://MASTODON.social/@alice/")
+	p.AddAlias("@Alice_Old")
+	want := []string{"https://mastodon.social/@alice", "https://fosstodon.org/@alice", "alice_old"}
+	if !slices.Equal(p.Aliases, want) {
+		t.Errorf("Aliases = %v, want %v", p.Aliases, want)
+	}
+}
+
+func TestAddRequestedAlias(t *testing.T) {
+	tests := []struct {
+		platform  string
+		requested string
+		want      []string
+	}{
+		{"github", "https://github.com/alice-old", []string{"https://github.com/alice-old"}},
+		{"github", "https://x.com/intent/follow?screen_name=alice", nil},
+		{"github", "https://github.com/Alice?tab=repositories", nil},
+		{"generic", "https://alice.dev/about", nil},
+	}
+	for _, tt := range tests {
+		p := &Profile{Platform: tt.platform, URL: "https://github.com/alice", Username: "alice"}
+		p.AddRequestedAlias(tt.requested)
+		if !slices.Equal(p.Aliases, tt.want) {
+			t.Errorf("AddRequestedAlias(%q) on %s: Aliases = %v, want %v", tt.requested, tt.platform, p.Aliases, tt.want)
+		}
+	}
+}
+
+func TestDedupeAliases(t *testing.T) {
+	old := &Profile{Platform: "mastodon", URL: "https://mastodon.social/@alice", Username: "alice", Bio: "moved"}
+	current := &Profile{
+		Platform: "mastodon", URL: "https://hachyderm.io/@alice", Username: "alice",
+		Aliases: []string{"https://mastodon.social/@alice"},
+	}
+	got := Dedupe([]*Profile{old, current})
+	if len(got) != 1 {
+		t.Fatalf("Dedupe() = %d profiles, want 1", len(got))
+	}
+	if len(got[0].SocialLinks) != 0 {
+		t.Errorf("SocialLinks = %v, want none", got[0].SocialLinks)
+	}
+}
@@ -0,0 +1,85 @@
+package profile
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// mentionPattern matches @mentions, but not email addresses or URL paths.
+	mentionPattern = regexp.MustCompile(`(?:^|[^\w@/.+-])@([A-Za-z0-9_](?:[A-Za-z0-9_.-]*[A-Za-z0-9_])?)`)
+	// hashtagPattern matches #hashtags with at least one letter, so "#1" is skipped.
+	hashtagPattern = regexp.MustCompile(`(?:^|[^\w&#/])#([\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*)`)
+	// formerPrefix matches the words before a handle the person no longer uses.
+	formerPrefix = regexp.MustCompile(`(?i)(?:\b(?:formerly(?:\s+known\s+as)?|previously|fka|prev|was)|\bf\.k\.a\.?|\bprev\.)[\s:]*$`)
+	// selfPrefix matches the words before a handle the person says is theirs.
+	selfPrefix = regexp.MustCompile(`(?i)(?:\b(?:aka|also|i'?m|i\s+am|find\s+me\s+(?:as|at)|handle)|\ba\.k\.a\.?)[\s:]*$`)
+	// selfSuffix matches the words after a handle the person says they use elsewhere.
+	selfSuffix = regexp.MustCompile(`(?i)^\s*(?:on\s+(?:most|all|every|other)\b|everywhere\b|elsewhere\b|across\b|on\s+(?:twitter|x|github|gitlab|instagram|bluesky|threads|tiktok|reddit|mastodon|linkedin|youtube)\b)`)
+)
+
+// AddBioHints records the handles, former handles, other mentions, and hashtags in
+// the bio under FieldHandles, FieldFormerHandles, FieldMentions, and FieldHashtags,
+// leaving fields a platform already set alone:
+//
+//	"@tstromberg on most platforms"  -> handles: tstromberg
+//	"formerly @tstrombergcg"         -> former_handles: tstrombergcg
+//	"Engineer @chainguard-dev"       -> mentions: chainguard-dev
+//	"#golang #security"              -> hashtags: golang, security
+//
+// Fediverse handles such as "@alice@hachyderm.io" are not mentions; they are links.
+func (p *Profile) AddBioHints() {
+	if p.Bio == "" {
+		return
+	}
+	var handles, former, mentions []string
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(p.Bio, -1) {
+		start, end := m[2], m[3]
+		if end < len(p.Bio) && p.Bio[end] == '@' {
+			continue
+		}
+		name := p.Bio[start:end]
+		before, after := p.Bio[:start-1], p.Bio[end:]
+		switch {
+		case formerPrefix.MatchString(before):
+			former = appendNew(former, name)
+		case selfPrefix.MatchString(before) || selfSuffix.MatchString(after):
+			handles = appendNew(handles, name)
+		default:
+			mentions = appendNew(mentions, name)
+		}
+	}
+	var tags []string
+	for _, m := range hashtagPattern.FindAllStringSubmatch(p.Bio, -1) {
+		tags = appendNew(tags, strings.ToLower(m[1]))
+	}
+
+	p.setList(FieldHandles, handles)
+	p.setList(FieldFormerHandles, former)
+	p.setList(FieldMentions, mentions)
+	p.setList(FieldHashtags, tags)
+}
+
+// appendNew appends s to list unless it is already there, ignoring case.
+func appendNew(list []string, s string) []string {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// setList stores values under key as the list accessors read them, unless key is set.
+func (p *Profile) setList(key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	if _, ok := p.Field(key); ok {
+		return
+	}
+	if p.Fields == nil {
+		p.Fields = make(map[string]string)
+	}
+	p.Fields[key] = strings.Join(values, ", ")
+}
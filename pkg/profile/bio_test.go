@@ -0,0 +1,43 @@
+package profile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddBioHints(t *testing.T) {
+	p := &Profile{Bio: "Engineer @chainguard-dev. @tstromberg on most platforms, formerly @tstrombergcg. " +
+		"Mail me at t@example.com, fediverse @thomas@hachyderm.io. #Golang #security #1 https://x.dev/#anchor #golang"}
+	p.AddBioHints()
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"Handles", p.Handles(), []string{"tstromberg"}},
+		{"FormerHandles", p.FormerHandles(), []string{"tstrombergcg"}},
+		{"Mentions", p.Mentions(), []string{"chainguard-dev"}},
+		{"Hashtags", p.Hashtags(), []string{"golang", "security"}},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s() = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	for _, bio := range []string{"aka @janed", "@janed everywhere", "I'm @janed on Twitter"} {
+		p := &Profile{Bio: bio}
+		p.AddBioHints()
+		if got := p.Handles(); !slices.Equal(got, []string{"janed"}) {
+			t.Errorf("Handles() for %q = %v, want [janed]", bio, got)
+		}
+	}
+
+	// Fields a platform set are kept
+	p = &Profile{Bio: "#rust", Fields: map[string]string{FieldHashtags: "go"}}
+	p.AddBioHints()
+	if got := p.Hashtags(); !slices.Equal(got, []string{"go"}) {
+		t.Errorf("Hashtags() = %v, want the platform's kept", got)
+	}
+}
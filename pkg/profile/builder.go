@@ -0,0 +1,120 @@
+package profile
+
+import (
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+)
+
+// Builder assembles a Profile from extraction steps that may run concurrently,
+// deduplicating and validating values as they are added.
+type Builder struct {
+	p      *Profile
+	links  map[string]bool // links.Key of each SocialLinks entry
+	emails map[string]bool
+	mu     sync.Mutex
+}
+
+// NewBuilder returns a Builder for a profile on platform fetched from url.
+func NewBuilder(platform, url string) *Builder {
+	return &Builder{
+		p:      &Profile{Platform: platform, URL: url, Fields: make(map[string]string)},
+		links:  make(map[string]bool),
+		emails: make(map[string]bool),
+	}
+}
+
+// Edit calls fn with the profile under the Builder's lock, for setting core fields
+// such as Name and Bio.
+func (b *Builder) Edit(fn func(p *Profile)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	fn(b.p)
+}
+
+// AddField sets Fields[key] to the trimmed value unless the value is empty or key is
+// already set, so the first extraction step to find a value wins. It reports whether
+// the value was stored.
+func (b *Builder) AddField(key, value string) bool {
+	value = strings.TrimSpace(value)
+	if key == "" || value == "" {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.p.Fields[key]; ok {
+		return false
+	}
+	b.p.Fields[key] = value
+	return true
+}
+
+// AddLink appends link to SocialLinks if it is an absolute http(s) URL that is not on
+// links.DefaultDenylist and not already present, ignoring case and trailing slashes.
+// It reports whether link was added.
+func (b *Builder) AddLink(link string) bool {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	if links.DefaultDenylist.Denied(link) {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := links.Key(link)
+	if b.links[key] {
+		return false
+	}
+	b.links[key] = true
+	b.p.SocialLinks = append(b.p.SocialLinks, link)
+	return true
+}
+
+// AddLinks calls AddLink for each link.
+func (b *Builder) AddLinks(links ...string) {
+	for _, link := range links {
+		b.AddLink(link)
+	}
+}
+
+// AddEmail records a valid email address, accepting "mailto:" links. The first address
+// is stored in Fields["email"] and later ones in "email_2", "email_3", and so on.
+// It reports whether the address was new and valid.
+func (b *Builder) AddEmail(email string) bool {
+	email = strings.TrimSpace(email)
+	if len(email) >= len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+		email = email[len("mailto:"):]
+	}
+	email, _, _ = strings.Cut(email, "?")
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || !strings.Contains(email[strings.LastIndex(email, "@"):], ".") {
+		return false
+	}
+	email = strings.ToLower(email)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.emails[email] {
+		return false
+	}
+	b.emails[email] = true
+	key := FieldEmail
+	if n := len(b.emails); n > 1 {
+		key = FieldEmail + "_" + strconv.Itoa(n)
+	}
+	b.p.Fields[key] = email
+	return true
+}
+
+// Build returns the assembled profile. The Builder must not be used afterwards.
+func (b *Builder) Build() *Profile {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.p
+}
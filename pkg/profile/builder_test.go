@@ -0,0 +1,62 @@
+package profile
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder("habr", "https://habr.com/en/users/alice")
+	b.Edit(func(p *Profile) { p.Name = "Alice" })
+
+	if !b.AddField(FieldEmployer, "  Acme  ") {
+		t.Error("AddField(employer) = false, want true")
+	}
+	if b.AddField(FieldEmployer, "Other") || b.AddField("blank", " ") {
+		t.Error("AddField() overwrote a field or stored an empty value")
+	}
+
+	b.AddLinks(
+		"https://github.com/alice",
+		"https://GITHUB.com/alice/",
+		"javascript:alert(1)",
+		"https://twitter.com/intent/tweet?text=hello",
+		"/relative/path",
+		"https://mastodon.social/@alice",
+	)
+
+	for _, email := range []string{"mailto:Alice@Example.com", "alice@example.com", "not-an-email", "bob@localhost", "bob@example.org"} {
+		b.AddEmail(email)
+	}
+
+	p := b.Build()
+	if p.Platform != "habr" || p.Name != "Alice" || p.Fields[FieldEmployer] != "Acme" {
+		t.Errorf("profile = %+v, want platform, name, and trimmed employer", p)
+	}
+	if want := []string{"https://github.com/alice", "https://mastodon.social/@alice"}; !slices.Equal(p.SocialLinks, want) {
+		t.Errorf("SocialLinks = %v, want %v", p.SocialLinks, want)
+	}
+	if p.Fields["email"] != "alice@example.com" || p.Fields["email_2"] != "bob@example.org" || p.Fields["email_3"] != "" {
+		t.Errorf("emails = %q, %q, %q; want alice, bob, none", p.Fields["email"], p.Fields["email_2"], p.Fields["email_3"])
+	}
+}
+
+func TestBuilderConcurrent(t *testing.T) {
+	b := NewBuilder("generic", "https://example.com")
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.AddLink(fmt.Sprintf("https://example.com/%d", i%10))
+			b.AddField(fmt.Sprintf("k%d", i%5), "v")
+		}()
+	}
+	wg.Wait()
+	p := b.Build()
+	if len(p.SocialLinks) != 10 || len(p.Fields) != 5 {
+		t.Errorf("got %d links and %d fields, want 10 and 5", len(p.SocialLinks), len(p.Fields))
+	}
+}
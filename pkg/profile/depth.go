@@ -0,0 +1,58 @@
+package profile
+
+import "fmt"
+
+// Depth controls how much work a fetch does beyond the primary request.
+// The zero value is DepthStandard.
+type Depth int
+
+// Extraction depths.
+const (
+	DepthStandard Depth = iota // Profile data plus the secondary fetches each platform does by default
+	DepthMinimal               // Only the primary API or page request: no HTML second fetch, posts, or feeds
+	DepthDeep                  // Everything in standard plus more posts, feeds, and contact pages
+)
+
+// Post limits per depth, for platforms that page through recent posts.
+const (
+	standardPostLimit = 50
+	deepPostLimit     = 100
+)
+
+// String returns the depth name used by ParseDepth.
+func (d Depth) String() string {
+	switch d {
+	case DepthMinimal:
+		return "minimal"
+	case DepthDeep:
+		return "deep"
+	default:
+		return "standard"
+	}
+}
+
+// PostLimit returns how many recent posts to request at this depth; 0 means none.
+func (d Depth) PostLimit() int {
+	switch d {
+	case DepthMinimal:
+		return 0
+	case DepthDeep:
+		return deepPostLimit
+	default:
+		return standardPostLimit
+	}
+}
+
+// ParseDepth parses "minimal", "standard", or "deep". An empty string is DepthStandard.
+func ParseDepth(s string) (Depth, error) {
+	switch s {
+	case "", "standard":
+		return DepthStandard, nil
+	case "minimal":
+		return DepthMinimal, nil
+	case "deep":
+		return DepthDeep, nil
+	default:
+		return DepthStandard, fmt.Errorf("unknown depth %q (want minimal, standard, or deep)", s)
+	}
+}
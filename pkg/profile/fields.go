@@ -0,0 +1,297 @@
+package profile
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Canonical Fields keys. Platforms write these names so consumers can read them
+// through the typed accessors below instead of guessing per-platform spellings.
+const (
+	FieldFollowers       = "followers"        // Accounts following this one
+	FieldFollowing       = "following"        // Accounts this one follows
+	FieldSubscribers     = "subscribers"      // Channel or newsletter subscribers
+	FieldConnections     = "connections"      // LinkedIn connections ("500+" normalizes to 500)
+	FieldVideos          = "videos"           // Uploaded videos
+	FieldRepositories    = "public_repos"     // Public code repositories
+	FieldStars           = "stars"            // Stars on the account's own top repositories
+	FieldLanguages       = "languages"        // Primary languages of the top repositories, most common first
+	FieldOrganizations   = "organizations"    // Organizations the account belongs to, comma-separated
+	FieldReputation      = "reputation"       // Q&A site reputation
+	FieldEmployer        = "employer"         // Current employer
+	FieldCompany         = "company"          // Self-described company (GitHub), used when employer is unset
+	FieldEmail           = "email"            // Primary public email address
+	FieldHeadline        = "headline"         // Professional headline
+	FieldTitle           = "title"            // Job title
+	FieldTitleNormalized = "title_normalized" // Canonical role of the job title, such as "software engineer"
+	FieldSeniority       = "seniority"        // Seniority of the job title, one of the Seniority levels
+	FieldPronouns        = "pronouns"         // Stated pronouns
+	FieldAvatarURL       = "avatar_url"       // Profile picture URL
+	FieldAchievements    = "achievements"     // Badges earned, comma-separated, with tiers as in "pull-shark x3"
+	FieldSponsorable     = "sponsorable"      // "true" if the account accepts sponsorships
+	FieldSponsors        = "sponsors"         // Accounts sponsoring this one
+	FieldSponsoring      = "sponsoring"       // Accounts this one sponsors
+	FieldHandles         = "handles"          // Usernames the bio says are used elsewhere, as in "@alice on most platforms"
+	FieldFormerHandles   = "former_handles"   // Usernames the bio says were used before, as in "formerly @alice"
+	FieldMentions        = "mentions"         // Other accounts the bio @mentions, such as an employer
+	FieldHashtags        = "hashtags"         // The bio's #hashtags, lowercased without "#"
+	FieldFlags           = "flags"            // Region codes of flag emoji in the name or bio, such as "DE"
+	FieldEmojiSignals    = "emoji_signals"    // What emoji in the name or bio signal, such as "pride"
+	FieldMovedTo         = "moved_to"         // URL of the account this one says it moved to
+	FieldMovedFrom       = "moved_from"       // URLs of accounts that moved to this one, oldest last
+)
+
+// fieldAliases lists other spellings of canonical keys found in older data and
+// in fields copied verbatim from platforms (such as Mastodon profile fields).
+var fieldAliases = map[string][]string{
+	FieldFollowers:    {"follower_count", "followers_count"},
+	FieldFollowing:    {"following_count", "follows_count"},
+	FieldSubscribers:  {"subscriber_count", "subscribers_count"},
+	FieldRepositories: {"repos", "repositories"},
+	FieldAvatarURL:    {"avatar"},
+}
+
+// Field returns the value of a canonical key, falling back to its known aliases.
+func (p *Profile) Field(key string) (string, bool) {
+	if p.Fields == nil {
+		return "", false
+	}
+	if v := p.Fields[key]; v != "" {
+		return v, true
+	}
+	for _, alias := range fieldAliases[key] {
+		if v := p.Fields[alias]; v != "" {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Followers returns the follower count.
+func (p *Profile) Followers() (int, bool) { return p.count(FieldFollowers) }
+
+// Following returns how many accounts the profile follows.
+func (p *Profile) Following() (int, bool) { return p.count(FieldFollowing) }
+
+// Subscribers returns the subscriber count.
+func (p *Profile) Subscribers() (int, bool) { return p.count(FieldSubscribers) }
+
+// Connections returns the LinkedIn connection count; "500+" is returned as 500.
+func (p *Profile) Connections() (int, bool) { return p.count(FieldConnections) }
+
+// Repositories returns the public repository count.
+func (p *Profile) Repositories() (int, bool) { return p.count(FieldRepositories) }
+
+// Stars returns the stars on the account's own top repositories.
+func (p *Profile) Stars() (int, bool) { return p.count(FieldStars) }
+
+// Sponsors returns how many accounts sponsor the profile.
+func (p *Profile) Sponsors() (int, bool) { return p.count(FieldSponsors) }
+
+// Sponsoring returns how many accounts the profile sponsors.
+func (p *Profile) Sponsoring() (int, bool) { return p.count(FieldSponsoring) }
+
+// Sponsorable reports whether the account accepts sponsorships.
+func (p *Profile) Sponsorable() bool {
+	v, _ := p.Field(FieldSponsorable)
+	return v == "true"
+}
+
+// Achievements returns the badges the profile has earned, such as "pull-shark x3".
+func (p *Profile) Achievements() []string { return p.list(FieldAchievements) }
+
+// Languages returns the primary languages of the account's top repositories, most
+// common first.
+func (p *Profile) Languages() []string { return p.list(FieldLanguages) }
+
+// Organizations returns the organizations the account belongs to.
+func (p *Profile) Organizations() []string { return p.list(FieldOrganizations) }
+
+// MovedFrom returns the URLs of accounts that moved to this one.
+func (p *Profile) MovedFrom() []string { return p.list(FieldMovedFrom) }
+
+// Handles returns the usernames the bio says the person uses on other platforms.
+func (p *Profile) Handles() []string { return p.list(FieldHandles) }
+
+// FormerHandles returns the usernames the bio says the person used before.
+func (p *Profile) FormerHandles() []string { return p.list(FieldFormerHandles) }
+
+// Pronouns returns the stated pronouns, such as "she/her".
+func (p *Profile) Pronouns() (string, bool) { return p.Field(FieldPronouns) }
+
+// Flags returns the region codes of the flag emoji in the name or bio, such as "DE".
+// They hint at where someone is from or lives without saying which.
+func (p *Profile) Flags() []string { return p.list(FieldFlags) }
+
+// EmojiSignals returns what emoji in the name or bio signal, such as "pride".
+func (p *Profile) EmojiSignals() []string { return p.list(FieldEmojiSignals) }
+
+// Mentions returns the other accounts the bio @mentions, without the "@".
+func (p *Profile) Mentions() []string { return p.list(FieldMentions) }
+
+// Hashtags returns the bio's hashtags, lowercased without the "#".
+func (p *Profile) Hashtags() []string { return p.list(FieldHashtags) }
+
+// Employer returns the current employer, falling back to the self-described company.
+func (p *Profile) Employer() (string, bool) {
+	if v, ok := p.Field(FieldEmployer); ok {
+		return v, true
+	}
+	return p.Field(FieldCompany)
+}
+
+// Email returns the primary public email address.
+func (p *Profile) Email() (string, bool) { return p.Field(FieldEmail) }
+
+// JoinedAt returns when the account was created, parsed from CreatedAt.
+// Partial dates such as "2015" or "2015-06" resolve to the start of that period.
+func (p *Profile) JoinedAt() (time.Time, bool) { return ParseTime(p.CreatedAt) }
+
+// UpdatedAtTime returns the last profile update, parsed from UpdatedAt.
+func (p *Profile) UpdatedAtTime() (time.Time, bool) { return ParseTime(p.UpdatedAt) }
+
+// LastActiveTime returns the most recent public activity, parsed from LastActive.
+func (p *Profile) LastActiveTime() (time.Time, bool) { return ParseTime(p.LastActive) }
+
+// UpdateLastActive sets LastActive to ts if ts is a parseable date later than the
+// current value. Platforms call it with each activity signal they find.
+func (p *Profile) UpdateLastActive(ts string) {
+	t, ok := ParseTime(ts)
+	if !ok {
+		return
+	}
+	if cur, ok := p.LastActiveTime(); ok && !t.After(cur) {
+		return
+	}
+	p.LastActive = ts
+}
+
+// InactiveSince reports whether the profile's last public activity was before cutoff,
+// which is how callers detect abandoned accounts. Profiles without a known LastActive
+// are not reported as inactive.
+func (p *Profile) InactiveSince(cutoff time.Time) bool {
+	t, ok := p.LastActiveTime()
+	return ok && t.Before(cutoff)
+}
+
+// list returns the comma-separated values of a canonical key.
+func (p *Profile) list(key string) []string {
+	v, ok := p.Field(key)
+	if !ok {
+		return nil
+	}
+	return strings.Split(v, ", ")
+}
+
+func (p *Profile) count(key string) (int, bool) {
+	v, ok := p.Field(key)
+	if !ok {
+		return 0, false
+	}
+	return ParseCount(v)
+}
+
+// datePrecision is how much of a date a source string specified.
+type datePrecision int
+
+const (
+	precisionYear datePrecision = iota
+	precisionMonth
+	precisionDay
+	precisionTime
+)
+
+// timeLayouts are the date formats platforms report, most specific first.
+var timeLayouts = []struct {
+	layout    string
+	precision datePrecision
+}{
+	{time.RFC3339Nano, precisionTime},
+	{time.RFC3339, precisionTime},
+	{"2006-01-02T15:04:05", precisionTime},
+	{"2006-01-02 15:04:05", precisionTime},
+	{time.RFC1123Z, precisionTime},
+	{time.RFC1123, precisionTime},
+	{time.RubyDate, precisionTime}, // Twitter and Weibo: "Wed Mar 04 12:00:00 +0000 2009"
+	{time.DateOnly, precisionDay},
+	{"January 2, 2006", precisionDay},
+	{"Jan 2, 2006", precisionDay},
+	{"2 January 2006", precisionDay},
+	{"2 Jan 2006", precisionDay},
+	{"2006-01", precisionMonth},
+	{"January 2006", precisionMonth},
+	{"Jan 2006", precisionMonth},
+	{"2006", precisionYear},
+}
+
+// datePrefixes are phrases platforms put before join dates.
+var datePrefixes = []string{"joined on", "joined", "member since", "redditor since", "user since", "since", "on"}
+
+// ParseTime parses the date formats found in CreatedAt, UpdatedAt, LastActive, and date fields,
+// including phrases like "Joined March 2019" and "redditor since 2020".
+func ParseTime(s string) (time.Time, bool) {
+	t, _, ok := parseTime(s)
+	return t, ok
+}
+
+func parseTime(s string) (time.Time, datePrecision, bool) {
+	s = strings.TrimSpace(s)
+	for _, prefix := range datePrefixes {
+		if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) && s[len(prefix)] == ' ' {
+			s = strings.TrimSpace(s[len(prefix):])
+			break
+		}
+	}
+	if s == "" {
+		return time.Time{}, 0, false
+	}
+	for _, l := range timeLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return t, l.precision, true
+		}
+	}
+	// Unix seconds, as some APIs report
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n > 1e8 {
+		return time.Unix(n, 0).UTC(), precisionTime, true
+	}
+	return time.Time{}, 0, false
+}
+
+// countSuffixes maps abbreviations in counts like "1.2K" or "3万" to multipliers.
+var countSuffixes = map[string]float64{
+	"k": 1e3, "m": 1e6, "b": 1e9,
+	"万": 1e4, "亿": 1e8, //nolint:gosmopolitan // Chinese counts on Bilibili and Weibo
+}
+
+// ParseCount parses counts as platforms display them: "1234", "1,234", "1.2K",
+// "3.4M", "500+", or "1.5万".
+func ParseCount(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "+")
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.ReplaceAll(s, " ", "")
+	if s == "" {
+		return 0, false
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 {
+			return 0, false
+		}
+		return n, true
+	}
+
+	mult := 1.0
+	for suffix, m := range countSuffixes {
+		if trimmed, ok := strings.CutSuffix(strings.ToLower(s), suffix); ok {
+			s, mult = trimmed, m
+			break
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0, false
+	}
+	return int(math.Round(f * mult)), true
+}
@@ -0,0 +1,119 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   int
+		wantOK bool
+	}{
+		{"1234", 1234, true},
+		{"1,234", 1234, true},
+		{"1.2K", 1200, true},
+		{"3.4M", 3400000, true},
+		{"500+", 500, true},
+		{"1.5万", 15000, true},
+		{"", 0, false},
+		{"many", 0, false},
+		{"-5", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseCount(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseCount(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2015-06-01T12:30:00Z", time.Date(2015, 6, 1, 12, 30, 0, 0, time.UTC)},
+		{"2015-06-01", time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"2015-06", time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"2015", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Jun 1, 2015", time.Date(2015, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"1433161800", time.Date(2015, 6, 1, 12, 30, 0, 0, time.UTC)},
+		{"Mon Jun 01 12:30:00 +0000 2015", time.Date(2015, 6, 1, 12, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := ParseTime(tt.in)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, %v; want %v", tt.in, got, ok, tt.want)
+		}
+	}
+	if _, ok := ParseTime("last week"); ok {
+		t.Error("ParseTime(\"last week\") should fail")
+	}
+}
+
+func TestProfileAccessors(t *testing.T) {
+	p := &Profile{
+		CreatedAt: "2011-01-25T18:44:36Z",
+		Fields: map[string]string{
+			FieldFollowers:    "1.2k",
+			"following_count": "42",
+			FieldConnections:  "500+",
+			FieldCompany:      "@acme",
+		},
+	}
+
+	if n, ok := p.Followers(); !ok || n != 1200 {
+		t.Errorf("Followers() = %d, %v; want 1200", n, ok)
+	}
+	if n, ok := p.Following(); !ok || n != 42 {
+		t.Errorf("Following() via alias = %d, %v; want 42", n, ok)
+	}
+	if n, ok := p.Connections(); !ok || n != 500 {
+		t.Errorf("Connections() = %d, %v; want 500", n, ok)
+	}
+	if _, ok := p.Subscribers(); ok {
+		t.Error("Subscribers() should be unset")
+	}
+	if e, ok := p.Employer(); !ok || e != "@acme" {
+		t.Errorf("Employer() = %q, %v; want company fallback", e, ok)
+	}
+	if j, ok := p.JoinedAt(); !ok || j.Year() != 2011 {
+		t.Errorf("JoinedAt() = %v, %v; want 2011", j, ok)
+	}
+
+	var empty Profile
+	if _, ok := empty.Email(); ok {
+		t.Error("Email() on empty profile should be unset")
+	}
+}
+
+func TestUpdateLastActive(t *testing.T) {
+	var p Profile
+	p.UpdateLastActive("not a date")
+	if p.LastActive != "" {
+		t.Errorf("LastActive = %q after unparseable date, want empty", p.LastActive)
+	}
+	p.UpdateLastActive("2024-03-01")
+	p.UpdateLastActive("2023-12-31T23:59:59Z") // older, ignored
+	if p.LastActive != "2024-03-01" {
+		t.Errorf("LastActive = %q, want 2024-03-01", p.LastActive)
+	}
+	p.UpdateLastActive("2024-03-01T08:00:00Z")
+	if p.LastActive != "2024-03-01T08:00:00Z" {
+		t.Errorf("LastActive = %q, want later timestamp", p.LastActive)
+	}
+
+	cutoff := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if !p.InactiveSince(cutoff) {
+		t.Error("InactiveSince(2024-06-01) = false, want true")
+	}
+	if p.InactiveSince(cutoff.AddDate(-1, 0, 0)) {
+		t.Error("InactiveSince(2023-06-01) = true, want false")
+	}
+	var unknown Profile
+	if unknown.InactiveSince(cutoff) {
+		t.Error("InactiveSince with no LastActive = true, want false")
+	}
+}
@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// fingerprintVersion is hashed into every fingerprint so a change to the canonical
+// form produces new IDs rather than silently colliding with old ones.
+const fingerprintVersion = "v1"
+
+// CanonicalURL returns u in the form fingerprints use: lowercased, without scheme,
+// "www.", query string, fragment, or trailing slash.
+func CanonicalURL(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+	u = strings.TrimPrefix(u, "www.")
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	return strings.TrimRight(u, "/")
+}
+
+// Fingerprint returns a stable ID for the account p describes, derived from its
+// canonical URL, platform, and username. Re-crawling the same account yields the same
+// fingerprint regardless of URL scheme, case, or tracking parameters.
+func (p *Profile) Fingerprint() string {
+	return hashParts(fingerprintVersion,
+		CanonicalURL(p.URL),
+		strings.ToLower(p.Platform),
+		strings.ToLower(strings.TrimPrefix(p.Username, "@")))
+}
+
+// IdentityID returns a stable ID for a set of profiles believed to belong to one
+// person, such as a FetchRecursive result. It depends only on which accounts are in
+// the set, not their order; profiles with an Error are ignored.
+func IdentityID(profiles []*Profile) string {
+	var fps []string
+	seen := make(map[string]bool)
+	for _, p := range profiles {
+		if p == nil || p.Error != "" {
+			continue
+		}
+		if fp := p.Fingerprint(); !seen[fp] {
+			seen[fp] = true
+			fps = append(fps, fp)
+		}
+	}
+	if len(fps) == 0 {
+		return ""
+	}
+	sort.Strings(fps)
+	return hashParts(append([]string{fingerprintVersion, "identity"}, fps...)...)
+}
+
+// hashParts hashes parts unambiguously and returns the first 128 bits as hex.
+func hashParts(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
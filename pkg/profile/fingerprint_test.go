@@ -0,0 +1,56 @@
+package profile
+
+import "testing"
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://github.com/TStromberg", "github.com/tstromberg"},
+		{"http://www.github.com/tstromberg/", "github.com/tstromberg"},
+		{"https://x.com/alice?utm_source=bio#top", "x.com/alice"},
+		{" mastodon.social/@alice ", "mastodon.social/@alice"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalURL(tt.in); got != tt.want {
+			t.Errorf("CanonicalURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := &Profile{Platform: "github", URL: "https://github.com/TStromberg", Username: "TStromberg", Name: "Thomas"}
+	b := &Profile{Platform: "github", URL: "http://www.github.com/tstromberg/?tab=repos", Username: "tstromberg", Bio: "changed"}
+	c := &Profile{Platform: "gitlab", URL: "https://gitlab.com/tstromberg", Username: "tstromberg"}
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("re-crawled profile fingerprints differ: %s vs %s", a.Fingerprint(), b.Fingerprint())
+	}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("different accounts share a fingerprint")
+	}
+	if len(a.Fingerprint()) != 32 {
+		t.Errorf("len(Fingerprint()) = %d, want 32", len(a.Fingerprint()))
+	}
+}
+
+func TestIdentityID(t *testing.T) {
+	gh := &Profile{Platform: "github", URL: "https://github.com/alice", Username: "alice"}
+	masto := &Profile{Platform: "mastodon", URL: "https://hachyderm.io/@alice", Username: "alice"}
+	failed := &Profile{Platform: "twitter", URL: "https://x.com/alice", Error: "login required"}
+
+	id := IdentityID([]*Profile{gh, masto})
+	if id == "" {
+		t.Fatal("IdentityID() is empty")
+	}
+	if got := IdentityID([]*Profile{masto, failed, gh, gh}); got != id {
+		t.Errorf("IdentityID() depends on order, duplicates, or errors: %s vs %s", got, id)
+	}
+	if got := IdentityID([]*Profile{gh}); got == id {
+		t.Error("IdentityID() of a subset matches the full set")
+	}
+	if got := IdentityID(nil); got != "" {
+		t.Errorf("IdentityID(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,128 @@
+package profile
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Footprint summarizes open-source activity across profiles believed to belong to
+// one person: code hosting accounts, the languages and organizations they show, and
+// accounts on package registries.
+type Footprint struct {
+	Repositories  int                 `json:"repositories"`            // Sum of public repositories over all accounts
+	Stars         int                 `json:"stars"`                   // Sum of stars on the accounts' top repositories
+	Languages     []string            `json:"languages,omitempty"`     // Primary languages, most used first
+	Organizations []string            `json:"organizations,omitempty"` // Organizations the accounts belong to
+	Registries    []RegistryAccount   `json:"registries,omitempty"`    // Package registry accounts linked from any profile
+	Accounts      []*AccountFootprint `json:"accounts"`                // Every code hosting account, most stars first
+}
+
+// AccountFootprint is one code hosting account's contribution to a Footprint.
+type AccountFootprint struct {
+	Platform     string   `json:"platform"`               // Platform name
+	URL          string   `json:"url"`                    // Profile URL
+	Languages    []string `json:"languages,omitempty"`    // Primary languages of its top repositories
+	Repositories int      `json:"repositories,omitempty"` // Public repositories
+	Stars        int      `json:"stars,omitempty"`        // Stars on its top repositories
+	IsGuess      bool     `json:"is_guess,omitempty"`     // Whether the profile was found by guessing
+	Sponsorable  bool     `json:"sponsorable,omitempty"`  // Whether it accepts sponsorships
+	Sponsors     int      `json:"sponsors,omitempty"`     // Accounts sponsoring it
+	Achievements []string `json:"achievements,omitempty"` // Badges earned, such as "pull-shark x3"
+}
+
+// RegistryAccount is an account on a package registry, which usually means its
+// owner publishes packages there.
+type RegistryAccount struct {
+	Registry string `json:"registry"` // Registry name, such as "npm"
+	Username string `json:"username"` // Account name on the registry
+	URL      string `json:"url"`      // Account page
+}
+
+// registryPatterns match the account pages of package registries. Package pages are
+// not matched: linking a package does not say who owns it.
+var registryPatterns = []struct {
+	pattern  *regexp.Regexp
+	registry string
+}{
+	{regexp.MustCompile(`(?i)^https?://(?:www\.)?npmjs\.com/~([\w.-]+)/?$`), "npm"},
+	{regexp.MustCompile(`(?i)^https?://pypi\.org/user/([\w.-]+)/?$`), "pypi"},
+	{regexp.MustCompile(`(?i)^https?://crates\.io/users/([\w-]+)/?$`), "crates.io"},
+	{regexp.MustCompile(`(?i)^https?://rubygems\.org/profiles/([\w.-]+)/?$`), "rubygems"},
+	{regexp.MustCompile(`(?i)^https?://(?:www\.)?nuget\.org/profiles/([\w.-]+)/?$`), "nuget"},
+	{regexp.MustCompile(`(?i)^https?://hub\.docker\.com/u/([\w.-]+)/?$`), "docker hub"},
+	{regexp.MustCompile(`(?i)^https?://hex\.pm/users/([\w.-]+)/?$`), "hex"},
+	{regexp.MustCompile(`(?i)^https?://(?:www\.)?packagist\.org/users/([\w.-]+)/?$`), "packagist"},
+}
+
+// RegistryAccountFor returns the package registry account at link, if it is one.
+func RegistryAccountFor(link string) (RegistryAccount, bool) {
+	for _, r := range registryPatterns {
+		if m := r.pattern.FindStringSubmatch(link); m != nil {
+			return RegistryAccount{Registry: r.registry, Username: m[1], URL: link}, true
+		}
+	}
+	return RegistryAccount{}, false
+}
+
+// SummarizeFootprint computes the Footprint of profiles. Profiles with an Error are
+// skipped; guessed profiles are included and marked so callers can filter on
+// confidence first. An account counts as code hosting if it reports repositories,
+// stars, or languages.
+func SummarizeFootprint(profiles []*Profile) Footprint {
+	f := Footprint{Accounts: []*AccountFootprint{}}
+	languageScores := map[string]int{}
+	seenRegistry := map[string]bool{}
+	for _, p := range profiles {
+		if p == nil || p.Error != "" {
+			continue
+		}
+		for _, link := range append([]string{p.URL, p.Website}, p.SocialLinks...) {
+			r, ok := RegistryAccountFor(link)
+			if !ok {
+				continue
+			}
+			key := r.Registry + "/" + strings.ToLower(r.Username)
+			if seenRegistry[key] {
+				continue
+			}
+			seenRegistry[key] = true
+			f.Registries = append(f.Registries, r)
+		}
+		for _, org := range p.Organizations() {
+			f.Organizations = appendNew(f.Organizations, org)
+		}
+
+		a := &AccountFootprint{
+			Platform:     p.Platform,
+			URL:          p.URL,
+			Languages:    p.Languages(),
+			IsGuess:      p.IsGuess,
+			Sponsorable:  p.Sponsorable(),
+			Achievements: p.Achievements(),
+		}
+		a.Repositories, _ = p.Repositories()
+		a.Stars, _ = p.Stars()
+		a.Sponsors, _ = p.Sponsors()
+		if a.Repositories == 0 && a.Stars == 0 && len(a.Languages) == 0 {
+			continue
+		}
+		// Each account's languages are most used first, so earlier ones score higher
+		for i, lang := range a.Languages {
+			if languageScores[lang] == 0 {
+				f.Languages = append(f.Languages, lang)
+			}
+			languageScores[lang] += len(a.Languages) - i
+		}
+		f.Repositories += a.Repositories
+		f.Stars += a.Stars
+		f.Accounts = append(f.Accounts, a)
+	}
+	sort.SliceStable(f.Languages, func(i, j int) bool {
+		return languageScores[f.Languages[i]] > languageScores[f.Languages[j]]
+	})
+	sort.SliceStable(f.Accounts, func(i, j int) bool {
+		return f.Accounts[i].Stars > f.Accounts[j].Stars
+	})
+	return f
+}
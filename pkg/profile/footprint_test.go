@@ -0,0 +1,51 @@
+package profile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSummarizeFootprint(t *testing.T) {
+	profiles := []*Profile{
+		{
+			Platform: "codeberg", URL: "https://codeberg.org/alice",
+			Fields: map[string]string{FieldRepositories: "4", FieldLanguages: "Rust, Go"},
+		},
+		{
+			Platform: "github", URL: "https://github.com/alice",
+			SocialLinks: []string{"https://www.npmjs.com/~alice", "https://www.npmjs.com/package/left-pad"},
+			Fields: map[string]string{
+				FieldRepositories: "40", FieldStars: "1.2K", FieldLanguages: "Go, Python",
+				FieldOrganizations: "kubernetes, chainguard-dev", FieldSponsorable: "true",
+			},
+		},
+		{
+			Platform: "mastodon", URL: "https://hachyderm.io/@alice",
+			SocialLinks: []string{"https://crates.io/users/alice", "https://npmjs.com/~Alice"},
+			Fields:      map[string]string{FieldFollowers: "300"},
+		},
+		{Platform: "gitlab", URL: "https://gitlab.com/alice", Error: "not found"},
+	}
+
+	f := SummarizeFootprint(profiles)
+
+	if f.Repositories != 44 || f.Stars != 1200 {
+		t.Errorf("Repositories, Stars = %d, %d; want 44, 1200", f.Repositories, f.Stars)
+	}
+	if want := []string{"Go", "Rust", "Python"}; !slices.Equal(f.Languages, want) {
+		t.Errorf("Languages = %v, want %v", f.Languages, want)
+	}
+	if want := []string{"kubernetes", "chainguard-dev"}; !slices.Equal(f.Organizations, want) {
+		t.Errorf("Organizations = %v, want %v", f.Organizations, want)
+	}
+	var registries []string
+	for _, r := range f.Registries {
+		registries = append(registries, r.Registry+":"+r.Username)
+	}
+	if want := []string{"npm:alice", "crates.io:alice"}; !slices.Equal(registries, want) {
+		t.Errorf("Registries = %v, want %v", registries, want)
+	}
+	if len(f.Accounts) != 2 || f.Accounts[0].Platform != "github" || !f.Accounts[0].Sponsorable {
+		t.Errorf("Accounts = %+v, want github then codeberg", f.Accounts)
+	}
+}
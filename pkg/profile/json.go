@@ -0,0 +1,269 @@
+package profile
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+// AppendJSON appends p encoded as JSON to dst and returns the extended buffer. The
+// output is the same as json.Marshal's, but it is written without reflection and,
+// given a dst with room, without allocating, for servers returning many profiles.
+func (p *Profile) AppendJSON(dst []byte) ([]byte, error) {
+	if math.IsNaN(p.Confidence) || math.IsInf(p.Confidence, 0) {
+		return dst, &json.UnsupportedValueError{Str: strconv.FormatFloat(p.Confidence, 'g', -1, 64)}
+	}
+	o := object{b: append(dst, '{')}
+	o.str("Platform", p.Platform, true)
+	o.str("URL", p.URL, true)
+	if p.Authenticated {
+		o.key("Authenticated")
+		o.b = append(o.b, "true"...)
+	}
+	o.str("Error", p.Error, true)
+	o.str("Username", p.Username, true)
+	o.str("Name", p.Name, true)
+	o.str("Bio", p.Bio, true)
+	o.str("Location", p.Location, true)
+	o.str("Website", p.Website, true)
+	o.str("CreatedAt", p.CreatedAt, true)
+	o.str("UpdatedAt", p.UpdatedAt, true)
+	o.str("LastActive", p.LastActive, true)
+	if len(p.Fields) > 0 {
+		o.key("Fields")
+		o.b = appendStringMap(o.b, p.Fields)
+	}
+	o.strs("SocialLinks", p.SocialLinks)
+	o.strs("Aliases", p.Aliases)
+	if len(p.Posts) > 0 {
+		o.key("Posts")
+		o.b = appendArray(o.b, p.Posts, func(o *object, v *Post) {
+			o.str("type", string(v.Type), false)
+			o.str("title", v.Title, true)
+			o.str("content", v.Content, true)
+			o.str("url", v.URL, true)
+			o.str("category", v.Category, true)
+			o.str("content_ref", v.ContentRef, true)
+		})
+	}
+	if len(p.Experience) > 0 {
+		o.key("Experience")
+		o.b = appendArray(o.b, p.Experience, func(o *object, v *Experience) {
+			o.str("title", v.Title, true)
+			o.str("organization", v.Organization, true)
+			o.str("location", v.Location, true)
+			o.str("start", v.Start, true)
+			o.str("end", v.End, true)
+			o.str("description", v.Description, true)
+		})
+	}
+	if len(p.Education) > 0 {
+		o.key("Education")
+		o.b = appendArray(o.b, p.Education, func(o *object, v *Education) {
+			o.str("school", v.School, true)
+			o.str("degree", v.Degree, true)
+			o.str("field", v.Field, true)
+			o.str("start", v.Start, true)
+			o.str("end", v.End, true)
+		})
+	}
+	if len(p.Certifications) > 0 {
+		o.key("Certifications")
+		o.b = appendArray(o.b, p.Certifications, func(o *object, v *Certification) {
+			o.str("name", v.Name, false)
+			o.str("authority", v.Authority, true)
+			o.str("issued", v.Issued, true)
+			o.str("expires", v.Expires, true)
+			o.str("url", v.URL, true)
+		})
+	}
+	if len(p.Publications) > 0 {
+		o.key("Publications")
+		o.b = appendArray(o.b, p.Publications, func(o *object, v *Publication) {
+			o.str("title", v.Title, false)
+			o.str("publisher", v.Publisher, true)
+			o.str("date", v.Date, true)
+			o.str("url", v.URL, true)
+			o.str("description", v.Description, true)
+		})
+	}
+	if len(p.Volunteering) > 0 {
+		o.key("Volunteering")
+		o.b = appendArray(o.b, p.Volunteering, func(o *object, v *Volunteering) {
+			o.str("role", v.Role, true)
+			o.str("organization", v.Organization, true)
+			o.str("cause", v.Cause, true)
+			o.str("start", v.Start, true)
+			o.str("end", v.End, true)
+		})
+	}
+	if len(p.Honors) > 0 {
+		o.key("Honors")
+		o.b = appendArray(o.b, p.Honors, func(o *object, v *Honor) {
+			o.str("title", v.Title, false)
+			o.str("issuer", v.Issuer, true)
+			o.str("date", v.Date, true)
+			o.str("description", v.Description, true)
+		})
+	}
+	o.str("Unstructured", p.Unstructured, true)
+	o.str("UnstructuredRef", p.UnstructuredRef, true)
+	if p.IsGuess {
+		o.key("IsGuess")
+		o.b = append(o.b, "true"...)
+	}
+	if p.Confidence != 0 {
+		o.key("Confidence")
+		o.b = appendFloat(o.b, p.Confidence)
+	}
+	o.strs("GuessMatch", p.GuessMatch)
+	return append(o.b, '}'), nil
+}
+
+// object writes the members of a JSON object whose opening brace is already in b.
+type object struct {
+	b    []byte
+	more bool
+}
+
+func (o *object) key(name string) {
+	if o.more {
+		o.b = append(o.b, ',')
+	}
+	o.more = true
+	o.b = append(o.b, '"')
+	o.b = append(o.b, name...)
+	o.b = append(o.b, '"', ':')
+}
+
+func (o *object) str(name, v string, omitEmpty bool) {
+	if v == "" && omitEmpty {
+		return
+	}
+	o.key(name)
+	o.b = appendString(o.b, v)
+}
+
+func (o *object) strs(name string, vs []string) {
+	if len(vs) == 0 {
+		return
+	}
+	o.key(name)
+	o.b = append(o.b, '[')
+	for i, v := range vs {
+		if i > 0 {
+			o.b = append(o.b, ',')
+		}
+		o.b = appendString(o.b, v)
+	}
+	o.b = append(o.b, ']')
+}
+
+// appendArray appends vs as an array of objects whose members member writes.
+func appendArray[T any](b []byte, vs []T, member func(*object, *T)) []byte {
+	b = append(b, '[')
+	for i := range vs {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		o := object{b: append(b, '{')}
+		member(&o, &vs[i])
+		b = append(o.b, '}')
+	}
+	return append(b, ']')
+}
+
+// appendStringMap appends m with its keys sorted, as encoding/json does. Profiles
+// have a handful of fields, so the keys are gathered on the stack when they fit.
+func appendStringMap(b []byte, m map[string]string) []byte {
+	var stack [32]string
+	keys := stack[:0]
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	b = append(b, '{')
+	for i, k := range keys {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendString(b, k)
+		b = append(b, ':')
+		b = appendString(b, m[k])
+	}
+	return append(b, '}')
+}
+
+// appendFloat formats f as encoding/json does: like %g for very large and small
+// magnitudes, with a short exponent, and in plain decimal otherwise.
+func appendFloat(b []byte, f float64) []byte {
+	abs := math.Abs(f)
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		b = strconv.AppendFloat(b, f, 'e', -1, 64)
+		// e-09 becomes e-9
+		if n := len(b); b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+		return b
+	}
+	return strconv.AppendFloat(b, f, 'f', -1, 64)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendString appends s as a JSON string, escaped as encoding/json escapes it: HTML
+// characters and the JavaScript line separators as \u escapes, and invalid UTF-8 as
+// U+FFFD.
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\b':
+				b = append(b, '\\', 'b')
+			case '\f':
+				b = append(b, '\\', 'f')
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
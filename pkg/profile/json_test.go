@@ -0,0 +1,121 @@
+package profile
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// tricky holds every kind of character encoding/json escapes.
+const tricky = "a \"quoted\" \\ <b>&amp;</b>\n\t\r\b\f\x01 café \u2028\u2029 \xff end"
+
+// fullProfile returns a profile with every field set, so a field AppendJSON does not
+// write shows up as a difference from json.Marshal.
+func fullProfile() *Profile {
+	return &Profile{
+		Platform: "github", URL: "https://github.com/alice?a=1&b=<2>", Authenticated: true, Error: tricky,
+		Username: "alice", Name: "Alice Liddell", Bio: tricky, Location: "Wonderland", Website: "https://alice.dev",
+		CreatedAt: "2011-03-04", UpdatedAt: "2024-05-06", LastActive: "2024-07-08",
+		Fields:      map[string]string{"zeta": "last", "alpha": tricky, "Mid": "", "é": "accent"},
+		SocialLinks: []string{"https://twitter.com/alice", tricky},
+		Aliases:     []string{"alice-old"},
+		Posts:       []Post{{Type: PostTypeComment, Title: "t", Content: tricky, URL: "u", Category: "c", ContentRef: "sha256:ab"}, {}},
+		Experience: []Experience{{
+			Title: "t", Organization: "o", Location: "l", Start: "2020", End: "2021", Description: "d",
+		}},
+		Education:       []Education{{School: "s", Degree: "d", Field: "f", Start: "2010", End: "2014"}},
+		Certifications:  []Certification{{Name: "n", Authority: "a", Issued: "i", Expires: "e", URL: "u"}, {}},
+		Publications:    []Publication{{Title: "t", Publisher: "p", Date: "d", URL: "u", Description: "d"}, {}},
+		Volunteering:    []Volunteering{{Role: "r", Organization: "o", Cause: "c", Start: "s", End: "e"}},
+		Honors:          []Honor{{Title: "t", Issuer: "i", Date: "d", Description: "d"}, {}},
+		Unstructured:    tricky,
+		UnstructuredRef: "sha256:cd",
+		IsGuess:         true,
+		Confidence:      0.85,
+		GuessMatch:      []string{"username", "name"},
+	}
+}
+
+// allSet reports the path of the first zero field in v, descending into struct slices.
+func allSet(v reflect.Value, path string) string {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if p := allSet(v.Field(i), path+"."+v.Type().Field(i).Name); p != "" {
+				return p
+			}
+		}
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return path
+		}
+		return allSet(v.Index(0), path+"[0]")
+	default:
+		if v.IsZero() {
+			return path
+		}
+	}
+	return ""
+}
+
+func TestAppendJSON(t *testing.T) {
+	full := fullProfile()
+	if path := allSet(reflect.ValueOf(full).Elem(), "Profile"); path != "" {
+		t.Fatalf("fullProfile leaves %s unset; set it and teach AppendJSON to write it", path)
+	}
+
+	for _, p := range []*Profile{
+		full,
+		{},
+		{Platform: "mastodon", URL: "https://mastodon.social/@alice"},
+		{Confidence: 1e-7},
+		{Confidence: 1e21},
+		{Confidence: 12345.5},
+		{Confidence: -0.000001},
+	} {
+		want, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := p.AppendJSON([]byte("prefix"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "prefix"+string(want) {
+			t.Errorf("AppendJSON() =\n%s\nwant\n%s", got[len("prefix"):], want)
+		}
+	}
+
+	if _, err := (&Profile{Confidence: math.NaN()}).AppendJSON(nil); err == nil {
+		t.Error("AppendJSON() of a NaN confidence succeeded, want an error")
+	}
+}
+
+func TestAppendJSONAllocs(t *testing.T) {
+	p := fullProfile()
+	buf := make([]byte, 0, 8192)
+	if allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = p.AppendJSON(buf[:0]) //nolint:errcheck // checked in TestAppendJSON
+	}); allocs != 0 {
+		t.Errorf("AppendJSON() made %v allocations, want 0", allocs)
+	}
+}
+
+// BenchmarkAppendJSON compares AppendJSON with json.Marshal on the same profile.
+func BenchmarkAppendJSON(b *testing.B) {
+	p := fullProfile()
+	b.Run("AppendJSON", func(b *testing.B) {
+		b.ReportAllocs()
+		var buf []byte
+		for b.Loop() {
+			buf, _ = p.AppendJSON(buf[:0]) //nolint:errcheck // checked in TestAppendJSON
+		}
+	})
+	b.Run("json.Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = json.Marshal(p) //nolint:errcheck // checked in TestAppendJSON
+		}
+	})
+}
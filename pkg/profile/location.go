@@ -0,0 +1,61 @@
+package profile
+
+import (
+	"strings"
+	"unicode"
+)
+
+// locationLabels are prefixes some platforms put before a location, in the scripts
+// they appear in, such as Weibo's "IP属地：北京" or VK's "Город: Москва".
+var locationLabels = []string{
+	"ip属地", "所在地", "家乡", "地区", "居住地",
+	"город", "местоположение", "місто",
+	"الموقع", "المدينة",
+	"location", "city",
+}
+
+// locationSeparators are the list separators of non-Latin scripts, which
+// NormalizeLocation rewrites as ", " so that "北京、海淀区" splits like "Beijing, Haidian".
+var locationSeparators = strings.NewReplacer(
+	"、", ", ", "・", ", ", "·", ", ", "،", ", ", "؛", ", ", "|", ", ",
+)
+
+// NormalizeLocation tidies a location for comparison across platforms without
+// changing its script: full-width letters, digits, and punctuation become their
+// ASCII forms, separators such as "、" and "،" become ", ", labels such as "IP属地："
+// are dropped, and runs of spaces collapse. It returns false if nothing is left.
+func NormalizeLocation(s string) (string, bool) {
+	s = strings.Map(foldWidth, s)
+	s = strings.Join(strings.Fields(s), " ")
+	for _, label := range locationLabels {
+		if len(s) <= len(label) || !strings.EqualFold(s[:len(label)], label) {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(strings.TrimLeft(s[len(label):], " "), ":"); ok {
+			s = rest
+			break
+		}
+	}
+	s = locationSeparators.Replace(s)
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == ',' })
+	kept := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimFunc(part, unicode.IsSpace); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	s = strings.Join(kept, ", ")
+	return s, s != ""
+}
+
+// foldWidth maps full-width forms (U+FF01–U+FF5E) and the ideographic space to
+// their ASCII equivalents, as CJK input methods often produce "北京，中国" or "Ｂｅｉｊｉｎｇ".
+func foldWidth(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - 0xFEE0
+	}
+	return r
+}
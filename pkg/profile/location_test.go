@@ -0,0 +1,31 @@
+package profile
+
+import "testing"
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"San Francisco, CA", "San Francisco, CA"},
+		{"  Berlin   Germany ", "Berlin Germany"},
+		{"北京，海淀区", "北京, 海淀区"},
+		{"北京、中国", "北京, 中国"},
+		{"IP属地：广东", "广东"},
+		{"Ｔｏｋｙｏ　日本", "Tokyo 日本"},
+		{"Город: Санкт-Петербург", "Санкт-Петербург"},
+		{"Москва , Россия", "Москва, Россия"},
+		{"القاهرة، مصر", "القاهرة, مصر"},
+		{"東京都・渋谷区", "東京都, 渋谷区"},
+		{"Location: Earth", "Earth"},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeLocation(tt.in)
+		if !ok || got != tt.want {
+			t.Errorf("NormalizeLocation(%q) = %q, %v; want %q", tt.in, got, ok, tt.want)
+		}
+	}
+	if got, ok := NormalizeLocation(" 、 "); ok {
+		t.Errorf("NormalizeLocation(\" 、 \") = %q, want failure", got)
+	}
+}
@@ -0,0 +1,218 @@
+package profile
+
+import (
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+)
+
+// hostAliases maps mobile and legacy hostnames, whose pages differ but describe the
+// same accounts, to the platform's main one. Exact mirrors are in links.MirrorHost, and
+// domains platforms have moved away from in links.MigratedHost.
+var hostAliases = map[string]string{
+	"mobile.twitter.com": "twitter.com",
+	"mobile.x.com":       "twitter.com",
+	"m.youtube.com":      "youtube.com",
+	"old.reddit.com":     "reddit.com",
+	"m.facebook.com":     "facebook.com",
+}
+
+// IdentityURL returns u in the form Dedupe compares accounts in: canonicalized as by
+// links.Canonicalize and CanonicalURL, with alternate and retired hostnames such as
+// x.com and vkontakte.ru replaced by the platform's current main one.
+func IdentityURL(u string) string {
+	u = CanonicalURL(links.Canonicalize(u))
+	host, path, _ := strings.Cut(u, "/")
+	if alias, ok := hostAliases[host]; ok {
+		host = alias
+	}
+	host = links.MirrorHost(links.MigratedHost(host))
+	if path == "" {
+		return host
+	}
+	return host + "/" + path
+}
+
+// Dedupe merges profiles that describe the same account, or that repeat one another,
+// so a crawl reports each identity once. Profiles match when they:
+//   - have the same URL once compared by IdentityURL (twitter.com/alice and x.com/Alice),
+//     or one's URL is among the other's Aliases;
+//   - are on the same platform and host under the same username; or
+//   - are a generic page at another profile's Website whose name agrees with it, such
+//     as the personal site a GitHub profile links to.
+//
+// The first profile of each group keeps its position and absorbs the others with Merge,
+// except that a generic page is always folded into the platform profile linking to it.
+func Dedupe(profiles []*Profile) []*Profile {
+	var out []*Profile
+	index := make(map[string]int)
+	for _, p := range profiles {
+		if p == nil {
+			continue
+		}
+		keys := p.identityKeys()
+		i, found := -1, false
+		for _, k := range keys {
+			if i, found = index[k]; found {
+				break
+			}
+		}
+		if !found {
+			i = len(out)
+			out = append(out, p)
+		} else {
+			out[i].Merge(p)
+		}
+		for _, k := range keys {
+			index[k] = i
+		}
+	}
+
+	// Fold generic pages into the profile whose Website they are
+	sites := make(map[string]*Profile)
+	for _, p := range out {
+		if p.Platform != "generic" && p.Website != "" {
+			if _, ok := sites[IdentityURL(p.Website)]; !ok {
+				sites[IdentityURL(p.Website)] = p
+			}
+		}
+	}
+	kept := out[:0]
+	for _, p := range out {
+		if owner := sites[IdentityURL(p.URL)]; p.Platform == "generic" && owner != nil && namesAgree(owner.Name, p.Name) {
+			owner.Merge(p)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
+// identityKeys returns the keys under which Dedupe considers p the same account as another.
+func (p *Profile) identityKeys() []string {
+	var keys []string
+	if p.URL != "" {
+		keys = append(keys, "url:"+IdentityURL(p.URL))
+	}
+	for _, a := range p.Aliases {
+		if strings.Contains(a, "/") {
+			keys = append(keys, "url:"+IdentityURL(a))
+		}
+	}
+	// Error stubs carry no username worth trusting; generic pages have none
+	if p.Error != "" || p.Username == "" || p.Platform == "" || p.Platform == "generic" || p.Platform == "unknown" {
+		return keys
+	}
+	host, _, _ := strings.Cut(IdentityURL(p.URL), "/")
+	return append(keys, "account:"+strings.ToLower(p.Platform)+":"+host+":"+strings.ToLower(strings.TrimPrefix(p.Username, "@")))
+}
+
+// namesAgree reports whether a page titled page could belong to someone named name.
+func namesAgree(name, page string) bool {
+	if name == "" || page == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(page), strings.ToLower(name))
+}
+
+// Merge folds other, a second view of the same identity, into p. Values p already has
+// win; other fills empty fields, adds Fields keys p lacks, and contributes the links,
+// posts, and guess reasons p does not have. If p is an error stub and other is not, or
+// p's URL is among other's Aliases, p takes other's data instead. A merged profile is
+// a guess only if both were.
+func (p *Profile) Merge(other *Profile) {
+	if other == nil || other == p {
+		return
+	}
+	if p.Error != "" && other.Error == "" {
+		stub := *p
+		*p = *other
+		other = &stub
+		// A stub links nowhere and says nothing; only its URL is worth keeping
+		other.Error = ""
+	} else if other.hasAlias(p.URL) {
+		// p is an address the account has left, so other is the current view
+		former := *p
+		*p = *other
+		other = &former
+	}
+
+	for _, f := range []struct{ dst, src *string }{
+		{&p.Username, &other.Username}, {&p.Name, &other.Name}, {&p.Bio, &other.Bio},
+		{&p.Location, &other.Location}, {&p.Website, &other.Website}, {&p.CreatedAt, &other.CreatedAt},
+		{&p.UpdatedAt, &other.UpdatedAt},
+	} {
+		if *f.dst == "" {
+			*f.dst = *f.src
+		}
+	}
+	// Unstructured may be inline or in a blob, but is taken whole either way
+	if p.Unstructured == "" && p.UnstructuredRef == "" {
+		p.Unstructured, p.UnstructuredRef = other.Unstructured, other.UnstructuredRef
+	}
+	p.UpdateLastActive(other.LastActive)
+	p.Authenticated = p.Authenticated || other.Authenticated
+
+	if p.IsGuess && !other.IsGuess {
+		p.Confidence, p.GuessMatch = 0, nil
+	}
+	p.IsGuess = p.IsGuess && other.IsGuess
+	if p.IsGuess {
+		p.Confidence = max(p.Confidence, other.Confidence)
+		p.GuessMatch = links.Dedupe(append(p.GuessMatch, other.GuessMatch...))
+	}
+
+	for k, v := range other.Fields {
+		if _, ok := p.Fields[k]; !ok {
+			if p.Fields == nil {
+				p.Fields = make(map[string]string)
+			}
+			p.Fields[k] = v
+		}
+	}
+
+	extra := other.SocialLinks
+	if other.URL != "" && IdentityURL(other.URL) != IdentityURL(p.URL) && !p.hasAlias(other.URL) {
+		extra = append([]string{other.URL}, extra...)
+	}
+	for _, link := range extra {
+		if IdentityURL(link) != IdentityURL(p.URL) {
+			p.SocialLinks = append(p.SocialLinks, link)
+		}
+	}
+	p.SocialLinks = links.Dedupe(p.SocialLinks)
+	for _, a := range other.Aliases {
+		p.AddAlias(a)
+	}
+
+	seenPosts := make(map[Post]bool, len(p.Posts))
+	for _, post := range p.Posts {
+		seenPosts[post] = true
+	}
+	for _, post := range other.Posts {
+		if !seenPosts[post] {
+			seenPosts[post] = true
+			p.Posts = append(p.Posts, post)
+		}
+	}
+
+	// Career history is taken whole; interleaving two résumés would misorder them
+	if len(p.Experience) == 0 {
+		p.Experience = other.Experience
+	}
+	if len(p.Education) == 0 {
+		p.Education = other.Education
+	}
+	if len(p.Certifications) == 0 {
+		p.Certifications = other.Certifications
+	}
+	if len(p.Publications) == 0 {
+		p.Publications = other.Publications
+	}
+	if len(p.Volunteering) == 0 {
+		p.Volunteering = other.Volunteering
+	}
+	if len(p.Honors) == 0 {
+		p.Honors = other.Honors
+	}
+}
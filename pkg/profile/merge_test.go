@@ -0,0 +1,92 @@
+package profile
+
+import "testing"
+
+func TestIdentityURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://x.com/Alice?utm_source=bio", "twitter.com/alice"},
+		{"https://twitter.com/intent/follow?screen_name=alice", "twitter.com/alice"},
+		{"https://mobile.twitter.com/alice/", "twitter.com/alice"},
+		{"https://www.vkontakte.ru/Durov", "vk.com/durov"},
+		{"https://www.alice.dev/", "alice.dev"},
+	}
+	for _, tt := range tests {
+		if got := IdentityURL(tt.in); got != tt.want {
+			t.Errorf("IdentityURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	gh := &Profile{
+		Platform: "github", URL: "https://github.com/alice", Username: "alice", Name: "Alice Liddell",
+		Website: "https://alice.dev/", SocialLinks: []string{"https://twitter.com/alice"},
+	}
+	tw := &Profile{Platform: "twitter", URL: "https://twitter.com/alice", Username: "alice", Bio: "curiouser"}
+	x := &Profile{
+		Platform: "twitter", URL: "https://x.com/Alice", Username: "Alice", Location: "Wonderland",
+		Fields: map[string]string{FieldFollowers: "42"}, SocialLinks: []string{"https://x.com/alice", "https://alice.dev"},
+	}
+	site := &Profile{
+		Platform: "generic", URL: "https://www.alice.dev", Name: "Alice Liddell - Blog",
+		SocialLinks: []string{"https://hachyderm.io/@alice"},
+	}
+	masto := &Profile{Platform: "mastodon", URL: "https://hachyderm.io/@alice", Username: "alice"}
+	otherMasto := &Profile{Platform: "mastodon", URL: "https://mastodon.social/@alice", Username: "alice"}
+
+	got := Dedupe([]*Profile{gh, site, tw, x, masto, otherMasto})
+	if len(got) != 4 {
+		for _, p := range got {
+			t.Logf("%s %s", p.Platform, p.URL)
+		}
+		t.Fatalf("Dedupe() returned %d profiles, want 4", len(got))
+	}
+	if got[0] != gh || got[1] != tw || got[2] != masto || got[3] != otherMasto {
+		t.Errorf("Dedupe() did not keep the first profile of each identity in order")
+	}
+	if tw.Location != "Wonderland" || tw.Bio != "curiouser" || tw.Fields[FieldFollowers] != "42" {
+		t.Errorf("x.com profile not merged into twitter.com profile: %+v", tw)
+	}
+	for _, link := range tw.SocialLinks {
+		if IdentityURL(link) == "twitter.com/alice" {
+			t.Errorf("merged profile links to itself: %v", tw.SocialLinks)
+		}
+	}
+	want := map[string]bool{"https://twitter.com/alice": true, "https://www.alice.dev": true, "https://hachyderm.io/@alice": true}
+	if len(gh.SocialLinks) != len(want) {
+		t.Errorf("SocialLinks = %v, want %d links", gh.SocialLinks, len(want))
+	}
+	for _, link := range gh.SocialLinks {
+		if !want[link] {
+			t.Errorf("unexpected link %q in %v", link, gh.SocialLinks)
+		}
+	}
+}
+
+func TestDedupeKeepsUnrelatedSites(t *testing.T) {
+	gh := &Profile{Platform: "github", URL: "https://github.com/alice", Name: "Alice Liddell", Website: "https://wonderland.example"}
+	site := &Profile{Platform: "generic", URL: "https://wonderland.example", Name: "Wonderland Tea Company"}
+	if got := Dedupe([]*Profile{gh, site}); len(got) != 2 {
+		t.Errorf("Dedupe() merged a site whose name disagrees: %d profiles", len(got))
+	}
+}
+
+func TestMerge(t *testing.T) {
+	stub := &Profile{Platform: "linkedin", URL: "https://linkedin.com/in/alice", Error: "login required"}
+	stub.Merge(&Profile{Platform: "linkedin", URL: "https://www.linkedin.com/in/alice/", Name: "Alice"})
+	if stub.Error != "" || stub.Name != "Alice" {
+		t.Errorf("error stub not replaced by fetched profile: %+v", stub)
+	}
+
+	guessed := &Profile{URL: "https://github.com/alice", IsGuess: true, Confidence: 0.6, GuessMatch: []string{"username"}}
+	guessed.Merge(&Profile{URL: "https://github.com/alice", Name: "Alice", LastActive: "2024-05-01"})
+	if guessed.IsGuess || guessed.Confidence != 0 || guessed.GuessMatch != nil {
+		t.Errorf("confirmed profile still marked as a guess: %+v", guessed)
+	}
+	if guessed.LastActive != "2024-05-01" {
+		t.Errorf("LastActive = %q, want 2024-05-01", guessed.LastActive)
+	}
+}
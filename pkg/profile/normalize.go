@@ -0,0 +1,92 @@
+package profile
+
+import (
+	"strconv"
+	"time"
+)
+
+// RawSuffix is appended to a Fields key to hold the original string when Normalize
+// rewrites a value, e.g. "followers_raw": "1.2K" next to "followers": "1200".
+const RawSuffix = "_raw"
+
+// countFields are the Fields keys holding counts that Normalize rewrites as integers.
+var countFields = []string{
+	FieldFollowers, FieldFollowing, FieldSubscribers, FieldConnections,
+	FieldVideos, FieldRepositories, FieldStars, FieldReputation, "post_karma", "comment_karma",
+}
+
+// Normalize rewrites counts in Fields as plain integers ("1,234" and "1.2K" become
+// "1234" and "1200") and CreatedAt, UpdatedAt, and LastActive as ISO-8601
+// ("Joined March 2019" becomes "2019-03"). Values that change keep their original
+// string under the key with RawSuffix; the dates use "created_at_raw",
+// "updated_at_raw", and "last_active_raw". Pronouns become lowercase pairs such as
+// "she/her", and Location is tidied by NormalizeLocation, keeping "location_raw".
+// Values that cannot be parsed are left alone.
+func (p *Profile) Normalize() {
+	for _, key := range countFields {
+		raw, ok := p.Fields[key]
+		if !ok {
+			continue
+		}
+		if v, ok := NormalizeCount(raw); ok && v != raw {
+			p.Fields[key] = v
+			p.Fields[key+RawSuffix] = raw
+		}
+	}
+	p.CreatedAt = p.normalizeDate(p.CreatedAt, "created_at"+RawSuffix)
+	p.UpdatedAt = p.normalizeDate(p.UpdatedAt, "updated_at"+RawSuffix)
+	p.LastActive = p.normalizeDate(p.LastActive, "last_active"+RawSuffix)
+	if v, ok := NormalizeLocation(p.Location); ok && v != p.Location {
+		if p.Fields == nil {
+			p.Fields = make(map[string]string)
+		}
+		p.Fields["location"+RawSuffix] = p.Location
+		p.Location = v
+	}
+	if raw, ok := p.Fields[FieldPronouns]; ok {
+		if v, ok := NormalizePronouns(raw); ok && v != raw {
+			p.Fields[FieldPronouns] = v
+			p.Fields[FieldPronouns+RawSuffix] = raw
+		}
+	}
+}
+
+func (p *Profile) normalizeDate(raw, rawKey string) string {
+	v, ok := NormalizeDate(raw)
+	if !ok || v == raw {
+		return raw
+	}
+	if p.Fields == nil {
+		p.Fields = make(map[string]string)
+	}
+	p.Fields[rawKey] = raw
+	return v
+}
+
+// NormalizeCount returns s as a plain decimal integer.
+func NormalizeCount(s string) (string, bool) {
+	n, ok := ParseCount(s)
+	if !ok {
+		return "", false
+	}
+	return strconv.Itoa(n), true
+}
+
+// NormalizeDate returns s in ISO-8601 at the precision it was given: "2019",
+// "2019-03", "2019-03-07", or a full RFC 3339 timestamp in UTC.
+func NormalizeDate(s string) (string, bool) {
+	t, precision, ok := parseTime(s)
+	if !ok {
+		return "", false
+	}
+	switch precision {
+	case precisionYear:
+		return t.Format("2006"), true
+	case precisionMonth:
+		return t.Format("2006-01"), true
+	case precisionDay:
+		return t.Format(time.DateOnly), true
+	default:
+		return t.UTC().Format(time.RFC3339), true
+	}
+}
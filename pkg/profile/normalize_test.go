@@ -0,0 +1,78 @@
+package profile
+
+import "testing"
+
+func TestNormalizeDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2023-04-06", "2023-04-06"},
+		{"2023-04-06T10:00:00+02:00", "2023-04-06T08:00:00Z"},
+		{"Joined March 2019", "2019-03"},
+		{"joined on Jun 1, 2015", "2015-06-01"},
+		{"redditor since 2020", "2020"},
+		{"Member since 2 January 2018", "2018-01-02"},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeDate(tt.in)
+		if !ok || got != tt.want {
+			t.Errorf("NormalizeDate(%q) = %q, %v; want %q", tt.in, got, ok, tt.want)
+		}
+	}
+	if got, ok := NormalizeDate("a while ago"); ok {
+		t.Errorf("NormalizeDate(\"a while ago\") = %q, want failure", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	p := &Profile{
+		CreatedAt: "Joined March 2019",
+		UpdatedAt: "2023-04-06",
+		Location:  "北京，海淀区",
+		Fields: map[string]string{
+			FieldFollowers:   "1,234",
+			FieldSubscribers: "5.6K",
+			FieldConnections: "500+",
+			FieldFollowing:   "42",
+			"post_karma":     "1.2M",
+			FieldVideos:      "lots",
+		},
+	}
+	p.Normalize()
+
+	want := map[string]string{
+		FieldFollowers:               "1234",
+		FieldFollowers + RawSuffix:   "1,234",
+		FieldSubscribers:             "5600",
+		FieldSubscribers + RawSuffix: "5.6K",
+		FieldConnections:             "500",
+		FieldConnections + RawSuffix: "500+",
+		FieldFollowing:               "42",
+		"post_karma":                 "1200000",
+		"post_karma" + RawSuffix:     "1.2M",
+		FieldVideos:                  "lots",
+		"created_at" + RawSuffix:     "Joined March 2019",
+		"location" + RawSuffix:       "北京，海淀区",
+	}
+	for k, v := range want {
+		if p.Fields[k] != v {
+			t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+		}
+	}
+	for _, k := range []string{FieldFollowing + RawSuffix, FieldVideos + RawSuffix, "updated_at" + RawSuffix} {
+		if v, ok := p.Fields[k]; ok {
+			t.Errorf("Fields[%q] = %q, want unset for unchanged value", k, v)
+		}
+	}
+	if p.CreatedAt != "2019-03" || p.UpdatedAt != "2023-04-06" {
+		t.Errorf("CreatedAt, UpdatedAt = %q, %q; want 2019-03, 2023-04-06", p.CreatedAt, p.UpdatedAt)
+	}
+
+	// Normalizing again is a no-op
+	before := len(p.Fields)
+	p.Normalize()
+	if len(p.Fields) != before || p.Fields[FieldFollowers+RawSuffix] != "1,234" {
+		t.Errorf("second Normalize changed fields: %v", p.Fields)
+	}
+}
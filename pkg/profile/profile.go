@@ -11,6 +11,7 @@ var (
 	ErrNoCookies       = errors.New("no cookies available")
 	ErrProfileNotFound = errors.New("profile not found")
 	ErrRateLimited     = errors.New("rate limited")
+	ErrQuotaExceeded   = errors.New("quota exceeded")
 )
 
 // PostType indicates the type of user-generated content.
@@ -25,15 +26,72 @@ const (
 	PostTypeQuestion   PostType = "question"
 	PostTypeAnswer     PostType = "answer"
 	PostTypeRepository PostType = "repository"
+	PostTypeTalk       PostType = "talk"
+	PostTypeGame       PostType = "game"
 )
 
 // Post represents a piece of user-generated content (post, comment, video, etc.).
 type Post struct {
-	Type     PostType `json:"type"`               // Type of content
-	Title    string   `json:"title,omitempty"`    // Title (for videos, articles, posts)
-	Content  string   `json:"content,omitempty"`  // Body text or description
-	URL      string   `json:"url,omitempty"`      // Link to the original content
-	Category string   `json:"category,omitempty"` // Category (subreddit, channel, topic, etc.)
+	Type       PostType `json:"type"`                  // Type of content
+	Title      string   `json:"title,omitempty"`       // Title (for videos, articles, posts)
+	Content    string   `json:"content,omitempty"`     // Body text or description
+	URL        string   `json:"url,omitempty"`         // Link to the original content
+	Category   string   `json:"category,omitempty"`    // Category (subreddit, channel, topic, etc.)
+	ContentRef string   `json:"content_ref,omitempty"` // Blob holding Content when stored out of line (see package blob)
+}
+
+// Experience is a position held at an organization.
+type Experience struct {
+	Title        string `json:"title,omitempty"`        // Job title
+	Organization string `json:"organization,omitempty"` // Employer name
+	Location     string `json:"location,omitempty"`     // Where the position was based
+	Start        string `json:"start,omitempty"`        // Start date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	End          string `json:"end,omitempty"`          // End date, empty if current
+	Description  string `json:"description,omitempty"`  // Free-form description
+}
+
+// Education is a degree or course of study at a school.
+type Education struct {
+	School string `json:"school,omitempty"` // School name
+	Degree string `json:"degree,omitempty"` // Degree name (e.g., "BSc")
+	Field  string `json:"field,omitempty"`  // Field of study
+	Start  string `json:"start,omitempty"`  // Start date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	End    string `json:"end,omitempty"`    // End date
+}
+
+// Certification is a license or certification.
+type Certification struct {
+	Name      string `json:"name"`                // Certification name
+	Authority string `json:"authority,omitempty"` // Issuing organization
+	Issued    string `json:"issued,omitempty"`    // Issue date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	Expires   string `json:"expires,omitempty"`   // Expiration date
+	URL       string `json:"url,omitempty"`       // Verification URL
+}
+
+// Publication is an authored paper, book, or article.
+type Publication struct {
+	Title       string `json:"title"`                 // Publication title
+	Publisher   string `json:"publisher,omitempty"`   // Publisher or venue
+	Date        string `json:"date,omitempty"`        // Publication date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	URL         string `json:"url,omitempty"`         // Link to the publication
+	Description string `json:"description,omitempty"` // Abstract or summary
+}
+
+// Volunteering is an unpaid role at an organization.
+type Volunteering struct {
+	Role         string `json:"role,omitempty"`         // Role held
+	Organization string `json:"organization,omitempty"` // Organization name
+	Cause        string `json:"cause,omitempty"`        // Cause area (e.g., "education")
+	Start        string `json:"start,omitempty"`        // Start date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	End          string `json:"end,omitempty"`          // End date, empty if current
+}
+
+// Honor is an award or recognition.
+type Honor struct {
+	Title       string `json:"title"`                 // Award name
+	Issuer      string `json:"issuer,omitempty"`      // Issuing organization
+	Date        string `json:"date,omitempty"`        // Award date (YYYY, YYYY-MM, or YYYY-MM-DD)
+	Description string `json:"description,omitempty"` // Description
 }
 
 // Profile represents extracted data from a social media profile.
@@ -47,13 +105,14 @@ type Profile struct {
 	Error         string `json:",omitempty"` // Error message if fetch failed (e.g., "login required")
 
 	// Core profile data
-	Username  string `json:",omitempty"` // Handle/username (without @ prefix)
-	Name      string `json:",omitempty"` // Display name
-	Bio       string `json:",omitempty"` // Profile bio/description
-	Location  string `json:",omitempty"` // Geographic location
-	Website   string `json:",omitempty"` // Personal website URL
-	CreatedAt string `json:",omitempty"` // Account creation date (ISO timestamp)
-	UpdatedAt string `json:",omitempty"` // Most recent activity or profile update (ISO timestamp)
+	Username   string `json:",omitempty"` // Handle/username (without @ prefix)
+	Name       string `json:",omitempty"` // Display name
+	Bio        string `json:",omitempty"` // Profile bio/description
+	Location   string `json:",omitempty"` // Geographic location
+	Website    string `json:",omitempty"` // Personal website URL
+	CreatedAt  string `json:",omitempty"` // Account creation date (ISO timestamp)
+	UpdatedAt  string `json:",omitempty"` // Last profile update (ISO timestamp)
+	LastActive string `json:",omitempty"` // Most recent public activity: post, comment, or event (ISO timestamp)
 
 	// Platform-specific fields
 	Fields map[string]string `json:",omitempty"` // Additional platform-specific data (headline, employer, etc.)
@@ -61,11 +120,23 @@ type Profile struct {
 	// For further crawling
 	SocialLinks []string `json:",omitempty"` // Other social media URLs detected on the profile
 
+	// Earlier identities, so records made under them can still be matched
+	Aliases []string `json:",omitempty"` // Former URLs and usernames of the account, from redirects, moves, and the bio
+
 	// User-generated content (posts, comments, videos, etc.)
 	Posts []Post `json:",omitempty"` // Structured content extracted from the profile
 
+	// Career history (LinkedIn and other résumé-style profiles)
+	Experience     []Experience    `json:",omitempty"` // Positions, most recent first
+	Education      []Education     `json:",omitempty"` // Schools, most recent first
+	Certifications []Certification `json:",omitempty"` // Licenses and certifications
+	Publications   []Publication   `json:",omitempty"` // Authored publications
+	Volunteering   []Volunteering  `json:",omitempty"` // Volunteer roles
+	Honors         []Honor         `json:",omitempty"` // Honors and awards
+
 	// Fallback for unrecognized platforms
-	Unstructured string `json:",omitempty"` // Raw markdown content (HTML->MD conversion)
+	Unstructured    string `json:",omitempty"` // Raw markdown content (HTML->MD conversion)
+	UnstructuredRef string `json:",omitempty"` // Blob holding Unstructured when stored out of line (see package blob)
 
 	// Guess mode fields (omitted from JSON when empty)
 	IsGuess    bool     `json:",omitempty"` // True if this profile was discovered via guessing
@@ -50,3 +50,36 @@ func TestProfileDefaults(t *testing.T) {
 		t.Error("SocialLinks should be nil by default")
 	}
 }
+
+func TestDepth(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      Depth
+		wantLimit int
+	}{
+		{"", DepthStandard, 50},
+		{"standard", DepthStandard, 50},
+		{"minimal", DepthMinimal, 0},
+		{"deep", DepthDeep, 100},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDepth(tt.in)
+		if err != nil {
+			t.Fatalf("ParseDepth(%q) error = %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseDepth(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		if got.PostLimit() != tt.wantLimit {
+			t.Errorf("%v.PostLimit() = %d, want %d", got, got.PostLimit(), tt.wantLimit)
+		}
+		if tt.in != "" && got.String() != tt.in {
+			t.Errorf("String() = %q, want %q", got.String(), tt.in)
+		}
+	}
+
+	if _, err := ParseDepth("extreme"); err == nil {
+		t.Error("ParseDepth(extreme) expected error")
+	}
+}
@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"sort"
+	"time"
+)
+
+// Reach summarizes audience size and account age across profiles believed to belong
+// to one person, such as the results of a recursive fetch.
+type Reach struct {
+	TotalFollowers int             `json:"total_followers"`          // Sum of Audience over all accounts
+	TotalFollowing int             `json:"total_following"`          // Sum of accounts followed
+	MostFollowed   *AccountReach   `json:"most_followed,omitempty"`  // Account with the largest audience
+	OldestAccount  *AccountReach   `json:"oldest_account,omitempty"` // Account with the earliest join date
+	NewestAccount  *AccountReach   `json:"newest_account,omitempty"` // Account with the latest join date
+	Accounts       []*AccountReach `json:"accounts"`                 // Every account, largest audience first
+}
+
+// AccountReach is one account's contribution to a Reach.
+type AccountReach struct {
+	JoinedAt  time.Time `json:"joined_at,omitzero"`  // Account creation time, if known
+	Platform  string    `json:"platform"`            // Platform name
+	URL       string    `json:"url"`                 // Profile URL
+	Audience  int       `json:"audience"`            // Followers, or subscribers or connections where the platform has no followers
+	Following int       `json:"following,omitempty"` // Accounts followed
+	AgeDays   int       `json:"age_days,omitempty"`  // Days since JoinedAt
+	IsGuess   bool      `json:"is_guess,omitempty"`  // Whether the profile was found by guessing
+}
+
+// SummarizeReach computes the Reach of profiles. Profiles with an Error are skipped;
+// guessed profiles are included and marked so callers can filter on confidence first.
+func SummarizeReach(profiles []*Profile) Reach {
+	return summarizeReach(profiles, time.Now())
+}
+
+func summarizeReach(profiles []*Profile, now time.Time) Reach {
+	r := Reach{Accounts: []*AccountReach{}}
+	for _, p := range profiles {
+		if p == nil || p.Error != "" {
+			continue
+		}
+		a := &AccountReach{Platform: p.Platform, URL: p.URL, IsGuess: p.IsGuess}
+		a.Audience, _ = p.audience()
+		a.Following, _ = p.Following()
+		if joined, ok := p.JoinedAt(); ok && !joined.After(now) {
+			a.JoinedAt = joined
+			a.AgeDays = int(now.Sub(joined).Hours() / 24)
+		}
+
+		r.TotalFollowers += a.Audience
+		r.TotalFollowing += a.Following
+		if a.Audience > 0 && (r.MostFollowed == nil || a.Audience > r.MostFollowed.Audience) {
+			r.MostFollowed = a
+		}
+		if !a.JoinedAt.IsZero() {
+			if r.OldestAccount == nil || a.JoinedAt.Before(r.OldestAccount.JoinedAt) {
+				r.OldestAccount = a
+			}
+			if r.NewestAccount == nil || a.JoinedAt.After(r.NewestAccount.JoinedAt) {
+				r.NewestAccount = a
+			}
+		}
+		r.Accounts = append(r.Accounts, a)
+	}
+	sort.SliceStable(r.Accounts, func(i, j int) bool {
+		return r.Accounts[i].Audience > r.Accounts[j].Audience
+	})
+	return r
+}
+
+// audience returns the profile's follower count, falling back to subscribers
+// (YouTube, newsletters) and then connections (LinkedIn).
+func (p *Profile) audience() (int, bool) {
+	if n, ok := p.Followers(); ok {
+		return n, true
+	}
+	if n, ok := p.Subscribers(); ok {
+		return n, true
+	}
+	return p.Connections()
+}
@@ -0,0 +1,58 @@
+package profile
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeReach(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	profiles := []*Profile{
+		{
+			Platform: "github", URL: "https://github.com/alice", CreatedAt: "2010-01-01",
+			Fields: map[string]string{FieldFollowers: "120", FieldFollowing: "10"},
+		},
+		{
+			Platform: "youtube", URL: "https://youtube.com/@alice",
+			Fields: map[string]string{FieldSubscribers: "1.5K"},
+		},
+		{
+			Platform: "mastodon", URL: "https://hachyderm.io/@alice", CreatedAt: "2022-11-05T00:00:00Z",
+			Fields: map[string]string{FieldFollowers: "300", FieldFollowing: "5"}, IsGuess: true,
+		},
+		{Platform: "twitter", URL: "https://x.com/alice", Error: "login required"},
+		{Platform: "generic", URL: "https://alice.dev"},
+	}
+
+	r := summarizeReach(profiles, now)
+
+	if r.TotalFollowers != 1920 {
+		t.Errorf("TotalFollowers = %d, want 1920", r.TotalFollowers)
+	}
+	if r.TotalFollowing != 15 {
+		t.Errorf("TotalFollowing = %d, want 15", r.TotalFollowing)
+	}
+	if r.MostFollowed == nil || r.MostFollowed.Platform != "youtube" {
+		t.Errorf("MostFollowed = %+v, want youtube", r.MostFollowed)
+	}
+	if r.OldestAccount == nil || r.OldestAccount.Platform != "github" || r.OldestAccount.AgeDays != 5479 {
+		t.Errorf("OldestAccount = %+v, want github aged 5479 days", r.OldestAccount)
+	}
+	if r.NewestAccount == nil || r.NewestAccount.Platform != "mastodon" || !r.NewestAccount.IsGuess {
+		t.Errorf("NewestAccount = %+v, want guessed mastodon", r.NewestAccount)
+	}
+	if len(r.Accounts) != 4 {
+		t.Fatalf("len(Accounts) = %d, want 4 (errored profile skipped)", len(r.Accounts))
+	}
+	var order []string
+	for _, a := range r.Accounts {
+		order = append(order, a.Platform)
+	}
+	if got := order[0] + "," + order[1] + "," + order[2] + "," + order[3]; got != "youtube,mastodon,github,generic" {
+		t.Errorf("Accounts order = %s, want youtube,mastodon,github,generic", got)
+	}
+
+	if empty := summarizeReach(nil, now); empty.MostFollowed != nil || empty.TotalFollowers != 0 {
+		t.Errorf("summarizeReach(nil) = %+v, want zero", empty)
+	}
+}
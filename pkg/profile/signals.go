@@ -0,0 +1,109 @@
+package profile
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pronounPattern matches pronoun sets such as "she/her", "He / Him", "they/them/theirs",
+// or "she/they", whose first word must be a subject pronoun.
+var pronounPattern = regexp.MustCompile(`(?i)(?:^|[^\w/])((?:she|he|they|xe|ze|zie|ey|fae|it|any)\s*[/·]\s*(?:her|hers|him|his|them|theirs|she|he|they|xem|xyr|zir|hir|em|faer|its|it|all|any)(?:\s*[/·]\s*[a-z]+)?)\b`)
+
+// emojiSignals are emoji that say something about their poster, by the name recorded
+// in FieldEmojiSignals. Country flags are recorded separately, under FieldFlags.
+var emojiSignals = []struct{ emoji, signal string }{
+	{"🏳️‍🌈", "pride"},
+	{"🏳‍🌈", "pride"},
+	{"🏳️‍⚧️", "transgender"},
+	{"🏳‍⚧", "transgender"},
+	{"♿", "disability"},
+}
+
+// NormalizePronouns returns a pronoun set as lowercase words joined by "/", such as
+// "she/her" for "She / Her", keeping at most the first two words.
+func NormalizePronouns(s string) (string, bool) {
+	m := pronounPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+	words := strings.FieldsFunc(strings.ToLower(m[1]), func(r rune) bool {
+		return r == '/' || r == '·' || r == ' '
+	})
+	if len(words) > 2 {
+		words = words[:2]
+	}
+	return strings.Join(words, "/"), true
+}
+
+// AddNameAndBioSignals records the pronouns and emoji signals in the display name and
+// bio: FieldPronouns when no platform field gave them, FieldFlags for country flags,
+// and FieldEmojiSignals for emoji such as the pride flag.
+func (p *Profile) AddNameAndBioSignals() {
+	p.addPronouns()
+	p.addEmojiSignals()
+}
+
+// addPronouns sets FieldPronouns, if no platform set it, from a field the user named
+// "Pronouns" (as on Mastodon), the display name, or the bio, in that order of trust.
+func (p *Profile) addPronouns() {
+	if _, ok := p.Fields[FieldPronouns]; ok {
+		return
+	}
+	var sources []string
+	for k, v := range p.Fields {
+		if strings.EqualFold(strings.TrimSpace(k), FieldPronouns) {
+			sources = append(sources, v)
+		}
+	}
+	for _, source := range append(sources, p.Name, p.Bio) {
+		if v, ok := NormalizePronouns(source); ok {
+			p.setList(FieldPronouns, []string{v})
+			return
+		}
+	}
+}
+
+// addEmojiSignals records the country flags and the emojiSignals in the display name
+// and bio. Flags are ISO 3166 codes such as "DE", or subdivision codes such as
+// "gb-sct", and hint at a location without being one.
+func (p *Profile) addEmojiSignals() {
+	text := p.Name + " " + p.Bio
+	var signals []string
+	for _, e := range emojiSignals {
+		if strings.Contains(text, e.emoji) {
+			signals = appendNew(signals, e.signal)
+		}
+	}
+	p.setList(FieldEmojiSignals, signals)
+	p.setList(FieldFlags, flagCodes(text))
+}
+
+// flagCodes returns the region codes of the flag emoji in text: pairs of regional
+// indicator symbols, and black flags followed by subdivision tags.
+func flagCodes(text string) []string {
+	runes := []rune(text)
+	var codes []string
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case isRegionalIndicator(r) && i+1 < len(runes) && isRegionalIndicator(runes[i+1]):
+			codes = appendNew(codes, string([]rune{r - 0x1F1E6 + 'A', runes[i+1] - 0x1F1E6 + 'A'}))
+			i++
+		case r == 0x1F3F4: // Waving black flag, then tags spelling a subdivision
+			var tag []rune
+			for i+1 < len(runes) && runes[i+1] >= 0xE0020 && runes[i+1] < 0xE007F {
+				i++
+				tag = append(tag, runes[i]-0xE0000)
+			}
+			if len(tag) > 2 && i+1 < len(runes) && runes[i+1] == 0xE007F {
+				i++
+				codes = appendNew(codes, string(tag[:2])+"-"+string(tag[2:]))
+			}
+		}
+	}
+	return codes
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
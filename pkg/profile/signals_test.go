@@ -0,0 +1,58 @@
+package profile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizePronouns(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"he/him", "he/him", true},
+		{"She / Her", "she/her", true},
+		{"they/them/theirs", "they/them", true},
+		{"she/they", "she/they", true},
+		{"Jane Doe (xe/xem)", "xe/xem", true},
+		{"johwhj · he·him", "he/him", true},
+		{"input/output", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizePronouns(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("NormalizePronouns(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestAddNameAndBioSignals(t *testing.T) {
+	p := &Profile{Name: "Jane 🏳️‍🌈 (She/Her)", Bio: "Berlin via 🇺🇸, now 🇩🇪 🏴\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F fan. they/them in spirit"}
+	p.AddNameAndBioSignals()
+	if got, _ := p.Pronouns(); got != "she/her" {
+		t.Errorf("Pronouns() = %q, want the display name's she/her", got)
+	}
+	if got := p.Flags(); !slices.Equal(got, []string{"US", "DE", "gb-sct"}) {
+		t.Errorf("Flags() = %v, want [US DE gb-sct]", got)
+	}
+	if got := p.EmojiSignals(); !slices.Equal(got, []string{"pride"}) {
+		t.Errorf("EmojiSignals() = %v, want [pride]", got)
+	}
+
+	// A profile field the user named "Pronouns", as on Mastodon, beats the bio
+	p = &Profile{Bio: "he/him", Fields: map[string]string{"Pronouns": "They / Them"}}
+	p.AddNameAndBioSignals()
+	if got, _ := p.Pronouns(); got != "they/them" {
+		t.Errorf("Pronouns() = %q, want the Pronouns field's they/them", got)
+	}
+}
+
+func TestNormalizePronounsField(t *testing.T) {
+	p := &Profile{Fields: map[string]string{FieldPronouns: "He / Him"}}
+	p.Normalize()
+	if p.Fields[FieldPronouns] != "he/him" || p.Fields[FieldPronouns+RawSuffix] != "He / Him" {
+		t.Errorf("Normalize() pronouns = %q (raw %q), want he/him", p.Fields[FieldPronouns], p.Fields[FieldPronouns+RawSuffix])
+	}
+}
@@ -0,0 +1,67 @@
+package profile
+
+import "github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+
+// Clean applies CleanText to every single-line string in the profile and
+// CleanMultiline to the bio, field values, descriptions, and post content, so that
+// entities, NBSP, and zero-width characters left by a platform's markup do not
+// break matching across platforms. Fields that end up empty are removed.
+func (p *Profile) Clean() {
+	for _, s := range []*string{&p.Username, &p.Name, &p.Location, &p.Website} {
+		*s = htmlutil.CleanText(*s)
+	}
+	p.Bio = htmlutil.CleanMultiline(p.Bio)
+	for k, v := range p.Fields {
+		if v = htmlutil.CleanMultiline(v); v == "" {
+			delete(p.Fields, k)
+			continue
+		}
+		p.Fields[k] = v
+	}
+	kept := p.SocialLinks[:0]
+	for _, link := range p.SocialLinks {
+		if link = htmlutil.CleanText(link); link != "" {
+			kept = append(kept, link)
+		}
+	}
+	p.SocialLinks = kept
+	kept = p.Aliases[:0]
+	for _, alias := range p.Aliases {
+		if alias = htmlutil.CleanText(alias); alias != "" {
+			kept = append(kept, alias)
+		}
+	}
+	p.Aliases = kept
+	for i := range p.Posts {
+		post := &p.Posts[i]
+		post.Title, post.Category = htmlutil.CleanText(post.Title), htmlutil.CleanText(post.Category)
+		post.Content = htmlutil.CleanMultiline(post.Content)
+	}
+	for i := range p.Experience {
+		e := &p.Experience[i]
+		e.Title, e.Organization, e.Location = htmlutil.CleanText(e.Title), htmlutil.CleanText(e.Organization), htmlutil.CleanText(e.Location)
+		e.Description = htmlutil.CleanMultiline(e.Description)
+	}
+	for i := range p.Education {
+		e := &p.Education[i]
+		e.School, e.Degree, e.Field = htmlutil.CleanText(e.School), htmlutil.CleanText(e.Degree), htmlutil.CleanText(e.Field)
+	}
+	for i := range p.Certifications {
+		c := &p.Certifications[i]
+		c.Name, c.Authority = htmlutil.CleanText(c.Name), htmlutil.CleanText(c.Authority)
+	}
+	for i := range p.Publications {
+		pub := &p.Publications[i]
+		pub.Title, pub.Publisher = htmlutil.CleanText(pub.Title), htmlutil.CleanText(pub.Publisher)
+		pub.Description = htmlutil.CleanMultiline(pub.Description)
+	}
+	for i := range p.Volunteering {
+		v := &p.Volunteering[i]
+		v.Role, v.Organization, v.Cause = htmlutil.CleanText(v.Role), htmlutil.CleanText(v.Organization), htmlutil.CleanText(v.Cause)
+	}
+	for i := range p.Honors {
+		h := &p.Honors[i]
+		h.Title, h.Issuer = htmlutil.CleanText(h.Title), htmlutil.CleanText(h.Issuer)
+		h.Description = htmlutil.CleanMultiline(h.Description)
+	}
+}
@@ -0,0 +1,34 @@
+package profile
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClean(t *testing.T) {
+	p := &Profile{
+		Name:        "Jane\u00a0Doe\u200b",
+		Bio:         "Go &amp; Rust\u00a0 \n\n\nKubernetes",
+		Location:    " Berlin ",
+		Fields:      map[string]string{FieldEmployer: "Acme&nbsp;Corp", "blank": "\u200b"},
+		SocialLinks: []string{" https://example.com/jane\ufeff", "\u200b"},
+		Experience:  []Experience{{Title: "Staff\u00a0Engineer", Description: "Built\u00a0things\n\nwell"}},
+	}
+	p.Clean()
+
+	if p.Name != "Jane Doe" || p.Bio != "Go & Rust\n\nKubernetes" || p.Location != "Berlin" {
+		t.Errorf("Name, Bio, Location = %q, %q, %q", p.Name, p.Bio, p.Location)
+	}
+	if p.Fields[FieldEmployer] != "Acme Corp" {
+		t.Errorf("employer = %q, want %q", p.Fields[FieldEmployer], "Acme Corp")
+	}
+	if _, ok := p.Fields["blank"]; ok {
+		t.Error("field left empty by cleaning was kept")
+	}
+	if want := []string{"https://example.com/jane"}; !slices.Equal(p.SocialLinks, want) {
+		t.Errorf("SocialLinks = %q, want %q", p.SocialLinks, want)
+	}
+	if e := p.Experience[0]; e.Title != "Staff Engineer" || e.Description != "Built things\n\nwell" {
+		t.Errorf("Experience = %+v", e)
+	}
+}
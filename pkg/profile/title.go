@@ -0,0 +1,155 @@
+package profile
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Seniority is how senior a job title is, as ClassifyTitle records it.
+type Seniority string
+
+// Seniority levels, in increasing order of Rank. Individual contributor and
+// management levels share the scale: a staff engineer ranks with a manager, a
+// principal with a director.
+const (
+	SeniorityIntern        Seniority = "intern"
+	SeniorityJunior        Seniority = "junior"
+	SeniorityMid           Seniority = "mid"
+	SenioritySenior        Seniority = "senior"
+	SeniorityLead          Seniority = "lead"
+	SeniorityStaff         Seniority = "staff"
+	SeniorityManager       Seniority = "manager"
+	SeniorityPrincipal     Seniority = "principal"
+	SeniorityDirector      Seniority = "director"
+	SeniorityDistinguished Seniority = "distinguished"
+	SeniorityVP            Seniority = "vp"
+	SeniorityExecutive     Seniority = "executive"
+)
+
+// seniorityRanks orders the Seniority levels.
+var seniorityRanks = map[Seniority]int{
+	SeniorityIntern: 1, SeniorityJunior: 2, SeniorityMid: 3, SenioritySenior: 4,
+	SeniorityLead: 5, SeniorityStaff: 5, SeniorityManager: 5,
+	SeniorityPrincipal: 6, SeniorityDirector: 6,
+	SeniorityDistinguished: 7, SeniorityVP: 7, SeniorityExecutive: 8,
+}
+
+// Rank returns s's place on the seniority scale, from 1 for interns to 8 for
+// executives, or 0 for an unknown level.
+func (s Seniority) Rank() int { return seniorityRanks[s] }
+
+// AtLeast reports whether s is as senior as other or more, as in "staff+".
+func (s Seniority) AtLeast(other Seniority) bool {
+	return s.Rank() > 0 && s.Rank() >= other.Rank()
+}
+
+// titleRule maps titles matching pattern to a canonical role. A level set on the
+// rule is the title's seniority whatever its modifiers say.
+type titleRule struct {
+	pattern *regexp.Regexp
+	role    string
+	level   Seniority
+}
+
+// titleRules are tried in order, so specific roles come before the general ones
+// they contain, such as "engineering manager" before "engineer".
+var titleRules = []titleRule{
+	{regexp.MustCompile(`\b(?:ceo|chief executive)\b`), "chief executive officer", SeniorityExecutive},
+	{regexp.MustCompile(`\b(?:cto|chief technology)\b`), "chief technology officer", SeniorityExecutive},
+	{regexp.MustCompile(`\b(?:ciso|cso|chief (?:information )?security)\b`), "chief security officer", SeniorityExecutive},
+	{regexp.MustCompile(`\b(?:cfo|coo|cpo|cmo|chief [a-z]+ officer)\b`), "chief officer", SeniorityExecutive},
+	{regexp.MustCompile(`\b(?:co-?)?founder\b`), "founder", SeniorityExecutive},
+	{regexp.MustCompile(`\bpresident\b`), "president", SeniorityExecutive},
+	{regexp.MustCompile(`\b(?:s?vp|evp|vice president)\b`), "vice president", SeniorityVP},
+	{regexp.MustCompile(`\bdirector\b`), "director", SeniorityDirector},
+	{regexp.MustCompile(`\b(?:head of|head)\b`), "head", SeniorityDirector},
+	{regexp.MustCompile(`\b(?:engineering|software|development|dev|eng) manager\b|\bem\b`), "engineering manager", SeniorityManager},
+	{regexp.MustCompile(`\bproduct (?:manager|owner)\b|\bpm\b`), "product manager", ""},
+	{regexp.MustCompile(`\bmanager\b`), "manager", SeniorityManager},
+	{regexp.MustCompile(`\b(?:developer advocate|devrel|developer relations|dev advocate)\b`), "developer advocate", ""},
+	{regexp.MustCompile(`\b(?:site reliability|sre|devops|platform engineer|infrastructure engineer)\b`), "site reliability engineer", ""},
+	{regexp.MustCompile(`\b(?:security|appsec|infosec|penetration|pentest)`), "security engineer", ""},
+	{regexp.MustCompile(`\b(?:machine learning|ml|ai) engineer\b`), "machine learning engineer", ""},
+	{regexp.MustCompile(`\bdata scientist\b`), "data scientist", ""},
+	{regexp.MustCompile(`\bdata engineer\b`), "data engineer", ""},
+	{regexp.MustCompile(`\b(?:research scientist|researcher)\b`), "researcher", ""},
+	{regexp.MustCompile(`\b(?:designer|ux|ui/ux)\b`), "designer", ""},
+	{regexp.MustCompile(`\b(?:swe|sde|software|developer|programmer|(?:front|back)[- ]?end|full[- ]?stack)\b`), "software engineer", ""},
+	{regexp.MustCompile(`\b(?:engineer|eng)\b`), "engineer", ""},
+	{regexp.MustCompile(`\b(?:architect)\b`), "architect", ""},
+	{regexp.MustCompile(`\b(?:scientist)\b`), "scientist", ""},
+	{regexp.MustCompile(`\b(?:analyst)\b`), "analyst", ""},
+	{regexp.MustCompile(`\b(?:consultant)\b`), "consultant", ""},
+}
+
+// seniorityModifiers map words in a title to its seniority, tried in order so that
+// "senior staff" is staff and "senior director" stays with its rule.
+var seniorityModifiers = []struct {
+	pattern *regexp.Regexp
+	level   Seniority
+}{
+	{regexp.MustCompile(`\b(?:intern|internship|trainee|apprentice)\b`), SeniorityIntern},
+	{regexp.MustCompile(`\b(?:distinguished|fellow)\b`), SeniorityDistinguished},
+	{regexp.MustCompile(`\bprincipal\b|\b(?:iv|v|4|5)$`), SeniorityPrincipal},
+	{regexp.MustCompile(`\bstaff\b`), SeniorityStaff},
+	{regexp.MustCompile(`\b(?:lead|tech lead|team lead)\b`), SeniorityLead},
+	{regexp.MustCompile(`\b(?:senior|sr)\b|\b(?:iii|3)$`), SenioritySenior},
+	{regexp.MustCompile(`\b(?:junior|jr|associate|entry[- ]level|graduate|new grad)\b|\b(?:i|1)$`), SeniorityJunior},
+	{regexp.MustCompile(`\b(?:ii|2)$`), SeniorityMid},
+}
+
+// titleSeparators split a headline into its title and the rest, as in
+// "Staff Engineer at Acme" or "Principal Eng | Kubernetes".
+var titleSeparators = regexp.MustCompile(`\s+(?:at|@|-|–|—|\||·)\s+|\s*[,|·]\s*`)
+
+// NormalizeTitle returns the canonical role and seniority of a job title, such as
+// "software engineer" and SeniorityStaff for "Staff Software Engineer", or false if
+// no rule knows the title. Individual contributor titles without a level are mid.
+func NormalizeTitle(title string) (role string, seniority Seniority, ok bool) {
+	t := strings.ToLower(strings.Join(strings.Fields(title), " "))
+	t = strings.ReplaceAll(t, ".", "")
+	for _, rule := range titleRules {
+		if !rule.pattern.MatchString(t) {
+			continue
+		}
+		if rule.level != "" {
+			return rule.role, rule.level, true
+		}
+		for _, m := range seniorityModifiers {
+			if m.pattern.MatchString(t) {
+				return rule.role, m.level, true
+			}
+		}
+		return rule.role, SeniorityMid, true
+	}
+	return "", "", false
+}
+
+// ClassifyTitle records the canonical role and seniority of the profile's job title
+// under FieldTitleNormalized and FieldSeniority. The title is the title field, else
+// the current position's, else the first part of the headline.
+func (p *Profile) ClassifyTitle() {
+	var candidates []string
+	if v, ok := p.Field(FieldTitle); ok {
+		candidates = append(candidates, v)
+	}
+	if len(p.Experience) > 0 && p.Experience[0].End == "" {
+		candidates = append(candidates, p.Experience[0].Title)
+	}
+	if v, ok := p.Field(FieldHeadline); ok {
+		candidates = append(candidates, titleSeparators.Split(v, 2)[0])
+	}
+	for _, title := range candidates {
+		if role, seniority, ok := NormalizeTitle(title); ok {
+			p.setList(FieldTitleNormalized, []string{role})
+			p.setList(FieldSeniority, []string{string(seniority)})
+			return
+		}
+	}
+}
+
+// Seniority returns the seniority ClassifyTitle found for the profile's job title.
+func (p *Profile) Seniority() (Seniority, bool) {
+	v, ok := p.Field(FieldSeniority)
+	return Seniority(v), ok
+}
@@ -0,0 +1,55 @@
+package profile
+
+import "testing"
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct {
+		title     string
+		role      string
+		seniority Seniority
+	}{
+		{"Staff Software Engineer", "software engineer", SeniorityStaff},
+		{"SWE III", "software engineer", SenioritySenior},
+		{"SDE II", "software engineer", SeniorityMid},
+		{"Principal Eng", "engineer", SeniorityPrincipal},
+		{"Sr. Site Reliability Engineer", "site reliability engineer", SenioritySenior},
+		{"Senior Staff Engineer", "engineer", SeniorityStaff},
+		{"Software Engineering Intern", "software engineer", SeniorityIntern},
+		{"Junior Frontend Developer", "software engineer", SeniorityJunior},
+		{"Engineering Manager", "engineering manager", SeniorityManager},
+		{"Senior Director of Engineering", "director", SeniorityDirector},
+		{"Co-Founder & CTO", "chief technology officer", SeniorityExecutive},
+		{"Developer Advocate", "developer advocate", SeniorityMid},
+		{"Distinguished Engineer", "engineer", SeniorityDistinguished},
+		{"Product Manager", "product manager", SeniorityMid},
+	}
+	for _, tt := range tests {
+		role, seniority, ok := NormalizeTitle(tt.title)
+		if !ok || role != tt.role || seniority != tt.seniority {
+			t.Errorf("NormalizeTitle(%q) = %q, %q, %v; want %q, %q", tt.title, role, seniority, ok, tt.role, tt.seniority)
+		}
+	}
+	if _, _, ok := NormalizeTitle("Dog person"); ok {
+		t.Error("NormalizeTitle(Dog person) succeeded")
+	}
+}
+
+func TestSeniorityAtLeast(t *testing.T) {
+	if !SeniorityPrincipal.AtLeast(SeniorityStaff) || !SeniorityStaff.AtLeast(SeniorityStaff) {
+		t.Error("principal and staff should be staff+")
+	}
+	if SenioritySenior.AtLeast(SeniorityStaff) || Seniority("").AtLeast(SeniorityIntern) {
+		t.Error("senior and unknown should not be staff+")
+	}
+}
+
+func TestClassifyTitle(t *testing.T) {
+	p := &Profile{
+		Fields:     map[string]string{FieldHeadline: "Staff Engineer at Acme | Kubernetes"},
+		Experience: []Experience{{Title: "Vision Quest", Organization: "Acme"}},
+	}
+	p.ClassifyTitle()
+	if got, ok := p.Seniority(); !ok || got != SeniorityStaff || p.Fields[FieldTitleNormalized] != "engineer" {
+		t.Errorf("ClassifyTitle() = %q, %q; want the headline's staff engineer", p.Fields[FieldTitleNormalized], got)
+	}
+}
@@ -0,0 +1,255 @@
+// Package readcv fetches read.cv profiles.
+package readcv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "readcv"
+
+var usernamePattern = regexp.MustCompile(`(?i)read\.cv/([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are read.cv paths that are not people.
+var nonProfiles = map[string]bool{
+	"explore": true, "login": true, "signup": true, "about": true, "pricing": true, "teams": true,
+	"sites": true, "jobs": true, "settings": true, "privacy": true, "terms": true,
+}
+
+// Match returns true if the URL is a read.cv profile.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles read.cv requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a read.cv client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a read.cv profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://read.cv/" + username
+	c.logger.InfoContext(ctx, "fetching read.cv profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a read.cv profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://read.cv/"+username, username)
+}
+
+var nextDataPattern = regexp.MustCompile(`(?s)<script id="__NEXT_DATA__" type="application/json"[^>]*>(.*?)</script>`)
+
+// cvProfile is the profile object read.cv embeds in __NEXT_DATA__ (props.pageProps.profile).
+type cvProfile struct {
+	Username    string `json:"username"`
+	DisplayName string `json:"displayName"`
+	Pronouns    string `json:"pronouns"`
+	Location    string `json:"location"`
+	Website     string `json:"website"`
+	About       string `json:"about"`
+	General     struct {
+		Title string `json:"title"`
+	} `json:"general"`
+	Contact []struct {
+		Platform string `json:"platform"`
+		URL      string `json:"url"`
+	} `json:"contact"`
+	Sections []struct {
+		Type  string    `json:"type"` // "work", "education", "side-projects", "speaking", ...
+		Items []cvEntry `json:"items"`
+	} `json:"sections"`
+}
+
+type cvEntry struct {
+	Heading     string `json:"heading"` // job title or degree
+	Company     string `json:"company"` // employer or school
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Start       cvDate `json:"startDate"`
+	End         cvDate `json:"endDate"`
+}
+
+// cvDate is a year with an optional month; a zero year on an end date means "present".
+type cvDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+}
+
+func (d cvDate) String() string {
+	switch {
+	case d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%04d", d.Year)
+	default:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+}
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	m := nextDataPattern.FindStringSubmatch(content)
+	if m == nil {
+		return nil, errors.New("profile not found (no page data)")
+	}
+	var data struct {
+		Props struct {
+			PageProps struct {
+				Profile *cvProfile `json:"profile"`
+			} `json:"pageProps"`
+		} `json:"props"`
+	}
+	if err := json.Unmarshal([]byte(m[1]), &data); err != nil {
+		return nil, fmt.Errorf("parse read.cv page data: %w", err)
+	}
+	cv := data.Props.PageProps.Profile
+	if cv == nil || cv.DisplayName == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Name:     strings.TrimSpace(cv.DisplayName),
+		Bio:      strings.TrimSpace(cv.About),
+		Location: strings.TrimSpace(cv.Location),
+		Website:  cv.Website,
+		Fields:   make(map[string]string),
+	}
+	if cv.Username != "" {
+		p.Username = cv.Username
+	}
+	if cv.Pronouns != "" {
+		p.Fields[profile.FieldPronouns] = cv.Pronouns
+	}
+	if cv.General.Title != "" {
+		p.Fields[profile.FieldHeadline] = cv.General.Title
+	}
+
+	for _, section := range cv.Sections {
+		for _, e := range section.Items {
+			switch section.Type {
+			case "work":
+				p.Experience = append(p.Experience, profile.Experience{
+					Title:        e.Heading,
+					Organization: e.Company,
+					Location:     e.Location,
+					Start:        e.Start.String(),
+					End:          e.End.String(),
+					Description:  e.Description,
+				})
+			case "education":
+				p.Education = append(p.Education, profile.Education{
+					School: e.Company,
+					Degree: e.Heading,
+					Start:  e.Start.String(),
+					End:    e.End.String(),
+				})
+			case "speaking", "writing":
+				p.Publications = append(p.Publications, profile.Publication{
+					Title:       e.Heading,
+					Publisher:   e.Company,
+					Date:        e.Start.String(),
+					URL:         e.URL,
+					Description: e.Description,
+				})
+			default:
+			}
+		}
+	}
+	if len(p.Experience) > 0 && p.Experience[0].End == "" && p.Experience[0].Organization != "" {
+		p.Fields[profile.FieldEmployer] = p.Experience[0].Organization
+	}
+
+	var found []string
+	for _, c := range cv.Contact {
+		if strings.HasPrefix(c.URL, "http") {
+			found = append(found, c.URL)
+		}
+	}
+	if p.Website != "" {
+		found = append(found, p.Website)
+	}
+	p.SocialLinks = links.Clean(found, Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,90 @@
+package readcv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://read.cv/janedoe", true},
+		{"https://read.cv/janedoe/", true},
+		{"read.cv/jane.doe", true},
+		{"https://read.cv/janedoe/projects/1", false},
+		{"https://read.cv/explore", false},
+		{"https://example.com/janedoe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("read.cv should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><body><script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"profile":{
+		"username":"janedoe","displayName":"Jane Doe","pronouns":"she/her","location":"Lisbon, Portugal",
+		"website":"https://janedoe.dev","about":"Designer who codes.","general":{"title":"Design Engineer"},
+		"contact":[{"platform":"Twitter","url":"https://twitter.com/janedoe"},{"platform":"Email","url":"mailto:jane@doe.dev"}],
+		"sections":[
+			{"type":"work","items":[
+				{"heading":"Design Engineer","company":"Acme","location":"Remote","startDate":{"year":2022,"month":3},"endDate":{}},
+				{"heading":"Product Designer","company":"Initech","startDate":{"year":2018},"endDate":{"year":2022,"month":2}}]},
+			{"type":"education","items":[{"heading":"BFA, Interaction Design","company":"RISD","startDate":{"year":2014},"endDate":{"year":2018}}]},
+			{"type":"speaking","items":[{"heading":"Designing in the browser","company":"Config","startDate":{"year":2023,"month":6},"url":"https://config.com/talk"}]}
+		]}}}}</script></body></html>`
+
+	p, err := parseProfile(page, "https://read.cv/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Location != "Lisbon, Portugal" || p.Bio != "Designer who codes." {
+		t.Errorf("Name = %q, Location = %q, Bio = %q", p.Name, p.Location, p.Bio)
+	}
+	if p.Fields[profile.FieldHeadline] != "Design Engineer" || p.Fields[profile.FieldPronouns] != "she/her" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if p.Fields[profile.FieldEmployer] != "Acme" {
+		t.Errorf("employer = %q, want Acme", p.Fields[profile.FieldEmployer])
+	}
+
+	wantExp := []profile.Experience{
+		{Title: "Design Engineer", Organization: "Acme", Location: "Remote", Start: "2022-03"},
+		{Title: "Product Designer", Organization: "Initech", Start: "2018", End: "2022-02"},
+	}
+	if len(p.Experience) != len(wantExp) {
+		t.Fatalf("Experience = %+v", p.Experience)
+	}
+	for i, want := range wantExp {
+		if p.Experience[i] != want {
+			t.Errorf("Experience[%d] = %+v, want %+v", i, p.Experience[i], want)
+		}
+	}
+	if len(p.Education) != 1 || p.Education[0].School != "RISD" || p.Education[0].End != "2018" {
+		t.Errorf("Education = %+v", p.Education)
+	}
+	if len(p.Publications) != 1 || p.Publications[0].Publisher != "Config" || p.Publications[0].Date != "2023-06" {
+		t.Errorf("Publications = %+v", p.Publications)
+	}
+	if len(p.SocialLinks) != 2 || p.SocialLinks[0] != "https://twitter.com/janedoe" || p.SocialLinks[1] != "https://janedoe.dev" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	page := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{}}}</script>`
+	if _, err := parseProfile(page, "https://read.cv/nobody", "nobody"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseProfile() error = %v, want ErrProfileNotFound", err)
+	}
+}
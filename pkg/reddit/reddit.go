@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -91,6 +92,24 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), normalizedURL, username)
 }
 
+// Parse extracts a profile from an old.reddit.com user page's HTML without fetching anything.
+// The redesigned site's markup is not supported.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), fmt.Sprintf("https://old.reddit.com/user/%s", username), username)
+}
+
+// Profile page patterns for parseProfile.
+var (
+	karmaPattern        = regexp.MustCompile(`(\d+(?:,\d+)?)\s*(?:post|link)\s*karma`)
+	commentKarmaPattern = regexp.MustCompile(`(\d+(?:,\d+)?)\s*comment\s*karma`)
+	agePattern          = regexp.MustCompile(`(?is)<span class="age">.*?<time[^>]+datetime="([^"]+)"`)
+	cakeDayPattern      = regexp.MustCompile(`(?i)redditor since.*?(\d{4})`)
+)
+
 func parseProfile(html, url, username string) (*profile.Profile, error) {
 	prof := &profile.Profile{
 		Platform: platform,
@@ -113,24 +132,25 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	}
 
 	// Extract karma
-	karmaPattern := regexp.MustCompile(`(\d+(?:,\d+)?)\s*(?:post|link)\s*karma`)
 	if matches := karmaPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Fields["post_karma"] = strings.ReplaceAll(matches[1], ",", "")
 	}
 
-	commentKarmaPattern := regexp.MustCompile(`(\d+(?:,\d+)?)\s*comment\s*karma`)
 	if matches := commentKarmaPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.Fields["comment_karma"] = strings.ReplaceAll(matches[1], ",", "")
 	}
 
-	// Extract cake day (account creation date)
-	cakeDayPattern := regexp.MustCompile(`(?i)redditor since.*?(\d{4})`)
-	if matches := cakeDayPattern.FindStringSubmatch(html); len(matches) > 1 {
+	// Extract cake day (account creation date). The sidebar's "redditor for" age
+	// carries the exact date; other layouts only show the year.
+	if matches := agePattern.FindStringSubmatch(html); len(matches) > 1 {
+		prof.CreatedAt = matches[1]
+	} else if matches := cakeDayPattern.FindStringSubmatch(html); len(matches) > 1 {
 		prof.CreatedAt = matches[1] // Year only
 	}
 
 	// Extract posts and comments with subreddit context
 	prof.Posts = extractPosts(html, 50)
+	prof.LastActive = extractLastActive(html)
 
 	// Extract unique subreddits from posts
 	subreddits := extractSubreddits(html)
@@ -155,25 +175,29 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// usernamePattern matches the username in /user/ and /u/ URLs.
+var usernamePattern = regexp.MustCompile(`reddit\.com/(?:user|u)/([^/?#]+)`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
 	urlStr = strings.TrimPrefix(urlStr, "http://")
 
 	// Extract reddit.com/user/username or reddit.com/u/username
-	re := regexp.MustCompile(`reddit\.com/(?:user|u)/([^/?#]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
 	return ""
 }
 
+// subredditPattern matches the subreddit of a post or comment on old Reddit.
+var subredditPattern = regexp.MustCompile(`data-subreddit="([^"]+)"`)
+
 // extractSubreddits extracts subreddit names from Reddit profile HTML.
 func extractSubreddits(html string) []string {
 	// Extract from data-subreddit attributes in comment/post divs
-	pattern := regexp.MustCompile(`data-subreddit="([^"]+)"`)
-	matches := pattern.FindAllStringSubmatch(html, -1)
+	matches := subredditPattern.FindAllStringSubmatch(html, -1)
 
 	seen := make(map[string]bool)
 	var subreddits []string
@@ -225,6 +249,15 @@ func isGenericSubreddit(sub string) bool {
 	return generic[sub]
 }
 
+// Old Reddit "thing" divs for submitted posts, with the title link, and for comments,
+// with the first paragraph of their text.
+var (
+	postPattern = regexp.MustCompile(`(?s)<div[^>]+class="[^"]*\blink\b[^"]*"[^>]+data-subreddit="([^"]+)"[^>]*>` +
+		`.*?<a[^>]+class="[^"]*\btitle\b[^"]*"[^>]*>([^<]+)</a>`)
+	commentPattern = regexp.MustCompile(`(?s)<div[^>]+class="[^"]*\bcomment\b[^"]*"[^>]+data-subreddit="([^"]+)"[^>]*>` +
+		`.*?<div class="md"[^>]*><p>([^<]+)</p>`)
+)
+
 // extractPosts extracts posts and comments from Reddit profile HTML.
 // Posts have titles (submitted links/self-posts), comments have content text.
 func extractPosts(html string, limit int) []profile.Post {
@@ -235,15 +268,12 @@ func extractPosts(html string, limit int) []profile.Post {
 	// Comments: class contains "comment" and have data-subreddit, with content in <div class="md">
 
 	// Extract submitted posts (links/self-posts) - look for "thing ... link" divs
-	postRE := `(?s)<div[^>]+class="[^"]*\blink\b[^"]*"[^>]+data-subreddit="([^"]+)"[^>]*>` +
-		`.*?<a[^>]+class="[^"]*\btitle\b[^"]*"[^>]*>([^<]+)</a>`
-	postPattern := regexp.MustCompile(postRE)
 	postMatches := postPattern.FindAllStringSubmatch(html, -1)
 
 	for _, match := range postMatches {
 		if len(match) > 2 && len(posts) < limit {
 			subreddit := match[1]
-			title := strings.TrimSpace(stripHTML(match[2]))
+			title := htmlutil.Text(match[2])
 			if title == "" {
 				continue
 			}
@@ -256,9 +286,6 @@ func extractPosts(html string, limit int) []profile.Post {
 	}
 
 	// Extract comments - look for "thing ... comment" divs
-	commentRE := `(?s)<div[^>]+class="[^"]*\bcomment\b[^"]*"[^>]+data-subreddit="([^"]+)"[^>]*>` +
-		`.*?<div class="md"[^>]*><p>([^<]+)</p>`
-	commentPattern := regexp.MustCompile(commentRE)
 	commentMatches := commentPattern.FindAllStringSubmatch(html, -1)
 
 	for _, match := range commentMatches {
@@ -267,7 +294,7 @@ func extractPosts(html string, limit int) []profile.Post {
 		}
 
 		subreddit := match[1]
-		text := strings.TrimSpace(stripHTML(match[2]))
+		text := htmlutil.Text(match[2])
 
 		// Skip very short comments
 		if len(text) < 20 {
@@ -295,19 +322,20 @@ func extractPosts(html string, limit int) []profile.Post {
 	return posts
 }
 
-// stripHTML removes HTML tags from a string (simple implementation).
-func stripHTML(s string) string {
-	// Remove HTML tags
-	tagPattern := regexp.MustCompile(`<[^>]*>`)
-	s = tagPattern.ReplaceAllString(s, "")
-
-	// Decode HTML entities
-	s = strings.ReplaceAll(s, "&lt;", "<")
-	s = strings.ReplaceAll(s, "&gt;", ">")
-	s = strings.ReplaceAll(s, "&amp;", "&")
-	s = strings.ReplaceAll(s, "&quot;", "\"")
-	s = strings.ReplaceAll(s, "&#39;", "'")
-	s = strings.ReplaceAll(s, "&nbsp;", " ")
-
-	return s
+// timestampPattern matches the Unix millisecond timestamp of a post or comment.
+var timestampPattern = regexp.MustCompile(`data-timestamp="(\d+)"`)
+
+// extractLastActive returns the time of the newest post or comment on the page.
+// Each "thing" div carries its creation time in data-timestamp as Unix milliseconds.
+func extractLastActive(html string) string {
+	var latest int64
+	for _, m := range timestampPattern.FindAllStringSubmatch(html, -1) {
+		if ms, err := strconv.ParseInt(m[1], 10, 64); err == nil && ms > latest {
+			latest = ms
+		}
+	}
+	if latest == 0 {
+		return ""
+	}
+	return time.UnixMilli(latest).UTC().Format(time.RFC3339)
 }
@@ -175,6 +175,8 @@ func TestParseProfile(t *testing.T) {
 		wantName       string
 		wantPostKarma  string
 		wantSubreddits string
+		wantLastActive string
+		wantCreated    string
 	}{
 		{
 			name: "full profile",
@@ -182,13 +184,24 @@ func TestParseProfile(t *testing.T) {
 				<span>1,234 post karma</span>
 				<span>5,678 comment karma</span>
 				<span>redditor since 2019</span>
-				<div data-subreddit="golang"></div>
-				<div data-subreddit="rust"></div>
+				<div data-subreddit="golang" data-timestamp="1700000000000"></div>
+				<div data-subreddit="rust" data-timestamp="1710000000000"></div>
 			</body></html>`,
 			wantUsername:   "johndoe",
 			wantName:       "johndoe",
 			wantPostKarma:  "1234",
 			wantSubreddits: "golang, rust",
+			wantLastActive: "2024-03-09T16:00:00Z",
+			wantCreated:    "2019",
+		},
+		{
+			name: "sidebar cake day",
+			html: `<html><head><title>overview for olduser - Reddit</title></head><body>
+				<span class="age">redditor for <time title="Mon Jan 30 00:00:00 2012 UTC" datetime="2012-01-30T00:00:00+00:00">12 years</time></span>
+			</body></html>`,
+			wantUsername: "olduser",
+			wantName:     "olduser",
+			wantCreated:  "2012-01-30T00:00:00+00:00",
 		},
 		{
 			name:         "minimal profile",
@@ -223,6 +236,12 @@ func TestParseProfile(t *testing.T) {
 			if tt.wantSubreddits != "" && prof.Fields["subreddits"] != tt.wantSubreddits {
 				t.Errorf("subreddits = %q, want %q", prof.Fields["subreddits"], tt.wantSubreddits)
 			}
+			if prof.CreatedAt != tt.wantCreated {
+				t.Errorf("CreatedAt = %q, want %q", prof.CreatedAt, tt.wantCreated)
+			}
+			if prof.LastActive != tt.wantLastActive {
+				t.Errorf("LastActive = %q, want %q", prof.LastActive, tt.wantLastActive)
+			}
 		})
 	}
 }
@@ -331,23 +350,3 @@ func TestIsGenericSubreddit(t *testing.T) {
 		})
 	}
 }
-
-func TestStripHTML(t *testing.T) {
-	tests := []struct {
-		input string
-		want  string
-	}{
-		{"<p>Hello</p>", "Hello"},
-		{"&lt;script&gt;", "<script>"},
-		{"&amp;&quot;&#39;", "&\"'"},
-		{"Hello&nbsp;World", "Hello World"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			if got := stripHTML(tt.input); got != tt.want {
-				t.Errorf("stripHTML(%q) = %q, want %q", tt.input, got, tt.want)
-			}
-		})
-	}
-}
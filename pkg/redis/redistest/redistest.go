@@ -0,0 +1,459 @@
+// Package redistest provides an in-memory Redis server for tests.
+//
+// It implements the subset of commands sociopath uses. Lua scripts cannot run; tests
+// register a Go function to stand in for each script with HandleScript.
+package redistest
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // script IDs are SHA-1 digests
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ScriptFunc stands in for a Lua script. It runs with the server locked, so it is
+// atomic like a script.
+type ScriptFunc func(keys, args []string) (any, error)
+
+// Server is an in-memory Redis server listening on a loopback port.
+type Server struct {
+	ln      net.Listener
+	strings map[string]string
+	hashes  map[string]map[string]string
+	lists   map[string][]string
+	sets    map[string]map[string]bool
+	zsets   map[string]map[string]float64
+	scripts map[string]ScriptFunc // By SHA-1 of the script
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+}
+
+// NewServer starts a server. Close stops it.
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		ln:      ln,
+		strings: make(map[string]string),
+		hashes:  make(map[string]map[string]string),
+		lists:   make(map[string][]string),
+		sets:    make(map[string]map[string]bool),
+		zsets:   make(map[string]map[string]float64),
+		scripts: make(map[string]ScriptFunc),
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// URL returns the redis:// URL of the server.
+func (s *Server) URL() string {
+	return "redis://" + s.ln.Addr().String()
+}
+
+// Close stops the server and waits for its connections to end.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+// HandleScript makes EVAL and EVALSHA of script run fn.
+func (s *Server) HandleScript(script string, fn ScriptFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[scriptID(script)] = fn
+}
+
+func scriptID(script string) string {
+	sum := sha1.Sum([]byte(script)) //nolint:gosec // matches Redis
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	var conns sync.WaitGroup
+	var mu sync.Mutex
+	open := make(map[net.Conn]bool)
+	defer func() {
+		mu.Lock()
+		for c := range open {
+			_ = c.Close() //nolint:errcheck // shutting down
+		}
+		mu.Unlock()
+		conns.Wait()
+	}()
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		open[c] = true
+		mu.Unlock()
+		conns.Add(1)
+		go func() {
+			defer conns.Done()
+			s.handle(c)
+			mu.Lock()
+			delete(open, c)
+			mu.Unlock()
+			_ = c.Close() //nolint:errcheck // connection is done
+		}()
+	}
+}
+
+func (s *Server) handle(c net.Conn) {
+	r := bufio.NewReader(c)
+	w := bufio.NewWriter(c)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		reply, err := s.exec(args)
+		s.mu.Unlock()
+		if err != nil {
+			reply = err
+		}
+		writeReply(w, reply)
+		if w.Flush() != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command line %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func writeReply(w *bufio.Writer, reply any) {
+	switch v := reply.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case error:
+		w.WriteString("-" + v.Error() + "\r\n")
+	case int64:
+		w.WriteString(":" + strconv.FormatInt(v, 10) + "\r\n")
+	case int:
+		w.WriteString(":" + strconv.Itoa(v) + "\r\n")
+	case string:
+		w.WriteString("$" + strconv.Itoa(len(v)) + "\r\n" + v + "\r\n")
+	case []any:
+		w.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	case []string:
+		w.WriteString("*" + strconv.Itoa(len(v)) + "\r\n")
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	default:
+		w.WriteString("-ERR unsupported reply type " + fmt.Sprintf("%T", v) + "\r\n")
+	}
+}
+
+var errArgs = errors.New("ERR wrong number of arguments")
+
+//nolint:gocognit,gocyclo,cyclop,maintidx,revive // one case per command
+func (s *Server) exec(args []string) (any, error) {
+	if len(args) == 0 {
+		return nil, errors.New("ERR empty command")
+	}
+	cmd := strings.ToUpper(args[0])
+	args = args[1:]
+	switch cmd {
+	case "PING":
+		return "PONG", nil
+	case "AUTH", "SELECT":
+		return "OK", nil
+	case "GET":
+		if len(args) != 1 {
+			return nil, errArgs
+		}
+		if v, ok := s.strings[args[0]]; ok {
+			return v, nil
+		}
+		return nil, nil
+	case "SET":
+		if len(args) < 2 {
+			return nil, errArgs
+		}
+		s.strings[args[0]] = args[1]
+		return "OK", nil
+	case "DEL":
+		n := 0
+		for _, k := range args {
+			if s.exists(k) {
+				n++
+			}
+			delete(s.strings, k)
+			delete(s.hashes, k)
+			delete(s.lists, k)
+			delete(s.sets, k)
+			delete(s.zsets, k)
+		}
+		return n, nil
+	case "INCR":
+		if len(args) != 1 {
+			return nil, errArgs
+		}
+		n, _ := strconv.Atoi(s.strings[args[0]]) //nolint:errcheck // missing keys count from 0
+		n++
+		s.strings[args[0]] = strconv.Itoa(n)
+		return n, nil
+	case "HSET":
+		if len(args) < 3 || len(args)%2 == 0 {
+			return nil, errArgs
+		}
+		h := s.hashes[args[0]]
+		if h == nil {
+			h = make(map[string]string)
+			s.hashes[args[0]] = h
+		}
+		added := 0
+		for i := 1; i < len(args); i += 2 {
+			if _, ok := h[args[i]]; !ok {
+				added++
+			}
+			h[args[i]] = args[i+1]
+		}
+		return added, nil
+	case "HGET":
+		if len(args) != 2 {
+			return nil, errArgs
+		}
+		if v, ok := s.hashes[args[0]][args[1]]; ok {
+			return v, nil
+		}
+		return nil, nil
+	case "HMGET":
+		if len(args) < 2 {
+			return nil, errArgs
+		}
+		out := make([]any, 0, len(args)-1)
+		for _, f := range args[1:] {
+			if v, ok := s.hashes[args[0]][f]; ok {
+				out = append(out, v)
+			} else {
+				out = append(out, nil)
+			}
+		}
+		return out, nil
+	case "RPUSH":
+		if len(args) < 2 {
+			return nil, errArgs
+		}
+		s.lists[args[0]] = append(s.lists[args[0]], args[1:]...)
+		return len(s.lists[args[0]]), nil
+	case "LPOP":
+		if len(args) != 1 {
+			return nil, errArgs
+		}
+		l := s.lists[args[0]]
+		if len(l) == 0 {
+			return nil, nil
+		}
+		s.lists[args[0]] = l[1:]
+		if len(l) == 1 {
+			delete(s.lists, args[0])
+		}
+		return l[0], nil
+	case "LLEN":
+		if len(args) != 1 {
+			return nil, errArgs
+		}
+		return len(s.lists[args[0]]), nil
+	case "LRANGE":
+		if len(args) != 3 {
+			return nil, errArgs
+		}
+		l := s.lists[args[0]]
+		start, stop, err := listRange(len(l), args[1], args[2])
+		if err != nil {
+			return nil, err
+		}
+		return slices.Clone(l[start:stop]), nil
+	case "SADD":
+		if len(args) < 2 {
+			return nil, errArgs
+		}
+		set := s.sets[args[0]]
+		if set == nil {
+			set = make(map[string]bool)
+			s.sets[args[0]] = set
+		}
+		added := 0
+		for _, m := range args[1:] {
+			if !set[m] {
+				set[m] = true
+				added++
+			}
+		}
+		return added, nil
+	case "SISMEMBER":
+		if len(args) != 2 {
+			return nil, errArgs
+		}
+		if s.sets[args[0]][args[1]] {
+			return 1, nil
+		}
+		return 0, nil
+	case "ZADD":
+		if len(args) < 3 || len(args)%2 == 0 {
+			return nil, errArgs
+		}
+		z := s.zsets[args[0]]
+		if z == nil {
+			z = make(map[string]float64)
+			s.zsets[args[0]] = z
+		}
+		added := 0
+		for i := 1; i < len(args); i += 2 {
+			score, err := parseScore(args[i])
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := z[args[i+1]]; !ok {
+				added++
+			}
+			z[args[i+1]] = score
+		}
+		return added, nil
+	case "ZCOUNT", "ZREMRANGEBYSCORE":
+		if len(args) != 3 {
+			return nil, errArgs
+		}
+		lo, err := parseScore(args[1])
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parseScore(args[2])
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		for m, score := range s.zsets[args[0]] {
+			if score >= lo && score <= hi {
+				n++
+				if cmd == "ZREMRANGEBYSCORE" {
+					delete(s.zsets[args[0]], m)
+				}
+			}
+		}
+		return n, nil
+	case "SCRIPT":
+		if len(args) != 2 || strings.ToUpper(args[0]) != "LOAD" {
+			return nil, errors.New("ERR only SCRIPT LOAD is supported")
+		}
+		id := scriptID(args[1])
+		if s.scripts[id] == nil {
+			return nil, errors.New("ERR script has no handler registered with HandleScript")
+		}
+		return id, nil
+	case "EVAL", "EVALSHA":
+		if len(args) < 2 {
+			return nil, errArgs
+		}
+		id := args[0]
+		if cmd == "EVAL" {
+			id = scriptID(args[0])
+		}
+		fn := s.scripts[id]
+		if fn == nil {
+			if cmd == "EVALSHA" {
+				return nil, errors.New("NOSCRIPT No matching script")
+			}
+			return nil, errors.New("ERR script has no handler registered with HandleScript")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 || n > len(args)-2 {
+			return nil, errors.New("ERR invalid number of keys")
+		}
+		return fn(args[2:2+n], args[2+n:])
+	default:
+		return nil, fmt.Errorf("ERR unknown command '%s'", cmd)
+	}
+}
+
+func (s *Server) exists(key string) bool {
+	_, str := s.strings[key]
+	_, hash := s.hashes[key]
+	_, list := s.lists[key]
+	_, set := s.sets[key]
+	_, zset := s.zsets[key]
+	return str || hash || list || set || zset
+}
+
+// listRange converts LRANGE's inclusive, possibly negative, indexes to a slice range.
+func listRange(n int, startArg, stopArg string) (start, stop int, err error) {
+	start, err = strconv.Atoi(startArg)
+	if err != nil {
+		return 0, 0, err
+	}
+	stop, err = strconv.Atoi(stopArg)
+	if err != nil {
+		return 0, 0, err
+	}
+	if start < 0 {
+		start += n
+	}
+	if stop < 0 {
+		stop += n
+	}
+	start = max(start, 0)
+	stop = min(stop+1, n)
+	if start >= stop {
+		return 0, 0, nil
+	}
+	return start, stop, nil
+}
+
+func parseScore(s string) (float64, error) {
+	switch s {
+	case "-inf":
+		return math.Inf(-1), nil
+	case "+inf", "inf":
+		return math.Inf(1), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
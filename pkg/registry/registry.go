@@ -0,0 +1,239 @@
+// Package registry describes what each supported platform can extract, so UIs built
+// on sociopath can show users what to expect before fetching.
+package registry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/activitypub"
+	"github.com/codeGROOVE-dev/sociopath/pkg/bilibili"
+	"github.com/codeGROOVE-dev/sociopath/pkg/bluesky"
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/calcom"
+	"github.com/codeGROOVE-dev/sociopath/pkg/calendly"
+	"github.com/codeGROOVE-dev/sociopath/pkg/codeberg"
+	"github.com/codeGROOVE-dev/sociopath/pkg/devto"
+	"github.com/codeGROOVE-dev/sociopath/pkg/discord"
+	"github.com/codeGROOVE-dev/sociopath/pkg/ens"
+	"github.com/codeGROOVE-dev/sociopath/pkg/fivehundredpx"
+	"github.com/codeGROOVE-dev/sociopath/pkg/flickr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/generic"
+	"github.com/codeGROOVE-dev/sociopath/pkg/github"
+	"github.com/codeGROOVE-dev/sociopath/pkg/githubsponsors"
+	"github.com/codeGROOVE-dev/sociopath/pkg/habr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/instagram"
+	"github.com/codeGROOVE-dev/sociopath/pkg/itchio"
+	"github.com/codeGROOVE-dev/sociopath/pkg/kofi"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkedin"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linktree"
+	"github.com/codeGROOVE-dev/sociopath/pkg/mastodon"
+	"github.com/codeGROOVE-dev/sociopath/pkg/medium"
+	"github.com/codeGROOVE-dev/sociopath/pkg/meetup"
+	"github.com/codeGROOVE-dev/sociopath/pkg/nostr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/patreon"
+	"github.com/codeGROOVE-dev/sociopath/pkg/peertube"
+	"github.com/codeGROOVE-dev/sociopath/pkg/pgp"
+	"github.com/codeGROOVE-dev/sociopath/pkg/polywork"
+	"github.com/codeGROOVE-dev/sociopath/pkg/readcv"
+	"github.com/codeGROOVE-dev/sociopath/pkg/reddit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sessionize"
+	"github.com/codeGROOVE-dev/sociopath/pkg/soundcloud"
+	"github.com/codeGROOVE-dev/sociopath/pkg/stackoverflow"
+	"github.com/codeGROOVE-dev/sociopath/pkg/steam"
+	"github.com/codeGROOVE-dev/sociopath/pkg/substack"
+	"github.com/codeGROOVE-dev/sociopath/pkg/tiktok"
+	"github.com/codeGROOVE-dev/sociopath/pkg/twitter"
+	"github.com/codeGROOVE-dev/sociopath/pkg/vkontakte"
+	"github.com/codeGROOVE-dev/sociopath/pkg/weibo"
+	"github.com/codeGROOVE-dev/sociopath/pkg/writefreely"
+	"github.com/codeGROOVE-dev/sociopath/pkg/youtube"
+)
+
+// Kinds of profile data a platform can extract.
+const (
+	DataName        = "name"
+	DataBio         = "bio"
+	DataLocation    = "location"
+	DataWebsite     = "website"
+	DataAvatar      = "avatar"
+	DataCreatedAt   = "created_at"
+	DataEmail       = "email"
+	DataEmployer    = "employer"
+	DataExperience  = "experience" // Full work history in Profile.Experience
+	DataFollowers   = "followers"
+	DataPosts       = "posts"
+	DataSocialLinks = "social_links"
+)
+
+// HealthUnknown is the health of platforms no health check has run for.
+const HealthUnknown = "unknown"
+
+// Capability describes one platform.
+type Capability struct {
+	Health       Health    `json:"health"`
+	Platform     string    `json:"platform"` // As sociopath.PlatformForURL names it
+	Data         []string  `json:"data"`     // What a fetch can extract, if the profile has it
+	RateLimit    RateLimit `json:"rate_limit"`
+	AuthRequired bool      `json:"auth_required"`
+}
+
+// RateLimit describes how fast a platform can be fetched.
+type RateLimit struct {
+	Note       string `json:"note,omitempty"` // The platform's own limits, as typically observed
+	MinDelayMS int64  `json:"min_delay_ms"`   // Sociopath's built-in delay between requests to the platform
+}
+
+// Health is the latest health check result for a platform.
+type Health struct {
+	CheckedAt time.Time `json:"checked_at,omitzero"`
+	Status    string    `json:"status"` // A sociopath Health status, or "unknown"
+}
+
+type platform struct {
+	authRequired func() bool
+	name         string
+	host         string // Host whose rate limit applies
+	note         string
+	data         []string
+}
+
+// platforms lists every platform sociopath fetches, in PlatformForURL's order.
+var platforms = []platform{
+	{name: "linkedin", authRequired: linkedin.AuthRequired, host: "www.linkedin.com",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataEmployer, DataExperience},
+		note: "profile views per account are capped daily; heavy use triggers login challenges"},
+	{name: "twitter", authRequired: twitter.AuthRequired, host: "x.com",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataCreatedAt, DataSocialLinks},
+		note: "per-account limits on API requests, reset every 15 minutes"},
+	{name: "linktree", authRequired: linktree.AuthRequired, host: "linktr.ee",
+		data: []string{DataName, DataBio, DataWebsite, DataEmail, DataSocialLinks}},
+	{name: "github", authRequired: github.AuthRequired, host: "api.github.com",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataAvatar, DataCreatedAt, DataEmail, DataEmployer, DataFollowers, DataSocialLinks},
+		note: "60 API requests per hour without a token, 5,000 with one"},
+	{name: "codeberg", authRequired: codeberg.AuthRequired, host: "codeberg.org",
+		data: []string{DataName, DataBio, DataWebsite, DataCreatedAt, DataFollowers}},
+	{name: "calcom", authRequired: calcom.AuthRequired, host: "cal.com",
+		data: []string{DataName, DataBio, DataAvatar, DataPosts, DataSocialLinks}},
+	{name: "calendly", authRequired: calendly.AuthRequired, host: "calendly.com",
+		data: []string{DataName, DataAvatar, DataPosts, DataSocialLinks}},
+	{name: "polywork", authRequired: polywork.AuthRequired, host: "www.polywork.com",
+		data: []string{DataName, DataBio, DataLocation, DataEmployer, DataExperience, DataSocialLinks}},
+	{name: "readcv", authRequired: readcv.AuthRequired, host: "read.cv",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataEmployer, DataExperience, DataSocialLinks}},
+	{name: "soundcloud", authRequired: soundcloud.AuthRequired, host: "soundcloud.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataFollowers, DataSocialLinks}},
+	{name: "fivehundredpx", authRequired: fivehundredpx.AuthRequired, host: "500px.com",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataFollowers, DataSocialLinks}},
+	{name: "flickr", authRequired: flickr.AuthRequired, host: "www.flickr.com",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataFollowers, DataSocialLinks}},
+	{name: "discord", authRequired: discord.AuthRequired, host: "discord.com",
+		data: []string{DataName, DataBio, DataSocialLinks}},
+	{name: "steam", authRequired: steam.AuthRequired, host: "steamcommunity.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataSocialLinks}},
+	{name: "itchio", authRequired: itchio.AuthRequired, host: "itch.io",
+		data: []string{DataName, DataBio, DataPosts, DataSocialLinks}},
+	{name: "githubsponsors", authRequired: githubsponsors.AuthRequired, host: "github.com",
+		data: []string{DataName, DataBio, DataSocialLinks}},
+	{name: "kofi", authRequired: kofi.AuthRequired, host: "ko-fi.com",
+		data: []string{DataName, DataBio, DataSocialLinks}},
+	{name: "patreon", authRequired: patreon.AuthRequired, host: "www.patreon.com",
+		data: []string{DataName, DataBio, DataSocialLinks}},
+	{name: "meetup", authRequired: meetup.AuthRequired, host: "www.meetup.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataSocialLinks}},
+	{name: "sessionize", authRequired: sessionize.AuthRequired, host: "sessionize.com",
+		data: []string{DataName, DataBio, DataLocation, DataPosts, DataSocialLinks}},
+	{name: "medium", authRequired: medium.AuthRequired, host: "medium.com",
+		data: []string{DataName, DataBio, DataFollowers, DataSocialLinks}},
+	{name: "reddit", authRequired: reddit.AuthRequired, host: "old.reddit.com",
+		data: []string{DataName, DataCreatedAt, DataPosts, DataSocialLinks},
+		note: "about 10 requests per minute without OAuth"},
+	{name: "youtube", authRequired: youtube.AuthRequired, host: "www.youtube.com",
+		data: []string{DataName, DataBio, DataCreatedAt, DataPosts, DataSocialLinks}},
+	{name: "substack", authRequired: substack.AuthRequired, host: "substack.com",
+		data: []string{DataName, DataBio, DataSocialLinks}},
+	{name: "bilibili", authRequired: bilibili.AuthRequired, host: "api.bilibili.com",
+		data: []string{DataName, DataBio, DataFollowers, DataSocialLinks},
+		note: "aggressive anti-bot checks; repeated requests are answered with errors"},
+	{name: "bluesky", authRequired: bluesky.AuthRequired, host: "public.api.bsky.app",
+		data: []string{DataName, DataBio, DataCreatedAt, DataPosts},
+		note: "3,000 requests per 5 minutes per IP"},
+	{name: "devto", authRequired: devto.AuthRequired, host: "dev.to",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataCreatedAt, DataPosts, DataSocialLinks}},
+	{name: "stackoverflow", authRequired: stackoverflow.AuthRequired, host: "stackoverflow.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataSocialLinks},
+		note: "throttles IPs that make many requests per second"},
+	{name: "habr", authRequired: habr.AuthRequired, host: "habr.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataSocialLinks}},
+	{name: "instagram", authRequired: instagram.AuthRequired, host: "www.instagram.com",
+		note: "not yet implemented"},
+	{name: "tiktok", authRequired: tiktok.AuthRequired, host: "www.tiktok.com",
+		data: []string{DataName, DataBio, DataCreatedAt, DataSocialLinks}},
+	{name: "vkontakte", authRequired: vkontakte.AuthRequired, host: "vk.com",
+		data: []string{DataName, DataBio, DataLocation, DataSocialLinks}},
+	{name: "weibo", authRequired: weibo.AuthRequired, host: "weibo.com",
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataEmployer, DataFollowers}},
+	{name: "peertube", authRequired: peertube.AuthRequired,
+		data: []string{DataName, DataBio, DataAvatar, DataCreatedAt, DataFollowers, DataPosts, DataSocialLinks}},
+	{name: "writefreely", authRequired: writefreely.AuthRequired,
+		data: []string{DataName, DataBio, DataPosts, DataSocialLinks}},
+	{name: "nostr", authRequired: nostr.AuthRequired,
+		data: []string{DataName, DataBio, DataWebsite, DataAvatar, DataCreatedAt, DataSocialLinks}},
+	{name: "ens", authRequired: ens.AuthRequired, host: "enstate.rs",
+		data: []string{DataName, DataBio, DataLocation, DataWebsite, DataAvatar, DataEmail, DataSocialLinks}},
+	{name: "pgp", authRequired: pgp.AuthRequired, host: "keys.openpgp.org",
+		data: []string{DataName, DataCreatedAt, DataEmail}},
+	{name: "mastodon", authRequired: mastodon.AuthRequired,
+		data: []string{DataName, DataBio, DataLocation, DataCreatedAt, DataPosts, DataSocialLinks},
+		note: "300 requests per 5 minutes per IP on most servers"},
+	{name: "activitypub", authRequired: activitypub.AuthRequired,
+		data: []string{DataName, DataBio, DataLocation, DataAvatar, DataCreatedAt, DataPosts, DataSocialLinks}},
+	{name: "generic", authRequired: generic.AuthRequired,
+		data: []string{DataName, DataBio, DataEmail, DataPosts, DataSocialLinks}},
+}
+
+var (
+	healthMu sync.RWMutex
+	health   = make(map[string]Health)
+)
+
+// RecordHealth stores the result of a health check of platform, as sociopath.Diagnose
+// reports it, for Capabilities to return.
+func RecordHealth(platform, status string, checkedAt time.Time) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	health[platform] = Health{Status: status, CheckedAt: checkedAt}
+}
+
+// Capabilities returns what every supported platform can extract, whether it needs
+// login cookies, how fast it can be fetched, and its latest recorded health.
+func Capabilities() []Capability {
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+
+	caps := make([]Capability, 0, len(platforms))
+	for _, p := range platforms {
+		c := Capability{
+			Platform:     p.name,
+			Data:         append([]string(nil), p.data...),
+			AuthRequired: p.authRequired(),
+			RateLimit:    RateLimit{Note: p.note, MinDelayMS: cache.MinDelay(p.host).Milliseconds()},
+			Health:       Health{Status: HealthUnknown},
+		}
+		if h, ok := health[p.name]; ok {
+			c.Health = h
+		}
+		caps = append(caps, c)
+	}
+	return caps
+}
+
+// Lookup returns the capability of the named platform.
+func Lookup(name string) (Capability, bool) {
+	for _, c := range Capabilities() {
+		if c.Platform == name {
+			return c, true
+		}
+	}
+	return Capability{}, false
+}
@@ -0,0 +1,59 @@
+package registry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCapabilities(t *testing.T) {
+	caps := Capabilities()
+	seen := make(map[string]bool)
+	for _, c := range caps {
+		if seen[c.Platform] {
+			t.Errorf("platform %q listed twice", c.Platform)
+		}
+		seen[c.Platform] = true
+		if len(c.Data) == 0 && c.Platform != "instagram" {
+			t.Errorf("platform %q extracts nothing", c.Platform)
+		}
+		if c.Health.Status == "" {
+			t.Errorf("platform %q has no health status", c.Platform)
+		}
+	}
+
+	linkedin, ok := Lookup("linkedin")
+	if !ok || !linkedin.AuthRequired || linkedin.RateLimit.MinDelayMS != 1200 {
+		t.Errorf("Lookup(linkedin) = %+v, %v", linkedin, ok)
+	}
+	github, ok := Lookup("github")
+	if !ok || github.AuthRequired || github.RateLimit.MinDelayMS != 200 || github.RateLimit.Note == "" {
+		t.Errorf("Lookup(github) = %+v, %v", github, ok)
+	}
+	if _, ok := Lookup("myspace"); ok {
+		t.Error("Lookup(myspace) found a platform")
+	}
+}
+
+func TestRecordHealth(t *testing.T) {
+	when := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	RecordHealth("reddit", "incomplete", when)
+	t.Cleanup(func() {
+		healthMu.Lock()
+		delete(health, "reddit")
+		healthMu.Unlock()
+	})
+
+	reddit, _ := Lookup("reddit")
+	if reddit.Health.Status != "incomplete" || !reddit.Health.CheckedAt.Equal(when) {
+		t.Errorf("reddit health = %+v", reddit.Health)
+	}
+	if devto, _ := Lookup("devto"); devto.Health.Status != HealthUnknown {
+		t.Errorf("devto health = %+v, want unknown", devto.Health)
+	}
+
+	// Callers cannot modify the registry through returned slices
+	reddit.Data[0] = "changed"
+	if again, _ := Lookup("reddit"); again.Data[0] == "changed" {
+		t.Error("Capabilities() shares Data with the registry")
+	}
+}
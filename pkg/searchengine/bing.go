@@ -0,0 +1,57 @@
+package searchengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const bingEndpoint = "https://api.bing.microsoft.com/v7.0/search"
+
+// Bing searches with the Bing Web Search API.
+type Bing struct {
+	client
+	apiKey string
+}
+
+// NewBing creates a Bing provider using the given subscription key.
+func NewBing(_ context.Context, apiKey string, opts ...Option) (*Bing, error) {
+	if apiKey == "" {
+		return nil, errors.New("bing: API key required")
+	}
+	return &Bing{client: newClient(opts), apiKey: apiKey}, nil
+}
+
+// Search implements Provider.
+func (b *Bing) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	q := url.Values{"q": {query}, "responseFilter": {"Webpages"}}
+	if limit > 0 {
+		q.Set("count", strconv.Itoa(limit))
+	}
+	body, err := b.fetch(ctx, bingEndpoint+"?"+q.Encode(), map[string]string{"Ocp-Apim-Subscription-Key": b.apiKey})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		WebPages struct {
+			Value []struct {
+				Name    string `json:"name"`
+				URL     string `json:"url"`
+				Snippet string `json:"snippet"`
+			} `json:"value"`
+		} `json:"webPages"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing bing results: %w", err)
+	}
+
+	results := make([]Result, 0, len(resp.WebPages.Value))
+	for _, v := range resp.WebPages.Value {
+		results = append(results, Result{Title: v.Name, Snippet: v.Snippet, URL: v.URL})
+	}
+	return truncate(results, limit), nil
+}
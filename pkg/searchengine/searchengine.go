@@ -0,0 +1,83 @@
+// Package searchengine queries web search engines for candidate profile URLs.
+// The guess engine uses a Provider to find profiles by name and employer when
+// probing usernames directly finds nothing.
+package searchengine
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+)
+
+// Result is one search hit.
+type Result struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet,omitempty"`
+	URL     string `json:"url"`
+}
+
+// Provider runs a web search and returns up to limit results, best first.
+type Provider interface {
+	Search(ctx context.Context, query string, limit int) ([]Result, error)
+}
+
+// Option configures a provider.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// client holds the HTTP plumbing shared by providers.
+type client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+func newClient(opts []Option) client {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return client{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}
+}
+
+func (c client) fetch(ctx context.Context, urlStr string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", cache.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+// truncate caps results at limit, treating limit <= 0 as no limit.
+func truncate(results []Result, limit int) []Result {
+	if limit > 0 && len(results) > limit {
+		return results[:limit]
+	}
+	return results
+}
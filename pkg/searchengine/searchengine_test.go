@@ -0,0 +1,120 @@
+package searchengine
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+var discard = WithLogger(slog.New(slog.DiscardHandler))
+
+// serve points c at a test server running handler.
+func serve(t *testing.T, c *client, handler http.HandlerFunc) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c.httpClient.Transport = &mockTransport{mockURL: server.URL}
+}
+
+func TestBing(t *testing.T) {
+	b, err := NewBing(context.Background(), "key", discard)
+	if err != nil {
+		t.Fatalf("NewBing() error = %v", err)
+	}
+	serve(t, &b.client, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "key" || r.URL.Query().Get("q") != `"Jane Doe" Acme` {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"webPages": {"value": [
+			{"name": "Jane Doe - Acme | LinkedIn", "url": "https://www.linkedin.com/in/jdoe-acme", "snippet": "Engineer at Acme"},
+			{"name": "Jane Doe (@jd)", "url": "https://twitter.com/jd", "snippet": ""}
+		]}}`))
+	})
+
+	got, err := b.Search(context.Background(), `"Jane Doe" Acme`, 1)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	want := []Result{{Title: "Jane Doe - Acme | LinkedIn", Snippet: "Engineer at Acme", URL: "https://www.linkedin.com/in/jdoe-acme"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Search() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSerpAPI(t *testing.T) {
+	s, err := NewSerpAPI(context.Background(), "key", discard)
+	if err != nil {
+		t.Fatalf("NewSerpAPI() error = %v", err)
+	}
+	serve(t, &s.client, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "nobody":
+			_, _ = w.Write([]byte(`{"error": "Google hasn't returned any results for this query."}`))
+		default:
+			_, _ = w.Write([]byte(`{"organic_results": [{"title": "janedoe (Jane Doe) - GitHub", "link": "https://github.com/janedoe", "snippet": "Acme"}]}`))
+		}
+	})
+
+	got, err := s.Search(context.Background(), "Jane Doe", 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(got) != 1 || got[0].URL != "https://github.com/janedoe" || got[0].Snippet != "Acme" {
+		t.Errorf("Search() = %+v", got)
+	}
+	if got, err := s.Search(context.Background(), "nobody", 10); err != nil || len(got) != 0 {
+		t.Errorf("Search(no results) = %+v, %v; want none", got, err)
+	}
+}
+
+func TestSearxNG(t *testing.T) {
+	s, err := NewSearxNG(context.Background(), "https://searx.example.com/", discard)
+	if err != nil {
+		t.Fatalf("NewSearxNG() error = %v", err)
+	}
+	serve(t, &s.client, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" || r.URL.Query().Get("format") != "json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"results": [
+			{"title": "Jane Doe", "url": "https://mastodon.social/@janedoe", "content": "Toots"},
+			{"title": "Jane", "url": "https://janedoe.dev", "content": ""}
+		]}`))
+	})
+
+	got, err := s.Search(context.Background(), "Jane Doe", 0)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Snippet != "Toots" || got[1].URL != "https://janedoe.dev" {
+		t.Errorf("Search() = %+v", got)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	ctx := context.Background()
+	if _, err := NewBing(ctx, ""); err == nil {
+		t.Error("NewBing(\"\") should fail")
+	}
+	if _, err := NewSerpAPI(ctx, ""); err == nil {
+		t.Error("NewSerpAPI(\"\") should fail")
+	}
+	if _, err := NewSearxNG(ctx, "searx.example.com"); err == nil {
+		t.Error("NewSearxNG without scheme should fail")
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
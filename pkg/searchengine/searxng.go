@@ -0,0 +1,53 @@
+package searchengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SearxNG searches a self-hosted SearxNG instance. The instance must have the
+// JSON output format enabled in its settings.
+type SearxNG struct {
+	client
+	baseURL string
+}
+
+// NewSearxNG creates a provider for the SearxNG instance at baseURL, such as
+// "https://searx.example.com".
+func NewSearxNG(_ context.Context, baseURL string, opts ...Option) (*SearxNG, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("searxng: base URL must be an absolute http(s) URL")
+	}
+	return &SearxNG{client: newClient(opts), baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Search implements Provider.
+func (s *SearxNG) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	q := url.Values{"q": {query}, "format": {"json"}}
+	body, err := s.fetch(ctx, s.baseURL+"/search?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Results []struct {
+			Title   string `json:"title"`
+			URL     string `json:"url"`
+			Content string `json:"content"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing searxng results: %w", err)
+	}
+
+	results := make([]Result, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, Result{Title: r.Title, Snippet: r.Content, URL: r.URL})
+	}
+	return truncate(results, limit), nil
+}
@@ -0,0 +1,61 @@
+package searchengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+const serpAPIEndpoint = "https://serpapi.com/search.json"
+
+// SerpAPI searches Google through SerpAPI.
+type SerpAPI struct {
+	client
+	apiKey string
+}
+
+// NewSerpAPI creates a SerpAPI provider using the given API key.
+func NewSerpAPI(_ context.Context, apiKey string, opts ...Option) (*SerpAPI, error) {
+	if apiKey == "" {
+		return nil, errors.New("serpapi: API key required")
+	}
+	return &SerpAPI{client: newClient(opts), apiKey: apiKey}, nil
+}
+
+// Search implements Provider.
+func (s *SerpAPI) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	q := url.Values{"engine": {"google"}, "q": {query}, "api_key": {s.apiKey}}
+	if limit > 0 {
+		q.Set("num", strconv.Itoa(limit))
+	}
+	body, err := s.fetch(ctx, serpAPIEndpoint+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Error          string `json:"error"`
+		OrganicResults []struct {
+			Title   string `json:"title"`
+			Link    string `json:"link"`
+			Snippet string `json:"snippet"`
+		} `json:"organic_results"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing serpapi results: %w", err)
+	}
+	if resp.Error != "" && len(resp.OrganicResults) == 0 {
+		// SerpAPI reports "no results" as an error string
+		s.logger.DebugContext(ctx, "serpapi returned no results", "query", query, "error", resp.Error)
+		return nil, nil
+	}
+
+	results := make([]Result, 0, len(resp.OrganicResults))
+	for _, r := range resp.OrganicResults {
+		results = append(results, Result{Title: r.Title, Snippet: r.Snippet, URL: r.Link})
+	}
+	return truncate(results, limit), nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// maxPooledBuffer is the largest response buffer returned to the pool; the odd huge
+// profile should not pin its memory for every later request.
+const maxPooledBuffer = 1 << 20
+
+var buffers = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 16<<10)
+		return &b
+	},
+}
+
+// writeJSON writes v as the response body, followed by a newline. Profiles, the bulk
+// of what a busy server returns, are encoded by Profile.AppendJSON into a pooled
+// buffer, so serving one allocates only its headers' values; other values go
+// through encoding/json. Encoding before writing lets the response carry a
+// Content-Length, and turns a value that cannot be encoded into a 500 instead of a
+// truncated body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	bp := buffers.Get().(*[]byte) //nolint:errcheck,forcetypeassert // the pool only holds these
+	defer func() {
+		if cap(*bp) <= maxPooledBuffer {
+			buffers.Put(bp)
+		}
+	}()
+
+	b, err := appendJSON((*bp)[:0], v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		b, _ = appendJSON((*bp)[:0], map[string]string{"error": "encoding response: " + err.Error()}) //nolint:errcheck // a string map always encodes
+	}
+	b = append(b, '\n')
+	*bp = b
+
+	h := w.Header()
+	// Each response gets its own slice: header values are mutable, so a shared one
+	// could be changed under every other response
+	h["Content-Type"] = []string{"application/json"}
+	h.Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(status)
+	_, _ = w.Write(b) //nolint:errcheck // the client went away
+}
+
+func appendJSON(b []byte, v any) ([]byte, error) {
+	if p, ok := v.(*profile.Profile); ok && p != nil {
+		return p.AppendJSON(b)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return b, err
+	}
+	return append(b, data...), nil
+}
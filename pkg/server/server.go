@@ -0,0 +1,420 @@
+// Package server serves profile fetches over HTTP.
+//
+// GET /v1/profile?url=<profile URL> fetches a profile and returns it as JSON. When the
+// platform's budget is exhausted, whether by a daily quota, a politeness limit, or the
+// platform rate limiting us, the fetch is queued instead and the response is 202
+// Accepted with a job whose result GET /v1/jobs/<id> returns once a per-platform worker
+// gets to it, so callers never have to retry 429s themselves.
+//
+// With tenants configured, every request needs a tenant's API key, and each tenant's
+// fetches use its own cookies and proxy. Its jobs queue separately, so one tenant's
+// banned or rate-limited session does not hold up the others.
+//
+// Drain and Restore carry queued jobs over a restart.
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// Job statuses.
+const (
+	StatusQueued  = "queued"
+	StatusRunning = "running"
+	StatusDone    = "done"
+	StatusFailed  = "failed"
+)
+
+// Job is a fetch queued until its platform has budget again.
+type Job struct {
+	Created  time.Time        `json:"created_at"`
+	Finished time.Time        `json:"finished_at,omitzero"`
+	Profile  *profile.Profile `json:"profile,omitempty"`
+	ID       string           `json:"id"`
+	Tenant   string           `json:"tenant,omitempty"`
+	URL      string           `json:"url"`
+	Platform string           `json:"platform"`
+	Status   string           `json:"status"`
+	Error    string           `json:"error,omitempty"`
+	Attempts int              `json:"attempts"`
+}
+
+// FetchFunc fetches a profile; sociopath.Fetch is the default.
+type FetchFunc func(ctx context.Context, url string, opts ...sociopath.Option) (*profile.Profile, error)
+
+// Server handles profile requests. It is safe for concurrent use.
+type Server struct {
+	ctx        context.Context //nolint:containedctx // bounds the lifetime of queue workers
+	fetch      FetchFunc
+	logger     *slog.Logger
+	jobs       map[string]*Job
+	queues     map[string][]*Job // Pending jobs by tenant and platform; a worker runs while non-empty
+	tenants    []*tenant         // Nil serves everyone as one anonymous tenant
+	anonymous  *tenant
+	draining   chan struct{} // Closed by Drain
+	retryDelay time.Duration
+	jobTTL     time.Duration
+	workers    sync.WaitGroup
+	mu         sync.Mutex
+	drainOnce  sync.Once
+}
+
+// Option configures a Server.
+type Option func(*config)
+
+type config struct {
+	fetch      FetchFunc
+	logger     *slog.Logger
+	fetchOpts  []sociopath.Option
+	tenants    []Tenant
+	retryDelay time.Duration
+	jobTTL     time.Duration
+}
+
+// WithFetchOptions sets the options every fetch is made with, such as the cache,
+// cookies, and quotas.
+func WithFetchOptions(opts ...sociopath.Option) Option {
+	return func(c *config) { c.fetchOpts = append(c.fetchOpts, opts...) }
+}
+
+// WithFetchFunc replaces sociopath.Fetch, for tests and for wrapping fetches.
+func WithFetchFunc(fn FetchFunc) Option {
+	return func(c *config) { c.fetch = fn }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithRetryDelay sets how long a platform's queue waits after its budget runs out
+// before trying again (default 1 minute).
+func WithRetryDelay(d time.Duration) Option {
+	return func(c *config) { c.retryDelay = d }
+}
+
+// WithJobTTL sets how long finished jobs can be retrieved (default 1 hour).
+func WithJobTTL(d time.Duration) Option {
+	return func(c *config) { c.jobTTL = d }
+}
+
+// New creates a server. Queue workers stop when ctx is done.
+func New(ctx context.Context, opts ...Option) (*Server, error) {
+	cfg := &config{
+		fetch:      sociopath.Fetch,
+		logger:     slog.Default(),
+		retryDelay: time.Minute,
+		jobTTL:     time.Hour,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	s := &Server{
+		ctx:        ctx,
+		fetch:      cfg.fetch,
+		logger:     cfg.logger,
+		retryDelay: cfg.retryDelay,
+		jobTTL:     cfg.jobTTL,
+		jobs:       make(map[string]*Job),
+		queues:     make(map[string][]*Job),
+		anonymous:  &tenant{fetchOpts: cfg.fetchOpts},
+		draining:   make(chan struct{}),
+	}
+	if len(cfg.tenants) > 0 {
+		for i := range cfg.tenants {
+			t, err := newTenant(&cfg.tenants[i], cfg.fetchOpts)
+			if err != nil {
+				return nil, err
+			}
+			s.tenants = append(s.tenants, t)
+		}
+		if err := validateTenants(s.tenants); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Handler returns the server's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/profile", s.handleProfile)
+	mux.HandleFunc("GET /v1/jobs/{id}", s.handleJob)
+	return mux
+}
+
+// budgetExhausted reports whether err means the platform can take no more fetches
+// for now, as opposed to the fetch having failed.
+func budgetExhausted(err error) bool {
+	return errors.Is(err, sociopath.ErrQuotaExceeded) || errors.Is(err, cache.ErrPolitenessLimit) ||
+		errors.Is(err, sociopath.ErrRateLimited)
+}
+
+func (s *Server) handleProfile(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing or unknown API key")
+		return
+	}
+	u := r.URL.Query().Get("url")
+	if u == "" {
+		writeError(w, http.StatusBadRequest, "missing url parameter")
+		return
+	}
+	platform := sociopath.PlatformForURL(u)
+
+	// Fetches queued earlier for this platform go first
+	if job := s.enqueueIfBusy(t, u, platform); job != nil {
+		writeAccepted(w, job, s.retryDelay)
+		return
+	}
+
+	p, err := s.fetch(r.Context(), u, t.fetchOpts...)
+	if budgetExhausted(err) {
+		s.logger.InfoContext(r.Context(), "platform budget exhausted, queueing fetch", "url", u, "platform", platform, "tenant", t.name, "error", err)
+		writeAccepted(w, s.enqueue(t, u, platform), s.retryDelay)
+		return
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	t, ok := s.authenticate(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "missing or unknown API key")
+		return
+	}
+
+	s.mu.Lock()
+	job, ok := s.jobs[r.PathValue("id")]
+	var snapshot Job
+	if ok {
+		snapshot = *job
+	}
+	s.mu.Unlock()
+
+	// Other tenants' jobs do not exist as far as a tenant can tell
+	ok = ok && snapshot.Tenant == t.name
+	if !ok {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	if snapshot.Status == StatusQueued || snapshot.Status == StatusRunning {
+		w.Header().Set("Retry-After", strconv.Itoa(int(max(s.retryDelay, time.Second).Seconds())))
+	}
+	writeJSON(w, http.StatusOK, &snapshot)
+}
+
+// queueKey names the queue of t's fetches from platform.
+func queueKey(t *tenant, platform string) string {
+	return t.name + "/" + platform
+}
+
+// enqueueIfBusy queues t's fetch of u if t already has queued fetches from platform.
+func (s *Server) enqueueIfBusy(t *tenant, u, platform string) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queues[queueKey(t, platform)]) == 0 {
+		return nil
+	}
+	return s.enqueueLocked(t, u, platform)
+}
+
+func (s *Server) enqueue(t *tenant, u, platform string) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enqueueLocked(t, u, platform)
+}
+
+func (s *Server) enqueueLocked(t *tenant, u, platform string) *Job {
+	s.expireLocked()
+	job := &Job{ID: newJobID(), URL: u, Platform: platform, Tenant: t.name, Status: StatusQueued, Created: time.Now().UTC()}
+	s.jobs[job.ID] = job
+	s.pushLocked(t, job)
+	return job
+}
+
+// pushLocked adds job to the end of its queue, starting the queue's worker if the
+// queue was empty and the server is not draining.
+func (s *Server) pushLocked(t *tenant, job *Job) {
+	key := queueKey(t, job.Platform)
+	s.queues[key] = append(s.queues[key], job)
+	if len(s.queues[key]) == 1 && !s.isDraining() {
+		s.workers.Add(1)
+		go s.work(t, key)
+	}
+}
+
+func (s *Server) isDraining() bool {
+	select {
+	case <-s.draining:
+		return true
+	default:
+		return false
+	}
+}
+
+// Drain stops queue workers from starting more fetches, waits until the fetches in
+// flight finish or ctx is done, and returns the jobs still queued, oldest first per
+// queue. Pass them to Restore on a new server to carry them over a restart. Jobs
+// queued after Drain are kept but not run.
+func (s *Server) Drain(ctx context.Context) []Job {
+	s.drainOnce.Do(func() { close(s.draining) })
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.WarnContext(ctx, "gave up waiting for queued fetches in flight", "error", ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.queues))
+	for key := range s.queues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var pending []Job
+	for _, key := range keys {
+		for _, job := range s.queues[key] {
+			if job.Status == StatusQueued {
+				pending = append(pending, *job)
+			}
+		}
+	}
+	return pending
+}
+
+// Restore queues jobs drained from an earlier server under their original IDs, so
+// clients can keep polling them. Jobs of tenants this server does not have are dropped.
+func (s *Server) Restore(jobs []Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range jobs {
+		t := s.tenantNamed(jobs[i].Tenant)
+		if t == nil {
+			s.logger.Warn("dropping restored job of unknown tenant", "id", jobs[i].ID, "tenant", jobs[i].Tenant)
+			continue
+		}
+		job := jobs[i]
+		job.Status = StatusQueued
+		s.jobs[job.ID] = &job
+		s.pushLocked(t, &job)
+	}
+}
+
+// expireLocked forgets finished jobs older than the job TTL.
+func (s *Server) expireLocked() {
+	cutoff := time.Now().Add(-s.jobTTL)
+	for id, job := range s.jobs {
+		if !job.Finished.IsZero() && job.Finished.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// work runs the fetches queued under key in order, waiting out exhausted budgets,
+// until the queue is empty or the server drains.
+func (s *Server) work(t *tenant, key string) {
+	defer s.workers.Done()
+	for {
+		s.mu.Lock()
+		queue := s.queues[key]
+		if len(queue) == 0 {
+			delete(s.queues, key)
+			s.mu.Unlock()
+			return
+		}
+		if s.isDraining() {
+			s.mu.Unlock()
+			return
+		}
+		job := queue[0]
+		job.Status = StatusRunning
+		job.Attempts++
+		s.mu.Unlock()
+
+		p, err := s.fetch(s.ctx, job.URL, t.fetchOpts...)
+		if budgetExhausted(err) && s.ctx.Err() == nil {
+			s.logger.DebugContext(s.ctx, "platform budget still exhausted", "queue", key, "queued", len(queue), "error", err)
+			s.mu.Lock()
+			job.Status = StatusQueued
+			s.mu.Unlock()
+			timer := time.NewTimer(s.retryDelay)
+			select {
+			case <-timer.C:
+				continue
+			case <-s.draining:
+				timer.Stop()
+				return
+			case <-s.ctx.Done():
+				timer.Stop()
+				err = s.ctx.Err()
+			}
+		}
+
+		s.mu.Lock()
+		job.Finished = time.Now().UTC()
+		if err != nil {
+			job.Status = StatusFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = StatusDone
+			job.Profile = p
+		}
+		s.queues[key] = s.queues[key][1:]
+		s.mu.Unlock()
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) //nolint:errcheck // crypto/rand.Read never fails
+	return hex.EncodeToString(b)
+}
+
+// statusFor maps a fetch error to an HTTP status.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, sociopath.ErrProfileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, policy.ErrDenied), errors.Is(err, sociopath.ErrDisabled):
+		return http.StatusForbidden
+	case errors.Is(err, sociopath.ErrAuthRequired), errors.Is(err, sociopath.ErrNoCookies):
+		return http.StatusUnauthorized
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeAccepted(w http.ResponseWriter, job *Job, retry time.Duration) {
+	w.Header().Set("Location", "/v1/jobs/"+job.ID)
+	w.Header().Set("Retry-After", strconv.Itoa(int(max(retry, time.Second).Seconds())))
+	writeJSON(w, http.StatusAccepted, map[string]string{"id": job.ID, "status": StatusQueued, "url": job.URL})
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// budgetFetch fails with ErrQuotaExceeded until budget is raised, and returns a
+// profile named after the URL otherwise.
+type budgetFetch struct {
+	budget int
+	calls  int
+	mu     sync.Mutex
+}
+
+func (f *budgetFetch) fetch(_ context.Context, u string, _ ...sociopath.Option) (*profile.Profile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	switch {
+	case u == "https://github.com/ghost":
+		return nil, sociopath.ErrProfileNotFound
+	case f.budget <= 0:
+		return nil, fmt.Errorf("%w: github used 1 of 1 fetches in the last 24h", sociopath.ErrQuotaExceeded)
+	default:
+		f.budget--
+		return &profile.Profile{Platform: "github", URL: u}, nil
+	}
+}
+
+func (f *budgetFetch) setBudget(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.budget = n
+}
+
+func get(t *testing.T, ts *httptest.Server, path string, v any) *http.Response {
+	t.Helper()
+	resp, err := http.Get(ts.URL + path) //nolint:noctx // test
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // test
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("decoding %s: %v", path, err)
+		}
+	}
+	return resp
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := &budgetFetch{budget: 1}
+	s, err := New(ctx, WithFetchFunc(f.fetch), WithRetryDelay(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	profilePath := func(u string) string { return "/v1/profile?url=" + url.QueryEscape(u) }
+
+	var p profile.Profile
+	if resp := get(t, ts, profilePath("https://github.com/alice"), &p); resp.StatusCode != http.StatusOK || p.URL != "https://github.com/alice" {
+		t.Fatalf("first fetch = %d, %+v", resp.StatusCode, p)
+	}
+	if resp := get(t, ts, profilePath("https://github.com/ghost"), nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing profile status = %d, want 404", resp.StatusCode)
+	}
+
+	// The budget is spent: both fetches are queued, the second without being tried
+	var accepted []map[string]string
+	for _, u := range []string{"https://github.com/bob", "https://github.com/carol"} {
+		var body map[string]string
+		resp := get(t, ts, profilePath(u), &body)
+		if resp.StatusCode != http.StatusAccepted || body["id"] == "" || resp.Header.Get("Location") != "/v1/jobs/"+body["id"] || resp.Header.Get("Retry-After") == "" {
+			t.Fatalf("over-budget fetch = %d %v %v", resp.StatusCode, resp.Header, body)
+		}
+		accepted = append(accepted, body)
+	}
+
+	var job Job
+	get(t, ts, "/v1/jobs/"+accepted[1]["id"], &job)
+	if job.Status != StatusQueued || job.URL != "https://github.com/carol" {
+		t.Errorf("queued job = %+v", job)
+	}
+
+	f.setBudget(2)
+	for _, a := range accepted {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			job = Job{}
+			get(t, ts, "/v1/jobs/"+a["id"], &job)
+			if job.Status == StatusDone || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if job.Status != StatusDone || job.Profile == nil || job.Profile.URL != a["url"] || job.Finished.IsZero() {
+			t.Errorf("finished job = %+v", job)
+		}
+	}
+
+	if resp := get(t, ts, "/v1/jobs/nope", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown job status = %d, want 404", resp.StatusCode)
+	}
+	if resp := get(t, ts, "/v1/profile", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing url status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestWorkerStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := New(ctx, WithFetchFunc((&budgetFetch{}).fetch), WithRetryDelay(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := s.enqueue(s.anonymous, "https://github.com/alice", "github")
+	cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		status := job.Status
+		s.mu.Unlock()
+		if status == StatusFailed {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("queued job was not failed when the server's context ended")
+}
+
+func TestTenants(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Every tenant's fetches carry WithTenant, but only acme has cookies, so only its
+	// fetches carry a second option, and its session is rate limited
+	fetch := func(_ context.Context, u string, opts ...sociopath.Option) (*profile.Profile, error) {
+		if len(opts) > 1 {
+			return nil, sociopath.ErrRateLimited
+		}
+		return &profile.Profile{Platform: "linkedin", URL: u}, nil
+	}
+	s, err := New(ctx, WithFetchFunc(fetch), WithRetryDelay(time.Hour), WithTenants([]Tenant{
+		{Name: "acme", APIKey: "acme-key", Cookies: map[string]string{"li_at": "acme"}},
+		{Name: "beta", APIKey: "beta-key"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(s.Handler())
+	defer ts.Close()
+
+	request := func(path, header, key string, v any) int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+path, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header != "" {
+			req.Header.Set(header, key)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close() //nolint:errcheck // test
+		if v != nil {
+			if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+				t.Fatalf("decoding %s: %v", path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+	profilePath := "/v1/profile?url=" + url.QueryEscape("https://www.linkedin.com/in/alice")
+
+	if status := request(profilePath, "", "", nil); status != http.StatusUnauthorized {
+		t.Errorf("no key status = %d, want 401", status)
+	}
+	if status := request(profilePath, "X-API-Key", "wrong", nil); status != http.StatusUnauthorized {
+		t.Errorf("unknown key status = %d, want 401", status)
+	}
+
+	var accepted map[string]string
+	if status := request(profilePath, "X-API-Key", "acme-key", &accepted); status != http.StatusAccepted {
+		t.Fatalf("acme fetch status = %d, want 202", status)
+	}
+	// acme's queue does not hold up beta
+	var p profile.Profile
+	if status := request(profilePath, "Authorization", "Bearer beta-key", &p); status != http.StatusOK || p.URL == "" {
+		t.Errorf("beta fetch = %d, %+v", status, p)
+	}
+
+	var job Job
+	if status := request("/v1/jobs/"+accepted["id"], "Authorization", "Bearer acme-key", &job); status != http.StatusOK || job.Tenant != "acme" {
+		t.Errorf("acme job = %d, %+v", status, job)
+	}
+	if status := request("/v1/jobs/"+accepted["id"], "X-API-Key", "beta-key", nil); status != http.StatusNotFound {
+		t.Errorf("another tenant's job status = %d, want 404", status)
+	}
+}
+
+func TestNewRejectsBadTenants(t *testing.T) {
+	for _, tenants := range [][]Tenant{
+		{{Name: "acme"}},
+		{{APIKey: "k"}},
+		{{Name: "a/b", APIKey: "k"}},
+		{{Name: "acme", APIKey: "k1"}, {Name: "acme", APIKey: "k2"}},
+		{{Name: "acme", APIKey: "k"}, {Name: "beta", APIKey: "k"}},
+		{{Name: "acme", APIKey: "k", Proxy: "not a proxy"}},
+	} {
+		if _, err := New(context.Background(), WithTenants(tenants)); err == nil {
+			t.Errorf("New(%+v) succeeded, want error", tenants)
+		}
+	}
+}
+
+func TestDrainRestore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, err := New(ctx, WithFetchFunc((&budgetFetch{}).fetch), WithRetryDelay(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := s.enqueue(s.anonymous, "https://github.com/alice", "github")
+	second := s.enqueue(s.anonymous, "https://github.com/bob", "github")
+
+	deadline := time.Now().Add(5 * time.Second)
+	// The worker may still be trying the first job; Drain waits for it
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		attempts := first.Attempts
+		s.mu.Unlock()
+		if attempts > 0 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	pending := s.Drain(ctx)
+	if len(pending) != 2 || pending[0].ID != first.ID || pending[1].ID != second.ID {
+		t.Fatalf("Drain() = %+v, want both jobs in order", pending)
+	}
+
+	f := &budgetFetch{budget: 2}
+	restarted, err := New(ctx, WithFetchFunc(f.fetch), WithRetryDelay(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted.Restore(append(pending, Job{ID: "stranger", Tenant: "acme", Platform: "github"}))
+	for _, id := range []string{first.ID, second.ID} {
+		deadline := time.Now().Add(5 * time.Second)
+		var status string
+		for time.Now().Before(deadline) {
+			restarted.mu.Lock()
+			status = restarted.jobs[id].Status
+			restarted.mu.Unlock()
+			if status == StatusDone {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if status != StatusDone {
+			t.Errorf("restored job %s status = %s, want done", id, status)
+		}
+	}
+	restarted.mu.Lock()
+	_, ok := restarted.jobs["stranger"]
+	restarted.mu.Unlock()
+	if ok {
+		t.Error("Restore() kept a job of an unknown tenant")
+	}
+}
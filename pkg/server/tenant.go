@@ -0,0 +1,134 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/sociopath"
+)
+
+// Tenant is an API client of the server with credentials of its own.
+//
+//	[{"name": "acme", "api_key": "...", "cookies": {"li_at": "...", "JSESSIONID": "..."}, "proxy": "http://proxy.acme.example:3128"}]
+type Tenant struct {
+	Cookies map[string]string `json:"cookies,omitempty"` // Session cookies for authenticated platforms
+	Name    string            `json:"name"`
+	APIKey  string            `json:"api_key"`
+	Proxy   string            `json:"proxy,omitempty"` // HTTP proxy for the tenant's LinkedIn requests
+}
+
+// LoadTenants reads a JSON array of tenants from path.
+func LoadTenants(path string) ([]Tenant, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []Tenant
+	if err := json.Unmarshal(data, &tenants); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return tenants, nil
+}
+
+// WithTenants requires every request to carry one of the tenants' API keys, as
+// "Authorization: Bearer <key>" or "X-API-Key: <key>", and makes each tenant's fetches
+// with its own cookies and proxy in place of any set with WithFetchOptions, and against
+// its own quotas and circuit breakers (see sociopath.WithTenant). Cookies
+// from the environment or a browser store would be shared by every tenant without
+// cookies of its own, so the fetch options should not enable them.
+func WithTenants(tenants []Tenant) Option {
+	return func(c *config) { c.tenants = append(c.tenants, tenants...) }
+}
+
+// tenant is a Tenant ready to serve.
+type tenant struct {
+	name      string
+	apiKey    string
+	fetchOpts []sociopath.Option
+}
+
+func newTenant(t *Tenant, base []sociopath.Option) (*tenant, error) {
+	if t.Name == "" || strings.Contains(t.Name, "/") {
+		return nil, fmt.Errorf("tenant %q: name must be set and cannot contain '/'", t.Name)
+	}
+	if t.APIKey == "" {
+		return nil, fmt.Errorf("tenant %s: api_key must be set", t.Name)
+	}
+
+	opts := append([]sociopath.Option{}, base...)
+	opts = append(opts, sociopath.WithTenant(t.Name))
+	if len(t.Cookies) > 0 {
+		opts = append(opts, sociopath.WithCookies(t.Cookies))
+	}
+	if t.Proxy != "" {
+		proxy, err := url.Parse(t.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("tenant %s: invalid proxy %q", t.Name, t.Proxy)
+		}
+		opts = append(opts, sociopath.WithLinkedInProxy(proxy))
+	}
+	return &tenant{name: t.Name, apiKey: t.APIKey, fetchOpts: opts}, nil
+}
+
+// validateTenants checks that tenant names, which jobs and queues are kept under,
+// and API keys are unique.
+func validateTenants(tenants []*tenant) error {
+	names := make(map[string]bool)
+	keys := make(map[string]bool)
+	for _, t := range tenants {
+		if names[t.name] {
+			return fmt.Errorf("tenant %s is configured twice", t.name)
+		}
+		if keys[t.apiKey] {
+			return fmt.Errorf("tenant %s: api_key is already used by another tenant", t.name)
+		}
+		names[t.name] = true
+		keys[t.apiKey] = true
+	}
+	return nil
+}
+
+// tenantNamed returns the tenant called name, or the anonymous tenant for "" if the
+// server has no tenants.
+func (s *Server) tenantNamed(name string) *tenant {
+	if s.tenants == nil {
+		if name == "" {
+			return s.anonymous
+		}
+		return nil
+	}
+	for _, t := range s.tenants {
+		if t.name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// authenticate returns the tenant whose API key r carries, or the anonymous tenant
+// if the server has none.
+func (s *Server) authenticate(r *http.Request) (*tenant, bool) {
+	if s.tenants == nil {
+		return s.anonymous, true
+	}
+	key := r.Header.Get("X-API-Key")
+	if auth := r.Header.Get("Authorization"); key == "" && strings.HasPrefix(auth, "Bearer ") {
+		key = strings.TrimPrefix(auth, "Bearer ")
+	}
+	if key == "" {
+		return nil, false
+	}
+	// Compare against every key in constant time so timing reveals nothing about them
+	var found *tenant
+	for _, t := range s.tenants {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(t.apiKey)) == 1 {
+			found = t
+		}
+	}
+	return found, found != nil
+}
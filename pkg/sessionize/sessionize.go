@@ -0,0 +1,202 @@
+// Package sessionize fetches Sessionize speaker profiles.
+package sessionize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "sessionize"
+
+var usernamePattern = regexp.MustCompile(`(?i)sessionize\.com/([\w.-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are sessionize.com paths that are not speaker pages.
+var nonProfiles = map[string]bool{
+	"app": true, "api": true, "login": true, "register": true, "pricing": true, "blog": true,
+	"playbook": true, "speakers-directory": true, "speaker-directory": true, "events": true,
+	"about": true, "privacy": true, "terms": true, "contact": true, "help": true, "speaker": true,
+}
+
+// Match returns true if the URL is a Sessionize speaker profile URL.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because speaker profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles Sessionize requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Sessionize client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Sessionize speaker profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://sessionize.com/" + username
+	c.logger.InfoContext(ctx, "fetching sessionize profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a Sessionize speaker page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://sessionize.com/"+username, username)
+}
+
+var (
+	namePattern     = regexp.MustCompile(`(?is)<h1[^>]*class="[^"]*c-s-speaker-info__name[^"]*"[^>]*>(.*?)</h1>`)
+	taglinePattern  = regexp.MustCompile(`(?is)<p[^>]*class="[^"]*c-s-speaker-info__tagline[^"]*"[^>]*>(.*?)</p>`)
+	bioPattern      = regexp.MustCompile(`(?is)<div[^>]*class="[^"]*c-s-speaker-info__bio[^"]*"[^>]*>(.*?)</div>`)
+	locationPattern = regexp.MustCompile(`(?is)<p[^>]*class="[^"]*c-s-speaker-info__location[^"]*"[^>]*>(.*?)</p>`)
+	sessionPattern  = regexp.MustCompile(`(?is)<h3[^>]*class="[^"]*(?:c-s-session-list__title|s-title)[^"]*"[^>]*>(.*?)</h3>`)
+	eventPattern    = regexp.MustCompile(`(?is)<h3[^>]*class="[^"]*c-s-session-list__event[^"]*"[^>]*>(.*?)</h3>`)
+	linkPattern     = regexp.MustCompile(`(?i)<a[^>]+class="[^"]*c-s-links__link[^"]*"[^>]+href="([^"]+)"|<a[^>]+href="([^"]+)"[^>]+class="[^"]*c-s-links__link[^"]*"`)
+	tagPattern      = regexp.MustCompile(`<[^>]+>`)
+)
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: username,
+		Fields:   make(map[string]string),
+	}
+
+	if m := namePattern.FindStringSubmatch(content); m != nil {
+		p.Name = clean(m[1])
+	}
+	if p.Name == "" {
+		// Title is "Jane Doe's Speaker Profile @ Sessionize"
+		title := htmlutil.Title(content)
+		if name, _, ok := strings.Cut(title, "'s Speaker Profile"); ok {
+			p.Name = strings.TrimSpace(name)
+		}
+	}
+	if p.Name == "" {
+		return nil, errors.New("profile not found (no speaker name)")
+	}
+
+	if m := taglinePattern.FindStringSubmatch(content); m != nil {
+		p.Fields[profile.FieldHeadline] = clean(m[1])
+	}
+	if m := bioPattern.FindStringSubmatch(content); m != nil {
+		p.Bio = clean(m[1])
+	}
+	if p.Bio == "" {
+		p.Bio = htmlutil.Description(content)
+	}
+	if m := locationPattern.FindStringSubmatch(content); m != nil {
+		p.Location = clean(m[1])
+	}
+
+	// Sessions: each title may be followed by the event it was given at
+	titles := sessionPattern.FindAllStringSubmatchIndex(content, -1)
+	for i, m := range titles {
+		post := profile.Post{Type: profile.PostTypeTalk, Title: clean(content[m[2]:m[3]])}
+		end := len(content)
+		if i+1 < len(titles) {
+			end = titles[i+1][0]
+		}
+		if e := eventPattern.FindStringSubmatch(content[m[1]:end]); e != nil {
+			post.Category = clean(e[1])
+		}
+		if post.Title != "" {
+			p.Posts = append(p.Posts, post)
+		}
+	}
+
+	// The speaker's own links are in c-s-links; they include blogs SocialLinks would miss
+	var found []string
+	for _, m := range linkPattern.FindAllStringSubmatch(content, -1) {
+		href := m[1]
+		if href == "" {
+			href = m[2]
+		}
+		if href = html.UnescapeString(href); strings.HasPrefix(href, "http") {
+			found = append(found, href)
+		}
+	}
+	found = append(found, htmlutil.SocialLinks(content)...)
+	p.SocialLinks = links.Clean(found, Match)
+
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
+
+func clean(s string) string {
+	return htmlutil.CleanText(tagPattern.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,87 @@
+package sessionize
+
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://sessionize.com/jane-doe", true},
+		{"https://sessionize.com/jane-doe/", true},
+		{"sessionize.com/jane.doe?ref=x", true},
+		{"https://sessionize.com/app/speaker", false},
+		{"https://sessionize.com/pricing", false},
+		{"https://sessionize.com/", false},
+		{"https://sessionize.com/jane-doe/sessions/123", false},
+		{"https://example.com/jane-doe", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Sessionize should not require auth")
+	}
+}
+
+const speakerHTML = `<html><head><title>Jane Doe's Speaker Profile @ Sessionize</title></head><body>
+<div class="c-s-speaker-info">
+  <h1 class="c-s-speaker-info__name">Jane Doe</h1>
+  <p class="c-s-speaker-info__tagline">Staff Engineer at Acme</p>
+  <p class="c-s-speaker-info__location">Portland, Oregon, United States</p>
+  <div class="c-s-speaker-info__bio"><p>Jane builds &amp; breaks distributed systems.</p></div>
+  <ul class="c-s-links">
+    <li><a class="c-s-links__link" href="https://twitter.com/janedoe">Twitter</a></li>
+    <li><a href="https://janedoe.dev/" class="c-s-links__link c-s-links__link--blog">Blog</a></li>
+    <li><a class="c-s-links__link" href="https://sessionize.com/jane-doe">Sessionize</a></li>
+  </ul>
+</div>
+<ul class="c-s-session-list">
+  <li><h3 class="c-s-session-list__title">Consensus Without Tears</h3><h3 class="c-s-session-list__event">KubeCon NA 2024</h3></li>
+  <li><h3 class="c-s-session-list__title">Chaos &amp; Calm</h3></li>
+</ul>
+</body></html>`
+
+func TestParseProfile(t *testing.T) {
+	p, err := parseProfile(speakerHTML, "https://sessionize.com/jane-doe", "jane-doe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Location != "Portland, Oregon, United States" {
+		t.Errorf("Name = %q, Location = %q", p.Name, p.Location)
+	}
+	if p.Bio != "Jane builds & breaks distributed systems." {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if got := p.Fields[profile.FieldHeadline]; got != "Staff Engineer at Acme" {
+		t.Errorf("headline = %q", got)
+	}
+	if len(p.Posts) != 2 || p.Posts[0].Title != "Consensus Without Tears" || p.Posts[0].Category != "KubeCon NA 2024" ||
+		p.Posts[1].Title != "Chaos & Calm" || p.Posts[0].Type != profile.PostTypeTalk {
+		t.Errorf("Posts = %+v", p.Posts)
+	}
+	want := map[string]bool{"https://twitter.com/janedoe": true, "https://janedoe.dev/": true}
+	if len(p.SocialLinks) != len(want) {
+		t.Errorf("SocialLinks = %v, want %v", p.SocialLinks, want)
+	}
+	for _, link := range p.SocialLinks {
+		if !want[link] {
+			t.Errorf("unexpected link %q", link)
+		}
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	if _, err := parseProfile(`<html><title>Sessionize</title></html>`, "https://sessionize.com/nobody", "nobody"); err == nil {
+		t.Error("parseProfile(no speaker) should fail")
+	}
+}
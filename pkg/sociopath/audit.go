@@ -0,0 +1,47 @@
+package sociopath
+
+import (
+	"context"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/audit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// AuditLog records the outcome of fetches. *audit.Log implements it.
+type AuditLog interface {
+	Record(e audit.Entry) error
+}
+
+// WithAuditLog records every fetch made, including each URL a crawl follows, to l.
+// Fetches refused before any request (disabled platforms, policy denials, and
+// exhausted quotas) are not recorded.
+func WithAuditLog(l AuditLog) Option {
+	return func(c *config) { c.audit = l }
+}
+
+// recordAudit records the fetch of url that started at start to cfg's audit log.
+func recordAudit(ctx context.Context, cfg *config, url string, start time.Time, p *profile.Profile, err error) {
+	if cfg.audit == nil {
+		return
+	}
+	e := audit.Entry{
+		Time:     start.UTC(),
+		URL:      url,
+		Platform: PlatformForURL(url),
+		Status:   audit.StatusOK,
+		Elapsed:  float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		e.Status = audit.StatusError
+		e.Error = err.Error()
+	}
+	if p != nil {
+		e.Name = p.Name
+		e.Fields = len(p.Fields)
+		e.Links = len(p.SocialLinks)
+	}
+	if err := cfg.audit.Record(e); err != nil {
+		cfg.logger.WarnContext(ctx, "failed to write audit log", "url", url, "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/audit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+type memoryAuditLog struct {
+	entries []audit.Entry
+}
+
+func (m *memoryAuditLog) Record(e audit.Entry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func TestRecordAudit(t *testing.T) {
+	log := &memoryAuditLog{}
+	cfg := &config{audit: log, logger: slog.Default()}
+	start := time.Now()
+
+	p := &profile.Profile{Name: "Alice", Fields: map[string]string{"location": "Berlin"}, SocialLinks: []string{"https://twitter.com/alice"}}
+	recordAudit(context.Background(), cfg, "https://github.com/alice", start, p, nil)
+	recordAudit(context.Background(), cfg, "https://www.linkedin.com/in/bob", start, nil, ErrAuthRequired)
+
+	if len(log.entries) != 2 {
+		t.Fatalf("recorded %d entries, want 2", len(log.entries))
+	}
+	ok, failed := log.entries[0], log.entries[1]
+	if ok.Platform != "github" || ok.Status != audit.StatusOK || ok.Name != "Alice" || ok.Fields != 1 || ok.Links != 1 || ok.Failed() {
+		t.Errorf("ok entry = %+v", ok)
+	}
+	if failed.Platform != "linkedin" || failed.Status != audit.StatusError || failed.Error != ErrAuthRequired.Error() || !failed.Failed() {
+		t.Errorf("failed entry = %+v", failed)
+	}
+
+	// Fetches refused before any request are not audited
+	deny := policy.DeciderFunc(func(context.Context, policy.Request) (policy.Decision, error) { return policy.Deny("no"), nil })
+	log.entries = nil
+	if _, err := Fetch(context.Background(), "https://github.com/alice", WithAuditLog(log), WithPolicy(deny)); !errors.Is(err, policy.ErrDenied) {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(log.entries) != 0 {
+		t.Errorf("denied fetch was audited: %+v", log.entries)
+	}
+}
@@ -0,0 +1,656 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/blob"
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/instagram"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkedin"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+	"github.com/codeGROOVE-dev/sociopath/pkg/tiktok"
+	"github.com/codeGROOVE-dev/sociopath/pkg/twitter"
+	"github.com/codeGROOVE-dev/sociopath/pkg/vkontakte"
+)
+
+// Crawl limits.
+const (
+	maxCrawlDepth   = 3
+	maxLinksPerPage = 8
+
+	defaultCrawlIdle   = 30 * time.Second
+	sharedPollInterval = time.Second
+)
+
+// ErrRunExists is returned by Crawler.Start for a run ID that already has state.
+var ErrRunExists = errors.New("crawl run already exists")
+
+// ErrUnknownRun is returned by Crawler.Resume for a run ID with no saved state.
+var ErrUnknownRun = errors.New("unknown crawl run")
+
+// ErrInterrupted is returned by crawls that Crawler.Stop stopped.
+var ErrInterrupted = errors.New("crawl interrupted")
+
+// Crawler runs recursive crawls like FetchRecursive, but keeps the frontier, visited
+// set, and fetched profiles in a store.Store under a run ID. A run that is interrupted,
+// by a crash, a platform's cookies expiring, or a politeness limit (see
+// cache.SetPoliteness), can be continued with Resume.
+//
+// When the store is a store.Shared, such as store.Redis, any number of processes can
+// crawl a run together: one calls Start, and the others call Resume to join it. Each
+// takes URLs off the shared frontier in turn, and all of them stop once it has been
+// empty for the WithCrawlIdle timeout. Quotas are counted in the store, so they hold
+// across processes; a process that crashes mid-fetch loses the URL it was fetching.
+type Crawler struct {
+	store    store.Store
+	stop     chan struct{}
+	opts     []Option
+	stopOnce sync.Once
+}
+
+// NewCrawler returns a Crawler that keeps state in st and fetches with opts.
+func NewCrawler(st store.Store, opts ...Option) *Crawler {
+	return &Crawler{store: st, opts: opts, stop: make(chan struct{})}
+}
+
+// Stop makes the crawler's runs stop once the fetch in flight is saved, returning
+// ErrInterrupted along with the profiles fetched so far. The rest of the frontier
+// stays in the store for Resume. Unlike canceling the context, no fetch is lost.
+// Runs started after Stop stop before fetching anything.
+func (c *Crawler) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+// Start begins a new run crawling from url and returns all profiles it fetched.
+// If the run stops early, it returns the profiles fetched so far along with the error.
+func (c *Crawler) Start(ctx context.Context, runID, url string) ([]*profile.Profile, error) {
+	frontier, err := c.store.Frontier(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := c.store.Profiles(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if len(frontier) > 0 || len(saved) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrRunExists, runID)
+	}
+	if err := c.store.PushFrontier(ctx, runID, store.FrontierItem{URL: url}); err != nil {
+		return nil, err
+	}
+	return c.run(ctx, runID)
+}
+
+// Resume reloads the frontier and visited set of runID and continues crawling.
+// It returns all profiles fetched by the run, including those from earlier attempts.
+// If the run stops early, as when quotas defer some URLs (ErrQuotaExceeded), Stop is
+// called, or ctx is canceled, it returns the profiles so far along with the error, and
+// the URLs left stay queued for the next Resume.
+func (c *Crawler) Resume(ctx context.Context, runID string) ([]*profile.Profile, error) {
+	frontier, err := c.store.Frontier(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := c.store.Profiles(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if len(frontier) == 0 && len(saved) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRun, runID)
+	}
+	return c.run(ctx, runID)
+}
+
+func (c *Crawler) run(ctx context.Context, runID string) ([]*profile.Profile, error) {
+	// Quotas are counted in the store so they hold across runs; explicit options win
+	opts := append([]Option{WithQuotaStore(c.store)}, c.opts...)
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	cfg.stop = c.stop
+	var st crawlState = &storeState{store: c.store, runID: runID}
+	if _, ok := c.store.(store.Shared); ok {
+		idle := cfg.crawlIdle
+		if idle <= 0 {
+			idle = defaultCrawlIdle
+		}
+		shared := &sharedState{storeState: storeState{store: c.store, runID: runID}, idle: idle}
+		defer func() {
+			// Hand the URL being fetched when the crawl stopped back to the other processes
+			if rerr := shared.release(context.WithoutCancel(ctx)); rerr != nil {
+				cfg.logger.WarnContext(ctx, "failed to requeue url", "error", rerr)
+			}
+		}()
+		st = shared
+	}
+	err := crawl(ctx, cfg, opts, st)
+	// Partial results are still worth reporting when ctx is what stopped the crawl
+	profiles, perr := c.store.Profiles(context.WithoutCancel(ctx), runID)
+	if perr != nil {
+		return nil, errors.Join(err, perr)
+	}
+	return finishCrawl(ctx, cfg, profiles), err
+}
+
+type queueItem struct {
+	url   string
+	depth int
+}
+
+// crawlState holds a crawl's frontier, visited set, and results.
+type crawlState interface {
+	// next returns the item at the head of the frontier without removing it.
+	next(ctx context.Context) (queueItem, bool, error)
+	// advance removes the head of the frontier and appends children.
+	advance(ctx context.Context, children []queueItem) error
+	// requeue keeps deferred items for a later crawl, if the state outlives this one.
+	requeue(ctx context.Context, items []queueItem) error
+	// pending returns how many items the frontier holds.
+	pending(ctx context.Context) (int, error)
+	isVisited(ctx context.Context, url string) (bool, error)
+	markVisited(ctx context.Context, url string) error
+	add(ctx context.Context, p *profile.Profile) error
+	results(ctx context.Context) ([]*profile.Profile, error)
+	// resumable reports whether the state outlives the crawl. Resumable crawls stop on
+	// expired cookies, politeness limits, and cancellation, leaving the current item
+	// in the frontier.
+	resumable() bool
+}
+
+// crawl runs a breadth-first crawl until the frontier in st is empty.
+//
+//nolint:gocognit,revive,nonamedreturns // crawl policy has many small, independent rules; err is extended on return
+func crawl(ctx context.Context, cfg *config, opts []Option, st crawlState) (err error) {
+	ctx = modeContext(ctx, cfg)
+	// Profiles already in the state come from an earlier, interrupted attempt
+	saved, err := st.results(ctx)
+	if err != nil {
+		return err
+	}
+	initialPlatform := "" // Track the platform we started from
+	authenticated := make(map[string]bool)
+	if len(saved) > 0 {
+		initialPlatform = saved[0].Platform
+	}
+	for _, p := range saved {
+		authenticated[p.Platform] = authenticated[p.Platform] || p.Authenticated
+	}
+
+	fetched := 0
+	progress := newProgressTracker(cfg)
+	// Deferred items have left the frontier, so they go back on every return, even
+	// one for cancellation, or they would be lost to a resumed crawl
+	var deferred []queueItem
+	defer func() {
+		if len(deferred) == 0 {
+			return
+		}
+		if rerr := st.requeue(context.WithoutCancel(ctx), deferred); rerr != nil {
+			err = errors.Join(err, fmt.Errorf("requeueing %d deferred URLs: %w", len(deferred), rerr))
+		}
+	}()
+	for {
+		select {
+		case <-cfg.stop:
+			return ErrInterrupted
+		default:
+		}
+		item, ok, err := st.next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+
+		normalizedURL := normalizeURL(item.url)
+		seen, err := st.isVisited(ctx, normalizedURL)
+		if err != nil {
+			return err
+		}
+		if seen {
+			if err := st.advance(ctx, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// The seed is always fetched; links already covered by an earlier run are not
+		if cfg.visited != nil && item.depth > 0 && cfg.visited.Contains(normalizedURL) {
+			cfg.logger.DebugContext(ctx, "skipping previously visited url", "url", item.url)
+			if err := st.markVisited(ctx, normalizedURL); err != nil {
+				return err
+			}
+			if err := st.advance(ctx, nil); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fetched++
+		cfg.logger.InfoContext(ctx, "fetching profile", "url", item.url, "depth", item.depth, "fetched", fetched)
+
+		p, err := Fetch(ctx, item.url, opts...)
+		if errors.Is(err, ErrQuotaExceeded) {
+			// Not visited: the URL is fetched once the quota allows
+			cfg.logger.WarnContext(ctx, "deferring url over quota", "url", item.url, "error", err)
+			deferred = append(deferred, item)
+			if err := st.advance(ctx, nil); err != nil {
+				return err
+			}
+			progress.record(ctx, st, item.url, outcomeSkipped)
+			continue
+		}
+		if errors.Is(err, ErrDisabled) || errors.Is(err, policy.ErrDenied) {
+			cfg.logger.DebugContext(ctx, "skipping url", "url", item.url, "error", err)
+			if err := finishItem(ctx, cfg, st, normalizedURL, nil); err != nil {
+				return err
+			}
+			progress.record(ctx, st, item.url, outcomeSkipped)
+			continue
+		}
+		if err != nil && st.resumable() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Politeness limits lift later (next window or day); stop and resume then
+			if errors.Is(err, cache.ErrPolitenessLimit) {
+				return err
+			}
+			// Auth errors on a platform this run has fetched with cookies before mean the
+			// session expired; stop so the run can resume once cookies are refreshed
+			platform := PlatformForURL(item.url)
+			if authenticated[platform] && (errors.Is(err, profile.ErrNoCookies) || errors.Is(err, profile.ErrAuthRequired)) {
+				return fmt.Errorf("%s session expired at %s: %w", platform, item.url, err)
+			}
+		}
+		if err != nil {
+			// For auth-required platforms, try generic parser on any error (except LinkedIn)
+			// LinkedIn's generic HTML contains dozens of "People Also Viewed" links that cause runaway crawling
+			tryGeneric := (twitter.Match(item.url) || instagram.Match(item.url) ||
+				tiktok.Match(item.url) || vkontakte.Match(item.url)) && !linkedin.Match(item.url)
+
+			if !tryGeneric {
+				cfg.logger.WarnContext(ctx, "failed to fetch profile", "url", item.url, "error", err)
+				// If it's an auth-related error, add a stub profile with the error
+				if errors.Is(err, profile.ErrNoCookies) || errors.Is(err, profile.ErrAuthRequired) {
+					if err := st.add(ctx, &profile.Profile{
+						Platform: PlatformForURL(item.url),
+						URL:      item.url,
+						Error:    "login required",
+					}); err != nil {
+						return err
+					}
+				}
+				if err := finishItem(ctx, cfg, st, normalizedURL, nil); err != nil {
+					return err
+				}
+				progress.record(ctx, st, item.url, outcomeFailed)
+				continue
+			}
+
+			cfg.logger.InfoContext(ctx, "fetch failed, trying generic parser", "url", item.url, "error", err)
+			p, err = fetchGeneric(ctx, item.url, cfg)
+			if err != nil {
+				cfg.logger.WarnContext(ctx, "generic fetch also failed", "url", item.url, "error", err)
+				if err := finishItem(ctx, cfg, st, normalizedURL, nil); err != nil {
+					return err
+				}
+				progress.record(ctx, st, item.url, outcomeFailed)
+				continue
+			}
+		}
+		if err := addProfile(ctx, cfg, st, p); err != nil {
+			return err
+		}
+		authenticated[p.Platform] = authenticated[p.Platform] || p.Authenticated
+		if cfg.graph != nil {
+			recordLinks(cfg.graph, normalizedURL, item.url, p)
+		}
+
+		// Remember the platform we started from (depth 0)
+		if item.depth == 0 {
+			initialPlatform = p.Platform
+		}
+
+		// Don't crawl further if we've hit max depth
+		if item.depth >= maxCrawlDepth {
+			if err := finishItem(ctx, cfg, st, normalizedURL, nil); err != nil {
+				return err
+			}
+			progress.record(ctx, st, item.url, outcomeFetched)
+			continue
+		}
+
+		links, err := linksToFollow(ctx, cfg, st, p, item.url, normalizedURL, initialPlatform)
+		if err != nil {
+			return err
+		}
+		children := make([]queueItem, 0, len(links))
+		for _, link := range links {
+			children = append(children, queueItem{url: link, depth: item.depth + 1})
+		}
+		if err := finishItem(ctx, cfg, st, normalizedURL, children); err != nil {
+			return err
+		}
+		progress.record(ctx, st, item.url, outcomeFetched)
+	}
+
+	if len(deferred) == 0 {
+		return nil
+	}
+	if !st.resumable() {
+		cfg.logger.WarnContext(ctx, "skipped urls over quota", "count", len(deferred))
+		return nil
+	}
+	return fmt.Errorf("%w: %d URLs deferred", ErrQuotaExceeded, len(deferred))
+}
+
+// addProfile adds p to the crawl's results, with its long text moved to the blob
+// store if there is one. p keeps its text for following its links.
+func addProfile(ctx context.Context, cfg *config, st crawlState, p *profile.Profile) error {
+	if cfg.blobs != nil {
+		small, err := blob.Offload(ctx, cfg.blobs, p, cfg.blobMinSize)
+		if err != nil {
+			return err
+		}
+		p = small
+	}
+	return st.add(ctx, p)
+}
+
+// finishItem marks the head of the frontier as visited and replaces it with children.
+func finishItem(ctx context.Context, cfg *config, st crawlState, normalizedURL string, children []queueItem) error {
+	if err := st.markVisited(ctx, normalizedURL); err != nil {
+		return err
+	}
+	if cfg.visited != nil {
+		cfg.visited.Add(normalizedURL)
+	}
+	return st.advance(ctx, children)
+}
+
+// linksToFollow returns the links on p, fetched from pageURL, that the crawl should queue.
+func linksToFollow(
+	ctx context.Context, cfg *config, st crawlState, p *profile.Profile, pageURL, normalizedURL, initialPlatform string,
+) ([]string, error) {
+	var firstErr error
+	unvisited := func(link string) bool {
+		if normalizeURL(link) == normalizedURL {
+			return false
+		}
+		seen, err := st.isVisited(ctx, normalizeURL(link))
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		return err == nil && !seen
+	}
+
+	// From generic pages, only follow known social platform links to avoid runaway crawling
+	onlyKnownPlatforms := p.Platform == "generic"
+
+	// Collect links to queue, then limit
+	var linksToQueue []string
+
+	// Queue social links for crawling
+	for _, link := range p.SocialLinks {
+		if unvisited(link) && isValidProfileURL(link) {
+			// Skip links that are the same platform as our initial URL (single-account-per-person platforms)
+			if isSingleAccountPlatform(initialPlatform) && platformMatches(link, initialPlatform) {
+				continue
+			}
+
+			// For generic pages, only follow if it's a known social platform or same-domain contact/about page
+			// (contact pages are skipped at minimal depth)
+			contactPage := cfg.depth != profile.DepthMinimal && isSameDomainContactPage(link, pageURL)
+			if !onlyKnownPlatforms || isSocialPlatform(link) || contactPage {
+				linksToQueue = append(linksToQueue, link)
+			}
+		}
+	}
+
+	// Also queue website if present
+	if p.Website != "" && unvisited(p.Website) {
+		linksToQueue = append(linksToQueue, p.Website)
+	}
+
+	// Queue links from Fields map (sorted for deterministic iteration order)
+	keys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := p.Fields[k]
+		if isLikelySocialURL(k, v) && unvisited(v) {
+			linksToQueue = append(linksToQueue, v)
+		}
+	}
+
+	// Limit links per page to avoid explosion
+	if len(linksToQueue) > maxLinksPerPage {
+		linksToQueue = linksToQueue[:maxLinksPerPage]
+	}
+	return linksToQueue, firstErr
+}
+
+// memoryState is the in-memory crawl state used by FetchRecursive.
+type memoryState struct {
+	visited  map[string]bool
+	queue    []queueItem
+	profiles []*profile.Profile
+}
+
+func (s *memoryState) next(context.Context) (queueItem, bool, error) {
+	if len(s.queue) == 0 {
+		return queueItem{}, false, nil
+	}
+	return s.queue[0], true, nil
+}
+
+func (s *memoryState) advance(_ context.Context, children []queueItem) error {
+	s.queue = append(s.queue[1:], children...)
+	return nil
+}
+
+func (*memoryState) requeue(context.Context, []queueItem) error {
+	return nil
+}
+
+func (s *memoryState) pending(context.Context) (int, error) {
+	return len(s.queue), nil
+}
+
+func (s *memoryState) isVisited(_ context.Context, url string) (bool, error) {
+	return s.visited[url], nil
+}
+
+func (s *memoryState) markVisited(_ context.Context, url string) error {
+	s.visited[url] = true
+	return nil
+}
+
+func (s *memoryState) add(_ context.Context, p *profile.Profile) error {
+	s.profiles = append(s.profiles, p)
+	return nil
+}
+
+func (s *memoryState) results(context.Context) ([]*profile.Profile, error) {
+	return s.profiles, nil
+}
+
+func (*memoryState) resumable() bool { return false }
+
+// storeState keeps crawl state for one run in a store.Store. The frontier is read
+// once, then mirrored in memory: every change is made to the store and the mirror
+// together, so no step rereads the whole frontier.
+type storeState struct {
+	store    store.Store
+	runID    string
+	frontier []queueItem // Mirror of the store's frontier, once loaded
+	loaded   bool
+}
+
+// load reads the frontier from the store, the first time it is called.
+func (s *storeState) load(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+	frontier, err := s.store.Frontier(ctx, s.runID)
+	if err != nil {
+		return err
+	}
+	s.frontier = make([]queueItem, 0, len(frontier))
+	for _, it := range frontier {
+		s.frontier = append(s.frontier, queueItem{url: it.URL, depth: it.Depth})
+	}
+	s.loaded = true
+	return nil
+}
+
+func (s *storeState) next(ctx context.Context) (queueItem, bool, error) {
+	if err := s.load(ctx); err != nil || len(s.frontier) == 0 {
+		return queueItem{}, false, err
+	}
+	return s.frontier[0], true, nil
+}
+
+func (s *storeState) advance(ctx context.Context, children []queueItem) error {
+	// Queue children before popping the head, so an interruption in between
+	// repeats the head instead of losing its links
+	if err := s.requeue(ctx, children); err != nil {
+		return err
+	}
+	if _, _, err := s.store.PopFrontier(ctx, s.runID); err != nil {
+		return err
+	}
+	s.frontier = s.frontier[1:]
+	return nil
+}
+
+func (s *storeState) requeue(ctx context.Context, items []queueItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := s.load(ctx); err != nil {
+		return err
+	}
+	if err := s.push(ctx, items); err != nil {
+		return err
+	}
+	s.frontier = append(s.frontier, items...)
+	return nil
+}
+
+// push appends items to the store's frontier.
+func (s *storeState) push(ctx context.Context, items []queueItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	frontier := make([]store.FrontierItem, 0, len(items))
+	for _, it := range items {
+		frontier = append(frontier, store.FrontierItem{URL: it.url, Depth: it.depth})
+	}
+	return s.store.PushFrontier(ctx, s.runID, frontier...)
+}
+
+func (s *storeState) pending(ctx context.Context) (int, error) {
+	err := s.load(ctx)
+	return len(s.frontier), err
+}
+
+func (s *storeState) isVisited(ctx context.Context, url string) (bool, error) {
+	return s.store.Visited(ctx, s.runID, url)
+}
+
+func (s *storeState) markVisited(ctx context.Context, url string) error {
+	return s.store.MarkVisited(ctx, s.runID, url)
+}
+
+func (s *storeState) add(ctx context.Context, p *profile.Profile) error {
+	return s.store.SaveProfile(ctx, s.runID, p)
+}
+
+func (s *storeState) results(ctx context.Context) ([]*profile.Profile, error) {
+	return s.store.Profiles(ctx, s.runID)
+}
+
+func (*storeState) resumable() bool { return true }
+
+// sharedState keeps crawl state for a run that other processes crawl at the same
+// time. Items are popped off the frontier when taken, so that no other process takes
+// them too.
+type sharedState struct {
+	current *queueItem // Popped but not yet advanced past
+	storeState
+	idle time.Duration
+}
+
+func (s *sharedState) next(ctx context.Context) (queueItem, bool, error) {
+	if s.current != nil {
+		return *s.current, true, nil
+	}
+	deadline := time.Now().Add(s.idle)
+	for {
+		it, ok, err := s.store.PopFrontier(ctx, s.runID)
+		if err != nil {
+			return queueItem{}, false, err
+		}
+		if ok {
+			s.current = &queueItem{url: it.URL, depth: it.Depth}
+			return *s.current, true, nil
+		}
+		// Other processes may still queue links from the pages they are fetching
+		wait := min(sharedPollInterval, time.Until(deadline))
+		if wait <= 0 {
+			return queueItem{}, false, nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return queueItem{}, false, ctx.Err()
+		}
+	}
+}
+
+func (s *sharedState) advance(ctx context.Context, children []queueItem) error {
+	if err := s.push(ctx, children); err != nil {
+		return err
+	}
+	s.current = nil
+	return nil
+}
+
+// requeue appends items to the frontier. Other processes change it too, so it is
+// not mirrored.
+func (s *sharedState) requeue(ctx context.Context, items []queueItem) error {
+	return s.push(ctx, items)
+}
+
+func (s *sharedState) pending(ctx context.Context) (int, error) {
+	frontier, err := s.store.Frontier(ctx, s.runID)
+	return len(frontier), err
+}
+
+// release returns the item taken but not finished, if any, to the frontier.
+func (s *sharedState) release(ctx context.Context) error {
+	if s.current == nil {
+		return nil
+	}
+	if err := s.push(ctx, []queueItem{*s.current}); err != nil {
+		return err
+	}
+	s.current = nil
+	return nil
+}
@@ -0,0 +1,280 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/blob"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis/redistest"
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+)
+
+func TestCrawlerResume(t *testing.T) {
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS() error = %v", err)
+	}
+	c := NewCrawler(st)
+
+	if _, err := c.Resume(context.Background(), "missing"); !errors.Is(err, ErrUnknownRun) {
+		t.Errorf("Resume() of unknown run error = %v, want ErrUnknownRun", err)
+	}
+
+	// An interrupted run keeps its current item in the frontier
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Start(ctx, "run1", "https://example.com/"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Start() with canceled context error = %v, want context.Canceled", err)
+	}
+	frontier, err := st.Frontier(context.Background(), "run1")
+	if err != nil {
+		t.Fatalf("Frontier() error = %v", err)
+	}
+	if len(frontier) != 1 || frontier[0].URL != "https://example.com/" {
+		t.Errorf("frontier after interruption = %+v, want the seed", frontier)
+	}
+
+	if _, err := c.Start(context.Background(), "run1", "https://example.com/"); !errors.Is(err, ErrRunExists) {
+		t.Errorf("Start() of existing run error = %v, want ErrRunExists", err)
+	}
+}
+
+func TestStoreStateAdvance(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFS() error = %v", err)
+	}
+	if err := st.PushFrontier(ctx, "run", store.FrontierItem{URL: "a"}, store.FrontierItem{URL: "b", Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+	counted := &frontierCounter{Store: st}
+	s := &storeState{store: counted, runID: "run"}
+
+	item, ok, err := s.next(ctx)
+	if err != nil || !ok || item.url != "a" {
+		t.Fatalf("next() = %+v, %v, %v; want a", item, ok, err)
+	}
+	if err := s.advance(ctx, []queueItem{{url: "c", depth: 1}}); err != nil {
+		t.Fatalf("advance() error = %v", err)
+	}
+	frontier, err := st.Frontier(ctx, "run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frontier) != 2 || frontier[0].URL != "b" || frontier[1].URL != "c" {
+		t.Errorf("frontier = %+v, want [b c]", frontier)
+	}
+
+	// The rest of the crawl works from the frontier read at the start
+	var walked []string
+	for {
+		item, ok, err := s.next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+		walked = append(walked, item.url)
+		if n, err := s.pending(ctx); err != nil || n != 3-len(walked) {
+			t.Errorf("pending() = %d, %v; want %d", n, err, 3-len(walked))
+		}
+		if err := s.advance(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(walked) != 2 || walked[0] != "b" || walked[1] != "c" {
+		t.Errorf("walked %v, want [b c]", walked)
+	}
+	if counted.reads != 1 {
+		t.Errorf("read the whole frontier %d times, want once", counted.reads)
+	}
+	if rest, _ := st.Frontier(ctx, "run"); len(rest) != 0 { //nolint:errcheck // checked by length
+		t.Errorf("store frontier = %+v, want empty", rest)
+	}
+}
+
+// frontierCounter counts how often the whole frontier is read from a store.
+type frontierCounter struct {
+	store.Store
+	reads int
+}
+
+func (f *frontierCounter) Frontier(ctx context.Context, runID string) ([]store.FrontierItem, error) {
+	f.reads++
+	return f.Store.Frontier(ctx, runID)
+}
+
+func TestAddProfileOffloads(t *testing.T) {
+	ctx := context.Background()
+	blobs, err := blob.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config{blobs: blobs, blobMinSize: 10}
+	st := &memoryState{}
+	p := &profile.Profile{URL: "https://alice.dev/", Unstructured: "a long personal page"}
+	if err := addProfile(ctx, cfg, st, p); err != nil {
+		t.Fatalf("addProfile() error = %v", err)
+	}
+	if p.Unstructured == "" {
+		t.Error("addProfile() took the text from the profile being crawled")
+	}
+	saved := st.profiles[0]
+	if saved.Unstructured != "" || saved.UnstructuredRef != blob.Ref([]byte(p.Unstructured)) {
+		t.Errorf("saved profile = %+v, want the text replaced by its reference", saved)
+	}
+}
+
+func TestLinksToFollow(t *testing.T) {
+	st := &memoryState{visited: map[string]bool{"twitter.com/alice": true}}
+	p := &profile.Profile{
+		Platform: "mastodon",
+		SocialLinks: []string{
+			"https://mastodon.social/@alice", // the page itself
+			"https://twitter.com/alice",      // already visited
+			"https://github.com/alice",       // same platform as the seed
+			"https://bsky.app/profile/alice.bsky.social",
+		},
+		Website: "https://alice.dev",
+	}
+	cfg := &config{}
+
+	got, err := linksToFollow(context.Background(), cfg, st, p,
+		"https://mastodon.social/@alice", "mastodon.social/@alice", "github")
+	if err != nil {
+		t.Fatalf("linksToFollow() error = %v", err)
+	}
+	want := []string{"https://bsky.app/profile/alice.bsky.social", "https://alice.dev"}
+	if len(got) != len(want) {
+		t.Fatalf("linksToFollow() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("linksToFollow()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSharedState(t *testing.T) {
+	ctx := context.Background()
+	srv, err := redistest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close() //nolint:errcheck // test
+	client, err := redis.New(srv.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := store.NewRedis(client)
+	if err := st.PushFrontier(ctx, "run", store.FrontierItem{URL: "a"}, store.FrontierItem{URL: "b", Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+	s1 := &sharedState{storeState: storeState{store: st, runID: "run"}, idle: 10 * time.Millisecond}
+	s2 := &sharedState{storeState: storeState{store: st, runID: "run"}, idle: 10 * time.Millisecond}
+
+	// Each process gets its own item and keeps it until it advances
+	if item, ok, err := s1.next(ctx); err != nil || !ok || item.url != "a" {
+		t.Fatalf("s1.next() = %+v, %v, %v; want a", item, ok, err)
+	}
+	if item, ok, err := s1.next(ctx); err != nil || !ok || item.url != "a" {
+		t.Errorf("s1.next() again = %+v, %v, %v; want a", item, ok, err)
+	}
+	if item, ok, err := s2.next(ctx); err != nil || !ok || item.url != "b" {
+		t.Fatalf("s2.next() = %+v, %v, %v; want b", item, ok, err)
+	}
+	if err := s1.advance(ctx, []queueItem{{url: "c", depth: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s2.release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	frontier, err := st.Frontier(ctx, "run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frontier) != 2 || frontier[0].URL != "c" || frontier[1].URL != "b" {
+		t.Errorf("frontier = %+v, want [c b]", frontier)
+	}
+
+	for range 2 {
+		if _, _, err := s1.next(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := s1.advance(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok, err := s1.next(ctx); ok || err != nil {
+		t.Errorf("next() on a frontier empty past the idle timeout = %v, %v", ok, err)
+	}
+}
+
+func TestCrawlerSharedInterrupted(t *testing.T) {
+	srv, err := redistest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close() //nolint:errcheck // test
+	client, err := redis.New(srv.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := store.NewRedis(client)
+
+	// The seed taken off the shared frontier goes back when the crawl is canceled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cancelFirst := policy.DeciderFunc(func(context.Context, policy.Request) (policy.Decision, error) {
+		cancel()
+		return policy.Decision{}, context.Canceled
+	})
+	c := NewCrawler(st, WithCrawlIdle(10*time.Millisecond), WithPolicy(cancelFirst))
+	if _, err := c.Start(ctx, "run1", "https://example.com/"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Start() with canceled context error = %v, want context.Canceled", err)
+	}
+	frontier, err := st.Frontier(context.Background(), "run1")
+	if err != nil {
+		t.Fatalf("Frontier() error = %v", err)
+	}
+	if len(frontier) != 1 || frontier[0].URL != "https://example.com/" {
+		t.Errorf("frontier after interruption = %+v, want the seed", frontier)
+	}
+}
+
+func TestCrawlerStop(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := &profile.Profile{Platform: "github", URL: "https://github.com/alice"}
+	if err := st.SaveProfile(ctx, "run1", saved); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.PushFrontier(ctx, "run1", store.FrontierItem{URL: "https://example.com/", Depth: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A stopped crawler fetches nothing more but reports what the run has so far
+	c := NewCrawler(st)
+	c.Stop()
+	c.Stop()
+	profiles, err := c.Resume(ctx, "run1")
+	if !errors.Is(err, ErrInterrupted) {
+		t.Errorf("Resume() after Stop() error = %v, want ErrInterrupted", err)
+	}
+	if len(profiles) != 1 || profiles[0].URL != saved.URL {
+		t.Errorf("Resume() after Stop() profiles = %+v, want the saved profile", profiles)
+	}
+	if frontier, _ := st.Frontier(ctx, "run1"); len(frontier) != 1 { //nolint:errcheck // checked by length
+		t.Errorf("frontier after Stop() = %+v, want it untouched", frontier)
+	}
+}
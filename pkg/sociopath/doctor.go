@@ -0,0 +1,177 @@
+package sociopath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/registry"
+)
+
+// Canary is a known profile whose data should always extract, so a failure points at
+// sociopath or its credentials rather than at the profile.
+type Canary struct {
+	URL string `json:"url"`
+	// Expect lists the data that must be extracted, named as registry.Data* names
+	// it ("name", "bio", "created_at", ...), or a Fields key.
+	Expect []string `json:"expect"`
+	Auth   bool     `json:"auth,omitempty"` // The expected data needs login cookies
+}
+
+// DefaultCanaries are stable profiles on the major platforms, mostly accounts the
+// maintainers control, and the ones the live integration tests check.
+var DefaultCanaries = []Canary{
+	{URL: "https://github.com/tstromberg", Expect: []string{"name", "created_at"}},
+	{URL: "https://triangletoot.party/@thomrstrom", Expect: []string{"name", "bio", "created_at"}},
+	{URL: "https://dev.to/tstromberg", Expect: []string{"name", "created_at"}},
+	{URL: "https://bsky.app/profile/bsky.app", Expect: []string{"name", "created_at"}},
+	{URL: "https://old.reddit.com/user/medyagh", Expect: []string{"name"}},
+	{URL: "https://stackoverflow.com/users/22656/jon-skeet", Expect: []string{"name"}},
+	{URL: "https://youtube.com/@veritasium", Expect: []string{"name"}},
+	{URL: "https://medium.com/@ev", Expect: []string{"name"}},
+	{URL: "https://linktr.ee/m0nad", Expect: []string{"name"}},
+	{URL: "https://paulabartabajo.substack.com/", Expect: []string{"name"}},
+	{URL: "https://x.com/elonmusk", Expect: []string{"name"}, Auth: true},
+	{URL: "https://www.linkedin.com/in/mattmoor", Expect: []string{"name"}, Auth: true},
+}
+
+// LoadCanaries reads a JSON array of canaries from path.
+func LoadCanaries(path string) ([]Canary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var canaries []Canary
+	if err := json.Unmarshal(data, &canaries); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return canaries, nil
+}
+
+// Health statuses, from best to worst.
+const (
+	HealthOK          = "ok"
+	HealthRateLimited = "rate_limited" // Try again later
+	HealthNotFound    = "not_found"    // The canary is gone; the canary list needs updating
+	HealthAuth        = "auth"         // Cookies are missing, expired, or rejected
+	HealthIncomplete  = "incomplete"   // Fetched, but expected data is missing: the extractor is likely broken
+	HealthError       = "error"
+)
+
+var healthRank = map[string]int{
+	HealthOK: 0, HealthRateLimited: 1, HealthNotFound: 2, HealthAuth: 3, HealthIncomplete: 4, HealthError: 5,
+}
+
+// Health is the result of fetching one canary.
+type Health struct {
+	Platform      string   `json:"platform"`
+	URL           string   `json:"url"`
+	Status        string   `json:"status"`
+	Missing       []string `json:"missing,omitempty"`
+	Error         string   `json:"error,omitempty"`
+	Authenticated bool     `json:"authenticated"`
+	Elapsed       float64  `json:"elapsed_ms"`
+}
+
+// Diagnose fetches each canary and reports which extractors return complete data.
+// Each platform's worst result is recorded for registry.Capabilities. Canaries
+// should be fetched live: pass no HTTP cache, or a stale cached page will hide a
+// broken extractor.
+func Diagnose(ctx context.Context, canaries []Canary, opts ...Option) []Health {
+	results := make([]Health, 0, len(canaries))
+	for _, c := range canaries {
+		start := time.Now()
+		p, err := Fetch(ctx, c.URL, opts...)
+		h := diagnose(c, p, err)
+		h.Elapsed = float64(time.Since(start).Microseconds()) / 1000
+		results = append(results, h)
+	}
+	recordHealth(results, time.Now())
+	return results
+}
+
+// recordHealth records each platform's worst result in the registry.
+func recordHealth(results []Health, now time.Time) {
+	worst := make(map[string]string)
+	for _, h := range results {
+		if s, ok := worst[h.Platform]; !ok || healthRank[h.Status] > healthRank[s] {
+			worst[h.Platform] = h.Status
+		}
+	}
+	for platform, status := range worst {
+		registry.RecordHealth(platform, status, now)
+	}
+}
+
+// diagnose classifies the outcome of fetching canary c.
+func diagnose(c Canary, p *profile.Profile, err error) Health {
+	h := Health{Platform: PlatformForURL(c.URL), URL: c.URL, Status: HealthOK}
+	if p != nil {
+		h.Authenticated = p.Authenticated
+		for _, want := range c.Expect {
+			if !hasData(p, want) {
+				h.Missing = append(h.Missing, want)
+			}
+		}
+	}
+	if err != nil {
+		h.Error = err.Error()
+	}
+	switch {
+	case errors.Is(err, profile.ErrAuthRequired), errors.Is(err, profile.ErrNoCookies):
+		h.Status = HealthAuth
+	case errors.Is(err, profile.ErrRateLimited):
+		h.Status = HealthRateLimited
+	case errors.Is(err, profile.ErrProfileNotFound):
+		h.Status = HealthNotFound
+	case err != nil && p == nil:
+		h.Status = HealthError
+	case len(h.Missing) > 0 && c.Auth && !h.Authenticated:
+		// Platforms serve logged-out visitors a stub, so blame the cookies first
+		h.Status = HealthAuth
+	case len(h.Missing) > 0:
+		h.Status = HealthIncomplete
+	}
+	return h
+}
+
+// hasData reports whether p has the data named by key, as Canary.Expect names it.
+func hasData(p *profile.Profile, key string) bool {
+	switch strings.ToLower(key) {
+	case registry.DataName:
+		return p.Name != ""
+	case registry.DataBio:
+		return p.Bio != ""
+	case registry.DataLocation:
+		return p.Location != ""
+	case registry.DataWebsite:
+		return p.Website != ""
+	case registry.DataAvatar:
+		_, ok := p.Field(profile.FieldAvatarURL)
+		return ok
+	case registry.DataCreatedAt:
+		return p.CreatedAt != ""
+	case registry.DataEmail:
+		_, ok := p.Email()
+		return ok
+	case registry.DataEmployer:
+		_, ok := p.Employer()
+		return ok
+	case registry.DataExperience:
+		return len(p.Experience) > 0
+	case registry.DataFollowers:
+		_, ok := p.Followers()
+		return ok
+	case registry.DataSocialLinks:
+		return len(p.SocialLinks) > 0
+	case registry.DataPosts:
+		return len(p.Posts) > 0
+	default:
+		return p.Fields[key] != ""
+	}
+}
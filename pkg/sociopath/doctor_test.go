@@ -0,0 +1,84 @@
+package sociopath
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/registry"
+)
+
+func TestDiagnose(t *testing.T) {
+	public := Canary{URL: "https://github.com/alice", Expect: []string{"name", "company"}}
+	private := Canary{URL: "https://www.linkedin.com/in/alice", Expect: []string{"name"}, Auth: true}
+	full := &profile.Profile{Name: "Alice", Fields: map[string]string{"company": "Acme"}}
+
+	tests := []struct {
+		name        string
+		canary      Canary
+		p           *profile.Profile
+		err         error
+		wantStatus  string
+		wantMissing int
+	}{
+		{"complete", public, full, nil, HealthOK, 0},
+		{"markup changed", public, &profile.Profile{Name: "Alice"}, nil, HealthIncomplete, 1},
+		{"logged-out stub", private, &profile.Profile{Username: "alice"}, nil, HealthAuth, 1},
+		{"authenticated but broken", private, &profile.Profile{Username: "alice", Authenticated: true}, nil, HealthIncomplete, 1},
+		{"cookies rejected", private, nil, fmt.Errorf("fetch: %w", profile.ErrAuthRequired), HealthAuth, 0},
+		{"no cookies", private, nil, profile.ErrNoCookies, HealthAuth, 0},
+		{"rate limited", public, nil, profile.ErrRateLimited, HealthRateLimited, 0},
+		{"canary deleted", public, nil, profile.ErrProfileNotFound, HealthNotFound, 0},
+		{"network", public, nil, errors.New("connection refused"), HealthError, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := diagnose(tt.canary, tt.p, tt.err)
+			if h.Status != tt.wantStatus || len(h.Missing) != tt.wantMissing {
+				t.Errorf("diagnose() = %+v, want status %q with %d missing", h, tt.wantStatus, tt.wantMissing)
+			}
+			if h.Platform != PlatformForURL(tt.canary.URL) {
+				t.Errorf("Platform = %q", h.Platform)
+			}
+		})
+	}
+}
+
+func TestDefaultCanaries(t *testing.T) {
+	for _, c := range DefaultCanaries {
+		if PlatformForURL(c.URL) == "generic" || len(c.Expect) == 0 {
+			t.Errorf("canary %+v has no platform or expectations", c)
+		}
+	}
+}
+
+func TestRecordHealth(t *testing.T) {
+	recordHealth([]Health{
+		{Platform: "codeberg", Status: HealthOK},
+		{Platform: "codeberg", Status: HealthIncomplete},
+		{Platform: "codeberg", Status: HealthRateLimited},
+	}, time.Now())
+	if c, _ := registry.Lookup("codeberg"); c.Health.Status != HealthIncomplete {
+		t.Errorf("codeberg health = %+v, want the worst result", c.Health)
+	}
+}
+
+func TestCapabilitiesCoverPlatforms(t *testing.T) {
+	known := make(map[string]bool)
+	for _, c := range registry.Capabilities() {
+		known[c.Platform] = true
+	}
+	for _, url := range []string{
+		"https://www.linkedin.com/in/alice", "https://x.com/alice", "https://github.com/alice",
+		"https://mastodon.social/@alice", "https://bsky.app/profile/alice.bsky.social",
+		"https://dev.to/alice", "https://old.reddit.com/user/alice", "https://www.tiktok.com/@alice",
+		"nostr:npub1sn0wdenkukak0d9dfczzeacvhkrgz92ak56egt7vdgzn8pv2wfqqhrjdv9", "https://app.ens.domains/alice.eth",
+		"https://keys.openpgp.org/search?q=alice@example.com", "https://example.com",
+	} {
+		if platform := PlatformForURL(url); !known[platform] {
+			t.Errorf("platform %q is missing from the registry", platform)
+		}
+	}
+}
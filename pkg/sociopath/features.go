@@ -0,0 +1,175 @@
+package sociopath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// ErrDisabled is returned for fetches of platforms that Features turn off.
+var ErrDisabled = errors.New("disabled by configuration")
+
+// Capabilities that Features can turn off, for every platform or, written
+// "platform:capability" (e.g. "linkedin:auth"), for one.
+const (
+	CapabilityAuth  = "auth"  // Fetching with login cookies
+	CapabilityEmail = "email" // Email addresses in Fields and mailto: links
+	CapabilityPosts = "posts" // Posts, comments, and videos
+)
+
+var capabilities = map[string]bool{CapabilityAuth: true, CapabilityEmail: true, CapabilityPosts: true}
+
+// Environment variables read by Features.ApplyEnv, as comma-separated lists.
+const (
+	EnvDisabledPlatforms    = "SOCIOPATH_DISABLED_PLATFORMS"
+	EnvDisabledCapabilities = "SOCIOPATH_DISABLED_CAPABILITIES"
+)
+
+// Features turns platforms and capabilities off at deployment time, for data that is
+// off-limits. Platforms are named as PlatformForURL names them. The zero value
+// enables everything.
+//
+//	{"disabled_platforms": ["tiktok"], "disabled_capabilities": ["email", "linkedin:auth"]}
+type Features struct {
+	DisabledPlatforms    []string `json:"disabled_platforms,omitempty"`
+	DisabledCapabilities []string `json:"disabled_capabilities,omitempty"`
+}
+
+// LoadFeatures reads a feature config from a JSON file.
+func LoadFeatures(path string) (*Features, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ParseFeatures(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// ParseFeatures parses and validates a JSON feature config.
+func ParseFeatures(data []byte) (*Features, error) {
+	var f Features
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// ApplyEnv adds the platforms and capabilities listed in the SOCIOPATH_DISABLED_PLATFORMS
+// and SOCIOPATH_DISABLED_CAPABILITIES environment variables, so a deployment can
+// turn more off without editing the config file.
+func (f *Features) ApplyEnv() error {
+	f.DisabledPlatforms = append(f.DisabledPlatforms, splitList(os.Getenv(EnvDisabledPlatforms))...)
+	f.DisabledCapabilities = append(f.DisabledCapabilities, splitList(os.Getenv(EnvDisabledCapabilities))...)
+	return f.validate()
+}
+
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (f *Features) validate() error {
+	for _, c := range f.DisabledCapabilities {
+		name := c
+		if _, after, ok := strings.Cut(c, ":"); ok {
+			name = after
+		}
+		if !capabilities[strings.ToLower(name)] {
+			return fmt.Errorf("unknown capability %q (want auth, email, or posts, optionally as platform:capability)", c)
+		}
+	}
+	return nil
+}
+
+// PlatformEnabled reports whether platform may be fetched.
+func (f *Features) PlatformEnabled(platform string) bool {
+	if f == nil {
+		return true
+	}
+	for _, p := range f.DisabledPlatforms {
+		if strings.EqualFold(p, platform) {
+			return false
+		}
+	}
+	return true
+}
+
+// Enabled reports whether capability may be used on platform.
+func (f *Features) Enabled(platform, capability string) bool {
+	if f == nil {
+		return true
+	}
+	for _, c := range f.DisabledCapabilities {
+		if strings.EqualFold(c, capability) || strings.EqualFold(c, platform+":"+capability) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithFeatures turns off the platforms and capabilities f disables. Fetches of a
+// disabled platform fail with ErrDisabled before any request is made, and crawls
+// skip them.
+func WithFeatures(f *Features) Option {
+	return func(c *config) { c.features = f }
+}
+
+// checkFeatures returns ErrDisabled if url's platform is disabled, and otherwise
+// the config to fetch it with: without cookies from any source, including the
+// environment, if authenticated fetching is off.
+func checkFeatures(cfg *config, url string) (*config, error) {
+	if cfg.features == nil {
+		return cfg, nil
+	}
+	platform := PlatformForURL(url)
+	if !cfg.features.PlatformEnabled(platform) {
+		return nil, fmt.Errorf("%w: platform %s", ErrDisabled, platform)
+	}
+	if !cfg.features.Enabled(platform, CapabilityAuth) {
+		anon := *cfg
+		anon.cookies = nil
+		anon.browserCookies = false
+		anon.anonymous = true
+		return &anon, nil
+	}
+	return cfg, nil
+}
+
+// stripDisabled removes the data of capabilities the config turns off from p.
+func stripDisabled(cfg *config, p *profile.Profile) {
+	if cfg.features == nil {
+		return
+	}
+	if !cfg.features.Enabled(p.Platform, CapabilityPosts) {
+		p.Posts = nil
+	}
+	if !cfg.features.Enabled(p.Platform, CapabilityEmail) {
+		for key := range p.Fields {
+			if key == profile.FieldEmail || strings.HasPrefix(key, profile.FieldEmail+"_") {
+				delete(p.Fields, key)
+			}
+		}
+		var kept []string
+		for _, link := range p.SocialLinks {
+			if !strings.HasPrefix(strings.ToLower(link), "mailto:") {
+				kept = append(kept, link)
+			}
+		}
+		p.SocialLinks = kept
+	}
+}
@@ -0,0 +1,116 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestParseFeatures(t *testing.T) {
+	f, err := ParseFeatures([]byte(`{"disabled_platforms": ["TikTok"], "disabled_capabilities": ["email", "linkedin:auth"]}`))
+	if err != nil {
+		t.Fatalf("ParseFeatures() error = %v", err)
+	}
+	tests := []struct {
+		platform, capability string
+		want                 bool
+	}{
+		{"github", CapabilityEmail, false},
+		{"github", CapabilityAuth, true},
+		{"linkedin", CapabilityAuth, false},
+		{"twitter", CapabilityPosts, true},
+	}
+	for _, tt := range tests {
+		if got := f.Enabled(tt.platform, tt.capability); got != tt.want {
+			t.Errorf("Enabled(%q, %q) = %v, want %v", tt.platform, tt.capability, got, tt.want)
+		}
+	}
+	if f.PlatformEnabled("tiktok") || !f.PlatformEnabled("github") {
+		t.Errorf("PlatformEnabled: tiktok = %v, github = %v", f.PlatformEnabled("tiktok"), f.PlatformEnabled("github"))
+	}
+
+	if _, err := ParseFeatures([]byte(`{"disabled_capabilities": ["linkedin:scraping"]}`)); err == nil {
+		t.Error("ParseFeatures() accepted an unknown capability")
+	}
+
+	var none *Features
+	if !none.PlatformEnabled("tiktok") || !none.Enabled("github", CapabilityEmail) {
+		t.Error("nil Features should enable everything")
+	}
+}
+
+func TestFeaturesApplyEnv(t *testing.T) {
+	t.Setenv(EnvDisabledPlatforms, " instagram, ,tiktok")
+	t.Setenv(EnvDisabledCapabilities, "posts")
+	f := &Features{DisabledPlatforms: []string{"twitter"}}
+	if err := f.ApplyEnv(); err != nil {
+		t.Fatalf("ApplyEnv() error = %v", err)
+	}
+	for _, platform := range []string{"twitter", "instagram", "tiktok"} {
+		if f.PlatformEnabled(platform) {
+			t.Errorf("PlatformEnabled(%q) = true after ApplyEnv", platform)
+		}
+	}
+	if f.Enabled("github", CapabilityPosts) {
+		t.Error("posts still enabled after ApplyEnv")
+	}
+
+	t.Setenv(EnvDisabledCapabilities, "everything")
+	if err := (&Features{}).ApplyEnv(); err == nil {
+		t.Error("ApplyEnv() accepted an unknown capability")
+	}
+}
+
+func TestCheckFeatures(t *testing.T) {
+	cfg := &config{cookies: map[string]string{"li_at": "secret"}, browserCookies: true}
+	WithFeatures(&Features{DisabledPlatforms: []string{"tiktok"}, DisabledCapabilities: []string{"linkedin:auth"}})(cfg)
+
+	if _, err := checkFeatures(cfg, "https://www.tiktok.com/@alice"); !errors.Is(err, ErrDisabled) {
+		t.Errorf("checkFeatures(tiktok) error = %v, want ErrDisabled", err)
+	}
+	got, err := checkFeatures(cfg, "https://www.linkedin.com/in/alice")
+	if err != nil {
+		t.Fatalf("checkFeatures(linkedin) error = %v", err)
+	}
+	if got.cookies != nil || got.browserCookies || !got.anonymous {
+		t.Errorf("linkedin config kept cookies: %+v", got)
+	}
+	if cfg.cookies == nil || !cfg.browserCookies {
+		t.Error("checkFeatures modified the caller's config")
+	}
+	if got, _ := checkFeatures(cfg, "https://twitter.com/alice"); got != cfg { //nolint:errcheck // twitter is enabled
+		t.Error("twitter config should keep cookies")
+	}
+
+	if _, err := Fetch(context.Background(), "https://www.tiktok.com/@alice", WithFeatures(cfg.features)); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Fetch() error = %v, want ErrDisabled", err)
+	}
+}
+
+func TestStripDisabled(t *testing.T) {
+	cfg := &config{features: &Features{DisabledCapabilities: []string{"email", "github:posts"}}}
+	p := &profile.Profile{
+		Platform:    "github",
+		Fields:      map[string]string{"email": "a@example.com", "email_2": "b@example.com", "company": "Acme"},
+		SocialLinks: []string{"mailto:a@example.com", "https://twitter.com/alice"},
+		Posts:       []profile.Post{{Type: profile.PostTypeRepository, Title: "tool"}},
+	}
+	stripDisabled(cfg, p)
+	if len(p.Fields) != 1 || p.Fields["company"] != "Acme" {
+		t.Errorf("Fields = %v, want only company", p.Fields)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://twitter.com/alice" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+	if p.Posts != nil {
+		t.Errorf("Posts = %v, want none", p.Posts)
+	}
+
+	other := &profile.Profile{Platform: "mastodon", Posts: []profile.Post{{Type: profile.PostTypePost}}}
+	stripDisabled(cfg, other)
+	if len(other.Posts) != 1 {
+		t.Error("posts disabled for github only were stripped from mastodon")
+	}
+}
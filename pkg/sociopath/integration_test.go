@@ -222,7 +222,7 @@ func TestIntegrationLiveFetch(t *testing.T) {
 				CreatedAt: "2022-11-03T00:00:00.000Z",
 			},
 			cmpOpts: []cmp.Option{
-				cmpopts.IgnoreFields(profile.Profile{}, "Location", "Website", "UpdatedAt", "SocialLinks", "Fields", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
+				cmpopts.IgnoreFields(profile.Profile{}, "Location", "Website", "UpdatedAt", "LastActive", "SocialLinks", "Fields", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
 			},
 		},
 		{
@@ -633,7 +633,7 @@ func TestIntegrationLiveFetch(t *testing.T) {
 				// Name, Bio, Location are empty when auth is broken
 			},
 			cmpOpts: []cmp.Option{
-				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
+				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "LastActive", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
 			},
 		},
 		{
@@ -658,7 +658,7 @@ func TestIntegrationLiveFetch(t *testing.T) {
 				Username:      "mattmoor",
 			},
 			cmpOpts: []cmp.Option{
-				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
+				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "LastActive", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
 			},
 		},
 		{
@@ -683,7 +683,7 @@ func TestIntegrationLiveFetch(t *testing.T) {
 				Username:      "austen-bryan-23485a19",
 			},
 			cmpOpts: []cmp.Option{
-				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
+				cmpopts.IgnoreFields(profile.Profile{}, "Fields", "Name", "Bio", "Location", "Website", "CreatedAt", "UpdatedAt", "LastActive", "SocialLinks", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
 			},
 		},
 	}
@@ -692,8 +692,8 @@ func TestIntegrationLiveFetch(t *testing.T) {
 		// Ignore fields that change frequently or are platform-specific details
 		// Bio, Location, Website can be edited by users
 		// Fields, SocialLinks contain varying platform-specific data
-		// UpdatedAt, Posts, Unstructured change with activity
-		cmpopts.IgnoreFields(profile.Profile{}, "Bio", "Location", "Website", "Fields", "SocialLinks", "UpdatedAt", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
+		// UpdatedAt, LastActive, Posts, Unstructured change with activity
+		cmpopts.IgnoreFields(profile.Profile{}, "Bio", "Location", "Website", "Fields", "SocialLinks", "UpdatedAt", "LastActive", "Posts", "Unstructured", "IsGuess", "Confidence", "GuessMatch"),
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,124 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/github"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkedin"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// maxOrgMembers caps how many member profiles each roster source contributes.
+const maxOrgMembers = 50
+
+// FieldOrg is the Fields key FetchOrg sets to the organization a profile was listed under.
+const FieldOrg = "org"
+
+// FetchOrg enumerates the public members of an organization and returns a profile for each.
+// org may be:
+//   - a GitHub organization URL, whose public members are listed through the API;
+//   - a LinkedIn company URL, whose current employees are found through people
+//     search (this requires LinkedIn session cookies);
+//   - a company website or domain, whose team pages are read as with FetchTeam and
+//     which, given LinkedIn session cookies, is also resolved to its company page.
+//
+// Members that cannot be fetched are skipped. An error is returned only when no
+// member was found.
+func FetchOrg(ctx context.Context, org string, opts ...Option) ([]*profile.Profile, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ctx = modeContext(ctx, cfg)
+
+	var (
+		roster []*profile.Profile
+		urls   []string
+		errs   []error
+	)
+	switch {
+	case strings.Contains(strings.ToLower(org), "github.com/"):
+		name := github.OrgName(org)
+		if name == "" {
+			return nil, fmt.Errorf("not a github organization url: %s", org)
+		}
+		found, err := githubOrgMembers(ctx, cfg, name)
+		urls, errs = found, append(errs, err)
+	case linkedin.CompanyName(org) != "":
+		found, err := linkedinEmployees(ctx, cfg, linkedin.CompanyName(org), "")
+		urls, errs = found, append(errs, err)
+	default:
+		team, err := FetchTeam(ctx, org, opts...)
+		roster, errs = team, append(errs, err)
+		found, err := linkedinEmployees(ctx, cfg, "", org)
+		if errors.Is(err, profile.ErrAuthRequired) {
+			cfg.logger.InfoContext(ctx, "skipping linkedin employee search without session cookies", "org", org)
+			err = nil
+		}
+		urls, errs = found, append(errs, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, p := range roster {
+		seen[p.URL] = true
+	}
+	for _, u := range urls {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		p, err := Fetch(ctx, u, opts...)
+		if err != nil {
+			cfg.logger.WarnContext(ctx, "skipping org member", "url", u, "error", err)
+			continue
+		}
+		roster = append(roster, p)
+	}
+
+	if len(roster) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: no members found for %s", profile.ErrProfileNotFound, org)
+	}
+	for _, p := range roster {
+		if p.Fields == nil {
+			p.Fields = make(map[string]string)
+		}
+		p.Fields[FieldOrg] = org
+	}
+	return roster, nil
+}
+
+// githubOrgMembers lists the profile URLs of an organization's public GitHub members.
+func githubOrgMembers(ctx context.Context, cfg *config, org string) ([]string, error) {
+	client, err := newGitHubClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.OrgMembers(ctx, org, maxOrgMembers)
+}
+
+// linkedinEmployees lists the profile URLs of a company's employees, identifying the
+// company by universal name or, when that is empty, by its website domain.
+func linkedinEmployees(ctx context.Context, cfg *config, universalName, domain string) ([]string, error) {
+	client, err := newLinkedInClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var company *linkedin.Company
+	if universalName != "" {
+		company, err = client.Company(ctx, universalName)
+	} else {
+		company, err = client.ResolveCompany(ctx, domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client.Employees(ctx, company, maxOrgMembers)
+}
@@ -0,0 +1,117 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/bilibili"
+	"github.com/codeGROOVE-dev/sociopath/pkg/calcom"
+	"github.com/codeGROOVE-dev/sociopath/pkg/codeberg"
+	"github.com/codeGROOVE-dev/sociopath/pkg/devto"
+	"github.com/codeGROOVE-dev/sociopath/pkg/fivehundredpx"
+	"github.com/codeGROOVE-dev/sociopath/pkg/flickr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/generic"
+	"github.com/codeGROOVE-dev/sociopath/pkg/github"
+	"github.com/codeGROOVE-dev/sociopath/pkg/githubsponsors"
+	"github.com/codeGROOVE-dev/sociopath/pkg/habr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/itchio"
+	"github.com/codeGROOVE-dev/sociopath/pkg/kofi"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkedin"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linktree"
+	"github.com/codeGROOVE-dev/sociopath/pkg/mastodon"
+	"github.com/codeGROOVE-dev/sociopath/pkg/medium"
+	"github.com/codeGROOVE-dev/sociopath/pkg/meetup"
+	"github.com/codeGROOVE-dev/sociopath/pkg/patreon"
+	"github.com/codeGROOVE-dev/sociopath/pkg/polywork"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/readcv"
+	"github.com/codeGROOVE-dev/sociopath/pkg/reddit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sessionize"
+	"github.com/codeGROOVE-dev/sociopath/pkg/soundcloud"
+	"github.com/codeGROOVE-dev/sociopath/pkg/stackoverflow"
+	"github.com/codeGROOVE-dev/sociopath/pkg/substack"
+	"github.com/codeGROOVE-dev/sociopath/pkg/tiktok"
+	"github.com/codeGROOVE-dev/sociopath/pkg/twitter"
+	"github.com/codeGROOVE-dev/sociopath/pkg/vkontakte"
+	"github.com/codeGROOVE-dev/sociopath/pkg/youtube"
+)
+
+// ErrNoParser is returned by ParseHTML for platforms that cannot be parsed from HTML.
+var ErrNoParser = errors.New("no HTML parser for platform")
+
+// htmlParsers extract profiles from page HTML, by platform.
+var htmlParsers = map[string]func(ctx context.Context, html []byte, url string) (*profile.Profile, error){
+	"bilibili":       bilibili.Parse,
+	"calcom":         calcom.Parse,
+	"codeberg":       codeberg.Parse,
+	"devto":          devto.Parse,
+	"fivehundredpx":  fivehundredpx.Parse,
+	"flickr":         flickr.Parse,
+	"generic":        generic.Parse,
+	"github":         github.Parse,
+	"githubsponsors": githubsponsors.Parse,
+	"habr":           habr.Parse,
+	"itchio":         itchio.Parse,
+	"kofi":           kofi.Parse,
+	"linkedin":       linkedin.Parse,
+	"linktree":       linktree.Parse,
+	"mastodon":       mastodon.Parse,
+	"medium":         medium.Parse,
+	"meetup":         meetup.Parse,
+	"patreon":        patreon.Parse,
+	"polywork":       polywork.Parse,
+	"readcv":         readcv.Parse,
+	"reddit":         reddit.Parse,
+	"sessionize":     sessionize.Parse,
+	"soundcloud":     soundcloud.Parse,
+	"stackoverflow":  stackoverflow.Parse,
+	"substack":       substack.Parse,
+	"tiktok":         tiktok.Parse,
+	"twitter":        twitter.Parse,
+	"vkontakte":      vkontakte.Parse,
+	"youtube":        youtube.Parse,
+}
+
+// ParseHTML extracts a profile from the HTML of a page at url that was obtained some
+// other way, such as by a browser extension viewing it, without making any requests.
+// If platform is empty, it is detected from url. The profile is normalized and
+// annotated as Fetch would.
+func ParseHTML(ctx context.Context, platform string, html []byte, url string, opts ...Option) (*profile.Profile, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if platform == "" {
+		platform = PlatformForURL(url)
+	}
+	parse, ok := htmlParsers[platform]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoParser, platform)
+	}
+	p, err := parse(ctx, html, url)
+	if err != nil {
+		return nil, err
+	}
+	finish(ctx, cfg, p)
+	return p, nil
+}
+
+// ParseVoyager extracts LinkedIn profiles from Voyager API responses captured some
+// other way, such as in a HAR export of a logged-in browser session, without making
+// any requests. The profiles are normalized and annotated as Fetch would.
+func ParseVoyager(ctx context.Context, responses [][]byte, opts ...Option) ([]*profile.Profile, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	profiles, err := linkedin.ParseVoyager(responses)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		finish(ctx, cfg, p)
+	}
+	return profiles, nil
+}
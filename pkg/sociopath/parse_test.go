@@ -0,0 +1,29 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseHTML(t *testing.T) {
+	page := []byte(`<meta property="og:title" content="Jane Doe - Engineer | LinkedIn">
+<meta property="og:description" content="Distributed systems · Location: Berlin · 500+ connections on LinkedIn">`)
+
+	p, err := ParseHTML(context.Background(), "", page, "https://www.linkedin.com/in/janedoe")
+	if err != nil {
+		t.Fatalf("ParseHTML() error = %v", err)
+	}
+	if p.Platform != "linkedin" || p.Name != "Jane Doe" || p.Location != "Berlin" {
+		t.Errorf("ParseHTML() = %+v", p)
+	}
+
+	gh, err := ParseHTML(context.Background(), "", []byte(`<span class="p-name vcard-fullname" itemprop="name">Jane Doe</span>`), "https://github.com/janedoe")
+	if err != nil || gh.Platform != "github" || gh.Name != "Jane Doe" {
+		t.Errorf("ParseHTML(github) = %+v, %v", gh, err)
+	}
+
+	if _, err := ParseHTML(context.Background(), "myspace", page, "https://myspace.com/jane"); !errors.Is(err, ErrNoParser) {
+		t.Errorf("ParseHTML(myspace) error = %v, want ErrNoParser", err)
+	}
+}
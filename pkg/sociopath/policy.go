@@ -0,0 +1,55 @@
+package sociopath
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+)
+
+// WithPolicy consults d before every fetch, including each URL a crawl follows.
+// Denied fetches fail with policy.ErrDenied before any request is made, and crawls
+// skip them. Every decision is logged with its reason.
+func WithPolicy(d policy.Decider) Option {
+	return func(c *config) { c.policy = d }
+}
+
+// checkPolicy returns policy.ErrDenied if cfg's policy denies fetching url.
+// A policy that fails denies the fetch.
+func checkPolicy(ctx context.Context, cfg *config, url string) error {
+	if cfg.policy == nil {
+		return nil
+	}
+	platform := PlatformForURL(url)
+	req := policy.Request{
+		URL:           url,
+		Platform:      platform,
+		Authenticated: authenticated(ctx, cfg, platform),
+	}
+	decision, err := cfg.policy.Decide(ctx, req)
+	if err != nil {
+		cfg.logger.WarnContext(ctx, "policy failed, denying fetch", "url", url, "platform", req.Platform, "error", err)
+		return fmt.Errorf("%w: %w", policy.ErrDenied, err)
+	}
+	cfg.logger.InfoContext(ctx, "policy decision", "url", url, "platform", req.Platform,
+		"authenticated", req.Authenticated, "allow", decision.Allow, "reason", decision.Reason)
+	if !decision.Allow {
+		return fmt.Errorf("%w: %s", policy.ErrDenied, decision.Reason)
+	}
+	return nil
+}
+
+// authenticated reports whether a fetch from platform may carry session cookies,
+// checking the sources the platform clients read: WithCookies, browsers, and the
+// environment.
+func authenticated(ctx context.Context, cfg *config, platform string) bool {
+	if cfg.anonymous {
+		return false
+	}
+	if len(cfg.cookies) > 0 || cfg.browserCookies {
+		return true
+	}
+	cookies, err := auth.EnvSource{}.Cookies(ctx, platform)
+	return err == nil && len(cookies) > 0
+}
@@ -0,0 +1,50 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+)
+
+func TestFetchPolicy(t *testing.T) {
+	var got policy.Request
+	deny := policy.DeciderFunc(func(_ context.Context, req policy.Request) (policy.Decision, error) {
+		got = req
+		return policy.Deny("no consent on file"), nil
+	})
+
+	_, err := Fetch(context.Background(), "https://www.linkedin.com/in/alice",
+		WithPolicy(deny), WithCookies(map[string]string{"li_at": "secret"}))
+	if !errors.Is(err, policy.ErrDenied) {
+		t.Fatalf("Fetch() error = %v, want policy.ErrDenied", err)
+	}
+	if got.URL != "https://www.linkedin.com/in/alice" || got.Platform != "linkedin" || !got.Authenticated {
+		t.Errorf("policy saw %+v", got)
+	}
+
+	// Cookies stripped by Features are not reported as authenticated
+	_, err = Fetch(context.Background(), "https://www.linkedin.com/in/alice",
+		WithPolicy(deny), WithCookies(map[string]string{"li_at": "secret"}),
+		WithFeatures(&Features{DisabledCapabilities: []string{"linkedin:auth"}}))
+	if !errors.Is(err, policy.ErrDenied) || got.Authenticated {
+		t.Errorf("Fetch() error = %v, policy saw %+v", err, got)
+	}
+
+	// Cookies the platform client would read from the environment count too
+	t.Setenv("LINKEDIN_LI_AT", "secret")
+	if _, err := Fetch(context.Background(), "https://www.linkedin.com/in/alice", WithPolicy(deny)); !errors.Is(err, policy.ErrDenied) || !got.Authenticated {
+		t.Errorf("Fetch() with environment cookies error = %v, policy saw %+v", err, got)
+	}
+	if _, err := Fetch(context.Background(), "https://github.com/alice", WithPolicy(deny)); !errors.Is(err, policy.ErrDenied) || got.Authenticated {
+		t.Errorf("Fetch() of another platform error = %v, policy saw %+v", err, got)
+	}
+
+	failing := policy.DeciderFunc(func(context.Context, policy.Request) (policy.Decision, error) {
+		return policy.Decision{}, errors.New("consent service down")
+	})
+	if _, err := Fetch(context.Background(), "https://github.com/alice", WithPolicy(failing)); !errors.Is(err, policy.ErrDenied) {
+		t.Errorf("Fetch() with failing policy error = %v, want policy.ErrDenied", err)
+	}
+}
@@ -0,0 +1,102 @@
+package sociopath
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// rateWindow is how far back Progress.Rate looks.
+const rateWindow = time.Minute
+
+// Progress is a snapshot of a crawl's progress.
+type Progress struct {
+	Started   time.Time      `json:"started"`
+	Platforms map[string]int `json:"platforms,omitempty"` // Completed URLs by platform
+	Completed int            `json:"completed"`           // URLs fetched, successfully or not
+	Failed    int            `json:"failed"`              // Completed URLs whose fetch failed
+	Skipped   int            `json:"skipped"`             // URLs disabled, denied by policy, or deferred by quotas
+	Queued    int            `json:"queued"`              // URLs in the frontier
+	Rate      float64        `json:"rate"`                // URLs completed per second over the last minute
+}
+
+// Remaining estimates how long the URLs queued now will take at the current rate.
+// Crawls keep discovering links, so it is a lower bound; it is 0 when nothing is
+// queued and -1 when the rate is not yet known.
+func (p Progress) Remaining() time.Duration {
+	switch {
+	case p.Queued == 0:
+		return 0
+	case p.Rate <= 0:
+		return -1
+	default:
+		return time.Duration(float64(p.Queued) / p.Rate * float64(time.Second))
+	}
+}
+
+// WithProgress calls fn with the crawl's progress after each URL it handles, in
+// recursive fetches and Crawler runs. fn runs on the crawling goroutine, so it
+// should return quickly. Runs resumed by a Crawler count from the resume.
+func WithProgress(fn func(Progress)) Option {
+	return func(c *config) { c.progress = fn }
+}
+
+// Crawl outcomes reported to a progressTracker.
+const (
+	outcomeFetched = iota
+	outcomeFailed
+	outcomeSkipped
+)
+
+// progressTracker counts a crawl's outcomes and reports them to cfg.progress.
+type progressTracker struct {
+	fn          func(Progress)
+	progress    Progress
+	completions []time.Time // Within rateWindow, oldest first
+}
+
+func newProgressTracker(cfg *config) *progressTracker {
+	return &progressTracker{
+		fn:       cfg.progress,
+		progress: Progress{Started: time.Now(), Platforms: make(map[string]int)},
+	}
+}
+
+// record counts the outcome of url, once the crawl has advanced past it, and reports
+// progress if anyone is listening.
+func (t *progressTracker) record(ctx context.Context, st crawlState, url string, outcome int) {
+	if t.fn == nil {
+		return
+	}
+	now := time.Now()
+	switch outcome {
+	case outcomeSkipped:
+		t.progress.Skipped++
+	case outcomeFailed:
+		t.progress.Failed++
+		fallthrough
+	default:
+		t.progress.Completed++
+		t.progress.Platforms[PlatformForURL(url)]++
+		t.completions = append(t.completions, now)
+	}
+
+	cutoff := now.Add(-rateWindow)
+	i := 0
+	for i < len(t.completions) && t.completions[i].Before(cutoff) {
+		i++
+	}
+	t.completions = t.completions[i:]
+	// Early in a crawl, the rate is over the time since it started, but at least a
+	// second so one quick failure does not read as thousands per second
+	elapsed := max(min(now.Sub(t.progress.Started), rateWindow), time.Second)
+	t.progress.Rate = float64(len(t.completions)) / elapsed.Seconds()
+
+	if queued, err := st.pending(ctx); err == nil {
+		t.progress.Queued = queued
+	}
+
+	snapshot := t.progress
+	snapshot.Platforms = maps.Clone(t.progress.Platforms)
+	t.fn(snapshot)
+}
@@ -0,0 +1,47 @@
+package sociopath
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker(t *testing.T) {
+	var got []Progress
+	tracker := newProgressTracker(&config{progress: func(p Progress) { got = append(got, p) }})
+	tracker.progress.Started = time.Now().Add(-10 * time.Second)
+	st := &memoryState{queue: []queueItem{{url: "https://github.com/carol"}, {url: "https://github.com/dave"}}}
+	ctx := context.Background()
+
+	tracker.record(ctx, st, "https://github.com/alice", outcomeFetched)
+	tracker.record(ctx, st, "https://twitter.com/alice", outcomeFailed)
+	tracker.record(ctx, st, "https://www.linkedin.com/in/alice", outcomeSkipped)
+
+	if len(got) != 3 {
+		t.Fatalf("progress reported %d times, want 3", len(got))
+	}
+	p := got[2]
+	if p.Completed != 2 || p.Failed != 1 || p.Skipped != 1 || p.Queued != 2 {
+		t.Errorf("progress = %+v", p)
+	}
+	if p.Platforms["github"] != 1 || p.Platforms["twitter"] != 1 || p.Platforms["linkedin"] != 0 {
+		t.Errorf("progress platforms = %v", p.Platforms)
+	}
+	if p.Rate < 0.15 || p.Rate > 0.25 {
+		t.Errorf("progress rate = %v, want about 2 URLs in 10s", p.Rate)
+	}
+	if r := p.Remaining(); r < 8*time.Second || r > 14*time.Second {
+		t.Errorf("Remaining() = %v, want about 10s", r)
+	}
+	// Snapshots do not change as the crawl goes on
+	if got[0].Platforms["twitter"] != 0 {
+		t.Errorf("earlier snapshot changed: %v", got[0].Platforms)
+	}
+
+	if r := (Progress{Queued: 3}).Remaining(); r != -1 {
+		t.Errorf("Remaining() without a rate = %v, want -1", r)
+	}
+	if r := (Progress{}).Remaining(); r != 0 {
+		t.Errorf("Remaining() with nothing queued = %v, want 0", r)
+	}
+}
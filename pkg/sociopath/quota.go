@@ -0,0 +1,99 @@
+package sociopath
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// quotaWindow is the rolling window WithDailyQuota limits are counted over.
+const quotaWindow = 24 * time.Hour
+
+// QuotaStore records profile fetches counted against quotas. store.Store implements it.
+type QuotaStore interface {
+	// ReserveRequest records a fetch made at time at against key unless limit fetches
+	// were already recorded at or after since, and reports whether it did. Counting and
+	// recording must be one step, so processes sharing the store keep to the quota.
+	ReserveRequest(ctx context.Context, key string, since, at time.Time, limit int) (bool, error)
+}
+
+// WithDailyQuota limits profile fetches from platform (as named by PlatformForURL) to n
+// per rolling 24 hours. Fetches over the quota fail with ErrQuotaExceeded before any
+// request is made; crawls defer those URLs. Each WithTenant tenant has a quota of its
+// own. Counts are kept in memory for the process
+// unless WithQuotaStore is set; a Crawler uses its store.
+func WithDailyQuota(platform string, n int) Option {
+	return func(c *config) {
+		if c.quotas == nil {
+			c.quotas = make(map[string]int)
+		}
+		c.quotas[platform] = n
+	}
+}
+
+// WithQuotaStore keeps quota counts in q so they persist across processes and runs.
+func WithQuotaStore(q QuotaStore) Option {
+	return func(c *config) { c.quotaStore = q }
+}
+
+// processQuota counts fetches for processes without a QuotaStore.
+var processQuota = &memoryQuota{requests: make(map[string][]time.Time)}
+
+// checkQuota returns ErrQuotaExceeded if fetching url would exceed its platform's
+// quota, and otherwise records the fetch.
+func checkQuota(ctx context.Context, cfg *config, url string) error {
+	if len(cfg.quotas) == 0 {
+		return nil
+	}
+	platform := PlatformForURL(url)
+	limit, ok := cfg.quotas[platform]
+	if !ok {
+		return nil
+	}
+
+	var q QuotaStore = processQuota
+	if cfg.quotaStore != nil {
+		q = cfg.quotaStore
+	}
+	// Each tenant's account has its own allowance, so one tenant cannot use up another's
+	key := "platform:" + platform
+	if cfg.tenant != "" {
+		key = "tenant:" + cfg.tenant + "|" + key
+	}
+	now := time.Now()
+
+	ok, err := q.ReserveRequest(ctx, key, now.Add(-quotaWindow), now, limit)
+	if err != nil {
+		return fmt.Errorf("checking %s quota: %w", platform, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s used all %d fetches allowed in the last 24h", ErrQuotaExceeded, platform, limit)
+	}
+	return nil
+}
+
+// memoryQuota is a QuotaStore that lives for the process.
+type memoryQuota struct {
+	requests map[string][]time.Time
+	mu       sync.Mutex
+}
+
+func (m *memoryQuota) ReserveRequest(_ context.Context, key string, since, at time.Time, limit int) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Drop entries that have left every window we count over
+	kept := m.requests[key][:0]
+	for _, t := range m.requests[key] {
+		if !t.Before(since) {
+			kept = append(kept, t)
+		}
+	}
+	m.requests[key] = kept
+	if len(kept) >= limit {
+		return false, nil
+	}
+	m.requests[key] = append(kept, at)
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package sociopath
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/store"
+)
+
+func TestCheckQuota(t *testing.T) {
+	ctx := context.Background()
+	q := &memoryQuota{requests: make(map[string][]time.Time)}
+	cfg := &config{}
+	WithDailyQuota("github", 2)(cfg)
+	WithQuotaStore(q)(cfg)
+
+	for range 2 {
+		if err := checkQuota(ctx, cfg, "https://github.com/alice"); err != nil {
+			t.Fatalf("checkQuota() under quota error = %v", err)
+		}
+	}
+	if err := checkQuota(ctx, cfg, "https://github.com/bob"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("checkQuota() over quota error = %v, want ErrQuotaExceeded", err)
+	}
+	// Platforms without a quota are not counted
+	if err := checkQuota(ctx, cfg, "https://mastodon.social/@alice"); err != nil {
+		t.Errorf("checkQuota() for unlimited platform error = %v", err)
+	}
+	if n := len(q.requests["platform:mastodon"]); n != 0 {
+		t.Errorf("unlimited platform recorded %d requests, want 0", n)
+	}
+}
+
+func TestCheckQuotaPerTenant(t *testing.T) {
+	ctx := context.Background()
+	q := &memoryQuota{requests: make(map[string][]time.Time)}
+	cfgFor := func(tenant string) *config {
+		cfg := &config{}
+		WithDailyQuota("github", 1)(cfg)
+		WithQuotaStore(q)(cfg)
+		WithTenant(tenant)(cfg)
+		return cfg
+	}
+	acme, globex := cfgFor("acme"), cfgFor("globex")
+
+	if err := checkQuota(ctx, acme, "https://github.com/alice"); err != nil {
+		t.Fatalf("checkQuota() under quota error = %v", err)
+	}
+	if err := checkQuota(ctx, acme, "https://github.com/bob"); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("checkQuota() over quota error = %v, want ErrQuotaExceeded", err)
+	}
+	if err := checkQuota(ctx, globex, "https://github.com/bob"); err != nil {
+		t.Errorf("checkQuota() for another tenant error = %v, want its own quota", err)
+	}
+}
+
+func TestFetchQuotaExceeded(t *testing.T) {
+	_, err := Fetch(context.Background(), "https://github.com/alice",
+		WithDailyQuota("github", 0), WithQuotaStore(&memoryQuota{requests: make(map[string][]time.Time)}))
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Fetch() error = %v, want ErrQuotaExceeded", err)
+	}
+}
+
+func TestCrawlerDefersOverQuota(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewCrawler(st, WithDailyQuota("generic", 0))
+
+	profiles, err := c.Start(ctx, "run", "https://example.com/")
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("Start() error = %v, want ErrQuotaExceeded", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("Start() returned %d profiles, want 0", len(profiles))
+	}
+	frontier, err := st.Frontier(ctx, "run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frontier) != 1 || frontier[0].URL != "https://example.com/" {
+		t.Errorf("frontier = %+v, want the deferred seed", frontier)
+	}
+	if seen, _ := st.Visited(ctx, "run", "example.com"); seen { //nolint:errcheck // checked by value
+		t.Error("deferred URL was marked visited")
+	}
+}
+
+func TestCrawlerKeepsDeferredOnInterrupt(t *testing.T) {
+	ctx := context.Background()
+	st, err := store.NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := st.PushFrontier(ctx, "run",
+		store.FrontierItem{URL: "https://example.com/"}, store.FrontierItem{URL: "https://example.org/"}); err != nil {
+		t.Fatal(err)
+	}
+	// Stop once the first URL has been deferred, before the second is fetched
+	var c *Crawler
+	c = NewCrawler(st, WithDailyQuota("generic", 0), WithProgress(func(Progress) { c.Stop() }))
+
+	if _, err := c.Resume(ctx, "run"); !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("Resume() error = %v, want ErrInterrupted", err)
+	}
+	frontier, err := st.Frontier(ctx, "run")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	for _, it := range frontier {
+		urls = append(urls, it.URL)
+	}
+	if want := []string{"https://example.org/", "https://example.com/"}; !slices.Equal(urls, want) {
+		t.Errorf("frontier = %v, want %v", urls, want)
+	}
+}
@@ -22,33 +22,68 @@ package sociopath
 
 import (
 	"context"
-	"errors"
+	"crypto/tls"
+	"crypto/x509"
 	"log/slog"
+	"net/http"
+	"net/netip"
+	neturl "net/url"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/codeGROOVE-dev/sociopath/pkg/activitypub"
+	"github.com/codeGROOVE-dev/sociopath/pkg/analysis"
 	"github.com/codeGROOVE-dev/sociopath/pkg/bilibili"
+	"github.com/codeGROOVE-dev/sociopath/pkg/blob"
 	"github.com/codeGROOVE-dev/sociopath/pkg/bluesky"
+	"github.com/codeGROOVE-dev/sociopath/pkg/breach"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/calcom"
+	"github.com/codeGROOVE-dev/sociopath/pkg/calendly"
 	"github.com/codeGROOVE-dev/sociopath/pkg/codeberg"
 	"github.com/codeGROOVE-dev/sociopath/pkg/devto"
+	"github.com/codeGROOVE-dev/sociopath/pkg/discord"
+	"github.com/codeGROOVE-dev/sociopath/pkg/ens"
+	"github.com/codeGROOVE-dev/sociopath/pkg/fivehundredpx"
+	"github.com/codeGROOVE-dev/sociopath/pkg/flickr"
 	"github.com/codeGROOVE-dev/sociopath/pkg/generic"
 	"github.com/codeGROOVE-dev/sociopath/pkg/github"
+	"github.com/codeGROOVE-dev/sociopath/pkg/githubsponsors"
 	"github.com/codeGROOVE-dev/sociopath/pkg/guess"
 	"github.com/codeGROOVE-dev/sociopath/pkg/habr"
 	"github.com/codeGROOVE-dev/sociopath/pkg/instagram"
+	"github.com/codeGROOVE-dev/sociopath/pkg/itchio"
+	"github.com/codeGROOVE-dev/sociopath/pkg/kofi"
 	"github.com/codeGROOVE-dev/sociopath/pkg/linkedin"
+	"github.com/codeGROOVE-dev/sociopath/pkg/linkgraph"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 	"github.com/codeGROOVE-dev/sociopath/pkg/linktree"
+	"github.com/codeGROOVE-dev/sociopath/pkg/lookup"
 	"github.com/codeGROOVE-dev/sociopath/pkg/mastodon"
 	"github.com/codeGROOVE-dev/sociopath/pkg/medium"
+	"github.com/codeGROOVE-dev/sociopath/pkg/meetup"
+	"github.com/codeGROOVE-dev/sociopath/pkg/nostr"
+	"github.com/codeGROOVE-dev/sociopath/pkg/patreon"
+	"github.com/codeGROOVE-dev/sociopath/pkg/peertube"
+	"github.com/codeGROOVE-dev/sociopath/pkg/pgp"
+	"github.com/codeGROOVE-dev/sociopath/pkg/policy"
+	"github.com/codeGROOVE-dev/sociopath/pkg/polywork"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/readcv"
 	"github.com/codeGROOVE-dev/sociopath/pkg/reddit"
+	"github.com/codeGROOVE-dev/sociopath/pkg/searchengine"
+	"github.com/codeGROOVE-dev/sociopath/pkg/sessionize"
+	"github.com/codeGROOVE-dev/sociopath/pkg/soundcloud"
 	"github.com/codeGROOVE-dev/sociopath/pkg/stackoverflow"
+	"github.com/codeGROOVE-dev/sociopath/pkg/steam"
 	"github.com/codeGROOVE-dev/sociopath/pkg/substack"
+	"github.com/codeGROOVE-dev/sociopath/pkg/teampage"
 	"github.com/codeGROOVE-dev/sociopath/pkg/tiktok"
 	"github.com/codeGROOVE-dev/sociopath/pkg/twitter"
 	"github.com/codeGROOVE-dev/sociopath/pkg/vkontakte"
 	"github.com/codeGROOVE-dev/sociopath/pkg/weibo"
+	"github.com/codeGROOVE-dev/sociopath/pkg/writefreely"
 	"github.com/codeGROOVE-dev/sociopath/pkg/youtube"
 )
 
@@ -57,6 +92,21 @@ type (
 	Profile = profile.Profile
 	// HTTPCache re-exports cache.HTTPCache for convenience.
 	HTTPCache = cache.HTTPCache
+	// Depth re-exports profile.Depth for convenience.
+	Depth = profile.Depth
+	// Reach re-exports profile.Reach for convenience.
+	Reach = profile.Reach
+	// Footprint re-exports profile.Footprint for convenience.
+	Footprint = profile.Footprint
+	// Timeline re-exports analysis.Timeline for convenience.
+	Timeline = analysis.Timeline
+)
+
+// Re-export extraction depths.
+const (
+	DepthMinimal  = profile.DepthMinimal
+	DepthStandard = profile.DepthStandard
+	DepthDeep     = profile.DepthDeep
 )
 
 // Re-export common errors.
@@ -65,6 +115,8 @@ var (
 	ErrNoCookies       = profile.ErrNoCookies
 	ErrProfileNotFound = profile.ErrProfileNotFound
 	ErrRateLimited     = profile.ErrRateLimited
+	ErrQuotaExceeded   = profile.ErrQuotaExceeded
+	ErrNotCached       = cache.ErrNotCached
 )
 
 // Option configures a Fetch call.
@@ -72,10 +124,41 @@ type Option func(*config)
 
 type config struct {
 	cache          cache.HTTPCache
+	visited        VisitedSet
+	graph          *linkgraph.Graph
+	tagger         analysis.Tagger
+	breaches       breach.Checker
+	features       *Features
+	policy         policy.Decider
+	audit          AuditLog
+	renderer       generic.Renderer
+	search         searchengine.Provider
+	quotaStore     QuotaStore
+	quotas         map[string]int
 	cookies        map[string]string
+	linkedInProxy  *neturl.URL
 	logger         *slog.Logger
 	githubToken    string
+	tenant         string
+	githubGists    bool
 	browserCookies bool
+	anonymous      bool // Read no cookies at all, not even from the environment
+	usernameProbes bool
+	botScores      bool
+	inferEmployer  bool
+	offline        bool
+	staleAfter     time.Duration
+	identity       cache.Identity
+	requestHooks   []cache.RequestHook
+	network        cache.Network
+	limits         *cache.Limits
+	crawlIdle      time.Duration
+	blobs          blob.Store
+	blobMinSize    int
+	stop           <-chan struct{} // Closed by Crawler.Stop
+	progress       func(Progress)
+	companies      analysis.CompanyProvider
+	depth          profile.Depth
 }
 
 // WithCookies sets explicit cookie values for authenticated platforms.
@@ -83,11 +166,25 @@ func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
+// WithTenant fetches on behalf of tenant, one of several clients sharing the process
+// with credentials of their own. Each tenant has its own quotas (see WithDailyQuota)
+// and circuit breakers, so one tenant's usage or a block of its account does not stop
+// the others.
+func WithTenant(tenant string) Option {
+	return func(c *config) { c.tenant = tenant }
+}
+
 // WithBrowserCookies enables reading cookies from browser stores.
 func WithBrowserCookies() Option {
 	return func(c *config) { c.browserCookies = true }
 }
 
+// WithLinkedInProxy sends LinkedIn requests through the HTTP proxy at proxyURL, so a
+// LinkedIn session is always used from the same address.
+func WithLinkedInProxy(proxyURL *neturl.URL) Option {
+	return func(c *config) { c.linkedInProxy = proxyURL }
+}
+
 // WithHTTPCache sets the HTTP cache for responses.
 func WithHTTPCache(httpCache cache.HTTPCache) Option {
 	return func(c *config) { c.cache = httpCache }
@@ -103,14 +200,346 @@ func WithGitHubToken(token string) Option {
 	return func(c *config) { c.githubToken = token }
 }
 
+// WithGitHubGists makes GitHub fetches scan the user's personal public gists, such as
+// a resume or dotfiles, for email addresses and links. It costs one more API request.
+func WithGitHubGists() Option {
+	return func(c *config) { c.githubGists = true }
+}
+
+// WithDepth sets how much each platform fetches beyond the primary request:
+// DepthMinimal skips HTML second fetches, posts, and contact pages; DepthDeep
+// adds more posts and site feeds. The default is DepthStandard.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
+// ParseDepth parses "minimal", "standard", or "deep".
+func ParseDepth(s string) (Depth, error) {
+	return profile.ParseDepth(s)
+}
+
+// WithUsernameProbes makes guessing check each platform's existence endpoint for a
+// username before fetching candidates, skipping platforms where it is not registered
+// or is held by a brand or squatter.
+func WithUsernameProbes() Option {
+	return func(c *config) { c.usernameProbes = true }
+}
+
+// WithOfflineMode makes every fetch answer only from the HTTP cache, failing with
+// ErrNotCached where the network would be needed. Runs against a filled cache are then
+// reproducible and need no connection.
+func WithOfflineMode(offline bool) Option {
+	return func(c *config) { c.offline = offline }
+}
+
+// WithStaleWhileRevalidate answers from cached responses older than maxAge at once and
+// refreshes them in the background, trading one stale answer for low latency (see
+// cache.WithStaleWhileRevalidate). maxAge should be shorter than the cache TTL.
+func WithStaleWhileRevalidate(maxAge time.Duration) Option {
+	return func(c *config) { c.staleAfter = maxAge }
+}
+
+// WithUserAgent identifies requests with ua instead of the default, which names
+// sociopath, its version, and cache.ContactURL. Platforms that block unknown clients
+// still see a browser's User-Agent unless WithoutBrowserEmulation is set.
+func WithUserAgent(ua string) Option {
+	return func(c *config) { c.identity.UserAgent = ua }
+}
+
+// WithContact adds a way to reach the operator to identified requests: an email
+// address is sent as the From header, anything else, such as a URL, as X-Contact.
+func WithContact(contact string) Option {
+	return func(c *config) { c.identity.Contact = contact }
+}
+
+// WithoutBrowserEmulation identifies every request, including those to platforms that
+// are otherwise fetched with a browser's User-Agent. Those platforms may then refuse
+// or degrade their answers.
+func WithoutBrowserEmulation() Option {
+	return func(c *config) { c.identity.Everywhere = true }
+}
+
+// WithRootCAs verifies servers against roots instead of the system's, for networks
+// whose proxies intercept TLS with a certificate of their own. Include the system
+// roots (x509.SystemCertPool) in roots to trust both.
+func WithRootCAs(roots *x509.CertPool) Option {
+	return func(c *config) { c.network.RootCAs = roots }
+}
+
+// WithClientCert presents cert to servers, such as egress proxies, that require a
+// client certificate.
+func WithClientCert(cert tls.Certificate) Option {
+	p := &cert // One pointer for every fetch, so they share connections
+	return func(c *config) { c.network.Certificate = p }
+}
+
+// WithIPVersion connects over IPv4 only (4) or IPv6 only (6), for platforms that
+// treat the two differently.
+func WithIPVersion(version int) Option {
+	return func(c *config) { c.network.IPVersion = version }
+}
+
+// WithLocalAddr sends requests from addr, one of the host's addresses, so traffic
+// leaves through a chosen egress IP. cache.InterfaceAddr finds an interface's address.
+func WithLocalAddr(addr netip.Addr) Option {
+	return func(c *config) { c.network.LocalAddr = addr }
+}
+
+// WithResolver looks up hosts with r instead of the system resolver, for example a
+// cache.DoHResolver to keep lookups private. Personal sites' addresses are checked
+// against private ranges as r returns them, so the check matches the connection.
+// r must be comparable, such as a pointer.
+func WithResolver(r cache.Resolver) Option {
+	return func(c *config) { c.network.Resolver = r }
+}
+
+// WithRequestHook calls hook on every request a platform client sends, just before
+// it goes out, so callers can add headers such as proxy credentials without changing
+// the platform packages. Hooks run in the order added, after the identity headers are
+// set; requests answered from the cache never reach them.
+func WithRequestHook(hook func(*http.Request)) Option {
+	return func(c *config) { c.requestHooks = append(c.requestHooks, hook) }
+}
+
+// WithBotScores adds Fields["bot_score"] and Fields["bot_signals"] to every fetched
+// profile, estimating how likely the account is automated or spam (see analysis.BotScore).
+func WithBotScores() Option {
+	return func(c *config) { c.botScores = true }
+}
+
+// WithEmployerInference maps company email domains found in a crawl to employers with
+// provider, or analysis.DomainCompany if provider is nil, and records where they agree
+// with the profiles' employer fields (see analysis.InferEmployer).
+func WithEmployerInference(provider analysis.CompanyProvider) Option {
+	return func(c *config) {
+		c.inferEmployer = true
+		c.companies = provider
+	}
+}
+
+// WithTagger runs t over every fetched profile's bio and posts, storing its topic
+// tags in Fields["topics"] and any sentiment in Fields["sentiment"]. Tagging errors
+// are logged and do not fail the fetch. analysis.NewKeywordTagger is a built-in Tagger.
+func WithTagger(t analysis.Tagger) Option {
+	return func(c *config) { c.tagger = t }
+}
+
+// WithBreachChecker checks every email found on a fetched profile with c and stores
+// only whether any appears in a known breach, and in how many, in Fields["breached"]
+// and Fields["breach_count"]. Checking is off unless this option is given.
+func WithBreachChecker(c breach.Checker) Option {
+	return func(cfg *config) { cfg.breaches = c }
+}
+
+// WithRenderer renders generic websites whose static HTML is an empty JavaScript
+// app shell (React, Vue, and similar) so they still yield content.
+// generic.FindChrome returns a Renderer backed by a local headless Chrome.
+func WithRenderer(r generic.Renderer) Option {
+	return func(c *config) { c.renderer = r }
+}
+
+// WithSearchProvider lets guessing search the web for the known names, qualified
+// by employer, when probing usernames does not cover a platform.
+func WithSearchProvider(p searchengine.Provider) Option {
+	return func(c *config) { c.search = p }
+}
+
+// VisitedSet records URLs fetched by earlier crawls. *visited.Set implements it.
+type VisitedSet interface {
+	Contains(url string) bool
+	Add(url string)
+}
+
+// WithVisitedSet makes FetchRecursive skip non-seed URLs already in v and add every
+// URL it fetches, so a restarted crawl does not refetch what earlier runs covered.
+func WithVisitedSet(v VisitedSet) Option {
+	return func(c *config) { c.visited = v }
+}
+
+// WithFetchLimits sets the content types and body size fetches accept, replacing
+// cache.DefaultContentTypes and cache.DefaultMaxBodyBytes. Responses outside them fail
+// with a *cache.ContentTypeError or *cache.BodyTooLargeError.
+func WithFetchLimits(l cache.Limits) Option {
+	return func(c *config) { c.limits = &l }
+}
+
+// WithBlobStore makes crawls keep Unstructured text and post contents of at least
+// minSize bytes (blob.DefaultMinSize if zero) in s, so the profiles a crawl holds and
+// saves carry references to them instead. Read the text back with blob.Inline.
+func WithBlobStore(s blob.Store, minSize int) Option {
+	return func(c *config) { c.blobs, c.blobMinSize = s, minSize }
+}
+
+// WithCrawlIdle sets how long a Crawler sharing a run with other processes waits on
+// an empty frontier for them to queue more links before it stops (default 30s).
+func WithCrawlIdle(d time.Duration) Option {
+	return func(c *config) { c.crawlIdle = d }
+}
+
+// WithLinkGraph makes FetchRecursive record every fetched profile and the profile
+// links it contains into g, whether or not those links are followed.
+func WithLinkGraph(g *linkgraph.Graph) Option {
+	return func(c *config) { c.graph = g }
+}
+
+// SummarizeReach totals followers and account ages across profiles returned by
+// FetchRecursive, FetchRecursiveWithGuess, or GuessFromUsername.
+func SummarizeReach(profiles []*profile.Profile) Reach {
+	return profile.SummarizeReach(profiles)
+}
+
+// SummarizeFootprint combines the repositories, stars, languages, organizations, and
+// package registry accounts of profiles returned by FetchRecursive,
+// FetchRecursiveWithGuess, or GuessFromUsername.
+func SummarizeFootprint(profiles []*profile.Profile) Footprint {
+	return profile.SummarizeFootprint(profiles)
+}
+
+// BuildTimeline reconstructs an employment timeline from profiles returned by
+// FetchRecursive, FetchRecursiveWithGuess, or GuessFromUsername.
+func BuildTimeline(profiles []*profile.Profile) Timeline {
+	return analysis.BuildTimeline(profiles)
+}
+
 // Fetch retrieves a profile from the given URL.
-// The platform is automatically detected from the URL.
+// The platform is automatically detected from the URL. Links to a domain the platform
+// has moved away from, such as vkontakte.ru, are fetched from the current one.
 func Fetch(ctx context.Context, url string, opts ...Option) (*profile.Profile, error) {
 	cfg := &config{logger: slog.Default()}
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	ctx = modeContext(ctx, cfg)
+	url = links.MigrateURL(url)
+
+	cfg, err := checkFeatures(cfg, url)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPolicy(ctx, cfg, url); err != nil {
+		return nil, err
+	}
+	if err := checkQuota(ctx, cfg, url); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	p, err := fetch(ctx, url, cfg)
+	if p != nil {
+		p.AddRequestedAlias(url)
+		finish(ctx, cfg, p)
+	}
+	recordAudit(ctx, cfg, url, start, p, err)
+	return p, err
+}
+
+// modesKey marks a context modeContext has already prepared.
+type modesKey struct{}
+
+// modeContext marks ctx with the fetch modes cfg enables for lower layers, such as
+// offline mode, stale-while-revalidate, and how requests are identified and sent for
+// cache.FetchURL. Crawls prepare ctx once and pass it to Fetch with the same options,
+// so a ctx already prepared is returned as is; request hooks would otherwise run once
+// per preparation.
+func modeContext(ctx context.Context, cfg *config) context.Context {
+	if ctx.Value(modesKey{}) != nil {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, modesKey{}, true)
+	if cfg.offline {
+		ctx = cache.WithOffline(ctx)
+	}
+	if cfg.staleAfter > 0 {
+		ctx = cache.WithStaleWhileRevalidate(ctx, cfg.staleAfter)
+	}
+	if cfg.identity != (cache.Identity{}) {
+		ctx = cache.WithIdentity(ctx, cfg.identity)
+	}
+	if cfg.network != (cache.Network{}) {
+		ctx = cache.WithNetwork(ctx, cfg.network)
+	}
+	if cfg.limits != nil {
+		ctx = cache.WithLimits(ctx, *cfg.limits)
+	}
+	for _, hook := range cfg.requestHooks {
+		ctx = cache.WithRequestHook(ctx, hook)
+	}
+	if cfg.tenant != "" {
+		ctx = cache.WithTenant(ctx, cfg.tenant)
+	}
+	return ctx
+}
+
+// finish normalizes a fetched profile and applies the configured annotations.
+func finish(ctx context.Context, cfg *config, p *profile.Profile) {
+	stripDisabled(cfg, p)
+	// Entities, NBSP, and zero-width characters survive some platforms' extraction
+	p.Clean()
+	// Platforms report counts and dates however their pages display them
+	p.Normalize()
+	p.AddBioHints()
+	p.AddAliases()
+	p.AddNameAndBioSignals()
+	p.ClassifyTitle()
+	p.SocialLinks = links.DefaultDenylist.Filter(p.SocialLinks)
+	// ENS names in bios ("vitalik.eth") are followed like profile links
+	if names := ens.FindNames(p.Bio); len(names) > 0 && p.Platform != "ens" {
+		for _, name := range names {
+			p.SocialLinks = append(p.SocialLinks, ens.URL(name))
+		}
+		p.SocialLinks = links.Dedupe(p.SocialLinks)
+	}
+	// So are fediverse handles ("@alice@hachyderm.io")
+	if handles := mastodon.FindHandles(p.Bio); len(handles) > 0 {
+		for _, handle := range handles {
+			p.SocialLinks = append(p.SocialLinks, mastodon.HandleURL(handle))
+		}
+		p.SocialLinks = links.Dedupe(p.SocialLinks)
+	}
+	if cfg.botScores {
+		analysis.AnnotateBotScore(p)
+	}
+	if cfg.tagger != nil {
+		if err := analysis.ApplyTags(ctx, cfg.tagger, p); err != nil {
+			cfg.logger.WarnContext(ctx, "tagging failed", "url", p.URL, "error", err)
+		}
+	}
+	if cfg.breaches != nil {
+		if err := breach.Apply(ctx, cfg.breaches, p); err != nil {
+			cfg.logger.WarnContext(ctx, "breach check failed", "url", p.URL, "error", err)
+		}
+	}
+}
+
+// FetchTeam finds the team pages on a company's website, such as "example.com",
+// and returns a profile for each person listed there.
+func FetchTeam(ctx context.Context, domain string, opts ...Option) ([]*profile.Profile, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	ctx = modeContext(ctx, cfg)
+
+	var topts []teampage.Option
+	if cfg.cache != nil {
+		topts = append(topts, teampage.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		topts = append(topts, teampage.WithLogger(cfg.logger))
+	}
+	client, err := teampage.New(ctx, topts...)
+	if err != nil {
+		return nil, err
+	}
+	people, err := client.Fetch(ctx, domain)
+	for _, p := range people {
+		finish(ctx, cfg, p)
+	}
+	return people, err
+}
 
+// fetch dispatches url to the fetcher for its platform.
+func fetch(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	// Try each platform's Match function in order of specificity
 	// Note: Order matters! More specific patterns should come before generic ones.
 	// TikTok must come before Mastodon because Mastodon matches /@username pattern.
@@ -136,6 +565,36 @@ func Fetch(ctx context.Context, url string, opts ...Option) (*profile.Profile, e
 		return fetchBilibili(ctx, url, cfg)
 	case codeberg.Match(url):
 		return fetchCodeberg(ctx, url, cfg)
+	case calcom.Match(url):
+		return fetchCalCom(ctx, url, cfg)
+	case calendly.Match(url):
+		return fetchCalendly(ctx, url, cfg)
+	case polywork.Match(url):
+		return fetchPolywork(ctx, url, cfg)
+	case readcv.Match(url):
+		return fetchReadCV(ctx, url, cfg)
+	case soundcloud.Match(url):
+		return fetchSoundCloud(ctx, url, cfg)
+	case fivehundredpx.Match(url):
+		return fetchFiveHundredPx(ctx, url, cfg)
+	case flickr.Match(url):
+		return fetchFlickr(ctx, url, cfg)
+	case discord.Match(url):
+		return fetchDiscord(ctx, url, cfg)
+	case steam.Match(url):
+		return fetchSteam(ctx, url, cfg)
+	case itchio.Match(url):
+		return fetchItchIO(ctx, url, cfg)
+	case githubsponsors.Match(url):
+		return fetchGitHubSponsors(ctx, url, cfg)
+	case kofi.Match(url):
+		return fetchKoFi(ctx, url, cfg)
+	case patreon.Match(url):
+		return fetchPatreon(ctx, url, cfg)
+	case meetup.Match(url):
+		return fetchMeetup(ctx, url, cfg)
+	case sessionize.Match(url):
+		return fetchSessionize(ctx, url, cfg)
 	case bluesky.Match(url):
 		return fetchBlueSky(ctx, url, cfg)
 	case devto.Match(url):
@@ -152,37 +611,67 @@ func Fetch(ctx context.Context, url string, opts ...Option) (*profile.Profile, e
 		return fetchVKontakte(ctx, url, cfg)
 	case weibo.Match(url):
 		return fetchWeibo(ctx, url, cfg)
+	case peertube.Match(url):
+		return fetchPeerTube(ctx, url, cfg)
+	case writefreely.Match(url):
+		return fetchWriteFreely(ctx, url, cfg)
+	case nostr.Match(url):
+		return fetchNostr(ctx, url, cfg)
+	case ens.Match(url):
+		return fetchENS(ctx, url, cfg)
+	case pgp.Match(url):
+		return fetchPGP(ctx, url, cfg)
 	case mastodon.Match(url):
 		return fetchMastodon(ctx, url, cfg)
 	default:
+		// Fediverse servers of every kind answer with an actor object; other sites
+		// send their page, and the minimal depth allows only that one request. URLs
+		// generic refuses to fetch are not tried as actors either.
+		if cfg.depth != profile.DepthMinimal && generic.ValidateURL(url) == nil {
+			if p, err := fetchActivityPub(ctx, url, cfg); err == nil {
+				return p, nil
+			}
+		}
 		return fetchGeneric(ctx, url, cfg)
 	}
 }
 
 func fetchLinkedIn(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	client, err := newLinkedInClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func newLinkedInClient(ctx context.Context, cfg *config) (*linkedin.Client, error) {
 	var opts []linkedin.Option
+	if cfg.anonymous {
+		opts = append(opts, linkedin.WithoutCookies())
+	}
 	if len(cfg.cookies) > 0 {
 		opts = append(opts, linkedin.WithCookies(cfg.cookies))
 	}
 	if cfg.browserCookies {
 		opts = append(opts, linkedin.WithBrowserCookies())
 	}
+	if cfg.linkedInProxy != nil {
+		opts = append(opts, linkedin.WithProxy(cfg.linkedInProxy))
+	}
 	if cfg.cache != nil {
 		opts = append(opts, linkedin.WithHTTPCache(cfg.cache))
 	}
 	if cfg.logger != nil {
 		opts = append(opts, linkedin.WithLogger(cfg.logger))
 	}
-
-	client, err := linkedin.New(ctx, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return client.Fetch(ctx, url)
+	return linkedin.New(ctx, opts...)
 }
 
 func fetchTwitter(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []twitter.Option
+	if cfg.anonymous {
+		opts = append(opts, twitter.WithoutCookies())
+	}
 	if len(cfg.cookies) > 0 {
 		opts = append(opts, twitter.WithCookies(cfg.cookies))
 	}
@@ -211,6 +700,9 @@ func fetchMastodon(ctx context.Context, url string, cfg *config) (*profile.Profi
 	if cfg.logger != nil {
 		opts = append(opts, mastodon.WithLogger(cfg.logger))
 	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, mastodon.WithDepth(cfg.depth))
+	}
 
 	client, err := mastodon.New(ctx, opts...)
 	if err != nil {
@@ -219,6 +711,111 @@ func fetchMastodon(ctx context.Context, url string, cfg *config) (*profile.Profi
 	return client.Fetch(ctx, url)
 }
 
+func fetchActivityPub(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []activitypub.Option
+	if cfg.cache != nil {
+		opts = append(opts, activitypub.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, activitypub.WithLogger(cfg.logger))
+	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, activitypub.WithDepth(cfg.depth))
+	}
+
+	client, err := activitypub.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchPeerTube(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []peertube.Option
+	if cfg.cache != nil {
+		opts = append(opts, peertube.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, peertube.WithLogger(cfg.logger))
+	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, peertube.WithDepth(cfg.depth))
+	}
+
+	client, err := peertube.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchWriteFreely(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []writefreely.Option
+	if cfg.cache != nil {
+		opts = append(opts, writefreely.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, writefreely.WithLogger(cfg.logger))
+	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, writefreely.WithDepth(cfg.depth))
+	}
+
+	client, err := writefreely.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchNostr(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []nostr.Option
+	if cfg.cache != nil {
+		opts = append(opts, nostr.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, nostr.WithLogger(cfg.logger))
+	}
+
+	client, err := nostr.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchENS(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []ens.Option
+	if cfg.cache != nil {
+		opts = append(opts, ens.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, ens.WithLogger(cfg.logger))
+	}
+
+	client, err := ens.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchPGP(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []pgp.Option
+	if cfg.cache != nil {
+		opts = append(opts, pgp.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, pgp.WithLogger(cfg.logger))
+	}
+
+	client, err := pgp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
 func fetchBlueSky(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []bluesky.Option
 	if cfg.cache != nil {
@@ -227,6 +824,9 @@ func fetchBlueSky(ctx context.Context, url string, cfg *config) (*profile.Profil
 	if cfg.logger != nil {
 		opts = append(opts, bluesky.WithLogger(cfg.logger))
 	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, bluesky.WithDepth(cfg.depth))
+	}
 
 	client, err := bluesky.New(ctx, opts...)
 	if err != nil {
@@ -243,6 +843,9 @@ func fetchDevTo(ctx context.Context, url string, cfg *config) (*profile.Profile,
 	if cfg.logger != nil {
 		opts = append(opts, devto.WithLogger(cfg.logger))
 	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, devto.WithDepth(cfg.depth))
+	}
 
 	client, err := devto.New(ctx, opts...)
 	if err != nil {
@@ -298,6 +901,9 @@ func fetchInstagram(ctx context.Context, url string, cfg *config) (*profile.Prof
 
 func fetchTikTok(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []tiktok.Option
+	if cfg.anonymous {
+		opts = append(opts, tiktok.WithoutCookies())
+	}
 	if len(cfg.cookies) > 0 {
 		opts = append(opts, tiktok.WithCookies(cfg.cookies))
 	}
@@ -317,6 +923,9 @@ func fetchTikTok(ctx context.Context, url string, cfg *config) (*profile.Profile
 
 func fetchVKontakte(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []vkontakte.Option
+	if cfg.anonymous {
+		opts = append(opts, vkontakte.WithoutCookies())
+	}
 	if len(cfg.cookies) > 0 {
 		opts = append(opts, vkontakte.WithCookies(cfg.cookies))
 	}
@@ -336,6 +945,9 @@ func fetchVKontakte(ctx context.Context, url string, cfg *config) (*profile.Prof
 
 func fetchWeibo(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []weibo.Option
+	if cfg.anonymous {
+		opts = append(opts, weibo.WithoutCookies())
+	}
 	if len(cfg.cookies) > 0 {
 		opts = append(opts, weibo.WithCookies(cfg.cookies))
 	}
@@ -373,6 +985,14 @@ func fetchLinktree(ctx context.Context, url string, cfg *config) (*profile.Profi
 }
 
 func fetchGitHub(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	client, err := newGitHubClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func newGitHubClient(ctx context.Context, cfg *config) (*github.Client, error) {
 	var opts []github.Option
 	if cfg.cache != nil {
 		opts = append(opts, github.WithHTTPCache(cfg.cache))
@@ -380,15 +1000,16 @@ func fetchGitHub(ctx context.Context, url string, cfg *config) (*profile.Profile
 	if cfg.logger != nil {
 		opts = append(opts, github.WithLogger(cfg.logger))
 	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, github.WithDepth(cfg.depth))
+	}
 	if cfg.githubToken != "" {
 		opts = append(opts, github.WithToken(cfg.githubToken))
 	}
-
-	client, err := github.New(ctx, opts...)
-	if err != nil {
-		return nil, err
+	if cfg.githubGists {
+		opts = append(opts, github.WithGists())
 	}
-	return client.Fetch(ctx, url)
+	return github.New(ctx, opts...)
 }
 
 func fetchMedium(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
@@ -487,6 +1108,246 @@ func fetchCodeberg(ctx context.Context, url string, cfg *config) (*profile.Profi
 	return client.Fetch(ctx, url)
 }
 
+func fetchSessionize(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []sessionize.Option
+	if cfg.cache != nil {
+		opts = append(opts, sessionize.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, sessionize.WithLogger(cfg.logger))
+	}
+
+	client, err := sessionize.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchMeetup(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []meetup.Option
+	if cfg.cache != nil {
+		opts = append(opts, meetup.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, meetup.WithLogger(cfg.logger))
+	}
+
+	client, err := meetup.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchPatreon(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []patreon.Option
+	if cfg.cache != nil {
+		opts = append(opts, patreon.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, patreon.WithLogger(cfg.logger))
+	}
+
+	client, err := patreon.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchKoFi(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []kofi.Option
+	if cfg.cache != nil {
+		opts = append(opts, kofi.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, kofi.WithLogger(cfg.logger))
+	}
+
+	client, err := kofi.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchGitHubSponsors(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []githubsponsors.Option
+	if cfg.cache != nil {
+		opts = append(opts, githubsponsors.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, githubsponsors.WithLogger(cfg.logger))
+	}
+
+	client, err := githubsponsors.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchItchIO(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []itchio.Option
+	if cfg.cache != nil {
+		opts = append(opts, itchio.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, itchio.WithLogger(cfg.logger))
+	}
+
+	client, err := itchio.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchSteam(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []steam.Option
+	if cfg.cache != nil {
+		opts = append(opts, steam.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, steam.WithLogger(cfg.logger))
+	}
+
+	client, err := steam.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchDiscord(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []discord.Option
+	if cfg.cache != nil {
+		opts = append(opts, discord.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, discord.WithLogger(cfg.logger))
+	}
+
+	client, err := discord.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchFlickr(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []flickr.Option
+	if cfg.cache != nil {
+		opts = append(opts, flickr.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, flickr.WithLogger(cfg.logger))
+	}
+
+	client, err := flickr.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchFiveHundredPx(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []fivehundredpx.Option
+	if cfg.cache != nil {
+		opts = append(opts, fivehundredpx.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, fivehundredpx.WithLogger(cfg.logger))
+	}
+
+	client, err := fivehundredpx.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchSoundCloud(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []soundcloud.Option
+	if cfg.cache != nil {
+		opts = append(opts, soundcloud.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, soundcloud.WithLogger(cfg.logger))
+	}
+
+	client, err := soundcloud.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchReadCV(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []readcv.Option
+	if cfg.cache != nil {
+		opts = append(opts, readcv.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, readcv.WithLogger(cfg.logger))
+	}
+
+	client, err := readcv.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchPolywork(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []polywork.Option
+	if cfg.cache != nil {
+		opts = append(opts, polywork.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, polywork.WithLogger(cfg.logger))
+	}
+
+	client, err := polywork.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchCalendly(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []calendly.Option
+	if cfg.cache != nil {
+		opts = append(opts, calendly.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, calendly.WithLogger(cfg.logger))
+	}
+
+	client, err := calendly.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
+func fetchCalCom(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
+	var opts []calcom.Option
+	if cfg.cache != nil {
+		opts = append(opts, calcom.WithHTTPCache(cfg.cache))
+	}
+	if cfg.logger != nil {
+		opts = append(opts, calcom.WithLogger(cfg.logger))
+	}
+
+	client, err := calcom.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return client.Fetch(ctx, url)
+}
+
 func fetchGeneric(ctx context.Context, url string, cfg *config) (*profile.Profile, error) {
 	var opts []generic.Option
 	if cfg.cache != nil {
@@ -495,6 +1356,12 @@ func fetchGeneric(ctx context.Context, url string, cfg *config) (*profile.Profil
 	if cfg.logger != nil {
 		opts = append(opts, generic.WithLogger(cfg.logger))
 	}
+	if cfg.depth != profile.DepthStandard {
+		opts = append(opts, generic.WithDepth(cfg.depth))
+	}
+	if cfg.renderer != nil {
+		opts = append(opts, generic.WithRenderer(cfg.renderer))
+	}
 
 	client, err := generic.New(ctx, opts...)
 	if err != nil {
@@ -508,125 +1375,77 @@ func fetchGeneric(ctx context.Context, url string, cfg *config) (*profile.Profil
 // Only links that match known social media platforms are followed.
 // For platforms with single-account-per-person assumption (GitHub, LinkedIn, Twitter, etc.),
 // it skips recursing into additional profiles from the same platform.
+// Profiles that turn out to describe the same account or repeat one another, such as
+// twitter.com and x.com links to one handle, are merged with profile.Dedupe.
+// Use a Crawler to keep the crawl state in a store so it can be resumed.
 func FetchRecursive(ctx context.Context, url string, opts ...Option) ([]*profile.Profile, error) {
 	cfg := &config{logger: slog.Default()}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
-	visited := make(map[string]bool)
-	var profiles []*profile.Profile
-	initialPlatform := "" // Track the platform we started from
-
-	type queueItem struct {
-		url   string
-		depth int
+	st := &memoryState{visited: make(map[string]bool), queue: []queueItem{{url: url, depth: 0}}}
+	if err := crawl(ctx, cfg, opts, st); err != nil {
+		return nil, err
 	}
-	const maxDepth = 3
-	const maxLinksPerPage = 8
-
-	queue := []queueItem{{url: url, depth: 0}}
-	for len(queue) > 0 {
-		item := queue[0]
-		queue = queue[1:]
-
-		normalizedURL := normalizeURL(item.url)
-		if visited[normalizedURL] {
-			continue
-		}
-		visited[normalizedURL] = true
-
-		cfg.logger.InfoContext(ctx, "fetching profile", "url", item.url, "depth", item.depth, "visited", len(visited))
-
-		p, err := Fetch(ctx, item.url, opts...)
-		if err != nil {
-			// For auth-required platforms, try generic parser on any error (except LinkedIn)
-			// LinkedIn's generic HTML contains dozens of "People Also Viewed" links that cause runaway crawling
-			tryGeneric := (twitter.Match(item.url) || instagram.Match(item.url) ||
-				tiktok.Match(item.url) || vkontakte.Match(item.url)) && !linkedin.Match(item.url)
-
-			if !tryGeneric {
-				cfg.logger.WarnContext(ctx, "failed to fetch profile", "url", item.url, "error", err)
-				// If it's an auth-related error, add a stub profile with the error
-				if errors.Is(err, profile.ErrNoCookies) || errors.Is(err, profile.ErrAuthRequired) {
-					profiles = append(profiles, &profile.Profile{
-						Platform: PlatformForURL(item.url),
-						URL:      item.url,
-						Error:    "login required",
-					})
-				}
-				continue
-			}
-
-			cfg.logger.InfoContext(ctx, "fetch failed, trying generic parser", "url", item.url, "error", err)
-			p, err = fetchGeneric(ctx, item.url, cfg)
-			if err != nil {
-				cfg.logger.WarnContext(ctx, "generic fetch also failed", "url", item.url, "error", err)
-				continue
-			}
-		}
-		profiles = append(profiles, p)
-
-		// Remember the platform we started from (depth 0)
-		if item.depth == 0 {
-			initialPlatform = p.Platform
-		}
-
-		// Don't crawl further if we've hit max depth
-		if item.depth >= maxDepth {
-			continue
-		}
-
-		// From generic pages, only follow known social platform links to avoid runaway crawling
-		onlyKnownPlatforms := p.Platform == "generic"
-
-		// Collect links to queue, then limit
-		var linksToQueue []string
-
-		// Queue social links for crawling
-		for _, link := range p.SocialLinks {
-			if !visited[normalizeURL(link)] && isValidProfileURL(link) {
-				// Skip links that are the same platform as our initial URL (single-account-per-person platforms)
-				if isSingleAccountPlatform(initialPlatform) && platformMatches(link, initialPlatform) {
-					continue
-				}
-
-				// For generic pages, only follow if it's a known social platform or same-domain contact/about page
-				if !onlyKnownPlatforms || isSocialPlatform(link) || isSameDomainContactPage(link, item.url) {
-					linksToQueue = append(linksToQueue, link)
-				}
-			}
-		}
+	return finishCrawl(ctx, cfg, st.profiles), nil
+}
 
-		// Also queue website if present
-		if p.Website != "" && !visited[normalizeURL(p.Website)] {
-			linksToQueue = append(linksToQueue, p.Website)
+// finishCrawl merges duplicate profiles in a crawl's results and runs the analyses
+// that compare profiles with one another.
+func finishCrawl(ctx context.Context, cfg *config, profiles []*profile.Profile) []*profile.Profile {
+	profiles = profile.Dedupe(profiles)
+	if cfg.inferEmployer {
+		if err := analysis.InferEmployer(ctx, cfg.companies, profiles); err != nil {
+			cfg.logger.WarnContext(ctx, "employer inference failed", "error", err)
 		}
+	}
+	return profiles
+}
 
-		// Queue links from Fields map (sorted for deterministic iteration order)
-		keys := make([]string, 0, len(p.Fields))
-		for k := range p.Fields {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, k := range keys {
-			v := p.Fields[k]
-			if isLikelySocialURL(k, v) && !visited[normalizeURL(v)] {
-				linksToQueue = append(linksToQueue, v)
-			}
-		}
+// recordLinks adds the profile fetched from url and its outgoing profile links to g,
+// using the same link sources the crawler follows.
+func recordLinks(g *linkgraph.Graph, id, url string, p *profile.Profile) {
+	g.AddNode(linkgraph.Node{ID: id, URL: url, Platform: p.Platform, Username: p.Username, Fetched: true})
 
-		// Limit links per page to avoid explosion
-		if len(linksToQueue) > maxLinksPerPage {
-			linksToQueue = linksToQueue[:maxLinksPerPage]
+	for _, link := range p.SocialLinks {
+		if isValidProfileURL(link) {
+			g.AddEdge(id, normalizeURL(link), link, linkEdgeType(p, link))
 		}
-
-		for _, link := range linksToQueue {
-			queue = append(queue, queueItem{url: link, depth: item.depth + 1})
+	}
+	if p.Website != "" {
+		g.AddEdge(id, normalizeURL(p.Website), p.Website, linkgraph.EdgeWebsite)
+	}
+	keys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v := p.Fields[k]; isLikelySocialURL(k, v) && normalizeURL(v) != normalizeURL(p.Website) {
+			g.AddEdge(id, normalizeURL(v), v, linkgraph.EdgeField)
 		}
 	}
+}
 
-	return profiles, nil
+// linkEdgeType guesses where on p a social link came from: links mentioned in the bio
+// or README text are weaker evidence than declared profile links. Every link on an
+// ENS profile comes from a text record.
+func linkEdgeType(p *profile.Profile, link string) linkgraph.EdgeType {
+	bare := normalizeURL(link)
+	if name := ens.NameFromURL(link); name != "" {
+		bare = name
+	}
+	switch {
+	case p.Platform == "ens":
+		return linkgraph.EdgeENS
+	case strings.Contains(strings.ToLower(p.Bio), bare):
+		return linkgraph.EdgeBio
+	case strings.Contains(strings.ToLower(p.Unstructured), bare):
+		return linkgraph.EdgeReadme
+	default:
+		return linkgraph.EdgeRelMe
+	}
 }
 
 // isValidProfileURL filters out URLs that are not actual user profiles.
@@ -648,6 +1467,21 @@ func isSocialPlatform(url string) bool {
 		linktree.Match(url) ||
 		github.Match(url) ||
 		codeberg.Match(url) ||
+		calcom.Match(url) ||
+		calendly.Match(url) ||
+		polywork.Match(url) ||
+		readcv.Match(url) ||
+		soundcloud.Match(url) ||
+		fivehundredpx.Match(url) ||
+		flickr.Match(url) ||
+		discord.Match(url) ||
+		steam.Match(url) ||
+		itchio.Match(url) ||
+		githubsponsors.Match(url) ||
+		kofi.Match(url) ||
+		patreon.Match(url) ||
+		meetup.Match(url) ||
+		sessionize.Match(url) ||
 		medium.Match(url) ||
 		reddit.Match(url) ||
 		youtube.Match(url) ||
@@ -662,6 +1496,11 @@ func isSocialPlatform(url string) bool {
 		instagram.Match(url) ||
 		tiktok.Match(url) ||
 		vkontakte.Match(url) ||
+		peertube.Match(url) ||
+		writefreely.Match(url) ||
+		nostr.Match(url) ||
+		ens.Match(url) ||
+		pgp.Match(url) ||
 		mastodon.Match(url)
 }
 
@@ -701,7 +1540,10 @@ func isSameDomainContactPage(link, baseURL string) bool {
 }
 
 // normalizeURL normalizes a URL for deduplication (removes trailing slash, lowercases host).
+// Mastodon remote views are normalized to the account's home server URL, and domains a
+// platform has moved away from to the current one.
 func normalizeURL(url string) string {
+	url = links.MigrateURL(mastodon.Canonical(url))
 	url = strings.TrimSuffix(url, "/")
 	url = strings.TrimPrefix(url, "https://")
 	url = strings.TrimPrefix(url, "http://")
@@ -729,7 +1571,10 @@ func isSingleAccountPlatform(platform string) bool {
 	switch platform {
 	case "github", "codeberg", "linkedin", "twitter", "reddit", "youtube",
 		"stackoverflow", "bluesky", "mastodon", "medium",
-		"instagram", "tiktok", "vkontakte":
+		"instagram", "tiktok", "vkontakte", "sessionize", "meetup",
+		"patreon", "kofi", "itchio", "steam", "flickr",
+		"fivehundredpx", "soundcloud", "readcv", "polywork",
+		"calendly", "calcom":
 		return true
 	default:
 		return false
@@ -739,6 +1584,7 @@ func isSingleAccountPlatform(platform string) bool {
 // PlatformForURL returns the platform name for a URL, or "generic" if unknown.
 // This uses the same matching logic as Fetch() to ensure consistency.
 func PlatformForURL(url string) string {
+	url = links.MigrateURL(url)
 	switch {
 	case linkedin.Match(url):
 		return "linkedin"
@@ -750,6 +1596,36 @@ func PlatformForURL(url string) string {
 		return "github"
 	case codeberg.Match(url):
 		return "codeberg"
+	case calcom.Match(url):
+		return "calcom"
+	case calendly.Match(url):
+		return "calendly"
+	case polywork.Match(url):
+		return "polywork"
+	case readcv.Match(url):
+		return "readcv"
+	case soundcloud.Match(url):
+		return "soundcloud"
+	case fivehundredpx.Match(url):
+		return "fivehundredpx"
+	case flickr.Match(url):
+		return "flickr"
+	case discord.Match(url):
+		return "discord"
+	case steam.Match(url):
+		return "steam"
+	case itchio.Match(url):
+		return "itchio"
+	case githubsponsors.Match(url):
+		return "githubsponsors"
+	case kofi.Match(url):
+		return "kofi"
+	case patreon.Match(url):
+		return "patreon"
+	case meetup.Match(url):
+		return "meetup"
+	case sessionize.Match(url):
+		return "sessionize"
 	case medium.Match(url):
 		return "medium"
 	case reddit.Match(url):
@@ -776,6 +1652,16 @@ func PlatformForURL(url string) string {
 		return "vkontakte"
 	case weibo.Match(url):
 		return "weibo"
+	case peertube.Match(url):
+		return "peertube"
+	case writefreely.Match(url):
+		return "writefreely"
+	case nostr.Match(url):
+		return "nostr"
+	case ens.Match(url):
+		return "ens"
+	case pgp.Match(url):
+		return "pgp"
 	case mastodon.Match(url):
 		return "mastodon"
 	default:
@@ -790,6 +1676,32 @@ func platformMatches(url, platform string) bool {
 		return github.Match(url)
 	case "codeberg":
 		return codeberg.Match(url)
+	case "calcom":
+		return calcom.Match(url)
+	case "calendly":
+		return calendly.Match(url)
+	case "polywork":
+		return polywork.Match(url)
+	case "readcv":
+		return readcv.Match(url)
+	case "soundcloud":
+		return soundcloud.Match(url)
+	case "fivehundredpx":
+		return fivehundredpx.Match(url)
+	case "flickr":
+		return flickr.Match(url)
+	case "steam":
+		return steam.Match(url)
+	case "itchio":
+		return itchio.Match(url)
+	case "kofi":
+		return kofi.Match(url)
+	case "patreon":
+		return patreon.Match(url)
+	case "meetup":
+		return meetup.Match(url)
+	case "sessionize":
+		return sessionize.Match(url)
 	case "linkedin":
 		return linkedin.Match(url)
 	case "twitter":
@@ -814,6 +1726,16 @@ func platformMatches(url, platform string) bool {
 		return vkontakte.Match(url)
 	case "weibo":
 		return weibo.Match(url)
+	case "peertube":
+		return peertube.Match(url)
+	case "writefreely":
+		return writefreely.Match(url)
+	case "nostr":
+		return nostr.Match(url)
+	case "ens":
+		return ens.Match(url)
+	case "pgp":
+		return pgp.Match(url)
 	default:
 		return false
 	}
@@ -833,6 +1755,7 @@ func FetchRecursiveWithGuess(ctx context.Context, url string, opts ...Option) ([
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	ctx = modeContext(ctx, cfg)
 
 	// Build fetcher function that wraps our Fetch
 	fetcher := func(ctx context.Context, url string) (*profile.Profile, error) {
@@ -840,18 +1763,10 @@ func FetchRecursiveWithGuess(ctx context.Context, url string, opts ...Option) ([
 	}
 
 	// Guess additional profiles
-	guessCfg := guess.Config{
-		Logger:           cfg.logger,
-		Fetcher:          fetcher,
-		PlatformDetector: PlatformForURL,
-	}
-
-	guessed := guess.Related(ctx, profiles, guessCfg)
+	guessed := guess.Related(ctx, profiles, guessConfig(ctx, cfg, fetcher))
 
-	// Append guessed profiles to result
-	profiles = append(profiles, guessed...)
-
-	return profiles, nil
+	// Append guessed profiles to result, merging guesses that found an account already crawled
+	return finishCrawl(ctx, cfg, append(profiles, guessed...)), nil
 }
 
 // GuessFromUsername guesses profiles across platforms based on a username.
@@ -863,6 +1778,7 @@ func GuessFromUsername(ctx context.Context, username string, opts ...Option) ([]
 	for _, opt := range opts {
 		opt(cfg)
 	}
+	ctx = modeContext(ctx, cfg)
 
 	// Create a synthetic profile with just the username
 	seedProfile := &profile.Profile{
@@ -876,13 +1792,26 @@ func GuessFromUsername(ctx context.Context, username string, opts ...Option) ([]
 	}
 
 	// Guess profiles
+	guessed := guess.Related(ctx, []*profile.Profile{seedProfile}, guessConfig(ctx, cfg, fetcher))
+
+	return finishCrawl(ctx, cfg, guessed), nil
+}
+
+// guessConfig builds the guess engine configuration for cfg.
+func guessConfig(ctx context.Context, cfg *config, fetcher guess.Fetcher) guess.Config {
 	guessCfg := guess.Config{
 		Logger:           cfg.logger,
 		Fetcher:          fetcher,
 		PlatformDetector: PlatformForURL,
+		Search:           cfg.search,
 	}
-
-	guessed := guess.Related(ctx, []*profile.Profile{seedProfile}, guessCfg)
-
-	return guessed, nil
+	if cfg.usernameProbes {
+		client, err := lookup.New(ctx, lookup.WithHTTPCache(cfg.cache), lookup.WithLogger(cfg.logger), lookup.WithProfileFetcher(lookup.ProfileFetcher(fetcher)))
+		if err != nil {
+			cfg.logger.WarnContext(ctx, "username probes unavailable", "error", err)
+		} else {
+			guessCfg.UsernameChecker = client.Registered
+		}
+	}
+	return guessCfg
 }
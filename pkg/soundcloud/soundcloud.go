@@ -0,0 +1,196 @@
+// Package soundcloud fetches SoundCloud artist profiles.
+package soundcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "soundcloud"
+
+var usernamePattern = regexp.MustCompile(`(?i)soundcloud\.com/([\w-]+)/?(?:[?#]|$)`)
+
+// nonProfiles are soundcloud.com paths that are not artists.
+var nonProfiles = map[string]bool{
+	"discover": true, "stream": true, "upload": true, "search": true, "you": true, "charts": true,
+	"pages": true, "terms-of-use": true, "messages": true, "notifications": true, "settings": true,
+	"signin": true, "logout": true, "imprint": true, "jobs": true, "pro": true, "mobile": true,
+}
+
+// Match returns true if the URL is a SoundCloud profile.
+func Match(urlStr string) bool {
+	return extractUsername(urlStr) != ""
+}
+
+// AuthRequired returns false because profiles are public.
+func AuthRequired() bool { return false }
+
+// Client handles SoundCloud requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a SoundCloud client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a SoundCloud profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+
+	normalizedURL := "https://soundcloud.com/" + strings.ToLower(username)
+	c.logger.InfoContext(ctx, "fetching soundcloud profile", "url", normalizedURL, "username", username)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseProfile(string(body), normalizedURL, username)
+}
+
+// Parse extracts a profile from a SoundCloud profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), "https://soundcloud.com/"+strings.ToLower(username), username)
+}
+
+// hydrationPattern captures the JSON the page boots from: window.__sc_hydration = [...];
+var hydrationPattern = regexp.MustCompile(`(?s)window\.__sc_hydration\s*=\s*(\[.*?\]);\s*</script>`)
+
+type hydratedUser struct {
+	Permalink      string `json:"permalink"`
+	Username       string `json:"username"`
+	FullName       string `json:"full_name"`
+	Description    string `json:"description"`
+	City           string `json:"city"`
+	CountryCode    string `json:"country_code"`
+	CreatedAt      string `json:"created_at"`
+	FollowersCount int    `json:"followers_count"`
+	FollowingCount int    `json:"followings_count"`
+	TrackCount     int    `json:"track_count"`
+	Verified       bool   `json:"verified"`
+}
+
+func parseProfile(content, urlStr, username string) (*profile.Profile, error) {
+	m := hydrationPattern.FindStringSubmatch(content)
+	if m == nil {
+		return nil, errors.New("profile not found (no hydration data)")
+	}
+	var entries []struct {
+		Hydratable string          `json:"hydratable"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(m[1]), &entries); err != nil {
+		return nil, fmt.Errorf("parse soundcloud hydration: %w", err)
+	}
+
+	var user hydratedUser
+	for _, e := range entries {
+		if e.Hydratable == "user" {
+			if err := json.Unmarshal(e.Data, &user); err != nil {
+				return nil, fmt.Errorf("parse soundcloud user: %w", err)
+			}
+			break
+		}
+	}
+	if user.Permalink == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	p := &profile.Profile{
+		Platform:  platform,
+		URL:       urlStr,
+		Username:  user.Permalink,
+		Name:      strings.TrimSpace(user.FullName),
+		Bio:       strings.TrimSpace(user.Description),
+		CreatedAt: user.CreatedAt,
+		Fields: map[string]string{
+			profile.FieldFollowers: strconv.Itoa(user.FollowersCount),
+			profile.FieldFollowing: strconv.Itoa(user.FollowingCount),
+			"tracks":               strconv.Itoa(user.TrackCount),
+		},
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSpace(user.Username)
+	}
+	if user.Username != "" && user.Username != p.Name {
+		p.Fields["display_name"] = user.Username
+	}
+	switch {
+	case user.City != "" && user.CountryCode != "":
+		p.Location = user.City + ", " + user.CountryCode
+	default:
+		p.Location = user.City + user.CountryCode
+	}
+	if user.Verified {
+		p.Fields["verified"] = "true"
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(p.Bio), Match)
+	return p, nil
+}
+
+func extractUsername(urlStr string) string {
+	m := usernamePattern.FindStringSubmatch(urlStr)
+	if m == nil || nonProfiles[strings.ToLower(m[1])] {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,68 @@
+package soundcloud
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://soundcloud.com/janedoe", true},
+		{"https://soundcloud.com/jane-doe-music/", true},
+		{"https://m.soundcloud.com/janedoe", true},
+		{"https://soundcloud.com/janedoe/first-track", false},
+		{"https://soundcloud.com/discover", false},
+		{"https://soundcloud.com/", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("SoundCloud should not require auth")
+	}
+}
+
+func TestParseProfile(t *testing.T) {
+	page := `<html><body><script>window.__sc_hydration = [{"hydratable":"anonymousId","data":"123"},
+{"hydratable":"user","data":{"permalink":"janedoe","username":"DJ Jane","full_name":"Jane Doe",
+"description":"Ambient + techno. Bookings: https://twitter.com/djjane","city":"Berlin","country_code":"DE",
+"created_at":"2012-04-01T10:00:00Z","followers_count":5120,"followings_count":88,"track_count":42,"verified":true}}];</script>
+</body></html>`
+
+	p, err := parseProfile(page, "https://soundcloud.com/janedoe", "janedoe")
+	if err != nil {
+		t.Fatalf("parseProfile() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Username != "janedoe" || p.Fields["display_name"] != "DJ Jane" {
+		t.Errorf("Name = %q, Username = %q, display_name = %q", p.Name, p.Username, p.Fields["display_name"])
+	}
+	if p.Location != "Berlin, DE" || p.CreatedAt != "2012-04-01T10:00:00Z" {
+		t.Errorf("Location = %q, CreatedAt = %q", p.Location, p.CreatedAt)
+	}
+	if p.Fields["followers"] != "5120" || p.Fields["tracks"] != "42" || p.Fields["verified"] != "true" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://twitter.com/djjane" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseProfileNotFound(t *testing.T) {
+	page := `<script>window.__sc_hydration = [{"hydratable":"anonymousId","data":"123"}];</script>`
+	if _, err := parseProfile(page, "https://soundcloud.com/nobody", "nobody"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseProfile() error = %v, want ErrProfileNotFound", err)
+	}
+	if _, err := parseProfile(`<html></html>`, "https://soundcloud.com/nobody", "nobody"); err == nil {
+		t.Error("parseProfile(no hydration) should fail")
+	}
+}
@@ -3,7 +3,6 @@ package stackoverflow
 
 import (
 	"context"
-	"crypto/tls"
 	"log/slog"
 	"net/http"
 	"regexp"
@@ -58,14 +57,9 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 3 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // needed for corporate proxies
-			},
-		},
-		cache:  cfg.cache,
-		logger: cfg.logger,
+		httpClient: &http.Client{Timeout: 3 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
 	}, nil
 }
 
@@ -79,7 +73,7 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "sociopath/1.0")
+	req.Header.Set("User-Agent", cache.UserAgent)
 
 	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
 	if err != nil {
@@ -89,6 +83,19 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseHTML(body, urlStr, username), nil
 }
 
+// Parse extracts a profile from a StackOverflow user page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	return parseHTML(data, urlStr, extractUsername(urlStr)), nil
+}
+
+// Profile page patterns for parseHTML.
+var (
+	locPattern    = regexp.MustCompile(`<div[^>]*class="[^"]*wmx2[^"]*truncate[^"]*"[^>]*title="([^"]+)"`)
+	repPattern    = regexp.MustCompile(`(?i)<div[^>]*class="[^"]*fs-title[^"]*"[^>]*>\s*([\d,]+)\s*</div>\s*<div[^>]*>reputation</div>`)
+	memberPattern = regexp.MustCompile(`title="(\d{4}-\d{2}-\d{2}) [^"]*"[^>]*>\s*Member for`)
+	tagPattern    = regexp.MustCompile(`(?i)<a[^>]*class="[^"]*post-tag[^"]*"[^>]*>([^<]+)</a>`)
+)
+
 func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	content := string(data)
 
@@ -111,7 +118,6 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract location
-	locPattern := regexp.MustCompile(`<div[^>]*class="[^"]*wmx2[^"]*truncate[^"]*"[^>]*title="([^"]+)"`)
 	if m := locPattern.FindStringSubmatch(content); len(m) > 1 {
 		loc := strings.TrimSpace(m[1])
 		if len(loc) > 3 && len(loc) < 100 {
@@ -121,13 +127,16 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	}
 
 	// Extract reputation
-	repPattern := regexp.MustCompile(`(?i)<div[^>]*class="[^"]*fs-title[^"]*"[^>]*>\s*([\d,]+)\s*</div>\s*<div[^>]*>reputation</div>`)
 	if m := repPattern.FindStringSubmatch(content); len(m) > 1 {
-		p.Fields["reputation"] = m[1]
+		p.Fields[profile.FieldReputation] = m[1]
+	}
+
+	// Extract join date - the "Member for" label's title holds the exact creation time
+	if m := memberPattern.FindStringSubmatch(content); len(m) > 1 {
+		p.CreatedAt = m[1]
 	}
 
 	// Extract top tags
-	tagPattern := regexp.MustCompile(`(?i)<a[^>]*class="[^"]*post-tag[^"]*"[^>]*>([^<]+)</a>`)
 	tagMatches := tagPattern.FindAllStringSubmatch(content, 5)
 	var tags []string
 	for _, m := range tagMatches {
@@ -149,9 +158,11 @@ func parseHTML(data []byte, urlStr, username string) *profile.Profile {
 	return p
 }
 
+// usernamePattern matches the username slug after the user ID.
+var usernamePattern = regexp.MustCompile(`/users/\d+/([^/?]+)`)
+
 func extractUsername(urlStr string) string {
-	re := regexp.MustCompile(`/users/\d+/([^/?]+)`)
-	if m := re.FindStringSubmatch(urlStr); len(m) > 1 {
+	if m := usernamePattern.FindStringSubmatch(urlStr); len(m) > 1 {
 		return m[1]
 	}
 	return ""
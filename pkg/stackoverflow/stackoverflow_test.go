@@ -148,6 +148,7 @@ func TestParseHTML(t *testing.T) {
 		wantLocation string
 		wantRep      string
 		wantTags     string
+		wantCreated  string
 	}{
 		{
 			name: "full profile",
@@ -156,6 +157,7 @@ func TestParseHTML(t *testing.T) {
 				<div class="fs-title">1,234,567</div><div>reputation</div>
 				<a class="post-tag">c#</a>
 				<a class="post-tag">java</a>
+				<span title="2008-09-26 12:05:05Z" class="fc-black-400">Member for <span>16 years</span></span>
 			</body></html>`,
 			url:          "https://stackoverflow.com/users/22656/jon-skeet",
 			username:     "jon-skeet",
@@ -163,6 +165,7 @@ func TestParseHTML(t *testing.T) {
 			wantLocation: "Reading, UK",
 			wantRep:      "1,234,567",
 			wantTags:     "c#, java",
+			wantCreated:  "2008-09-26",
 		},
 		{
 			name:     "minimal profile",
@@ -196,6 +199,9 @@ func TestParseHTML(t *testing.T) {
 			if tt.wantTags != "" && profile.Fields["top_tags"] != tt.wantTags {
 				t.Errorf("top_tags = %q, want %q", profile.Fields["top_tags"], tt.wantTags)
 			}
+			if profile.CreatedAt != tt.wantCreated {
+				t.Errorf("CreatedAt = %q, want %q", profile.CreatedAt, tt.wantCreated)
+			}
 		})
 	}
 }
@@ -0,0 +1,182 @@
+// Package steam fetches Steam Community profiles via the public XML profile feed.
+package steam
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "steam"
+
+// Profiles are addressed by custom vanity URL (/id/) or 64-bit Steam ID (/profiles/).
+var profilePattern = regexp.MustCompile(`(?i)steamcommunity\.com/(id|profiles)/([\w-]+)/?(?:[?#]|$)`)
+
+// Match returns true if the URL is a Steam Community profile.
+func Match(urlStr string) bool {
+	return profilePattern.MatchString(urlStr)
+}
+
+// AuthRequired returns false because the XML profile feed is public.
+func AuthRequired() bool { return false }
+
+// Client handles Steam requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a Steam client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch retrieves a Steam Community profile.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	m := profilePattern.FindStringSubmatch(urlStr)
+	if m == nil {
+		return nil, fmt.Errorf("could not extract profile from: %s", urlStr)
+	}
+	kind, id := strings.ToLower(m[1]), m[2]
+
+	normalizedURL := "https://steamcommunity.com/" + kind + "/" + id
+	c.logger.InfoContext(ctx, "fetching steam profile", "url", normalizedURL, "id", id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, normalizedURL+"/?xml=1", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "application/xml,text/xml;q=0.9,*/*;q=0.8")
+
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseXML(body, normalizedURL)
+}
+
+// xmlProfile is the subset of the ?xml=1 profile feed we use.
+type xmlProfile struct {
+	Error        string `xml:"error"`
+	SteamID64    string `xml:"steamID64"`
+	PersonaName  string `xml:"steamID"`
+	CustomURL    string `xml:"customURL"`
+	RealName     string `xml:"realname"`
+	Location     string `xml:"location"`
+	Summary      string `xml:"summary"`
+	MemberSince  string `xml:"memberSince"`
+	PrivacyState string `xml:"privacyState"`
+	Headline     string `xml:"headline"`
+	Groups       []struct {
+		Name string `xml:"groupName"`
+	} `xml:"groups>group"`
+}
+
+var tagPattern = regexp.MustCompile(`<[^>]+>`)
+
+func parseXML(data []byte, urlStr string) (*profile.Profile, error) {
+	var resp xmlProfile
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse steam profile: %w", err)
+	}
+	// Unknown profiles answer with <response><error>The specified profile could not be found.</error></response>.
+	if resp.Error != "" || resp.SteamID64 == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: strings.TrimSpace(resp.CustomURL),
+		Name:     strings.TrimSpace(resp.RealName),
+		Location: strings.TrimSpace(resp.Location),
+		Fields:   make(map[string]string),
+	}
+	if p.Username == "" {
+		p.Username = resp.SteamID64
+	}
+	persona := strings.TrimSpace(resp.PersonaName)
+	if p.Name == "" {
+		p.Name = persona
+	}
+	if p.Name == "" {
+		return nil, errors.New("profile not found (no persona name)")
+	}
+	if persona != "" && persona != p.Name {
+		p.Fields["persona"] = persona
+	}
+	p.Fields["steam_id"] = resp.SteamID64
+	if resp.PrivacyState != "" {
+		p.Fields["privacy"] = resp.PrivacyState
+	}
+	if resp.Headline != "" {
+		p.Fields["headline"] = strings.TrimSpace(resp.Headline)
+	}
+
+	// Steam writes member-since dates as "March 3, 2009".
+	if t, err := time.Parse("January 2, 2006", strings.TrimSpace(resp.MemberSince)); err == nil {
+		p.CreatedAt = t.Format("2006-01-02")
+	}
+
+	// The summary is HTML; keep its links for crawling before flattening it into the bio.
+	summary := strings.ReplaceAll(resp.Summary, "<br>", "\n")
+	p.Bio = strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(summary, "")))
+	if p.Bio == "No information given." {
+		p.Bio = ""
+	}
+
+	var groups []string
+	for _, g := range resp.Groups {
+		if name := strings.TrimSpace(g.Name); name != "" {
+			groups = append(groups, name)
+		}
+	}
+	if len(groups) > 0 {
+		p.Fields["groups"] = strings.Join(groups, ", ")
+	}
+
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(resp.Summary), Match)
+	return p, nil
+}
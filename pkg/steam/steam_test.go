@@ -0,0 +1,78 @@
+package steam
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://steamcommunity.com/id/janedoe", true},
+		{"https://steamcommunity.com/id/janedoe/", true},
+		{"https://steamcommunity.com/profiles/76561197960287930", true},
+		{"https://steamcommunity.com/id/janedoe/games/", false},
+		{"https://steamcommunity.com/groups/gophers", false},
+		{"https://store.steampowered.com/app/620", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("Steam should not require auth")
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<profile>
+	<steamID64>76561197960287930</steamID64>
+	<steamID><![CDATA[gopher_jane]]></steamID>
+	<privacyState>public</privacyState>
+	<memberSince>March 3, 2009</memberSince>
+	<customURL><![CDATA[janedoe]]></customURL>
+	<location><![CDATA[Portland, Oregon, United States]]></location>
+	<realname><![CDATA[Jane Doe]]></realname>
+	<summary><![CDATA[Speedrunner &amp; Go dev.<br>Streams at <a class="bb_link" href="https://steamcommunity.com/linkfilter/?u=https%3A%2F%2Ftwitch.tv%2Fjanedoe">https://twitch.tv/janedoe</a>]]></summary>
+	<groups>
+		<group isPrimary="1"><groupName><![CDATA[Gophers]]></groupName></group>
+		<group isPrimary="0"><groupName><![CDATA[Speedrun Club]]></groupName></group>
+	</groups>
+</profile>`
+
+	p, err := parseXML([]byte(data), "https://steamcommunity.com/id/janedoe")
+	if err != nil {
+		t.Fatalf("parseXML() error = %v", err)
+	}
+	if p.Name != "Jane Doe" || p.Username != "janedoe" || p.Fields["persona"] != "gopher_jane" {
+		t.Errorf("Name = %q, Username = %q, persona = %q", p.Name, p.Username, p.Fields["persona"])
+	}
+	if p.Location != "Portland, Oregon, United States" || p.CreatedAt != "2009-03-03" {
+		t.Errorf("Location = %q, CreatedAt = %q", p.Location, p.CreatedAt)
+	}
+	if p.Bio != "Speedrunner & Go dev.\nStreams at https://twitch.tv/janedoe" {
+		t.Errorf("Bio = %q", p.Bio)
+	}
+	if p.Fields["steam_id"] != "76561197960287930" || p.Fields["groups"] != "Gophers, Speedrun Club" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://twitch.tv/janedoe" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+}
+
+func TestParseXMLNotFound(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?><response><error><![CDATA[The specified profile could not be found.]]></error></response>`
+	if _, err := parseXML([]byte(data), "https://steamcommunity.com/id/nobody"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("parseXML() error = %v, want ErrProfileNotFound", err)
+	}
+}
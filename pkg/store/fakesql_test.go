@@ -0,0 +1,302 @@
+package store
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// fakeSQLDriver is a database/sql driver that runs exactly the statements SQL issues
+// against in-memory tables, so the SQL store is tested without linking in SQLite.
+// Connections opened with the same name share a database. Each statement runs
+// atomically, and a transaction holds the database until it ends, as SQLite's
+// locking does.
+type fakeSQLDriver struct{}
+
+func init() {
+	sql.Register("sociopathfake", fakeSQLDriver{})
+}
+
+var fakeSQLDBs = struct {
+	m map[string]*fakeSQLDB
+	sync.Mutex
+}{m: make(map[string]*fakeSQLDB)}
+
+func (fakeSQLDriver) Open(name string) (driver.Conn, error) {
+	fakeSQLDBs.Lock()
+	defer fakeSQLDBs.Unlock()
+	db := fakeSQLDBs.m[name]
+	if db == nil {
+		db = &fakeSQLDB{tables: fakeSQLTables{visited: make(map[[2]string]bool)}}
+		fakeSQLDBs.m[name] = db
+	}
+	return &fakeSQLConn{db: db}, nil
+}
+
+type fakeSQLDB struct {
+	tables fakeSQLTables
+	mu     sync.Mutex
+}
+
+type fakeSQLTables struct {
+	visited  map[[2]string]bool // run_id, url
+	profiles []fakeProfileRow
+	frontier []fakeFrontierRow // In id order
+	requests []fakeRequestRow
+	nextID   int64
+}
+
+type fakeProfileRow struct {
+	run, url, data string
+	seq            int64
+}
+
+type fakeFrontierRow struct {
+	run, url  string
+	id, depth int64
+}
+
+type fakeRequestRow struct {
+	key string
+	at  int64
+}
+
+func (t *fakeSQLTables) clone() fakeSQLTables {
+	return fakeSQLTables{
+		visited:  maps.Clone(t.visited),
+		profiles: slices.Clone(t.profiles),
+		frontier: slices.Clone(t.frontier),
+		requests: slices.Clone(t.requests),
+		nextID:   t.nextID,
+	}
+}
+
+// fakeSQLResult is what a statement produced: rows for queries, a count for the rest.
+type fakeSQLResult struct {
+	cols     []string
+	rows     [][]driver.Value
+	affected int64
+}
+
+func (*fakeSQLResult) LastInsertId() (int64, error) {
+	return 0, errors.New("fake sql: LastInsertId is not supported")
+}
+
+func (r *fakeSQLResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+type fakeSQLStatement func(t *fakeSQLTables, args []driver.Value) *fakeSQLResult
+
+// fakeSQLStatements maps each statement SQL runs, with its whitespace collapsed, to
+// its effect on the tables.
+var fakeSQLStatements = map[string]fakeSQLStatement{
+	`INSERT INTO sociopath_profiles (run_id, url, seq, data) VALUES (?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM sociopath_profiles WHERE run_id = ?), ?) ON CONFLICT (run_id, url) DO UPDATE SET data = excluded.data`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		run, url, data := a[0].(string), a[1].(string), a[3].(string) //nolint:errcheck // panics on a type mismatch
+		var seq int64
+		for i, p := range t.profiles {
+			if p.run != run {
+				continue
+			}
+			if p.url == url {
+				t.profiles[i].data = data
+				return &fakeSQLResult{affected: 1}
+			}
+			seq = max(seq, p.seq)
+		}
+		t.profiles = append(t.profiles, fakeProfileRow{run: run, url: url, data: data, seq: seq + 1})
+		return &fakeSQLResult{affected: 1}
+	},
+	`SELECT data FROM sociopath_profiles WHERE run_id = ? AND url = ?`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		r := &fakeSQLResult{cols: []string{"data"}}
+		for _, p := range t.profiles {
+			if p.run == a[0] && p.url == a[1] {
+				r.rows = append(r.rows, []driver.Value{p.data})
+			}
+		}
+		return r
+	},
+	`SELECT data FROM sociopath_profiles WHERE run_id = ? ORDER BY seq`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		var matched []fakeProfileRow
+		for _, p := range t.profiles {
+			if p.run == a[0] {
+				matched = append(matched, p)
+			}
+		}
+		slices.SortFunc(matched, func(x, y fakeProfileRow) int { return int(x.seq - y.seq) })
+		r := &fakeSQLResult{cols: []string{"data"}}
+		for _, p := range matched {
+			r.rows = append(r.rows, []driver.Value{p.data})
+		}
+		return r
+	},
+	`INSERT INTO sociopath_frontier (run_id, url, depth) VALUES (?, ?, ?)`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		t.nextID++
+		t.frontier = append(t.frontier, fakeFrontierRow{
+			run: a[0].(string), url: a[1].(string), depth: a[2].(int64), id: t.nextID, //nolint:errcheck // panics on a type mismatch
+		})
+		return &fakeSQLResult{affected: 1}
+	},
+	`DELETE FROM sociopath_frontier WHERE id = (SELECT id FROM sociopath_frontier WHERE run_id = ? ORDER BY id LIMIT 1) RETURNING url, depth`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		r := &fakeSQLResult{cols: []string{"url", "depth"}}
+		for i, it := range t.frontier {
+			if it.run == a[0] {
+				t.frontier = slices.Delete(t.frontier, i, i+1)
+				r.rows = append(r.rows, []driver.Value{it.url, it.depth})
+				r.affected = 1
+				break
+			}
+		}
+		return r
+	},
+	`SELECT url, depth FROM sociopath_frontier WHERE run_id = ? ORDER BY id`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		r := &fakeSQLResult{cols: []string{"url", "depth"}}
+		for _, it := range t.frontier {
+			if it.run == a[0] {
+				r.rows = append(r.rows, []driver.Value{it.url, it.depth})
+			}
+		}
+		return r
+	},
+	`INSERT INTO sociopath_visited (run_id, url) VALUES (?, ?) ON CONFLICT DO NOTHING`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		key := [2]string{a[0].(string), a[1].(string)} //nolint:errcheck // panics on a type mismatch
+		if t.visited[key] {
+			return &fakeSQLResult{}
+		}
+		t.visited[key] = true
+		return &fakeSQLResult{affected: 1}
+	},
+	`SELECT 1 FROM sociopath_visited WHERE run_id = ? AND url = ?`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		r := &fakeSQLResult{cols: []string{"1"}}
+		if t.visited[[2]string{a[0].(string), a[1].(string)}] { //nolint:errcheck // panics on a type mismatch
+			r.rows = append(r.rows, []driver.Value{int64(1)})
+		}
+		return r
+	},
+	`INSERT INTO sociopath_requests (quota_key, at) VALUES (?, ?)`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		t.requests = append(t.requests, fakeRequestRow{key: a[0].(string), at: a[1].(int64)}) //nolint:errcheck // panics on a type mismatch
+		return &fakeSQLResult{affected: 1}
+	},
+	`DELETE FROM sociopath_requests WHERE quota_key = ? AND at < ?`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		before := len(t.requests)
+		t.requests = slices.DeleteFunc(t.requests, func(r fakeRequestRow) bool {
+			return r.key == a[0] && r.at < a[1].(int64) //nolint:errcheck // panics on a type mismatch
+		})
+		return &fakeSQLResult{affected: int64(before - len(t.requests))}
+	},
+	`INSERT INTO sociopath_requests (quota_key, at) SELECT ?, ? WHERE (SELECT COUNT(*) FROM sociopath_requests WHERE quota_key = ? AND at >= ?) < ?`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		var n int64
+		for _, r := range t.requests {
+			if r.key == a[2] && r.at >= a[3].(int64) { //nolint:errcheck // panics on a type mismatch
+				n++
+			}
+		}
+		if n >= a[4].(int64) { //nolint:errcheck // panics on a type mismatch
+			return &fakeSQLResult{}
+		}
+		t.requests = append(t.requests, fakeRequestRow{key: a[0].(string), at: a[1].(int64)}) //nolint:errcheck // panics on a type mismatch
+		return &fakeSQLResult{affected: 1}
+	},
+	`SELECT COUNT(*) FROM sociopath_requests WHERE quota_key = ? AND at >= ?`: func(t *fakeSQLTables, a []driver.Value) *fakeSQLResult {
+		var n int64
+		for _, r := range t.requests {
+			if r.key == a[0] && r.at >= a[1].(int64) { //nolint:errcheck // panics on a type mismatch
+				n++
+			}
+		}
+		return &fakeSQLResult{cols: []string{"COUNT(*)"}, rows: [][]driver.Value{{n}}}
+	},
+	`SELECT COUNT(*) FROM sociopath_requests`: func(t *fakeSQLTables, _ []driver.Value) *fakeSQLResult {
+		return &fakeSQLResult{cols: []string{"COUNT(*)"}, rows: [][]driver.Value{{int64(len(t.requests))}}}
+	},
+}
+
+type fakeSQLConn struct {
+	db       *fakeSQLDB
+	snapshot fakeSQLTables // Restored on rollback
+	inTx     bool
+}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	if strings.HasPrefix(query, "CREATE ") {
+		return &fakeSQLStmt{conn: c, run: func(*fakeSQLTables, []driver.Value) *fakeSQLResult { return &fakeSQLResult{} }}, nil
+	}
+	run, ok := fakeSQLStatements[query]
+	if !ok {
+		return nil, fmt.Errorf("fake sql: unsupported statement %q", query)
+	}
+	return &fakeSQLStmt{conn: c, run: run}, nil
+}
+
+func (c *fakeSQLConn) Close() error {
+	if c.inTx {
+		return c.Rollback()
+	}
+	return nil
+}
+
+func (c *fakeSQLConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	c.snapshot = c.db.tables.clone()
+	c.inTx = true
+	return c, nil
+}
+
+func (c *fakeSQLConn) Commit() error {
+	c.inTx = false
+	c.db.mu.Unlock()
+	return nil
+}
+
+func (c *fakeSQLConn) Rollback() error {
+	c.db.tables = c.snapshot
+	c.inTx = false
+	c.db.mu.Unlock()
+	return nil
+}
+
+func (c *fakeSQLConn) exec(run fakeSQLStatement, args []driver.Value) *fakeSQLResult {
+	if !c.inTx {
+		c.db.mu.Lock()
+		defer c.db.mu.Unlock()
+	}
+	return run(&c.db.tables, args)
+}
+
+type fakeSQLStmt struct {
+	conn *fakeSQLConn
+	run  fakeSQLStatement
+}
+
+func (*fakeSQLStmt) Close() error  { return nil }
+func (*fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.exec(s.run, args), nil
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeSQLRows{result: s.conn.exec(s.run, args)}, nil
+}
+
+type fakeSQLRows struct {
+	result *fakeSQLResult
+	next   int
+}
+
+func (r *fakeSQLRows) Columns() []string { return r.result.cols }
+func (*fakeSQLRows) Close() error        { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
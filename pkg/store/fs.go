@@ -0,0 +1,347 @@
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// File names within a run directory.
+const (
+	profilesFile = "profiles.jsonl" // append-only; later lines replace earlier ones with the same URL
+	frontierFile = "frontier.json"  // rewritten atomically on every change
+	visitedFile  = "visited.txt"    // append-only, one URL per line
+	quotaDir     = ".quota"         // one append-only file of UnixNano timestamps per key
+)
+
+// FS is a Store that keeps each run in its own directory under a root directory.
+// It is meant for use by one process at a time; use SQL to share runs between processes.
+type FS struct {
+	visited map[string]map[string]bool // runID -> URLs, loaded lazily
+	root    string
+	mu      sync.Mutex
+}
+
+var _ Store = (*FS)(nil)
+
+// NewFS returns a Store rooted at dir, creating it if needed.
+func NewFS(dir string) (*FS, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FS{root: dir, visited: make(map[string]map[string]bool)}, nil
+}
+
+// runDir returns the directory for runID, creating it if needed.
+func (s *FS) runDir(runID string) (string, error) {
+	if err := validateRunID(runID); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(s.root, runID)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// SaveProfile appends p to the run's profile log.
+func (s *FS) SaveProfile(_ context.Context, runID string, p *profile.Profile) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.runDir(runID)
+	if err != nil {
+		return err
+	}
+	return appendLine(filepath.Join(dir, profilesFile), data)
+}
+
+// LoadProfile returns the most recently saved profile for url.
+func (s *FS) LoadProfile(ctx context.Context, runID, url string) (*profile.Profile, error) {
+	profiles, err := s.Profiles(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		if p.URL == url {
+			return p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Profiles returns the latest version of each profile saved for the run.
+func (s *FS) Profiles(_ context.Context, runID string) ([]*profile.Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir, err := s.runDir(runID)
+	if err != nil {
+		return nil, err
+	}
+	var profiles []*profile.Profile
+	index := make(map[string]int)
+	err = readLines(filepath.Join(dir, profilesFile), func(line string) error {
+		var p profile.Profile
+		if err := json.Unmarshal([]byte(line), &p); err != nil {
+			return err
+		}
+		if i, ok := index[p.URL]; ok {
+			profiles[i] = &p
+			return nil
+		}
+		index[p.URL] = len(profiles)
+		profiles = append(profiles, &p)
+		return nil
+	})
+	return profiles, err
+}
+
+// PushFrontier appends items to the run's frontier.
+func (s *FS) PushFrontier(_ context.Context, runID string, items ...FrontierItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frontier, path, err := s.loadFrontier(runID)
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, append(frontier, items...))
+}
+
+// PopFrontier removes and returns the first item of the run's frontier.
+func (s *FS) PopFrontier(_ context.Context, runID string) (FrontierItem, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frontier, path, err := s.loadFrontier(runID)
+	if err != nil || len(frontier) == 0 {
+		return FrontierItem{}, false, err
+	}
+	if err := writeJSON(path, frontier[1:]); err != nil {
+		return FrontierItem{}, false, err
+	}
+	return frontier[0], true, nil
+}
+
+// Frontier returns the run's frontier.
+func (s *FS) Frontier(_ context.Context, runID string) ([]FrontierItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frontier, _, err := s.loadFrontier(runID)
+	return frontier, err
+}
+
+func (s *FS) loadFrontier(runID string) (frontier []FrontierItem, path string, err error) {
+	dir, err := s.runDir(runID)
+	if err != nil {
+		return nil, "", err
+	}
+	path = filepath.Join(dir, frontierFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, path, nil
+	}
+	if err != nil {
+		return nil, path, err
+	}
+	if err := json.Unmarshal(data, &frontier); err != nil {
+		return nil, path, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return frontier, path, nil
+}
+
+// MarkVisited appends url to the run's visited log.
+func (s *FS) MarkVisited(_ context.Context, runID, url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen, dir, err := s.loadVisited(runID)
+	if err != nil {
+		return err
+	}
+	if seen[url] {
+		return nil
+	}
+	if err := appendLine(filepath.Join(dir, visitedFile), []byte(url)); err != nil {
+		return err
+	}
+	seen[url] = true
+	return nil
+}
+
+// Visited reports whether url is in the run's visited log.
+func (s *FS) Visited(_ context.Context, runID, url string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen, _, err := s.loadVisited(runID)
+	return seen[url], err
+}
+
+func (s *FS) loadVisited(runID string) (seen map[string]bool, dir string, err error) {
+	dir, err = s.runDir(runID)
+	if err != nil {
+		return nil, "", err
+	}
+	if seen, ok := s.visited[runID]; ok {
+		return seen, dir, nil
+	}
+	seen = make(map[string]bool)
+	err = readLines(filepath.Join(dir, visitedFile), func(line string) error {
+		seen[line] = true
+		return nil
+	})
+	if err != nil {
+		return nil, dir, err
+	}
+	s.visited[runID] = seen
+	return seen, dir, nil
+}
+
+// RecordRequest appends at to the quota log for key.
+func (s *FS) RecordRequest(_ context.Context, key string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recordRequest(key, at)
+}
+
+// CountRequests counts entries in the quota log for key at or after since.
+func (s *FS) CountRequests(_ context.Context, key string, since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.countRequests(key, since)
+}
+
+// ReserveRequest appends at to the quota log for key if it has fewer than limit
+// entries at or after since.
+func (s *FS) ReserveRequest(_ context.Context, key string, since, at time.Time, limit int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n, err := s.countRequests(key, since)
+	if err != nil || n >= limit {
+		return false, err
+	}
+	return true, s.recordRequest(key, at)
+}
+
+func (s *FS) recordRequest(key string, at time.Time) error {
+	path, err := s.quotaPath(key)
+	if err != nil {
+		return err
+	}
+	return appendLine(path, []byte(strconv.FormatInt(at.UnixNano(), 10)))
+}
+
+func (s *FS) countRequests(key string, since time.Time) (int, error) {
+	path, err := s.quotaPath(key)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	cutoff := since.UnixNano()
+	err = readLines(path, func(line string) error {
+		at, err := strconv.ParseInt(line, 10, 64)
+		if err != nil {
+			return err
+		}
+		if at >= cutoff {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// quotaPath returns the quota log for key, creating its directory if needed.
+func (s *FS) quotaPath(key string) (string, error) {
+	dir := filepath.Join(s.root, quotaDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+	return filepath.Join(dir, name+".log"), nil
+}
+
+// Close is a no-op; every change is written through immediately.
+func (*FS) Close() error {
+	return nil
+}
+
+// validateRunID rejects run IDs that are empty, could escape the store directory,
+// or are hidden (the store keeps its own state in dot directories).
+func validateRunID(runID string) error {
+	if runID == "" || strings.HasPrefix(runID, ".") || strings.ContainsAny(runID, `/\`) {
+		return fmt.Errorf("invalid run ID %q", runID)
+	}
+	return nil
+}
+
+func appendLine(path string, line []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close() //nolint:errcheck // already failing
+		return err
+	}
+	return f.Close()
+}
+
+// readLines calls fn for each non-empty line of path. A missing file has no lines.
+func readLines(path string, fn func(line string) error) error {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // read-only file
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		if line := sc.Text(); line != "" {
+			if err := fn(line); err != nil {
+				return fmt.Errorf("reading %s: %w", path, err)
+			}
+		}
+	}
+	return sc.Err()
+}
+
+// writeJSON replaces path with the JSON encoding of v atomically.
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
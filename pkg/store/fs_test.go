@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestFS(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFS(dir)
+	if err != nil {
+		t.Fatalf("NewFS() error = %v", err)
+	}
+
+	// Profiles: upsert keeps first-saved order
+	for _, p := range []*profile.Profile{
+		{URL: "https://github.com/alice", Name: "Alice"},
+		{URL: "https://mastodon.social/@alice", Name: "Alice M"},
+		{URL: "https://github.com/alice", Name: "Alice Updated"},
+	} {
+		if err := s.SaveProfile(ctx, "run1", p); err != nil {
+			t.Fatalf("SaveProfile() error = %v", err)
+		}
+	}
+	profiles, err := s.Profiles(ctx, "run1")
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "Alice Updated" || profiles[1].Name != "Alice M" {
+		t.Errorf("Profiles() = %+v", profiles)
+	}
+	if _, err := s.LoadProfile(ctx, "run1", "https://twitter.com/alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadProfile() missing error = %v, want ErrNotFound", err)
+	}
+	if other, _ := s.Profiles(ctx, "run2"); len(other) != 0 { //nolint:errcheck // checked by length
+		t.Errorf("run2 should be empty, got %d profiles", len(other))
+	}
+
+	// Frontier: FIFO
+	if err := s.PushFrontier(ctx, "run1", FrontierItem{URL: "a", Depth: 1}, FrontierItem{URL: "b", Depth: 2}); err != nil {
+		t.Fatalf("PushFrontier() error = %v", err)
+	}
+	item, ok, err := s.PopFrontier(ctx, "run1")
+	if err != nil || !ok || item.URL != "a" || item.Depth != 1 {
+		t.Errorf("PopFrontier() = %+v, %v, %v; want a/1", item, ok, err)
+	}
+	if rest, _ := s.Frontier(ctx, "run1"); len(rest) != 1 || rest[0].URL != "b" { //nolint:errcheck // checked by content
+		t.Errorf("Frontier() = %+v, want [b]", rest)
+	}
+
+	// Visited
+	if err := s.MarkVisited(ctx, "run1", "github.com/alice"); err != nil {
+		t.Fatalf("MarkVisited() error = %v", err)
+	}
+	if err := s.MarkVisited(ctx, "run1", "github.com/alice"); err != nil {
+		t.Fatalf("MarkVisited() twice error = %v", err)
+	}
+
+	// Everything survives reopening
+	reopened, err := NewFS(dir)
+	if err != nil {
+		t.Fatalf("NewFS() error = %v", err)
+	}
+	if p, err := reopened.LoadProfile(ctx, "run1", "https://github.com/alice"); err != nil || p.Name != "Alice Updated" {
+		t.Errorf("LoadProfile() after reopen = %+v, %v", p, err)
+	}
+	if ok, err := reopened.Visited(ctx, "run1", "github.com/alice"); err != nil || !ok {
+		t.Errorf("Visited() after reopen = %v, %v; want true", ok, err)
+	}
+	if ok, _ := reopened.Visited(ctx, "run1", "github.com/bob"); ok { //nolint:errcheck // checked by value
+		t.Error("Visited() reports a URL that was never marked")
+	}
+	item, ok, err = reopened.PopFrontier(ctx, "run1")
+	if err != nil || !ok || item.URL != "b" {
+		t.Errorf("PopFrontier() after reopen = %+v, %v, %v; want b", item, ok, err)
+	}
+	if _, ok, _ := reopened.PopFrontier(ctx, "run1"); ok { //nolint:errcheck // checked by ok
+		t.Error("PopFrontier() on empty frontier returned ok")
+	}
+}
+
+func TestValidateRunID(t *testing.T) {
+	for _, id := range []string{"", ".", "..", "../etc", `a\b`, ".quota"} {
+		if err := validateRunID(id); err == nil {
+			t.Errorf("validateRunID(%q) = nil, want error", id)
+		}
+	}
+	if err := validateRunID("2024-06-01-batch"); err != nil {
+		t.Errorf("validateRunID() error = %v", err)
+	}
+}
+
+func TestFSQuota(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFS(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now.Add(-30 * time.Hour), now.Add(-2 * time.Hour), now} {
+		if err := s.RecordRequest(ctx, "platform:linkedin", at); err != nil {
+			t.Fatalf("RecordRequest() error = %v", err)
+		}
+	}
+	n, err := s.CountRequests(ctx, "platform:linkedin", now.Add(-24*time.Hour))
+	if err != nil || n != 2 {
+		t.Errorf("CountRequests() = %d, %v; want 2", n, err)
+	}
+	if n, _ := s.CountRequests(ctx, "platform:github", now.Add(-24*time.Hour)); n != 0 { //nolint:errcheck // checked by value
+		t.Errorf("CountRequests() for unused key = %d, want 0", n)
+	}
+	testReserveRequest(t, s)
+}
+
+// testReserveRequest checks that s records requests against a quota key until the
+// limit is reached within the window.
+func testReserveRequest(t *testing.T, s Store) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	for i, want := range []bool{true, true, false} {
+		if ok, err := s.ReserveRequest(ctx, "platform:twitter", since, now, 2); err != nil || ok != want {
+			t.Errorf("ReserveRequest() #%d = %v, %v; want %v", i+1, ok, err, want)
+		}
+	}
+	if ok, err := s.ReserveRequest(ctx, "platform:twitter", now.Add(time.Second), now.Add(time.Second), 2); err != nil || !ok {
+		t.Errorf("ReserveRequest() in a later window = %v, %v; want true", ok, err)
+	}
+	if ok, err := s.ReserveRequest(ctx, "platform:bluesky", since, now, 2); err != nil || !ok {
+		t.Errorf("ReserveRequest() for another key = %v, %v; want true", ok, err)
+	}
+}
@@ -0,0 +1,228 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // Redis names scripts by their SHA-1 digest
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+)
+
+// Redis is a Store kept in a Redis server, so crawl workers on different machines can
+// share runs. Popping the frontier is atomic, so each item goes to one worker.
+//
+// Keys are prefixed with "sociopath:". Each run uses a hash of profiles by URL, a list
+// of their URLs in the order first saved, a list holding the frontier, and a set of
+// visited URLs; quota requests are sorted sets scored by time.
+type Redis struct {
+	client *redis.Client
+}
+
+var (
+	_ Store  = (*Redis)(nil)
+	_ Shared = (*Redis)(nil)
+)
+
+// NewRedis returns a Store using client. Closing the store closes client.
+func NewRedis(client *redis.Client) *Redis {
+	return &Redis{client: client}
+}
+
+func redisRunKey(runID, name string) string {
+	return "sociopath:run:" + runID + ":" + name
+}
+
+// Shared reports that several processes can crawl a run at once.
+func (*Redis) Shared() bool { return true }
+
+// SaveProfile stores p, keeping its original position in Profiles.
+func (s *Redis) SaveProfile(ctx context.Context, runID string, p *profile.Profile) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	added, err := s.client.Int(ctx, "HSET", redisRunKey(runID, "profiles"), p.URL, string(data))
+	if err != nil || added == 0 {
+		return err
+	}
+	_, err = s.client.Do(ctx, "RPUSH", redisRunKey(runID, "order"), p.URL)
+	return err
+}
+
+// LoadProfile returns the profile stored for url.
+func (s *Redis) LoadProfile(ctx context.Context, runID, url string) (*profile.Profile, error) {
+	data, err := s.client.String(ctx, "HGET", redisRunKey(runID, "profiles"), url)
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p profile.Profile
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Profiles returns the run's profiles in the order first saved.
+func (s *Redis) Profiles(ctx context.Context, runID string) ([]*profile.Profile, error) {
+	urls, err := s.client.Strings(ctx, "LRANGE", redisRunKey(runID, "order"), "0", "-1")
+	if err != nil || len(urls) == 0 {
+		return nil, err
+	}
+	values, err := s.client.Strings(ctx, append([]string{"HMGET", redisRunKey(runID, "profiles")}, urls...)...)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make([]*profile.Profile, 0, len(values))
+	for _, data := range values {
+		if data == "" {
+			continue
+		}
+		var p profile.Profile
+		if err := json.Unmarshal([]byte(data), &p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, &p)
+	}
+	return profiles, nil
+}
+
+// PushFrontier appends items to the run's frontier in one command.
+func (s *Redis) PushFrontier(ctx context.Context, runID string, items ...FrontierItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	args := []string{"RPUSH", redisRunKey(runID, "frontier")}
+	for _, it := range items {
+		data, err := json.Marshal(it)
+		if err != nil {
+			return err
+		}
+		args = append(args, string(data))
+	}
+	_, err := s.client.Do(ctx, args...)
+	return err
+}
+
+// PopFrontier removes and returns the oldest item of the run's frontier.
+func (s *Redis) PopFrontier(ctx context.Context, runID string) (FrontierItem, bool, error) {
+	data, err := s.client.String(ctx, "LPOP", redisRunKey(runID, "frontier"))
+	if errors.Is(err, redis.Nil) {
+		return FrontierItem{}, false, nil
+	}
+	if err != nil {
+		return FrontierItem{}, false, err
+	}
+	var it FrontierItem
+	if err := json.Unmarshal([]byte(data), &it); err != nil {
+		return FrontierItem{}, false, err
+	}
+	return it, true, nil
+}
+
+// Frontier returns the run's frontier, oldest first.
+func (s *Redis) Frontier(ctx context.Context, runID string) ([]FrontierItem, error) {
+	values, err := s.client.Strings(ctx, "LRANGE", redisRunKey(runID, "frontier"), "0", "-1")
+	if err != nil {
+		return nil, err
+	}
+	items := make([]FrontierItem, 0, len(values))
+	for _, data := range values {
+		var it FrontierItem
+		if err := json.Unmarshal([]byte(data), &it); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// MarkVisited records url as visited in the run.
+func (s *Redis) MarkVisited(ctx context.Context, runID, url string) error {
+	_, err := s.client.Do(ctx, "SADD", redisRunKey(runID, "visited"), url)
+	return err
+}
+
+// Visited reports whether url was visited in the run.
+func (s *Redis) Visited(ctx context.Context, runID, url string) (bool, error) {
+	n, err := s.client.Int(ctx, "SISMEMBER", redisRunKey(runID, "visited"), url)
+	return n == 1, err
+}
+
+// reserveScript records a request in a quota's sorted set unless the set already
+// holds the limit since the window start, and drops records older than any window.
+//
+// KEYS[1] is the set; ARGV[1] the window start, ARGV[2] the limit, ARGV[3] the
+// request's score, ARGV[4] its member, and ARGV[5] the retention cutoff. It returns 1
+// if it recorded the request and 0 if not.
+const reserveScript = `
+if redis.call('ZCOUNT', KEYS[1], ARGV[1], '+inf') >= tonumber(ARGV[2]) then
+  return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[4])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[5])
+return 1
+`
+
+var reserveScriptSHA = func() string {
+	sum := sha1.Sum([]byte(reserveScript)) //nolint:gosec // script ID, not security
+	return hex.EncodeToString(sum[:])
+}()
+
+// RecordRequest records a request against quota key, dropping records too old to
+// count against any quota.
+func (s *Redis) RecordRequest(ctx context.Context, key string, at time.Time) error {
+	qkey := "sociopath:quota:" + key
+	if _, err := s.client.Do(ctx, "ZADD", qkey, strconv.FormatInt(at.UnixNano(), 10), redisQuotaMember(at)); err != nil {
+		return err
+	}
+	_, err := s.client.Do(ctx, "ZREMRANGEBYSCORE", qkey, "-inf", strconv.FormatInt(at.Add(-requestRetention).UnixNano(), 10))
+	return err
+}
+
+// CountRequests counts requests recorded against key at or after since.
+func (s *Redis) CountRequests(ctx context.Context, key string, since time.Time) (int, error) {
+	n, err := s.client.Int(ctx, "ZCOUNT", "sociopath:quota:"+key, strconv.FormatInt(since.UnixNano(), 10), "+inf")
+	return int(n), err
+}
+
+// ReserveRequest records a request against quota key if fewer than limit were
+// recorded at or after since. A script counts and records, so no other client's
+// commands run in between.
+func (s *Redis) ReserveRequest(ctx context.Context, key string, since, at time.Time, limit int) (bool, error) {
+	args := []string{
+		"1", "sociopath:quota:" + key,
+		strconv.FormatInt(since.UnixNano(), 10), strconv.Itoa(limit),
+		strconv.FormatInt(at.UnixNano(), 10), redisQuotaMember(at),
+		strconv.FormatInt(at.Add(-requestRetention).UnixNano(), 10),
+	}
+	n, err := s.client.Int(ctx, append([]string{"EVALSHA", reserveScriptSHA}, args...)...)
+	var replyErr redis.Error
+	if errors.As(err, &replyErr) && strings.HasPrefix(string(replyErr), "NOSCRIPT") {
+		// First use on this server; EVAL caches the script for later EVALSHAs
+		n, err = s.client.Int(ctx, append([]string{"EVAL", reserveScript}, args...)...)
+	}
+	return n == 1, err
+}
+
+// redisQuotaMember returns a sorted set member for a request at time at. Members must
+// be unique even for requests recorded at the same instant.
+func redisQuotaMember(at time.Time) string {
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix) //nolint:errcheck // crypto/rand.Read never fails
+	return strconv.FormatInt(at.UnixNano(), 10) + "-" + hex.EncodeToString(suffix)
+}
+
+// Close closes the Redis client.
+func (s *Redis) Close() error {
+	return s.client.Close()
+}
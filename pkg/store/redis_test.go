@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis"
+	"github.com/codeGROOVE-dev/sociopath/pkg/redis/redistest"
+)
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+	srv, err := redistest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close() //nolint:errcheck // test
+	// The quota script's logic in Go, over sets of its own
+	quotas := make(map[string][]int64)
+	srv.HandleScript(reserveScript, func(keys, args []string) (any, error) {
+		var n [4]int64
+		for i, arg := range []string{args[0], args[1], args[2], args[4]} {
+			v, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = v
+		}
+		since, limit, score, cutoff := n[0], n[1], n[2], n[3]
+		used := 0
+		for _, at := range quotas[keys[0]] {
+			if at >= since {
+				used++
+			}
+		}
+		if int64(used) >= limit {
+			return int64(0), nil
+		}
+		quotas[keys[0]] = slices.DeleteFunc(append(quotas[keys[0]], score), func(at int64) bool { return at <= cutoff })
+		return int64(1), nil
+	})
+	client, err := redis.New(srv.URL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewRedis(client)
+	defer s.Close() //nolint:errcheck // test
+
+	for _, p := range []*profile.Profile{
+		{URL: "https://github.com/alice", Name: "Alice"},
+		{URL: "https://mastodon.social/@alice", Name: "Alice M"},
+		{URL: "https://github.com/alice", Name: "Alice Updated"},
+	} {
+		if err := s.SaveProfile(ctx, "run1", p); err != nil {
+			t.Fatalf("SaveProfile() error = %v", err)
+		}
+	}
+	profiles, err := s.Profiles(ctx, "run1")
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "Alice Updated" || profiles[1].Name != "Alice M" {
+		t.Errorf("Profiles() = %+v", profiles)
+	}
+	if _, err := s.LoadProfile(ctx, "run1", "https://twitter.com/alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadProfile() missing error = %v, want ErrNotFound", err)
+	}
+
+	if err := s.PushFrontier(ctx, "run1", FrontierItem{URL: "a", Depth: 1}, FrontierItem{URL: "b", Depth: 2}); err != nil {
+		t.Fatalf("PushFrontier() error = %v", err)
+	}
+	item, ok, err := s.PopFrontier(ctx, "run1")
+	if err != nil || !ok || item.URL != "a" || item.Depth != 1 {
+		t.Errorf("PopFrontier() = %+v, %v, %v; want a/1", item, ok, err)
+	}
+	if rest, _ := s.Frontier(ctx, "run1"); len(rest) != 1 || rest[0].URL != "b" { //nolint:errcheck // checked by content
+		t.Errorf("Frontier() = %+v, want [b]", rest)
+	}
+	if _, _, err := s.PopFrontier(ctx, "run1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.PopFrontier(ctx, "run1"); ok || err != nil {
+		t.Errorf("PopFrontier() on empty frontier = %v, %v", ok, err)
+	}
+
+	if err := s.MarkVisited(ctx, "run1", "github.com/alice"); err != nil {
+		t.Fatalf("MarkVisited() error = %v", err)
+	}
+	if ok, err := s.Visited(ctx, "run1", "github.com/alice"); err != nil || !ok {
+		t.Errorf("Visited() = %v, %v; want true", ok, err)
+	}
+	if ok, _ := s.Visited(ctx, "run2", "github.com/alice"); ok { //nolint:errcheck // checked by value
+		t.Error("Visited() leaks between runs")
+	}
+
+	now := time.Now()
+	for _, at := range []time.Time{now.Add(-30 * time.Hour), now.Add(-2 * time.Hour), now, now} {
+		if err := s.RecordRequest(ctx, "platform:linkedin", at); err != nil {
+			t.Fatalf("RecordRequest() error = %v", err)
+		}
+	}
+	if n, err := s.CountRequests(ctx, "platform:linkedin", now.Add(-24*time.Hour)); err != nil || n != 3 {
+		t.Errorf("CountRequests() = %d, %v; want 3", n, err)
+	}
+
+	testReserveRequest(t, s)
+}
@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// sqlSchema creates the tables used by SQL. It is idempotent.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS sociopath_profiles (
+		run_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		data TEXT NOT NULL,
+		PRIMARY KEY (run_id, url)
+	)`,
+	`CREATE TABLE IF NOT EXISTS sociopath_frontier (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		run_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		depth INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS sociopath_frontier_run ON sociopath_frontier (run_id, id)`,
+	`CREATE TABLE IF NOT EXISTS sociopath_visited (
+		run_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		PRIMARY KEY (run_id, url)
+	)`,
+	`CREATE TABLE IF NOT EXISTS sociopath_requests (
+		quota_key TEXT NOT NULL,
+		at INTEGER NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS sociopath_requests_key ON sociopath_requests (quota_key, at)`,
+}
+
+// SQL is a Store backed by a SQLite database, version 3.35 or later, opened through
+// database/sql. Several processes may share one database file; SQLite serializes their
+// writes, and a busy timeout lets each wait its turn instead of failing with
+// SQLITE_BUSY.
+//
+// The driver is not linked in by this package, so programs register one themselves:
+//
+//	import _ "modernc.org/sqlite"
+//
+//	db, err := sql.Open("sqlite", "file:crawl.db?_pragma=busy_timeout(5000)")
+//	s, err := store.NewSQL(ctx, db)
+type SQL struct {
+	db *sql.DB
+}
+
+var (
+	_ Store  = (*SQL)(nil)
+	_ Shared = (*SQL)(nil)
+)
+
+// NewSQL returns a Store using db, creating its tables if they do not exist.
+// Closing the store closes db.
+func NewSQL(ctx context.Context, db *sql.DB) (*SQL, error) {
+	for _, stmt := range sqlSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("creating store schema: %w", err)
+		}
+	}
+	return &SQL{db: db}, nil
+}
+
+// Shared reports that several processes can crawl a run at once.
+func (*SQL) Shared() bool { return true }
+
+// SaveProfile upserts p, keeping its original position in Profiles.
+func (s *SQL) SaveProfile(ctx context.Context, runID string, p *profile.Profile) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO sociopath_profiles (run_id, url, seq, data)
+		VALUES (?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM sociopath_profiles WHERE run_id = ?), ?)
+		ON CONFLICT (run_id, url) DO UPDATE SET data = excluded.data`,
+		runID, p.URL, runID, string(data))
+	return err
+}
+
+// LoadProfile returns the profile stored for url.
+func (s *SQL) LoadProfile(ctx context.Context, runID, url string) (*profile.Profile, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT data FROM sociopath_profiles WHERE run_id = ? AND url = ?`, runID, url).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p profile.Profile
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Profiles returns the run's profiles in the order first saved.
+func (s *SQL) Profiles(ctx context.Context, runID string) ([]*profile.Profile, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT data FROM sociopath_profiles WHERE run_id = ? ORDER BY seq`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // rows.Err reports failures
+
+	var profiles []*profile.Profile
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var p profile.Profile
+		if err := json.Unmarshal([]byte(data), &p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, &p)
+	}
+	return profiles, rows.Err()
+}
+
+// PushFrontier appends items to the run's frontier in one transaction.
+func (s *SQL) PushFrontier(ctx context.Context, runID string, items ...FrontierItem) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() //nolint:errcheck // no-op after commit
+
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO sociopath_frontier (run_id, url, depth) VALUES (?, ?, ?)`,
+			runID, it.URL, it.Depth); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// PopFrontier removes and returns the oldest item of the run's frontier. Finding and
+// removing the item is one statement, so processes popping at once each get a
+// different item, and none sees an empty frontier while another holds a lock.
+func (s *SQL) PopFrontier(ctx context.Context, runID string) (FrontierItem, bool, error) {
+	var it FrontierItem
+	err := s.db.QueryRowContext(ctx, `
+		DELETE FROM sociopath_frontier
+		WHERE id = (SELECT id FROM sociopath_frontier WHERE run_id = ? ORDER BY id LIMIT 1)
+		RETURNING url, depth`,
+		runID).Scan(&it.URL, &it.Depth)
+	if errors.Is(err, sql.ErrNoRows) {
+		return FrontierItem{}, false, nil
+	}
+	if err != nil {
+		return FrontierItem{}, false, err
+	}
+	return it, true, nil
+}
+
+// Frontier returns the run's frontier, oldest first.
+func (s *SQL) Frontier(ctx context.Context, runID string) ([]FrontierItem, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT url, depth FROM sociopath_frontier WHERE run_id = ? ORDER BY id`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }() //nolint:errcheck // rows.Err reports failures
+
+	var items []FrontierItem
+	for rows.Next() {
+		var it FrontierItem
+		if err := rows.Scan(&it.URL, &it.Depth); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// MarkVisited records url as visited in the run.
+func (s *SQL) MarkVisited(ctx context.Context, runID, url string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO sociopath_visited (run_id, url) VALUES (?, ?) ON CONFLICT DO NOTHING`, runID, url)
+	return err
+}
+
+// Visited reports whether url was visited in the run.
+func (s *SQL) Visited(ctx context.Context, runID, url string) (bool, error) {
+	var one int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT 1 FROM sociopath_visited WHERE run_id = ? AND url = ?`, runID, url).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// RecordRequest records a request against quota key, dropping records too old to
+// count against any quota.
+func (s *SQL) RecordRequest(ctx context.Context, key string, at time.Time) error {
+	if _, err := s.db.ExecContext(ctx,
+		`INSERT INTO sociopath_requests (quota_key, at) VALUES (?, ?)`, key, at.UnixNano()); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM sociopath_requests WHERE quota_key = ? AND at < ?`, key, at.Add(-requestRetention).UnixNano())
+	return err
+}
+
+// CountRequests counts requests recorded against key at or after since.
+func (s *SQL) CountRequests(ctx context.Context, key string, since time.Time) (int, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sociopath_requests WHERE quota_key = ? AND at >= ?`, key, since.UnixNano()).Scan(&n)
+	return n, err
+}
+
+// ReserveRequest records a request against quota key if fewer than limit were
+// recorded at or after since. The count and the insert are one statement, so
+// processes sharing the database cannot both take the last request.
+func (s *SQL) ReserveRequest(ctx context.Context, key string, since, at time.Time, limit int) (bool, error) {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO sociopath_requests (quota_key, at)
+		SELECT ?, ? WHERE (SELECT COUNT(*) FROM sociopath_requests WHERE quota_key = ? AND at >= ?) < ?`,
+		key, at.UnixNano(), key, since.UnixNano(), limit)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`DELETE FROM sociopath_requests WHERE quota_key = ? AND at < ?`, key, at.Add(-requestRetention).UnixNano())
+	return true, err
+}
+
+// Close closes the underlying database.
+func (s *SQL) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,201 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// newTestSQL returns a SQL store on a fresh database of the fake driver in
+// fakesql_test.go.
+func newTestSQL(t *testing.T) *SQL {
+	t.Helper()
+	db, err := sql.Open("sociopathfake", t.TempDir()) // A name no other test uses
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSQL(context.Background(), db)
+	if err != nil {
+		t.Fatalf("NewSQL() error = %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() }) //nolint:errcheck // test
+	return s
+}
+
+func TestSQL(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQL(t)
+
+	for _, p := range []*profile.Profile{
+		{URL: "https://github.com/alice", Name: "Alice"},
+		{URL: "https://mastodon.social/@alice", Name: "Alice M"},
+		{URL: "https://github.com/alice", Name: "Alice Updated"},
+	} {
+		if err := s.SaveProfile(ctx, "run1", p); err != nil {
+			t.Fatalf("SaveProfile() error = %v", err)
+		}
+	}
+	profiles, err := s.Profiles(ctx, "run1")
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "Alice Updated" || profiles[1].Name != "Alice M" {
+		t.Errorf("Profiles() = %+v", profiles)
+	}
+	if _, err := s.LoadProfile(ctx, "run1", "https://twitter.com/alice"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("LoadProfile() missing error = %v, want ErrNotFound", err)
+	}
+	if other, _ := s.Profiles(ctx, "run2"); len(other) != 0 { //nolint:errcheck // checked by length
+		t.Errorf("run2 should be empty, got %d profiles", len(other))
+	}
+
+	if err := s.PushFrontier(ctx, "run1", FrontierItem{URL: "a", Depth: 1}, FrontierItem{URL: "b", Depth: 2}); err != nil {
+		t.Fatalf("PushFrontier() error = %v", err)
+	}
+	item, ok, err := s.PopFrontier(ctx, "run1")
+	if err != nil || !ok || item.URL != "a" || item.Depth != 1 {
+		t.Errorf("PopFrontier() = %+v, %v, %v; want a/1", item, ok, err)
+	}
+	if rest, _ := s.Frontier(ctx, "run1"); len(rest) != 1 || rest[0].URL != "b" { //nolint:errcheck // checked by content
+		t.Errorf("Frontier() = %+v, want [b]", rest)
+	}
+	if _, _, err := s.PopFrontier(ctx, "run1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.PopFrontier(ctx, "run1"); ok || err != nil {
+		t.Errorf("PopFrontier() on empty frontier = %v, %v", ok, err)
+	}
+
+	if err := s.MarkVisited(ctx, "run1", "github.com/alice"); err != nil {
+		t.Fatalf("MarkVisited() error = %v", err)
+	}
+	if err := s.MarkVisited(ctx, "run1", "github.com/alice"); err != nil {
+		t.Fatalf("MarkVisited() twice error = %v", err)
+	}
+	if ok, err := s.Visited(ctx, "run1", "github.com/alice"); err != nil || !ok {
+		t.Errorf("Visited() = %v, %v; want true", ok, err)
+	}
+	if ok, _ := s.Visited(ctx, "run2", "github.com/alice"); ok { //nolint:errcheck // checked by value
+		t.Error("Visited() leaks between runs")
+	}
+}
+
+func TestSQLQuota(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQL(t)
+
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{now.Add(-30 * time.Hour), now.Add(-2 * time.Hour), now, now} {
+		if err := s.RecordRequest(ctx, "platform:linkedin", at); err != nil {
+			t.Fatalf("RecordRequest() error = %v", err)
+		}
+	}
+	if n, err := s.CountRequests(ctx, "platform:linkedin", now.Add(-24*time.Hour)); err != nil || n != 3 {
+		t.Errorf("CountRequests() = %d, %v; want 3", n, err)
+	}
+	if n, _ := s.CountRequests(ctx, "platform:github", now.Add(-24*time.Hour)); n != 0 { //nolint:errcheck // checked by value
+		t.Errorf("CountRequests() for unused key = %d, want 0", n)
+	}
+
+	// Recording a week later drops everything older than any quota window
+	if err := s.RecordRequest(ctx, "platform:linkedin", now.Add(requestRetention)); err != nil {
+		t.Fatalf("RecordRequest() error = %v", err)
+	}
+	var rows int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sociopath_requests`).Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 3 {
+		t.Errorf("sociopath_requests holds %d rows, want 3 after pruning", rows)
+	}
+
+	testReserveRequest(t, s)
+}
+
+func TestSQLReserveRequestConcurrent(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQL(t)
+
+	// Workers racing for the last requests of a quota never take more than it allows
+	const limit = 50
+	now := time.Now()
+	var mu sync.Mutex
+	reserved := 0
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 20 {
+				ok, err := s.ReserveRequest(ctx, "platform:linkedin", now.Add(-time.Hour), now, limit)
+				if err != nil {
+					t.Errorf("ReserveRequest() error = %v", err)
+					return
+				}
+				if ok {
+					mu.Lock()
+					reserved++
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if reserved != limit {
+		t.Errorf("reserved %d requests, want %d", reserved, limit)
+	}
+	if n, err := s.CountRequests(ctx, "platform:linkedin", now.Add(-time.Hour)); err != nil || n != limit {
+		t.Errorf("CountRequests() = %d, %v; want %d", n, err, limit)
+	}
+}
+
+func TestSQLPopFrontierConcurrent(t *testing.T) {
+	ctx := context.Background()
+	s := newTestSQL(t)
+
+	const n = 200
+	items := make([]FrontierItem, n)
+	for i := range items {
+		items[i] = FrontierItem{URL: fmt.Sprintf("https://example.com/%d", i)}
+	}
+	if err := s.PushFrontier(ctx, "run", items...); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every item is popped exactly once, and no worker stops early on a false empty
+	var mu sync.Mutex
+	popped := make(map[string]int)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for {
+				it, ok, err := s.PopFrontier(ctx, "run")
+				if err != nil {
+					t.Errorf("PopFrontier() error = %v", err)
+					return
+				}
+				if !ok {
+					return
+				}
+				mu.Lock()
+				popped[it.URL]++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if len(popped) != n {
+		t.Errorf("popped %d distinct items, want %d", len(popped), n)
+	}
+	for url, times := range popped {
+		if times != 1 {
+			t.Errorf("%s popped %d times", url, times)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package store persists crawl state and results so interrupted crawls can be resumed
+// and several processes can share one crawl.
+//
+// State is grouped by run ID: each run has its own fetched profiles, frontier of URLs
+// still to fetch, and set of URLs already visited. Three implementations are provided:
+// FS stores runs as files in a directory, SQL stores them in a database/sql database
+// using SQLite-compatible SQL, and Redis stores them in a Redis server.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+// requestRetention is how long stores keep recorded requests, longer than any quota
+// window.
+const requestRetention = 7 * 24 * time.Hour
+
+// ErrNotFound is returned when a requested profile is not in the store.
+var ErrNotFound = errors.New("not found in store")
+
+// FrontierItem is a URL queued for crawling.
+type FrontierItem struct {
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// Store persists crawl state and results. Implementations are safe for concurrent use.
+type Store interface {
+	// SaveProfile stores p for the run, replacing any profile with the same URL.
+	SaveProfile(ctx context.Context, runID string, p *profile.Profile) error
+	// LoadProfile returns the profile stored for url, or ErrNotFound.
+	LoadProfile(ctx context.Context, runID, url string) (*profile.Profile, error)
+	// Profiles returns all profiles stored for the run, in the order first saved.
+	Profiles(ctx context.Context, runID string) ([]*profile.Profile, error)
+
+	// PushFrontier appends items to the end of the run's frontier.
+	PushFrontier(ctx context.Context, runID string, items ...FrontierItem) error
+	// PopFrontier removes and returns the first frontier item. ok is false if the frontier is empty.
+	PopFrontier(ctx context.Context, runID string) (item FrontierItem, ok bool, err error)
+	// Frontier returns the run's frontier without modifying it.
+	Frontier(ctx context.Context, runID string) ([]FrontierItem, error)
+
+	// MarkVisited records url as visited in the run.
+	MarkVisited(ctx context.Context, runID, url string) error
+	// Visited reports whether url was visited in the run.
+	Visited(ctx context.Context, runID, url string) (bool, error)
+
+	// RecordRequest records a request made at time at against quota key. Quotas are
+	// shared by all runs.
+	RecordRequest(ctx context.Context, key string, at time.Time) error
+	// CountRequests returns how many requests were recorded against key at or after since.
+	CountRequests(ctx context.Context, key string, since time.Time) (int, error)
+	// ReserveRequest records a request made at time at against quota key unless limit
+	// requests were already recorded at or after since, and reports whether it did.
+	// Counting and recording are one step, so processes sharing the store together
+	// keep to limit.
+	ReserveRequest(ctx context.Context, key string, since, at time.Time, limit int) (bool, error)
+
+	// Close releases resources held by the store.
+	Close() error
+}
+
+// Shared is implemented by stores whose runs several processes can crawl at once.
+// Crawlers take items off a shared store's frontier with PopFrontier, so that each
+// goes to one process, rather than removing them only once they are done.
+type Shared interface {
+	Store
+	// Shared reports whether the store can be shared; it returns true.
+	Shared() bool
+}
@@ -90,6 +90,19 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), urlStr, username)
 }
 
+// Parse extracts a profile from a Substack about page's HTML without fetching anything.
+// Fetch reads the /about page, which names the author.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	return parseProfile(string(data), urlStr, username)
+}
+
+// subPattern matches a subscriber count such as "1,234 subscribers".
+var subPattern = regexp.MustCompile(`([\d,]+)\s*(?:subscribers|Subscribers)`)
+
 func parseProfile(html, url, username string) (*profile.Profile, error) {
 	prof := &profile.Profile{
 		Platform: platform,
@@ -111,9 +124,8 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	prof.Bio = htmlutil.Description(html)
 
 	// Try to extract subscriber count
-	subPattern := regexp.MustCompile(`([\d,]+)\s*(?:subscribers|Subscribers)`)
 	if matches := subPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["subscribers"] = strings.ReplaceAll(matches[1], ",", "")
+		prof.Fields[profile.FieldSubscribers] = strings.ReplaceAll(matches[1], ",", "")
 	}
 
 	// Extract social links
@@ -135,14 +147,16 @@ func parseProfile(html, url, username string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// usernamePattern matches the publication subdomain of a Substack host.
+var usernamePattern = regexp.MustCompile(`^([^.]+)\.substack\.com`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
 	urlStr = strings.TrimPrefix(urlStr, "http://")
 
 	// Extract username.substack.com pattern
-	re := regexp.MustCompile(`^([^.]+)\.substack\.com`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -0,0 +1,357 @@
+// Package teampage extracts the people listed on a company website's team pages,
+// mapping an organization onto individual profiles.
+package teampage
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const (
+	platform = "teampage"
+	maxPages = 3    // team pages parsed per domain
+	maxCard  = 4000 // bytes of HTML one person card may span
+)
+
+// teamPaths are where company sites usually list their people, most specific first.
+var teamPaths = []string{"/team", "/our-team", "/people", "/leadership", "/about-us", "/about", "/company"}
+
+// Client handles team page requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// New creates a team page client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+	}, nil
+}
+
+// Fetch finds the team pages of the company at domain (such as "example.com") and
+// returns one profile per person listed, with name, title, photo, and social links.
+// It returns profile.ErrProfileNotFound if no page lists anyone.
+func (c *Client) Fetch(ctx context.Context, domain string) ([]*profile.Profile, error) {
+	base, err := baseURL(domain)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.InfoContext(ctx, "fetching team pages", "url", base)
+
+	employer := base.Hostname()
+	candidates := make([]string, 0, len(teamPaths)+4)
+	if home, err := c.get(ctx, base.String()); err == nil {
+		if name := siteName(home); name != "" {
+			employer = name
+		}
+		candidates = append(candidates, teamLinks(home, base)...)
+	} else {
+		c.logger.DebugContext(ctx, "homepage fetch failed", "url", base, "error", err)
+	}
+	for _, path := range teamPaths {
+		candidates = append(candidates, base.ResolveReference(&url.URL{Path: path}).String())
+	}
+
+	var people []*profile.Profile
+	seenPages := make(map[string]bool)
+	seenNames := make(map[string]bool)
+	pages := 0
+	for _, pageURL := range candidates {
+		if pages == maxPages || ctx.Err() != nil {
+			break
+		}
+		if seenPages[strings.TrimSuffix(pageURL, "/")] {
+			continue
+		}
+		seenPages[strings.TrimSuffix(pageURL, "/")] = true
+
+		body, err := c.get(ctx, pageURL)
+		if err != nil {
+			c.logger.DebugContext(ctx, "team page fetch failed", "url", pageURL, "error", err)
+			continue
+		}
+		found := parsePeople(body, pageURL, employer)
+		if len(found) == 0 {
+			continue
+		}
+		pages++
+		for _, p := range found {
+			if key := strings.ToLower(p.Name); !seenNames[key] {
+				seenNames[key] = true
+				people = append(people, p)
+			}
+		}
+	}
+
+	if len(people) == 0 {
+		return nil, profile.ErrProfileNotFound
+	}
+	c.logger.InfoContext(ctx, "extracted team members", "domain", base.Hostname(), "count", len(people))
+	return people, nil
+}
+
+func (c *Client) get(ctx context.Context, urlStr string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:146.0) Gecko/20100101 Firefox/146.0")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	body, err := cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// baseURL turns "example.com", "www.example.com/", or "https://example.com/x" into the site root.
+func baseURL(domain string) (*url.URL, error) {
+	domain = strings.TrimSpace(domain)
+	if !strings.Contains(domain, "://") {
+		domain = "https://" + domain
+	}
+	u, err := url.Parse(domain)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid company domain %q", domain)
+	}
+	return &url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}, nil
+}
+
+var (
+	siteNamePattern = regexp.MustCompile(`(?i)<meta[^>]+property=["']og:site_name["'][^>]+content=["']([^"']+)["']`)
+	anchorPattern   = regexp.MustCompile(`(?is)<a[^>]+href=["']([^"']+)["'][^>]*>(.*?)</a>`)
+	teamTextPattern = regexp.MustCompile(`(?i)\b(?:team|people|leadership|about us|who we are)\b`)
+)
+
+// siteName returns the company name a page declares in og:site_name.
+func siteName(page string) string {
+	if m := siteNamePattern.FindStringSubmatch(page); m != nil {
+		return strings.TrimSpace(html.UnescapeString(m[1]))
+	}
+	return ""
+}
+
+// teamLinks returns same-site links whose text suggests a team page.
+func teamLinks(page string, base *url.URL) []string {
+	var urls []string
+	for _, m := range anchorPattern.FindAllStringSubmatch(page, -1) {
+		if !teamTextPattern.MatchString(stripTags(m[2])) {
+			continue
+		}
+		ref, err := url.Parse(html.UnescapeString(m[1]))
+		if err != nil {
+			continue
+		}
+		u := base.ResolveReference(ref)
+		if u.Host != base.Host {
+			continue
+		}
+		u.Fragment = ""
+		urls = append(urls, u.String())
+	}
+	return urls
+}
+
+var (
+	// cardPattern matches elements whose class marks them as (part of) a person card.
+	cardPattern = regexp.MustCompile(`(?i)<(?:div|li|article|section|figure)\b[^>]*class=["']([^"']*(?:team|member|person|people|staff|employee|founder|leader|profile|bio)[^"']*)["'][^>]*>`)
+	namePattern = regexp.MustCompile(`(?is)<(?:h[2-6]|strong)\b[^>]*>(.*?)</(?:h[2-6]|strong)>|<\w+[^>]*class=["'][^"']*\bname\b[^"']*["'][^>]*>(.*?)</\w+>`)
+	rolePattern = regexp.MustCompile(`(?is)<\w+[^>]*class=["'][^"']*(?:title|role|position|job)[^"']*["'][^>]*>(.*?)</\w+>`)
+	textPattern = regexp.MustCompile(`(?is)<(?:p|span)\b[^>]*>(.*?)</(?:p|span)>`)
+	imgPattern  = regexp.MustCompile(`(?i)<img[^>]+(?:data-src|src)=["']([^"']+)["']`)
+	tagPattern  = regexp.MustCompile(`<[^>]+>`)
+)
+
+// headingWords appear in section headings that look like names ("Our Team", "Meet the Founders").
+var headingWords = map[string]bool{
+	"our": true, "the": true, "team": true, "people": true, "meet": true, "leadership": true,
+	"about": true, "staff": true, "founders": true, "board": true, "advisors": true, "investors": true,
+	"careers": true, "contact": true, "us": true, "join": true,
+}
+
+// parsePeople extracts the person cards on a team page.
+func parsePeople(page, pageURL, employer string) []*profile.Profile {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	matches := cardPattern.FindAllStringSubmatchIndex(page, -1)
+	var people []*profile.Profile
+	seen := make(map[string]bool)
+	for i, m := range matches {
+		class := page[m[2]:m[3]]
+		// A card runs to the next element with the same class, so its nested
+		// parts (photo, caption) stay with it
+		end := min(len(page), m[0]+maxCard)
+		for _, next := range matches[i+1:] {
+			if page[next[2]:next[3]] == class {
+				end = min(end, next[0])
+				break
+			}
+		}
+		p := parseCard(page[m[0]:end], base, employer)
+		if p == nil || seen[strings.ToLower(p.Name)] {
+			continue
+		}
+		seen[strings.ToLower(p.Name)] = true
+		people = append(people, p)
+	}
+	return people
+}
+
+// parseCard returns the person a card describes, or nil if it does not name exactly one person.
+func parseCard(card string, base *url.URL, employer string) *profile.Profile {
+	var name string
+	var nameEnd int
+	for _, m := range namePattern.FindAllStringSubmatchIndex(card, -1) {
+		text := submatch(card, m, 1)
+		if text == "" {
+			text = submatch(card, m, 2)
+		}
+		if !isPersonName(text) {
+			continue
+		}
+		if name != "" && !strings.EqualFold(name, text) {
+			return nil // a container listing several people
+		}
+		if name == "" {
+			name, nameEnd = text, m[1]
+		}
+	}
+	if name == "" {
+		return nil
+	}
+
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      base.String() + "#" + slug(name),
+		Name:     name,
+		Fields:   map[string]string{profile.FieldEmployer: employer},
+	}
+
+	if m := rolePattern.FindStringSubmatch(card); m != nil {
+		p.Fields[profile.FieldTitle] = clean(m[1])
+	}
+	for _, m := range textPattern.FindAllStringSubmatch(card[nameEnd:], -1) {
+		text := clean(m[1])
+		switch {
+		case text == "" || text == name:
+		case p.Fields[profile.FieldTitle] == "" && len(text) <= 80:
+			p.Fields[profile.FieldTitle] = text
+		case len(text) > 80 && p.Bio == "":
+			p.Bio = text
+		}
+	}
+	if p.Fields[profile.FieldTitle] == "" {
+		delete(p.Fields, profile.FieldTitle)
+	}
+
+	if m := imgPattern.FindStringSubmatch(card); m != nil {
+		if ref, err := url.Parse(html.UnescapeString(m[1])); err == nil {
+			p.Fields[profile.FieldAvatarURL] = base.ResolveReference(ref).String()
+		}
+	}
+	if emails := htmlutil.EmailAddresses(card); len(emails) > 0 {
+		p.Fields[profile.FieldEmail] = emails[0]
+	}
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(card), nil)
+	return p
+}
+
+// nameParticles are lowercase words allowed inside names ("Ludwig van Beethoven").
+var nameParticles = map[string]bool{
+	"van": true, "von": true, "der": true, "den": true, "de": true, "da": true, "di": true,
+	"del": true, "la": true, "le": true, "bin": true, "al": true, "dos": true, "du": true,
+}
+
+// isPersonName reports whether s looks like a full name: two to four capitalized
+// words, without digits or section-heading words.
+func isPersonName(s string) bool {
+	words := strings.Fields(s)
+	if len(words) < 2 || len(words) > 4 || len(s) > 60 {
+		return false
+	}
+	for i, w := range words {
+		if i > 0 && i < len(words)-1 && nameParticles[w] {
+			continue
+		}
+		if headingWords[strings.ToLower(w)] || strings.ContainsAny(w, "0123456789@:|/") {
+			return false
+		}
+		if r := []rune(w)[0]; !unicode.IsLetter(r) || unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func submatch(s string, m []int, n int) string {
+	if m[2*n] < 0 {
+		return ""
+	}
+	return clean(s[m[2*n]:m[2*n+1]])
+}
+
+// clean strips tags and entities and collapses whitespace.
+func clean(s string) string {
+	return htmlutil.CleanText(stripTags(s))
+}
+
+func stripTags(s string) string { return tagPattern.ReplaceAllString(s, " ") }
+
+// slug turns a name into a URL fragment: "Jane O'Doe" -> "jane-o-doe".
+func slug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if ('a' <= r && r <= 'z') || ('0' <= r && r <= '9') || r > 127 {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
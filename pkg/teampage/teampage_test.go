@@ -0,0 +1,175 @@
+package teampage
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const teamHTML = `<html><body>
+<section class="team-section">
+  <h2>Meet Our Team</h2>
+  <div class="team-member">
+    <div class="member-photo"><img src="/img/jane.jpg" alt=""></div>
+    <div class="member-info">
+      <h3>Jane Doe</h3>
+      <p class="member-title">Co-Founder &amp; CEO</p>
+      <p>Jane spent a decade building payment infrastructure before starting Acme with her co-founder in 2019.</p>
+      <a href="https://twitter.com/janedoe">Twitter</a>
+      <a href="https://www.linkedin.com/in/janedoe/">LinkedIn</a>
+    </div>
+  </div>
+  <div class="team-member">
+    <div class="member-photo"><img data-src="https://cdn.acme.com/bob.png"></div>
+    <div class="member-info">
+      <h3>Bob Smith</h3>
+      <p>VP Engineering</p>
+      <a href="https://github.com/bobsmith">GitHub</a>
+      <a href="mailto:bob@acme.com">Email</a>
+    </div>
+  </div>
+</section>
+<div class="careers"><h3>Join Us</h3></div>
+</body></html>`
+
+func TestParsePeople(t *testing.T) {
+	people := parsePeople(teamHTML, "https://acme.com/team", "Acme")
+	if len(people) != 2 {
+		t.Fatalf("parsePeople() returned %d people, want 2: %+v", len(people), people)
+	}
+
+	jane, bob := people[0], people[1]
+	if jane.Name != "Jane Doe" || jane.URL != "https://acme.com/team#jane-doe" || jane.Platform != platform {
+		t.Errorf("jane = %q %q %q", jane.Name, jane.URL, jane.Platform)
+	}
+	if got := jane.Fields[profile.FieldTitle]; got != "Co-Founder & CEO" {
+		t.Errorf("jane title = %q", got)
+	}
+	if got := jane.Fields[profile.FieldAvatarURL]; got != "https://acme.com/img/jane.jpg" {
+		t.Errorf("jane avatar = %q", got)
+	}
+	if got := jane.Fields[profile.FieldEmployer]; got != "Acme" {
+		t.Errorf("jane employer = %q", got)
+	}
+	if jane.Bio == "" {
+		t.Error("jane bio is empty")
+	}
+	if len(jane.SocialLinks) != 2 {
+		t.Errorf("jane links = %v, want twitter and linkedin", jane.SocialLinks)
+	}
+
+	if got := bob.Fields[profile.FieldTitle]; got != "VP Engineering" {
+		t.Errorf("bob title = %q", got)
+	}
+	if got := bob.Fields[profile.FieldAvatarURL]; got != "https://cdn.acme.com/bob.png" {
+		t.Errorf("bob avatar = %q", got)
+	}
+	if got := bob.Fields[profile.FieldEmail]; got != "bob@acme.com" {
+		t.Errorf("bob email = %q", got)
+	}
+	if len(bob.SocialLinks) != 1 || bob.SocialLinks[0] != "https://github.com/bobsmith" {
+		t.Errorf("bob links = %v", bob.SocialLinks)
+	}
+}
+
+func TestIsPersonName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"Jane Doe", true},
+		{"Mary Jo van Buren", true},
+		{"José Álvarez", true},
+		{"Meet Our Team", false},
+		{"Jane", false},
+		{"CEO & Founder", false},
+		{"Q3 Results", false},
+	}
+	for _, tt := range tests {
+		if got := isPersonName(tt.in); got != tt.want {
+			t.Errorf("isPersonName(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`<meta property="og:site_name" content="Acme Inc"><a href="/company/people">Our people</a>`))
+		case "/company/people":
+			_, _ = w.Write([]byte(teamHTML))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(context.Background(), WithLogger(slog.New(slog.DiscardHandler)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.httpClient.Transport = &mockTransport{mockURL: server.URL}
+
+	people, err := c.Fetch(context.Background(), "acme.com")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if len(people) != 2 {
+		t.Fatalf("Fetch() returned %d people, want 2", len(people))
+	}
+	if people[0].URL != "https://acme.com/company/people#jane-doe" || people[0].Fields[profile.FieldEmployer] != "Acme Inc" {
+		t.Errorf("people[0] = %q, employer %q", people[0].URL, people[0].Fields[profile.FieldEmployer])
+	}
+
+}
+
+func TestFetchNoTeam(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	c, err := New(context.Background(), WithLogger(slog.New(slog.DiscardHandler)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.httpClient.Transport = &mockTransport{mockURL: server.URL}
+
+	if _, err := c.Fetch(context.Background(), "empty.example"); !errors.Is(err, profile.ErrProfileNotFound) {
+		t.Errorf("Fetch() error = %v, want ErrProfileNotFound", err)
+	}
+}
+
+func TestBaseURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"acme.com":                  "https://acme.com/",
+		"http://www.acme.com/about": "http://www.acme.com/",
+	} {
+		u, err := baseURL(in)
+		if err != nil || u.String() != want {
+			t.Errorf("baseURL(%q) = %v, %v; want %q", in, u, err, want)
+		}
+	}
+	if _, err := baseURL("ftp://acme.com"); err == nil {
+		t.Error("baseURL(ftp) should fail")
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, err := url.Parse(t.mockURL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
@@ -44,6 +45,7 @@ type config struct {
 	cache          cache.HTTPCache
 	logger         *slog.Logger
 	browserCookies bool
+	noCookies      bool
 }
 
 // WithCookies sets explicit cookie values.
@@ -51,6 +53,12 @@ func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
+// WithoutCookies makes the client anonymous: it reads no cookies from WithCookies,
+// the environment, or browsers.
+func WithoutCookies() Option {
+	return func(c *config) { c.noCookies = true }
+}
+
 // WithBrowserCookies enables reading cookies from browser stores.
 func WithBrowserCookies() Option {
 	return func(c *config) { c.browserCookies = true }
@@ -75,12 +83,14 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	var sources []auth.Source
-	if len(cfg.cookies) > 0 {
-		sources = append(sources, auth.NewStaticSource(cfg.cookies))
-	}
-	sources = append(sources, auth.EnvSource{})
-	if cfg.browserCookies {
-		sources = append(sources, auth.NewBrowserSource(cfg.logger))
+	if !cfg.noCookies {
+		if len(cfg.cookies) > 0 {
+			sources = append(sources, auth.NewStaticSource(cfg.cookies))
+		}
+		sources = append(sources, auth.EnvSource{})
+		if cfg.browserCookies {
+			sources = append(sources, auth.NewBrowserSource(cfg.logger))
+		}
 	}
 
 	cookies, err := auth.ChainSources(ctx, platform, sources...)
@@ -131,6 +141,16 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return c.parseProfile(ctx, body, profileURL)
 }
 
+// Parse extracts a profile from a TikTok profile page's HTML without fetching anything.
+func Parse(ctx context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	c := &Client{logger: slog.New(slog.DiscardHandler)}
+	return c.parseProfile(ctx, data, "https://www.tiktok.com/@"+username)
+}
+
 func setHeaders(req *http.Request) {
 	// User-Agent matching Chrome 120 on macOS
 	userAgent := "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 " +
@@ -202,10 +222,13 @@ func (c *Client) parseProfile(ctx context.Context, body []byte, profileURL strin
 	if signature, ok := user["signature"].(string); ok {
 		p.Bio = signature
 	}
+	if created, ok := user["createTime"].(float64); ok && created > 0 {
+		p.CreatedAt = time.Unix(int64(created), 0).UTC().Format(time.RFC3339)
+	}
 
 	// Extract social links from page content
 	p.SocialLinks = htmlutil.SocialLinks(content)
-	p.SocialLinks = filterSamePlatformLinks(p.SocialLinks)
+	p.SocialLinks = links.Clean(p.SocialLinks, Match)
 
 	c.logger.InfoContext(ctx, "tiktok profile parsed",
 		"username", p.Username,
@@ -215,34 +238,27 @@ func (c *Client) parseProfile(ctx context.Context, body []byte, profileURL strin
 	return p, nil
 }
 
+// universalDataPattern matches the JSON TikTok embeds to hydrate its pages.
+var universalDataPattern = regexp.MustCompile(`<script[^>]*id="__UNIVERSAL_DATA_FOR_REHYDRATION__"[^>]*>([^<]+)</script>`)
+
 // extractUniversalData extracts the JSON content from the __UNIVERSAL_DATA_FOR_REHYDRATION__ script tag.
 func extractUniversalData(content string) string {
 	// Match: <script id="__UNIVERSAL_DATA_FOR_REHYDRATION__" type="application/json">{...}</script>
-	re := regexp.MustCompile(`<script[^>]*id="__UNIVERSAL_DATA_FOR_REHYDRATION__"[^>]*>([^<]+)</script>`)
-	if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+	if matches := universalDataPattern.FindStringSubmatch(content); len(matches) > 1 {
 		return matches[1]
 	}
 	return ""
 }
 
+// usernamePattern matches the username in a TikTok URL.
+var usernamePattern = regexp.MustCompile(`tiktok\.com/@([^/?]+)`)
+
 // extractUsername extracts the username from a TikTok URL or @username string.
 func extractUsername(s string) string {
 	if strings.Contains(s, "/") {
-		re := regexp.MustCompile(`tiktok\.com/@([^/?]+)`)
-		if m := re.FindStringSubmatch(s); len(m) > 1 {
+		if m := usernamePattern.FindStringSubmatch(s); len(m) > 1 {
 			return m[1]
 		}
 	}
 	return strings.TrimPrefix(s, "@")
 }
-
-// filterSamePlatformLinks removes TikTok URLs from the social links list.
-func filterSamePlatformLinks(links []string) []string {
-	var filtered []string
-	for _, link := range links {
-		if !Match(link) {
-			filtered = append(filtered, link)
-		}
-	}
-	return filtered
-}
@@ -3,6 +3,8 @@ package tiktok
 import (
 	"context"
 	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 )
 
 func TestMatch(t *testing.T) {
@@ -105,7 +107,7 @@ func TestExtractUniversalData(t *testing.T) {
 	}
 }
 
-func TestFilterSamePlatformLinks(t *testing.T) {
+func TestCleanLinks(t *testing.T) {
 	tests := []struct {
 		name  string
 		links []string
@@ -130,14 +132,14 @@ func TestFilterSamePlatformLinks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := filterSamePlatformLinks(tt.links)
+			got := links.Clean(tt.links, Match)
 			if len(got) != len(tt.want) {
-				t.Errorf("filterSamePlatformLinks() = %v, want %v", got, tt.want)
+				t.Errorf("links.Clean() = %v, want %v", got, tt.want)
 				return
 			}
 			for i := range got {
 				if got[i] != tt.want[i] {
-					t.Errorf("filterSamePlatformLinks()[%d] = %q, want %q", i, got[i], tt.want[i])
+					t.Errorf("links.Clean()[%d] = %q, want %q", i, got[i], tt.want[i])
 				}
 			}
 		})
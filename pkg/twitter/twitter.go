@@ -17,6 +17,7 @@ import (
 	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
 	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
 	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
 )
 
@@ -24,10 +25,12 @@ const platform = "twitter"
 
 // Match returns true if the URL is a Twitter/X profile URL.
 func Match(urlStr string) bool {
-	lower := strings.ToLower(urlStr)
-	return strings.Contains(lower, "twitter.com/") || strings.Contains(lower, "x.com/")
+	return hostPattern.MatchString(urlStr)
 }
 
+// hostPattern requires twitter.com or x.com as the whole host, so domains such as 500px.com don't match.
+var hostPattern = regexp.MustCompile(`(?i)(?:^|[/.])(?:twitter|x)\.com/`)
+
 // IsValidUsername validates a Twitter username against platform requirements.
 // Twitter usernames must be 1-15 characters and contain only alphanumeric or underscore.
 func IsValidUsername(username string) bool {
@@ -110,6 +113,7 @@ type config struct {
 	cache          cache.HTTPCache
 	logger         *slog.Logger
 	browserCookies bool
+	noCookies      bool
 }
 
 // WithCookies sets explicit cookie values.
@@ -117,6 +121,12 @@ func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
+// WithoutCookies makes the client anonymous: it reads no cookies from WithCookies,
+// the environment, or browsers.
+func WithoutCookies() Option {
+	return func(c *config) { c.noCookies = true }
+}
+
 // WithBrowserCookies enables reading cookies from browser stores.
 func WithBrowserCookies() Option {
 	return func(c *config) { c.browserCookies = true }
@@ -141,12 +151,14 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	var sources []auth.Source
-	if len(cfg.cookies) > 0 {
-		sources = append(sources, auth.NewStaticSource(cfg.cookies))
-	}
-	sources = append(sources, auth.EnvSource{})
-	if cfg.browserCookies {
-		sources = append(sources, auth.NewBrowserSource(cfg.logger))
+	if !cfg.noCookies {
+		if len(cfg.cookies) > 0 {
+			sources = append(sources, auth.NewStaticSource(cfg.cookies))
+		}
+		sources = append(sources, auth.EnvSource{})
+		if cfg.browserCookies {
+			sources = append(sources, auth.NewBrowserSource(cfg.logger))
+		}
 	}
 
 	cookies, err := auth.ChainSources(ctx, platform, sources...)
@@ -254,6 +266,17 @@ func (c *Client) fetchViaHTML(ctx context.Context, username, profileURL string)
 	return c.parseProfile(body, profileURL, username)
 }
 
+// Parse extracts a profile from an X/Twitter page's HTML without fetching anything,
+// for pages saved with their __INITIAL_STATE__ script intact.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	username := extractUsername(urlStr)
+	if username == "" {
+		return nil, fmt.Errorf("could not extract username from: %s", urlStr)
+	}
+	c := &Client{logger: slog.New(slog.DiscardHandler)}
+	return c.parseProfile(data, "https://x.com/"+username, username)
+}
+
 // EnableDebug enables debug logging.
 func (c *Client) EnableDebug() { c.debug = true }
 
@@ -294,7 +317,7 @@ func (c *Client) parseProfile(body []byte, profileURL, targetUsername string) (*
 		p.URL = profileURL
 		p.Authenticated = true
 		p.SocialLinks = htmlutil.SocialLinks(content)
-		p.SocialLinks = filterSamePlatformLinks(p.SocialLinks)
+		p.SocialLinks = links.Clean(p.SocialLinks, Match)
 		return p, nil
 	}
 
@@ -311,19 +334,23 @@ func (c *Client) parseProfile(body []byte, profileURL, targetUsername string) (*
 	p.URL = profileURL
 	p.Authenticated = true
 	p.SocialLinks = htmlutil.SocialLinks(content)
-	p.SocialLinks = filterSamePlatformLinks(p.SocialLinks)
+	p.SocialLinks = links.Clean(p.SocialLinks, Match)
 
 	return p, nil
 }
 
+// The __INITIAL_STATE__ assignment, on one line or spanning several up to </script>.
+var (
+	initialStatePattern       = regexp.MustCompile(`window\.__INITIAL_STATE__\s*=\s*(\{.+?\});?\s*(?:</script>|window\.)`)
+	initialStateScriptPattern = regexp.MustCompile(`(?s)window\.__INITIAL_STATE__\s*=\s*(\{.*?\});\s*</script>`)
+)
+
 func extractInitialState(content string) string {
-	re := regexp.MustCompile(`window\.__INITIAL_STATE__\s*=\s*(\{.+?\});?\s*(?:</script>|window\.)`)
-	if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+	if matches := initialStatePattern.FindStringSubmatch(content); len(matches) > 1 {
 		return matches[1]
 	}
 
-	re2 := regexp.MustCompile(`(?s)window\.__INITIAL_STATE__\s*=\s*(\{.*?\});\s*</script>`)
-	if matches := re2.FindStringSubmatch(content); len(matches) > 1 {
+	if matches := initialStateScriptPattern.FindStringSubmatch(content); len(matches) > 1 {
 		return matches[1]
 	}
 
@@ -470,10 +497,12 @@ func extractWebsiteFromUser(user map[string]any) string {
 	return ""
 }
 
+// usernamePattern matches the username in a twitter.com or x.com URL.
+var usernamePattern = regexp.MustCompile(`(?:x\.com|twitter\.com)/([^/?]+)`)
+
 func extractUsername(s string) string {
 	if strings.Contains(s, "/") {
-		re := regexp.MustCompile(`(?:x\.com|twitter\.com)/([^/?]+)`)
-		if m := re.FindStringSubmatch(s); len(m) > 1 {
+		if m := usernamePattern.FindStringSubmatch(s); len(m) > 1 {
 			return m[1]
 		}
 	}
@@ -560,12 +589,14 @@ func parseGraphQLResponse(body []byte, profileURL, _ string) (*profile.Profile,
 					Core   struct {
 						Name       string `json:"name"`
 						ScreenName string `json:"screen_name"`
+						CreatedAt  string `json:"created_at"`
 					} `json:"core"`
 					Location struct {
 						Location string `json:"location"`
 					} `json:"location"`
 					Legacy struct {
 						Description string `json:"description"`
+						CreatedAt   string `json:"created_at"`
 						Entities    struct {
 							URL struct {
 								URLs []struct {
@@ -598,8 +629,13 @@ func parseGraphQLResponse(body []byte, profileURL, _ string) (*profile.Profile,
 		Name:          result.Core.Name,
 		Bio:           result.Legacy.Description,
 		Location:      result.Location.Location,
+		CreatedAt:     result.Core.CreatedAt,
 		Fields:        make(map[string]string),
 	}
+	// Older responses carry the join date in legacy rather than core
+	if p.CreatedAt == "" {
+		p.CreatedAt = result.Legacy.CreatedAt
+	}
 
 	// Extract website
 	if len(result.Legacy.Entities.URL.URLs) > 0 {
@@ -612,14 +648,3 @@ func parseGraphQLResponse(body []byte, profileURL, _ string) (*profile.Profile,
 
 	return p, nil
 }
-
-func filterSamePlatformLinks(links []string) []string {
-	var filtered []string
-	for _, link := range links {
-		// Skip Twitter/X URLs
-		if !Match(link) {
-			filtered = append(filtered, link)
-		}
-	}
-	return filtered
-}
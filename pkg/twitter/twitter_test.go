@@ -1,6 +1,10 @@
 package twitter
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+)
 
 func TestMatch(t *testing.T) {
 	tests := []struct {
@@ -16,6 +20,8 @@ func TestMatch(t *testing.T) {
 		{"https://TWITTER.COM/johndoe", true},
 		{"https://linkedin.com/in/johndoe", false},
 		{"https://example.com", false},
+		{"https://500px.com/p/johndoe", false},
+		{"https://fedex.com/en-us", false},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +92,7 @@ func TestParseGraphQLResponse(t *testing.T) {
 		wantName     string
 		wantBio      string
 		wantLocation string
+		wantCreated  string
 		wantErr      bool
 	}{
 		{
@@ -104,6 +111,7 @@ func TestParseGraphQLResponse(t *testing.T) {
 							},
 							"legacy": {
 								"description": "Software engineer at Big Tech",
+								"created_at": "Wed Mar 04 12:00:00 +0000 2009",
 								"entities": {
 									"url": {
 										"urls": [
@@ -120,6 +128,7 @@ func TestParseGraphQLResponse(t *testing.T) {
 			wantName:     "John Doe",
 			wantBio:      "Software engineer at Big Tech",
 			wantLocation: "San Francisco, CA",
+			wantCreated:  "Wed Mar 04 12:00:00 +0000 2009",
 		},
 		{
 			name: "minimal profile",
@@ -186,28 +195,31 @@ func TestParseGraphQLResponse(t *testing.T) {
 			if tt.wantLocation != "" && profile.Location != tt.wantLocation {
 				t.Errorf("Location = %q, want %q", profile.Location, tt.wantLocation)
 			}
+			if profile.CreatedAt != tt.wantCreated {
+				t.Errorf("CreatedAt = %q, want %q", profile.CreatedAt, tt.wantCreated)
+			}
 		})
 	}
 }
 
-func TestFilterSamePlatformLinks(t *testing.T) {
-	links := []string{
+func TestCleanLinks(t *testing.T) {
+	in := []string{
 		"https://twitter.com/other",
 		"https://x.com/someone",
 		"https://github.com/user",
 		"https://linkedin.com/in/user",
 	}
 
-	filtered := filterSamePlatformLinks(links)
+	filtered := links.Clean(in, Match)
 
 	// Should filter out the twitter.com and x.com links
 	if len(filtered) != 2 {
-		t.Errorf("filterSamePlatformLinks() returned %d links, want 2", len(filtered))
+		t.Errorf("links.Clean() returned %d links, want 2", len(filtered))
 	}
 
 	for _, link := range filtered {
 		if Match(link) {
-			t.Errorf("filterSamePlatformLinks() should have filtered %q", link)
+			t.Errorf("links.Clean() should have filtered %q", link)
 		}
 	}
 }
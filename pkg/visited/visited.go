@@ -0,0 +1,279 @@
+// Package visited provides a compact, persistent record of URLs a crawl has fetched.
+//
+// A Set combines a Bloom filter, which remembers every URL ever added in a fixed
+// amount of memory at the cost of rare false positives, with an exact set of the
+// most recently added URLs. Saving and reopening the Set lets a restarted crawl
+// skip URLs fetched by earlier runs without loading the whole cache index.
+package visited
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileMagic identifies the on-disk format.
+const fileMagic = "SPVS1\n"
+
+// Defaults for Open when the file does not exist yet.
+const (
+	DefaultCapacity          = 10_000_000 // URLs the filter is sized for
+	DefaultFalsePositiveRate = 0.001
+	DefaultRecent            = 100_000 // URLs kept in the exact set
+)
+
+// Set is a persistent visited-URL set. It is safe for concurrent use.
+type Set struct {
+	recent      map[string]struct{}
+	path        string
+	bits        []uint64
+	ring        []string // insertion order of recent, for eviction
+	ringNext    int
+	hashes      uint32
+	count       uint64
+	recentLimit int
+	mu          sync.RWMutex
+	dirty       bool
+}
+
+// New creates an in-memory Set sized for capacity URLs at the given false positive
+// rate, keeping the last recentLimit URLs in an exact set.
+func New(capacity int, falsePositiveRate float64, recentLimit int) *Set {
+	capacity = max(capacity, 1)
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = DefaultFalsePositiveRate
+	}
+	// Optimal filter size m = -n ln p / (ln 2)^2 and hash count k = m/n ln 2
+	m := math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := max(1, int(math.Round(m/float64(capacity)*math.Ln2)))
+	words := int(math.Ceil(m / 64))
+
+	return &Set{
+		recent:      make(map[string]struct{}),
+		bits:        make([]uint64, words),
+		ring:        make([]string, max(recentLimit, 0)),
+		hashes:      uint32(k), //nolint:gosec // k is small
+		recentLimit: max(recentLimit, 0),
+	}
+}
+
+// Open loads the Set saved at path, or creates a default-sized one that will be
+// saved there if the file does not exist.
+func Open(path string) (*Set, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		s := New(DefaultCapacity, DefaultFalsePositiveRate, DefaultRecent)
+		s.path = path
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // read-only file
+
+	s, err := read(bufio.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("reading visited set %s: %w", path, err)
+	}
+	s.path = path
+	return s, nil
+}
+
+// Contains reports whether url was added before. False positives are possible for
+// URLs older than the recent window, at roughly the configured rate.
+func (s *Set) Contains(url string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.recent[url]; ok {
+		return true
+	}
+	h1, h2 := hashURL(url)
+	nbits := uint64(len(s.bits)) * 64
+	for i := range uint64(s.hashes) {
+		bit := (h1 + i*h2) % nbits
+		if s.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Add records url as visited.
+func (s *Set) Add(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h1, h2 := hashURL(url)
+	nbits := uint64(len(s.bits)) * 64
+	for i := range uint64(s.hashes) {
+		bit := (h1 + i*h2) % nbits
+		s.bits[bit/64] |= 1 << (bit % 64)
+	}
+	s.count++
+	s.dirty = true
+
+	if s.recentLimit == 0 {
+		return
+	}
+	if _, ok := s.recent[url]; ok {
+		return
+	}
+	if old := s.ring[s.ringNext]; old != "" {
+		delete(s.recent, old)
+	}
+	s.ring[s.ringNext] = url
+	s.recent[url] = struct{}{}
+	s.ringNext = (s.ringNext + 1) % s.recentLimit
+}
+
+// Len returns how many URLs have been added, including duplicates older than the recent window.
+func (s *Set) Len() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.count
+}
+
+// Save writes the Set to the path it was opened from, if it changed.
+// The file is replaced atomically.
+func (s *Set) Save() error {
+	if s.path == "" {
+		return errors.New("visited set has no path; use Open")
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.dirty {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }() //nolint:errcheck // no-op after successful rename
+
+	w := bufio.NewWriter(tmp)
+	if err := s.write(w); err != nil {
+		_ = tmp.Close() //nolint:errcheck // already failing
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close() //nolint:errcheck // already failing
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	s.dirty = false
+	return nil
+}
+
+// write serializes the set: magic, header, filter words, then recent URLs oldest first.
+func (s *Set) write(w io.Writer) error {
+	if _, err := io.WriteString(w, fileMagic); err != nil {
+		return err
+	}
+	header := []uint64{uint64(s.hashes), s.count, uint64(len(s.bits)), uint64(s.recentLimit)}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, s.bits); err != nil {
+		return err
+	}
+
+	var recent []string
+	for i := range s.recentLimit {
+		if u := s.ring[(s.ringNext+i)%s.recentLimit]; u != "" {
+			recent = append(recent, u)
+		}
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint64(len(recent))); err != nil {
+		return err
+	}
+	for _, u := range recent {
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(u))); err != nil { //nolint:gosec // URLs are short
+			return err
+		}
+		if _, err := io.WriteString(w, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func read(r io.Reader) (*Set, error) {
+	magic := make([]byte, len(fileMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, err
+	}
+	if string(magic) != fileMagic {
+		return nil, errors.New("not a visited set file")
+	}
+
+	var header [4]uint64
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+	hashes, count, words, recentLimit := header[0], header[1], header[2], header[3]
+	if hashes == 0 || hashes > 64 || words == 0 || words > 1<<32 || recentLimit > 1<<24 {
+		return nil, errors.New("corrupt visited set header")
+	}
+
+	s := &Set{
+		recent:      make(map[string]struct{}),
+		bits:        make([]uint64, words),
+		ring:        make([]string, recentLimit),
+		hashes:      uint32(hashes),
+		count:       count,
+		recentLimit: int(recentLimit),
+	}
+	if err := binary.Read(r, binary.LittleEndian, s.bits); err != nil {
+		return nil, err
+	}
+
+	var n uint64
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	}
+	for range n {
+		var size uint32
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		u := string(buf)
+		if s.recentLimit == 0 {
+			continue
+		}
+		if old := s.ring[s.ringNext]; old != "" {
+			delete(s.recent, old)
+		}
+		s.ring[s.ringNext] = u
+		s.recent[u] = struct{}{}
+		s.ringNext = (s.ringNext + 1) % s.recentLimit
+	}
+	return s, nil
+}
+
+// hashURL returns two independent 64-bit hashes for double hashing.
+func hashURL(url string) (h1, h2 uint64) {
+	a := fnv.New64a()
+	_, _ = a.Write([]byte(url)) //nolint:errcheck // hash writes never fail
+	b := fnv.New64()
+	_, _ = b.Write([]byte(url)) //nolint:errcheck // hash writes never fail
+	// An odd h2 never shares a factor with the power-of-two bit count
+	return a.Sum64(), b.Sum64() | 1
+}
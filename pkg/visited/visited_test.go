@@ -0,0 +1,104 @@
+package visited
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetContains(t *testing.T) {
+	s := New(10_000, 0.01, 100)
+	for i := range 5_000 {
+		s.Add(fmt.Sprintf("github.com/user%d", i))
+	}
+
+	for i := range 5_000 {
+		if u := fmt.Sprintf("github.com/user%d", i); !s.Contains(u) {
+			t.Fatalf("Contains(%q) = false after Add", u)
+		}
+	}
+
+	falsePositives := 0
+	for i := range 10_000 {
+		if s.Contains(fmt.Sprintf("mastodon.social/@other%d", i)) {
+			falsePositives++
+		}
+	}
+	// Sized for 1% at 10k entries; half full should stay well under that
+	if falsePositives > 100 {
+		t.Errorf("false positives = %d of 10000, want <= 100", falsePositives)
+	}
+	if s.Len() != 5_000 {
+		t.Errorf("Len() = %d, want 5000", s.Len())
+	}
+}
+
+func TestSetRecentEviction(t *testing.T) {
+	s := New(100, 0.01, 2)
+	s.Add("a")
+	s.Add("b")
+	s.Add("c")
+
+	if _, ok := s.recent["a"]; ok {
+		t.Error("oldest entry should have been evicted from the recent set")
+	}
+	for _, u := range []string{"b", "c"} {
+		if _, ok := s.recent[u]; !ok {
+			t.Errorf("recent set missing %q", u)
+		}
+	}
+	// Evicted entries are still remembered by the filter
+	if !s.Contains("a") {
+		t.Error("Contains(a) = false after eviction from recent set")
+	}
+}
+
+func TestOpenSaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visited.bin")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() on missing file error = %v", err)
+	}
+	s.Add("github.com/alice")
+	s.Add("bsky.app/profile/alice.bsky.social")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	for _, u := range []string{"github.com/alice", "bsky.app/profile/alice.bsky.social"} {
+		if !reopened.Contains(u) {
+			t.Errorf("reopened set does not contain %q", u)
+		}
+		if _, ok := reopened.recent[u]; !ok {
+			t.Errorf("reopened recent set missing %q", u)
+		}
+	}
+	if reopened.Contains("github.com/bob") {
+		t.Error("reopened set contains a URL that was never added")
+	}
+	if reopened.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", reopened.Len())
+	}
+}
+
+func TestOpenCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visited.bin")
+	if err := os.WriteFile(path, []byte("not a bloom filter"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Open() on corrupt file should fail")
+	}
+}
+
+func TestSaveWithoutPath(t *testing.T) {
+	if err := New(10, 0.01, 1).Save(); err == nil {
+		t.Error("Save() on a Set without a path should fail")
+	}
+}
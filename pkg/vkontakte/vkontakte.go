@@ -5,11 +5,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
 	"log/slog"
 	"net/http"
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/codeGROOVE-dev/sociopath/pkg/auth"
 	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
@@ -43,6 +45,7 @@ type config struct {
 	cache          cache.HTTPCache
 	logger         *slog.Logger
 	browserCookies bool
+	noCookies      bool
 }
 
 // WithCookies sets explicit cookie values.
@@ -50,6 +53,12 @@ func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
+// WithoutCookies makes the client anonymous: it reads no cookies from WithCookies,
+// the environment, or browsers.
+func WithoutCookies() Option {
+	return func(c *config) { c.noCookies = true }
+}
+
 // WithBrowserCookies enables reading cookies from browser stores.
 func WithBrowserCookies() Option {
 	return func(c *config) { c.browserCookies = true }
@@ -75,12 +84,14 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 
 	// Try to get cookies but don't fail if not available
 	var sources []auth.Source
-	if len(cfg.cookies) > 0 {
-		sources = append(sources, auth.NewStaticSource(cfg.cookies))
-	}
-	sources = append(sources, auth.EnvSource{})
-	if cfg.browserCookies {
-		sources = append(sources, auth.NewBrowserSource(cfg.logger))
+	if !cfg.noCookies {
+		if len(cfg.cookies) > 0 {
+			sources = append(sources, auth.NewStaticSource(cfg.cookies))
+		}
+		sources = append(sources, auth.EnvSource{})
+		if cfg.browserCookies {
+			sources = append(sources, auth.NewBrowserSource(cfg.logger))
+		}
 	}
 
 	cookies, _ := auth.ChainSources(ctx, platform, sources...) //nolint:errcheck // cookies are optional
@@ -127,7 +138,15 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 
-	return parseProfile(string(body), urlStr)
+	return parseProfile(decodeBody(body), urlStr)
+}
+
+// Parse extracts a profile from a VKontakte profile page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	if !strings.HasPrefix(urlStr, "http") {
+		urlStr = "https://vk.com/" + strings.TrimPrefix(urlStr, "vk.com/")
+	}
+	return parseProfile(decodeBody(data), urlStr)
 }
 
 func setHeaders(req *http.Request) {
@@ -139,9 +158,15 @@ func setHeaders(req *http.Request) {
 	req.Header.Set("Upgrade-Insecure-Requests", "1")
 }
 
-func parseProfile(html, url string) (*profile.Profile, error) {
+// Birthday and education labels, in English and Russian.
+var (
+	birthdayPattern = regexp.MustCompile(`(?i)birthday[^>]*>([^<]+)</|день рождения[^>]*>([^<]+)</`)
+	eduPattern      = regexp.MustCompile(`(?i)education[^>]*>([^<]+)</|образование[^>]*>([^<]+)</|studied at[^>]*>([^<]+)</|учился[^>]*>([^<]+)</`)
+)
+
+func parseProfile(content, url string) (*profile.Profile, error) {
 	// Check for bot detection page
-	if strings.Contains(html, "У вас большие запросы") || strings.Contains(html, "You are making too many requests") {
+	if strings.Contains(content, "У вас большие запросы") || strings.Contains(content, "You are making too many requests") {
 		return nil, errors.New("VK bot detection triggered - try using browser cookies")
 	}
 
@@ -153,20 +178,21 @@ func parseProfile(html, url string) (*profile.Profile, error) {
 	}
 
 	// Extract name from title or meta tags
-	prof.Name = htmlutil.Title(html)
+	prof.Name = htmlutil.Title(content)
 	if prof.Name != "" {
-		// Clean up VK title format "Name | VK"
-		if idx := strings.Index(prof.Name, " | VK"); idx != -1 {
-			prof.Name = strings.TrimSpace(prof.Name[:idx])
+		// Clean up VK title format "Name | VK", or "Name | ВКонтакте" in Russian
+		for _, suffix := range []string{" | VK", " | ВКонтакте"} {
+			if idx := strings.Index(prof.Name, suffix); idx != -1 {
+				prof.Name = strings.TrimSpace(prof.Name[:idx])
+			}
 		}
 	}
 
 	// Extract bio/description
-	prof.Bio = htmlutil.Description(html)
+	prof.Bio = htmlutil.Description(content)
 
 	// Extract birthday (Russian: День рождения)
-	birthdayPattern := regexp.MustCompile(`(?i)birthday[^>]*>([^<]+)</|день рождения[^>]*>([^<]+)</`)
-	if matches := birthdayPattern.FindStringSubmatch(html); len(matches) > 1 {
+	if matches := birthdayPattern.FindStringSubmatch(content); len(matches) > 1 {
 		for i := 1; i < len(matches); i++ {
 			if matches[i] != "" {
 				birthday := strings.TrimSpace(matches[i])
@@ -178,23 +204,14 @@ func parseProfile(html, url string) (*profile.Profile, error) {
 		}
 	}
 
-	// Extract city (Russian: Город)
-	cityPattern := regexp.MustCompile(`(?i)city[^>]*>([^<]+)</|город[^>]*>([^<]+)</`)
-	if matches := cityPattern.FindStringSubmatch(html); len(matches) > 1 {
-		for i := 1; i < len(matches); i++ {
-			if matches[i] != "" {
-				city := strings.TrimSpace(matches[i])
-				if city != "" {
-					prof.Location = city
-					break
-				}
-			}
-		}
+	// Extract city (Russian: Город). The value usually sits in a link in the element
+	// after the label, as in <div>Город:</div> <div><a href="...">Москва</a></div>
+	if matches := cityPattern.FindStringSubmatch(content); len(matches) > 1 {
+		prof.Location = strings.TrimSpace(html.UnescapeString(matches[1]))
 	}
 
 	// Extract education (Russian: Образование)
-	eduPattern := regexp.MustCompile(`(?i)education[^>]*>([^<]+)</|образование[^>]*>([^<]+)</|studied at[^>]*>([^<]+)</|учился[^>]*>([^<]+)</`)
-	if matches := eduPattern.FindStringSubmatch(html); len(matches) > 1 {
+	if matches := eduPattern.FindStringSubmatch(content); len(matches) > 1 {
 		for i := 1; i < len(matches); i++ {
 			if matches[i] != "" {
 				edu := strings.TrimSpace(matches[i])
@@ -207,7 +224,7 @@ func parseProfile(html, url string) (*profile.Profile, error) {
 	}
 
 	// Extract social links
-	prof.SocialLinks = htmlutil.SocialLinks(html)
+	prof.SocialLinks = htmlutil.SocialLinks(content)
 
 	// Filter out VK's own links
 	var filtered []string
@@ -221,6 +238,13 @@ func parseProfile(html, url string) (*profile.Profile, error) {
 	return prof, nil
 }
 
+// cityPattern matches the city label and captures the first text after it, skipping
+// the tags between them.
+var cityPattern = regexp.MustCompile(`(?i)(?:city|город)[^<>]*(?:<[^>]+>\s*)*:?\s*(?:<[^>]+>\s*)*([^<\s][^<]*)<`)
+
+// usernamePattern matches the screen name or ID in a VK URL.
+var usernamePattern = regexp.MustCompile(`vk\.com/([^/?#]+)`)
+
 func extractUsername(urlStr string) string {
 	// Remove protocol
 	urlStr = strings.TrimPrefix(urlStr, "https://")
@@ -228,10 +252,40 @@ func extractUsername(urlStr string) string {
 	urlStr = strings.TrimPrefix(urlStr, "www.")
 
 	// Extract vk.com/username pattern
-	re := regexp.MustCompile(`vk\.com/([^/?#]+)`)
-	if matches := re.FindStringSubmatch(urlStr); len(matches) > 1 {
+	if matches := usernamePattern.FindStringSubmatch(urlStr); len(matches) > 1 {
 		return matches[1]
 	}
 
 	return ""
 }
+
+// cp1251High maps the upper half of windows-1251, which older VK pages still use,
+// to Unicode. 0x98 is unassigned.
+var cp1251High = [128]rune{
+	'Ђ', 'Ѓ', '‚', 'ѓ', '„', '…', '†', '‡', '€', '‰', 'Љ', '‹', 'Њ', 'Ќ', 'Ћ', 'Џ',
+	'ђ', '‘', '’', '“', '”', '•', '–', '—', '\ufffd', '™', 'љ', '›', 'њ', 'ќ', 'ћ', 'џ',
+	'\u00a0', 'Ў', 'ў', 'Ј', '¤', 'Ґ', '¦', '§', 'Ё', '©', 'Є', '«', '¬', '\u00ad', '®', 'Ї',
+	'°', '±', 'І', 'і', 'ґ', 'µ', '¶', '·', 'ё', '№', 'є', '»', 'ј', 'Ѕ', 'ѕ', 'ї',
+	'А', 'Б', 'В', 'Г', 'Д', 'Е', 'Ж', 'З', 'И', 'Й', 'К', 'Л', 'М', 'Н', 'О', 'П',
+	'Р', 'С', 'Т', 'У', 'Ф', 'Х', 'Ц', 'Ч', 'Ш', 'Щ', 'Ъ', 'Ы', 'Ь', 'Э', 'Ю', 'Я',
+	'а', 'б', 'в', 'г', 'д', 'е', 'ж', 'з', 'и', 'й', 'к', 'л', 'м', 'н', 'о', 'п',
+	'р', 'с', 'т', 'у', 'ф', 'х', 'ц', 'ч', 'ш', 'щ', 'ъ', 'ы', 'ь', 'э', 'ю', 'я',
+}
+
+// decodeBody returns a page as UTF-8. Pages that are not valid UTF-8 are decoded as
+// windows-1251, so Cyrillic names and cities are not mangled.
+func decodeBody(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	var b strings.Builder
+	b.Grow(len(data) * 2)
+	for _, c := range data {
+		if c < 0x80 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteRune(cp1251High[c-0x80])
+	}
+	return b.String()
+}
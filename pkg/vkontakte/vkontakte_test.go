@@ -45,6 +45,42 @@ func TestNewWithoutCookies(t *testing.T) {
 	}
 }
 
+func TestWithoutCookiesIgnoresEnv(t *testing.T) {
+	t.Setenv("VK_REMIXSID", "secret")
+
+	for _, tt := range []struct {
+		name       string
+		opts       []Option
+		wantCookie bool
+	}{
+		{"env", nil, true},
+		{"without cookies", []Option{WithoutCookies()}, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var cookie string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cookie = r.Header.Get("Cookie")
+				_, _ = w.Write([]byte(`<html><head><title>Ivan Petrov | VK</title></head></html>`))
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			client, err := New(ctx, tt.opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			client.httpClient.Transport = &mockTransport{mockURL: server.URL}
+
+			if _, err := client.Fetch(ctx, "https://vk.com/ivanpetrov"); err != nil {
+				t.Fatalf("Fetch() error = %v", err)
+			}
+			if got := cookie != ""; got != tt.wantCookie {
+				t.Errorf("Cookie header = %q, want cookie sent: %v", cookie, tt.wantCookie)
+			}
+		})
+	}
+}
+
 func TestExtractUsername(t *testing.T) {
 	tests := []struct {
 		url  string
@@ -153,6 +189,7 @@ func TestParseProfile(t *testing.T) {
 		url      string
 		wantName string
 		wantBio  string
+		wantCity string
 		wantErr  bool
 	}{
 		{
@@ -165,6 +202,20 @@ func TestParseProfile(t *testing.T) {
 			wantName: "John Doe",
 			wantBio:  "Developer and designer",
 		},
+		{
+			name: "Russian profile",
+			html: `<html><head>
+				<title>Иван Петров | ВКонтакте</title>
+				<meta name="description" content="Разработчик &laquo;Яндекса&raquo;">
+			</head><body>
+				<div class="label fl_l">Город:</div>
+				<div class="labeled"><a href="/search?c[name]=0&amp;c[city]=1">Санкт-Петербург</a></div>
+			</body></html>`,
+			url:      "https://vk.com/ivan",
+			wantName: "Иван Петров",
+			wantBio:  "Разработчик «Яндекса»",
+			wantCity: "Санкт-Петербург",
+		},
 		{
 			name:    "bot detection triggered",
 			html:    `<html><body>You are making too many requests</body></html>`,
@@ -200,10 +251,25 @@ func TestParseProfile(t *testing.T) {
 			if tt.wantBio != "" && profile.Bio != tt.wantBio {
 				t.Errorf("Bio = %q, want %q", profile.Bio, tt.wantBio)
 			}
+			if profile.Location != tt.wantCity {
+				t.Errorf("Location = %q, want %q", profile.Location, tt.wantCity)
+			}
 		})
 	}
 }
 
+func TestDecodeBody(t *testing.T) {
+	// "<title>Мария Ёлкина | VK</title>" in windows-1251
+	page := append([]byte("<title>"), 0xcc, 0xe0, 0xf0, 0xe8, 0xff, ' ', 0xa8, 0xeb, 0xea, 0xe8, 0xed, 0xe0)
+	page = append(page, " | VK</title>"...)
+	if got, want := decodeBody(page), "<title>Мария Ёлкина | VK</title>"; got != want {
+		t.Errorf("decodeBody(windows-1251) = %q, want %q", got, want)
+	}
+	if got := decodeBody([]byte("Москва")); got != "Москва" {
+		t.Errorf("decodeBody(UTF-8) = %q, want it unchanged", got)
+	}
+}
+
 func TestWithOptions(t *testing.T) {
 	ctx := context.Background()
 
@@ -53,6 +53,7 @@ type config struct {
 	cache          cache.HTTPCache
 	logger         *slog.Logger
 	browserCookies bool
+	noCookies      bool
 }
 
 // WithCookies sets explicit cookie values.
@@ -60,6 +61,12 @@ func WithCookies(cookies map[string]string) Option {
 	return func(c *config) { c.cookies = cookies }
 }
 
+// WithoutCookies makes the client anonymous: it reads no cookies from WithCookies,
+// the environment, or browsers.
+func WithoutCookies() Option {
+	return func(c *config) { c.noCookies = true }
+}
+
 // WithHTTPCache sets the HTTP cache.
 func WithHTTPCache(httpCache cache.HTTPCache) Option {
 	return func(c *config) { c.cache = httpCache }
@@ -85,12 +92,14 @@ func New(ctx context.Context, opts ...Option) (*Client, error) {
 
 	// Build cookie sources chain
 	var sources []auth.Source
-	if len(cfg.cookies) > 0 {
-		sources = append(sources, auth.NewStaticSource(cfg.cookies))
-	}
-	sources = append(sources, auth.EnvSource{})
-	if cfg.browserCookies {
-		sources = append(sources, auth.NewBrowserSource(cfg.logger))
+	if !cfg.noCookies {
+		if len(cfg.cookies) > 0 {
+			sources = append(sources, auth.NewStaticSource(cfg.cookies))
+		}
+		sources = append(sources, auth.EnvSource{})
+		if cfg.browserCookies {
+			sources = append(sources, auth.NewBrowserSource(cfg.logger))
+		}
 	}
 
 	cookies, err := auth.ChainSources(ctx, platform, sources...)
@@ -134,6 +143,11 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 
 	c.logger.InfoContext(ctx, "fetching weibo profile", "url", urlStr, "username", username)
 
+	// The session handshake and API calls below are never cached
+	if cache.IsOffline(ctx) {
+		return nil, fmt.Errorf("%w: %s", cache.ErrNotCached, urlStr)
+	}
+
 	// Get XSRF token first
 	if err := c.fetchXSRFToken(ctx, username); err != nil {
 		return nil, fmt.Errorf("fetching XSRF token: %w", err)
@@ -170,6 +184,7 @@ type weiboProfile struct {
 	VerifiedReason string
 	Location       string
 	Hometown       string
+	IPLocation     string // Province the account last posted from, as Weibo shows it
 	Company        string
 	School         string
 	Gender         string
@@ -189,6 +204,7 @@ func (*Client) toProfile(wp *weiboProfile, urlStr string) *profile.Profile {
 		Name:          wp.ScreenName,
 		Bio:           wp.Description,
 		Location:      wp.Location,
+		CreatedAt:     wp.CreatedAt,
 		Fields:        make(map[string]string),
 	}
 
@@ -196,7 +212,7 @@ func (*Client) toProfile(wp *weiboProfile, urlStr string) *profile.Profile {
 		p.Fields["verified_reason"] = wp.VerifiedReason
 	}
 	if wp.Company != "" {
-		p.Fields["employer"] = wp.Company
+		p.Fields[profile.FieldEmployer] = wp.Company
 	}
 	if wp.School != "" {
 		p.Fields["school"] = wp.School
@@ -204,6 +220,10 @@ func (*Client) toProfile(wp *weiboProfile, urlStr string) *profile.Profile {
 	if wp.Hometown != "" {
 		p.Fields["hometown"] = wp.Hometown
 	}
+	// "IP属地：广东" loses its label
+	if loc, ok := profile.NormalizeLocation(wp.IPLocation); ok {
+		p.Fields["ip_location"] = loc
+	}
 	if wp.Gender != "" {
 		p.Fields["gender"] = wp.Gender
 	}
@@ -211,7 +231,7 @@ func (*Client) toProfile(wp *weiboProfile, urlStr string) *profile.Profile {
 		p.Fields["verified"] = "true"
 	}
 	if wp.FollowersCount > 0 {
-		p.Fields["followers"] = strconv.Itoa(wp.FollowersCount)
+		p.Fields[profile.FieldFollowers] = strconv.Itoa(wp.FollowersCount)
 	}
 
 	return p
@@ -228,7 +248,7 @@ func (c *Client) fetchXSRFToken(ctx context.Context, username string) error {
 	setCommonHeaders(req)
 	req.Header.Set("Cookie", fmt.Sprintf("SUB=%s; SUBP=%s", c.sub, c.subp))
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := cache.Client(ctx, c.httpClient).Do(req)
 	if err != nil {
 		return fmt.Errorf("fetching page: %w", err)
 	}
@@ -257,7 +277,7 @@ func (c *Client) resolveUsername(ctx context.Context, username string) (string,
 	setCommonHeaders(req)
 	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := cache.Client(ctx, c.httpClient).Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -299,7 +319,7 @@ func (c *Client) fetchProfileDetail(ctx context.Context, uid string) (*weiboProf
 	setCommonHeaders(req)
 	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := cache.Client(ctx, c.httpClient).Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -344,6 +364,7 @@ func (c *Client) fetchProfileDetail(ctx context.Context, uid string) (*weiboProf
 		VerifiedReason: result.Data.DescText,
 		Gender:         result.Data.Gender,
 		Hometown:       cleanHometown(result.Data.Hometown),
+		IPLocation:     result.Data.IPLocation,
 		CreatedAt:      result.Data.CreatedAt,
 	}
 
@@ -372,7 +393,7 @@ func (c *Client) enrichWithSideDetail(ctx context.Context, uid string, wp *weibo
 	setCommonHeaders(req)
 	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := cache.Client(ctx, c.httpClient).Do(req)
 	if err != nil {
 		return err
 	}
@@ -438,6 +459,7 @@ func setCommonHeaders(req *http.Request) {
 	req.Header.Set("Accept-Language", "en-US,en;q=0.5")
 	req.Header.Set("X-Requested-With", "XMLHttpRequest")
 	req.Header.Set("Referer", "https://weibo.com/")
+	cache.PrepareRequest(req.Context(), req)
 }
 
 func (c *Client) setAuthHeaders(req *http.Request) {
@@ -472,10 +494,12 @@ func cleanHometown(s string) string {
 	return strings.TrimSpace(s)
 }
 
+// usernamePattern matches the user ID or name in a weibo.com or weibo.cn URL.
+var usernamePattern = regexp.MustCompile(`https?://(?:www\.)?weibo\.(?:com|cn)/(?:u/)?([^/?#]+)`)
+
 // ExtractUsername extracts the username from a Weibo URL.
 func ExtractUsername(weiboURL string) string {
-	re := regexp.MustCompile(`https?://(?:www\.)?weibo\.(?:com|cn)/(?:u/)?([^/?#]+)`)
-	matches := re.FindStringSubmatch(weiboURL)
+	matches := usernamePattern.FindStringSubmatch(weiboURL)
 	if len(matches) > 1 {
 		return matches[1]
 	}
@@ -101,3 +101,32 @@ func TestCleanHometown(t *testing.T) {
 		})
 	}
 }
+
+func TestToProfile(t *testing.T) {
+	wp := &weiboProfile{
+		UID:            "1234567890",
+		ScreenName:     "张三_工程师",
+		Description:    "写代码的，喜欢猫",
+		Location:       "北京 海淀区",
+		Hometown:       cleanHometown("家乡：四川 成都"),
+		IPLocation:     "IP属地：广东",
+		Company:        "字节跳动",
+		FollowersCount: 1200,
+	}
+	p := (&Client{}).toProfile(wp, "https://weibo.com/u/1234567890")
+
+	if p.Name != "张三_工程师" || p.Bio != "写代码的，喜欢猫" || p.Location != "北京 海淀区" {
+		t.Errorf("Name, Bio, Location = %q, %q, %q; want the fixture's", p.Name, p.Bio, p.Location)
+	}
+	want := map[string]string{
+		"hometown":    "四川 成都",
+		"ip_location": "广东",
+		"employer":    "字节跳动",
+		"followers":   "1200",
+	}
+	for k, v := range want {
+		if p.Fields[k] != v {
+			t.Errorf("Fields[%q] = %q, want %q", k, p.Fields[k], v)
+		}
+	}
+}
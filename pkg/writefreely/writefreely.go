@@ -0,0 +1,259 @@
+// Package writefreely fetches WriteFreely blogs, including those hosted on write.as.
+package writefreely
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/cache"
+	"github.com/codeGROOVE-dev/sociopath/pkg/htmlutil"
+	"github.com/codeGROOVE-dev/sociopath/pkg/links"
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+const platform = "writefreely"
+
+// maxExcerpt is how many characters of a post's body are kept as its content.
+const maxExcerpt = 500
+
+// Known WriteFreely instances. Self-hosted ones are reached through ActivityPub.
+var knownInstances = map[string]bool{
+	"write.as": true, "qua.name": true, "wordsmith.social": true, "writing.exchange": true,
+}
+
+// reservedPaths are top-level pages on WriteFreely instances that are not blogs.
+var reservedPaths = map[string]bool{
+	"about": true, "api": true, "login": true, "logout": true, "signup": true, "me": true,
+	"new": true, "read": true, "pricing": true, "privacy": true, "contact": true, "admin": true,
+	"invite": true, "css": true, "js": true, "img": true, "favicon.ico": true, "robots.txt": true,
+}
+
+// Match returns true if the URL is a blog on a known WriteFreely instance.
+func Match(urlStr string) bool {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(strings.TrimPrefix(parsed.Hostname(), "www."))
+	return knownInstances[host] && extractAlias(parsed.Path) != ""
+}
+
+// AuthRequired returns false because WriteFreely blogs are public.
+func AuthRequired() bool { return false }
+
+// Client handles WriteFreely requests.
+type Client struct {
+	httpClient *http.Client
+	cache      cache.HTTPCache
+	logger     *slog.Logger
+	depth      profile.Depth
+}
+
+// Option configures a Client.
+type Option func(*config)
+
+type config struct {
+	cache  cache.HTTPCache
+	logger *slog.Logger
+	depth  profile.Depth
+}
+
+// WithHTTPCache sets the HTTP cache.
+func WithHTTPCache(httpCache cache.HTTPCache) Option {
+	return func(c *config) { c.cache = httpCache }
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithDepth sets how much secondary data to fetch.
+func WithDepth(depth profile.Depth) Option {
+	return func(c *config) { c.depth = depth }
+}
+
+// New creates a WriteFreely client.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	cfg := &config{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		cache:      cfg.cache,
+		logger:     cfg.logger,
+		depth:      cfg.depth,
+	}, nil
+}
+
+// Fetch retrieves a WriteFreely blog, its recent posts, and the instance's name.
+func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, error) {
+	parsed, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	alias := extractAlias(parsed.Path)
+	if alias == "" {
+		return nil, fmt.Errorf("could not extract blog alias from: %s", urlStr)
+	}
+	base := "https://" + parsed.Host
+	c.logger.InfoContext(ctx, "fetching writefreely blog", "url", urlStr, "alias", alias)
+
+	body, err := c.get(ctx, base+"/api/collections/"+url.PathEscape(alias))
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			Alias       string `json:"alias"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			Views       int    `json:"views"`
+			TotalPosts  int    `json:"total_posts"`
+		} `json:"data"`
+		Code int `json:"code"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing collection: %w", err)
+	}
+	if resp.Code != http.StatusOK || resp.Data.Alias == "" {
+		return nil, profile.ErrProfileNotFound
+	}
+
+	blog := resp.Data
+	p := &profile.Profile{
+		Platform: platform,
+		URL:      urlStr,
+		Username: blog.Alias,
+		Name:     strings.TrimSpace(blog.Title),
+		Bio:      strings.TrimSpace(blog.Description),
+		Fields:   map[string]string{"instance": parsed.Host},
+	}
+	if blog.TotalPosts > 0 {
+		p.Fields["posts"] = strconv.Itoa(blog.TotalPosts)
+	}
+	if blog.Views > 0 {
+		p.Fields["views"] = strconv.Itoa(blog.Views)
+	}
+	p.SocialLinks = links.Clean(htmlutil.SocialLinks(blog.Description), Match)
+
+	if c.depth == profile.DepthMinimal || !cache.HasBudget(ctx, cache.MinOptionalBudget) {
+		return p, nil
+	}
+	if limit := c.depth.PostLimit(); limit > 0 {
+		c.addPosts(ctx, p, base, blog.Alias, limit)
+	}
+	c.addInstance(ctx, p, base)
+	return p, nil
+}
+
+func (c *Client) get(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", cache.UserAgent)
+	return cache.FetchURL(ctx, c.cache, c.httpClient, req, c.logger)
+}
+
+// addPosts adds the blog's most recent posts to p.
+func (c *Client) addPosts(ctx context.Context, p *profile.Profile, base, alias string, limit int) {
+	body, err := c.get(ctx, base+"/api/collections/"+url.PathEscape(alias)+"/posts")
+	if err != nil {
+		c.logger.DebugContext(ctx, "writefreely posts fetch failed", "alias", alias, "error", err)
+		return
+	}
+	var resp struct {
+		Data struct {
+			Posts []struct {
+				Slug    string   `json:"slug"`
+				Title   string   `json:"title"`
+				Body    string   `json:"body"`
+				Created string   `json:"created"`
+				Tags    []string `json:"tags"`
+			} `json:"posts"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return
+	}
+	for _, post := range resp.Data.Posts {
+		if len(p.Posts) >= limit {
+			break
+		}
+		p.Posts = append(p.Posts, profile.Post{
+			Type:     profile.PostTypeArticle,
+			Title:    strings.TrimSpace(post.Title),
+			Content:  excerpt(post.Body),
+			URL:      base + "/" + alias + "/" + post.Slug,
+			Category: strings.Join(post.Tags, ", "),
+		})
+		p.UpdateLastActive(post.Created)
+	}
+}
+
+// addInstance records the instance's name and software from its NodeInfo document.
+func (c *Client) addInstance(ctx context.Context, p *profile.Profile, base string) {
+	body, err := c.get(ctx, base+"/api/nodeinfo")
+	if err != nil {
+		return
+	}
+	var info struct {
+		Software struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"software"`
+		Metadata struct {
+			NodeName        string `json:"nodeName"`
+			NodeDescription string `json:"nodeDescription"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return
+	}
+	if info.Metadata.NodeName != "" {
+		p.Fields["instance_name"] = info.Metadata.NodeName
+	}
+	if info.Metadata.NodeDescription != "" {
+		p.Fields["instance_description"] = info.Metadata.NodeDescription
+	}
+	if info.Software.Name != "" {
+		p.Fields["software"] = strings.TrimSpace(info.Software.Name + " " + info.Software.Version)
+	}
+}
+
+// extractAlias returns the blog alias a path starts with: "/matt/" and "/matt/post" both
+// name the blog "matt". Single-user instances serve their blog at the root and have
+// no alias in their URLs.
+func extractAlias(path string) string {
+	first, _, _ := strings.Cut(strings.Trim(path, "/"), "/")
+	first = strings.TrimPrefix(first, "@")
+	if first == "" || reservedPaths[strings.ToLower(first)] || strings.Contains(first, ".") {
+		return ""
+	}
+	return first
+}
+
+// excerpt returns the start of a Markdown post body, cut at a word boundary.
+func excerpt(body string) string {
+	body = strings.Join(strings.Fields(body), " ")
+	if utf8.RuneCountInString(body) <= maxExcerpt {
+		return body
+	}
+	cut := string([]rune(body)[:maxExcerpt])
+	if i := strings.LastIndex(cut, " "); i > maxExcerpt/2 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
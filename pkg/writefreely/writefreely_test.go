@@ -0,0 +1,113 @@
+package writefreely
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/sociopath/pkg/profile"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://write.as/matt", true},
+		{"https://write.as/matt/my-first-post", true},
+		{"https://qua.name/alice/", true},
+		{"https://write.as/about", false},
+		{"https://write.as/", false},
+		{"https://write.as/favicon.ico", false},
+		{"https://example.com/matt", false},
+	}
+	for _, tt := range tests {
+		if got := Match(tt.url); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	if AuthRequired() {
+		t.Error("WriteFreely should not require auth")
+	}
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v any
+		switch r.URL.Path {
+		case "/api/collections/matt":
+			v = map[string]any{"code": 200, "data": map[string]any{
+				"alias": "matt", "title": "Matt Baer", "description": "Building https://github.com/writefreely", "total_posts": 2,
+			}}
+		case "/api/collections/matt/posts":
+			v = map[string]any{"code": 200, "data": map[string]any{"posts": []map[string]any{
+				{"slug": "newest", "title": "Newest", "body": "Short\n\npost", "created": "2025-04-01T00:00:00Z", "tags": []string{"writing"}},
+				{"slug": "older", "title": "", "body": strings.Repeat("word ", 200), "created": "2024-01-01T00:00:00Z"},
+			}}}
+		case "/api/nodeinfo":
+			v = map[string]any{"software": map[string]string{"name": "writefreely", "version": "0.15.0"}, "metadata": map[string]string{"nodeName": "Write.as"}}
+		case "/api/collections/nobody":
+			w.WriteHeader(http.StatusNotFound)
+			v = map[string]any{"code": 404, "error_msg": "Collection not found."}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(v) //nolint:errcheck // test server
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.httpClient = &http.Client{Transport: &mockTransport{mockURL: server.URL}}
+
+	p, err := client.Fetch(ctx, "https://write.as/matt/")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if p.Platform != "writefreely" || p.Username != "matt" || p.Name != "Matt Baer" {
+		t.Errorf("profile = %+v", p)
+	}
+	if p.Fields["posts"] != "2" || p.Fields["instance_name"] != "Write.as" || p.Fields["software"] != "writefreely 0.15.0" {
+		t.Errorf("Fields = %v", p.Fields)
+	}
+	if len(p.SocialLinks) != 1 || p.SocialLinks[0] != "https://github.com/writefreely" {
+		t.Errorf("SocialLinks = %v", p.SocialLinks)
+	}
+	if len(p.Posts) != 2 {
+		t.Fatalf("got %d posts, want 2", len(p.Posts))
+	}
+	first := p.Posts[0]
+	if first.Type != profile.PostTypeArticle || first.URL != "https://write.as/matt/newest" || first.Content != "Short post" || first.Category != "writing" {
+		t.Errorf("Posts[0] = %+v", first)
+	}
+	if n := len([]rune(p.Posts[1].Content)); n > maxExcerpt+1 || !strings.HasSuffix(p.Posts[1].Content, "…") {
+		t.Errorf("long post excerpt has %d runes: %q", n, p.Posts[1].Content)
+	}
+	if p.LastActive != "2025-04-01T00:00:00Z" {
+		t.Errorf("LastActive = %q", p.LastActive)
+	}
+
+	if _, err := client.Fetch(ctx, "https://write.as/nobody"); err == nil {
+		t.Error("Fetch() of a missing blog succeeded")
+	}
+}
+
+type mockTransport struct {
+	mockURL string
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = "http"
+	req.URL.Host = t.mockURL[7:] // Strip "http://"
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -93,6 +93,21 @@ func (c *Client) Fetch(ctx context.Context, urlStr string) (*profile.Profile, er
 	return parseProfile(string(body), normalizedURL)
 }
 
+// Parse extracts a profile from a YouTube channel page's HTML without fetching anything.
+func Parse(_ context.Context, data []byte, urlStr string) (*profile.Profile, error) {
+	if !strings.HasPrefix(urlStr, "http") {
+		urlStr = "https://www.youtube.com/" + strings.TrimPrefix(urlStr, "youtube.com/")
+	}
+	return parseProfile(string(data), urlStr)
+}
+
+// Channel page patterns for parseProfile.
+var (
+	subPattern    = regexp.MustCompile(`([\d.]+[KMB]?)\s*(?:subscribers|Subscribers)`)
+	videoPattern  = regexp.MustCompile(`([\d,]+)\s*(?:videos|Videos)`)
+	joinedPattern = regexp.MustCompile(`"joinedDateText":\{[^}]*?"(?:content|simpleText)":"Joined ([^"]+)"`)
+)
+
 func parseProfile(html, url string) (*profile.Profile, error) {
 	prof := &profile.Profile{
 		Platform: platform,
@@ -117,15 +132,18 @@ func parseProfile(html, url string) (*profile.Profile, error) {
 	}
 
 	// Try to extract subscriber count
-	subPattern := regexp.MustCompile(`([\d.]+[KMB]?)\s*(?:subscribers|Subscribers)`)
 	if matches := subPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["subscribers"] = matches[1]
+		prof.Fields[profile.FieldSubscribers] = matches[1]
 	}
 
 	// Try to extract video count
-	videoPattern := regexp.MustCompile(`([\d,]+)\s*(?:videos|Videos)`)
 	if matches := videoPattern.FindStringSubmatch(html); len(matches) > 1 {
-		prof.Fields["videos"] = strings.ReplaceAll(matches[1], ",", "")
+		prof.Fields[profile.FieldVideos] = strings.ReplaceAll(matches[1], ",", "")
+	}
+
+	// Extract join date from the channel's about data, e.g. "Joined Mar 5, 2010"
+	if matches := joinedPattern.FindStringSubmatch(html); len(matches) > 1 {
+		prof.CreatedAt = matches[1]
 	}
 
 	// Extract video titles from accessibility labels
@@ -166,6 +184,12 @@ func isDefaultBio(bio string) bool {
 	return false
 }
 
+// Video titles from accessibility labels such as "Title 5 minutes", and the duration to strip.
+var (
+	accessPattern         = regexp.MustCompile(`"accessibilityData":\{"label":"([^"]+)\s+\d+\s*(?:minutes?|seconds?|hours?)`)
+	durationSuffixPattern = regexp.MustCompile(`\s*\d+\s*(?:minutes?|seconds?|hours?).*$`)
+)
+
 // extractVideoTitles extracts video titles from YouTube channel HTML.
 // YouTube embeds video titles in accessibility labels with duration info.
 func extractVideoTitles(html string, limit int) []profile.Post {
@@ -174,7 +198,6 @@ func extractVideoTitles(html string, limit int) []profile.Post {
 
 	// Extract from accessibility labels - these contain actual video titles with duration
 	// Pattern: "accessibilityData":{"label":"VIDEO TITLE DURATION"}
-	accessPattern := regexp.MustCompile(`"accessibilityData":\{"label":"([^"]+)\s+\d+\s*(?:minutes?|seconds?|hours?)`)
 	matches := accessPattern.FindAllStringSubmatch(html, -1)
 
 	for _, match := range matches {
@@ -184,7 +207,7 @@ func extractVideoTitles(html string, limit int) []profile.Post {
 
 		title := strings.TrimSpace(match[1])
 		// Clean up the title - remove trailing duration info
-		title = regexp.MustCompile(`\s*\d+\s*(?:minutes?|seconds?|hours?).*$`).ReplaceAllString(title, "")
+		title = durationSuffixPattern.ReplaceAllString(title, "")
 		title = strings.TrimSuffix(title, ",")
 		title = strings.TrimSpace(title)
 
@@ -201,20 +224,23 @@ func extractVideoTitles(html string, limit int) []profile.Post {
 	return posts
 }
 
+// usernamePatterns match the handle, custom name, legacy username, or channel ID in
+// the forms of YouTube channel URL.
+var usernamePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`youtube\.com/@([^/?#]+)`),
+	regexp.MustCompile(`youtube\.com/c/([^/?#]+)`),
+	regexp.MustCompile(`youtube\.com/user/([^/?#]+)`),
+	regexp.MustCompile(`youtube\.com/channel/([^/?#]+)`),
+}
+
 func extractUsername(s string) string {
 	s = strings.TrimPrefix(s, "https://")
 	s = strings.TrimPrefix(s, "http://")
 	s = strings.TrimPrefix(s, "www.")
 
 	// Try each YouTube URL pattern
-	patterns := []string{
-		`youtube\.com/@([^/?#]+)`,
-		`youtube\.com/c/([^/?#]+)`,
-		`youtube\.com/user/([^/?#]+)`,
-		`youtube\.com/channel/([^/?#]+)`,
-	}
-	for _, p := range patterns {
-		if m := regexp.MustCompile(p).FindStringSubmatch(s); len(m) > 1 {
+	for _, p := range usernamePatterns {
+		if m := p.FindStringSubmatch(s); len(m) > 1 {
 			return m[1]
 		}
 	}
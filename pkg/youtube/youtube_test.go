@@ -182,6 +182,7 @@ func TestParseProfile(t *testing.T) {
 		wantName        string
 		wantBio         string
 		wantSubscribers string
+		wantCreated     string
 	}{
 		{
 			name: "full profile",
@@ -191,11 +192,13 @@ func TestParseProfile(t *testing.T) {
 			</head><body>
 				<span>100K subscribers</span>
 				<span>200 videos</span>
+				<script>var ytInitialData = {"joinedDateText":{"content":"Joined Mar 5, 2010","styleRuns":[]}};</script>
 			</body></html>`,
 			url:             "https://youtube.com/@mychannel",
 			wantName:        "My Channel",
 			wantBio:         "Welcome to my channel",
 			wantSubscribers: "100K",
+			wantCreated:     "Mar 5, 2010",
 		},
 		{
 			name: "default bio filtered",
@@ -233,6 +236,9 @@ func TestParseProfile(t *testing.T) {
 			if tt.wantSubscribers != "" && profile.Fields["subscribers"] != tt.wantSubscribers {
 				t.Errorf("subscribers = %q, want %q", profile.Fields["subscribers"], tt.wantSubscribers)
 			}
+			if profile.CreatedAt != tt.wantCreated {
+				t.Errorf("CreatedAt = %q, want %q", profile.CreatedAt, tt.wantCreated)
+			}
 		})
 	}
 }